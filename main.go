@@ -6,8 +6,7 @@ import (
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/openapi"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
-	"github.com/hashicorp/terraform-plugin-sdk/plugin"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"os"
 	"regexp"
 )
@@ -27,17 +26,14 @@ func main() {
 	}
 
 	p := openapi.ProviderOpenAPI{ProviderName: providerName}
-	provider, err := p.CreateSchemaProvider()
+	serveOpts, err := p.CreateServeOpts()
 	if err != nil {
 		log.Fatalf("[ERROR] There was an error initialising the terraform provider: %s", err)
 	}
 
-	plugin.Serve(
-		&plugin.ServeOpts{
-			ProviderFunc: func() terraform.ResourceProvider {
-				return provider
-			},
-		})
+	plugin.Serve(serveOpts)
+
+	p.Shutdown()
 }
 
 func getProviderName(binaryName string) (string, error) {
@@ -4,13 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expectedHTTPStatusCodes []int) error {
@@ -28,6 +28,8 @@ func checkHTTPStatusCode(openAPIResource SpecResource, res *http.Response, expec
 			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Unauthorized: API access is denied due to invalid credentials (%s)", openAPIResource.getResourceName(), res.StatusCode, resBody)
 		case http.StatusNotFound:
 			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Not Found. Could not find resource instance: %s", res.StatusCode, resBody)}
+		case http.StatusGone:
+			return &openapierr.NotFoundError{OriginalError: fmt.Errorf("HTTP Response Status Code %d - Gone. The resource instance no longer exists: %s", res.StatusCode, resBody)}
 		default:
 			return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d not matching expected one %v (%s)", openAPIResource.getResourceName(), res.StatusCode, expectedHTTPStatusCodes, resBody)
 		}
@@ -87,20 +89,25 @@ func updateStateWithPayloadData(openAPIResource SpecResource, remoteData map[str
 		return err
 	}
 	for propertyName, propertyValue := range remoteData {
-		property, err := resourceSchema.getProperty(propertyName)
+		property, err := resourceSchema.getPropertyBasedOnResponseFieldName(propertyName)
 		if err != nil {
-			log.Printf("[WARN] The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err)
+			resourceSchema.getLogger().Warn(fmt.Sprintf("The API returned a property that is not specified in the resource's schema definition in the OpenAPI document - error = %s", err))
 			continue
 		}
 		if property.isPropertyNamedID() {
 			continue
 		}
+		// The API does not return the write-only values (or they are masked), the value configured is kept in the state
+		if property.WriteOnly {
+			continue
+		}
 		value, err := convertPayloadToLocalStateDataValue(property, propertyValue, false)
 		if err != nil {
 			return err
 		}
 		if value != nil {
-			if err := setResourceDataProperty(openAPIResource, propertyName, value, resourceLocalData); err != nil {
+			value = keepWriteOnlyValues(property, value, resourceLocalData.Get(property.getTerraformCompliantPropertyName()))
+			if err := setResourceDataProperty(openAPIResource, property.Name, value, resourceLocalData); err != nil {
 				return err
 			}
 		}
@@ -112,23 +119,32 @@ func convertPayloadToLocalStateDataValue(property *specSchemaDefinitionProperty,
 	if propertyValue == nil {
 		return nil, nil
 	}
+	if property.isMapOfObjectsProperty() {
+		return convertMapOfObjectsPayloadToLocalStateDataValue(property, propertyValue)
+	}
 	dataValueKind := reflect.TypeOf(propertyValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
 		objectInput := map[string]interface{}{}
 		mapValue := propertyValue.(map[string]interface{})
+		// The payload of a discriminated union is stored in the nested object of the variant returned by the API
+		if property.SpecSchemaDefinition.isDiscriminatedUnion() {
+			mapValue = property.SpecSchemaDefinition.fromUnionPayload(mapValue)
+		}
 		for propertyName, propertyValue := range mapValue {
-			schemaDefinitionProperty, err := property.SpecSchemaDefinition.getProperty(propertyName)
+			schemaDefinitionProperty, err := getObjectProperty(property, propertyName)
 			if err != nil {
 				return nil, err
 			}
 			var propValue interface{}
-			// Here we are processing the items of the list which are objects. In this case we need to keep the original
-			// types as Terraform honors property types for resource schemas attached to typeList properties
-			if property.isArrayOfObjectsProperty() {
-				propValue, err = convertPayloadToLocalStateDataValue(schemaDefinitionProperty, propertyValue, false)
-			} else { // Here we need to use strings as values as terraform typeMap only supports string items
+			// Here we are processing the properties of an object represented as a map of strings, in this case we need to
+			// use strings as values as terraform typeMap only supports string items. For the rest (items of lists of objects,
+			// values of maps and objects represented as blocks at any nesting level) the original types need to be kept as
+			// Terraform honors the property types defined in the nested resource schemas and map elem schemas
+			if property.isTerraformMapOfStringsObject() {
 				propValue, err = convertPayloadToLocalStateDataValue(schemaDefinitionProperty, propertyValue, true)
+			} else {
+				propValue, err = convertPayloadToLocalStateDataValue(schemaDefinitionProperty, propertyValue, false)
 			}
 			if err != nil {
 				return nil, err
@@ -201,6 +217,114 @@ func convertPayloadToLocalStateDataValue(property *specSchemaDefinitionProperty,
 	}
 }
 
+// getObjectProperty returns the object's property matching the given name. If the object is a map or an object that allows
+// additionalProperties and the name does not match any of the fixed properties, an additional property is returned instead.
+func getObjectProperty(objectProperty *specSchemaDefinitionProperty, propertyName string) (*specSchemaDefinitionProperty, error) {
+	if objectProperty.isMapProperty() {
+		return objectProperty.newAdditionalProperty(propertyName), nil
+	}
+	schemaDefinitionProperty, err := objectProperty.SpecSchemaDefinition.getPropertyBasedOnResponseFieldName(propertyName)
+	if err != nil {
+		if objectProperty.allowsAdditionalProperties() {
+			return objectProperty.newAdditionalProperty(propertyName), nil
+		}
+		return nil, err
+	}
+	return schemaDefinitionProperty, nil
+}
+
+// keepWriteOnlyValues returns the given state value where the values of the write-only properties of the nested objects
+// are replaced with the prior values stored in the state, since the API does not return them. The items of the lists of
+// objects are matched by position and the values of the maps of objects by key
+func keepWriteOnlyValues(property *specSchemaDefinitionProperty, value, priorValue interface{}) interface{} {
+	if property.WriteOnly {
+		return priorValue
+	}
+	if property.SpecSchemaDefinition == nil {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		priorObject, _ := priorValue.(map[string]interface{})
+		return property.SpecSchemaDefinition.keepWriteOnlyObjectValues(v, priorObject)
+	case []interface{}:
+		var priorItems []interface{}
+		switch p := priorValue.(type) {
+		case *schema.Set:
+			priorItems = p.List()
+		case []interface{}:
+			priorItems = p
+		}
+		for idx, item := range v {
+			itemValue, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var priorItem map[string]interface{}
+			for priorIdx, prior := range priorItems {
+				priorItemValue, _ := prior.(map[string]interface{})
+				if property.isMapOfObjectsProperty() && priorItemValue[mapOfObjectsKeyPropertyName] == itemValue[mapOfObjectsKeyPropertyName] ||
+					!property.isMapOfObjectsProperty() && priorIdx == idx {
+					priorItem = priorItemValue
+					break
+				}
+			}
+			v[idx] = property.SpecSchemaDefinition.keepWriteOnlyObjectValues(itemValue, priorItem)
+		}
+	}
+	return value
+}
+
+// keepWriteOnlyObjectValues returns the given object state value where the values of the write-only properties are
+// replaced with the prior values stored in the state, or removed if there are no prior values
+func (s *specSchemaDefinition) keepWriteOnlyObjectValues(value, priorValue map[string]interface{}) map[string]interface{} {
+	for _, property := range s.Properties {
+		name := property.getTerraformCompliantPropertyName()
+		if property.WriteOnly {
+			if priorPropertyValue, exists := priorValue[name]; exists {
+				value[name] = priorPropertyValue
+			} else {
+				delete(value, name)
+			}
+			continue
+		}
+		if propertyValue, exists := value[name]; exists {
+			value[name] = keepWriteOnlyValues(property, propertyValue, priorValue[name])
+		}
+	}
+	return value
+}
+
+// convertMapOfObjectsPayloadToLocalStateDataValue returns the state representation of the map of objects returned by the
+// API: a list of blocks (sorted by key) each containing the key of the map along with the properties of the object value
+func convertMapOfObjectsPayloadToLocalStateDataValue(property *specSchemaDefinitionProperty, propertyValue interface{}) (interface{}, error) {
+	mapValue, ok := propertyValue.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("property '%s' is supposed to be a map of objects", property.Name)
+	}
+	keys := make([]string, 0, len(mapValue))
+	for key := range mapValue {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	items := []interface{}{}
+	for _, key := range keys {
+		value, err := convertPayloadToLocalStateDataValue(property.newAdditionalProperty(key), mapValue[key], false)
+		if err != nil {
+			return nil, err
+		}
+		// The object values are represented as blocks, hence the value is a list with just one element
+		blocks, ok := value.([]interface{})
+		if !ok || len(blocks) != 1 {
+			return nil, fmt.Errorf("property '%s' value for key '%s' is supposed to be an object", property.Name, key)
+		}
+		item := blocks[0].(map[string]interface{})
+		item[mapOfObjectsKeyPropertyName] = key
+		items = append(items, item)
+	}
+	return items, nil
+}
+
 // setResourceDataProperty sets the expectedValue for the given schemaDefinitionPropertyName using the terraform compliant property name
 func setResourceDataProperty(openAPIResource SpecResource, schemaDefinitionPropertyName string, value interface{}, resourceLocalData *schema.ResourceData) error {
 	resourceSchema, _ := openAPIResource.getResourceSchema()
@@ -214,25 +338,35 @@ func setResourceDataProperty(openAPIResource SpecResource, schemaDefinitionPrope
 // setStateID sets the local resource's data ID with the newly identifier created in the POST API request. Refer to
 // r.resourceInfo.getResourceIdentifier() for more info regarding what property is selected as the identifier.
 func setStateID(openAPIres SpecResource, resourceLocalData *schema.ResourceData, payload map[string]interface{}) error {
-	resourceSchema, err := openAPIres.getResourceSchema()
+	id, err := getResourceIDFromPayload(openAPIres, payload)
 	if err != nil {
 		return err
 	}
+	resourceLocalData.SetId(id)
+	return nil
+}
+
+// getResourceIDFromPayload returns the value of the resource identifier property in the given payload as a string
+func getResourceIDFromPayload(openAPIres SpecResource, payload map[string]interface{}) (string, error) {
+	resourceSchema, err := openAPIres.getResourceSchema()
+	if err != nil {
+		return "", err
+	}
 	identifierProperty, err := resourceSchema.getResourceIdentifier()
 	if err != nil {
-		return err
+		return "", err
 	}
-	if payload[identifierProperty] == nil {
-		return fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
+	identifier, _ := getPayloadValue(payload, identifierProperty)
+	if identifier == nil {
+		return "", fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s'", identifierProperty)
 	}
 
-	switch payload[identifierProperty].(type) {
+	switch identifier.(type) {
 	case int:
-		resourceLocalData.SetId(strconv.Itoa(payload[identifierProperty].(int)))
+		return strconv.Itoa(identifier.(int)), nil
 	case float64:
-		resourceLocalData.SetId(strconv.Itoa(int(payload[identifierProperty].(float64))))
+		return strconv.Itoa(int(identifier.(float64))), nil
 	default:
-		resourceLocalData.SetId(payload[identifierProperty].(string))
+		return identifier.(string), nil
 	}
-	return nil
 }
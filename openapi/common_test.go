@@ -8,8 +8,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
 	"github.com/go-openapi/spec"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +49,15 @@ func TestCheckHTTPStatusCode(t *testing.T) {
 			expectedStatusCodes: []int{http.StatusOK},
 			expectedError:       errors.New("[resource='resourceName'] HTTP Response Status Code 401 - Unauthorized: API access is denied due to invalid credentials (unauthorized)"),
 		},
+		{
+			name: "response known with code 410 Gone",
+			response: &http.Response{
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+				StatusCode: http.StatusGone,
+			},
+			expectedStatusCodes: []int{http.StatusOK},
+			expectedError:       &openapierr.NotFoundError{OriginalError: errors.New("HTTP Response Status Code 410 - Gone. The resource instance no longer exists: ")},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -354,6 +364,40 @@ func TestUpdateStateWithPayloadData(t *testing.T) {
 	})
 }
 
+func TestUpdateStateWithPayloadDataWithResponseFieldNames(t *testing.T) {
+	Convey("Given a resource factory containing properties (top level and nested in objects) which values are returned in different response fields", t, func() {
+		nestedProperty := newStringSchemaDefinitionPropertyWithDefaults("secret", "", true, false, nil)
+		nestedProperty.ResponseFieldName = "secret_hash"
+		objectSchemaDefinition := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				nestedProperty,
+			},
+		}
+		objectProperty := newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", true, false, false, nil, objectSchemaDefinition)
+		passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", true, false, nil)
+		passwordProperty.ResponseFieldName = "password_hash"
+		r, resourceData := testCreateResourceFactory(t, passwordProperty, objectProperty)
+		Convey("When updateStateWithPayloadData is called with a payload containing the aliased response fields", func() {
+			remoteData := map[string]interface{}{
+				"password_hash": "someHashedPassword",
+				objectProperty.Name: map[string]interface{}{
+					"secret_hash": "someHashedSecret",
+				},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the top level property should be populated with the value from the aliased response field", func() {
+				So(resourceData.Get(passwordProperty.Name), ShouldEqual, "someHashedPassword")
+			})
+			Convey("And the nested property should be populated with the value from the aliased response field", func() {
+				So(resourceData.Get(objectProperty.Name).(map[string]interface{})[nestedProperty.Name], ShouldEqual, "someHashedSecret")
+			})
+		})
+	})
+}
+
 func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 
 	Convey("Given a resource factory", t, func() {
@@ -370,6 +414,55 @@ func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 			})
 		})
 
+		Convey("When convertPayloadToLocalStateDataValue is called with a map property with values of type string and a map value", func() {
+			property := newMapSchemaDefinitionPropertyWithDefaults("map_property", "", false, false, nil, typeString)
+			dataValue := map[string]interface{}{
+				"key1": "value1",
+				"key2": "value2",
+			}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the result value should be the same map", func() {
+				So(resultValue, ShouldResemble, dataValue)
+			})
+		})
+
+		Convey("When convertPayloadToLocalStateDataValue is called with a map property with values of type integer and a map value containing json numbers", func() {
+			property := newMapSchemaDefinitionPropertyWithDefaults("map_property", "", false, false, nil, typeInt)
+			dataValue := map[string]interface{}{
+				"key1": float64(1),
+			}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the result value should contain the values with the right type int", func() {
+				So(resultValue, ShouldResemble, map[string]interface{}{"key1": 1})
+			})
+		})
+
+		Convey("When convertPayloadToLocalStateDataValue is called with an object property that allows additional properties and a map value containing keys not in the fixed properties", func() {
+			property := newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", false, false, false, nil, &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("protocol", "", true, false, nil),
+				},
+			})
+			property.AdditionalPropertiesType = typeString
+			dataValue := map[string]interface{}{
+				"protocol":  "http",
+				"extra_key": "extra_value",
+			}
+			resultValue, err := convertPayloadToLocalStateDataValue(property, dataValue, false)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the result value should contain both the fixed and additional properties", func() {
+				So(resultValue, ShouldResemble, dataValue)
+			})
+		})
+
 		Convey("When convertPayloadToLocalStateDataValue is called with a bool property and a bool value", func() {
 			property := newBoolSchemaDefinitionPropertyWithDefaults("bool_property", "", false, false, nil)
 			dataValue := true
@@ -621,15 +714,15 @@ func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 			Convey("Then the error should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("Then the result value should be the list containing the object items all being string type (as terraform only supports maps of strings, hence values need to be stored as strings)", func() {
+			Convey("Then the result value should be the list containing the object items keeping their original types (as the object is represented as a block with typed properties)", func() {
 				So(resultValue.([]interface{})[0], ShouldContainKey, "example_int")
-				So(resultValue.([]interface{})[0].(map[string]interface{})["example_int"].(string), ShouldEqual, "80")
+				So(resultValue.([]interface{})[0].(map[string]interface{})["example_int"], ShouldEqual, 80)
 				So(resultValue.([]interface{})[0].(map[string]interface{}), ShouldContainKey, "example_string")
-				So(resultValue.([]interface{})[0].(map[string]interface{})["example_string"].(string), ShouldEqual, "http")
+				So(resultValue.([]interface{})[0].(map[string]interface{})["example_string"], ShouldEqual, "http")
 				So(resultValue.([]interface{})[0].(map[string]interface{}), ShouldContainKey, "example_bool")
-				So(resultValue.([]interface{})[0].(map[string]interface{})["example_bool"].(string), ShouldEqual, "true")
+				So(resultValue.([]interface{})[0].(map[string]interface{})["example_bool"], ShouldEqual, true)
 				So(resultValue.([]interface{})[0].(map[string]interface{}), ShouldContainKey, "example_float")
-				So(resultValue.([]interface{})[0].(map[string]interface{})["example_float"].(string), ShouldEqual, "10.45")
+				So(resultValue.([]interface{})[0].(map[string]interface{})["example_float"], ShouldEqual, 10.45)
 			})
 		})
 
@@ -688,6 +781,105 @@ func TestConvertPayloadToLocalStateDataValue(t *testing.T) {
 	})
 }
 
+func TestMapPropertyRoundTrip(t *testing.T) {
+	Convey("Given a resource factory configured with a map property with values of type string", t, func() {
+		mapProperty := newMapSchemaDefinitionPropertyWithDefaults("metadata", "", false, false, nil, typeString)
+		r, resourceData := testCreateResourceFactory(t, mapProperty)
+		Convey("When updateStateWithPayloadData is called with a remote payload containing a json object for the map property and then createPayloadFromLocalStateData is called", func() {
+			remoteData := map[string]interface{}{
+				mapProperty.Name: map[string]interface{}{
+					"key1": "value1",
+					"key2": "value2",
+				},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			payload := r.createPayloadFromLocalStateData(resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the state should contain the map values", func() {
+				So(resourceData.Get(mapProperty.Name), ShouldResemble, remoteData[mapProperty.Name])
+			})
+			Convey("And the payload built from the state should contain the same json object as the remote payload", func() {
+				So(payload, ShouldResemble, remoteData)
+			})
+		})
+	})
+}
+
+func TestMapOfObjectsPropertyRoundTrip(t *testing.T) {
+	Convey("Given a resource factory configured with a map property with values of type object", t, func() {
+		mapProperty := newMapSchemaDefinitionPropertyWithDefaults("origins", "", false, false, nil, typeObject)
+		mapProperty.SpecSchemaDefinition = &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("host", "", true, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+		}}
+		r, resourceData := testCreateResourceFactory(t, mapProperty)
+		Convey("When updateStateWithPayloadData is called with a remote payload containing a json object for the map property and then createPayloadFromLocalStateData is called", func() {
+			remoteData := map[string]interface{}{
+				mapProperty.Name: map[string]interface{}{
+					"primary":   map[string]interface{}{"host": "primary.example.com", "port": float64(443)},
+					"secondary": map[string]interface{}{"host": "secondary.example.com", "port": float64(80)},
+				},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			payload := r.createPayloadFromLocalStateData(resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the state should contain a block per map entry including the key", func() {
+				origins := resourceData.Get(mapProperty.Name).(*schema.Set).List()
+				So(origins, ShouldHaveLength, 2)
+				So(origins, ShouldContain, map[string]interface{}{"key": "primary", "host": "primary.example.com", "port": 443})
+				So(origins, ShouldContain, map[string]interface{}{"key": "secondary", "host": "secondary.example.com", "port": 80})
+			})
+			Convey("And the payload built from the state should contain the same json object as the remote payload", func() {
+				So(payload, ShouldResemble, map[string]interface{}{
+					mapProperty.Name: map[string]interface{}{
+						"primary":   map[string]interface{}{"host": "primary.example.com", "port": 443},
+						"secondary": map[string]interface{}{"host": "secondary.example.com", "port": 80},
+					},
+				})
+			})
+		})
+	})
+}
+
+func TestUpdateStateWithPayloadDataWriteOnlyProperties(t *testing.T) {
+	Convey("Given a resource factory configured with top level and nested write-only properties", t, func() {
+		password := newStringSchemaDefinitionPropertyWithDefaults("password", "", false, false, nil)
+		password.WriteOnly = true
+		token := newStringSchemaDefinitionPropertyWithDefaults("token", "", false, false, nil)
+		token.WriteOnly = true
+		credentials := newListSchemaDefinitionPropertyWithDefaults("credentials", "", false, false, false, nil, typeObject, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+			newStringSchemaDefinitionPropertyWithDefaults("user", "", false, false, nil),
+			token,
+		}})
+		r, resourceData := testCreateResourceFactory(t, newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil), password, credentials)
+		So(resourceData.Set("password", "secret"), ShouldBeNil)
+		So(resourceData.Set("credentials", []interface{}{map[string]interface{}{"user": "admin", "token": "some token"}}), ShouldBeNil)
+		Convey("When updateStateWithPayloadData is called with a remote payload that does not contain the write-only values or contains masked values", func() {
+			remoteData := map[string]interface{}{
+				"label":       "some label",
+				"password":    "*****",
+				"credentials": []interface{}{map[string]interface{}{"user": "root"}},
+			}
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the state should be updated with the remote values of the properties that are not write-only", func() {
+				So(resourceData.Get("label"), ShouldEqual, "some label")
+				So(resourceData.Get("credentials.0.user"), ShouldEqual, "root")
+			})
+			Convey("And the state should keep the configured values of the write-only properties", func() {
+				So(resourceData.Get("password"), ShouldEqual, "secret")
+				So(resourceData.Get("credentials.0.token"), ShouldEqual, "some token")
+			})
+		})
+	})
+}
+
 func TestSetResourceDataProperty(t *testing.T) {
 	Convey("Given a resource factory initialized with a spec resource with some schema definition", t, func() {
 		r, resourceData := testCreateResourceFactory(t, stringProperty, stringWithPreferredNameProperty)
@@ -772,6 +964,32 @@ func TestSetStateID(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory configured with a schema definition where the property tagged as id is nested in an object property", t, func() {
+		uidProperty := newStringSchemaDefinitionProperty("uid", "", false, true, false, false, false, false, true, false, nil)
+		metadataProperty := newObjectSchemaDefinitionPropertyWithDefaults("metadata", "", false, true, true, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{uidProperty}})
+		r, resourceData := testCreateResourceFactory(t, metadataProperty)
+		Convey("When setStateID is called with the resourceData and a responsePayload containing the nested property", func() {
+			responsePayload := map[string]interface{}{
+				metadataProperty.Name: map[string]interface{}{
+					uidProperty.Name: "uidValue",
+				},
+			}
+			err := setStateID(r.openAPIResource, resourceData, responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And resourceData ID should be the value of the nested property", func() {
+				So(resourceData.Id(), ShouldEqual, "uidValue")
+			})
+		})
+		Convey("When setStateID is called with the resourceData and a responsePayload missing the nested property", func() {
+			err := setStateID(r.openAPIResource, resourceData, map[string]interface{}{metadataProperty.Name: map[string]interface{}{}})
+			Convey("Then the error returned should mention the path to the nested identifier property", func() {
+				So(err.Error(), ShouldEqual, "response object returned from the API is missing mandatory identifier property 'metadata.uid'")
+			})
+		})
+	})
+
 	Convey("Given a resource factory configured with a schema definition that DOES not have an id property nor a property that should be used as the identifier", t, func() {
 		r, resourceData := testCreateResourceFactory(t)
 		Convey("When setStateID is called with the resourceData and responsePayload", func() {
@@ -788,3 +1006,86 @@ func TestSetStateID(t *testing.T) {
 		})
 	})
 }
+
+func TestConvertPayloadToLocalStateDataValueDiscriminatedUnion(t *testing.T) {
+	Convey("Given a discriminated union property", t, func() {
+		property := newObjectSchemaDefinitionPropertyWithDefaults("pet", "", false, false, false, nil, newUnionSchemaDefinitionStub())
+		Convey("When convertPayloadToLocalStateDataValue is called with the payload of one of the variants", func() {
+			resultValue, err := convertPayloadToLocalStateDataValue(property, map[string]interface{}{"kind": "dog", "bark": true}, false)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the result value should contain the variant block with the payload values and without the discriminator", func() {
+				So(resultValue, ShouldResemble, []interface{}{map[string]interface{}{"dog": []interface{}{map[string]interface{}{"bark": true}}}})
+			})
+		})
+	})
+}
+
+func TestUpdateStateWithPayloadDataDeeplyNestedObjects(t *testing.T) {
+	Convey("Given a resource factory with an object property containing primitives, a nested object, a list of objects and a list of primitives", t, func() {
+		nestedObject := newObjectSchemaDefinitionPropertyWithDefaults("origin", "", false, false, false, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+			newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil),
+		}})
+		listOfObjects := newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, typeObject, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+			newIntSchemaDefinitionPropertyWithDefaults("priority", "", false, false, nil),
+			newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("action", "", false, false, false, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("type", "", false, false, nil),
+				newListSchemaDefinitionPropertyWithDefaults("targets", "", false, false, false, nil, typeString, nil),
+			}}),
+		}})
+		objectProperty := newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{
+			newIntSchemaDefinitionPropertyWithDefaults("ttl", "", false, false, nil),
+			newNumberSchemaDefinitionPropertyWithDefaults("ratio", "", false, false, nil),
+			nestedObject,
+			listOfObjects,
+			newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, nil, typeString, nil),
+		}})
+		r, resourceData := testCreateResourceFactory(t, objectProperty)
+		remoteData := map[string]interface{}{
+			"settings": map[string]interface{}{
+				"ttl":    float64(60),
+				"ratio":  1.5,
+				"origin": map[string]interface{}{"port": float64(80)},
+				"rules": []interface{}{
+					map[string]interface{}{"priority": float64(1), "enabled": true, "action": map[string]interface{}{"type": "forward", "targets": []interface{}{"a", "b"}}},
+				},
+				"tags": []interface{}{"tag1"},
+			},
+		}
+		Convey("When updateStateWithPayloadData is called with the payload returned by the API", func() {
+			err := updateStateWithPayloadData(r.openAPIResource, remoteData, resourceData)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the state should keep the types of the nested properties at any nesting level", func() {
+				settings := resourceData.Get("settings").([]interface{})[0].(map[string]interface{})
+				So(settings["ttl"], ShouldEqual, 60)
+				So(settings["ratio"], ShouldEqual, 1.5)
+				So(settings["origin"], ShouldResemble, map[string]interface{}{"port": "80"})
+				So(settings["tags"], ShouldResemble, []interface{}{"tag1"})
+				rule := settings["rules"].([]interface{})[0].(map[string]interface{})
+				So(rule["priority"], ShouldEqual, 1)
+				So(rule["enabled"], ShouldEqual, true)
+				So(rule["action"], ShouldResemble, []interface{}{map[string]interface{}{"type": "forward", "targets": []interface{}{"a", "b"}}})
+			})
+			Convey("And the payload created from the state should match the payload returned by the API", func() {
+				payload := map[string]interface{}{}
+				err := r.populatePayload(payload, objectProperty, resourceData.Get("settings"))
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{
+					"settings": map[string]interface{}{
+						"ttl":    60,
+						"ratio":  1.5,
+						"origin": map[string]interface{}{"port": int64(80)},
+						"rules": []interface{}{
+							map[string]interface{}{"priority": 1, "enabled": true, "action": map[string]interface{}{"type": "forward", "targets": []interface{}{"a", "b"}}},
+						},
+						"tags": []interface{}{"tag1"},
+					},
+				})
+			})
+		})
+	})
+}
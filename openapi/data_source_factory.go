@@ -1,17 +1,20 @@
 package openapi
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/http"
+	"regexp"
 	"strconv"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const dataSourceFilterPropertyName = "filter"
 const dataSourceFilterSchemaNamePropertyName = "name"
 const dataSourceFilterSchemaValuesPropertyName = "values"
+const dataSourceFilterSchemaRegexPropertyName = "regex"
 
 type dataSourceFactory struct {
 	openAPIResource SpecResource
@@ -21,6 +24,16 @@ type filters []filter
 type filter struct {
 	name  string
 	value string
+	// regex contains the compiled value if the filter value is a regular expression, nil if the value must match exactly
+	regex *regexp.Regexp
+}
+
+// matches returns true if the given value matches the filter value
+func (f filter) matches(value string) bool {
+	if f.regex != nil {
+		return f.regex.MatchString(value)
+	}
+	return value == f.value
 }
 
 func newDataSourceFactory(openAPIResource SpecResource) dataSourceFactory {
@@ -35,8 +48,8 @@ func (d dataSourceFactory) createTerraformDataSource() (*schema.Resource, error)
 		return nil, err
 	}
 	return &schema.Resource{
-		Schema: s,
-		Read:   d.read,
+		Schema:      s,
+		ReadContext: withDiagnostics(d.read),
 	}, nil
 }
 
@@ -69,12 +82,18 @@ func (d dataSourceFactory) dataSourceFiltersSchema() *schema.Schema {
 					Required: true,
 					Elem:     &schema.Schema{Type: schema.TypeString},
 				},
+				dataSourceFilterSchemaRegexPropertyName: {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "If true, the filter value is a regular expression that the property value must match",
+				},
 			},
 		},
 	}
 }
 
-func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceFactory) read(ctx context.Context, data *schema.ResourceData, i interface{}) error {
 	openAPIClient := i.(ClientOpenAPI)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
@@ -87,8 +106,15 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
-	responsePayload := []map[string]interface{}{}
-	resp, err := openAPIClient.List(d.openAPIResource, &responsePayload, parentIDs...)
+	// the items are filtered as they are decoded so only the ones matching the filters are kept in memory
+	var filteredResults []map[string]interface{}
+	responsePayload := newListItemsStream(func(payloadItem map[string]interface{}) error {
+		if d.filterMatch(filters, payloadItem) {
+			filteredResults = append(filteredResults, payloadItem)
+		}
+		return nil
+	})
+	resp, err := openAPIClient.ListWithQueryParameters(d.openAPIResource, d.getFilterQueryParameters(filters), responsePayload, parentIDs...)
 	if err != nil {
 		return err
 	}
@@ -97,14 +123,6 @@ func (d dataSourceFactory) read(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[data source='%s'] GET %s failed: %s", d.openAPIResource.getResourceName(), resourcePath, err)
 	}
 
-	var filteredResults []map[string]interface{}
-	for _, payloadItem := range responsePayload {
-		match := d.filterMatch(filters, payloadItem)
-		if match {
-			filteredResults = append(filteredResults, payloadItem)
-		}
-	}
-
 	if len(filteredResults) == 0 {
 		return fmt.Errorf("your query returned no results. Please change your search criteria and try again")
 	}
@@ -142,7 +160,7 @@ func (d dataSourceFactory) filterMatch(filters filters, payloadItem map[string]i
 			default:
 				value = val.(string)
 			}
-			if value == filter.value {
+			if filter.matches(value) {
 				continue
 			}
 		}
@@ -172,7 +190,34 @@ func (d dataSourceFactory) validateInput(data *schema.ResourceData) (filters, er
 		if len(filterValue) > 1 {
 			return nil, fmt.Errorf("filters for primitive properties can not have more than one value in the values field")
 		}
-		filters = append(filters, filter{filterPropertyName, filterValue[0].(string)})
+		dataSourceFilter := filter{name: filterPropertyName, value: filterValue[0].(string)}
+		if isRegex, _ := f[dataSourceFilterSchemaRegexPropertyName].(bool); isRegex {
+			dataSourceFilter.regex, err = regexp.Compile(dataSourceFilter.value)
+			if err != nil {
+				return nil, fmt.Errorf("filter '%s' value '%s' is not a valid regular expression: %s", filterPropertyName, dataSourceFilter.value, err)
+			}
+		}
+		filters = append(filters, dataSourceFilter)
 	}
 	return filters, nil
 }
+
+// getFilterQueryParameters returns the query parameters that allow the API to filter the list server side: the values
+// of the filters (except for regular expressions) which properties are mapped to a query parameter of the List operation
+// via the 'x-terraform-filter-param' extension. The filters are still applied to the items returned by the API
+func (d dataSourceFactory) getFilterQueryParameters(filters filters) map[string][]string {
+	operation := d.openAPIResource.getResourceOperations().List
+	if operation == nil || len(operation.filterParameters) == 0 {
+		return nil
+	}
+	queryParameters := map[string][]string{}
+	for _, filter := range filters {
+		if filter.regex != nil {
+			continue
+		}
+		if queryParameterName, exists := operation.filterParameters[filter.name]; exists {
+			queryParameters[queryParameterName] = []string{filter.value}
+		}
+	}
+	return queryParameters
+}
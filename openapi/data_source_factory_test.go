@@ -1,12 +1,14 @@
 package openapi
 
 import (
+	"context"
 	"errors"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -46,10 +48,10 @@ func TestCreateTerraformDataSource(t *testing.T) {
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			assert.NotNil(t, dataSource, tc.name)
-			assert.NotNil(t, dataSource.Read, tc.name)
-			assert.Nil(t, dataSource.Delete, tc.name)
-			assert.Nil(t, dataSource.Create, tc.name)
-			assert.Nil(t, dataSource.Update, tc.name)
+			assert.NotNil(t, dataSource.ReadContext, tc.name)
+			assert.Nil(t, dataSource.DeleteContext, tc.name)
+			assert.Nil(t, dataSource.CreateContext, tc.name)
+			assert.Nil(t, dataSource.UpdateContext, tc.name)
 		} else {
 			assert.Equal(t, tc.expectedError.Error(), err.Error(), tc.name)
 		}
@@ -114,6 +116,9 @@ func TestCreateTerraformDataSourceSchema(t *testing.T) {
 			assert.Contains(t, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema, dataSourceFilterSchemaValuesPropertyName, tc.name)
 			assert.Equal(t, schema.TypeList, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema[dataSourceFilterSchemaValuesPropertyName].Type, tc.name)
 			assert.True(t, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema[dataSourceFilterSchemaValuesPropertyName].Required, tc.name)
+			assert.Contains(t, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema, dataSourceFilterSchemaRegexPropertyName, tc.name)
+			assert.Equal(t, schema.TypeBool, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema[dataSourceFilterSchemaRegexPropertyName].Type, tc.name)
+			assert.True(t, s[dataSourceFilterPropertyName].Elem.(*schema.Resource).Schema[dataSourceFilterSchemaRegexPropertyName].Optional, tc.name)
 
 			// resource specific properties as per swagger def (this properties are meant to be populated by the read operation when a match is found as per the filters)
 			assert.Nil(t, s["id"], tc.name) // we assert that s["id"] is Nil because during the creation of the schema id is treated in a special way and should not be populated at creation time (must be set in read() method)
@@ -217,12 +222,12 @@ func TestDataSourceRead(t *testing.T) {
 			responseListPayload: tc.responsePayload,
 		}
 		// When
-		err = dataSourceFactory.read(resourceData, client)
+		err = dataSourceFactory.read(context.Background(), resourceData, client)
 		// Then
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			// assert that the filtered data source contains the same values as the ones returned by the API
-			assert.Equal(t, 8, len(resourceData.State().Attributes), tc.name)                //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 4 prop given by the filter)
+			assert.Equal(t, 9, len(resourceData.State().Attributes), tc.name)                //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 5 prop given by the filter)
 			assert.Equal(t, client.responseListPayload[0]["id"], resourceData.Id(), tc.name) //resourceData.Id() is being called instead of resourceData.Get("id") because id property is a special one kept by Terraform
 			assert.Equal(t, client.responseListPayload[0]["label"], resourceData.Get("label"), tc.name)
 			expectedOwners := client.responseListPayload[0]["owners"].([]string)
@@ -273,7 +278,7 @@ func TestDataSourceRead_Subresource(t *testing.T) {
 			},
 		},
 	}
-	err = dataSourceFactory.read(resourceData, client)
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
 	require.NoError(t, err)
 	assert.Equal(t, []string{"parentPropertyID"}, client.parentIDsReceived) // check that the parent id is passed as expected
 	assert.Equal(t, "someID", resourceData.Id())
@@ -353,17 +358,17 @@ func TestDataSourceRead_ForNestedObjects(t *testing.T) {
 		},
 	}
 	// When
-	err = dataSourceFactory.read(resourceData, client)
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
 	// Then
 	assert.Nil(t, err)
 	// assert that the filtered data source contains the same values as the ones returned by the API
-	assert.Equal(t, 10, len(resourceData.State().Attributes))               //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 4 prop given by the filter)
+	assert.Equal(t, 11, len(resourceData.State().Attributes))               //this asserts that ONLY 1 element is returned when the filter is applied (2 prop of the elelemnt + 5 prop given by the filter)
 	assert.Equal(t, client.responseListPayload[0]["id"], resourceData.Id()) //resourceData.Id() is being called instead of resourceData.Get("id") because id property is a special one kept by Terraform
 	assert.Equal(t, client.responseListPayload[0]["label"], resourceData.Get("nested_object"))
 }
 
 func TestDataSourceRead_Fails_Because_Cannot_extract_ParentsID(t *testing.T) {
-	err := dataSourceFactory{}.read(nil, &clientOpenAPIStub{})
+	err := dataSourceFactory{}.read(context.Background(), nil, &clientOpenAPIStub{})
 	assert.EqualError(t, err, "can't get parent ids from a resourceFactory with no openAPIResource")
 }
 
@@ -394,7 +399,7 @@ func TestDataSourceRead_Fails_Because_List_Operation_Returns_Err(t *testing.T) {
 		},
 		error: errors.New("some error"),
 	}
-	err = dataSourceFactory.read(resourceData, client)
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
 	assert.EqualError(t, err, "some error")
 }
 
@@ -423,7 +428,7 @@ func TestDataSourceRead_Fails_Because_Bad_Status_Code(t *testing.T) {
 		returnHTTPCode: 400,
 	}
 	// When
-	err = dataSourceFactory.read(resourceData, client)
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
 	// Then
 	assert.Equal(t, errors.New("[data source='some resource'] GET  failed: [resource='some resource'] HTTP Response Status Code 400 not matching expected one [200] ()"), err)
 }
@@ -547,7 +552,7 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"label", "some label"},
+				filter{name: "label", value: "some label"},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
@@ -561,7 +566,7 @@ func TestFilterMatch(t *testing.T) {
 				newIntSchemaDefinitionPropertyWithDefaults("int property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"int property name", "5"},
+				filter{name: "int property name", value: "5"},
 			},
 			payloadItem: map[string]interface{}{
 				"int property name": 5,
@@ -575,7 +580,7 @@ func TestFilterMatch(t *testing.T) {
 				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"float property name", "6.0"},
+				filter{name: "float property name", value: "6.0"},
 			},
 			payloadItem: map[string]interface{}{
 				"float property name": 6.0, //because 6.0 is treateted as an interface golang keeps only the int part (6) so we need to treat thi case specially
@@ -589,7 +594,7 @@ func TestFilterMatch(t *testing.T) {
 				newNumberSchemaDefinitionPropertyWithDefaults("float property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"float property name", "6.89"},
+				filter{name: "float property name", value: "6.89"},
 			},
 			payloadItem: map[string]interface{}{
 				"float property name": 6.89,
@@ -603,7 +608,7 @@ func TestFilterMatch(t *testing.T) {
 				newBoolSchemaDefinitionPropertyWithDefaults("bool property name", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"bool property name", "false"},
+				filter{name: "bool property name", value: "false"},
 			},
 			payloadItem: map[string]interface{}{
 				"bool property name": false,
@@ -617,7 +622,7 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"invalid filter name", "some label"},
+				filter{name: "invalid filter name", value: "some label"},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
@@ -631,7 +636,7 @@ func TestFilterMatch(t *testing.T) {
 				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
 			},
 			filters: filters{
-				filter{"label", "invalid filter value"},
+				filter{name: "label", value: "invalid filter value"},
 			},
 			payloadItem: map[string]interface{}{
 				"label": "some label",
@@ -639,6 +644,51 @@ func TestFilterMatch(t *testing.T) {
 			expectedResult: false,
 			expectedError:  nil,
 		},
+		{
+			name: "happy path - payloadItem matches the regular expression filter",
+			specSchemaDefinitionProperties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", value: "^some.*", regex: regexp.MustCompile("^some.*")},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: true,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem doesn't match the regular expression filter",
+			specSchemaDefinitionProperties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", value: "^other.*", regex: regexp.MustCompile("^other.*")},
+			},
+			payloadItem: map[string]interface{}{
+				"label": "some label",
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
+		{
+			name: "crappy path - payloadItem matches only one of the filters",
+			specSchemaDefinitionProperties: specSchemaDefinitionProperties{
+				newStringSchemaDefinitionPropertyWithDefaults("label", "", false, true, nil),
+				newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, true, nil),
+			},
+			filters: filters{
+				filter{name: "label", value: "some label"},
+				filter{name: "enabled", value: "true"},
+			},
+			payloadItem: map[string]interface{}{
+				"label":   "some label",
+				"enabled": false,
+			},
+			expectedResult: false,
+			expectedError:  nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -666,6 +716,72 @@ func assertFilter(t *testing.T, filters filters, expectedFilter filter, msgAndAr
 	return false
 }
 
+func TestDataSourceRead_FilterQueryParameters(t *testing.T) {
+	// Given
+	dataSourceFactory := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			schemaDefinition: &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+					newStringSchemaDefinitionPropertyWithDefaults("region", "", false, false, nil),
+				},
+			},
+			resourceGetOperation: &specResourceOperation{},
+			resourceListOperation: &specResourceOperation{
+				filterParameters: map[string]string{"label": "label_eq", "region": "region"},
+			},
+		},
+	}
+	resourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	filterRegex := newFilter("region", []interface{}{"^us-.*"})
+	filterRegex[dataSourceFilterSchemaRegexPropertyName] = true
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{
+			newFilter("label", []interface{}{"my_label"}),
+			filterRegex,
+		},
+	})
+	client := &clientOpenAPIStub{
+		responseListPayload: []map[string]interface{}{
+			{"id": "someID", "label": "my_label", "region": "us-west-1"},
+			{"id": "someOtherID", "label": "my_label", "region": "eu-west-1"},
+		},
+	}
+	// When
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{"label_eq": {"my_label"}}, client.queryParametersReceived, "only the filters that are not regular expressions are sent as query parameters")
+	assert.Equal(t, "someID", resourceData.Id(), "the filters are applied to the items returned by the API")
+	assert.Equal(t, "us-west-1", resourceData.Get("region"))
+}
+
+func TestDataSourceValidateInput_InvalidRegex(t *testing.T) {
+	// Given
+	dataSourceFactory := dataSourceFactory{
+		openAPIResource: &specStubResource{
+			schemaDefinition: &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+				},
+			},
+		},
+	}
+	resourceSchema, err := dataSourceFactory.createTerraformDataSourceSchema()
+	require.NoError(t, err)
+	filterRegex := newFilter("label", []interface{}{"[invalid"})
+	filterRegex[dataSourceFilterSchemaRegexPropertyName] = true
+	resourceData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		dataSourceFilterPropertyName: []interface{}{filterRegex},
+	})
+	// When
+	_, err = dataSourceFactory.validateInput(resourceData)
+	// Then
+	assert.EqualError(t, err, "filter 'label' value '[invalid' is not a valid regular expression: error parsing regexp: missing closing ]: `[invalid`")
+}
+
 func newFilter(name string, values []interface{}) map[string]interface{} {
 	return map[string]interface{}{
 		dataSourceFilterSchemaNamePropertyName:   name,
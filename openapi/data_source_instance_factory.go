@@ -1,10 +1,11 @@
 package openapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const dataSourceInstanceIDProperty = "id"
@@ -29,8 +30,8 @@ func (d dataSourceInstanceFactory) createTerraformInstanceDataSource() (*schema.
 		return nil, err
 	}
 	return &schema.Resource{
-		Schema: s,
-		Read:   d.read,
+		Schema:      s,
+		ReadContext: withDiagnostics(d.read),
 	}, nil
 }
 
@@ -54,7 +55,7 @@ func (d dataSourceInstanceFactory) dataSourceInstanceSchema() *schema.Schema {
 	}
 }
 
-func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}) error {
+func (d dataSourceInstanceFactory) read(ctx context.Context, data *schema.ResourceData, i interface{}) error {
 	openAPIClient := i.(ClientOpenAPI)
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(d.openAPIResource, data)
 	if err != nil {
@@ -72,6 +73,9 @@ func (d dataSourceInstanceFactory) read(data *schema.ResourceData, i interface{}
 	if err := checkHTTPStatusCode(d.openAPIResource, resp, []int{http.StatusOK}); err != nil {
 		return fmt.Errorf("[data source instance='%s'] GET %s failed: %s", d.openAPIResource.getResourceName(), resourcePath, err)
 	}
+	if responsePayload, err = d.openAPIResource.getResourceOperations().Get.unwrapResponsePayload(responsePayload); err != nil {
+		return fmt.Errorf("[data source instance='%s'] GET %s failed: %s", d.openAPIResource.getResourceName(), resourcePath, err)
+	}
 	err = setStateID(d.openAPIResource, data, responsePayload)
 	if err != nil {
 		return err
@@ -1,8 +1,9 @@
 package openapi
 
 import (
+	"context"
 	"errors"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"net/http"
@@ -58,10 +59,10 @@ func TestCreateTerraformInstanceDataSource(t *testing.T) {
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
 			assert.NotNil(t, dataSource, tc.name)
-			assert.NotNil(t, dataSource.Read, tc.name)
-			assert.Nil(t, dataSource.Delete, tc.name)
-			assert.Nil(t, dataSource.Create, tc.name)
-			assert.Nil(t, dataSource.Update, tc.name)
+			assert.NotNil(t, dataSource.ReadContext, tc.name)
+			assert.Nil(t, dataSource.DeleteContext, tc.name)
+			assert.Nil(t, dataSource.CreateContext, tc.name)
+			assert.Nil(t, dataSource.UpdateContext, tc.name)
 		} else {
 			assert.Equal(t, tc.expectedError.Error(), err.Error(), tc.name)
 		}
@@ -198,7 +199,7 @@ func TestDataSourceInstanceRead(t *testing.T) {
 		}
 		resourceData := schema.TestResourceDataRaw(t, resourceSchema, dataSourceUserInput)
 		// When
-		err = dataSourceFactory.read(resourceData, tc.client)
+		err = dataSourceFactory.read(context.Background(), resourceData, tc.client)
 		// Then
 		if tc.expectedError == nil {
 			assert.Nil(t, err, tc.name)
@@ -233,7 +234,7 @@ func TestDataSourceInstanceRead_Fails_Because_Schema_is_not_valid(t *testing.T)
 }
 
 func TestDataSourceInstanceRead_Fails_Because_Cannot_extract_ParentsID(t *testing.T) {
-	err := dataSourceInstanceFactory{}.read(nil, &clientOpenAPIStub{})
+	err := dataSourceInstanceFactory{}.read(context.Background(), nil, &clientOpenAPIStub{})
 	assert.EqualError(t, err, "can't get parent ids from a resourceFactory with no openAPIResource")
 }
 
@@ -270,7 +271,7 @@ func TestDataSourceInstanceRead_Subresource(t *testing.T) {
 			"label": "my_label",
 		},
 	}
-	err = dataSourceFactory.read(resourceData, client)
+	err = dataSourceFactory.read(context.Background(), resourceData, client)
 	require.NoError(t, err)
 	assert.Equal(t, []string{"parentPropertyID"}, client.parentIDsReceived) // check that the parent id is passed as expected
 	assert.Equal(t, "someID", resourceData.Id())
@@ -0,0 +1,260 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkDeprecationMessage is the deprecation message used for the provider attributes and blocks deprecated in the
+// SDK provider schema, since the SDK provider schema only tells whether they are deprecated or not
+const frameworkDeprecationMessage = "This attribute is deprecated"
+
+// frameworkProvider is the terraform-plugin-framework provider muxed with the SDK provider when the provider is served
+// with the plugin protocol version 6. The framework provider serves the resources marked with the
+// 'x-terraform-resource-protocol-v6' extension while the rest of resources and data sources are served by the SDK provider.
+// Since both providers share the same provider configuration, the framework provider schema is built from the SDK
+// provider schema and the API client configured by the SDK provider is handed over to the framework resources.
+type frameworkProvider struct {
+	name string
+	// sdkProvider is the SDK provider muxed with the framework provider. The mux server configures the SDK provider before
+	// the framework provider so the API client is already available when the framework provider is configured
+	sdkProvider *schema.Provider
+	schema      providerschema.Schema
+	resources   []func() resource.Resource
+}
+
+var _ provider.Provider = &frameworkProvider{}
+
+// newFrameworkProvider returns a framework provider with the same schema as the given SDK provider schema (as returned by
+// the SDK provider server upgraded to the plugin protocol version 6) serving the resources provided
+func newFrameworkProvider(name string, sdkProvider *schema.Provider, sdkProviderSchema *tfprotov6.Schema, resources []*frameworkResource) (*frameworkProvider, error) {
+	providerSchema, err := newFrameworkProviderSchema(sdkProviderSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the plugin framework provider schema: %s", err)
+	}
+	p := &frameworkProvider{
+		name:        name,
+		sdkProvider: sdkProvider,
+		schema:      providerSchema,
+	}
+	for _, r := range resources {
+		frameworkResource := r
+		p.resources = append(p.resources, func() resource.Resource {
+			return frameworkResource
+		})
+	}
+	return p, nil
+}
+
+// Metadata returns the provider type name which is used as the prefix of the resource names
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = p.name
+}
+
+// Schema returns the provider schema, which is the same as the SDK provider schema
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = p.schema
+}
+
+// Configure hands over the API client configured by the SDK provider to the framework resources
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	providerClient := p.sdkProvider.Meta()
+	if providerClient == nil {
+		resp.Diagnostics.AddError("Provider not configured", fmt.Sprintf("[provider='%s'] the API client was not configured by the SDK provider", p.name))
+		return
+	}
+	resp.ResourceData = providerClient
+}
+
+// DataSources returns no data sources since all the data sources are served by the SDK provider
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// Resources returns the resources served by the framework provider
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return p.resources
+}
+
+// newFrameworkProviderSchema translates the given SDK provider schema into the equivalent framework provider schema. The
+// mux server requires the provider schemas of the muxed providers to be identical, hence the translation keeps the
+// attributes types, flags and descriptions as they are
+func newFrameworkProviderSchema(sdkProviderSchema *tfprotov6.Schema) (providerschema.Schema, error) {
+	if sdkProviderSchema == nil || sdkProviderSchema.Block == nil {
+		return providerschema.Schema{}, nil
+	}
+	attributes, blocks, err := newFrameworkProviderSchemaBlock(sdkProviderSchema.Block)
+	if err != nil {
+		return providerschema.Schema{}, err
+	}
+	description, markdownDescription := getFrameworkDescriptions(sdkProviderSchema.Block.Description, sdkProviderSchema.Block.DescriptionKind)
+	return providerschema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         description,
+		MarkdownDescription: markdownDescription,
+		DeprecationMessage:  getFrameworkDeprecationMessage(sdkProviderSchema.Block.Deprecated),
+	}, nil
+}
+
+func newFrameworkProviderSchemaBlock(block *tfprotov6.SchemaBlock) (map[string]providerschema.Attribute, map[string]providerschema.Block, error) {
+	var attributes map[string]providerschema.Attribute
+	var blocks map[string]providerschema.Block
+	for _, attribute := range block.Attributes {
+		frameworkAttribute, err := newFrameworkProviderAttribute(attribute)
+		if err != nil {
+			return nil, nil, fmt.Errorf("attribute '%s' %s", attribute.Name, err)
+		}
+		if attributes == nil {
+			attributes = map[string]providerschema.Attribute{}
+		}
+		attributes[attribute.Name] = frameworkAttribute
+	}
+	for _, nestedBlock := range block.BlockTypes {
+		frameworkBlock, err := newFrameworkProviderBlock(nestedBlock)
+		if err != nil {
+			return nil, nil, fmt.Errorf("block '%s' %s", nestedBlock.TypeName, err)
+		}
+		if blocks == nil {
+			blocks = map[string]providerschema.Block{}
+		}
+		blocks[nestedBlock.TypeName] = frameworkBlock
+	}
+	return attributes, blocks, nil
+}
+
+func newFrameworkProviderBlock(nestedBlock *tfprotov6.SchemaNestedBlock) (providerschema.Block, error) {
+	attributes, blocks, err := newFrameworkProviderSchemaBlock(nestedBlock.Block)
+	if err != nil {
+		return nil, err
+	}
+	description, markdownDescription := getFrameworkDescriptions(nestedBlock.Block.Description, nestedBlock.Block.DescriptionKind)
+	deprecationMessage := getFrameworkDeprecationMessage(nestedBlock.Block.Deprecated)
+	switch nestedBlock.Nesting {
+	case tfprotov6.SchemaNestedBlockNestingModeList:
+		return providerschema.ListNestedBlock{
+			NestedObject:        providerschema.NestedBlockObject{Attributes: attributes, Blocks: blocks},
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case tfprotov6.SchemaNestedBlockNestingModeSet:
+		return providerschema.SetNestedBlock{
+			NestedObject:        providerschema.NestedBlockObject{Attributes: attributes, Blocks: blocks},
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	case tfprotov6.SchemaNestedBlockNestingModeSingle:
+		return providerschema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+	return nil, fmt.Errorf("nesting mode '%s' not supported", nestedBlock.Nesting)
+}
+
+func newFrameworkProviderAttribute(attribute *tfprotov6.SchemaAttribute) (providerschema.Attribute, error) {
+	if attribute.NestedType != nil {
+		return nil, fmt.Errorf("nested attributes are not supported")
+	}
+	attributeType, err := getFrameworkAttrType(attribute.Type)
+	if err != nil {
+		return nil, err
+	}
+	description, markdownDescription := getFrameworkDescriptions(attribute.Description, attribute.DescriptionKind)
+	deprecationMessage := getFrameworkDeprecationMessage(attribute.Deprecated)
+	switch t := attributeType.(type) {
+	case types.ListType:
+		return providerschema.ListAttribute{ElementType: t.ElemType, Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	case types.SetType:
+		return providerschema.SetAttribute{ElementType: t.ElemType, Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	case types.MapType:
+		return providerschema.MapAttribute{ElementType: t.ElemType, Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	case types.ObjectType:
+		return providerschema.ObjectAttribute{AttributeTypes: t.AttrTypes, Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	}
+	switch attributeType {
+	case types.StringType:
+		return providerschema.StringAttribute{Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	case types.NumberType:
+		return providerschema.NumberAttribute{Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	case types.BoolType:
+		return providerschema.BoolAttribute{Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+	}
+	return providerschema.DynamicAttribute{Required: attribute.Required, Optional: attribute.Optional, Sensitive: attribute.Sensitive, Description: description, MarkdownDescription: markdownDescription, DeprecationMessage: deprecationMessage}, nil
+}
+
+// getFrameworkAttrType returns the framework type equivalent to the given terraform type
+func getFrameworkAttrType(terraformType tftypes.Type) (attr.Type, error) {
+	switch t := terraformType.(type) {
+	case tftypes.List:
+		elemType, err := getFrameworkAttrType(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elemType}, nil
+	case tftypes.Set:
+		elemType, err := getFrameworkAttrType(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elemType}, nil
+	case tftypes.Map:
+		elemType, err := getFrameworkAttrType(t.ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elemType}, nil
+	case tftypes.Object:
+		attrTypes := map[string]attr.Type{}
+		for name, attributeType := range t.AttributeTypes {
+			attrType, err := getFrameworkAttrType(attributeType)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = attrType
+		}
+		return types.ObjectType{AttrTypes: attrTypes}, nil
+	}
+	switch {
+	case terraformType.Is(tftypes.String):
+		return types.StringType, nil
+	case terraformType.Is(tftypes.Number):
+		return types.NumberType, nil
+	case terraformType.Is(tftypes.Bool):
+		return types.BoolType, nil
+	case terraformType.Is(tftypes.DynamicPseudoType):
+		return types.DynamicType, nil
+	}
+	return nil, fmt.Errorf("type '%s' not supported", terraformType)
+}
+
+// getFrameworkDescriptions returns the plain and markdown descriptions, only one of them is populated as per the given
+// description kind
+func getFrameworkDescriptions(description string, kind tfprotov6.StringKind) (string, string) {
+	if kind == tfprotov6.StringKindMarkdown {
+		return "", description
+	}
+	return description, ""
+}
+
+func getFrameworkDeprecationMessage(deprecated bool) string {
+	if deprecated {
+		return frameworkDeprecationMessage
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"testing"
+
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewFrameworkProviderSchema(t *testing.T) {
+	Convey("Given a SDK provider schema containing attributes and blocks", t, func() {
+		sdkProviderSchema := &tfprotov6.Schema{
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{Name: "apikey_auth", Type: tftypes.String, Required: true, Sensitive: true, Description: "API key", DescriptionKind: tfprotov6.StringKindPlain},
+					{Name: "region", Type: tftypes.String, Optional: true, Deprecated: true},
+					{Name: "headers", Type: tftypes.Map{ElementType: tftypes.String}, Optional: true},
+				},
+				BlockTypes: []*tfprotov6.SchemaNestedBlock{
+					{
+						TypeName: "endpoints",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{Name: "cdn_v1", Type: tftypes.String, Optional: true, Description: "Endpoint override", DescriptionKind: tfprotov6.StringKindMarkdown},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When newFrameworkProviderSchema is called", func() {
+			s, err := newFrameworkProviderSchema(sdkProviderSchema)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the attributes should keep their types, flags and descriptions", func() {
+				So(s.Attributes["apikey_auth"], ShouldResemble, providerschema.StringAttribute{Required: true, Sensitive: true, Description: "API key"})
+				So(s.Attributes["region"], ShouldResemble, providerschema.StringAttribute{Optional: true, DeprecationMessage: frameworkDeprecationMessage})
+				So(s.Attributes["headers"].(providerschema.MapAttribute).ElementType.Equal(types.StringType), ShouldBeTrue)
+			})
+			Convey("And the blocks should keep their nesting mode and attributes", func() {
+				endpoints, ok := s.Blocks["endpoints"].(providerschema.SetNestedBlock)
+				So(ok, ShouldBeTrue)
+				So(endpoints.NestedObject.Attributes["cdn_v1"], ShouldResemble, providerschema.StringAttribute{Optional: true, MarkdownDescription: "Endpoint override"})
+			})
+		})
+	})
+
+	Convey("Given a SDK provider schema containing a nested attribute", t, func() {
+		sdkProviderSchema := &tfprotov6.Schema{
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{Name: "settings", NestedType: &tfprotov6.SchemaObject{Nesting: tfprotov6.SchemaObjectNestingModeSingle}, Optional: true},
+				},
+			},
+		}
+		Convey("When newFrameworkProviderSchema is called", func() {
+			_, err := newFrameworkProviderSchema(sdkProviderSchema)
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "attribute 'settings' nested attributes are not supported")
+			})
+		})
+	})
+}
@@ -0,0 +1,463 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// frameworkResource is the terraform-plugin-framework implementation of an OpenAPI resource. The resource schema is
+// built from the resource schema definition representing the objects and arrays of objects as nested attributes, and
+// the CRUD operations are performed with the API client configured by the SDK provider. The resource factory is
+// embedded so the operations share the behaviour with the SDK resources (e,g: reading the remote resource)
+type frameworkResource struct {
+	resourceFactory
+	name           string
+	schema         resourceschema.Schema
+	providerClient ClientOpenAPI
+}
+
+var _ resource.Resource = &frameworkResource{}
+var _ resource.ResourceWithConfigure = &frameworkResource{}
+var _ resource.ResourceWithImportState = &frameworkResource{}
+
+// newFrameworkResource returns a framework resource with the given name (e,g: openapi_cdn_v1) for the OpenAPI resource
+// provided. An error is returned if the resource schema definition can not be represented with the framework schema
+func newFrameworkResource(name string, r resourceFactory) (*frameworkResource, error) {
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := resourceSchema.createFrameworkResourceAttributes(true)
+	if err != nil {
+		return nil, err
+	}
+	attributes[idDefaultPropertyName] = resourceschema.StringAttribute{
+		Computed:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	return &frameworkResource{
+		resourceFactory: r,
+		name:            name,
+		schema:          resourceschema.Schema{Attributes: attributes},
+	}, nil
+}
+
+// getFrameworkUnsupportedFeatures returns the features configured for the resource that are only implemented by the SDK
+// resources (e,g: polling or asynchronous operations), empty if the resource can be served by the framework resource
+func (r resourceFactory) getFrameworkUnsupportedFeatures() ([]string, error) {
+	var features []string
+	timeouts, err := r.openAPIResource.getTimeouts()
+	if err != nil {
+		return nil, err
+	}
+	if timeouts != nil && (timeouts.Post != nil || timeouts.Get != nil || timeouts.Put != nil || timeouts.Delete != nil) {
+		features = append(features, extTfResourceTimeout)
+	}
+	operations := r.openAPIResource.getResourceOperations()
+	var isPollingEnabled, isAsyncOperation, sendsResponseHeaders bool
+	for _, operation := range []*specResourceOperation{operations.Post, operations.Get, operations.Put, operations.Patch, operations.Delete} {
+		if operation == nil {
+			continue
+		}
+		for _, response := range operation.responses {
+			isPollingEnabled = isPollingEnabled || response.isPollingEnabled
+			isAsyncOperation = isAsyncOperation || response.asyncOperation != nil
+		}
+		sendsResponseHeaders = sendsResponseHeaders || len(operation.responseHeaderProperties) > 0
+	}
+	if isPollingEnabled {
+		features = append(features, extTfResourcePollEnabled)
+	}
+	if isAsyncOperation {
+		features = append(features, extTfAsyncOperation)
+	}
+	if sendsResponseHeaders {
+		features = append(features, extTfResponseHeaderProperty)
+	}
+	if operations.Put.usesETagLocking() || operations.Patch.usesETagLocking() || operations.Delete.usesETagLocking() {
+		features = append(features, extTfOptimisticLocking)
+	}
+	if operations.Get.isConditionalRead() {
+		features = append(features, extTfConditionalRead)
+	}
+	if operations.Post != nil && operations.Post.readAfterCreateRetries > 0 {
+		features = append(features, extTfReadAfterCreateRetries)
+	}
+	if operations.Delete != nil {
+		if operations.Delete.deletePoll != nil {
+			features = append(features, extTfResourceDeletePoll)
+		}
+		if len(operations.Delete.deleteBodyProperties) > 0 || operations.Delete.deleteBodyTemplate != nil {
+			features = append(features, extTfDeleteBody)
+		}
+		if operations.Delete.preDeleteOperation != nil {
+			features = append(features, extTfPreDeleteOperation)
+		}
+	}
+	if len(operations.Actions) > 0 {
+		features = append(features, extTfResourceAction)
+	}
+	if operations.Get.returnsBinary() {
+		features = append(features, fmt.Sprintf("%s responses", mediaTypeOctetStream))
+	}
+	if len(getResourceHeaderParameters(r.openAPIResource)) > 0 {
+		features = append(features, "resource headers")
+	}
+	if len(r.regions) > 0 {
+		features = append(features, providerPropertyRegion)
+	}
+	stateMigrations, err := r.openAPIResource.getStateMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(stateMigrations) > 0 {
+		features = append(features, extTfStateMigration)
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	requiredIfRules, err := createRequiredIfRules(resourceSchema)
+	if err != nil {
+		return nil, err
+	}
+	if len(requiredIfRules) > 0 {
+		features = append(features, extTfRequiredIf)
+	}
+	return features, nil
+}
+
+// Metadata returns the resource type name
+func (r *frameworkResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.name
+}
+
+// Schema returns the resource schema
+func (r *frameworkResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = r.schema
+}
+
+// Configure stores the API client handed over by the framework provider. The provider data is nil when the provider is
+// not configured yet (e,g: when validating the configuration)
+func (r *frameworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerClient, ok := req.ProviderData.(ClientOpenAPI)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("[resource='%s'] expected the provider data to be an OpenAPI client but got %T", r.name, req.ProviderData))
+		return
+	}
+	r.providerClient = providerClient
+}
+
+// Create performs the POST request with the planned values and stores the resource returned by the API in the state
+func (r *frameworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.submitTelemetryMetrics(TelemetryResourceOperationCreate, time.Now(), &resp.Diagnostics)
+
+	if r.openAPIResource.isReadOnlyResource() {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] resource is read only and can not be created, existing instances can be imported with 'terraform import' or read using the resource data source instance", r.openAPIResource.getResourceName()))
+		return
+	}
+	resourcePath, err := r.openAPIResource.getResourcePath(nil)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	requestPayload, err := resourceSchema.createFrameworkPayload(req.Plan.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	r.getLogger().Debug(fmt.Sprintf("[resource='%s'] POST payload: %s", r.openAPIResource.getResourceName(), sPrettyPrint(r.redactSensitiveValues(requestPayload))), "resource", r.openAPIResource.getResourceName())
+
+	operation := r.openAPIResource.getResourceOperations().Post
+	responsePayload := map[string]interface{}{}
+	res, err := r.providerClient.Post(r.openAPIResource, operation.wrapRequestPayload(requestPayload), &responsePayload)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, err))
+		return
+	}
+	if responsePayload, err = operation.unwrapResponsePayload(responsePayload); err != nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, err))
+		return
+	}
+	id, err := getResourceIDFromPayload(r.openAPIResource, responsePayload)
+	if err != nil {
+		// The POST response payload does not contain the resource (e,g: APIs returning 201 with an empty body), so the id
+		// is extracted from the location header and the state is populated from the resource returned by the API
+		location := res.Header.Get(operation.getLocationHeader())
+		if location == "" {
+			r.addError(&resp.Diagnostics, err)
+			return
+		}
+		if id, err = getIDFromLocation(location); err != nil {
+			r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] the id could not be extracted from the '%s' header: %s", r.openAPIResource.getResourceName(), operation.getLocationHeader(), err))
+			return
+		}
+		if responsePayload, err = r.readRemote(id, r.providerClient); err != nil {
+			r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] GET %s/%s after POST failed: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+			return
+		}
+	}
+	r.getLogger().Info(fmt.Sprintf("Resource '%s' ID: %s", resourcePath, id), "resource", r.openAPIResource.getResourceName(), "id", id)
+
+	state, err := r.getStateValue(ctx, id, responsePayload, req.Plan.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	resp.State.Raw = state
+}
+
+// Read performs the GET request and refreshes the state with the resource returned by the API. If the resource no longer
+// exists, it is removed from the state so Terraform plans to create it again
+func (r *frameworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.submitTelemetryMetrics(TelemetryResourceOperationRead, time.Now(), &resp.Diagnostics)
+
+	resourcePath, err := r.openAPIResource.getResourcePath(nil)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	id, err := r.getID(req.State.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	remoteData, err := r.readRemote(id, r.providerClient)
+	if err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() && r.openAPIResource.shouldRemoveOnNotFound() {
+			r.getLogger().Warn(fmt.Sprintf("[resource='%s'] %s/%s no longer exists, removing it from the state", r.openAPIResource.getResourceName(), resourcePath, id), "resource", r.openAPIResource.getResourceName(), "id", id)
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+		return
+	}
+	state, err := r.getStateValue(ctx, id, remoteData, req.State.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	resp.State.Raw = state
+}
+
+// Update performs the PUT request with the planned values (or the PATCH request with the changes between the prior state
+// and the planned values) and stores the resource returned by the API in the state
+func (r *frameworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.submitTelemetryMetrics(TelemetryResourceOperationUpdate, time.Now(), &resp.Diagnostics)
+
+	resourcePath, err := r.openAPIResource.getResourcePath(nil)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	operation, method := r.openAPIResource.getResourceOperations().getUpdateOperation()
+	if operation == nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.openAPIResource.getResourceName(), resourcePath))
+		return
+	}
+	id, err := r.getID(req.State.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	requestPayload, err := resourceSchema.createFrameworkPayload(req.Plan.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+
+	var res *http.Response
+	responsePayload := map[string]interface{}{}
+	expectedStatusCodes := []int{http.StatusOK, http.StatusAccepted}
+	if method == httpPatch {
+		priorPayload, err := resourceSchema.createFrameworkPayload(req.State.Raw)
+		if err != nil {
+			r.addError(&resp.Diagnostics, err)
+			return
+		}
+		patchPayload := r.createPatchPayload(operation.getUpdateStrategy(), priorPayload, requestPayload)
+		res, err = r.providerClient.Patch(r.openAPIResource, id, operation.wrapRequestPayload(patchPayload), &responsePayload)
+		expectedStatusCodes = append(expectedStatusCodes, http.StatusNoContent)
+	} else {
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] PUT payload: %s", r.openAPIResource.getResourceName(), sPrettyPrint(r.redactSensitiveValues(requestPayload))), "resource", r.openAPIResource.getResourceName())
+		res, err = r.providerClient.Put(r.openAPIResource, id, operation.wrapRequestPayload(requestPayload), &responsePayload)
+	}
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, expectedStatusCodes); err != nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+		return
+	}
+	if responsePayload, err = operation.unwrapResponsePayload(responsePayload); err != nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+		return
+	}
+	// PATCH operations might not return the resource (e,g: 204 No Content), in which case the resource is read so the
+	// state reflects the remote values
+	if method == httpPatch && len(responsePayload) == 0 {
+		if responsePayload, err = r.readRemote(id, r.providerClient); err != nil {
+			r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] GET %s/%s failed after PATCH: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+			return
+		}
+	}
+	state, err := r.getStateValue(ctx, id, responsePayload, req.Plan.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	resp.State.Raw = state
+}
+
+// Delete performs the DELETE request. Resources that no longer exist are considered deleted
+func (r *frameworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.submitTelemetryMetrics(TelemetryResourceOperationDelete, time.Now(), &resp.Diagnostics)
+
+	resourcePath, err := r.openAPIResource.getResourcePath(nil)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	id, err := r.getID(req.State.Raw)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	// Read only resources can not be deleted, the resource is just removed from the state
+	if r.openAPIResource.isReadOnlyResource() {
+		r.getLogger().Warn(fmt.Sprintf("resource '%s' is read only and can not be deleted, removing %s/%s from the state only", r.openAPIResource.getResourceName(), resourcePath, id), "resource", r.openAPIResource.getResourceName(), "id", id)
+		return
+	}
+	if r.openAPIResource.getResourceOperations().Delete == nil {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.getResourceName(), resourcePath))
+		return
+	}
+	res, err := r.providerClient.Delete(r.openAPIResource, id)
+	if err != nil {
+		r.addError(&resp.Diagnostics, err)
+		return
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}); err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			return
+		}
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] DELETE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, id, err))
+	}
+}
+
+// ImportState imports the resource by its id (or the value of the import lookup property if the resource supports
+// import lookups), the rest of the state is populated when the resource is read. Terraform fails the import if the
+// resource no longer exists
+func (r *frameworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] can not import a resource without providing its ID", r.openAPIResource.getResourceName()))
+		return
+	}
+	id := req.ID
+	if template := r.openAPIResource.getImportIDFormat(); template != "" {
+		format, err := newImportIDFormat(template, nil)
+		if err == nil {
+			_, id, err = format.parse(req.ID)
+		}
+		if err != nil {
+			r.addError(&resp.Diagnostics, fmt.Errorf("[resource='%s'] %s", r.openAPIResource.getResourceName(), err))
+			return
+		}
+	}
+	if r.openAPIResource.getImportLookupProperty() != "" {
+		var err error
+		id, err = r.lookupImportID(id, r.providerClient)
+		if err != nil {
+			r.addError(&resp.Diagnostics, err)
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(idDefaultPropertyName), id)...)
+}
+
+// getStateValue returns the state value for the resource with the given id populated with the payload returned by the
+// API. The prior value (e,g: the planned values) is used for the attributes not present in the payload
+func (r *frameworkResource) getStateValue(ctx context.Context, id string, payload map[string]interface{}, priorValue tftypes.Value) (tftypes.Value, error) {
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	objectType, ok := r.schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		return tftypes.Value{}, fmt.Errorf("[resource='%s'] the resource schema type is not an object", r.name)
+	}
+	var priorValues map[string]tftypes.Value
+	if !priorValue.IsNull() && priorValue.IsKnown() {
+		if err := priorValue.As(&priorValues); err != nil {
+			return tftypes.Value{}, err
+		}
+	}
+	values, err := resourceSchema.getFrameworkAttributeValues(objectType, payload, priorValues)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("[resource='%s'] %s", r.openAPIResource.getResourceName(), err)
+	}
+	values[idDefaultPropertyName] = tftypes.NewValue(tftypes.String, id)
+	return tftypes.NewValue(objectType, values), nil
+}
+
+// getID returns the value of the id attribute of the given state value
+func (r *frameworkResource) getID(state tftypes.Value) (string, error) {
+	attributes := map[string]tftypes.Value{}
+	if err := state.As(&attributes); err != nil {
+		return "", err
+	}
+	var id string
+	if err := attributes[idDefaultPropertyName].As(&id); err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", fmt.Errorf("[resource='%s'] the resource state is missing the id", r.name)
+	}
+	return id, nil
+}
+
+// addError adds the given error to the diagnostics
+func (r *frameworkResource) addError(diagnostics *diag.Diagnostics, err error) {
+	diagnostics.AddError(err.Error(), "")
+}
+
+// submitTelemetryMetrics submits the time it took to perform the resource operation since the start time provided and
+// the resource operation counters to the telemetry handler, if any. The operation is considered failed if the
+// diagnostics contain errors
+func (r *frameworkResource) submitTelemetryMetrics(operation TelemetryResourceOperation, start time.Time, diagnostics *diag.Diagnostics) {
+	if r.telemetryHandler == nil {
+		return
+	}
+	resourceName := r.openAPIResource.getResourceName()
+	r.telemetryHandler.SubmitResourceOperationTimingMetric(resourceName, operation, time.Since(start))
+	r.telemetryHandler.IncResourceOperationCounters(resourceName, operation, diagnostics.HasError())
+}
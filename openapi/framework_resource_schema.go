@@ -0,0 +1,504 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// createFrameworkResourceAttributes returns the framework resource attributes for the schema definition properties. As
+// opposed to the SDK resource schema, the objects and arrays of objects are represented as nested attributes regardless
+// of the types of their properties
+func (s *specSchemaDefinition) createFrameworkResourceAttributes(ignoreID bool) (map[string]resourceschema.Attribute, error) {
+	attributes := map[string]resourceschema.Attribute{}
+	for _, property := range s.Properties {
+		// The framework resources have an id attribute that contains the resource identifier, hence the attributes do not
+		// need to include an explicit ID property
+		if property.isPropertyNamedID() && ignoreID {
+			continue
+		}
+		attribute, err := property.frameworkAttribute()
+		if err != nil {
+			return nil, err
+		}
+		attributes[property.getTerraformCompliantPropertyName()] = attribute
+	}
+	return attributes, nil
+}
+
+// frameworkAttribute returns the framework resource attribute for the given specSchemaDefinitionProperty
+func (s *specSchemaDefinitionProperty) frameworkAttribute() (resourceschema.Attribute, error) {
+	required := s.isRequired()
+	optional := s.isOptional()
+	computed := s.isComputed() || s.isComputedWithDefault()
+	switch s.Type {
+	case typeString:
+		attribute := resourceschema.StringAttribute{Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive}
+		if s.Constraints != nil {
+			attribute.Validators = append(attribute.Validators, constraintsValidator{property: s})
+		}
+		if s.ForceNew {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, stringplanmodifier.RequiresReplace())
+		}
+		if computed {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, stringplanmodifier.UseStateForUnknown())
+		} else if defaultValue, ok := s.Default.(string); ok {
+			attribute.Computed = true
+			attribute.Default = stringdefault.StaticString(defaultValue)
+		}
+		return attribute, nil
+	case typeInt:
+		attribute := resourceschema.Int64Attribute{Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive}
+		if s.Constraints != nil {
+			attribute.Validators = append(attribute.Validators, constraintsValidator{property: s})
+		}
+		if s.ForceNew {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, int64planmodifier.RequiresReplace())
+		}
+		if computed {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, int64planmodifier.UseStateForUnknown())
+		} else if defaultValue, ok := getFrameworkNumberValue(s.Default); ok {
+			defaultInt, _ := defaultValue.Int64()
+			attribute.Computed = true
+			attribute.Default = int64default.StaticInt64(defaultInt)
+		}
+		return attribute, nil
+	case typeFloat:
+		attribute := resourceschema.Float64Attribute{Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive}
+		if s.Constraints != nil {
+			attribute.Validators = append(attribute.Validators, constraintsValidator{property: s})
+		}
+		if s.ForceNew {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, float64planmodifier.RequiresReplace())
+		}
+		if computed {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, float64planmodifier.UseStateForUnknown())
+		} else if defaultValue, ok := getFrameworkNumberValue(s.Default); ok {
+			defaultFloat, _ := defaultValue.Float64()
+			attribute.Computed = true
+			attribute.Default = float64default.StaticFloat64(defaultFloat)
+		}
+		return attribute, nil
+	case typeBool:
+		attribute := resourceschema.BoolAttribute{Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive}
+		if s.ForceNew {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, boolplanmodifier.RequiresReplace())
+		}
+		if computed {
+			attribute.PlanModifiers = append(attribute.PlanModifiers, boolplanmodifier.UseStateForUnknown())
+		} else if defaultValue, ok := s.Default.(bool); ok {
+			attribute.Computed = true
+			attribute.Default = booldefault.StaticBool(defaultValue)
+		}
+		return attribute, nil
+	case typeList:
+		var planModifiers []planmodifier.List
+		if s.ForceNew {
+			planModifiers = append(planModifiers, listplanmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, listplanmodifier.UseStateForUnknown())
+		}
+		if s.isArrayOfObjectsProperty() {
+			attributes, err := s.frameworkNestedAttributes()
+			if err != nil {
+				return nil, err
+			}
+			return resourceschema.ListNestedAttribute{NestedObject: resourceschema.NestedAttributeObject{Attributes: attributes, Validators: s.frameworkObjectValidators()}, Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive, PlanModifiers: planModifiers}, nil
+		}
+		elemType, err := getFrameworkPrimitiveType(s.ArrayItemsType)
+		if err != nil {
+			return nil, fmt.Errorf("list property '%s' has a non supported items type: %s", s.Name, err)
+		}
+		return resourceschema.ListAttribute{ElementType: elemType, Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive, PlanModifiers: planModifiers}, nil
+	case typeObject:
+		var planModifiers []planmodifier.Object
+		if s.ForceNew {
+			planModifiers = append(planModifiers, objectplanmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, objectplanmodifier.UseStateForUnknown())
+		}
+		attributes, err := s.frameworkNestedAttributes()
+		if err != nil {
+			return nil, err
+		}
+		return resourceschema.SingleNestedAttribute{Attributes: attributes, Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive, PlanModifiers: planModifiers, Validators: s.frameworkObjectValidators()}, nil
+	case typeMap:
+		var planModifiers []planmodifier.Map
+		if s.ForceNew {
+			planModifiers = append(planModifiers, mapplanmodifier.RequiresReplace())
+		}
+		if computed {
+			planModifiers = append(planModifiers, mapplanmodifier.UseStateForUnknown())
+		}
+		if s.isMapOfObjectsProperty() {
+			attributes, err := s.frameworkNestedAttributes()
+			if err != nil {
+				return nil, err
+			}
+			return resourceschema.MapNestedAttribute{NestedObject: resourceschema.NestedAttributeObject{Attributes: attributes, Validators: s.frameworkObjectValidators()}, Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive, PlanModifiers: planModifiers}, nil
+		}
+		elemType, err := getFrameworkPrimitiveType(s.AdditionalPropertiesType)
+		if err != nil {
+			return nil, fmt.Errorf("map property '%s' has a non supported additionalProperties type: %s", s.Name, err)
+		}
+		return resourceschema.MapAttribute{ElementType: elemType, Required: required, Optional: optional, Computed: computed, Sensitive: s.Sensitive, PlanModifiers: planModifiers}, nil
+	}
+	return nil, fmt.Errorf("non supported type %s", s.Type)
+}
+
+func (s *specSchemaDefinitionProperty) frameworkNestedAttributes() (map[string]resourceschema.Attribute, error) {
+	if s.SpecSchemaDefinition == nil {
+		return nil, fmt.Errorf("missing spec schema definition for property '%s' of type '%s'", s.Name, s.Type)
+	}
+	return s.SpecSchemaDefinition.createFrameworkResourceAttributes(false)
+}
+
+// frameworkObjectValidators returns the validators of the nested object of the property, discriminated unions only
+// allow one of the variants to be configured
+func (s *specSchemaDefinitionProperty) frameworkObjectValidators() []validator.Object {
+	if !s.SpecSchemaDefinition.isDiscriminatedUnion() {
+		return nil
+	}
+	return []validator.Object{discriminatedUnionValidator{variants: s.SpecSchemaDefinition.getVariantTerraformNames()}}
+}
+
+// discriminatedUnionValidator validates that only one of the variants of a discriminated union is configured
+type discriminatedUnionValidator struct {
+	variants []string
+}
+
+func (v discriminatedUnionValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("only one of the %s variants can be configured", strings.Join(v.variants, ", "))
+}
+
+func (v discriminatedUnionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v discriminatedUnionValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var configured []string
+	for name, value := range req.ConfigValue.Attributes() {
+		if !value.IsNull() {
+			configured = append(configured, name)
+		}
+	}
+	if len(configured) > 1 {
+		sort.Strings(configured)
+		resp.Diagnostics.AddAttributeError(req.Path, "Conflicting discriminated union variants", fmt.Sprintf("%s, found: %s", v.Description(ctx), strings.Join(configured, ", ")))
+	}
+}
+
+// arrayItemProperty returns a specSchemaDefinitionProperty describing the items of the array property
+func (s *specSchemaDefinitionProperty) arrayItemProperty() *specSchemaDefinitionProperty {
+	return &specSchemaDefinitionProperty{
+		Name:                 s.Name,
+		Type:                 s.ArrayItemsType,
+		SpecSchemaDefinition: s.SpecSchemaDefinition,
+	}
+}
+
+func getFrameworkPrimitiveType(propertyType schemaDefinitionPropertyType) (attr.Type, error) {
+	switch propertyType {
+	case typeString:
+		return types.StringType, nil
+	case typeInt:
+		return types.Int64Type, nil
+	case typeFloat:
+		return types.Float64Type, nil
+	case typeBool:
+		return types.BoolType, nil
+	}
+	return nil, fmt.Errorf("type '%s' is not a primitive type", propertyType)
+}
+
+// createFrameworkPayload translates the given terraform object value (e,g: the planned values) into a payload that can
+// be posted/put to the API. As opposed to the SDK resources, the attributes not configured are null instead of the zero
+// value of their type, so they are never sent to the API. Note the readonly properties will not be posted/put to the API
+func (s *specSchemaDefinition) createFrameworkPayload(value tftypes.Value) (map[string]interface{}, error) {
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	for _, property := range s.Properties {
+		if property.isReadOnly() || property.IsParentProperty {
+			continue
+		}
+		attributeValue, exists := attributes[property.getTerraformCompliantPropertyName()]
+		if !exists || attributeValue.IsNull() || !attributeValue.IsKnown() {
+			continue
+		}
+		payloadValue, err := property.frameworkPayloadValue(attributeValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the payload for property '%s': %s", property.Name, err)
+		}
+		// Nullable properties explicitly set to the null sentinel by the user are sent to the API as JSON null
+		if property.isNullValue(payloadValue) {
+			payload[property.Name] = nil
+			continue
+		}
+		if property.shouldOmitWhenEmpty(payloadValue) {
+			continue
+		}
+		payload[property.Name] = payloadValue
+	}
+	if s.isDiscriminatedUnion() {
+		return s.toUnionPayload(payload)
+	}
+	return payload, nil
+}
+
+// frameworkPayloadValue returns the payload value for the given terraform value of the property
+func (s *specSchemaDefinitionProperty) frameworkPayloadValue(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() || !value.IsKnown() {
+		return nil, nil
+	}
+	switch s.Type {
+	case typeString:
+		var v string
+		err := value.As(&v)
+		return v, err
+	case typeInt:
+		v := new(big.Float)
+		if err := value.As(&v); err != nil {
+			return nil, err
+		}
+		i, _ := v.Int64()
+		return int(i), nil
+	case typeFloat:
+		v := new(big.Float)
+		if err := value.As(&v); err != nil {
+			return nil, err
+		}
+		f, _ := v.Float64()
+		return f, nil
+	case typeBool:
+		var v bool
+		err := value.As(&v)
+		return v, err
+	case typeList:
+		var items []tftypes.Value
+		if err := value.As(&items); err != nil {
+			return nil, err
+		}
+		itemProperty := s.arrayItemProperty()
+		payloadItems := []interface{}{}
+		for _, item := range items {
+			payloadItem, err := itemProperty.frameworkPayloadValue(item)
+			if err != nil {
+				return nil, err
+			}
+			payloadItems = append(payloadItems, payloadItem)
+		}
+		return payloadItems, nil
+	case typeObject:
+		if s.SpecSchemaDefinition == nil {
+			return nil, fmt.Errorf("missing spec schema definition for property '%s' of type '%s'", s.Name, s.Type)
+		}
+		return s.SpecSchemaDefinition.createFrameworkPayload(value)
+	case typeMap:
+		elements := map[string]tftypes.Value{}
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		payloadElements := map[string]interface{}{}
+		for key, element := range elements {
+			payloadElement, err := s.newAdditionalProperty(key).frameworkPayloadValue(element)
+			if err != nil {
+				return nil, err
+			}
+			payloadElements[key] = payloadElement
+		}
+		return payloadElements, nil
+	}
+	return nil, fmt.Errorf("'%s' type not supported", s.Type)
+}
+
+// getFrameworkAttributeValues returns the values of the attributes of the given object type populated with the payload
+// returned by the API. The attributes not present in the payload (e,g: sensitive values not returned by the API) keep
+// the prior values provided if known; otherwise they are null
+func (s *specSchemaDefinition) getFrameworkAttributeValues(objectType tftypes.Object, payload map[string]interface{}, priorValues map[string]tftypes.Value) (map[string]tftypes.Value, error) {
+	if s.isDiscriminatedUnion() {
+		payload = s.fromUnionPayload(payload)
+	}
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for _, property := range s.Properties {
+		name := property.getTerraformCompliantPropertyName()
+		attributeType, exists := objectType.AttributeTypes[name]
+		if !exists {
+			continue
+		}
+		priorValue, priorExists := priorValues[name]
+		payloadValue := payload[property.getResponseFieldName()]
+		// The write-only properties are not returned by the API (or their values are masked), so the prior values are kept
+		if payloadValue == nil || property.WriteOnly {
+			if priorExists && priorValue.IsFullyKnown() {
+				values[name] = priorValue
+			}
+			continue
+		}
+		if !priorExists {
+			priorValue = tftypes.NewValue(attributeType, nil)
+		}
+		value, err := property.frameworkValue(attributeType, payloadValue, priorValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the value of property '%s': %s", property.Name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// frameworkValue returns the terraform value of the given type for the payload value returned by the API. The prior value
+// is used to keep the values of the nested attributes not present in the payload
+func (s *specSchemaDefinitionProperty) frameworkValue(terraformType tftypes.Type, payloadValue interface{}, priorValue tftypes.Value) (tftypes.Value, error) {
+	if payloadValue == nil {
+		return tftypes.NewValue(terraformType, nil), nil
+	}
+	switch s.Type {
+	case typeString:
+		v, ok := payloadValue.(string)
+		if !ok {
+			v = fmt.Sprintf("%v", payloadValue)
+		}
+		// The prior value is kept when it is the same as the value returned by the API once normalized, otherwise the value
+		// normalized by the API would differ from the configured one
+		if len(s.Normalizations) > 0 && !priorValue.IsNull() && priorValue.IsKnown() {
+			var prior string
+			if err := priorValue.As(&prior); err == nil && s.normalize(prior) == s.normalize(v) {
+				return priorValue, nil
+			}
+		}
+		return tftypes.NewValue(terraformType, v), nil
+	case typeInt, typeFloat:
+		v, ok := getFrameworkNumberValue(payloadValue)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected a number but got '%v'", payloadValue)
+		}
+		return tftypes.NewValue(terraformType, v), nil
+	case typeBool:
+		v, ok := payloadValue.(bool)
+		if !ok {
+			return tftypes.Value{}, fmt.Errorf("expected a boolean but got '%v'", payloadValue)
+		}
+		return tftypes.NewValue(terraformType, v), nil
+	case typeList:
+		listType, ok := terraformType.(tftypes.List)
+		payloadItems, isList := payloadValue.([]interface{})
+		if !ok || !isList {
+			return tftypes.Value{}, fmt.Errorf("expected a list but got '%v'", payloadValue)
+		}
+		var priorItems []tftypes.Value
+		if !priorValue.IsNull() && priorValue.IsKnown() {
+			if err := priorValue.As(&priorItems); err != nil {
+				return tftypes.Value{}, err
+			}
+		}
+		itemProperty := s.arrayItemProperty()
+		items := []tftypes.Value{}
+		for idx, payloadItem := range payloadItems {
+			priorItem := tftypes.NewValue(listType.ElementType, nil)
+			if idx < len(priorItems) {
+				priorItem = priorItems[idx]
+			}
+			item, err := itemProperty.frameworkValue(listType.ElementType, payloadItem, priorItem)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			items = append(items, item)
+		}
+		return tftypes.NewValue(terraformType, items), nil
+	case typeObject:
+		objectType, ok := terraformType.(tftypes.Object)
+		payloadObject, isObject := payloadValue.(map[string]interface{})
+		if !ok || !isObject {
+			return tftypes.Value{}, fmt.Errorf("expected an object but got '%v'", payloadValue)
+		}
+		if s.SpecSchemaDefinition == nil {
+			return tftypes.Value{}, fmt.Errorf("missing spec schema definition for property '%s' of type '%s'", s.Name, s.Type)
+		}
+		var priorValues map[string]tftypes.Value
+		if !priorValue.IsNull() && priorValue.IsKnown() {
+			if err := priorValue.As(&priorValues); err != nil {
+				return tftypes.Value{}, err
+			}
+		}
+		values, err := s.SpecSchemaDefinition.getFrameworkAttributeValues(objectType, payloadObject, priorValues)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+		return tftypes.NewValue(terraformType, values), nil
+	case typeMap:
+		mapType, ok := terraformType.(tftypes.Map)
+		payloadElements, isMap := payloadValue.(map[string]interface{})
+		if !ok || !isMap {
+			return tftypes.Value{}, fmt.Errorf("expected a map but got '%v'", payloadValue)
+		}
+		var priorElements map[string]tftypes.Value
+		if !priorValue.IsNull() && priorValue.IsKnown() {
+			if err := priorValue.As(&priorElements); err != nil {
+				return tftypes.Value{}, err
+			}
+		}
+		elements := map[string]tftypes.Value{}
+		for key, payloadElement := range payloadElements {
+			priorElement, exists := priorElements[key]
+			if !exists {
+				priorElement = tftypes.NewValue(mapType.ElementType, nil)
+			}
+			element, err := s.newAdditionalProperty(key).frameworkValue(mapType.ElementType, payloadElement, priorElement)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			elements[key] = element
+		}
+		return tftypes.NewValue(terraformType, elements), nil
+	}
+	return tftypes.Value{}, fmt.Errorf("'%s' type not supported", s.Type)
+}
+
+// getFrameworkNumberValue returns the given value as a big.Float, which is the type used by terraform to represent the
+// numbers. In golang, a number in JSON message is always parsed into float64 but the default values may be ints too
+func getFrameworkNumberValue(value interface{}) (*big.Float, bool) {
+	switch v := value.(type) {
+	case int:
+		return new(big.Float).SetInt64(int64(v)), true
+	case int32:
+		return new(big.Float).SetInt64(int64(v)), true
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	case float32:
+		return big.NewFloat(float64(v)), true
+	case float64:
+		return big.NewFloat(v), true
+	}
+	return nil, false
+}
@@ -0,0 +1,223 @@
+package openapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateFrameworkResourceAttributes(t *testing.T) {
+	Convey("Given a schema definition containing primitive, object and array of objects properties", t, func() {
+		objectSchemaDefinition := newTestSchema(newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil)).getSchemaDefinition()
+		mapOfObjects := newMapSchemaDefinitionPropertyWithDefaults("origins", "", false, false, nil, typeObject)
+		mapOfObjects.SpecSchemaDefinition = objectSchemaDefinition
+		s := newTestSchema(
+			idProperty,
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("priority", "", false, false, 5),
+			newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, objectSchemaDefinition),
+			newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, typeObject, objectSchemaDefinition),
+			newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, nil, typeString, nil),
+			newMapSchemaDefinitionPropertyWithDefaults("labels", "", false, false, nil, typeString),
+			mapOfObjects,
+		).getSchemaDefinition()
+		Convey("When createFrameworkResourceAttributes is called ignoring the id", func() {
+			attributes, err := s.createFrameworkResourceAttributes(true)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the id property should not be part of the attributes", func() {
+				So(attributes, ShouldNotContainKey, "id")
+			})
+			Convey("And the primitive properties should be represented as primitive attributes", func() {
+				So(attributes["name"].IsRequired(), ShouldBeTrue)
+				So(attributes["priority"].IsOptional(), ShouldBeTrue)
+				So(attributes["priority"].IsComputed(), ShouldBeTrue)
+				So(attributes["priority"].(resourceschema.Int64Attribute).Default, ShouldNotBeNil)
+				So(attributes["status"].IsComputed(), ShouldBeTrue)
+				So(attributes["status"].(resourceschema.StringAttribute).PlanModifiers, ShouldHaveLength, 1)
+			})
+			Convey("And the object property should be represented as a single nested attribute", func() {
+				settings, ok := attributes["settings"].(resourceschema.SingleNestedAttribute)
+				So(ok, ShouldBeTrue)
+				So(settings.Attributes, ShouldContainKey, "enabled")
+			})
+			Convey("And the array of objects property should be represented as a list nested attribute", func() {
+				rules, ok := attributes["rules"].(resourceschema.ListNestedAttribute)
+				So(ok, ShouldBeTrue)
+				So(rules.NestedObject.Attributes, ShouldContainKey, "enabled")
+			})
+			Convey("And the array of primitives and map properties should be represented as list and map attributes", func() {
+				So(attributes["tags"].(resourceschema.ListAttribute).ElementType.Equal(types.StringType), ShouldBeTrue)
+				So(attributes["labels"].(resourceschema.MapAttribute).ElementType.Equal(types.StringType), ShouldBeTrue)
+			})
+			Convey("And the map of objects property should be represented as a map nested attribute", func() {
+				origins, ok := attributes["origins"].(resourceschema.MapNestedAttribute)
+				So(ok, ShouldBeTrue)
+				So(origins.NestedObject.Attributes, ShouldContainKey, "enabled")
+			})
+		})
+	})
+
+	Convey("Given a schema definition containing a list property with items of a non supported type", t, func() {
+		s := newTestSchema(newListSchemaDefinitionPropertyWithDefaults("tags", "", false, false, false, nil, typeList, nil)).getSchemaDefinition()
+		Convey("When createFrameworkResourceAttributes is called", func() {
+			_, err := s.createFrameworkResourceAttributes(true)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateFrameworkPayload(t *testing.T) {
+	Convey("Given a schema definition and the terraform value with some attributes configured", t, func() {
+		objectSchemaDefinition := newTestSchema(newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil)).getSchemaDefinition()
+		s := newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+			newIntSchemaDefinitionPropertyWithDefaults("priority", "", false, false, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("status", "", false, true, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, objectSchemaDefinition),
+			newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, typeObject, objectSchemaDefinition),
+		).getSchemaDefinition()
+		objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"enabled": tftypes.Bool}}
+		valueType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":     tftypes.String,
+			"priority": tftypes.Number,
+			"status":   tftypes.String,
+			"settings": objectType,
+			"rules":    tftypes.List{ElementType: objectType},
+		}}
+		value := tftypes.NewValue(valueType, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, "my_firewall"),
+			"priority": tftypes.NewValue(tftypes.Number, nil),
+			"status":   tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"settings": tftypes.NewValue(objectType, map[string]tftypes.Value{"enabled": tftypes.NewValue(tftypes.Bool, true)}),
+			"rules": tftypes.NewValue(tftypes.List{ElementType: objectType}, []tftypes.Value{
+				tftypes.NewValue(objectType, map[string]tftypes.Value{"enabled": tftypes.NewValue(tftypes.Bool, false)}),
+			}),
+		})
+		Convey("When createFrameworkPayload is called", func() {
+			payload, err := s.createFrameworkPayload(value)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the payload should only contain the configured attributes that are not readonly", func() {
+				So(payload, ShouldResemble, map[string]interface{}{
+					"name":     "my_firewall",
+					"settings": map[string]interface{}{"enabled": true},
+					"rules":    []interface{}{map[string]interface{}{"enabled": false}},
+				})
+			})
+		})
+	})
+}
+
+func TestGetFrameworkAttributeValues(t *testing.T) {
+	Convey("Given a schema definition, the object type and the payload returned by the API", t, func() {
+		objectSchemaDefinition := newTestSchema(newIntSchemaDefinitionPropertyWithDefaults("port", "", false, false, nil)).getSchemaDefinition()
+		writeOnlyProperty := newStringSchemaDefinitionPropertyWithDefaults("api_key", "", false, false, nil)
+		writeOnlyProperty.WriteOnly = true
+		s := newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+			newStringSchemaDefinitionProperty("password", "", false, false, false, false, true, false, false, false, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, objectSchemaDefinition),
+			newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, typeObject, objectSchemaDefinition),
+			writeOnlyProperty,
+		).getSchemaDefinition()
+		objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}
+		valueType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"name":     tftypes.String,
+			"password": tftypes.String,
+			"settings": objectType,
+			"rules":    tftypes.List{ElementType: objectType},
+			"api_key":  tftypes.String,
+		}}
+		payload := map[string]interface{}{
+			"name":     "my_firewall",
+			"settings": map[string]interface{}{"port": float64(443)},
+			"rules":    []interface{}{map[string]interface{}{"port": float64(80)}},
+			"api_key":  "*****",
+		}
+		priorValues := map[string]tftypes.Value{
+			"password": tftypes.NewValue(tftypes.String, "secret"),
+			"api_key":  tftypes.NewValue(tftypes.String, "some key"),
+		}
+		Convey("When getFrameworkAttributeValues is called", func() {
+			values, err := s.getFrameworkAttributeValues(valueType, payload, priorValues)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the values should be populated with the payload", func() {
+				So(values["name"].Equal(tftypes.NewValue(tftypes.String, "my_firewall")), ShouldBeTrue)
+				So(values["settings"].Equal(tftypes.NewValue(objectType, map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, big.NewFloat(443))})), ShouldBeTrue)
+				So(values["rules"].Equal(tftypes.NewValue(tftypes.List{ElementType: objectType}, []tftypes.Value{
+					tftypes.NewValue(objectType, map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, big.NewFloat(80))}),
+				})), ShouldBeTrue)
+			})
+			Convey("And the attributes not returned by the API should keep the prior value", func() {
+				So(values["password"].Equal(tftypes.NewValue(tftypes.String, "secret")), ShouldBeTrue)
+			})
+			Convey("And the write-only attributes should keep the prior value even if returned by the API", func() {
+				So(values["api_key"].Equal(tftypes.NewValue(tftypes.String, "some key")), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestFrameworkValueNormalizedString(t *testing.T) {
+	Convey("Given a string property with normalizations", t, func() {
+		s := newStringSchemaDefinitionPropertyWithDefaults("hostname", "", false, false, nil)
+		s.Normalizations = []string{normalizeTrim, normalizeLowercase}
+		Convey("When frameworkValue is called with a payload value that matches the prior value once normalized", func() {
+			value, err := s.frameworkValue(tftypes.String, "api.domain.com", tftypes.NewValue(tftypes.String, " API.Domain.com"))
+			Convey("Then the prior value should be kept", func() {
+				So(err, ShouldBeNil)
+				So(value.Equal(tftypes.NewValue(tftypes.String, " API.Domain.com")), ShouldBeTrue)
+			})
+		})
+		Convey("When frameworkValue is called with a payload value that does not match the prior value once normalized", func() {
+			value, err := s.frameworkValue(tftypes.String, "other.domain.com", tftypes.NewValue(tftypes.String, " API.Domain.com"))
+			Convey("Then the value returned by the API should be used", func() {
+				So(err, ShouldBeNil)
+				So(value.Equal(tftypes.NewValue(tftypes.String, "other.domain.com")), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestDiscriminatedUnionValidator(t *testing.T) {
+	Convey("Given a discriminated union validator for the cat and dog variants", t, func() {
+		v := discriminatedUnionValidator{variants: []string{"cat", "dog"}}
+		catType := map[string]attr.Type{"lives": types.Int64Type}
+		dogType := map[string]attr.Type{"bark": types.BoolType}
+		unionType := map[string]attr.Type{"cat": types.ObjectType{AttrTypes: catType}, "dog": types.ObjectType{AttrTypes: dogType}}
+		cat := types.ObjectValueMust(catType, map[string]attr.Value{"lives": types.Int64Value(7)})
+		dog := types.ObjectValueMust(dogType, map[string]attr.Value{"bark": types.BoolValue(true)})
+		Convey("When ValidateObject is called with only one of the variants configured", func() {
+			resp := &validator.ObjectResponse{}
+			v.ValidateObject(context.Background(), validator.ObjectRequest{Path: path.Root("pet"), ConfigValue: types.ObjectValueMust(unionType, map[string]attr.Value{"cat": cat, "dog": types.ObjectNull(dogType)})}, resp)
+			Convey("Then the diagnostics should not contain errors", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeFalse)
+			})
+		})
+		Convey("When ValidateObject is called with both variants configured", func() {
+			resp := &validator.ObjectResponse{}
+			v.ValidateObject(context.Background(), validator.ObjectRequest{Path: path.Root("pet"), ConfigValue: types.ObjectValueMust(unionType, map[string]attr.Value{"cat": cat, "dog": dog})}, resp)
+			Convey("Then the diagnostics should contain the conflict error", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeTrue)
+				So(resp.Diagnostics.Errors()[0].Detail(), ShouldEqual, "only one of the cat, dog variants can be configured, found: cat, dog")
+			})
+		})
+	})
+}
@@ -0,0 +1,258 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewFrameworkResource(t *testing.T) {
+	Convey("Given a resource factory for a resource with an id and a name property", t, func() {
+		r := newResourceFactory(newSpecStubResource("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+		).getSchemaDefinition()))
+		Convey("When newFrameworkResource is called", func() {
+			frameworkResource, err := newFrameworkResource("openapi_firewall_v1", r)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema should contain the computed id and the name attributes", func() {
+				So(frameworkResource.schema.Attributes, ShouldHaveLength, 2)
+				So(frameworkResource.schema.Attributes[idDefaultPropertyName].IsComputed(), ShouldBeTrue)
+				So(frameworkResource.schema.Attributes["name"].IsRequired(), ShouldBeTrue)
+			})
+			Convey("And the state value should be populated with the id and the payload returned by the API", func() {
+				state, err := frameworkResource.getStateValue(context.Background(), "42", map[string]interface{}{"id": "42", "name": "my_firewall"}, tftypes.Value{})
+				So(err, ShouldBeNil)
+				id, err := frameworkResource.getID(state)
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, "42")
+				attributes := map[string]tftypes.Value{}
+				So(state.As(&attributes), ShouldBeNil)
+				So(attributes["name"].Equal(tftypes.NewValue(tftypes.String, "my_firewall")), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a resource factory for a resource with a property of a non supported type", t, func() {
+		r := newResourceFactory(newSpecStubResource("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newListSchemaDefinitionPropertyWithDefaults("rules", "", false, false, false, nil, typeList, nil),
+		).getSchemaDefinition()))
+		Convey("When newFrameworkResource is called", func() {
+			_, err := newFrameworkResource("openapi_firewall_v1", r)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestGetFrameworkUnsupportedFeatures(t *testing.T) {
+	testSchema := newTestSchema(
+		newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+		newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+	).getSchemaDefinition()
+	Convey("Given a resource factory for a resource that does not use any feature only supported by the SDK resources", t, func() {
+		r := newResourceFactory(newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, testSchema, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		Convey("When getFrameworkUnsupportedFeatures is called", func() {
+			features, err := r.getFrameworkUnsupportedFeatures()
+			Convey("Then the features returned should be empty", func() {
+				So(err, ShouldBeNil)
+				So(features, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a resource factory for a resource that uses features only supported by the SDK resources", t, func() {
+		timeout := time.Minute
+		specResource := newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, testSchema,
+			&specResourceOperation{
+				responses:              specResponses{http.StatusAccepted: &specResponse{asyncOperation: &specAsyncOperation{locationHeader: "Location"}}},
+				readAfterCreateRetries: 2,
+			},
+			&specResourceOperation{
+				responses:         specResponses{http.StatusAccepted: &specResponse{isPollingEnabled: true}},
+				optimisticLocking: &specOptimisticLocking{strategy: optimisticLockingETag},
+			},
+			&specResourceOperation{conditionalRead: true},
+			&specResourceOperation{
+				deletePoll:         &specDeletePoll{},
+				deleteBodyTemplate: map[string]interface{}{"force": true},
+				preDeleteOperation: &specPreDeleteOperation{},
+			})
+		specResource.timeouts = &specTimeouts{Post: &timeout}
+		r := newResourceFactory(specResource)
+		r.regions = []string{"rst1"}
+		Convey("When getFrameworkUnsupportedFeatures is called", func() {
+			features, err := r.getFrameworkUnsupportedFeatures()
+			Convey("Then the features returned should contain all the features found", func() {
+				So(err, ShouldBeNil)
+				So(features, ShouldResemble, []string{extTfResourceTimeout, extTfResourcePollEnabled, extTfAsyncOperation, extTfOptimisticLocking, extTfConditionalRead, extTfReadAfterCreateRetries, extTfResourceDeletePoll, extTfDeleteBody, extTfPreDeleteOperation, providerPropertyRegion})
+			})
+		})
+	})
+}
+
+func TestFrameworkResourceCreate(t *testing.T) {
+	Convey("Given a framework resource and an API that responds to the POST request with 201, a location header and an empty body", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost:
+				w.Header().Set("Location", "/v1/firewalls/42")
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodGet:
+				w.Write([]byte(`{"id":"42","name":"my_firewall"}`))
+			}
+		}))
+		defer api.Close()
+		specResource := newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+		).getSchemaDefinition(), &specResourceOperation{}, nil, &specResourceOperation{}, nil)
+		frameworkResource, err := newFrameworkResource("openapi_firewall_v1", newResourceFactory(specResource))
+		So(err, ShouldBeNil)
+		frameworkResource.providerClient = newTestAPIProviderClient(api.URL)
+		plan, err := frameworkResource.getStateValue(context.Background(), "", map[string]interface{}{"name": "my_firewall"}, tftypes.Value{})
+		So(err, ShouldBeNil)
+		Convey("When Create is called", func() {
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: frameworkResource.schema}}
+			frameworkResource.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: frameworkResource.schema, Raw: plan}}, resp)
+			Convey("Then the diagnostics should not contain errors", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeFalse)
+			})
+			Convey("And the state should contain the id extracted from the location header and the resource read from the API", func() {
+				var id, name string
+				So(resp.State.GetAttribute(context.Background(), path.Root(idDefaultPropertyName), &id).HasError(), ShouldBeFalse)
+				So(resp.State.GetAttribute(context.Background(), path.Root("name"), &name).HasError(), ShouldBeFalse)
+				So(id, ShouldEqual, "42")
+				So(name, ShouldEqual, "my_firewall")
+			})
+		})
+	})
+}
+
+func TestFrameworkResourceImportState(t *testing.T) {
+	Convey("Given a framework resource for a resource that supports import lookups by name", t, func() {
+		specResource := newSpecStubResource("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+		).getSchemaDefinition())
+		specResource.importLookupProperty = "name"
+		specResource.resourceListOperation = &specResourceOperation{}
+		frameworkResource, err := newFrameworkResource("openapi_firewall_v1", newResourceFactory(specResource))
+		So(err, ShouldBeNil)
+		frameworkResource.providerClient = &clientOpenAPIStub{
+			responseListPayload: []map[string]interface{}{
+				{"id": "some-id", "name": "my_firewall"},
+				{"id": "some-other-id", "name": "my_other_firewall"},
+			},
+		}
+		newImportStateResponse := func() *resource.ImportStateResponse {
+			return &resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: frameworkResource.schema,
+					Raw:    tftypes.NewValue(frameworkResource.schema.Type().TerraformType(context.Background()), nil),
+				},
+			}
+		}
+		Convey("When ImportState is called with the name of an existing resource", func() {
+			resp := newImportStateResponse()
+			frameworkResource.ImportState(context.Background(), resource.ImportStateRequest{ID: "my_firewall"}, resp)
+			Convey("Then the state should contain the id of the resource matching the name", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeFalse)
+				id, err := frameworkResource.getID(resp.State.Raw)
+				So(err, ShouldBeNil)
+				So(id, ShouldEqual, "some-id")
+			})
+		})
+		Convey("When ImportState is called with an empty ID", func() {
+			resp := newImportStateResponse()
+			frameworkResource.ImportState(context.Background(), resource.ImportStateRequest{ID: ""}, resp)
+			Convey("Then the diagnostics should contain the expected error", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeTrue)
+				So(resp.Diagnostics.Errors()[0].Summary(), ShouldEqual, "[resource='firewall_v1'] can not import a resource without providing its ID")
+			})
+		})
+	})
+}
+
+func TestFrameworkResourceRead(t *testing.T) {
+	Convey("Given a framework resource for a resource that no longer exists in the API", t, func() {
+		specResource := newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+		).getSchemaDefinition(), nil, nil, &specResourceOperation{}, nil)
+		frameworkResource, err := newFrameworkResource("openapi_firewall_v1", newResourceFactory(specResource))
+		So(err, ShouldBeNil)
+		frameworkResource.providerClient = &clientOpenAPIStub{returnHTTPCode: http.StatusNotFound}
+		state, err := frameworkResource.getStateValue(context.Background(), "42", map[string]interface{}{"id": "42", "name": "my_firewall"}, tftypes.Value{})
+		So(err, ShouldBeNil)
+		newReadResponse := func() *resource.ReadResponse {
+			return &resource.ReadResponse{State: tfsdk.State{Schema: frameworkResource.schema, Raw: state}}
+		}
+		Convey("When Read is called", func() {
+			resp := newReadResponse()
+			frameworkResource.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: frameworkResource.schema, Raw: state}}, resp)
+			Convey("Then the resource should be removed from the state", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeFalse)
+				So(resp.State.Raw.IsNull(), ShouldBeTrue)
+			})
+		})
+		Convey("When Read is called and the resource is configured to not be removed from the state on 404", func() {
+			specResource.keepOnNotFound = true
+			resp := newReadResponse()
+			frameworkResource.Read(context.Background(), resource.ReadRequest{State: tfsdk.State{Schema: frameworkResource.schema, Raw: state}}, resp)
+			Convey("Then the diagnostics should contain an error and the resource should be kept in the state", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeTrue)
+				So(resp.Diagnostics.Errors()[0].Summary(), ShouldStartWith, "[resource='firewall_v1'] GET /v1/firewalls/42 failed")
+				So(resp.State.Raw.IsNull(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestFrameworkResourceUpdate(t *testing.T) {
+	Convey("Given a framework resource for a resource that only supports PATCH updates", t, func() {
+		specResource := newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil),
+			newStringSchemaDefinitionPropertyWithDefaults("label", "", false, false, nil),
+		).getSchemaDefinition(), nil, nil, &specResourceOperation{}, nil)
+		specResource.resourcePatchOperation = &specResourceOperation{}
+		frameworkResource, err := newFrameworkResource("openapi_firewall_v1", newResourceFactory(specResource))
+		So(err, ShouldBeNil)
+		client := &clientOpenAPIStub{responsePayload: map[string]interface{}{"id": "42", "name": "my_new_firewall"}}
+		frameworkResource.providerClient = client
+		state, err := frameworkResource.getStateValue(context.Background(), "42", map[string]interface{}{"id": "42", "name": "my_firewall", "label": "some label"}, tftypes.Value{})
+		So(err, ShouldBeNil)
+		plan, err := frameworkResource.getStateValue(context.Background(), "42", map[string]interface{}{"id": "42", "name": "my_new_firewall"}, tftypes.Value{})
+		So(err, ShouldBeNil)
+		Convey("When Update is called", func() {
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: frameworkResource.schema, Raw: state}}
+			frameworkResource.Update(context.Background(), resource.UpdateRequest{
+				State: tfsdk.State{Schema: frameworkResource.schema, Raw: state},
+				Plan:  tfsdk.Plan{Schema: frameworkResource.schema, Raw: plan},
+			}, resp)
+			Convey("Then the diagnostics should not contain errors", func() {
+				So(resp.Diagnostics.HasError(), ShouldBeFalse)
+			})
+			Convey("And the client should have received a merge patch containing only the changes", func() {
+				So(client.patchPayloadReceived, ShouldResemble, map[string]interface{}{"name": "my_new_firewall", "label": nil})
+			})
+			Convey("And the state should contain the values returned by the API", func() {
+				var name string
+				So(resp.State.GetAttribute(context.Background(), path.Root("name"), &name).HasError(), ShouldBeFalse)
+				So(name, ShouldEqual, "my_new_firewall")
+			})
+		})
+	})
+}
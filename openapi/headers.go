@@ -5,4 +5,15 @@ const (
 	authorizationHeader = "Authorization"
 	userAgentHeader     = "User-Agent"
 	contentType         = "Content-Type"
+	acceptHeader        = "Accept"
+)
+
+// Media types
+const (
+	mediaTypeJSON              = "application/json"
+	mediaTypeMultipartFormData = "multipart/form-data"
+	mediaTypeFormURLEncoded    = "application/x-www-form-urlencoded"
+	mediaTypeXML               = "application/xml"
+	mediaTypeTextXML           = "text/xml"
+	mediaTypeOctetStream       = "application/octet-stream"
 )
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger defines the interface used by the OpenAPI provider to log messages. Each method receives the message to be
+// logged and an optional list of alternating key-value pairs containing structured fields relevant to the message
+// (e,g: Debug("performing request", "method", "GET", "url", "https://api.com/v1/cdns")). Embedders can provide their
+// own implementation via ProviderOpenAPI.Logger to route the provider logs into their own logging stack. If no logger
+// is provided, the standard logger will be used.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// stdLogger is the default Logger implementation which writes the messages using the standard logger prefixed with
+// the log level (e,g: [DEBUG]) as expected by Terraform. The fields are appended to the message as key=value
+type stdLogger struct{}
+
+func (l stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.print("DEBUG", msg, keysAndValues)
+}
+
+func (l stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.print("INFO", msg, keysAndValues)
+}
+
+func (l stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.print("WARN", msg, keysAndValues)
+}
+
+func (l stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.print("ERROR", msg, keysAndValues)
+}
+
+func (l stdLogger) print(level, msg string, keysAndValues []interface{}) {
+	log.Printf("[%s] %s%s", level, msg, formatLogFields(keysAndValues))
+}
+
+// formatLogFields returns the key-value pairs formatted as ' key=value'. If the number of elements is odd the last key
+// will be logged with a '<missing>' value
+func formatLogFields(keysAndValues []interface{}) string {
+	if len(keysAndValues) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var value interface{} = "<missing>"
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		sb.WriteString(fmt.Sprintf(" %v=%v", keysAndValues[i], value))
+	}
+	return sb.String()
+}
+
+// loggerOrDefault returns the logger passed in or the default standard logger if the logger is nil
+func loggerOrDefault(logger Logger) Logger {
+	if logger == nil {
+		return stdLogger{}
+	}
+	return logger
+}
@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type loggedMessage struct {
+	level         string
+	msg           string
+	keysAndValues []interface{}
+}
+
+// loggerStub is a Logger implementation that records the messages logged so they can be asserted in the tests
+type loggerStub struct {
+	messages []loggedMessage
+}
+
+func (l *loggerStub) Debug(msg string, keysAndValues ...interface{}) {
+	l.messages = append(l.messages, loggedMessage{"DEBUG", msg, keysAndValues})
+}
+
+func (l *loggerStub) Info(msg string, keysAndValues ...interface{}) {
+	l.messages = append(l.messages, loggedMessage{"INFO", msg, keysAndValues})
+}
+
+func (l *loggerStub) Warn(msg string, keysAndValues ...interface{}) {
+	l.messages = append(l.messages, loggedMessage{"WARN", msg, keysAndValues})
+}
+
+func (l *loggerStub) Error(msg string, keysAndValues ...interface{}) {
+	l.messages = append(l.messages, loggedMessage{"ERROR", msg, keysAndValues})
+}
+
+func (l *loggerStub) containsMessage(level, msg string) bool {
+	for _, m := range l.messages {
+		if m.level == level && m.msg == msg {
+			return true
+		}
+	}
+	return false
+}
+
+func TestStdLogger(t *testing.T) {
+	testCases := []struct {
+		name        string
+		logFunc     func(l Logger)
+		expectedLog string
+	}{
+		{
+			name:        "debug message with no fields",
+			logFunc:     func(l Logger) { l.Debug("some message") },
+			expectedLog: "[DEBUG] some message\n",
+		},
+		{
+			name:        "info message with fields",
+			logFunc:     func(l Logger) { l.Info("some message", "resource", "cdn_v1", "id", 1234) },
+			expectedLog: "[INFO] some message resource=cdn_v1 id=1234\n",
+		},
+		{
+			name:        "warn message with a key missing the value",
+			logFunc:     func(l Logger) { l.Warn("some message", "resource") },
+			expectedLog: "[WARN] some message resource=<missing>\n",
+		},
+		{
+			name:        "error message with fields",
+			logFunc:     func(l Logger) { l.Error("some message", "resource", "cdn_v1") },
+			expectedLog: "[ERROR] some message resource=cdn_v1\n",
+		},
+	}
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		log.SetFlags(0)
+		tc.logFunc(stdLogger{})
+		assert.Equal(t, tc.expectedLog, buf.String(), tc.name)
+	}
+	log.SetFlags(log.LstdFlags)
+}
+
+func TestLoggerOrDefault(t *testing.T) {
+	assert.Equal(t, stdLogger{}, loggerOrDefault(nil))
+	logger := &loggerStub{}
+	assert.Equal(t, logger, loggerOrDefault(logger))
+}
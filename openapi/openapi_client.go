@@ -1,11 +1,18 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
-	"log"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"net/url"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 
@@ -18,6 +25,7 @@ const (
 	httpGet    httpMethodSupported = "GET"
 	httpPost   httpMethodSupported = "POST"
 	httpPut    httpMethodSupported = "PUT"
+	httpPatch  httpMethodSupported = "PATCH"
 	httpDelete httpMethodSupported = "DELETE"
 )
 
@@ -25,9 +33,15 @@ const (
 type ClientOpenAPI interface {
 	Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	Patch(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 	Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error)
+	DeleteWithPayload(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error)
+	PreDelete(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error)
+	PerformAction(resource SpecResource, action *specResourceAction, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error)
 	List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	ListWithQueryParameters(resource SpecResource, queryParameters map[string][]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
+	GetAsyncOperation(resource SpecResource, operationURL string, responsePayload interface{}, parentIDs ...string) (*http.Response, error)
 }
 
 // ProviderClient defines a client that is configured based on the OpenAPI server side documentation
@@ -38,6 +52,12 @@ type ProviderClient struct {
 	httpClient                  http_goclient.HttpClientIface
 	providerConfiguration       providerConfiguration
 	apiAuthenticator            specAuthenticator
+	// userAgentSuffix is appended to the default user agent sent in all the API requests
+	userAgentSuffix string
+	// retryConfiguration contains the retry policy configured in the plugin configuration, nil if the requests should
+	// not be retried (unless the operations configure their own retry policy)
+	retryConfiguration *RetryConfiguration
+	logger             Logger
 }
 
 // Post performs a POST request to the server API based on the resource configuration and the payload passed in
@@ -60,6 +80,17 @@ func (o *ProviderClient) Put(resource SpecResource, id string, requestPayload in
 	return o.performRequest(httpPut, resourceURL, operation, requestPayload, responsePayload)
 }
 
+// Patch performs a PATCH request to the server API based on the resource configuration and the patch document passed in.
+// The content type of the request depends on the update strategy configured in the PATCH operation
+func (o *ProviderClient) Patch(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	operation := resource.getResourceOperations().Patch
+	return o.performRequest(httpPatch, resourceURL, operation, requestPayload, responsePayload)
+}
+
 // Get performs a GET request to the server API based on the resource configuration and the resource instance id passed in
 func (o *ProviderClient) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
@@ -72,26 +103,160 @@ func (o *ProviderClient) Get(resource SpecResource, id string, responsePayload i
 
 // List performs a GET request to the root level endpoint of the resource (e,g: GET /v1/groups)
 func (o *ProviderClient) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return o.ListWithQueryParameters(resource, nil, responsePayload, parentIDs...)
+}
+
+// ListWithQueryParameters performs a GET request to the root level endpoint of the resource appending the given query
+// parameters (e,g: GET /v1/groups?label=my_label). The query parameters take preference over the static query
+// parameters configured for the operation. Query parameters with multiple values are encoded using the collectionFormat
+// of the List operation query parameter. If the operation is paginated and the response payload is a list items
+// stream, all the pages are fetched
+func (o *ProviderClient) ListWithQueryParameters(resource SpecResource, queryParameters map[string][]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceURL(resource, parentIDs)
 	if err != nil {
 		return nil, err
 	}
 	operation := resource.getResourceOperations().List
+	var names []string
+	for name := range queryParameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resourceURL = appendQueryParameters(resourceURL, operation.getQueryParameter(name, queryParameters[name]))
+	}
+	if stream, ok := responsePayload.(*listItemsStream); ok && operation != nil && operation.pagination != nil {
+		return o.listPages(resourceURL, operation, stream)
+	}
 	return o.performRequest(httpGet, resourceURL, operation, nil, responsePayload)
 }
 
+// GetAsyncOperation performs a GET request to the asynchronous operation URL returned by the API for the resource. If the
+// operation URL is relative, it is resolved against the resource URL. The request is configured as the resource GET
+// operation (e,g: security schemes and headers)
+func (o *ProviderClient) GetAsyncOperation(resource SpecResource, operationURL string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	u, err := url.Parse(operationURL)
+	if err != nil {
+		return nil, fmt.Errorf("asynchronous operation URL '%s' is not valid: %s", operationURL, err)
+	}
+	if !u.IsAbs() {
+		resourceURL, err := o.getResourceURL(resource, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+		base, err := url.Parse(resourceURL)
+		if err != nil {
+			return nil, err
+		}
+		operationURL = base.ResolveReference(u).String()
+	}
+	operation := resource.getResourceOperations().Get
+	if operation == nil {
+		operation = &specResourceOperation{}
+	}
+	return o.performRequest(httpGet, operationURL, operation, nil, responsePayload)
+}
+
 // Delete performs a DELETE request to the server API based on the resource configuration and the resource instance id passed in
 func (o *ProviderClient) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	return o.DeleteWithPayload(resource, id, nil, parentIDs...)
+}
+
+// DeleteWithPayload performs a DELETE request to the server API based on the resource configuration and the resource
+// instance id passed in, sending the given payload in the request body (no body is sent if the payload is nil)
+func (o *ProviderClient) DeleteWithPayload(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
 	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
 	if err != nil {
 		return nil, err
 	}
 	operation := resource.getResourceOperations().Delete
-	return o.performRequest(httpDelete, resourceURL, operation, nil, nil)
+	return o.performRequest(httpDelete, resourceURL, operation, requestPayload, nil)
+}
+
+// PreDelete performs the request of the operation that must be called before deleting the resource instance id passed
+// in ('x-terraform-pre-delete-operation' extension of the resource DELETE operation), e,g: POST /v1/resource/{id}/detach
+func (o *ProviderClient) PreDelete(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	deleteOperation := resource.getResourceOperations().Delete
+	if deleteOperation == nil || deleteOperation.preDeleteOperation == nil {
+		return nil, fmt.Errorf("resource '%s' does not define a pre delete operation", resource.getResourceName())
+	}
+	preDeleteOperation := deleteOperation.preDeleteOperation
+	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(preDeleteOperation.method, resourceURL+preDeleteOperation.pathSuffix, preDeleteOperation.operation, requestPayload, nil)
 }
 
+// PerformAction performs the request of the given action ('x-terraform-resource-action' extension) on the resource
+// instance id passed in, e,g: POST /v1/servers/{id}/reboot
+func (o *ProviderClient) PerformAction(resource SpecResource, action *specResourceAction, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	resourceURL, err := o.getResourceIDURL(resource, parentIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	return o.performRequest(action.method, resourceURL+action.pathSuffix, action.operation, requestPayload, nil)
+}
+
+// performRequest sends the request retrying it as per the retry policy that applies to the operation while the API
+// responds with a retryable status code. The response of the last attempt is returned
 func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
-	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, operation.SecuritySchemes, o.providerConfiguration)
+	resp, err := o.sendRequestRefreshingCredentials(method, resourceURL, operation, requestPayload, responsePayload)
+	retryConfiguration := operation.getRetryConfiguration(o.retryConfiguration)
+	if retryConfiguration == nil {
+		return resp, err
+	}
+	for retry := 0; retry < retryConfiguration.getMaxRetries() && err == nil && resp != nil && retryConfiguration.isRetryable(resp.StatusCode); retry++ {
+		wait := retryConfiguration.getWaitTime(retry, resp, time.Now())
+		o.getLogger().Debug(fmt.Sprintf("%s %s returned %d, retrying the request in %s (retry %d/%d)", method, resourceURL, resp.StatusCode, wait, retry+1, retryConfiguration.getMaxRetries()), "method", method, "url", resourceURL, "status_code", resp.StatusCode)
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(wait)
+		resp, err = o.sendRequestRefreshingCredentials(method, resourceURL, operation, requestPayload, responsePayload)
+	}
+	return resp, err
+}
+
+// sendRequestRefreshingCredentials sends the request retrying it once with refreshed credentials if the API responds
+// with 401 Unauthorized and any of the authenticators used to authenticate the request supports refreshing the credentials
+func (o *ProviderClient) sendRequestRefreshingCredentials(method httpMethodSupported, resourceURL string, operation *specResourceOperation, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	reqContext, err := o.prepareRequest(method, resourceURL, operation)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.sendRequest(method, operation, reqContext, requestPayload, responsePayload)
+	if err == nil && resp != nil && resp.StatusCode == http.StatusUnauthorized && invalidateRefreshableAuthenticators(reqContext.authenticators) {
+		o.getLogger().Debug(fmt.Sprintf("%s %s returned %d, retrying the request with refreshed credentials", method, resourceURL, resp.StatusCode), "method", method, "url", resourceURL)
+		if resp.Body != nil {
+			resp.Body.Close()
+		}
+		if reqContext, err = o.prepareRequest(method, resourceURL, operation); err != nil {
+			return nil, err
+		}
+		return o.sendRequest(method, operation, reqContext, requestPayload, responsePayload)
+	}
+	return resp, err
+}
+
+// invalidateRefreshableAuthenticators invalidates the credentials of the given authenticators that support refreshing
+// them (e,g: token command authenticators). Returns true if any authenticator was invalidated; false otherwise
+func invalidateRefreshableAuthenticators(authenticators []specAPIKeyAuthenticator) bool {
+	invalidated := false
+	for _, authenticator := range authenticators {
+		if refreshable, ok := authenticator.(refreshableAuthenticator); ok {
+			refreshable.invalidate()
+			invalidated = true
+		}
+	}
+	return invalidated
+}
+
+// prepareRequest returns the auth context of the request containing the URL (including the configured query parameters)
+// and the headers the request should be sent with, authenticated as per the security schemes of the operation
+func (o *ProviderClient) prepareRequest(method httpMethodSupported, resourceURL string, operation *specResourceOperation) (*authContext, error) {
+	resourceURL = o.appendConfiguredQueryParameters(resourceURL, operation)
+	reqContext, err := o.apiAuthenticator.prepareAuth(resourceURL, o.providerConfiguration.selectSecuritySchemes(operation.getSecurityRequirements()), o.providerConfiguration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
@@ -100,26 +265,216 @@ func (o *ProviderClient) performRequest(method httpMethodSupported, resourceURL
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure the API request for %s %s: %s", method, resourceURL, err)
 	}
-	log.Printf("[DEBUG] Performing %s %s", method, reqContext.url)
+	o.getLogger().Debug(fmt.Sprintf("Performing %s %s", method, reqContext.url), "method", method, "url", reqContext.url)
 
-	userAgentHeader := version.BuildUserAgent(runtime.GOOS, runtime.GOARCH)
+	userAgentHeader := version.BuildUserAgentWithSuffix(runtime.GOOS, runtime.GOARCH, o.userAgentSuffix)
 	o.appendUserAgentHeader(reqContext.headers, userAgentHeader)
 
 	o.logHeadersSafely(reqContext.headers)
+	return reqContext, nil
+}
+
+func (o *ProviderClient) sendRequest(method httpMethodSupported, operation *specResourceOperation, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	// operations that do not consume or produce application/json send and read XML documents, read binary blobs or send
+	// the request payload as a form
+	if _, isStream := responsePayload.(*listItemsStream); operation.usesXML() && !isStream && (method == httpPost || method == httpPut || method == httpGet) {
+		return o.sendXMLRequest(method, operation, reqContext, requestPayload, responsePayload)
+	}
+	if operation.returnsBinary() && method == httpGet && responsePayload != nil {
+		return o.getBinary(reqContext, responsePayload)
+	}
+	if operation.requestMediaType != "" && (method == httpPost || method == httpPut) {
+		return o.sendFormRequest(method, operation.requestMediaType, reqContext, requestPayload, responsePayload)
+	}
 
+	// the response bodies are decoded once the response is received rather than by the http client so the non successful
+	// responses are always returned (e,g: 429 Too Many Requests with no body or 502 Bad Gateway HTML pages) and can be
+	// retried or reported as per their status code
 	switch method {
 	case httpPost:
-		return o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		resp, err := o.httpClient.PostJson(reqContext.url, reqContext.headers, requestPayload, nil)
+		return decodeResponse(method, reqContext.url, resp, err, responsePayload)
 	case httpPut:
-		return o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, &responsePayload)
+		resp, err := o.httpClient.PutJson(reqContext.url, reqContext.headers, requestPayload, nil)
+		return decodeResponse(method, reqContext.url, resp, err, responsePayload)
+	case httpPatch:
+		patchClient, err := o.getPatchClient()
+		if err != nil {
+			return nil, err
+		}
+		reqContext.headers[contentType] = operation.getPatchContentType()
+		resp, err := patchClient.Patch(reqContext.url, reqContext.headers, requestPayload, nil)
+		return decodeResponse(method, reqContext.url, resp, err, responsePayload)
 	case httpGet:
-		return o.httpClient.Get(reqContext.url, reqContext.headers, &responsePayload)
+		if stream, ok := responsePayload.(*listItemsStream); ok {
+			return o.getStream(reqContext, stream)
+		}
+		// conditional reads might respond with 304 Not Modified and no body
+		if _, ok := reqContext.headers[ifNoneMatchHeader]; ok && responsePayload != nil {
+			return o.getIfModified(reqContext, responsePayload)
+		}
+		resp, err := o.httpClient.Get(reqContext.url, reqContext.headers, nil)
+		return decodeResponse(method, reqContext.url, resp, err, responsePayload)
 	case httpDelete:
-		return o.httpClient.Delete(reqContext.url, reqContext.headers)
+		if requestPayload == nil {
+			return o.httpClient.Delete(reqContext.url, reqContext.headers)
+		}
+		deleteClient, err := o.getDeleteWithBodyClient()
+		if err != nil {
+			return nil, err
+		}
+		return deleteClient.DeleteWithBody(reqContext.url, reqContext.headers, requestPayload)
 	}
 	return nil, fmt.Errorf("method '%s' not supported", method)
 }
 
+// decodeResponse decodes the JSON body of the successful responses into the response payload. Empty bodies (e,g: 201
+// Created with a Location header) are not considered an error. The non successful responses are returned as is, as well
+// as the responses of the requests that do not expect a payload (e,g: health check responses which might not be JSON)
+func decodeResponse(method httpMethodSupported, url string, resp *http.Response, err error, responsePayload interface{}) (*http.Response, error) {
+	if err != nil || resp == nil || resp.Body == nil || responsePayload == nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if len(bytes.TrimSpace(body)) == 0 {
+		return resp, nil
+	}
+	if err := json.Unmarshal(body, &responsePayload); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for request = '%s %s'. Response = '%s'", err, method, url, resp.Status)
+	}
+	return resp, nil
+}
+
+// getPatchClient returns the http client used to send PATCH requests since the http_goclient.HttpClientIface does not
+// support them
+func (o *ProviderClient) getPatchClient() (httpPatchClient, error) {
+	switch httpClient := o.httpClient.(type) {
+	case httpPatchClient:
+		return httpClient, nil
+	case *http_goclient.HttpClient:
+		return &patchHTTPClient{httpClient}, nil
+	}
+	return nil, fmt.Errorf("method '%s' not supported by the http client", httpPatch)
+}
+
+// getDeleteWithBodyClient returns the http client used to send DELETE requests with a body since the
+// http_goclient.HttpClientIface does not support them
+func (o *ProviderClient) getDeleteWithBodyClient() (httpDeleteWithBodyClient, error) {
+	switch httpClient := o.httpClient.(type) {
+	case httpDeleteWithBodyClient:
+		return httpClient, nil
+	case *http_goclient.HttpClient:
+		return &deleteHTTPClient{httpClient}, nil
+	}
+	return nil, fmt.Errorf("method '%s' with request body not supported by the http client", httpDelete)
+}
+
+// getStream performs the GET request without buffering the response body so the list items can be decoded by the stream
+// as they are read. The body of non successful responses is left untouched so the caller can still read the error
+// returned by the API
+func (o *ProviderClient) getStream(reqContext *authContext, stream *listItemsStream) (*http.Response, error) {
+	resp, err := o.httpClient.Get(reqContext.url, reqContext.headers, nil)
+	if err != nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	if err := stream.decode(resp.Body); err != nil {
+		return nil, fmt.Errorf("GET %s response could not be processed: %s", reqContext.url, err)
+	}
+	resp.Body = http.NoBody
+	return resp, nil
+}
+
+// getIfModified performs the conditional GET request decoding the response body only if the API returns the resource,
+// the 304 Not Modified responses (and the non successful ones) are returned as is
+func (o *ProviderClient) getIfModified(reqContext *authContext, responsePayload interface{}) (*http.Response, error) {
+	resp, err := o.httpClient.Get(reqContext.url, reqContext.headers, nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err := json.Unmarshal(body, responsePayload); err != nil {
+		return nil, fmt.Errorf("GET %s response could not be processed: %s", reqContext.url, err)
+	}
+	return resp, nil
+}
+
+// getBinary performs the GET request of the operations that return binary blobs (application/octet-stream), the blob
+// is returned in the response payload as the base64 encoded value of the binary content property. The responses
+// containing JSON (as per the content type returned by the API) are decoded as usual, while the non successful
+// responses (and the 304 Not Modified ones) are returned as is
+func (o *ProviderClient) getBinary(reqContext *authContext, responsePayload interface{}) (*http.Response, error) {
+	reqContext.headers[acceptHeader] = mediaTypeOctetStream + ", " + mediaTypeJSON
+	resp, err := o.httpClient.Get(reqContext.url, reqContext.headers, nil)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get(contentType)); mediaType == mediaTypeJSON || strings.HasSuffix(mediaType, "+json") {
+		if err := json.Unmarshal(body, responsePayload); err != nil {
+			return nil, fmt.Errorf("GET %s response could not be processed: %s", reqContext.url, err)
+		}
+		return resp, nil
+	}
+	payload, err := json.Marshal(map[string]interface{}{binaryContentPropertyName: base64.StdEncoding.EncodeToString(body)})
+	if err != nil {
+		return nil, err
+	}
+	return resp, json.Unmarshal(payload, responsePayload)
+}
+
+// appendConfiguredQueryParameters appends to the resource URL the default query parameters configured in the provider
+// and the operation query parameters ('x-terraform-query-params' extension), the latter taking preference if both
+// define the same parameter. Parameters already present in the URL are not appended again
+func (o *ProviderClient) appendConfiguredQueryParameters(resourceURL string, operation *specResourceOperation) string {
+	queryParameterValues := map[string][]string{}
+	for name, value := range o.providerConfiguration.getDefaultQueryParams() {
+		queryParameterValues[name] = []string{value}
+	}
+	for name, values := range operation.queryParameters {
+		queryParameterValues[name] = values
+	}
+	if len(queryParameterValues) == 0 {
+		return resourceURL
+	}
+	existingQueryParameters := url.Values{}
+	if u, err := url.Parse(resourceURL); err == nil {
+		existingQueryParameters = u.Query()
+	}
+	var names []string
+	for name := range queryParameterValues {
+		if _, exists := existingQueryParameters[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	queryParameters := make([]queryParameter, len(names))
+	for i, name := range names {
+		queryParameters[i] = operation.getQueryParameter(name, queryParameterValues[name])
+	}
+	return appendQueryParameters(resourceURL, queryParameters...)
+}
+
+// getLogger returns the logger configured in the client or the default logger if none was provided
+func (o ProviderClient) getLogger() Logger {
+	return loggerOrDefault(o.logger)
+}
+
 func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value string) {
 	headers[userAgentHeader] = value
 }
@@ -130,17 +485,26 @@ func (o *ProviderClient) appendUserAgentHeader(headers map[string]string, value
 func (o *ProviderClient) logHeadersSafely(headers map[string]string) {
 	for headerName, headerValue := range headers {
 		if headerValue == "" {
-			log.Printf("[DEBUG] Request Header '%s' sent with empty value :(", headerName)
+			o.getLogger().Debug(fmt.Sprintf("Request Header '%s' sent with empty value :(", headerName), "header", headerName)
 		}
-		log.Printf("[DEBUG] Request Header '%s' sent", headerName)
+		o.getLogger().Debug(fmt.Sprintf("Request Header '%s' sent", headerName), "header", headerName)
 	}
 }
 
 // appendOperationHeaders returns a maps containing the headers passed in and adds whatever headers the operation requires. The values
-// are retrieved from the provider configuration.
+// are retrieved from the provider configuration, or from the resource configuration for the headers that are resource attributes.
 func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderParam, headers map[string]string) error {
 	if operationHeaders != nil && len(operationHeaders) > 0 {
 		for _, headerParam := range operationHeaders {
+			if headerParam.IsResourceAttribute {
+				if headerParam.IsRequired && headerParam.value == "" {
+					return fmt.Errorf("required header '%s' is missing the value. Please make sure the property '%s' is configured with a value in the resource's terraform configuration", headerParam.Name, headerParam.GetHeaderTerraformConfigurationName())
+				}
+				if headerParam.value != "" {
+					headers[headerParam.Name] = headerParam.value
+				}
+				continue
+			}
 			headerValue := o.providerConfiguration.getHeaderValueFor(headerParam)
 			if headerParam.IsRequired && headerValue == "" {
 				return fmt.Errorf("required header '%s' is missing the value. Please make sure the property '%s' is configured with a value in the provider's terraform configuration", headerParam.Name, headerParam.GetHeaderTerraformConfigurationName())
@@ -152,10 +516,9 @@ func (o ProviderClient) appendOperationHeaders(operationHeaders []SpecHeaderPara
 	return nil
 }
 
-func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
-	var host string
-	var err error
-
+// getGlobalHost returns the host defined in the OpenAPI document. If the document is multi-region, the host returned
+// is the one for the region configured by the user (or the default region if the user did not configure any)
+func (o ProviderClient) getGlobalHost() (string, error) {
 	isMultiRegion, _, regions, err := o.openAPIBackendConfiguration.isMultiRegion()
 	if err != nil {
 		return "", err
@@ -170,15 +533,24 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 				return "", err
 			}
 		}
-		host, err = o.openAPIBackendConfiguration.getHostByRegion(region)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		host, err = o.openAPIBackendConfiguration.getHost()
-		if err != nil {
-			return "", err
-		}
+		return o.openAPIBackendConfiguration.getHostByRegion(region)
+	}
+	return o.openAPIBackendConfiguration.getHost()
+}
+
+// getResourceGlobalHost returns the host for the region configured in the resource if any (only supported for
+// multi-region providers); otherwise the global host is returned
+func (o ProviderClient) getResourceGlobalHost(resource SpecResource) (string, error) {
+	if region := getRegion(resource); region != "" {
+		return o.openAPIBackendConfiguration.getHostByRegion(region)
+	}
+	return o.getGlobalHost()
+}
+
+func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string) (string, error) {
+	host, err := o.getResourceGlobalHost(resource)
+	if err != nil {
+		return "", err
 	}
 
 	basePath := o.openAPIBackendConfiguration.getBasePath()
@@ -187,18 +559,33 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		return "", err
 	}
 
+	path := resourceRelativePath
+	if strings.Index(resourceRelativePath, "/") != 0 {
+		path = fmt.Sprintf("/%s", resourceRelativePath)
+	}
+
+	// The api base url takes precedence over any host (including resource host and endpoints overrides) and base path
+	if apiBaseURL := o.providerConfiguration.getAPIBaseURL(); apiBaseURL != "" {
+		o.getLogger().Debug(fmt.Sprintf("provider is configured with api base url override, API calls for resource '%s' will be made against '%s'", resourceRelativePath, apiBaseURL), "resource", resource.getResourceName(), "url", apiBaseURL)
+		return fmt.Sprintf("%s%s", apiBaseURL, path), nil
+	}
+
 	// Fall back to override the host if value is not empty; otherwise global host will be used as usual
 	hostOverride, err := resource.getHost()
 	if err != nil {
 		return "", err
 	}
 	if hostOverride != "" {
-		log.Printf("[INFO] resource '%s' is configured with host override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, hostOverride, host)
+		o.getLogger().Info(fmt.Sprintf("resource '%s' is configured with host override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, hostOverride, host), "resource", resource.getResourceName(), "host", hostOverride)
 		host = hostOverride
 	}
 
-	if endPointHost := o.providerConfiguration.getEndPoint(resource.getResourceName()); endPointHost != "" {
-		log.Printf("[INFO] resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointHost, host)
+	endPointHost := o.providerConfiguration.getEndPoint(resource.getResourceName())
+	if endPointHost == "" {
+		endPointHost = o.providerConfiguration.getTagEndPoint(resource.getResourceTags())
+	}
+	if endPointHost != "" {
+		o.getLogger().Info(fmt.Sprintf("resource '%s' is configured with endpoint override, API calls will be made against '%s' instead of '%s'", resourceRelativePath, endPointHost, host), "resource", resource.getResourceName(), "host", endPointHost)
 		host = endPointHost
 	}
 
@@ -212,11 +599,6 @@ func (o ProviderClient) getResourceURL(resource SpecResource, parentIDs []string
 		return "", err
 	}
 
-	path := resourceRelativePath
-	if strings.Index(resourceRelativePath, "/") != 0 {
-		path = fmt.Sprintf("/%s", resourceRelativePath)
-	}
-
 	if basePath != "" && basePath != "/" {
 		if strings.Index(basePath, "/") == 0 {
 			return fmt.Sprintf("%s://%s%s%s", defaultScheme, host, basePath, path), nil
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+)
+
+const awsContentSha256Header = "X-Amz-Content-Sha256"
+
+// awsSigV4Transport is a http.RoundTripper that signs the requests with AWS Signature Version 4 before delegating them to
+// the next round tripper. Since the signature covers the body and the headers, this transport must be the last one
+// modifying the request before it is sent to the API
+type awsSigV4Transport struct {
+	signer  *v4.Signer
+	region  string
+	service string
+	next    http.RoundTripper
+	// now returns the time used to sign the requests, configurable for testing purposes
+	now func() time.Time
+}
+
+// newAWSSigV4Transport returns an awsSigV4Transport that signs the requests using the configuration provided and
+// delegates them to the transport provided. If the transport provided is nil the default transport will be used.
+func newAWSSigV4Transport(configuration awsSigV4Configuration, transport http.RoundTripper) *awsSigV4Transport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &awsSigV4Transport{
+		signer:  v4.NewSigner(credentials.NewStaticCredentials(configuration.AccessKeyID, configuration.SecretAccessKey, configuration.SessionToken)),
+		region:  configuration.Region,
+		service: configuration.Service,
+		next:    transport,
+		now:     time.Now,
+	}
+}
+
+// RoundTrip signs a copy of the request so the original request is not modified as per the http.RoundTripper contract.
+// The payload hash is always sent in the X-Amz-Content-Sha256 header so the API can verify the body received
+func (t *awsSigV4Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	signedReq := new(http.Request)
+	*signedReq = *req
+	signedReq.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		signedReq.Header[k] = append([]string(nil), v...)
+	}
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body for %s %s: %s", req.Method, req.URL, err)
+		}
+	}
+	payloadHash := sha256.Sum256(body)
+	signedReq.Header.Set(awsContentSha256Header, hex.EncodeToString(payloadHash[:]))
+
+	if _, err := t.signer.Sign(signedReq, bytes.NewReader(body), t.service, t.region, t.now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request %s %s with AWS SigV4: %s", req.Method, req.URL, err)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		signedReq.Body = req.Body
+	} else {
+		signedReq.Body = newRequestBody(body)
+		signedReq.GetBody = func() (io.ReadCloser, error) {
+			return newRequestBody(body), nil
+		}
+		signedReq.ContentLength = int64(len(body))
+	}
+	return t.next.RoundTrip(signedReq)
+}
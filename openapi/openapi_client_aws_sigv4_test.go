@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAWSSigV4TransportRoundTrip(t *testing.T) {
+	Convey("Given an aws sigv4 transport with static credentials and an API that records the requests received", t, func() {
+		var receivedHeaders http.Header
+		var receivedBody []byte
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedHeaders = r.Header
+			receivedBody, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		transport := newAWSSigV4Transport(awsSigV4Configuration{AccessKeyID: "AKID", SecretAccessKey: "SECRET", SessionToken: "TOKEN", Region: "us-west-2", Service: "execute-api"}, nil)
+		transport.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+		client := &http.Client{Transport: transport}
+		Convey("When a POST request with a body is sent", func() {
+			body := []byte(`{"label":"some label"}`)
+			req, _ := http.NewRequest(http.MethodPost, api.URL+"/v1/cdns", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the request should contain the signature headers", func() {
+				So(receivedHeaders.Get("X-Amz-Date"), ShouldEqual, "20150830T123600Z")
+				So(receivedHeaders.Get("X-Amz-Security-Token"), ShouldEqual, "TOKEN")
+				So(receivedHeaders.Get(authorizationHeader), ShouldStartWith, "AWS4-HMAC-SHA256 Credential=AKID/20150830/us-west-2/execute-api/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token, Signature=")
+			})
+			Convey("And the request should contain the payload hash of the body", func() {
+				payloadHash := sha256.Sum256(body)
+				So(receivedHeaders.Get(awsContentSha256Header), ShouldEqual, hex.EncodeToString(payloadHash[:]))
+			})
+			Convey("And the body should be sent to the API unchanged", func() {
+				So(string(receivedBody), ShouldEqual, string(body))
+			})
+			Convey("And the original request should not be modified", func() {
+				So(req.Header.Get(authorizationHeader), ShouldBeEmpty)
+				So(req.Header.Get(awsContentSha256Header), ShouldBeEmpty)
+			})
+		})
+		Convey("When a GET request without body is sent", func() {
+			req, _ := http.NewRequest(http.MethodGet, api.URL+"/v1/cdns/someID", nil)
+			_, err := client.Do(req)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the request should contain the payload hash of an empty body", func() {
+				So(receivedHeaders.Get(awsContentSha256Header), ShouldEqual, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+				So(receivedHeaders.Get(authorizationHeader), ShouldStartWith, "AWS4-HMAC-SHA256 Credential=AKID/20150830/us-west-2/execute-api/aws4_request")
+			})
+		})
+		Convey("When the same request is sent twice at the same time", func() {
+			var signatures []string
+			for i := 0; i < 2; i++ {
+				req, _ := http.NewRequest(http.MethodPut, api.URL+"/v1/cdns/someID", bytes.NewReader([]byte(`{"label":"some label"}`)))
+				_, err := client.Do(req)
+				So(err, ShouldBeNil)
+				signatures = append(signatures, receivedHeaders.Get(authorizationHeader))
+			}
+			Convey("Then the signatures should be the same", func() {
+				So(signatures[0], ShouldEqual, signatures[1])
+			})
+			Convey("And the signature should change if the body changes", func() {
+				req, _ := http.NewRequest(http.MethodPut, api.URL+"/v1/cdns/someID", bytes.NewReader([]byte(`{"label":"other label"}`)))
+				_, err := client.Do(req)
+				So(err, ShouldBeNil)
+				So(receivedHeaders.Get(authorizationHeader), ShouldNotEqual, signatures[0])
+			})
+		})
+	})
+}
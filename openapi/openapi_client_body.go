@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// httpBodyClient defines the behaviour expected from http clients that support sending request bodies already encoded
+// (e,g: forms or XML documents), which is not part of the http_goclient.HttpClientIface
+type httpBodyClient interface {
+	SendBody(method, url string, headers map[string]string, body io.Reader, bodyContentType string, out interface{}) (*http.Response, error)
+}
+
+// bodyHTTPClient extends the http_goclient.HttpClient with the ability to send request bodies already encoded
+type bodyHTTPClient struct {
+	*http_goclient.HttpClient
+}
+
+// SendBody issues an HTTP request with the given method to the specified URL including the headers passed in. The
+// content type of the body is set to the body content type given, if any.
+//
+// The 'body' param is the encoded request body, nil if the request has no body.
+// The 'out' param interface is the un-marshall representation of the JSON http response returned, nil if the response
+// body should be returned as is. Empty response bodies are not considered an error
+func (c *bodyHTTPClient) SendBody(method, url string, headers map[string]string, body io.Reader, bodyContentType string, out interface{}) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if bodyContentType != "" {
+		req.Header.Set(contentType, bodyContentType)
+	}
+	resp, err := c.HttpClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s %s %s failed. Response Error: '%s'", req.Method, req.URL, req.Proto, err.Error())
+	}
+	if out == nil {
+		return resp, nil
+	}
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	if len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, out); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for request = '%s %s %s'. Response = '%s'", err.Error(), req.Method, req.URL, req.Proto, resp.Status)
+		}
+	}
+	return resp, nil
+}
+
+// getBodyClient returns the http client used to send the request bodies of the given media type since the
+// http_goclient.HttpClientIface does not support them
+func (o *ProviderClient) getBodyClient(method httpMethodSupported, mediaType string) (httpBodyClient, error) {
+	switch httpClient := o.httpClient.(type) {
+	case httpBodyClient:
+		return httpClient, nil
+	case *http_goclient.HttpClient:
+		return &bodyHTTPClient{httpClient}, nil
+	}
+	return nil, fmt.Errorf("method '%s' with %s body not supported by the http client", method, mediaType)
+}
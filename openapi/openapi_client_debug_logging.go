@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// otfVarDebugHTTP defines the environment variable that enables (or disables, if set to false) the logging of the full
+// API requests and responses regardless of the TF_LOG level
+const otfVarDebugHTTP = "OTF_DEBUG_HTTP"
+const tfLogEnvVar = "TF_LOG"
+
+// httpDebugLoggingMaxBodySize defines the max number of bytes of the request and response bodies that are logged
+const httpDebugLoggingMaxBodySize = 64 * 1024
+
+// defaultSensitiveHeaders contains the headers that are always masked when logging the API requests and responses since
+// they may contain credentials
+var defaultSensitiveHeaders = []string{authorizationHeader, "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Amz-Security-Token"}
+
+// defaultSensitiveBodyFields contains the body fields that are always masked when logging the API requests and responses
+// since they usually contain credentials (e,g: tokens returned by the APIs)
+var defaultSensitiveBodyFields = []string{"access_token", "refresh_token", "id_token", "client_secret", "password"}
+
+// isHTTPDebugLoggingEnabled returns true if the OTF_DEBUG_HTTP environment variable is set to a true value, or if it is
+// not set and Terraform is running with the TF_LOG level DEBUG or TRACE
+func isHTTPDebugLoggingEnabled() bool {
+	if enabled, err := strconv.ParseBool(os.Getenv(otfVarDebugHTTP)); err == nil {
+		return enabled
+	}
+	switch strings.ToUpper(os.Getenv(tfLogEnvVar)) {
+	case "DEBUG", "TRACE":
+		return true
+	}
+	return false
+}
+
+// httpDebugLoggingTransport is a http.RoundTripper that logs the full API requests and responses (method, URL, headers
+// and bodies) at debug level. The headers, query parameters and JSON body fields that may contain credentials are
+// redacted, including the ones used by the security definitions and the sensitive properties of the resources. The
+// bodies are buffered in memory in order to be logged
+type httpDebugLoggingTransport struct {
+	next                 http.RoundTripper
+	sensitiveHeaders     map[string]bool
+	sensitiveQueryParams map[string]bool
+	sensitiveBodyFields  map[string]bool
+	logger               Logger
+}
+
+// newHTTPDebugLoggingTransport returns a httpDebugLoggingTransport that delegates the requests to the transport provided,
+// masking the values of the headers, query parameters and body fields provided in addition to the
+// defaultSensitiveHeaders and defaultSensitiveBodyFields. If the transport provided is nil the default transport will be
+// used.
+func newHTTPDebugLoggingTransport(transport http.RoundTripper, sensitiveHeaders, sensitiveQueryParams, sensitiveBodyFields []string, logger Logger) *httpDebugLoggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t := &httpDebugLoggingTransport{
+		next:                 transport,
+		sensitiveHeaders:     map[string]bool{},
+		sensitiveQueryParams: map[string]bool{},
+		sensitiveBodyFields:  map[string]bool{},
+		logger:               logger,
+	}
+	for _, header := range append(append([]string{}, defaultSensitiveHeaders...), sensitiveHeaders...) {
+		t.sensitiveHeaders[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, queryParam := range sensitiveQueryParams {
+		t.sensitiveQueryParams[queryParam] = true
+	}
+	for _, field := range append(append([]string{}, defaultSensitiveBodyFields...), sensitiveBodyFields...) {
+		t.sensitiveBodyFields[field] = true
+	}
+	return t
+}
+
+// RoundTrip logs the request, delegates it to the next round tripper and logs the response received. The body of the
+// request is read from a copy of the request so the original request is not modified as per the http.RoundTripper
+// contract
+func (t *httpDebugLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	loggedReq := new(http.Request)
+	*loggedReq = *req
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body for %s %s: %s", req.Method, req.URL, err)
+		}
+		requestBody = body
+		loggedReq.Body = newRequestBody(body)
+		loggedReq.GetBody = func() (io.ReadCloser, error) {
+			return newRequestBody(body), nil
+		}
+	}
+	url := t.redactURL(req)
+	t.getLogger().Debug(fmt.Sprintf("HTTP request %s %s\n%s", req.Method, url, t.format(req.Header, requestBody)), "method", req.Method, "url", url)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(loggedReq)
+	if err != nil {
+		t.getLogger().Debug(fmt.Sprintf("HTTP request %s %s failed (time:%s): %s", req.Method, url, time.Since(start), err), "method", req.Method, "url", url)
+		return nil, err
+	}
+	var responseBody []byte
+	if resp.Body != nil {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body for %s %s: %s", req.Method, req.URL, err)
+		}
+		responseBody = body
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	t.getLogger().Debug(fmt.Sprintf("HTTP response %s %s returned %s (time:%s)\n%s", req.Method, url, resp.Status, time.Since(start), t.format(resp.Header, responseBody)), "method", req.Method, "url", url, "status_code", resp.StatusCode)
+	return resp, nil
+}
+
+// getLogger returns the logger configured in the transport or the default logger if none was provided
+func (t *httpDebugLoggingTransport) getLogger() Logger {
+	return loggerOrDefault(t.logger)
+}
+
+// redactURL returns the request URL with the values of the sensitive query parameters redacted
+func (t *httpDebugLoggingTransport) redactURL(req *http.Request) string {
+	if req.URL == nil {
+		return ""
+	}
+	query := req.URL.Query()
+	redacted := false
+	for name := range query {
+		if t.sensitiveQueryParams[name] {
+			query.Set(name, redactedValue)
+			redacted = true
+		}
+	}
+	if !redacted {
+		return req.URL.String()
+	}
+	u := *req.URL
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// format returns the headers (sorted by name and with the sensitive values redacted) followed by the body provided (see
+// redactBody)
+func (t *httpDebugLoggingTransport) format(headers http.Header, body []byte) string {
+	var names []string
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if t.sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = redactedValue
+			}
+			sb.WriteString(fmt.Sprintf("%s: %s\n", name, value))
+		}
+	}
+	if len(body) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(t.redactBody(body))
+	}
+	return sb.String()
+}
+
+// redactBody returns the body to log: JSON bodies are logged with the values of the sensitive fields redacted, while
+// the rest of the bodies (e,g: forms, XML documents or binary blobs) are not logged, only their size, since they can not
+// be redacted. The body logged is truncated if it exceeds the httpDebugLoggingMaxBodySize
+func (t *httpDebugLoggingTransport) redactBody(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return fmt.Sprintf("(body of %d bytes not logged since it is not JSON)", len(body))
+	}
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(t.redactJSONValue(value)); err != nil {
+		return fmt.Sprintf("(body of %d bytes not logged since it is not JSON)", len(body))
+	}
+	redactedBody := bytes.TrimSuffix(buffer.Bytes(), []byte("\n"))
+	if len(redactedBody) > httpDebugLoggingMaxBodySize {
+		return fmt.Sprintf("%s... (truncated, %d bytes in total)", redactedBody[:httpDebugLoggingMaxBodySize], len(body))
+	}
+	return string(redactedBody)
+}
+
+// redactJSONValue returns the given JSON value with the values of the sensitive fields redacted, including the ones of
+// the nested objects and lists
+func (t *httpDebugLoggingTransport) redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			if t.sensitiveBodyFields[key] && fieldValue != nil {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = t.redactJSONValue(fieldValue)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = t.redactJSONValue(item)
+		}
+		return redacted
+	}
+	return value
+}
@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsHTTPDebugLoggingEnabled(t *testing.T) {
+	testCases := []struct {
+		debugHTTP       string
+		tfLog           string
+		expectedEnabled bool
+	}{
+		{debugHTTP: "", tfLog: "", expectedEnabled: false},
+		{debugHTTP: "true", tfLog: "", expectedEnabled: true},
+		{debugHTTP: "1", tfLog: "", expectedEnabled: true},
+		{debugHTTP: "", tfLog: "DEBUG", expectedEnabled: true},
+		{debugHTTP: "", tfLog: "trace", expectedEnabled: true},
+		{debugHTTP: "", tfLog: "INFO", expectedEnabled: false},
+		{debugHTTP: "false", tfLog: "DEBUG", expectedEnabled: false},
+		{debugHTTP: "not-a-bool", tfLog: "", expectedEnabled: false},
+	}
+	for _, tc := range testCases {
+		Convey(fmt.Sprintf("Given the environment variables OTF_DEBUG_HTTP='%s' and TF_LOG='%s'", tc.debugHTTP, tc.tfLog), t, func() {
+			os.Setenv(otfVarDebugHTTP, tc.debugHTTP)
+			os.Setenv(tfLogEnvVar, tc.tfLog)
+			defer os.Unsetenv(otfVarDebugHTTP)
+			defer os.Unsetenv(tfLogEnvVar)
+			Convey("When isHTTPDebugLoggingEnabled is called", func() {
+				enabled := isHTTPDebugLoggingEnabled()
+				Convey("Then the value returned should be the expected one", func() {
+					So(enabled, ShouldEqual, tc.expectedEnabled)
+				})
+			})
+		})
+	}
+}
+
+func TestHTTPDebugLoggingTransportRoundTrip(t *testing.T) {
+	Convey("Given a debug logging transport configured with sensitive headers and query params and an API that echoes the request body", t, func() {
+		var receivedBody, receivedAPIKey string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			receivedBody = string(body)
+			receivedAPIKey = r.Header.Get("X-API-Key")
+			w.Header().Set("Set-Cookie", "session=someSession")
+			w.Header().Set("X-Request-Id", "someRequestID")
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"someID"}`))
+		}))
+		defer api.Close()
+		logger := &loggerStub{}
+		client := &http.Client{Transport: newHTTPDebugLoggingTransport(nil, []string{"x-api-key"}, []string{"access_token"}, nil, logger)}
+		Convey("When a request containing credentials is performed", func() {
+			req, _ := http.NewRequest(http.MethodPost, api.URL+"/v1/cdns?access_token=superSecretToken&label=some", strings.NewReader(`{"label":"some"}`))
+			req.Header.Set("X-API-Key", "superSecretAPIKey")
+			req.Header.Set(authorizationHeader, "Bearer superSecretBearer")
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := client.Do(req)
+			So(err, ShouldBeNil)
+			defer resp.Body.Close()
+			responseBody, _ := ioutil.ReadAll(resp.Body)
+			Convey("Then the request should be sent unmodified to the API", func() {
+				So(receivedBody, ShouldEqual, `{"label":"some"}`)
+				So(receivedAPIKey, ShouldEqual, "superSecretAPIKey")
+			})
+			Convey("And the response body should still be readable", func() {
+				So(string(responseBody), ShouldEqual, `{"id":"someID"}`)
+			})
+			Convey("And the request and the response should have been logged with the credentials redacted", func() {
+				So(logger.messages, ShouldHaveLength, 2)
+				request, response := logger.messages[0].msg, logger.messages[1].msg
+				So(request, ShouldStartWith, fmt.Sprintf("HTTP request POST %s/v1/cdns?access_token=%%28sensitive%%29&label=some", api.URL))
+				So(request, ShouldContainSubstring, "X-Api-Key: (sensitive)")
+				So(request, ShouldContainSubstring, "Authorization: (sensitive)")
+				So(request, ShouldContainSubstring, "Content-Type: application/json")
+				So(request, ShouldEndWith, "\n\n"+`{"label":"some"}`)
+				So(response, ShouldContainSubstring, "returned 201 Created")
+				So(response, ShouldContainSubstring, "Set-Cookie: (sensitive)")
+				So(response, ShouldContainSubstring, "X-Request-Id: someRequestID")
+				So(response, ShouldEndWith, `{"id":"someID"}`)
+				for _, m := range logger.messages {
+					So(m.msg, ShouldNotContainSubstring, "superSecret")
+				}
+			})
+		})
+	})
+	Convey("Given a debug logging transport and an API returning a body bigger than the max size logged", t, func() {
+		largeBody := fmt.Sprintf(`{"label":"%s"}`, strings.Repeat("a", httpDebugLoggingMaxBodySize))
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(largeBody))
+		}))
+		defer api.Close()
+		logger := &loggerStub{}
+		client := &http.Client{Transport: newHTTPDebugLoggingTransport(nil, nil, nil, nil, logger)}
+		Convey("When a request is performed", func() {
+			resp, err := client.Get(api.URL)
+			So(err, ShouldBeNil)
+			defer resp.Body.Close()
+			responseBody, _ := ioutil.ReadAll(resp.Body)
+			Convey("Then the whole response body should be returned", func() {
+				So(string(responseBody), ShouldEqual, largeBody)
+			})
+			Convey("And the response body logged should be truncated", func() {
+				So(logger.messages[1].msg, ShouldEndWith, fmt.Sprintf("... (truncated, %d bytes in total)", len(largeBody)))
+			})
+		})
+	})
+	Convey("Given a debug logging transport configured with the sensitive properties of the resources", t, func() {
+		var receivedBody string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			receivedBody = string(body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"someID","label":"some","admin_password":"superSecretPassword","credentials":{"access_token":"superSecretToken"}}`))
+		}))
+		defer api.Close()
+		logger := &loggerStub{}
+		client := &http.Client{Transport: newHTTPDebugLoggingTransport(nil, nil, nil, []string{"admin_password"}, logger)}
+		Convey("When a POST request with a body containing sensitive fields is performed", func() {
+			requestBody := `{"label":"some","admin_password":"superSecretPassword","tags":[{"password":"superSecretTagPassword"}]}`
+			resp, err := client.Post(api.URL+"/v1/cdns", "application/json", strings.NewReader(requestBody))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			Convey("Then the request body should be sent unmodified to the API", func() {
+				So(receivedBody, ShouldEqual, requestBody)
+			})
+			Convey("And the request and the response bodies should have been logged with the sensitive fields redacted", func() {
+				So(logger.messages, ShouldHaveLength, 2)
+				So(logger.messages[0].msg, ShouldEndWith, "\n\n"+`{"admin_password":"(sensitive)","label":"some","tags":[{"password":"(sensitive)"}]}`)
+				So(logger.messages[1].msg, ShouldEndWith, `{"admin_password":"(sensitive)","credentials":{"access_token":"(sensitive)"},"id":"someID","label":"some"}`)
+				for _, m := range logger.messages {
+					So(m.msg, ShouldNotContainSubstring, "superSecret")
+				}
+			})
+		})
+		Convey("When a request with a body that is not JSON is performed", func() {
+			resp, err := client.Post(api.URL+"/v1/cdns", "application/x-www-form-urlencoded", strings.NewReader("admin_password=superSecretPassword"))
+			So(err, ShouldBeNil)
+			resp.Body.Close()
+			Convey("Then only the size of the request body should have been logged", func() {
+				So(logger.messages[0].msg, ShouldEndWith, "(body of 34 bytes not logged since it is not JSON)")
+				So(logger.messages[0].msg, ShouldNotContainSubstring, "superSecret")
+			})
+		})
+	})
+	Convey("Given a debug logging transport and an API that is not reachable", t, func() {
+		logger := &loggerStub{}
+		client := &http.Client{Transport: newHTTPDebugLoggingTransport(nil, nil, nil, nil, logger)}
+		Convey("When a request is performed", func() {
+			_, err := client.Get("http://127.0.0.1:0/v1/cdns")
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the failure should have been logged", func() {
+				So(logger.messages, ShouldHaveLength, 2)
+				So(logger.messages[1].msg, ShouldStartWith, "HTTP request GET http://127.0.0.1:0/v1/cdns failed")
+			})
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// httpDeleteWithBodyClient defines the behaviour expected from http clients that support DELETE requests with a body,
+// which is not part of the http_goclient.HttpClientIface
+type httpDeleteWithBodyClient interface {
+	DeleteWithBody(url string, headers map[string]string, in interface{}) (*http.Response, error)
+}
+
+// deleteHTTPClient extends the http_goclient.HttpClient with the ability to send DELETE requests with a body
+type deleteHTTPClient struct {
+	*http_goclient.HttpClient
+}
+
+// DeleteWithBody issues a DELETE HTTP request to the specified URL including the headers passed in. The content type
+// of the body is set to application/json.
+//
+// The 'in' param interface is marshall and added to the http request body.
+func (c *deleteHTTPClient) DeleteWithBody(url string, headers map[string]string, in interface{}) (*http.Response, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set(contentType, "application/json")
+	resp, err := c.HttpClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s %s %s failed. Response Error: '%s'", req.Method, req.URL, req.Proto, err.Error())
+	}
+	return resp, nil
+}
@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientDeleteWithPayload(t *testing.T) {
+	Convey("Given a providerClient and an API that records the DELETE requests", t, func() {
+		var methodReceived, contentTypeReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methodReceived = r.Method
+			contentTypeReceived = r.Header.Get(contentType)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		resource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		Convey("When providerClient DeleteWithPayload method is called with a payload", func() {
+			resp, err := providerClient.DeleteWithPayload(resource, "1234", map[string]interface{}{"force": true})
+			Convey("Then the request should be a DELETE with the JSON payload in the body", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+				So(methodReceived, ShouldEqual, http.MethodDelete)
+				So(contentTypeReceived, ShouldEqual, "application/json")
+				So(bodyReceived, ShouldEqual, `{"force":true}`)
+			})
+		})
+		Convey("When providerClient Delete method is called", func() {
+			_, err := providerClient.Delete(resource, "1234")
+			Convey("Then the request should be a DELETE without body", func() {
+				So(err, ShouldBeNil)
+				So(methodReceived, ShouldEqual, http.MethodDelete)
+				So(bodyReceived, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a providerClient configured with an http client that does not support DELETE requests with body", t, func() {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       "wwww.host.com",
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClientStub{},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		resource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		Convey("When providerClient DeleteWithPayload method is called with a payload", func() {
+			_, err := providerClient.DeleteWithPayload(resource, "1234", map[string]interface{}{"force": true})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "method 'DELETE' with request body not supported by the http client")
+			})
+		})
+	})
+}
+
+func TestProviderClientPreDelete(t *testing.T) {
+	Convey("Given a providerClient and an API that records the requests and responds with no content", t, func() {
+		var requestReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestReceived = r.Method + " " + r.URL.Path
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When providerClient PreDelete method is called for a resource configured with a pre delete operation", func() {
+			deleteOperation := &specResourceOperation{preDeleteOperation: &specPreDeleteOperation{method: httpPost, pathSuffix: "/detach", operation: &specResourceOperation{}}}
+			resource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, deleteOperation)
+			resp, err := providerClient.PreDelete(resource, "1234", map[string]interface{}{"force": true})
+			Convey("Then the request should be sent to the pre delete operation path of the resource instance", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+				So(requestReceived, ShouldEqual, "POST /v1/resource/1234/detach")
+				So(bodyReceived, ShouldEqual, `{"force":true}`)
+			})
+		})
+		Convey("When providerClient PreDelete method is called for a resource that is not configured with a pre delete operation", func() {
+			resource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+			_, err := providerClient.PreDelete(resource, "1234", nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "resource 'resource' does not define a pre delete operation")
+			})
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// multipartFile is the value of the request payload properties sent as file parts in the multipart/form-data requests
+type multipartFile struct {
+	fileName string
+	content  []byte
+}
+
+// newMultipartFormDataBody returns the multipart/form-data body containing the given properties (in alphabetical order)
+// along with its content type. The multipartFile values are sent as file parts and the rest of values as form fields
+// (refer to getFormFieldValue). Nil values are not sent
+func newMultipartFormDataBody(properties map[string]interface{}) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for _, name := range getSortedPropertyNames(properties) {
+		var err error
+		switch value := properties[name].(type) {
+		case nil:
+			continue
+		case multipartFile:
+			var part io.Writer
+			if part, err = writer.CreateFormFile(name, value.fileName); err == nil {
+				_, err = part.Write(value.content)
+			}
+		default:
+			var field string
+			if field, err = getFormFieldValue(value); err == nil {
+				err = writer.WriteField(name, field)
+			}
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to add the property '%s' to the %s body: %s", name, mediaTypeMultipartFormData, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, writer.FormDataContentType(), nil
+}
+
+// newFormURLEncodedBody returns the application/x-www-form-urlencoded body containing the given properties (in
+// alphabetical order) as form fields (refer to getFormFieldValue). Nil values are not sent
+func newFormURLEncodedBody(properties map[string]interface{}) (*strings.Reader, error) {
+	form := url.Values{}
+	for _, name := range getSortedPropertyNames(properties) {
+		if properties[name] == nil {
+			continue
+		}
+		field, err := getFormFieldValue(properties[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to add the property '%s' to the %s body: %s", name, mediaTypeFormURLEncoded, err)
+		}
+		form.Set(name, field)
+	}
+	return strings.NewReader(form.Encode()), nil
+}
+
+// getFormFieldValue returns the value of the form field for the given property value: strings are sent as they are,
+// lists and objects as their JSON representation and the rest of primitive values as their string representation
+func getFormFieldValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case map[string]interface{}, []interface{}:
+		field, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(field), nil
+	case multipartFile:
+		return "", fmt.Errorf("files are only supported in %s bodies", mediaTypeMultipartFormData)
+	}
+	return fmt.Sprint(value), nil
+}
+
+func getSortedPropertyNames(properties map[string]interface{}) []string {
+	var names []string
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sendFormRequest sends the given request payload, which must be an object, as a form of the given media type
+// (multipart/form-data or application/x-www-form-urlencoded)
+func (o *ProviderClient) sendFormRequest(method httpMethodSupported, mediaType string, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	bodyClient, err := o.getBodyClient(method, mediaType)
+	if err != nil {
+		return nil, err
+	}
+	properties, ok := requestPayload.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s request payloads must be objects (%T)", mediaType, requestPayload)
+	}
+	var body io.Reader
+	formContentType := mediaType
+	if mediaType == mediaTypeMultipartFormData {
+		body, formContentType, err = newMultipartFormDataBody(properties)
+	} else {
+		body, err = newFormURLEncodedBody(properties)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp, err := bodyClient.SendBody(string(method), reqContext.url, reqContext.headers, body, formContentType, nil)
+	return decodeResponse(method, reqContext.url, resp, err, responsePayload)
+}
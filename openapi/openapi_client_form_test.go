@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewMultipartFormDataBody(t *testing.T) {
+	Convey("Given a request payload containing a file and form fields", t, func() {
+		properties := map[string]interface{}{
+			"certificate": multipartFile{fileName: "cert.pem", content: []byte("-----BEGIN CERTIFICATE-----")},
+			"name":        "my_cert",
+			"enabled":     true,
+			"tags":        []interface{}{"a", "b"},
+			"description": nil,
+		}
+		Convey("When newMultipartFormDataBody is called", func() {
+			body, formDataContentType, err := newMultipartFormDataBody(properties)
+			So(err, ShouldBeNil)
+			mediaType, params, err := mime.ParseMediaType(formDataContentType)
+			So(err, ShouldBeNil)
+			form, err := multipart.NewReader(body, params["boundary"]).ReadForm(1024)
+			So(err, ShouldBeNil)
+			Convey("Then the content type should be multipart/form-data", func() {
+				So(mediaType, ShouldEqual, mediaTypeMultipartFormData)
+			})
+			Convey("And the file should be sent as a file part", func() {
+				So(form.File["certificate"], ShouldHaveLength, 1)
+				So(form.File["certificate"][0].Filename, ShouldEqual, "cert.pem")
+				file, err := form.File["certificate"][0].Open()
+				So(err, ShouldBeNil)
+				content, _ := ioutil.ReadAll(file)
+				So(string(content), ShouldEqual, "-----BEGIN CERTIFICATE-----")
+			})
+			Convey("And the rest of values should be sent as form fields", func() {
+				So(form.Value, ShouldResemble, map[string][]string{"enabled": {"true"}, "name": {"my_cert"}, "tags": {`["a","b"]`}})
+			})
+		})
+	})
+}
+
+func TestProviderClientMultipartRequests(t *testing.T) {
+	Convey("Given a providerClient and an API that records the multipart/form-data requests", t, func() {
+		var methodReceived, nameReceived, fileReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methodReceived = r.Method
+			if err := r.ParseMultipartForm(1024); err == nil {
+				nameReceived = r.FormValue("name")
+				if file, _, err := r.FormFile("certificate"); err == nil {
+					content, _ := ioutil.ReadAll(file)
+					fileReceived = string(content)
+				}
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1234","name":"my_cert"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		operation := &specResourceOperation{requestMediaType: mediaTypeMultipartFormData}
+		resource := newSpecStubResourceWithOperations("certificate", "/v1/certificates", false, nil, operation, operation, &specResourceOperation{}, &specResourceOperation{})
+		requestPayload := map[string]interface{}{"name": "my_cert", "certificate": multipartFile{fileName: "cert.pem", content: []byte("content")}}
+		Convey("When providerClient Post method is called for a resource which POST operation consumes multipart/form-data", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Post(resource, requestPayload, &responsePayload)
+			Convey("Then the request payload should be sent as multipart/form-data", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusCreated)
+				So(methodReceived, ShouldEqual, http.MethodPost)
+				So(nameReceived, ShouldEqual, "my_cert")
+				So(fileReceived, ShouldEqual, "content")
+			})
+			Convey("And the response payload should be the one returned by the API", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "my_cert"})
+			})
+		})
+		Convey("When providerClient Put method is called for a resource which PUT operation consumes multipart/form-data", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Put(resource, "1234", requestPayload, &responsePayload)
+			Convey("Then the request payload should be sent as multipart/form-data", func() {
+				So(err, ShouldBeNil)
+				So(methodReceived, ShouldEqual, http.MethodPut)
+				So(fileReceived, ShouldEqual, "content")
+			})
+		})
+		Convey("When providerClient Post method is called with a request payload that is not an object", func() {
+			_, err := providerClient.Post(resource, []interface{}{"my_cert"}, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "multipart/form-data request payloads must be objects ([]interface {})")
+			})
+		})
+	})
+}
+
+func TestNewFormURLEncodedBody(t *testing.T) {
+	Convey("Given a request payload containing primitive and complex values", t, func() {
+		properties := map[string]interface{}{
+			"name":        "my_token",
+			"ttl":         3600,
+			"scopes":      []interface{}{"read", "write"},
+			"description": nil,
+		}
+		Convey("When newFormURLEncodedBody is called", func() {
+			body, err := newFormURLEncodedBody(properties)
+			So(err, ShouldBeNil)
+			content, _ := ioutil.ReadAll(body)
+			Convey("Then the properties should be form encoded in alphabetical order", func() {
+				So(string(content), ShouldEqual, "name=my_token&scopes=%5B%22read%22%2C%22write%22%5D&ttl=3600")
+			})
+		})
+		Convey("When newFormURLEncodedBody is called with a file", func() {
+			_, err := newFormURLEncodedBody(map[string]interface{}{"certificate": multipartFile{fileName: "cert.pem"}})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to add the property 'certificate' to the application/x-www-form-urlencoded body: files are only supported in multipart/form-data bodies")
+			})
+		})
+	})
+}
+
+func TestProviderClientFormURLEncodedRequests(t *testing.T) {
+	Convey("Given a providerClient and an API that records the form-encoded requests", t, func() {
+		var contentTypeReceived, nameReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentTypeReceived = r.Header.Get(contentType)
+			if err := r.ParseForm(); err == nil {
+				nameReceived = r.PostForm.Get("name")
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"1234","name":"my_token"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		operation := &specResourceOperation{requestMediaType: mediaTypeFormURLEncoded}
+		resource := newSpecStubResourceWithOperations("token", "/v1/tokens", false, nil, operation, operation, &specResourceOperation{}, &specResourceOperation{})
+		Convey("When providerClient Post method is called for a resource which POST operation consumes application/x-www-form-urlencoded", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Post(resource, map[string]interface{}{"name": "my_token"}, &responsePayload)
+			Convey("Then the request payload should be sent form encoded", func() {
+				So(err, ShouldBeNil)
+				So(contentTypeReceived, ShouldEqual, mediaTypeFormURLEncoded)
+				So(nameReceived, ShouldEqual, "my_token")
+			})
+			Convey("And the response payload should be the one returned by the API", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "my_token"})
+			})
+		})
+	})
+}
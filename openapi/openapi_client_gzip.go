@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const acceptEncodingHeader = "Accept-Encoding"
+const contentEncodingHeader = "Content-Encoding"
+const gzipEncoding = "gzip"
+
+// gzipTransport is a http.RoundTripper that compresses the request bodies with gzip before delegating the request to the
+// next round tripper and transparently decompresses the gzip responses returned by the API
+type gzipTransport struct {
+	next http.RoundTripper
+}
+
+// newGzipTransport returns a gzipTransport that delegates the requests to the transport provided. If the transport
+// provided is nil the default transport will be used.
+func newGzipTransport(transport http.RoundTripper) *gzipTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &gzipTransport{next: transport}
+}
+
+// RoundTrip compresses the body of a copy of the request so the original request is not modified as per the
+// http.RoundTripper contract. The content length of the request is updated with the size of the compressed body. The
+// response body (if compressed) is decompressed as it is read so large responses are not buffered in memory.
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	compressedReq := new(http.Request)
+	*compressedReq = *req
+	compressedReq.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		compressedReq.Header[k] = append([]string(nil), v...)
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := t.compress(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compress request body for %s %s: %s", req.Method, req.URL, err)
+		}
+		compressedReq.Body = newRequestBody(body)
+		compressedReq.GetBody = func() (io.ReadCloser, error) {
+			return newRequestBody(body), nil
+		}
+		compressedReq.ContentLength = int64(len(body))
+		compressedReq.Header.Set(contentEncodingHeader, gzipEncoding)
+	}
+	// Setting the Accept-Encoding header explicitly disables the transparent decompression performed by the default
+	// transport, hence the response is decompressed below
+	compressedReq.Header.Set(acceptEncodingHeader, gzipEncoding)
+
+	resp, err := t.next.RoundTrip(compressedReq)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(resp.Header.Get(contentEncodingHeader), gzipEncoding) {
+		resp.Body = &gzipResponseBody{body: resp.Body}
+		resp.Header.Del(contentEncodingHeader)
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+	return resp, nil
+}
+
+func (t *gzipTransport) compress(body io.ReadCloser) ([]byte, error) {
+	defer body.Close()
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := io.Copy(writer, body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+// gzipResponseBody decompresses the response body as it is read. The gzip reader is created lazily on the first read
+// so responses with an empty body (e,g: 204 No Content) do not fail
+type gzipResponseBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+}
+
+func (g *gzipResponseBody) Read(p []byte) (int, error) {
+	if g.reader == nil {
+		reader, err := gzip.NewReader(g.body)
+		if err != nil {
+			return 0, err
+		}
+		g.reader = reader
+	}
+	return g.reader.Read(p)
+}
+
+func (g *gzipResponseBody) Close() error {
+	if g.reader != nil {
+		g.reader.Close()
+	}
+	return g.body.Close()
+}
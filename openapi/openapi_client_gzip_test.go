@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func gzipCompress(t *testing.T, value string) []byte {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(value)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return compressed.Bytes()
+}
+
+func TestNewGzipTransport(t *testing.T) {
+	Convey("Given a nil transport", t, func() {
+		Convey("When newGzipTransport is called", func() {
+			transport := newGzipTransport(nil)
+			Convey("Then the gzip transport should delegate the requests to the default transport", func() {
+				So(transport.next, ShouldEqual, http.DefaultTransport)
+			})
+		})
+	})
+	Convey("Given a custom transport", t, func() {
+		customTransport := &http.Transport{MaxIdleConnsPerHost: 50}
+		Convey("When newGzipTransport is called", func() {
+			transport := newGzipTransport(customTransport)
+			Convey("Then the gzip transport should delegate the requests to the custom transport", func() {
+				So(transport.next, ShouldEqual, customTransport)
+			})
+		})
+	})
+}
+
+func TestGzipTransportRoundTrip(t *testing.T) {
+	Convey("Given a gzip transport and an API that decompresses the request bodies and returns gzip responses", t, func() {
+		var receivedContentEncoding, receivedAcceptEncoding, receivedBody string
+		var receivedContentLength int64
+		var receivedBodyLength int
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedContentEncoding = r.Header.Get("Content-Encoding")
+			receivedAcceptEncoding = r.Header.Get("Accept-Encoding")
+			receivedContentLength = r.ContentLength
+			compressed, _ := ioutil.ReadAll(r.Body)
+			receivedBodyLength = len(compressed)
+			if len(compressed) > 0 {
+				reader, err := gzip.NewReader(bytes.NewReader(compressed))
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				decompressed, _ := ioutil.ReadAll(reader)
+				receivedBody = string(decompressed)
+			}
+			response := gzipCompress(t, `{"id":"someID","name":"value"}`)
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Set("Content-Length", strconv.Itoa(len(response)))
+			w.Write(response)
+		}))
+		defer api.Close()
+		client := &http.Client{Transport: newGzipTransport(nil)}
+		Convey("When a request with a body is performed", func() {
+			req, _ := http.NewRequest(http.MethodPost, api.URL, bytes.NewBufferString(`{"name":"value"}`))
+			res, err := client.Do(req)
+			So(err, ShouldBeNil)
+			body, err := ioutil.ReadAll(res.Body)
+			Convey("Then the API should receive the compressed body and the gzip headers", func() {
+				So(receivedContentEncoding, ShouldEqual, "gzip")
+				So(receivedAcceptEncoding, ShouldEqual, "gzip")
+				So(receivedBody, ShouldEqual, `{"name":"value"}`)
+			})
+			Convey("And the content length sent should match the size of the compressed body", func() {
+				So(receivedContentLength, ShouldEqual, receivedBodyLength)
+			})
+			Convey("And the original request should not be modified", func() {
+				So(req.Header.Get("Content-Encoding"), ShouldBeEmpty)
+				So(req.Header.Get("Accept-Encoding"), ShouldBeEmpty)
+			})
+			Convey("And the response body should be transparently decompressed", func() {
+				So(err, ShouldBeNil)
+				So(string(body), ShouldEqual, `{"id":"someID","name":"value"}`)
+			})
+			Convey("And the response should no longer contain the compressed content encoding and length", func() {
+				So(res.Header.Get("Content-Encoding"), ShouldBeEmpty)
+				So(res.Header.Get("Content-Length"), ShouldBeEmpty)
+				So(res.ContentLength, ShouldEqual, -1)
+				So(res.Uncompressed, ShouldBeTrue)
+			})
+		})
+		Convey("When a request without a body is performed", func() {
+			req, _ := http.NewRequest(http.MethodGet, api.URL, nil)
+			res, err := client.Do(req)
+			So(err, ShouldBeNil)
+			body, _ := ioutil.ReadAll(res.Body)
+			Convey("Then the API should not receive the content encoding header nor a body", func() {
+				So(receivedContentEncoding, ShouldBeEmpty)
+				So(receivedBodyLength, ShouldEqual, 0)
+			})
+			Convey("And the API should receive the accept encoding header", func() {
+				So(receivedAcceptEncoding, ShouldEqual, "gzip")
+			})
+			Convey("And the response body should be transparently decompressed", func() {
+				So(string(body), ShouldEqual, `{"id":"someID","name":"value"}`)
+			})
+		})
+	})
+
+	Convey("Given a gzip transport and an API that does not compress the responses", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"id":"someID"}`))
+		}))
+		defer api.Close()
+		client := &http.Client{Transport: newGzipTransport(nil)}
+		Convey("When a request is performed", func() {
+			req, _ := http.NewRequest(http.MethodGet, api.URL, nil)
+			res, err := client.Do(req)
+			So(err, ShouldBeNil)
+			body, _ := ioutil.ReadAll(res.Body)
+			Convey("Then the response body should be returned as is", func() {
+				So(string(body), ShouldEqual, `{"id":"someID"}`)
+				So(res.ContentLength, ShouldEqual, len(`{"id":"someID"}`))
+			})
+		})
+	})
+
+	Convey("Given a gzip transport and an API that returns an empty body with the gzip content encoding", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Encoding", "gzip")
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer api.Close()
+		client := &http.Client{Transport: newGzipTransport(nil)}
+		Convey("When a request is performed", func() {
+			req, _ := http.NewRequest(http.MethodDelete, api.URL, nil)
+			res, err := client.Do(req)
+			So(err, ShouldBeNil)
+			body, err := ioutil.ReadAll(res.Body)
+			Convey("Then reading the response body should not fail", func() {
+				So(err, ShouldBeNil)
+				So(body, ShouldBeEmpty)
+				So(res.Body.Close(), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a provider http client configured with the gzip transport and a request interceptor", t, func() {
+		var interceptedContentEncoding string
+		var interceptedBody []byte
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reader, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			decompressed, _ := ioutil.ReadAll(reader)
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gzipCompress(t, string(decompressed)))
+		}))
+		defer api.Close()
+		httpClient := newHTTPClient(func(req *http.Request) error {
+			interceptedContentEncoding = req.Header.Get("Content-Encoding")
+			interceptedBody, _ = ioutil.ReadAll(req.Body)
+			return nil
+		}, nil)
+		httpClient.Transport = newGzipTransport(httpClient.Transport)
+		client := &http_goclient.HttpClient{HttpClient: httpClient}
+		Convey("When PostJson is called", func() {
+			response := map[string]interface{}{}
+			_, err := client.PostJson(api.URL, map[string]string{}, map[string]interface{}{"name": "value"}, &response)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the request interceptor should see the final compressed body sent to the API", func() {
+				So(interceptedContentEncoding, ShouldEqual, "gzip")
+				So(interceptedBody, ShouldResemble, gzipCompress(t, `{"name":"value"}`))
+			})
+			Convey("And the response payload should be decompressed and unmarshalled", func() {
+				So(response, ShouldResemble, map[string]interface{}{"name": "value"})
+			})
+		})
+	})
+}
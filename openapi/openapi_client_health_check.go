@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// healthCheck performs a GET request against the health check path provided and returns an error if the API does not
+// respond with a successful status code. The request is sent the same way as any other API request (global security
+// schemes, headers, query parameters and transport), hence it validates that the provider configuration is correct before
+// any resource operation is performed
+func (o *ProviderClient) healthCheck(path string) error {
+	healthCheckURL, err := o.getHealthCheckURL(path)
+	if err != nil {
+		return fmt.Errorf("provider health check failed: %s", err)
+	}
+	resp, err := o.performRequest(httpGet, healthCheckURL, &specResourceOperation{}, nil, nil)
+	if err != nil {
+		return fmt.Errorf("provider health check GET %s failed: %s", healthCheckURL, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("provider health check GET %s failed with HTTP Response Status Code %d: the API rejected the credentials, please make sure the authentication properties configured in the provider are valid", healthCheckURL, resp.StatusCode)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("provider health check GET %s failed with HTTP Response Status Code %d (%s)", healthCheckURL, resp.StatusCode, string(body))
+	}
+	o.getLogger().Debug(fmt.Sprintf("provider health check GET %s succeeded with HTTP Response Status Code %d", healthCheckURL, resp.StatusCode), "url", healthCheckURL)
+	return nil
+}
+
+// getHealthCheckURL returns the URL of the health check path, relative to the API base URL if configured by the user or
+// otherwise to the host and base path defined in the OpenAPI document
+func (o *ProviderClient) getHealthCheckURL(path string) (string, error) {
+	if strings.Index(path, "/") != 0 {
+		path = fmt.Sprintf("/%s", path)
+	}
+	if apiBaseURL := o.providerConfiguration.getAPIBaseURL(); apiBaseURL != "" {
+		return fmt.Sprintf("%s%s", apiBaseURL, path), nil
+	}
+	host, err := o.getGlobalHost()
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		return "", fmt.Errorf("host is a mandatory attribute to get the health check URL")
+	}
+	scheme, err := o.openAPIBackendConfiguration.getHTTPScheme()
+	if err != nil {
+		return "", err
+	}
+	basePath := strings.TrimRight(o.openAPIBackendConfiguration.getBasePath(), "/")
+	if basePath != "" && strings.Index(basePath, "/") != 0 {
+		basePath = fmt.Sprintf("/%s", basePath)
+	}
+	return fmt.Sprintf("%s://%s%s%s", scheme, host, basePath, path), nil
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientHealthCheck(t *testing.T) {
+	Convey("Given a providerClient configured with global security schemes and an API that records the health check requests", t, func() {
+		var receivedPaths, receivedAPIKeys []string
+		returnStatusCode := http.StatusOK
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedPaths = append(receivedPaths, r.URL.Path)
+			receivedAPIKeys = append(receivedAPIKeys, r.Header.Get("X-API-KEY"))
+			w.WriteHeader(returnStatusCode)
+			w.Write([]byte("some non JSON body"))
+		}))
+		defer api.Close()
+		backendConfiguration := &specStubBackendConfiguration{
+			host:       strings.TrimPrefix(api.URL, "http://"),
+			basePath:   "/api",
+			httpScheme: "http",
+		}
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: backendConfiguration,
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"api_key": apiKeyHeaderAuthenticator{apiKey: apiKey{name: "X-API-KEY", value: "superSecretKey"}},
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}, nil),
+		}
+		Convey("When healthCheck is called and the API returns a successful response", func() {
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the health check request should be sent to the base path using the global security schemes", func() {
+				So(receivedPaths, ShouldResemble, []string{"/api/me"})
+				So(receivedAPIKeys, ShouldResemble, []string{"superSecretKey"})
+			})
+		})
+		Convey("When healthCheck is called with a path that does not start with a forward slash", func() {
+			err := providerClient.healthCheck("health")
+			Convey("Then the error returned should be nil and the request should be sent to the expected path", func() {
+				So(err, ShouldBeNil)
+				So(receivedPaths, ShouldResemble, []string{"/api/health"})
+			})
+		})
+		Convey("When healthCheck is called and the provider is configured with the api base url", func() {
+			providerClient.providerConfiguration.APIBaseURL = api.URL + "/v2"
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should be nil and the request should be sent relative to the api base url", func() {
+				So(err, ShouldBeNil)
+				So(receivedPaths, ShouldResemble, []string{"/v2/me"})
+			})
+		})
+		Convey("When healthCheck is called and the API returns unauthorized", func() {
+			returnStatusCode = http.StatusUnauthorized
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should be the expected auth error", func() {
+				So(err.Error(), ShouldEqual, "provider health check GET "+api.URL+"/api/me failed with HTTP Response Status Code 401: the API rejected the credentials, please make sure the authentication properties configured in the provider are valid")
+			})
+			Convey("And the error should not contain the credentials", func() {
+				So(err.Error(), ShouldNotContainSubstring, "superSecretKey")
+			})
+		})
+		Convey("When healthCheck is called and the API returns forbidden", func() {
+			returnStatusCode = http.StatusForbidden
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should be the expected auth error", func() {
+				So(err.Error(), ShouldEqual, "provider health check GET "+api.URL+"/api/me failed with HTTP Response Status Code 403: the API rejected the credentials, please make sure the authentication properties configured in the provider are valid")
+			})
+		})
+		Convey("When healthCheck is called and the API returns an unexpected status code", func() {
+			returnStatusCode = http.StatusInternalServerError
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should contain the status code and the response body", func() {
+				So(err.Error(), ShouldEqual, "provider health check GET "+api.URL+"/api/me failed with HTTP Response Status Code 500 (some non JSON body)")
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a backend configuration with no host", t, func() {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{httpScheme: "https"},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator:            newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When healthCheck is called", func() {
+			err := providerClient.healthCheck("/me")
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "provider health check failed: host is a mandatory attribute to get the health check URL")
+			})
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// listItemsStream is a list response payload that decodes the items of the JSON array returned by the API one at a time,
+// handing each item over to the handler as soon as it is decoded. This avoids holding the whole decoded collection in
+// memory when the API returns large lists; it is up to the handler to decide which items (if any) are kept. The stream
+// can decode several bodies in a row (e,g: one per page) and the handler will receive the items of all of them
+type listItemsStream struct {
+	handler func(item map[string]interface{}) error
+	// itemsField is the name of the response payload field containing the items when the API wraps the list in a
+	// JSON object (e,g: {"items": [...], "next_cursor": "..."}). If empty, the response payload must be a JSON array
+	itemsField string
+	// cursorField is the name of the response payload field containing the cursor that points to the next page, only
+	// looked up when the response payload is a JSON object
+	cursorField string
+	// pageItems and nextCursor contain the number of items and the cursor found in the last body decoded
+	pageItems  int
+	nextCursor string
+}
+
+func newListItemsStream(handler func(item map[string]interface{}) error) *listItemsStream {
+	return &listItemsStream{handler: handler}
+}
+
+// decode reads the JSON array from the body incrementally. A null body is treated as an empty list. Decoding stops at
+// the first error returned by the handler. If the stream is configured with an itemsField, the body can also be a JSON
+// object containing the array in the given field
+func (s *listItemsStream) decode(body io.Reader) error {
+	s.pageItems = 0
+	s.nextCursor = ""
+	decoder := json.NewDecoder(body)
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("failed to decode list response body: %s", err)
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); ok && delim == '{' && s.itemsField != "" {
+		return s.decodeObject(decoder)
+	}
+	return s.decodeItems(decoder, token)
+}
+
+// decodeObject reads the fields of the JSON object wrapping the list, decoding the items contained in the itemsField
+// and capturing the value of the cursorField. Any other field is skipped
+func (s *listItemsStream) decodeObject(decoder *json.Decoder) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to decode list response body: %s", err)
+		}
+		switch token {
+		case s.itemsField:
+			itemsToken, err := decoder.Token()
+			if err != nil {
+				return fmt.Errorf("failed to decode list response body field '%s': %s", s.itemsField, err)
+			}
+			if itemsToken == nil {
+				continue
+			}
+			if err := s.decodeItems(decoder, itemsToken); err != nil {
+				return err
+			}
+		case s.cursorField:
+			var cursor interface{}
+			if err := decoder.Decode(&cursor); err != nil {
+				return fmt.Errorf("failed to decode list response body field '%s': %s", s.cursorField, err)
+			}
+			if cursor != nil {
+				s.nextCursor = fmt.Sprintf("%v", cursor)
+			}
+		default:
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return fmt.Errorf("failed to decode list response body: %s", err)
+			}
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to decode list response body: %s", err)
+	}
+	return nil
+}
+
+// decodeItems reads the items of the JSON array which opening token has already been read
+func (s *listItemsStream) decodeItems(decoder *json.Decoder, token json.Token) error {
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("failed to decode list response body: expected a JSON array but received '%v'", token)
+	}
+	for decoder.More() {
+		var item map[string]interface{}
+		if err := decoder.Decode(&item); err != nil {
+			return fmt.Errorf("failed to decode list response body item: %s", err)
+		}
+		s.pageItems++
+		if err := s.handler(item); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("failed to decode list response body: %s", err)
+	}
+	return nil
+}
+
+// UnmarshalJSON makes the stream usable with clients that decode the response body themselves
+func (s *listItemsStream) UnmarshalJSON(data []byte) error {
+	return s.decode(bytes.NewReader(data))
+}
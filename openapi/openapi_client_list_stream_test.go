@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListItemsStreamDecode(t *testing.T) {
+	Convey("Given a list items stream that records the items received", t, func() {
+		var items []map[string]interface{}
+		stream := newListItemsStream(func(item map[string]interface{}) error {
+			items = append(items, item)
+			return nil
+		})
+		Convey("When decode is called with a JSON array containing several items", func() {
+			err := stream.decode(strings.NewReader(`[{"id":"1","label":"first"},{"id":"2","size":5}]`))
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the handler should have received all the items in order", func() {
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1", "label": "first"}, {"id": "2", "size": float64(5)}})
+			})
+		})
+		Convey("When decode is called several times (e,g: one per page)", func() {
+			err := stream.decode(strings.NewReader(`[{"id":"1"}]`))
+			So(err, ShouldBeNil)
+			err = stream.decode(strings.NewReader(`[{"id":"2"}]`))
+			Convey("Then the handler should have received the items of all the bodies", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}})
+			})
+		})
+		Convey("When decode is called with an empty JSON array", func() {
+			err := stream.decode(strings.NewReader(`[]`))
+			Convey("Then the error returned should be nil and no items should be received", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldBeEmpty)
+			})
+		})
+		Convey("When decode is called with a null body", func() {
+			err := stream.decode(strings.NewReader(`null`))
+			Convey("Then the error returned should be nil and no items should be received", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldBeEmpty)
+			})
+		})
+		Convey("When decode is called with a JSON object and the stream is configured with the items and cursor fields", func() {
+			stream.itemsField = "items"
+			stream.cursorField = "next_cursor"
+			err := stream.decode(strings.NewReader(`{"total":2,"items":[{"id":"1"},{"id":"2"}],"meta":{"page":1},"next_cursor":"abc"}`))
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the handler should have received the items contained in the items field", func() {
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}, {"id": "2"}})
+			})
+			Convey("And the stream should have recorded the number of items and the cursor of the page", func() {
+				So(stream.pageItems, ShouldEqual, 2)
+				So(stream.nextCursor, ShouldEqual, "abc")
+			})
+		})
+		Convey("When decode is called with a JSON object that contains a null items field and no cursor", func() {
+			stream.itemsField = "items"
+			stream.cursorField = "next_cursor"
+			err := stream.decode(strings.NewReader(`{"items":null}`))
+			Convey("Then the error returned should be nil and no items nor cursor should be received", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldBeEmpty)
+				So(stream.pageItems, ShouldEqual, 0)
+				So(stream.nextCursor, ShouldBeEmpty)
+			})
+		})
+		Convey("When decode is called with a JSON object", func() {
+			err := stream.decode(strings.NewReader(`{"id":"1"}`))
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to decode list response body: expected a JSON array but received '{'")
+			})
+		})
+		Convey("When decode is called with an empty body", func() {
+			err := stream.decode(strings.NewReader(``))
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to decode list response body: EOF")
+			})
+		})
+		Convey("When decode is called with an array containing an item that is not an object", func() {
+			err := stream.decode(strings.NewReader(`[{"id":"1"},"someString"]`))
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to decode list response body item: json: cannot unmarshal string into Go value of type map[string]interface {}")
+			})
+			Convey("And the items decoded before the failure should have been received", func() {
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}})
+			})
+		})
+		Convey("When decode is called with a truncated JSON array", func() {
+			err := stream.decode(strings.NewReader(`[{"id":"1"}`))
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+		Convey("When the stream is unmarshalled with encoding/json", func() {
+			err := stream.UnmarshalJSON([]byte(`[{"id":"1"}]`))
+			Convey("Then the items should be handed over to the handler", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1"}})
+			})
+		})
+	})
+
+	Convey("Given a list items stream which handler fails", t, func() {
+		received := 0
+		stream := newListItemsStream(func(item map[string]interface{}) error {
+			received++
+			return errors.New("some error")
+		})
+		Convey("When decode is called with a JSON array containing several items", func() {
+			err := stream.decode(strings.NewReader(`[{"id":"1"},{"id":"2"}]`))
+			Convey("Then the error returned should be the handler error and the decoding should stop", func() {
+				So(err.Error(), ShouldEqual, "some error")
+				So(received, ShouldEqual, 1)
+			})
+		})
+	})
+}
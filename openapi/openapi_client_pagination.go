@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// paginationStrategy knows how to walk through the pages of a paginated list. The strategies keep track of the pages
+// read so far, hence a new one must be used every time the list is fetched
+type paginationStrategy interface {
+	// firstPageURL returns the URL of the first page of the list
+	firstPageURL(resourceURL string) string
+	// nextPageURL returns the URL of the page that follows the one just read (pageURL) based on the response received
+	// and the items decoded by the stream. An empty URL is returned if the page read was the last one
+	nextPageURL(pageURL string, resp *http.Response, stream *listItemsStream) (string, error)
+}
+
+// newPaginationStrategy returns the strategy that implements the pagination type provided
+func newPaginationStrategy(resourceURL string, pagination *specPagination) (paginationStrategy, error) {
+	switch pagination.strategy {
+	case paginationPage:
+		return &pagePaginationStrategy{resourceURL: resourceURL, pagination: pagination, page: pagination.startPage}, nil
+	case paginationCursor:
+		return &cursorPaginationStrategy{resourceURL: resourceURL, pagination: pagination}, nil
+	case paginationLinkHeader:
+		return &linkHeaderPaginationStrategy{pagination: pagination}, nil
+	}
+	return nil, fmt.Errorf("pagination type '%s' not supported", pagination.strategy)
+}
+
+// appendLimitQueryParameter appends the page size query parameter to the URL if the pagination configures it
+func appendLimitQueryParameter(resourceURL string, pagination *specPagination) string {
+	if pagination.limitParam == "" {
+		return resourceURL
+	}
+	return appendQueryParameters(resourceURL, queryParameter{name: pagination.limitParam, values: []string{strconv.Itoa(pagination.limit)}, collectionFormat: collectionFormatCSV})
+}
+
+// isLastPage returns true if the page read did not return any item or returned fewer items than the page size
+func isLastPage(pagination *specPagination, stream *listItemsStream) bool {
+	return stream.pageItems == 0 || (pagination.limit > 0 && stream.pageItems < pagination.limit)
+}
+
+// pagePaginationStrategy requests the pages incrementing the page number query parameter (e,g: ?page=1, ?page=2...)
+// until a page does not return any item or returns fewer items than the page size
+type pagePaginationStrategy struct {
+	resourceURL string
+	pagination  *specPagination
+	page        int
+}
+
+func (p *pagePaginationStrategy) firstPageURL(resourceURL string) string {
+	return p.pageURL()
+}
+
+func (p *pagePaginationStrategy) nextPageURL(pageURL string, resp *http.Response, stream *listItemsStream) (string, error) {
+	if isLastPage(p.pagination, stream) {
+		return "", nil
+	}
+	p.page++
+	return p.pageURL(), nil
+}
+
+func (p *pagePaginationStrategy) pageURL() string {
+	pageURL := appendQueryParameters(p.resourceURL, queryParameter{name: p.pagination.pageParam, values: []string{strconv.Itoa(p.page)}, collectionFormat: collectionFormatCSV})
+	return appendLimitQueryParameter(pageURL, p.pagination)
+}
+
+// cursorPaginationStrategy requests the pages sending the cursor returned in the previous page response payload as
+// query parameter (e,g: ?cursor=abc) until a page does not return any cursor
+type cursorPaginationStrategy struct {
+	resourceURL string
+	pagination  *specPagination
+}
+
+func (c *cursorPaginationStrategy) firstPageURL(resourceURL string) string {
+	return appendLimitQueryParameter(resourceURL, c.pagination)
+}
+
+func (c *cursorPaginationStrategy) nextPageURL(pageURL string, resp *http.Response, stream *listItemsStream) (string, error) {
+	if stream.nextCursor == "" {
+		return "", nil
+	}
+	nextPageURL := appendQueryParameters(c.resourceURL, queryParameter{name: c.pagination.cursorParam, values: []string{stream.nextCursor}, collectionFormat: collectionFormatCSV})
+	return appendLimitQueryParameter(nextPageURL, c.pagination), nil
+}
+
+// linkHeaderPaginationStrategy requests the pages following the rel="next" URL of the Link response header until a
+// page response does not contain it. Relative URLs are resolved against the URL of the page read. The next page URLs
+// pointing to a different scheme or host than the page read are rejected so the API credentials are never sent to a
+// host other than the API one
+type linkHeaderPaginationStrategy struct {
+	pagination *specPagination
+}
+
+func (l *linkHeaderPaginationStrategy) firstPageURL(resourceURL string) string {
+	return appendLimitQueryParameter(resourceURL, l.pagination)
+}
+
+func (l *linkHeaderPaginationStrategy) nextPageURL(pageURL string, resp *http.Response, stream *listItemsStream) (string, error) {
+	next := getNextLink(resp.Header.Values("Link"))
+	if next == "" {
+		return "", nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("link header next page URL '%s' is not valid: %s", next, err)
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	nextURL := base.ResolveReference(u)
+	if !strings.EqualFold(nextURL.Scheme, base.Scheme) || !strings.EqualFold(nextURL.Host, base.Host) {
+		return "", fmt.Errorf("link header next page URL '%s' does not match the scheme and host of the page read '%s'", next, pageURL)
+	}
+	return nextURL.String(), nil
+}
+
+// getNextLink returns the URL of the link with relation type 'next' contained in the Link header values provided
+// (e,g: <https://api.server.com/v1/cdns?page=2>; rel="next", <https://api.server.com/v1/cdns?page=5>; rel="last"),
+// empty if there is none
+func getNextLink(headerValues []string) string {
+	for _, headerValue := range headerValues {
+		for _, link := range strings.Split(headerValue, ",") {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// listPages fetches all the pages of the list as per the pagination configured in the operation, handing the items of
+// every page over to the stream. The response of the last page is returned, or the first non successful response
+func (o *ProviderClient) listPages(resourceURL string, operation *specResourceOperation, stream *listItemsStream) (*http.Response, error) {
+	strategy, err := newPaginationStrategy(resourceURL, operation.pagination)
+	if err != nil {
+		return nil, err
+	}
+	stream.itemsField = operation.pagination.itemsField
+	stream.cursorField = operation.pagination.cursorField
+	pagesRead := map[string]bool{}
+	pageURL := strategy.firstPageURL(resourceURL)
+	for {
+		resp, err := o.performRequest(httpGet, pageURL, operation, nil, stream)
+		if err != nil || resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+			return resp, err
+		}
+		pagesRead[pageURL] = true
+		nextPageURL, err := strategy.nextPageURL(pageURL, resp, stream)
+		if err != nil {
+			return nil, fmt.Errorf("GET %s pagination failed: %s", resourceURL, err)
+		}
+		if nextPageURL == "" {
+			return resp, nil
+		}
+		if pagesRead[nextPageURL] {
+			return nil, fmt.Errorf("GET %s pagination failed: the next page '%s' has already been read", resourceURL, nextPageURL)
+		}
+		o.getLogger().Debug(fmt.Sprintf("GET %s returned %d items, requesting the next page %s", pageURL, stream.pageItems, nextPageURL), "url", pageURL, "next_page_url", nextPageURL)
+		pageURL = nextPageURL
+	}
+}
@@ -0,0 +1,214 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderClientListPages(t *testing.T) {
+	Convey("Given a providerClient and an API that paginates the list of items", t, func() {
+		var requestedURLs []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedURLs = append(requestedURLs, r.URL.RequestURI())
+			switch r.URL.Path {
+			case "/v1/pages":
+				switch r.URL.Query().Get("page") {
+				case "1":
+					w.Write([]byte(`[{"id":"1"},{"id":"2"}]`))
+				case "2":
+					w.Write([]byte(`[{"id":"3"}]`))
+				default:
+					w.Write([]byte(`[]`))
+				}
+			case "/v1/cursors":
+				switch r.URL.Query().Get("cursor") {
+				case "":
+					w.Write([]byte(`{"items":[{"id":"1"},{"id":"2"}],"next_cursor":"abc"}`))
+				case "abc":
+					w.Write([]byte(`{"items":[{"id":"3"}],"next_cursor":null}`))
+				}
+			case "/v1/links":
+				switch r.URL.Query().Get("page") {
+				case "":
+					w.Header().Set("Link", `</v1/links?page=2>; rel="next", </v1/links?page=2>; rel="last"`)
+					w.Write([]byte(`[{"id":"1"},{"id":"2"}]`))
+				case "2":
+					w.Write([]byte(`[{"id":"3"}]`))
+				}
+			case "/v1/foreign":
+				w.Header().Set("Link", `<https://api.other.com/v1/foreign?page=2>; rel="next"`)
+				w.Write([]byte(`[{"id":"1"}]`))
+			case "/v1/loop":
+				w.Header().Set("Link", `</v1/loop>; rel="next"`)
+				w.Write([]byte(`[{"id":"1"}]`))
+			case "/v1/error":
+				if r.URL.Query().Get("page") == "2" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(`[{"id":"1"}]`))
+			}
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		var items []map[string]interface{}
+		stream := newListItemsStream(func(item map[string]interface{}) error {
+			items = append(items, item)
+			return nil
+		})
+		expectedItems := []map[string]interface{}{{"id": "1"}, {"id": "2"}, {"id": "3"}}
+		newPaginatedResource := func(path string, pagination *specPagination) *specStubResource {
+			specStubResource := newSpecStubResourceWithOperations("resource", path, false, nil, nil, nil, nil, nil)
+			specStubResource.resourceListOperation = &specResourceOperation{pagination: pagination}
+			return specStubResource
+		}
+		Convey("When providerClient List method is called for a resource using page pagination", func() {
+			pagination, _ := newSpecPagination(paginationPage)
+			resp, err := providerClient.List(newPaginatedResource("/v1/pages", pagination), stream)
+			Convey("Then the error returned should be nil and the response should be the expected", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the stream handler should have received the items of all the pages", func() {
+				So(items, ShouldResemble, expectedItems)
+				So(requestedURLs, ShouldResemble, []string{"/v1/pages?page=1", "/v1/pages?page=2", "/v1/pages?page=3"})
+			})
+		})
+		Convey("When providerClient List method is called for a resource using page pagination with a page size", func() {
+			pagination := &specPagination{strategy: paginationPage, pageParam: "page", startPage: 1, limitParam: "size", limit: 2}
+			_, err := providerClient.List(newPaginatedResource("/v1/pages", pagination), stream)
+			Convey("Then the page returning fewer items than the page size should be considered the last one", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, expectedItems)
+				So(requestedURLs, ShouldResemble, []string{"/v1/pages?page=1&size=2", "/v1/pages?page=2&size=2"})
+			})
+		})
+		Convey("When providerClient List method is called for a resource using cursor pagination", func() {
+			pagination, _ := newSpecPagination(paginationCursor)
+			_, err := providerClient.List(newPaginatedResource("/v1/cursors", pagination), stream)
+			Convey("Then the stream handler should have received the items of all the pages", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, expectedItems)
+				So(requestedURLs, ShouldResemble, []string{"/v1/cursors", "/v1/cursors?cursor=abc"})
+			})
+		})
+		Convey("When providerClient List method is called for a resource using link header pagination", func() {
+			pagination, _ := newSpecPagination(paginationLinkHeader)
+			_, err := providerClient.List(newPaginatedResource("/v1/links", pagination), stream)
+			Convey("Then the stream handler should have received the items of all the pages", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldResemble, expectedItems)
+				So(requestedURLs, ShouldResemble, []string{"/v1/links", "/v1/links?page=2"})
+			})
+		})
+		Convey("When providerClient List method is called for a resource which next page link points to the page already read", func() {
+			pagination, _ := newSpecPagination(paginationLinkHeader)
+			_, err := providerClient.List(newPaginatedResource("/v1/loop", pagination), stream)
+			Convey("Then the error returned should be the expected", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("GET %s/v1/loop pagination failed: the next page '%s/v1/loop' has already been read", api.URL, api.URL))
+			})
+		})
+		Convey("When providerClient List method is called for a resource which next page link points to a different host", func() {
+			pagination, _ := newSpecPagination(paginationLinkHeader)
+			_, err := providerClient.List(newPaginatedResource("/v1/foreign", pagination), stream)
+			Convey("Then the error returned should be the expected and the next page should not be requested", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("GET %s/v1/foreign pagination failed: link header next page URL 'https://api.other.com/v1/foreign?page=2' does not match the scheme and host of the page read '%s/v1/foreign'", api.URL, api.URL))
+				So(requestedURLs, ShouldResemble, []string{"/v1/foreign"})
+			})
+		})
+		Convey("When providerClient List method is called for a paginated resource and the API fails to return a page", func() {
+			pagination, _ := newSpecPagination(paginationPage)
+			resp, err := providerClient.List(newPaginatedResource("/v1/error", pagination), stream)
+			Convey("Then the non successful response should be returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func TestLinkHeaderPaginationStrategyNextPageURL(t *testing.T) {
+	testCases := []struct {
+		name            string
+		link            string
+		expectedNextURL string
+		expectedErr     bool
+	}{
+		{name: "relative next page URLs are resolved against the page read", link: `</v1/cdns?page=2>; rel="next"`, expectedNextURL: "https://api.server.com/v1/cdns?page=2"},
+		{name: "absolute next page URLs of the same origin are followed", link: `<https://API.server.com/v1/cdns?page=2>; rel="next"`, expectedNextURL: "https://API.server.com/v1/cdns?page=2"},
+		{name: "no next page link means there are no more pages", link: `</v1/cdns?page=1>; rel="prev"`, expectedNextURL: ""},
+		{name: "next page URLs pointing to a different host are rejected", link: `<https://api.other.com/v1/cdns?page=2>; rel="next"`, expectedErr: true},
+		{name: "next page URLs pointing to a different scheme are rejected", link: `<http://api.server.com/v1/cdns?page=2>; rel="next"`, expectedErr: true},
+		{name: "next page URLs pointing to a different port are rejected", link: `<https://api.server.com:8443/v1/cdns?page=2>; rel="next"`, expectedErr: true},
+	}
+	strategy := &linkHeaderPaginationStrategy{pagination: &specPagination{strategy: paginationLinkHeader}}
+	for _, tc := range testCases {
+		resp := &http.Response{Header: http.Header{"Link": []string{tc.link}}}
+		nextURL, err := strategy.nextPageURL("https://api.server.com/v1/cdns", resp, nil)
+		if tc.expectedErr {
+			assert.Error(t, err, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedNextURL, nextURL, tc.name)
+	}
+}
+
+func TestGetNextLink(t *testing.T) {
+	testCases := []struct {
+		name         string
+		headerValues []string
+		expectedLink string
+	}{
+		{
+			name:         "link header containing several links",
+			headerValues: []string{`<https://api.server.com/v1/cdns?page=1>; rel="prev", <https://api.server.com/v1/cdns?page=3>; rel="next"`},
+			expectedLink: "https://api.server.com/v1/cdns?page=3",
+		},
+		{
+			name:         "several link headers",
+			headerValues: []string{`<https://api.server.com/v1/cdns?page=1>; rel="first"`, `</v1/cdns?page=2>; rel=next`},
+			expectedLink: "/v1/cdns?page=2",
+		},
+		{
+			name:         "link with several relation types",
+			headerValues: []string{`<https://api.server.com/v1/cdns?page=2>; title="next page"; rel="next last"`},
+			expectedLink: "https://api.server.com/v1/cdns?page=2",
+		},
+		{
+			name:         "link header without next link",
+			headerValues: []string{`<https://api.server.com/v1/cdns?page=1>; rel="prev"`},
+			expectedLink: "",
+		},
+		{
+			name:         "no link header",
+			headerValues: nil,
+			expectedLink: "",
+		},
+		{
+			name:         "malformed link",
+			headerValues: []string{`https://api.server.com/v1/cdns?page=2; rel="next"`},
+			expectedLink: "",
+		},
+	}
+	for _, tc := range testCases {
+		link := getNextLink(tc.headerValues)
+		assert.Equal(t, tc.expectedLink, link, tc.name)
+	}
+}
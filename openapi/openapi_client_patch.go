@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/dikhan/http_goclient"
+)
+
+// httpPatchClient defines the behaviour expected from http clients that support PATCH requests, which is not part of
+// the http_goclient.HttpClientIface
+type httpPatchClient interface {
+	Patch(url string, headers map[string]string, in interface{}, out interface{}) (*http.Response, error)
+}
+
+// patchHTTPClient extends the http_goclient.HttpClient with the ability to send PATCH requests
+type patchHTTPClient struct {
+	*http_goclient.HttpClient
+}
+
+// Patch issues a PATCH HTTP request to the specified URL including the headers passed in. The content type of the body
+// (e,g: application/merge-patch+json) is expected to be part of the headers passed in.
+//
+// The 'in' param interface is marshall and added to the http request body.
+// The 'out' param interface is the un-marshall representation of the http response returned. Unlike the rest of the
+// http_goclient operations, empty response bodies (e,g: 204 No Content) are not considered an error
+func (c *patchHTTPClient) Patch(url string, headers map[string]string, in interface{}, out interface{}) (*http.Response, error) {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := c.HttpClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s %s %s failed. Response Error: '%s'", req.Method, req.URL, req.Proto, err.Error())
+	}
+	if out == nil {
+		return resp, nil
+	}
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	if len(responseBody) > 0 {
+		if err := json.Unmarshal(responseBody, out); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for request = '%s %s %s'. Response = '%s'", err.Error(), req.Method, req.URL, req.Proto, resp.Status)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderClientPatch(t *testing.T) {
+	Convey("Given a providerClient and an API that supports PATCH requests", t, func() {
+		var methodReceived, contentTypeReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methodReceived = r.Method
+			contentTypeReceived = r.Header.Get(contentType)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(body)
+			if r.URL.Path == "/v1/resource/no-content" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.Write([]byte(`{"id":"1234","name":"some name"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When providerClient Patch method is called for a resource using the default update strategy", func() {
+			resource := newSpecStubResource("resource", "/v1/resource", false, nil)
+			resource.resourcePatchOperation = &specResourceOperation{}
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Patch(resource, "1234", map[string]interface{}{"name": "some name"}, &responsePayload)
+			Convey("Then the request should be a PATCH with the merge patch content type", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(methodReceived, ShouldEqual, http.MethodPatch)
+				So(contentTypeReceived, ShouldEqual, "application/merge-patch+json")
+				So(bodyReceived, ShouldEqual, `{"name":"some name"}`)
+			})
+			Convey("And the response payload should contain the resource returned by the API", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "some name"})
+			})
+		})
+		Convey("When providerClient Patch method is called for a resource using the json-patch update strategy", func() {
+			resource := newSpecStubResource("resource", "/v1/resource", false, nil)
+			resource.resourcePatchOperation = &specResourceOperation{updateStrategy: updateStrategyJSONPatch}
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Patch(resource, "1234", []jsonPatchOperation{{Op: "replace", Path: "/name", Value: "some name"}}, &responsePayload)
+			Convey("Then the request should be sent with the JSON patch content type and document", func() {
+				So(err, ShouldBeNil)
+				So(contentTypeReceived, ShouldEqual, "application/json-patch+json")
+				So(bodyReceived, ShouldEqual, `[{"op":"replace","path":"/name","value":"some name"}]`)
+			})
+		})
+		Convey("When providerClient Patch method is called and the API responds with no content", func() {
+			resource := newSpecStubResource("resource", "/v1/resource", false, nil)
+			resource.resourcePatchOperation = &specResourceOperation{}
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Patch(resource, "no-content", map[string]interface{}{"name": "some name"}, &responsePayload)
+			Convey("Then the error should be nil and the response payload should be empty", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNoContent)
+				So(responsePayload, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a providerClient configured with an http client that does not support PATCH requests", t, func() {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       "wwww.host.com",
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClientStub{},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When providerClient Patch method is called", func() {
+			resource := newSpecStubResource("resource", "/v1/resource", false, nil)
+			resource.resourcePatchOperation = &specResourceOperation{}
+			_, err := providerClient.Patch(resource, "1234", map[string]interface{}{}, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "method 'PATCH' not supported by the http client")
+			})
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenBucketRateLimiter limits the rate of events using a token bucket: the bucket holds up to burst tokens, it is
+// refilled at the configured rate and each event takes a token. It is safe for concurrent use.
+type tokenBucketRateLimiter struct {
+	ratePerSecond float64
+	burst         float64
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+	// now returns the current time, configurable for testing purposes
+	now func() time.Time
+}
+
+// newTokenBucketRateLimiter returns a rate limiter with the bucket full so the first burst of events is not delayed
+func newTokenBucketRateLimiter(rateLimitConfiguration RateLimitConfiguration) *tokenBucketRateLimiter {
+	burst := float64(rateLimitConfiguration.getBurst())
+	return &tokenBucketRateLimiter{
+		ratePerSecond: rateLimitConfiguration.RequestsPerSecond,
+		burst:         burst,
+		tokens:        burst,
+		now:           time.Now,
+	}
+}
+
+// reserve takes a token from the bucket and returns how long the caller must wait before the event can happen. If
+// there are no tokens available the token is borrowed from the future so concurrent callers are served in order
+func (l *tokenBucketRateLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.ratePerSecond
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.ratePerSecond * float64(time.Second))
+}
+
+// cancel gives back the token taken by a reservation whose event did not happen (e,g: the request was cancelled while
+// waiting)
+func (l *tokenBucketRateLimiter) cancel() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// rateLimitedTransport is a http.RoundTripper that delays the requests as needed so they do not exceed the rate allowed
+// by the rate limiter before delegating them to the next round tripper
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *tokenBucketRateLimiter
+}
+
+// newRateLimitedTransport returns a rateLimitedTransport that delegates the requests to the transport provided. If the
+// transport provided is nil the default transport will be used.
+func newRateLimitedTransport(transport http.RoundTripper, limiter *tokenBucketRateLimiter) *rateLimitedTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &rateLimitedTransport{next: transport, limiter: limiter}
+}
+
+// RoundTrip waits until the rate limiter allows the request to be performed. If the request context is done while
+// waiting, the context error is returned and the request is not performed
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.limiter.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			t.limiter.cancel()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucketRateLimiterReserve(t *testing.T) {
+	now := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	limiter := newTokenBucketRateLimiter(RateLimitConfiguration{RequestsPerSecond: 2, Burst: 2})
+	limiter.now = func() time.Time { return now }
+
+	assert.Equal(t, time.Duration(0), limiter.reserve(), "the first request of the burst should not wait")
+	assert.Equal(t, time.Duration(0), limiter.reserve(), "the second request of the burst should not wait")
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(), "the requests exceeding the burst should wait for a token")
+	assert.Equal(t, time.Second, limiter.reserve(), "concurrent requests should be served in order")
+
+	now = now.Add(time.Second)
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(), "the tokens borrowed should be paid back before new requests are allowed")
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, time.Duration(0), limiter.reserve())
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(), "the bucket should not hold more tokens than the burst")
+
+	limiter.cancel()
+	assert.Equal(t, 500*time.Millisecond, limiter.reserve(), "the token of a cancelled reservation should be given back")
+}
+
+func TestNewTokenBucketRateLimiterDefaultBurst(t *testing.T) {
+	limiter := newTokenBucketRateLimiter(RateLimitConfiguration{RequestsPerSecond: 0.5})
+	assert.Equal(t, float64(1), limiter.burst)
+	assert.Equal(t, float64(1), limiter.tokens, "the bucket should be full")
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	requests := 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	t.Run("nil transport delegates the requests to the default transport", func(t *testing.T) {
+		transport := newRateLimitedTransport(nil, newTokenBucketRateLimiter(RateLimitConfiguration{RequestsPerSecond: 1}))
+		assert.Equal(t, http.DefaultTransport, transport.next)
+	})
+
+	t.Run("the requests exceeding the rate limit are delayed", func(t *testing.T) {
+		requests = 0
+		httpClient := &http.Client{Transport: newRateLimitedTransport(nil, newTokenBucketRateLimiter(RateLimitConfiguration{RequestsPerSecond: 20, Burst: 1}))}
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			resp, err := httpClient.Get(api.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+		assert.Equal(t, 3, requests)
+		assert.True(t, time.Since(start) >= 100*time.Millisecond, "the second and third requests should have waited 50ms each")
+	})
+
+	t.Run("the request is not performed if its context is done while waiting", func(t *testing.T) {
+		requests = 0
+		limiter := newTokenBucketRateLimiter(RateLimitConfiguration{RequestsPerSecond: 0.1, Burst: 1})
+		limiter.reserve()
+		httpClient := &http.Client{Transport: newRateLimitedTransport(nil, limiter)}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequest(http.MethodGet, api.URL, nil)
+		require.NoError(t, err)
+		_, err = httpClient.Do(req.WithContext(ctx))
+		assert.Error(t, err)
+		assert.Equal(t, 0, requests)
+	})
+}
+
+func TestProviderFactoryGetHTTPClientWithRateLimit(t *testing.T) {
+	p := providerFactory{serviceConfiguration: &ServiceConfigStub{RateLimit: &RateLimitConfiguration{RequestsPerSecond: 10}}}
+	httpClient := p.getHTTPClient(nil, nil)
+	transport, ok := httpClient.Transport.(*rateLimitedTransport)
+	require.True(t, ok, "the rate limited transport should wrap the rest of the transports")
+	assert.Equal(t, float64(10), transport.limiter.ratePerSecond)
+
+	p = providerFactory{serviceConfiguration: &ServiceConfigStub{}}
+	httpClient = p.getHTTPClient(nil, nil)
+	_, ok = httpClient.Transport.(*rateLimitedTransport)
+	assert.False(t, ok, "the requests should not be rate limited if the rate limit is not configured")
+}
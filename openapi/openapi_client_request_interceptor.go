@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// RequestInterceptor defines a function that is invoked right before every API request performed by the OpenAPI
+// provider client is dispatched. The request passed in already contains all the headers configured by the provider
+// (including authentication headers, operation headers and the User-Agent header) and its body can be read normally,
+// enabling the interceptor to compute values derived from the final request (e,g: signatures) and add or modify
+// headers accordingly. If the interceptor returns an error the request will not be sent and the error will be
+// returned to the caller.
+type RequestInterceptor func(*http.Request) error
+
+// requestInterceptorTransport is a http.RoundTripper that calls the configured RequestInterceptor before delegating
+// the request to the next round tripper
+type requestInterceptorTransport struct {
+	interceptor RequestInterceptor
+	next        http.RoundTripper
+}
+
+// newHTTPClient returns the http.Client used by the provider to make the API requests. If the transport provided is nil
+// the default transport will be used. If the requestInterceptor provided is not nil, the client transport will call it
+// before every request is dispatched.
+func newHTTPClient(requestInterceptor RequestInterceptor, transport http.RoundTripper) *http.Client {
+	if requestInterceptor == nil {
+		return &http.Client{Transport: transport}
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{
+		Transport: &requestInterceptorTransport{
+			interceptor: requestInterceptor,
+			next:        transport,
+		},
+	}
+}
+
+// RoundTrip executes the interceptor against a copy of the request so the original request is not modified as per
+// the http.RoundTripper contract. The request body is buffered so the interceptor can read it and the body is still
+// sent to the API afterwards.
+func (t *requestInterceptorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	interceptedReq := new(http.Request)
+	*interceptedReq = *req
+	interceptedReq.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		interceptedReq.Header[k] = append([]string(nil), v...)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body for %s %s: %s", req.Method, req.URL, err)
+		}
+		interceptedReq.Body = newRequestBody(body)
+		interceptedReq.GetBody = func() (io.ReadCloser, error) {
+			return newRequestBody(body), nil
+		}
+	}
+	interceptorBody := interceptedReq.Body
+
+	if err := t.interceptor(interceptedReq); err != nil {
+		return nil, fmt.Errorf("request interceptor failed for %s %s: %s", req.Method, req.URL, err)
+	}
+
+	// If the interceptor did not replace the body, reset it as it may have been consumed by the interceptor
+	if req.Body != nil && interceptedReq.Body == interceptorBody {
+		interceptedReq.Body = newRequestBody(body)
+		interceptedReq.ContentLength = int64(len(body))
+	}
+	return t.next.RoundTrip(interceptedReq)
+}
+
+func newRequestBody(body []byte) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewReader(body))
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	Convey("Given a nil request interceptor", t, func() {
+		Convey("When newHTTPClient is called", func() {
+			client := newHTTPClient(nil, nil)
+			Convey("Then the client returned should use the default transport", func() {
+				So(client.Transport, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a request interceptor", t, func() {
+		interceptor := func(req *http.Request) error { return nil }
+		Convey("When newHTTPClient is called", func() {
+			client := newHTTPClient(interceptor, nil)
+			Convey("Then the client returned should use the request interceptor transport", func() {
+				So(client.Transport, ShouldHaveSameTypeAs, &requestInterceptorTransport{})
+				So(client.Transport.(*requestInterceptorTransport).next, ShouldEqual, http.DefaultTransport)
+			})
+		})
+	})
+	Convey("Given a custom transport", t, func() {
+		transport := &http.Transport{MaxIdleConnsPerHost: 50}
+		Convey("When newHTTPClient is called with a nil request interceptor", func() {
+			client := newHTTPClient(nil, transport)
+			Convey("Then the client returned should use the custom transport", func() {
+				So(client.Transport, ShouldEqual, transport)
+			})
+		})
+		Convey("When newHTTPClient is called with a request interceptor", func() {
+			client := newHTTPClient(func(req *http.Request) error { return nil }, transport)
+			Convey("Then the request interceptor transport should delegate the requests to the custom transport", func() {
+				So(client.Transport.(*requestInterceptorTransport).next, ShouldEqual, transport)
+			})
+		})
+	})
+}
+
+func TestRequestInterceptorTransportRoundTrip(t *testing.T) {
+	Convey("Given a request interceptor that reads the request body and sets a header derived from it", t, func() {
+		var receivedHeader, receivedBody string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedHeader = r.Header.Get("X-Signature")
+			b, _ := ioutil.ReadAll(r.Body)
+			receivedBody = string(b)
+		}))
+		defer api.Close()
+		interceptor := func(req *http.Request) error {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("X-Signature", "signed:"+string(b))
+			return nil
+		}
+		client := newHTTPClient(interceptor, nil)
+		Convey("When a request with a body is performed", func() {
+			req, _ := http.NewRequest(http.MethodPost, api.URL, bytes.NewBufferString(`{"name":"value"}`))
+			res, err := client.Do(req)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(res.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the API should receive the header set by the interceptor", func() {
+				So(receivedHeader, ShouldEqual, `signed:{"name":"value"}`)
+			})
+			Convey("And the API should receive the original body even though the interceptor consumed it", func() {
+				So(receivedBody, ShouldEqual, `{"name":"value"}`)
+			})
+			Convey("And the original request headers should not be modified", func() {
+				So(req.Header.Get("X-Signature"), ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a request interceptor that returns an error", t, func() {
+		apiCalled := false
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiCalled = true
+		}))
+		defer api.Close()
+		client := newHTTPClient(func(req *http.Request) error {
+			return errors.New("some interceptor error")
+		}, nil)
+		Convey("When a request is performed", func() {
+			req, _ := http.NewRequest(http.MethodGet, api.URL, nil)
+			_, err := client.Do(req)
+			Convey("Then the error returned should contain the interceptor error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "request interceptor failed for GET "+api.URL+": some interceptor error")
+			})
+			Convey("And the request should not have been sent to the API", func() {
+				So(apiCalled, ShouldBeFalse)
+			})
+		})
+	})
+}
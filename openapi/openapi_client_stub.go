@@ -12,13 +12,43 @@ type clientOpenAPIStub struct {
 	responseListPayload []map[string]interface{}
 	error               error
 	returnHTTPCode      int
+	returnBody          string
+	returnHeaders       http.Header
 	idReceived          string
 	parentIDsReceived   []string
+	// queryParametersReceived contains the query parameters received in the last List call
+	queryParametersReceived map[string][]string
 
-	funcPut func() (*http.Response, error)
+	funcPut  func() (*http.Response, error)
+	funcPost func() (*http.Response, error)
+	// postPayloadReceived contains the request payload received in the last Post call
+	postPayloadReceived interface{}
+	// funcPatch, if set, is called when Patch is invoked instead of returning the responsePayload
+	funcPatch func() (*http.Response, error)
+	// patchPayloadReceived contains the patch document received in the last Patch call
+	patchPayloadReceived interface{}
+	// deletePayloadReceived contains the request payload received in the last Delete call
+	deletePayloadReceived interface{}
+	// funcPreDelete, if set, is called when PreDelete is invoked instead of responding with 204 No Content
+	funcPreDelete func() (*http.Response, error)
+	// preDeletePayloadsReceived contains the request payloads received in the PreDelete calls
+	preDeletePayloadsReceived []interface{}
+	// actionsReceived and actionPayloadsReceived contain the names of the actions and the request payloads received in
+	// the PerformAction calls
+	actionsReceived        []string
+	actionPayloadsReceived []interface{}
+
+	// asyncOperationPayloads contains the payloads returned (in order) by the GetAsyncOperation calls, the last one is
+	// returned once the rest have been returned
+	asyncOperationPayloads     []map[string]interface{}
+	asyncOperationURLsReceived []string
 }
 
 func (c *clientOpenAPIStub) Post(resource SpecResource, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.postPayloadReceived = requestPayload
+	if c.funcPost != nil {
+		return c.funcPost()
+	}
 	if c.error != nil {
 		return nil, c.error
 	}
@@ -50,6 +80,25 @@ func (c *clientOpenAPIStub) Put(resource SpecResource, id string, requestPayload
 	return c.generateStubResponse(http.StatusOK), nil
 }
 
+func (c *clientOpenAPIStub) Patch(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.parentIDsReceived = parentIDs
+	c.patchPayloadReceived = requestPayload
+	if c.funcPatch != nil {
+		return c.funcPatch()
+	}
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = c.responsePayload
+	default:
+		panic("unexpected type")
+	}
+	return c.generateStubResponse(http.StatusOK), nil
+}
+
 func (c *clientOpenAPIStub) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
 	if c.error != nil {
 		return nil, c.error
@@ -67,13 +116,24 @@ func (c *clientOpenAPIStub) Get(resource SpecResource, id string, responsePayloa
 }
 
 func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	return c.ListWithQueryParameters(resource, nil, responsePayload, parentIDs...)
+}
+
+func (c *clientOpenAPIStub) ListWithQueryParameters(resource SpecResource, queryParameters map[string][]string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
 	if c.error != nil {
 		return nil, c.error
 	}
+	c.queryParametersReceived = queryParameters
 	c.parentIDsReceived = parentIDs
 	switch p := responsePayload.(type) {
 	case *[]map[string]interface{}:
 		*p = c.responseListPayload
+	case *listItemsStream:
+		for _, item := range c.responseListPayload {
+			if err := p.handler(item); err != nil {
+				return nil, err
+			}
+		}
 	default:
 		panic("unexpected type")
 	}
@@ -82,19 +142,68 @@ func (c *clientOpenAPIStub) List(resource SpecResource, responsePayload interfac
 }
 
 func (c *clientOpenAPIStub) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	return c.DeleteWithPayload(resource, id, nil, parentIDs...)
+}
+
+func (c *clientOpenAPIStub) PreDelete(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.preDeletePayloadsReceived = append(c.preDeletePayloadsReceived, requestPayload)
+	if c.funcPreDelete != nil {
+		return c.funcPreDelete()
+	}
+	return &http.Response{StatusCode: http.StatusNoContent, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func (c *clientOpenAPIStub) PerformAction(resource SpecResource, action *specResourceAction, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
 	if c.error != nil {
 		return nil, c.error
 	}
 	c.idReceived = id
+	c.actionsReceived = append(c.actionsReceived, action.name)
+	c.actionPayloadsReceived = append(c.actionPayloadsReceived, requestPayload)
+	return &http.Response{StatusCode: http.StatusAccepted, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func (c *clientOpenAPIStub) DeleteWithPayload(resource SpecResource, id string, requestPayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.idReceived = id
+	c.deletePayloadReceived = requestPayload
 	c.parentIDsReceived = parentIDs
 	delete(c.responsePayload, id)
 	return c.generateStubResponse(http.StatusNoContent), nil
 }
 
+func (c *clientOpenAPIStub) GetAsyncOperation(resource SpecResource, operationURL string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	if c.error != nil {
+		return nil, c.error
+	}
+	c.parentIDsReceived = parentIDs
+	c.asyncOperationURLsReceived = append(c.asyncOperationURLsReceived, operationURL)
+	payload := map[string]interface{}{}
+	if len(c.asyncOperationPayloads) > 0 {
+		payload = c.asyncOperationPayloads[0]
+		if len(c.asyncOperationPayloads) > 1 {
+			c.asyncOperationPayloads = c.asyncOperationPayloads[1:]
+		}
+	}
+	switch p := responsePayload.(type) {
+	case *map[string]interface{}:
+		*p = payload
+	default:
+		panic("unexpected type")
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("")), Header: http.Header{}}, nil
+}
+
 func (c *clientOpenAPIStub) generateStubResponse(defaultHTTPCode int) *http.Response {
 	return &http.Response{
 		StatusCode: c.returnCode(defaultHTTPCode),
-		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Body:       ioutil.NopCloser(strings.NewReader(c.returnBody)),
+		Header:     c.returnHeaders,
 	}
 }
 
@@ -3,8 +3,10 @@ package openapi
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -107,6 +109,36 @@ func TestAppendOperationHeaders(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a providerClient set up with a provider configuration containing a value for a header that is a resource attribute", t, func() {
+		providerClient := &ProviderClient{
+			providerConfiguration: providerConfiguration{
+				Headers: map[string]string{"x_request_tier": "providerValue"},
+			},
+		}
+		Convey("When appendOperationHeaders is called with the header carrying the value configured in the resource", func() {
+			headersMap := map[string]string{}
+			err := providerClient.appendOperationHeaders(SpecHeaderParameters{{Name: "X-Request-Tier", IsResourceAttribute: true, value: "premium"}}, headersMap)
+			Convey("Then the header should be sent with the value configured in the resource", func() {
+				So(err, ShouldBeNil)
+				So(headersMap, ShouldResemble, map[string]string{"X-Request-Tier": "premium"})
+			})
+		})
+		Convey("When appendOperationHeaders is called with the optional header without value", func() {
+			headersMap := map[string]string{}
+			err := providerClient.appendOperationHeaders(SpecHeaderParameters{{Name: "X-Request-Tier", IsResourceAttribute: true}}, headersMap)
+			Convey("Then the header should not be sent", func() {
+				So(err, ShouldBeNil)
+				So(headersMap, ShouldBeEmpty)
+			})
+		})
+		Convey("When appendOperationHeaders is called with the required header without value", func() {
+			err := providerClient.appendOperationHeaders(SpecHeaderParameters{{Name: "X-Request-Tier", IsResourceAttribute: true, IsRequired: true}}, map[string]string{})
+			Convey("Then the error should point to the resource configuration", func() {
+				So(err.Error(), ShouldEqual, "required header 'X-Request-Tier' is missing the value. Please make sure the property 'x_request_tier' is configured with a value in the resource's terraform configuration")
+			})
+		})
+	})
 }
 
 func TestAppendUserAgentHeader(t *testing.T) {
@@ -368,6 +400,44 @@ func TestGetResourceURL(t *testing.T) {
 			})
 		})
 
+		Convey("When getResourceURL is called and the provider configuration contains an api base url", func() {
+			providerClient.providerConfiguration.APIBaseURL = "http://localhost:8080/mock"
+			specStubResource := &specStubResource{
+				name: "resource",
+				path: "/v1/resource",
+				host: "some.resource.override.com",
+			}
+			providerClient.providerConfiguration.Endpoints = map[string]string{"resource": "some.endpoint.override.com"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then resourceURL should use the api base url instead of the host, base path and overrides", func() {
+				So(resourceURL, ShouldEqual, "http://localhost:8080/mock/v1/resource")
+			})
+		})
+
+		Convey("When getResourceURL is called and the provider configuration contains an endpoint for one of the resource tags", func() {
+			specStubResource := &specStubResource{
+				name: "resource",
+				path: "/v1/resource",
+				host: "some.resource.override.com",
+				tags: []string{"compute", "billing"},
+			}
+			providerClient.providerConfiguration.TagEndpoints = map[string]string{"billing": "billing.endpoint.override.com"}
+			resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+			Convey("Then the resourceURL should use the tag endpoint instead of the resource host override", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://billing.endpoint.override.com/api/v1/resource")
+			})
+			Convey("And the resource endpoint should take preference over the tag endpoint", func() {
+				providerClient.providerConfiguration.Endpoints = map[string]string{"resource": "some.endpoint.override.com"}
+				resourceURL, err := providerClient.getResourceURL(specStubResource, []string{})
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://some.endpoint.override.com/api/v1/resource")
+			})
+		})
+
 		Convey("When getResourceURL is called with a resource which blows up on getResourcePath", func() {
 			specStubResource := &specStubResource{
 				funcGetResourcePath: func(parentIDs []string) (string, error) { return "", errors.New("getResourcePath blew up") },
@@ -679,6 +749,28 @@ func TestGetResourceURL(t *testing.T) {
 		})
 	})
 
+	Convey("Given a providerClient set up with a backend configuration that is multi-region and the region field being filled in", t, func() {
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       "wwww.%s.host.com",
+				basePath:   "/api",
+				httpScheme: "http",
+				regions:    []string{"us-west1", "us-east1"},
+			},
+			httpClient:            &http_goclient.HttpClientStub{},
+			providerConfiguration: providerConfiguration{Region: "us-west1"},
+			apiAuthenticator:      &specStubAuthenticator{},
+		}
+		Convey("When getResourceURL is called with a resource configured with a different region", func() {
+			resource := &specRegionalResource{SpecResource: &specStubResource{path: "/v1/resource"}, region: "us-east1"}
+			resourceURL, err := providerClient.getResourceURL(resource, []string{})
+			Convey("Then the resourceURL should use the host of the resource region", func() {
+				So(err, ShouldBeNil)
+				So(resourceURL, ShouldEqual, "http://wwww.us-east1.host.com/api/v1/resource")
+			})
+		})
+	})
+
 	Convey("Given a providerClient set up with a backend configuration that is multi-region and the region field being the default (pretending user did not provide value for provider's region property)", t, func() {
 		expectedRegion := "us-east1"
 		providerConfiguration := providerConfiguration{
@@ -726,7 +818,7 @@ func TestGetResourceURL(t *testing.T) {
 				basePath:   "/api",
 				httpScheme: "http",
 				regions:    []string{""},
-				err:        fmt.Errorf(expectedError),
+				err:        errors.New(expectedError),
 			},
 			httpClient:            &http_goclient.HttpClientStub{},
 			providerConfiguration: providerConfiguration{},
@@ -753,7 +845,7 @@ func TestGetResourceURL(t *testing.T) {
 				basePath:         "/api",
 				httpScheme:       "http",
 				regions:          []string{"us-east1"},
-				defaultRegionErr: fmt.Errorf(expectedError),
+				defaultRegionErr: errors.New(expectedError),
 			},
 			httpClient:            &http_goclient.HttpClientStub{},
 			providerConfiguration: providerConfiguration,
@@ -780,7 +872,7 @@ func TestGetResourceURL(t *testing.T) {
 				basePath:        "/api",
 				httpScheme:      "http",
 				regions:         []string{"us-east1"},
-				hostByRegionErr: fmt.Errorf(expectedError),
+				hostByRegionErr: errors.New(expectedError),
 			},
 			httpClient:            &http_goclient.HttpClientStub{},
 			providerConfiguration: providerConfiguration,
@@ -806,7 +898,7 @@ func TestGetResourceURL(t *testing.T) {
 				basePath:   "/api",
 				httpScheme: "http",
 				regions:    []string{},
-				hostErr:    fmt.Errorf(expectedError),
+				hostErr:    errors.New(expectedError),
 			},
 			httpClient:            &http_goclient.HttpClientStub{},
 			providerConfiguration: providerConfiguration{},
@@ -895,6 +987,38 @@ func TestPerformRequest(t *testing.T) {
 				So(httpClient.In.(map[string]interface{})[expectedReqPayloadProperty1], ShouldEqual, expectedReqPayloadProperty1Value)
 			})
 		})
+		Convey("When performRequest GET method is called and the providerClient is configured with a user agent suffix", func() {
+			providerClient.userAgentSuffix = "acme-cli/1.2"
+			resourceGetOperation := &specResourceOperation{
+				HeaderParameters: SpecHeaderParameters{},
+				responses:        specResponses{},
+				SecuritySchemes:  SpecSecuritySchemes{},
+			}
+			_, err := providerClient.performRequest("GET", "http://wwww.host.com/api/v1/resource/id", resourceGetOperation, nil, map[string]interface{}{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the User-Agent header with the suffix appended to the default user agent", func() {
+				So(httpClient.Headers[userAgentHeader], ShouldStartWith, "OpenAPI Terraform Provider")
+				So(httpClient.Headers[userAgentHeader], ShouldEndWith, " acme-cli/1.2")
+			})
+		})
+		Convey("When performRequest GET method is called and the providerClient is configured with a logger", func() {
+			logger := &loggerStub{}
+			providerClient.logger = logger
+			resourceGetOperation := &specResourceOperation{
+				HeaderParameters: SpecHeaderParameters{},
+				responses:        specResponses{},
+				SecuritySchemes:  SpecSecuritySchemes{},
+			}
+			_, err := providerClient.performRequest("GET", "http://wwww.host.com/api/v1/resource/id", resourceGetOperation, nil, map[string]interface{}{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then the logger should have received the request message", func() {
+				So(logger.containsMessage("DEBUG", "Performing GET http://wwww.host.com/api/v1/resource/id"), ShouldBeTrue)
+			})
+		})
 		Convey("When performRequest with a method that is not supported", func() {
 			resourcePostOperation := &specResourceOperation{
 				HeaderParameters: SpecHeaderParameters{},
@@ -944,6 +1068,511 @@ func TestPerformRequest(t *testing.T) {
 	})
 }
 
+func TestPerformRequestWithAlternativeSecurityRequirements(t *testing.T) {
+	Convey("Given a providerClient configured with a value for the query security definition only and an operation that supports header or query auth", t, func() {
+		var receivedRequest *http.Request
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedRequest = r
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		securityRequirements := createSecurityRequirements([]map[string][]string{{"header_auth": []string{}}, {"query_auth": []string{}}})
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"header_auth": createAPIKeyAuthenticator(newAPIKeyHeaderSecurityDefinition("header_auth", "X-API-Key"), ""),
+					"query_auth":  createAPIKeyAuthenticator(newAPIKeyQuerySecurityDefinition("query_auth", "api_key"), "someQueryValue"),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When performRequest is called", func() {
+			operation := &specResourceOperation{SecuritySchemes: securityRequirements[0], alternativeSecuritySchemes: securityRequirements[1:]}
+			_, err := providerClient.performRequest(httpGet, api.URL+"/v1/resource/id", operation, nil, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the request should have been authenticated using the alternative security requirement configured", func() {
+				So(receivedRequest.URL.Query().Get("api_key"), ShouldEqual, "someQueryValue")
+				So(receivedRequest.Header.Get("X-API-Key"), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+// closeRecordingTransport records the bodies of the responses received so the tests can check whether they were closed
+type closeRecordingTransport struct {
+	bodies []*closeRecordingBody
+}
+
+type closeRecordingBody struct {
+	io.ReadCloser
+	closed bool
+}
+
+func (b *closeRecordingBody) Close() error {
+	b.closed = true
+	return b.ReadCloser.Close()
+}
+
+func (t *closeRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body := &closeRecordingBody{ReadCloser: resp.Body}
+	t.bodies = append(t.bodies, body)
+	resp.Body = body
+	return resp, nil
+}
+
+func TestPerformRequestRefreshesCredentialsOnUnauthorized(t *testing.T) {
+	Convey("Given a providerClient configured with a token command authenticator and an API that rejects the first token", t, func() {
+		var receivedAuthHeaders []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedAuthHeaders = append(receivedAuthHeaders, r.Header.Get(authorizationHeader))
+			if len(receivedAuthHeaders) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		tokenCommand, cleanUp := newCountingTokenCommand(t, 0)
+		defer cleanUp()
+		securitySchemes := createSecuritySchemes([]map[string][]string{{"bearer_auth": []string{""}}})
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"bearer_auth": newAPITokenCommandAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), tokenCommand),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{SecuritySchemes: securitySchemes}, nil, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the response returned should be the one from the retried request", func() {
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the request should have been retried with a refreshed token", func() {
+				So(receivedAuthHeaders, ShouldResemble, []string{"Bearer token-1", "Bearer token-2"})
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a token command authenticator and an API that rejects the first token with an empty body", t, func() {
+		var receivedAuthHeaders []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedAuthHeaders = append(receivedAuthHeaders, r.Header.Get(authorizationHeader))
+			if len(receivedAuthHeaders) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"id":"someID"}`))
+		}))
+		defer api.Close()
+		tokenCommand, cleanUp := newCountingTokenCommand(t, 0)
+		defer cleanUp()
+		transport := &closeRecordingTransport{}
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{Transport: transport}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"bearer_auth": newAPITokenCommandAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), tokenCommand),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When performRequest is called with the GET method and a response payload", func() {
+			securitySchemes := createSecuritySchemes([]map[string][]string{{"bearer_auth": []string{""}}})
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.performRequest(httpGet, api.URL+"/v1/resource/id", &specResourceOperation{SecuritySchemes: securitySchemes}, nil, &responsePayload)
+			Convey("Then the request should have been retried with a refreshed token and the payload of the retried request returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(receivedAuthHeaders, ShouldResemble, []string{"Bearer token-1", "Bearer token-2"})
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "someID"})
+			})
+			Convey("And the body of the unauthorized response should have been closed before retrying the request", func() {
+				So(transport.bodies, ShouldHaveLength, 2)
+				So(transport.bodies[0].closed, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with token command authenticators for different security definitions and an API that rejects the first token", t, func() {
+		var receivedAuthHeaders []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedAuthHeaders = append(receivedAuthHeaders, r.Header.Get(authorizationHeader))
+			if len(receivedAuthHeaders) == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer api.Close()
+		tokenCommand, cleanUp := newCountingTokenCommand(t, 0)
+		defer cleanUp()
+		otherTokenCommand, otherCleanUp := newCountingTokenCommand(t, 0)
+		defer otherCleanUp()
+		otherToken, err := otherTokenCommand.getToken()
+		So(err, ShouldBeNil)
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+					"bearer_auth": newAPITokenCommandAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), tokenCommand),
+					"other_auth":  newAPITokenCommandAuthenticator(newAPIKeyHeaderSecurityDefinition("other_auth", "X-Other-Token"), otherTokenCommand),
+				},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When performRequest is called for an operation that only requires one of the security definitions", func() {
+			securitySchemes := createSecuritySchemes([]map[string][]string{{"bearer_auth": []string{""}}})
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{SecuritySchemes: securitySchemes}, nil, nil)
+			Convey("Then the request should have been retried with a refreshed token", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(receivedAuthHeaders, ShouldResemble, []string{"Bearer token-1", "Bearer token-2"})
+			})
+			Convey("And the credentials of the security definitions not used by the operation should not have been invalidated", func() {
+				token, err := otherTokenCommand.getToken()
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, otherToken)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with no refreshable authenticators and an API that returns unauthorized", t, func() {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration:       providerConfiguration{},
+			apiAuthenticator:            newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the unauthorized response should be returned without retrying the request", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusUnauthorized)
+				So(requests, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestPerformRequestRetries(t *testing.T) {
+	newProviderClient := func(retryConfiguration *RetryConfiguration) *ProviderClient {
+		return &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration:       providerConfiguration{},
+			apiAuthenticator:            newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+			retryConfiguration:          retryConfiguration,
+		}
+	}
+	newAPI := func(statusCodes ...int) (*httptest.Server, *int) {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			statusCode := statusCodes[len(statusCodes)-1]
+			if requests < len(statusCodes) {
+				statusCode = statusCodes[requests]
+			}
+			requests++
+			w.Header().Set(retryAfterHeader, "0")
+			w.WriteHeader(statusCode)
+		}))
+		return api, &requests
+	}
+
+	Convey("Given a providerClient configured with a retry policy and an API that returns retryable status codes before succeeding", t, func() {
+		api, requests := newAPI(http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK)
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{MaxRetries: newRetryMaxRetries(3), Backoff: "1ms"})
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the response returned should be the one from the request that succeeded", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(*requests, ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a retry policy and an API that returns retryable status codes with empty or HTML bodies before succeeding", t, func() {
+		requests := 0
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			switch requests {
+			case 1:
+				w.Header().Set(retryAfterHeader, "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			case 2:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case 3:
+				w.Header().Set(contentType, "text/html")
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte("<html><body>502 Bad Gateway</body></html>"))
+			default:
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"id":"someID"}`))
+			}
+		}))
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{MaxRetries: newRetryMaxRetries(3), Backoff: "1ms"})
+		for _, method := range []httpMethodSupported{httpGet, httpPost, httpPut} {
+			method := method
+			Convey(fmt.Sprintf("When performRequest is called with the %s method and a response payload", method), func() {
+				requests = 0
+				responsePayload := map[string]interface{}{}
+				resp, err := providerClient.performRequest(method, api.URL+"/v1/resource", &specResourceOperation{}, map[string]interface{}{"label": "label"}, &responsePayload)
+				Convey("Then the request should be retried and the payload of the request that succeeded should be returned", func() {
+					So(err, ShouldBeNil)
+					So(resp.StatusCode, ShouldEqual, http.StatusOK)
+					So(requests, ShouldEqual, 4)
+					So(responsePayload, ShouldResemble, map[string]interface{}{"id": "someID"})
+				})
+			})
+		}
+	})
+
+	Convey("Given a providerClient configured with a retry policy and an API that always returns a retryable status code", t, func() {
+		api, requests := newAPI(http.StatusServiceUnavailable)
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{MaxRetries: newRetryMaxRetries(2), Backoff: "1ms"})
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the response of the last retry should be returned once the max retries are reached", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(*requests, ShouldEqual, 3)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a retry policy and an API that returns a status code that is not retryable", t, func() {
+		api, requests := newAPI(http.StatusNotFound)
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{Backoff: "1ms"})
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the response should be returned without retrying the request", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a retry policy with zero max retries and an API that returns a retryable status code", t, func() {
+		api, requests := newAPI(http.StatusServiceUnavailable)
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{MaxRetries: newRetryMaxRetries(0), Backoff: "1ms"})
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the response should be returned without retrying the request", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a providerClient without a retry policy and an operation that configures its own retry policy", t, func() {
+		api, requests := newAPI(http.StatusConflict, http.StatusOK)
+		defer api.Close()
+		providerClient := newProviderClient(nil)
+		operation := &specResourceOperation{retry: &RetryConfiguration{Backoff: "1ms", StatusCodes: []int{http.StatusConflict}}}
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", operation, nil, nil)
+			Convey("Then the request should be retried as per the operation retry policy", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(*requests, ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given a providerClient configured with a retry policy and an operation that disables the retries", t, func() {
+		api, requests := newAPI(http.StatusServiceUnavailable)
+		defer api.Close()
+		providerClient := newProviderClient(&RetryConfiguration{Backoff: "1ms"})
+		operation := &specResourceOperation{retry: &RetryConfiguration{disabled: true}}
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", operation, nil, nil)
+			Convey("Then the response should be returned without retrying the request", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey("Given a providerClient without a retry policy and an API that returns a retryable status code", t, func() {
+		api, requests := newAPI(http.StatusServiceUnavailable)
+		defer api.Close()
+		providerClient := newProviderClient(nil)
+		Convey("When performRequest is called", func() {
+			resp, err := providerClient.performRequest(httpDelete, api.URL+"/v1/resource/id", &specResourceOperation{}, nil, nil)
+			Convey("Then the response should be returned without retrying the request", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusServiceUnavailable)
+				So(*requests, ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestAppendConfiguredQueryParameters(t *testing.T) {
+	Convey("Given a providerClient configured with default query params", t, func() {
+		providerClient := &ProviderClient{
+			providerConfiguration: providerConfiguration{
+				DefaultQueryParams: map[string]string{"apiVersion": "2023-01-01", "format": "json"},
+			},
+		}
+		Convey("When appendConfiguredQueryParameters is called with an operation that does not contain query parameters", func() {
+			resourceURL := providerClient.appendConfiguredQueryParameters("http://host.com/v1/resource", &specResourceOperation{})
+			Convey("Then the resource URL returned should contain the default query params", func() {
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?apiVersion=2023-01-01&format=json")
+			})
+		})
+		Convey("When appendConfiguredQueryParameters is called with an operation that overrides one of the query parameters", func() {
+			resourceURL := providerClient.appendConfiguredQueryParameters("http://host.com/v1/resource", &specResourceOperation{queryParameters: map[string][]string{"apiVersion": {"2024-01-01"}, "other": {"some value"}}})
+			Convey("Then the resource URL returned should contain the operation query parameter values", func() {
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?apiVersion=2024-01-01&format=json&other=some+value")
+			})
+		})
+		Convey("When appendConfiguredQueryParameters is called with a resource URL that already contains one of the query parameters", func() {
+			resourceURL := providerClient.appendConfiguredQueryParameters("http://host.com/v1/resource?apiVersion=2022-01-01", &specResourceOperation{})
+			Convey("Then the query parameter already present should not be duplicated", func() {
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?apiVersion=2022-01-01&format=json")
+			})
+		})
+		Convey("When appendConfiguredQueryParameters is called with an operation containing array query parameters", func() {
+			operation := &specResourceOperation{
+				queryParameters:   map[string][]string{"status": {"active", "pending"}, "tags": {"foo", "bar"}},
+				collectionFormats: map[string]collectionFormat{"status": collectionFormatMulti},
+			}
+			resourceURL := providerClient.appendConfiguredQueryParameters("http://host.com/v1/resource", operation)
+			Convey("Then the array values should be encoded using the collection format of the query parameter, csv by default", func() {
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource?apiVersion=2023-01-01&format=json&status=active&status=pending&tags=foo,bar")
+			})
+		})
+	})
+	Convey("Given a providerClient that is not configured with default query params", t, func() {
+		providerClient := &ProviderClient{}
+		Convey("When appendConfiguredQueryParameters is called with an operation that does not contain query parameters", func() {
+			resourceURL := providerClient.appendConfiguredQueryParameters("http://host.com/v1/resource", &specResourceOperation{})
+			Convey("Then the resource URL returned should remain the same", func() {
+				So(resourceURL, ShouldEqual, "http://host.com/v1/resource")
+			})
+		})
+	})
+}
+
+func TestProviderClientCRUDDefaultQueryParams(t *testing.T) {
+	Convey("Given a providerClient configured with default query params and an API that records the request URLs", t, func() {
+		var requestURLs []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestURLs = append(requestURLs, fmt.Sprintf("%s %s", r.Method, r.URL.String()))
+			w.Write([]byte(`{}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient: &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			providerConfiguration: providerConfiguration{
+				DefaultQueryParams: map[string]string{"apiVersion": "2023-01-01"},
+			},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		specStubResource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		Convey("When the four CRUD operations are called", func() {
+			_, postErr := providerClient.Post(specStubResource, map[string]interface{}{}, nil)
+			_, getErr := providerClient.Get(specStubResource, "someID", nil)
+			_, putErr := providerClient.Put(specStubResource, "someID", map[string]interface{}{}, nil)
+			_, deleteErr := providerClient.Delete(specStubResource, "someID")
+			Convey("Then no errors should be returned", func() {
+				So(postErr, ShouldBeNil)
+				So(getErr, ShouldBeNil)
+				So(putErr, ShouldBeNil)
+				So(deleteErr, ShouldBeNil)
+			})
+			Convey("And all the request URLs should contain the default query params", func() {
+				So(requestURLs, ShouldResemble, []string{
+					"POST /v1/resource?apiVersion=2023-01-01",
+					"GET /v1/resource/someID?apiVersion=2023-01-01",
+					"PUT /v1/resource/someID?apiVersion=2023-01-01",
+					"DELETE /v1/resource/someID?apiVersion=2023-01-01",
+				})
+			})
+		})
+	})
+}
+
+func TestProviderClientGetIfNoneMatch(t *testing.T) {
+	Convey("Given a providerClient and an API that responds with 304 Not Modified if the If-None-Match header matches the ETag of the resource", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(ifNoneMatchHeader) == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set(etagHeader, `"v2"`)
+			w.Write([]byte(`{"name":"someName"}`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		newResource := func(ifNoneMatch string) SpecResource {
+			return &specResourceWithIfNoneMatch{SpecResource: newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}), ifNoneMatch: ifNoneMatch}
+		}
+		Convey("When Get is called with the current ETag of the resource", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Get(newResource(`"v1"`), "someID", &responsePayload)
+			Convey("Then the 304 response should be returned without error and the response payload should be empty", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNotModified)
+				So(responsePayload, ShouldBeEmpty)
+			})
+		})
+		Convey("When Get is called with an ETag that is not the current one", func() {
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Get(newResource(`"v0"`), "someID", &responsePayload)
+			Convey("Then the resource and its ETag should be returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+				So(resp.Header.Get(etagHeader), ShouldEqual, `"v2"`)
+				So(responsePayload, ShouldResemble, map[string]interface{}{"name": "someName"})
+			})
+		})
+	})
+}
+
 func TestProviderClientPost(t *testing.T) {
 
 	Convey("Given a providerClient set up with stub auth that injects some headers to the request", t, func() {
@@ -1217,6 +1846,59 @@ func TestProviderClientPut(t *testing.T) {
 
 }
 
+func TestProviderClientGetAsyncOperation(t *testing.T) {
+	Convey("Given a providerClient set up with stub client that returns some response", t, func() {
+		httpClient := &http_goclient.HttpClientStub{
+			Response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader(`{"status":"Succeeded"}`)),
+			},
+		}
+		expectedHeader := "Authentication"
+		expectedHeaderValue := "Bearer secret!"
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("wwww.host.com", "/api", "http"),
+			httpClient:                  httpClient,
+			providerConfiguration:       providerConfiguration{},
+			apiAuthenticator:            newStubAuthenticator(expectedHeader, expectedHeaderValue, nil),
+		}
+		specStubResource := &specStubResource{
+			path:                 "/v1/resource",
+			resourceGetOperation: &specResourceOperation{},
+		}
+		Convey("When providerClient GetAsyncOperation method is called with a relative operation URL", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.GetAsyncOperation(specStubResource, "/api/v1/operations/1", &responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the operation URL resolved against the resource URL", func() {
+				So(httpClient.URL, ShouldEqual, "http://wwww.host.com/api/v1/operations/1")
+			})
+			Convey("And then client should have received the right Authentication header and expected value", func() {
+				So(httpClient.Headers[expectedHeader], ShouldEqual, expectedHeaderValue)
+			})
+		})
+		Convey("When providerClient GetAsyncOperation method is called with an absolute operation URL", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.GetAsyncOperation(specStubResource, "https://operations.host.com/v1/operations/1", &responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the operation URL as is", func() {
+				So(httpClient.URL, ShouldEqual, "https://operations.host.com/v1/operations/1")
+			})
+		})
+		Convey("When providerClient GetAsyncOperation method is called with an operation URL that is not valid", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.GetAsyncOperation(specStubResource, "://operations", &responsePayload)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "asynchronous operation URL '://operations' is not valid")
+			})
+		})
+	})
+}
+
 func TestProviderClientGet(t *testing.T) {
 
 	Convey("Given a providerClient set up with stub client that returns some response", t, func() {
@@ -1336,6 +2018,63 @@ func TestProviderClientGet(t *testing.T) {
 
 }
 
+func TestProviderClientGetBinary(t *testing.T) {
+	Convey("Given a providerClient and a resource which GET operation returns binary blobs", t, func() {
+		httpClient := &http_goclient.HttpClientStub{}
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("wwww.host.com", "/api", "http"),
+			httpClient:                  httpClient,
+			apiAuthenticator:            newStubAuthenticator("Authentication", "Bearer secret!", nil),
+		}
+		specStubResource := &specStubResource{
+			path:                 "/v1/certificates",
+			resourceGetOperation: &specResourceOperation{responseMediaType: mediaTypeOctetStream},
+		}
+		Convey("When providerClient GET method is called and the API returns a binary blob", func() {
+			httpClient.Response = &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{contentType: []string{mediaTypeOctetStream}},
+				Body:       ioutil.NopCloser(strings.NewReader("-----BEGIN CERTIFICATE-----")),
+			}
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Get(specStubResource, "1234", &responsePayload)
+			Convey("Then the response payload should contain the base64 encoded blob", func() {
+				So(err, ShouldBeNil)
+				So(responsePayload, ShouldResemble, map[string]interface{}{binaryContentPropertyName: "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"})
+			})
+			Convey("And the request should accept binary blobs", func() {
+				So(httpClient.Headers[acceptHeader], ShouldEqual, "application/octet-stream, application/json")
+			})
+		})
+		Convey("When providerClient GET method is called and the API returns JSON", func() {
+			httpClient.Response = &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{contentType: []string{"application/json; charset=utf-8"}},
+				Body:       ioutil.NopCloser(strings.NewReader(`{"id":"1234"}`)),
+			}
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Get(specStubResource, "1234", &responsePayload)
+			Convey("Then the response payload should be the JSON returned by the API", func() {
+				So(err, ShouldBeNil)
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234"})
+			})
+		})
+		Convey("When providerClient GET method is called and the API returns a non successful response", func() {
+			httpClient.Response = &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"message":"not found"}`)),
+			}
+			responsePayload := map[string]interface{}{}
+			resp, err := providerClient.Get(specStubResource, "1234", &responsePayload)
+			Convey("Then the response should be returned as is", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+				So(responsePayload, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestProviderClientList(t *testing.T) {
 	Convey("Given a providerClient set up with stub client that returns some response", t, func() {
 		httpClient := &http_goclient.HttpClientStub{
@@ -1457,6 +2196,138 @@ func TestProviderClientList(t *testing.T) {
 
 }
 
+func TestProviderClientListWithQueryParameters(t *testing.T) {
+	Convey("Given a providerClient set up with stub client that returns some response", t, func() {
+		httpClient := &http_goclient.HttpClientStub{
+			Response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader(`[]`)),
+			},
+		}
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: newStubBackendConfiguration("wwww.host.com", "/api", "http"),
+			httpClient:                  httpClient,
+			providerConfiguration:       providerConfiguration{},
+			apiAuthenticator:            newStubAuthenticator("", "", nil),
+		}
+		Convey("When providerClient ListWithQueryParameters method is called with some query parameters", func() {
+			specStubResource := &specStubResource{
+				path: "/v1/resource",
+				resourceListOperation: &specResourceOperation{
+					responses:       specResponses{},
+					SecuritySchemes: SpecSecuritySchemes{},
+				},
+			}
+			responsePayload := []map[string]interface{}{}
+			_, err := providerClient.ListWithQueryParameters(specStubResource, map[string][]string{"name": {"my name"}, "label": {"some label"}}, &responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then client should have received the URL with the query parameters sorted by name", func() {
+				So(httpClient.URL, ShouldEqual, "http://wwww.host.com/api/v1/resource?label=some+label&name=my+name")
+			})
+		})
+	})
+	Convey("Given a providerClient and a List operation with array query parameters configured with different collection formats", t, func() {
+		var rawQuery string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rawQuery = r.URL.RawQuery
+			w.Write([]byte(`[]`))
+		}))
+		defer api.Close()
+		providerClient := newTestAPIProviderClient(api.URL)
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfQueryParams: map[string]interface{}{"status": []interface{}{"active", "pending"}},
+				},
+			},
+			OperationProps: spec.OperationProps{
+				Parameters: []spec.Parameter{
+					{ParamProps: spec.ParamProps{Name: "status", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "multi"}},
+					{ParamProps: spec.ParamProps{Name: "csv", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array"}},
+					{ParamProps: spec.ParamProps{Name: "ssv", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "ssv"}},
+					{ParamProps: spec.ParamProps{Name: "tsv", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "tsv"}},
+					{ParamProps: spec.ParamProps{Name: "pipes", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "pipes"}},
+				},
+				Responses: &spec.Responses{},
+			},
+		}
+		specStubResource := &specStubResource{
+			path:                  "/v1/resource",
+			resourceListOperation: (&SpecV2Resource{}).createResourceOperation(operation),
+		}
+		Convey("When providerClient ListWithQueryParameters method is called with array values for the query parameters", func() {
+			queryParameters := map[string][]string{
+				"csv":   {"a", "b"},
+				"ssv":   {"a", "b"},
+				"tsv":   {"a", "b"},
+				"pipes": {"a", "b"},
+			}
+			responsePayload := []map[string]interface{}{}
+			_, err := providerClient.ListWithQueryParameters(specStubResource, queryParameters, &responsePayload)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And then the API should have received the array values encoded using the collection format of each query parameter", func() {
+				So(rawQuery, ShouldEqual, "csv=a,b&pipes=a%7Cb&ssv=a%20b&tsv=a%09b&status=active&status=pending")
+			})
+		})
+	})
+}
+
+func TestProviderClientListStream(t *testing.T) {
+	Convey("Given a providerClient and an API that returns a list of items", t, func() {
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v1/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"not found"}`))
+				return
+			}
+			w.Write([]byte(`[{"id":"1","label":"first"},{"id":"2","label":"second"}]`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		var items []map[string]interface{}
+		stream := newListItemsStream(func(item map[string]interface{}) error {
+			items = append(items, item)
+			return nil
+		})
+		Convey("When providerClient List method is called with a list items stream", func() {
+			specStubResource := newSpecStubResourceWithOperations("resource", "/v1/resource", false, nil, nil, nil, nil, nil)
+			specStubResource.resourceListOperation = &specResourceOperation{}
+			resp, err := providerClient.List(specStubResource, stream)
+			Convey("Then the error returned should be nil and the response should be the expected", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+			Convey("And the stream handler should have received the items returned by the API", func() {
+				So(items, ShouldResemble, []map[string]interface{}{{"id": "1", "label": "first"}, {"id": "2", "label": "second"}})
+			})
+		})
+		Convey("When providerClient List method is called with a list items stream and the API returns an error", func() {
+			specStubResource := newSpecStubResourceWithOperations("missing", "/v1/missing", false, nil, nil, nil, nil, nil)
+			specStubResource.resourceListOperation = &specResourceOperation{}
+			resp, err := providerClient.List(specStubResource, stream)
+			Convey("Then the error returned should be nil and no items should be handed over to the stream", func() {
+				So(err, ShouldBeNil)
+				So(items, ShouldBeEmpty)
+			})
+			Convey("And the response body should still contain the error returned by the API", func() {
+				So(resp.StatusCode, ShouldEqual, http.StatusNotFound)
+				body, _ := ioutil.ReadAll(resp.Body)
+				So(string(body), ShouldEqual, `{"message":"not found"}`)
+			})
+		})
+	})
+}
+
 func TestProviderClientDelete(t *testing.T) {
 
 	Convey("Given a providerClient set up with stub client that returns some response", t, func() {
@@ -1571,3 +2442,35 @@ func TestProviderClientDelete(t *testing.T) {
 		})
 	})
 }
+
+func TestProviderClientPerformAction(t *testing.T) {
+	Convey("Given a providerClient and an API that records the requests and responds with accepted", t, func() {
+		var requestReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestReceived = r.Method + " " + r.URL.Path
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		Convey("When providerClient PerformAction method is called with an action of the resource", func() {
+			resource := newSpecStubResourceWithOperations("server", "/v1/servers", false, nil, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+			action := &specResourceAction{name: "reboot", method: httpPost, pathSuffix: "/reboot", operation: &specResourceOperation{}}
+			resp, err := providerClient.PerformAction(resource, action, "1234", map[string]interface{}{"type": "soft"})
+			Convey("Then the request should be sent to the action path of the resource instance", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusAccepted)
+				So(requestReceived, ShouldEqual, "POST /v1/servers/1234/reboot")
+				So(bodyReceived, ShouldEqual, `{"type":"soft"}`)
+			})
+		})
+	})
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// collectionFormat defines the format of array values sent as query parameters as described in the OpenAPI
+// specification: https://swagger.io/specification/v2/#parameterObject
+type collectionFormat string
+
+const (
+	// collectionFormatCSV comma separated values (e,g: foo,bar). This is the default value as per the OpenAPI specification
+	collectionFormatCSV collectionFormat = "csv"
+	// collectionFormatSSV space separated values (e,g: foo bar)
+	collectionFormatSSV collectionFormat = "ssv"
+	// collectionFormatTSV tab separated values (e,g: foo\tbar)
+	collectionFormatTSV collectionFormat = "tsv"
+	// collectionFormatPipes pipe separated values (e,g: foo|bar)
+	collectionFormatPipes collectionFormat = "pipes"
+	// collectionFormatMulti corresponds to multiple parameter instances instead of multiple values for a single instance
+	// (e,g: name=foo&name=bar)
+	collectionFormatMulti collectionFormat = "multi"
+)
+
+// newCollectionFormat returns the collectionFormat for the given value, falling back to csv if the value is empty
+func newCollectionFormat(format string) (collectionFormat, error) {
+	switch c := collectionFormat(format); c {
+	case "":
+		return collectionFormatCSV, nil
+	case collectionFormatCSV, collectionFormatSSV, collectionFormatTSV, collectionFormatPipes, collectionFormatMulti:
+		return c, nil
+	}
+	return "", fmt.Errorf("collectionFormat '%s' not supported, supported values are: csv, ssv, tsv, pipes and multi", format)
+}
+
+// encodedDelimiter returns the delimiter used to join the array values already URL encoded so it can be used as is
+// when building the URL
+func (c collectionFormat) encodedDelimiter() string {
+	switch c {
+	case collectionFormatSSV:
+		return "%20"
+	case collectionFormatTSV:
+		return "%09"
+	case collectionFormatPipes:
+		return "%7C"
+	}
+	return ","
+}
+
+// queryParameter describes a query parameter to be appended to the request URL
+type queryParameter struct {
+	name             string
+	values           []string
+	collectionFormat collectionFormat
+}
+
+// encode returns the URL encoded query string for the parameter according to the collection format. Parameters with
+// multi collection format will be encoded as multiple parameter instances (e,g: name=foo&name=bar)
+func (q queryParameter) encode() string {
+	name := url.QueryEscape(q.name)
+	encodedValues := make([]string, len(q.values))
+	for i, value := range q.values {
+		encodedValues[i] = url.QueryEscape(value)
+	}
+	if q.collectionFormat == collectionFormatMulti {
+		for i, value := range encodedValues {
+			encodedValues[i] = fmt.Sprintf("%s=%s", name, value)
+		}
+		return strings.Join(encodedValues, "&")
+	}
+	return fmt.Sprintf("%s=%s", name, strings.Join(encodedValues, q.collectionFormat.encodedDelimiter()))
+}
+
+// appendQueryParameters returns the resource URL with the given query parameters appended, taking into account whether
+// the URL already contains a query string. Parameters with multi collection format and no values are ignored
+func appendQueryParameters(resourceURL string, queryParameters ...queryParameter) string {
+	for _, queryParameter := range queryParameters {
+		encodedQueryParameter := queryParameter.encode()
+		if encodedQueryParameter == "" {
+			continue
+		}
+		separator := "?"
+		if strings.Contains(resourceURL, "?") {
+			separator = "&"
+		}
+		resourceURL = fmt.Sprintf("%s%s%s", resourceURL, separator, encodedQueryParameter)
+	}
+	return resourceURL
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCollectionFormat(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		format                   string
+		expectedCollectionFormat collectionFormat
+		expectedErr              error
+	}{
+		{name: "empty format defaults to csv", format: "", expectedCollectionFormat: collectionFormatCSV},
+		{name: "csv format", format: "csv", expectedCollectionFormat: collectionFormatCSV},
+		{name: "ssv format", format: "ssv", expectedCollectionFormat: collectionFormatSSV},
+		{name: "tsv format", format: "tsv", expectedCollectionFormat: collectionFormatTSV},
+		{name: "pipes format", format: "pipes", expectedCollectionFormat: collectionFormatPipes},
+		{name: "multi format", format: "multi", expectedCollectionFormat: collectionFormatMulti},
+		{name: "not supported format", format: "json", expectedErr: errors.New("collectionFormat 'json' not supported, supported values are: csv, ssv, tsv, pipes and multi")},
+	}
+	for _, tc := range testCases {
+		format, err := newCollectionFormat(tc.format)
+		assert.Equal(t, tc.expectedCollectionFormat, format, tc.name)
+		assert.Equal(t, tc.expectedErr, err, tc.name)
+	}
+}
+
+func TestAppendQueryParameters(t *testing.T) {
+	testCases := []struct {
+		name            string
+		resourceURL     string
+		queryParameters []queryParameter
+		expectedURL     string
+	}{
+		{
+			name:            "csv format",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatCSV}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo,bar",
+		},
+		{
+			name:            "ssv format",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatSSV}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo%20bar",
+		},
+		{
+			name:            "tsv format",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatTSV}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo%09bar",
+		},
+		{
+			name:            "pipes format",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatPipes}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo%7Cbar",
+		},
+		{
+			name:            "multi format",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatMulti}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo&tags=bar",
+		},
+		{
+			name:            "multi format with no values is ignored",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{}, collectionFormat: collectionFormatMulti}},
+			expectedURL:     "https://www.host.com/v1/resource",
+		},
+		{
+			name:            "values containing characters that need escaping",
+			resourceURL:     "https://www.host.com/v1/resource",
+			queryParameters: []queryParameter{{name: "tags", values: []string{"foo,1", "bar&2"}, collectionFormat: collectionFormatCSV}},
+			expectedURL:     "https://www.host.com/v1/resource?tags=foo%2C1,bar%262",
+		},
+		{
+			name:        "multiple query parameters appended to a URL already containing a query string",
+			resourceURL: "https://www.host.com/v1/resource?api_key=secret",
+			queryParameters: []queryParameter{
+				{name: "tags", values: []string{"foo", "bar"}, collectionFormat: collectionFormatPipes},
+				{name: "ids", values: []string{"1", "2"}, collectionFormat: collectionFormatMulti},
+			},
+			expectedURL: "https://www.host.com/v1/resource?api_key=secret&tags=foo%7Cbar&ids=1&ids=2",
+		},
+	}
+	for _, tc := range testCases {
+		resourceURL := appendQueryParameters(tc.resourceURL, tc.queryParameters...)
+		assert.Equal(t, tc.expectedURL, resourceURL, tc.name)
+	}
+}
@@ -0,0 +1,316 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// sendXMLRequest sends the request of the operations that consume or produce XML: the request payload, which must be an
+// object, is sent as an XML document if the operation consumes XML (JSON otherwise) and the XML documents returned in
+// the successful responses are converted into the response payload
+func (o *ProviderClient) sendXMLRequest(method httpMethodSupported, operation *specResourceOperation, reqContext *authContext, requestPayload interface{}, responsePayload interface{}) (*http.Response, error) {
+	bodyClient, err := o.getBodyClient(method, mediaTypeXML)
+	if err != nil {
+		return nil, err
+	}
+	var body io.Reader
+	bodyContentType := ""
+	if method != httpGet && requestPayload != nil {
+		var document []byte
+		if isXMLMediaType(operation.requestMediaType) {
+			properties, ok := requestPayload.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s request payloads must be objects (%T)", operation.requestMediaType, requestPayload)
+			}
+			document, err = newXMLDocument(properties, operation.xmlSchema)
+			bodyContentType = operation.requestMediaType
+		} else {
+			document, err = json.Marshal(requestPayload)
+			bodyContentType = mediaTypeJSON
+		}
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(document)
+	}
+	if isXMLMediaType(operation.responseMediaType) {
+		reqContext.headers[acceptHeader] = operation.responseMediaType
+	}
+	resp, err := bodyClient.SendBody(string(method), reqContext.url, reqContext.headers, body, bodyContentType, nil)
+	if err != nil || responsePayload == nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, err
+	}
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	if len(bytes.TrimSpace(responseBody)) == 0 {
+		return resp, nil
+	}
+	if isXMLMediaType(operation.responseMediaType) {
+		properties, err := parseXMLDocument(responseBody, operation.xmlSchema)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal XML response body ['%s'] for request = '%s %s'. Response = '%s'", err, method, reqContext.url, resp.Status)
+		}
+		// the properties are converted into the response payload type the same way the JSON responses are
+		if responseBody, err = json.Marshal(properties); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(responseBody, responsePayload); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response body ['%s'] for request = '%s %s'. Response = '%s'", err, method, reqContext.url, resp.Status)
+	}
+	return resp, nil
+}
+
+// newXMLDocument returns the XML document representing the given properties, the root element and the elements and
+// attributes of the properties are named as per the XML schema (properties that are not described by the schema are
+// sent as elements named after the property). The properties are sent in alphabetical order and nil values are not sent
+func newXMLDocument(properties map[string]interface{}, xmlSchema *specXMLSchema) ([]byte, error) {
+	if xmlSchema == nil {
+		xmlSchema = &specXMLSchema{name: "resource"}
+	}
+	buffer := &bytes.Buffer{}
+	buffer.WriteString(xml.Header)
+	encoder := xml.NewEncoder(buffer)
+	if err := encodeXMLElement(encoder, xmlSchema, properties); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func encodeXMLElement(encoder *xml.Encoder, xmlSchema *specXMLSchema, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: xmlSchema.name}}
+	if xmlSchema.prefix != "" {
+		start.Name.Local = xmlSchema.prefix + ":" + xmlSchema.name
+		if xmlSchema.namespace != "" {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:" + xmlSchema.prefix}, Value: xmlSchema.namespace})
+		}
+	} else if xmlSchema.namespace != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: xmlSchema.namespace})
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var children []string
+		for _, name := range getSortedPropertyNames(v) {
+			property := xmlSchema.getProperty(name)
+			if v[name] == nil {
+				continue
+			}
+			if property.attribute {
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: property.name}, Value: getXMLText(v[name])})
+				continue
+			}
+			children = append(children, name)
+		}
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, name := range children {
+			if err := encodeXMLProperty(encoder, xmlSchema.getProperty(name), v[name]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeXMLElement(encoder, xmlSchema.getItems(), item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		if err := encoder.EncodeToken(xml.CharData(getXMLText(v))); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// encodeXMLProperty encodes the element of the given property value, the items of the lists that are not wrapped are
+// encoded as sibling elements
+func encodeXMLProperty(encoder *xml.Encoder, property *specXMLSchema, value interface{}) error {
+	if items, ok := value.([]interface{}); ok && !property.wrapped {
+		for _, item := range items {
+			if err := encodeXMLElement(encoder, property.getItems(), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return encodeXMLElement(encoder, property, value)
+}
+
+func getXMLText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// xmlElement is the generic representation of the elements of the XML documents returned by the API
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     string
+}
+
+// parseXMLDocument returns the properties represented by the given XML document. The children elements and attributes
+// of the root element are converted into properties as per the XML schema: the values are converted into the types of
+// the properties and the lists are built from the wrapped or sibling item elements. The elements that are not described
+// by the schema are converted into properties named after the element
+func parseXMLDocument(document []byte, xmlSchema *specXMLSchema) (map[string]interface{}, error) {
+	root, err := parseXMLElement(xml.NewDecoder(bytes.NewReader(document)))
+	if err != nil {
+		return nil, err
+	}
+	if xmlSchema == nil {
+		xmlSchema = &specXMLSchema{}
+	}
+	return decodeXMLObject(root, xmlSchema), nil
+}
+
+// parseXMLElement returns the next element read by the decoder along with its children
+func parseXMLElement(decoder *xml.Decoder) (*xmlElement, error) {
+	var stack []*xmlElement
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element := &xmlElement{name: t.Name.Local, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, element)
+			}
+			stack = append(stack, element)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			element.text = strings.TrimSpace(element.text)
+			if stack = stack[:len(stack)-1]; len(stack) == 0 {
+				return element, nil
+			}
+		}
+	}
+}
+
+func decodeXMLObject(element *xmlElement, xmlSchema *specXMLSchema) map[string]interface{} {
+	object := map[string]interface{}{}
+	described := map[string]bool{}
+	for propertyName, property := range xmlSchema.properties {
+		if property.attribute {
+			for _, attr := range element.attrs {
+				if attr.Name.Local == property.name {
+					object[propertyName] = decodeXMLText(attr.Value, property.schemaType)
+				}
+			}
+			continue
+		}
+		itemsName := property.name
+		if property.schemaType == "array" && !property.wrapped {
+			itemsName = property.getItems().name
+		}
+		described[itemsName] = true
+		if value, exists := decodeXMLProperty(element, property, itemsName); exists {
+			object[propertyName] = value
+		}
+	}
+	for _, child := range element.children {
+		if described[child.name] {
+			continue
+		}
+		if _, exists := object[child.name]; exists {
+			// repeated elements that are not described by the schema are considered a list
+			if list, ok := object[child.name].([]interface{}); ok {
+				object[child.name] = append(list, decodeXMLValue(child, &specXMLSchema{name: child.name}))
+			} else {
+				object[child.name] = []interface{}{object[child.name], decodeXMLValue(child, &specXMLSchema{name: child.name})}
+			}
+			continue
+		}
+		object[child.name] = decodeXMLValue(child, &specXMLSchema{name: child.name})
+	}
+	return object
+}
+
+// decodeXMLProperty returns the value of the given property contained in the children of the element, false is returned
+// if the element does not contain the property
+func decodeXMLProperty(element *xmlElement, property *specXMLSchema, elementName string) (interface{}, bool) {
+	var matches []*xmlElement
+	for _, child := range element.children {
+		if child.name == elementName {
+			matches = append(matches, child)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, false
+	}
+	if property.schemaType != "array" {
+		return decodeXMLValue(matches[0], property), true
+	}
+	items := matches
+	if property.wrapped {
+		items = matches[0].children
+	}
+	list := []interface{}{}
+	for _, item := range items {
+		list = append(list, decodeXMLValue(item, property.getItems()))
+	}
+	return list, true
+}
+
+func decodeXMLValue(element *xmlElement, xmlSchema *specXMLSchema) interface{} {
+	if xmlSchema.schemaType == "object" || (xmlSchema.schemaType == "" && (len(element.children) > 0 || len(element.attrs) > 0)) {
+		return decodeXMLObject(element, xmlSchema)
+	}
+	if xmlSchema.schemaType == "array" {
+		list := []interface{}{}
+		for _, item := range element.children {
+			list = append(list, decodeXMLValue(item, xmlSchema.getItems()))
+		}
+		return list
+	}
+	return decodeXMLText(element.text, xmlSchema.schemaType)
+}
+
+// decodeXMLText returns the text converted into the given type (numbers are returned as float64 as in the JSON
+// responses), the text is returned as is if it can not be converted
+func decodeXMLText(text string, schemaType string) interface{} {
+	switch schemaType {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(text); err == nil {
+			return boolean
+		}
+	}
+	return text
+}
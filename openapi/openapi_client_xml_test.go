@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dikhan/http_goclient"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewXMLDocument(t *testing.T) {
+	Convey("Given the XML schema of a resource with attributes, wrapped and sibling lists and nested objects", t, func() {
+		xmlSchema := &specXMLSchema{
+			name:       "user",
+			namespace:  "https://api.server.com/schema",
+			schemaType: "object",
+			properties: map[string]*specXMLSchema{
+				"id":      {name: "id", attribute: true, schemaType: "integer"},
+				"name":    {name: "fullName", schemaType: "string"},
+				"tags":    {name: "tags", wrapped: true, schemaType: "array", items: &specXMLSchema{name: "tag", schemaType: "string"}},
+				"emails":  {name: "emails", schemaType: "array", items: &specXMLSchema{name: "email", schemaType: "string"}},
+				"address": {name: "address", schemaType: "object", properties: map[string]*specXMLSchema{"city": {name: "city", schemaType: "string"}}},
+			},
+		}
+		payload := map[string]interface{}{
+			"id":      float64(1234),
+			"name":    "John <Doe>",
+			"tags":    []interface{}{"admin", "dev"},
+			"emails":  []interface{}{"john@doe.com", "jd@doe.com"},
+			"address": map[string]interface{}{"city": "Seattle"},
+			"label":   nil,
+		}
+		Convey("When newXMLDocument is called with a payload", func() {
+			document, err := newXMLDocument(payload, xmlSchema)
+			Convey("Then the document returned should represent the payload as per the XML schema", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEqual, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<user xmlns="https://api.server.com/schema" id="1234"><address><city>Seattle</city></address><email>john@doe.com</email><email>jd@doe.com</email><fullName>John &lt;Doe&gt;</fullName><tags><tag>admin</tag><tag>dev</tag></tags></user>`)
+			})
+			Convey("And parseXMLDocument should return the given payload when called with the document", func() {
+				properties, err := parseXMLDocument(document, xmlSchema)
+				So(err, ShouldBeNil)
+				delete(payload, "label")
+				So(properties, ShouldResemble, payload)
+			})
+		})
+		Convey("When newXMLDocument is called with a schema configured with a prefix", func() {
+			document, err := newXMLDocument(map[string]interface{}{"name": "John"}, &specXMLSchema{name: "user", namespace: "https://api.server.com/schema", prefix: "api"})
+			Convey("Then the root element should be prefixed", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEndWith, `<api:user xmlns:api="https://api.server.com/schema"><name>John</name></api:user>`)
+			})
+		})
+	})
+}
+
+func TestParseXMLDocument(t *testing.T) {
+	Convey("Given the XML schema of a resource", t, func() {
+		xmlSchema := &specXMLSchema{
+			name:       "user",
+			schemaType: "object",
+			properties: map[string]*specXMLSchema{
+				"id":     {name: "id", attribute: true, schemaType: "integer"},
+				"active": {name: "active", schemaType: "boolean"},
+				"tags":   {name: "tags", wrapped: true, schemaType: "array", items: &specXMLSchema{name: "tag", schemaType: "string"}},
+			},
+		}
+		Convey("When parseXMLDocument is called with a document containing elements that are not described by the schema", func() {
+			properties, err := parseXMLDocument([]byte(`<api:user xmlns:api="https://api.server.com/schema" id="1234"><api:active>true</api:active><tags/><label>some label</label><role>admin</role><role>dev</role><status><code>ok</code></status></api:user>`), xmlSchema)
+			Convey("Then the properties returned should contain the values converted into the property types and the elements not described", func() {
+				So(err, ShouldBeNil)
+				So(properties, ShouldResemble, map[string]interface{}{
+					"id":     float64(1234),
+					"active": true,
+					"tags":   []interface{}{},
+					"label":  "some label",
+					"role":   []interface{}{"admin", "dev"},
+					"status": map[string]interface{}{"code": "ok"},
+				})
+			})
+		})
+		Convey("When parseXMLDocument is called with a document that is not valid", func() {
+			_, err := parseXMLDocument([]byte(`<user><name>John</user>`), xmlSchema)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestProviderClientXMLRequests(t *testing.T) {
+	Convey("Given a providerClient and an API that consumes and produces XML", t, func() {
+		var contentTypeReceived, acceptReceived, bodyReceived string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentTypeReceived = r.Header.Get(contentType)
+			acceptReceived = r.Header.Get(acceptHeader)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodyReceived = string(body)
+			w.Header().Set(contentType, mediaTypeXML)
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><user id="1234"><name>John</name></user>`))
+		}))
+		defer api.Close()
+		providerClient := &ProviderClient{
+			openAPIBackendConfiguration: &specStubBackendConfiguration{
+				host:       strings.TrimPrefix(api.URL, "http://"),
+				httpScheme: "http",
+			},
+			httpClient:       &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			apiAuthenticator: newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+		}
+		xmlSchema := &specXMLSchema{name: "user", schemaType: "object", properties: map[string]*specXMLSchema{"id": {name: "id", attribute: true, schemaType: "string"}}}
+		operation := &specResourceOperation{requestMediaType: mediaTypeXML, responseMediaType: mediaTypeXML, xmlSchema: xmlSchema}
+		resource := newSpecStubResourceWithOperations("user", "/v1/users", false, nil, operation, operation, operation, &specResourceOperation{})
+		Convey("When providerClient Post method is called for a resource which POST operation consumes and produces application/xml", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Post(resource, map[string]interface{}{"name": "John"}, &responsePayload)
+			Convey("Then the request payload should be sent as an XML document", func() {
+				So(err, ShouldBeNil)
+				So(contentTypeReceived, ShouldEqual, mediaTypeXML)
+				So(acceptReceived, ShouldEqual, mediaTypeXML)
+				So(bodyReceived, ShouldEndWith, `<user><name>John</name></user>`)
+			})
+			Convey("And the response payload should contain the properties of the XML document returned by the API", func() {
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "John"})
+			})
+		})
+		Convey("When providerClient Get method is called for a resource which GET operation produces application/xml", func() {
+			responsePayload := map[string]interface{}{}
+			_, err := providerClient.Get(resource, "1234", &responsePayload)
+			Convey("Then the request should not have a body and the response payload should contain the properties of the XML document", func() {
+				So(err, ShouldBeNil)
+				So(bodyReceived, ShouldBeEmpty)
+				So(acceptReceived, ShouldEqual, mediaTypeXML)
+				So(responsePayload, ShouldResemble, map[string]interface{}{"id": "1234", "name": "John"})
+			})
+		})
+		Convey("When providerClient Post method is called with a payload that is not an object", func() {
+			_, err := providerClient.Post(resource, []interface{}{"John"}, nil)
+			Convey("Then the error returned should describe the problem", func() {
+				So(err.Error(), ShouldEqual, "application/xml request payloads must be objects ([]interface {})")
+			})
+		})
+	})
+}
@@ -1,9 +1,25 @@
 package openapi
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/swag"
 )
 
+// openAPIDocumentStdin is the OpenAPI document location that refers to the standard input
+const openAPIDocumentStdin = "-"
+
+// stdin is the reader used to read the OpenAPI document when the location is openAPIDocumentStdin
+var stdin io.Reader = os.Stdin
+
 // SpecAnalyser analyses the swagger doc and provides helper methods to retrieve all the end points that can
 // be used as terraform resources. These endpoints have to meet certain criteria to be considered eligible resources
 // as explained below:
@@ -20,6 +36,10 @@ type SpecAnalyser interface {
 	// GetTerraformCompliantDataSources is responsible for finding endpoints that are deemed terraform data source compatible
 	// and returns a list of SpecResource configured as data sources
 	GetTerraformCompliantDataSources() []SpecResource
+	// GetTerraformCompliantDataSourceInstances is responsible for finding the GET-only endpoints, that is resource instance
+	// paths exposing a GET operation which root path does not expose a POST operation, and returns a list of SpecResource
+	// that can be used as data source instances
+	GetTerraformCompliantDataSourceInstances() []SpecResource
 	// GetSecurity returns a SpecSecurity based on the security defined in the OpenAPI document
 	GetSecurity() SpecSecurity
 	// GetAllHeaderParameters returns SpecHeaderParameters containing all the headers defined in the OpenAPI document. This
@@ -39,23 +59,110 @@ type SpecAnalyserVersion string
 const (
 	// specAnalyserV2 version that supports OpenAPI v2 (swagger)
 	specAnalyserV2 SpecAnalyserVersion = "v2"
+	// specAnalyserV3 version that supports OpenAPI v3.0
+	specAnalyserV3 SpecAnalyserVersion = "v3"
 )
 
 // CreateSpecAnalyser is a factory method that returns the appropriate implementation of SpecAnalyser
 // depending upon the openApiSpecAnalyserVersion passed in.
-// Currently only OpenAPI v2 version is supported but this constructor is ready to handle new implementations such as v3
-// when the time comes
+// Currently OpenAPI v2 and v3.0 versions are supported
 func CreateSpecAnalyser(specAnalyserVersion SpecAnalyserVersion, openAPIDocumentURL string) (SpecAnalyser, error) {
 	var err error
 	var specAnalyser SpecAnalyser
 	switch specAnalyserVersion {
 	case specAnalyserV2:
 		specAnalyser, err = newSpecAnalyserV2(openAPIDocumentURL)
+	case specAnalyserV3:
+		specAnalyser, err = newSpecAnalyserV3(openAPIDocumentURL)
 	default:
-		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s]", specAnalyserVersion, specAnalyserV2)
+		return nil, fmt.Errorf("open api spec analyser version '%s' not supported, please choose a valid SpecAnalyser implementation [%s, %s]", specAnalyserVersion, specAnalyserV2, specAnalyserV3)
 	}
 	if err != nil {
 		return nil, err
 	}
 	return specAnalyser, nil
 }
+
+// newSpecAnalyser returns the SpecAnalyser implementation that supports the version of the OpenAPI document served at
+// the openAPIDocumentURL. The document is only retrieved once
+func newSpecAnalyser(openAPIDocumentURL string) (SpecAnalyser, error) {
+	if openAPIDocumentURL == "" {
+		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
+	}
+	document, err := loadOpenAPIDocument(openAPIDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	return newSpecAnalyserFromDocument(openAPIDocumentURL, document, nil)
+}
+
+// loadOpenAPIDocument returns the OpenAPI document located at the given location, which can be either:
+// - an HTTP(S) URL (e,g: https://api.com/swagger.yaml)
+// - a file URL (e,g: file:///specs/swagger.yaml) or a path to a file stored in the disk, absolute or relative to the
+// working directory (e,g: ./specs/swagger.yaml)
+// - the openAPIDocumentStdin value ('-'), in which case the document is read from the standard input
+func loadOpenAPIDocument(openAPIDocumentURL string) (json.RawMessage, error) {
+	if openAPIDocumentURL == openAPIDocumentStdin {
+		document, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the OpenAPI document from the standard input: %s", err)
+		}
+		return document, nil
+	}
+	return loads.JSONDoc(getOpenAPIDocumentPath(openAPIDocumentURL))
+}
+
+// getOpenAPIDocumentPath returns the path of the file referred by file URLs (e,g: file:///specs/swagger.yaml results
+// into /specs/swagger.yaml), any other location is returned as is
+func getOpenAPIDocumentPath(openAPIDocumentURL string) string {
+	return strings.TrimPrefix(openAPIDocumentURL, "file://")
+}
+
+// newSpecAnalyserFromDocument returns the SpecAnalyser that supports the OpenAPI document (JSON or YAML) already
+// retrieved from the openAPIDocumentURL. The messages are logged with the logger provided (the standard logger is used
+// if the logger is nil)
+func newSpecAnalyserFromDocument(openAPIDocumentURL string, document json.RawMessage, logger Logger) (SpecAnalyser, error) {
+	document, err := openAPIDocumentToJSON(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentURL, err)
+	}
+	specAnalyserVersion, err := getSpecAnalyserVersion(document)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI document from '%s' is not supported - error = %s", openAPIDocumentURL, err)
+	}
+	if specAnalyserVersion == specAnalyserV3 {
+		return newSpecAnalyserV3FromDocument(openAPIDocumentURL, document, logger)
+	}
+	return newSpecAnalyserV2FromDocument(openAPIDocumentURL, document, logger)
+}
+
+// getSpecAnalyserVersion returns the SpecAnalyserVersion that supports the OpenAPI document based on the 'openapi' field
+// (OpenAPI v3) of the document. Documents without the 'openapi' field are considered OpenAPI v2 documents.
+func getSpecAnalyserVersion(document json.RawMessage) (SpecAnalyserVersion, error) {
+	var versions struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(document, &versions); err != nil {
+		return "", err
+	}
+	switch {
+	case versions.OpenAPI == "":
+		return specAnalyserV2, nil
+	case strings.HasPrefix(versions.OpenAPI, "3.0"), strings.HasPrefix(versions.OpenAPI, "3.1"):
+		return specAnalyserV3, nil
+	}
+	return "", fmt.Errorf("openapi version '%s' not supported, supported versions are: swagger 2.0, openapi 3.0.x and openapi 3.1.x", versions.OpenAPI)
+}
+
+// openAPIDocumentToJSON returns the JSON representation of the document, which can be either in JSON or YAML format
+func openAPIDocumentToJSON(document json.RawMessage) (json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(document)
+	if len(trimmed) == 0 || trimmed[0] == '{' {
+		return document, nil
+	}
+	yamlDocument, err := swag.BytesToYAMLDoc(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return swag.YAMLToJSON(yamlDocument)
+}
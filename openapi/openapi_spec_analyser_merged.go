@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"fmt"
+)
+
+// mergedSpecDocument contains the SpecAnalyser of one of the documents merged into the provider along with the prefix
+// added to the names of its resources
+type mergedSpecDocument struct {
+	url                string
+	resourceNamePrefix string
+	specAnalyser       SpecAnalyser
+}
+
+// mergedSpecAnalyser implements the SpecAnalyser interface merging the resources and data sources of multiple OpenAPI
+// documents into one provider. The first document is the main one, its backend configuration (scheme, base path,
+// regions) and global security apply to the whole provider, whereas the resources of the rest of documents are called
+// against the host defined in their document. The resource names must be unique across the documents
+type mergedSpecAnalyser struct {
+	documents []mergedSpecDocument
+	// logger (optional) is used to log the collisions and the hosts that can not be resolved when merging the documents
+	logger Logger
+}
+
+// newMergedSpecAnalyser returns a SpecAnalyser merging the given documents, the first one being the main document
+func newMergedSpecAnalyser(documents []mergedSpecDocument) *mergedSpecAnalyser {
+	return &mergedSpecAnalyser{documents: documents}
+}
+
+// getLogger returns the logger configured in the merged analyser or the default logger if none was provided
+func (m *mergedSpecAnalyser) getLogger() Logger {
+	return loggerOrDefault(m.logger)
+}
+
+func (m *mergedSpecAnalyser) GetTerraformCompliantResources() ([]SpecResource, error) {
+	resources, collisions, err := m.mergeResources("resource", func(specAnalyser SpecAnalyser) ([]SpecResource, error) {
+		return specAnalyser.GetTerraformCompliantResources()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(collisions) > 0 {
+		return nil, collisions[0]
+	}
+	return resources, nil
+}
+
+// GetTerraformCompliantDataSources returns the data sources of all the documents. The data sources whose names collide
+// with the ones of a previous document are not returned
+func (m *mergedSpecAnalyser) GetTerraformCompliantDataSources() []SpecResource {
+	dataSources, collisions, _ := m.mergeResources("data source", func(specAnalyser SpecAnalyser) ([]SpecResource, error) {
+		return specAnalyser.GetTerraformCompliantDataSources(), nil
+	})
+	for _, collision := range collisions {
+		m.getLogger().Warn(fmt.Sprintf("%s, skipping the data source registration", collision))
+	}
+	return dataSources
+}
+
+// GetTerraformCompliantDataSourceInstances returns the data source instances of all the documents. The data source
+// instances whose names collide with the ones of a previous document are not returned
+func (m *mergedSpecAnalyser) GetTerraformCompliantDataSourceInstances() []SpecResource {
+	dataSourceInstances, collisions, _ := m.mergeResources("data source instance", func(specAnalyser SpecAnalyser) ([]SpecResource, error) {
+		return specAnalyser.GetTerraformCompliantDataSourceInstances(), nil
+	})
+	for _, collision := range collisions {
+		m.getLogger().Warn(fmt.Sprintf("%s, skipping the data source instance registration", collision))
+	}
+	return dataSourceInstances
+}
+
+func (m *mergedSpecAnalyser) GetSecurity() SpecSecurity {
+	return mergedSpecSecurity{documents: m.documents}
+}
+
+// GetAllHeaderParameters returns the headers defined in all the documents, the headers defined in multiple documents
+// are only returned once
+func (m *mergedSpecAnalyser) GetAllHeaderParameters() (SpecHeaderParameters, error) {
+	allHeaders := SpecHeaderParameters{}
+	for _, document := range m.documents {
+		headers, err := document.specAnalyser.GetAllHeaderParameters()
+		if err != nil {
+			return nil, err
+		}
+		for _, header := range headers {
+			if !allHeaders.specHeaderExists(header) {
+				allHeaders = append(allHeaders, header)
+			}
+		}
+	}
+	return allHeaders, nil
+}
+
+func (m *mergedSpecAnalyser) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
+	return m.documents[0].specAnalyser.GetAPIBackendConfiguration()
+}
+
+// mergeResources returns the resources of all the documents, prefixing their names and configuring the host of the
+// additional documents. The resources whose names collide with the ones of a previous document are not returned, an
+// error describing each collision is returned instead
+func (m *mergedSpecAnalyser) mergeResources(kind string, getResources func(SpecAnalyser) ([]SpecResource, error)) ([]SpecResource, []error, error) {
+	var mergedResources []SpecResource
+	var collisions []error
+	resourceDocuments := map[string]string{}
+	for i, document := range m.documents {
+		resources, err := getResources(document.specAnalyser)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to analyse the OpenAPI document '%s': %s", document.url, err)
+		}
+		host := ""
+		if i > 0 {
+			host = m.getAdditionalDocumentHost(document)
+		}
+		for _, resource := range resources {
+			if document.resourceNamePrefix != "" || host != "" {
+				resource = newMergedSpecResource(resource, document.resourceNamePrefix, host)
+			}
+			if resource.shouldIgnoreResource() {
+				mergedResources = append(mergedResources, resource)
+				continue
+			}
+			if url, exists := resourceDocuments[resource.getResourceName()]; exists {
+				collisions = append(collisions, fmt.Errorf("%s name '%s' is defined in multiple OpenAPI documents ('%s' and '%s'), please configure a resource_name_prefix for the additional swagger documents to avoid the name collision", kind, resource.getResourceName(), url, document.url))
+				continue
+			}
+			resourceDocuments[resource.getResourceName()] = document.url
+			mergedResources = append(mergedResources, resource)
+		}
+	}
+	return mergedResources, collisions, nil
+}
+
+// getAdditionalDocumentHost returns the host the resources of the given additional document should be called against,
+// empty if the host is the same as the main document one (hence the main document backend configuration applies)
+func (m *mergedSpecAnalyser) getAdditionalDocumentHost(document mergedSpecDocument) string {
+	backendConfiguration, err := document.specAnalyser.GetAPIBackendConfiguration()
+	if err != nil {
+		m.getLogger().Warn(fmt.Sprintf("failed to get the backend configuration of the OpenAPI document '%s', using the host of the main document: %s", document.url, err))
+		return ""
+	}
+	host, err := backendConfiguration.getHost()
+	if err != nil {
+		m.getLogger().Warn(fmt.Sprintf("failed to get the host of the OpenAPI document '%s', using the host of the main document: %s", document.url, err))
+		return ""
+	}
+	if mainBackendConfiguration, err := m.GetAPIBackendConfiguration(); err == nil {
+		if mainHost, err := mainBackendConfiguration.getHost(); err == nil && mainHost == host {
+			return ""
+		}
+	}
+	return host
+}
+
+// mergedSpecResource decorates the resources of the merged documents, adding the document resource name prefix to the
+// resource name and falling back to the document host if the resource does not override the host
+type mergedSpecResource struct {
+	SpecResource
+	name string
+	host string
+}
+
+func newMergedSpecResource(resource SpecResource, resourceNamePrefix, host string) *mergedSpecResource {
+	name := resource.getResourceName()
+	if resourceNamePrefix != "" {
+		name = fmt.Sprintf("%s_%s", resourceNamePrefix, name)
+	}
+	return &mergedSpecResource{
+		SpecResource: resource,
+		name:         name,
+		host:         host,
+	}
+}
+
+func (r *mergedSpecResource) getResourceName() string {
+	return r.name
+}
+
+func (r *mergedSpecResource) getHost() (string, error) {
+	host, err := r.SpecResource.getHost()
+	if err != nil || host != "" {
+		return host, err
+	}
+	return r.host, nil
+}
+
+// mergedSpecSecurity implements the SpecSecurity interface merging the security definitions of all the documents, the
+// global security schemes and requirements are the ones defined in the main document
+type mergedSpecSecurity struct {
+	documents []mergedSpecDocument
+}
+
+// GetAPIKeySecurityDefinitions returns the security definitions of all the documents, the definitions with the same
+// name are only returned once (the main document one takes preference)
+func (m mergedSpecSecurity) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
+	allSecurityDefinitions := SpecSecurityDefinitions{}
+	for _, document := range m.documents {
+		securityDefinitions, err := document.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		if securityDefinitions == nil {
+			continue
+		}
+		for _, securityDefinition := range *securityDefinitions {
+			if allSecurityDefinitions.findSecurityDefinitionFor(securityDefinition.getName()) == nil {
+				allSecurityDefinitions = append(allSecurityDefinitions, securityDefinition)
+			}
+		}
+	}
+	return &allSecurityDefinitions, nil
+}
+
+func (m mergedSpecSecurity) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error) {
+	return m.documents[0].specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
+}
+
+func (m mergedSpecSecurity) GetGlobalSecurityRequirements() ([]SpecSecuritySchemes, error) {
+	return m.documents[0].specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
+}
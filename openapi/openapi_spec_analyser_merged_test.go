@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMergedSpecAnalyserGetTerraformCompliantResources(t *testing.T) {
+	Convey("Given a merged spec analyser with a main document and an additional document served by a different host with a resource name prefix", t, func() {
+		mainSpecAnalyser := &specAnalyserStub{
+			resources:            []SpecResource{newSpecStubResource("users", "/v1/users", false, nil)},
+			backendConfiguration: newStubBackendConfiguration("api.example.com", "/", "https"),
+		}
+		additionalSpecAnalyser := &specAnalyserStub{
+			resources:            []SpecResource{newSpecStubResource("invoice", "/v1/invoices", false, nil)},
+			backendConfiguration: newStubBackendConfiguration("billing.example.com", "/", "https"),
+		}
+		m := newMergedSpecAnalyser([]mergedSpecDocument{
+			{url: "https://api.example.com/swagger.yaml", specAnalyser: mainSpecAnalyser},
+			{url: "https://billing.example.com/swagger.yaml", resourceNamePrefix: "billing", specAnalyser: additionalSpecAnalyser},
+		})
+		Convey("When GetTerraformCompliantResources is called", func() {
+			resources, err := m.GetTerraformCompliantResources()
+			Convey("Then the resources of both documents should be returned, the additional ones prefixed and using the document host", func() {
+				So(err, ShouldBeNil)
+				So(resources, ShouldHaveLength, 2)
+				So(resources[0].getResourceName(), ShouldEqual, "users")
+				host, err := resources[0].getHost()
+				So(err, ShouldBeNil)
+				So(host, ShouldBeEmpty)
+				So(resources[1].getResourceName(), ShouldEqual, "billing_invoice")
+				host, err = resources[1].getHost()
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "billing.example.com")
+			})
+		})
+	})
+	Convey("Given a merged spec analyser with an additional document which resource overrides the host", t, func() {
+		resource := newSpecStubResource("invoice", "/v1/invoices", false, nil)
+		resource.host = "override.example.com"
+		m := newMergedSpecAnalyser([]mergedSpecDocument{
+			{url: "https://api.example.com/swagger.yaml", specAnalyser: &specAnalyserStub{backendConfiguration: newStubBackendConfiguration("api.example.com", "/", "https")}},
+			{url: "https://billing.example.com/swagger.yaml", specAnalyser: &specAnalyserStub{resources: []SpecResource{resource}, backendConfiguration: newStubBackendConfiguration("billing.example.com", "/", "https")}},
+		})
+		Convey("When GetTerraformCompliantResources is called", func() {
+			resources, err := m.GetTerraformCompliantResources()
+			Convey("Then the resource host override should take preference over the document host", func() {
+				So(err, ShouldBeNil)
+				So(resources, ShouldHaveLength, 1)
+				So(resources[0].getResourceName(), ShouldEqual, "invoice")
+				host, err := resources[0].getHost()
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "override.example.com")
+			})
+		})
+	})
+	Convey("Given a merged spec analyser with documents defining resources with the same name", t, func() {
+		backendConfiguration := newStubBackendConfiguration("api.example.com", "/", "https")
+		m := newMergedSpecAnalyser([]mergedSpecDocument{
+			{url: "https://api.example.com/users.yaml", specAnalyser: &specAnalyserStub{resources: []SpecResource{newSpecStubResource("users", "/v1/users", false, nil)}, backendConfiguration: backendConfiguration}},
+			{url: "https://api.example.com/admin.yaml", specAnalyser: &specAnalyserStub{resources: []SpecResource{newSpecStubResource("users", "/v1/admin/users", false, nil), newSpecStubResource("ignored", "/v1/ignored", true, nil)}, backendConfiguration: backendConfiguration}},
+		})
+		Convey("When GetTerraformCompliantResources is called", func() {
+			_, err := m.GetTerraformCompliantResources()
+			Convey("Then the error returned should describe the collision", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "resource name 'users' is defined in multiple OpenAPI documents ('https://api.example.com/users.yaml' and 'https://api.example.com/admin.yaml'), please configure a resource_name_prefix for the additional swagger documents to avoid the name collision")
+			})
+		})
+	})
+}
+
+func TestMergedSpecAnalyserGetTerraformCompliantDataSources(t *testing.T) {
+	Convey("Given a merged spec analyser with documents defining data sources with the same name", t, func() {
+		backendConfiguration := newStubBackendConfiguration("api.example.com", "/", "https")
+		m := newMergedSpecAnalyser([]mergedSpecDocument{
+			{url: "https://api.example.com/users.yaml", specAnalyser: &specAnalyserStub{dataSources: []SpecResource{newSpecStubResource("users", "/v1/users", false, nil)}, backendConfiguration: backendConfiguration}},
+			{url: "https://api.example.com/admin.yaml", specAnalyser: &specAnalyserStub{dataSources: []SpecResource{newSpecStubResource("users", "/v1/admin/users", false, nil), newSpecStubResource("groups", "/v1/admin/groups", false, nil)}, backendConfiguration: backendConfiguration}},
+		})
+		logger := &loggerStub{}
+		m.logger = logger
+		Convey("When GetTerraformCompliantDataSources is called", func() {
+			dataSources := m.GetTerraformCompliantDataSources()
+			Convey("Then the colliding data source of the additional document should be skipped", func() {
+				So(dataSources, ShouldHaveLength, 2)
+				So(dataSources[0].getResourceName(), ShouldEqual, "users")
+				So(dataSources[0].(*specStubResource).path, ShouldEqual, "/v1/users")
+				So(dataSources[1].getResourceName(), ShouldEqual, "groups")
+			})
+			Convey("And the skipped data source should be logged with the merged analyser logger", func() {
+				So(logger.containsMessage("WARN", "data source name 'users' is defined in multiple OpenAPI documents ('https://api.example.com/users.yaml' and 'https://api.example.com/admin.yaml'), please configure a resource_name_prefix for the additional swagger documents to avoid the name collision, skipping the data source registration"), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestMergedSpecAnalyserGetAllHeaderParametersAndSecurity(t *testing.T) {
+	Convey("Given a merged spec analyser with documents defining the same header and security definition", t, func() {
+		m := newMergedSpecAnalyser([]mergedSpecDocument{
+			{url: "https://api.example.com/users.yaml", specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{{Name: "X-Request-ID", TerraformName: "x_request_id"}},
+				security: &specSecurityStub{
+					securityDefinitions:   &SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "Authorization")},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{{"apikey_auth": []string{}}}),
+				},
+			}},
+			{url: "https://api.example.com/billing.yaml", specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{{Name: "X-Request-ID", TerraformName: "x_request_id"}, {Name: "X-Billing-Account", TerraformName: "x_billing_account"}},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{newAPIKeyHeaderSecurityDefinition("apikey_auth", "X-Other"), newAPIKeyHeaderSecurityDefinition("billing_auth", "X-Billing-Key")},
+				},
+			}},
+		})
+		Convey("When GetAllHeaderParameters is called", func() {
+			headers, err := m.GetAllHeaderParameters()
+			Convey("Then the headers of all the documents should be returned once", func() {
+				So(err, ShouldBeNil)
+				So(headers, ShouldHaveLength, 2)
+				So(headers[0].Name, ShouldEqual, "X-Request-ID")
+				So(headers[1].Name, ShouldEqual, "X-Billing-Account")
+			})
+		})
+		Convey("When GetAPIKeySecurityDefinitions is called", func() {
+			securityDefinitions, err := m.GetSecurity().GetAPIKeySecurityDefinitions()
+			Convey("Then the security definitions of all the documents should be returned once, the main document ones taking preference", func() {
+				So(err, ShouldBeNil)
+				So(*securityDefinitions, ShouldHaveLength, 2)
+				So((*securityDefinitions)[0].getName(), ShouldEqual, "apikey_auth")
+				So((*securityDefinitions)[0].(specAPIKeyHeaderSecurityDefinition).apiKey.Name, ShouldEqual, "Authorization")
+				So((*securityDefinitions)[1].getName(), ShouldEqual, "billing_auth")
+			})
+		})
+		Convey("When GetGlobalSecuritySchemes is called", func() {
+			globalSecuritySchemes, err := m.GetSecurity().GetGlobalSecuritySchemes()
+			Convey("Then the global security schemes of the main document should be returned", func() {
+				So(err, ShouldBeNil)
+				So(globalSecuritySchemes, ShouldHaveLength, 1)
+				So(globalSecuritySchemes[0].Name, ShouldEqual, "apikey_auth")
+			})
+		})
+	})
+}
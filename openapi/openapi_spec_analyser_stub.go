@@ -4,6 +4,7 @@ package openapi
 type specAnalyserStub struct {
 	resources            []SpecResource
 	dataSources          []SpecResource
+	dataSourceInstances  []SpecResource
 	security             *specSecurityStub
 	headers              SpecHeaderParameters
 	backendConfiguration SpecBackendConfiguration
@@ -21,6 +22,10 @@ func (s *specAnalyserStub) GetTerraformCompliantDataSources() []SpecResource {
 	return s.dataSources
 }
 
+func (s *specAnalyserStub) GetTerraformCompliantDataSourceInstances() []SpecResource {
+	return s.dataSourceInstances
+}
+
 func (s *specAnalyserStub) GetSecurity() SpecSecurity {
 	return s.security
 }
@@ -1,8 +1,11 @@
 package openapi
 
 import (
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -40,7 +43,107 @@ func TestCreateSpecAnalyser(t *testing.T) {
 				So(err, ShouldNotBeNil)
 			})
 			Convey("Then the error message should equal", func() {
-				So(err.Error(), ShouldEqual, "open api spec analyser version 'nonSupportedVersion' not supported, please choose a valid SpecAnalyser implementation [v2]")
+				So(err.Error(), ShouldEqual, "open api spec analyser version 'nonSupportedVersion' not supported, please choose a valid SpecAnalyser implementation [v2, v3]")
+			})
+		})
+	})
+}
+
+func TestNewSpecAnalyser(t *testing.T) {
+	Convey("Given an OpenAPI v2 document", t, func() {
+		file := initAPISpecFile(`swagger: "2.0"`)
+		defer os.Remove(file.Name())
+		Convey("When newSpecAnalyser method is called", func() {
+			specAnalyser, err := newSpecAnalyser(file.Name())
+			Convey("Then the specAnalyser returned should be of type specV2Analyser", func() {
+				So(err, ShouldBeNil)
+				So(specAnalyser, ShouldHaveSameTypeAs, &specV2Analyser{})
+			})
+		})
+	})
+
+	Convey("Given an OpenAPI v3.0 document", t, func() {
+		file := initAPISpecFile(openAPIV3CDNDocument)
+		defer os.Remove(file.Name())
+		Convey("When newSpecAnalyser method is called", func() {
+			specAnalyser, err := newSpecAnalyser(file.Name())
+			Convey("Then the specAnalyser returned should be of type specV3Analyser", func() {
+				So(err, ShouldBeNil)
+				So(specAnalyser, ShouldHaveSameTypeAs, &specV3Analyser{})
+			})
+		})
+	})
+
+	Convey("Given an OpenAPI v3.1 document", t, func() {
+		file := initAPISpecFile(`{"openapi": "3.1.0", "info": {"title": "test", "version": "1.0.0"}, "paths": {}}`)
+		defer os.Remove(file.Name())
+		Convey("When newSpecAnalyser method is called", func() {
+			specAnalyser, err := newSpecAnalyser(file.Name())
+			Convey("Then the specAnalyser returned should be of type specV3Analyser", func() {
+				So(err, ShouldBeNil)
+				So(specAnalyser, ShouldHaveSameTypeAs, &specV3Analyser{})
+			})
+		})
+	})
+
+	Convey("Given an OpenAPI v4.0 document", t, func() {
+		file := initAPISpecFile(`{"openapi": "4.0.0", "paths": {}}`)
+		defer os.Remove(file.Name())
+		Convey("When newSpecAnalyser method is called", func() {
+			_, err := newSpecAnalyser(file.Name())
+			Convey("Then the error returned should state the version is not supported", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("OpenAPI document from '%s' is not supported - error = openapi version '4.0.0' not supported, supported versions are: swagger 2.0, openapi 3.0.x and openapi 3.1.x", file.Name()))
+			})
+		})
+	})
+
+	Convey("Given a non valid openAPIDocumentURL", t, func() {
+		Convey("When newSpecAnalyser method is called", func() {
+			_, err := newSpecAnalyser("some non valid spec file")
+			Convey("Then the error message should equal", func() {
+				So(err.Error(), ShouldEqual, "failed to retrieve the OpenAPI document from 'some non valid spec file' - error = open some non valid spec file: no such file or directory")
+			})
+		})
+	})
+}
+
+func TestLoadOpenAPIDocument(t *testing.T) {
+	Convey("Given a swagger file stored in the disk", t, func() {
+		file := initAPISpecFile(`swagger: "2.0"`)
+		defer os.Remove(file.Name())
+		Convey("When loadOpenAPIDocument is called with the path to the file", func() {
+			document, err := loadOpenAPIDocument(file.Name())
+			Convey("Then the document returned should be the content of the file", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEqual, `swagger: "2.0"`)
+			})
+		})
+		Convey("When loadOpenAPIDocument is called with the file URL of the file", func() {
+			document, err := loadOpenAPIDocument("file://" + file.Name())
+			Convey("Then the document returned should be the content of the file", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEqual, `swagger: "2.0"`)
+			})
+		})
+		Convey("When loadOpenAPIDocument is called with the path to the file relative to the working directory", func() {
+			wd, _ := os.Getwd()
+			relativePath, _ := filepath.Rel(wd, file.Name())
+			document, err := loadOpenAPIDocument(relativePath)
+			Convey("Then the document returned should be the content of the file", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEqual, `swagger: "2.0"`)
+			})
+		})
+	})
+	Convey("Given a swagger file provided in the standard input", t, func() {
+		stdin = strings.NewReader(`swagger: "2.0"`)
+		defer func() { stdin = os.Stdin }()
+		Convey("When loadOpenAPIDocument is called with '-'", func() {
+			document, err := loadOpenAPIDocument(openAPIDocumentStdin)
+			Convey("Then the document returned should be the one provided in the standard input", func() {
+				So(err, ShouldBeNil)
+				So(string(document), ShouldEqual, `swagger: "2.0"`)
 			})
 		})
 	})
@@ -21,4 +21,7 @@ type specAuthenticator interface {
 type authContext struct {
 	headers map[string]string
 	url     string
+	// authenticators contains the authenticators that prepared the auth context, so their credentials can be refreshed
+	// if the API rejects them
+	authenticators []specAPIKeyAuthenticator
 }
@@ -2,34 +2,40 @@ package openapi
 
 import (
 	"fmt"
-	"log"
 )
 
 // apiAuth is an implementation of specAuthenticator encapsulating the general settings to be applied in case
 // an operation does not contain a security policy; otherwise the operation's security policies will be applied instead.
 type apiAuth struct {
 	globalSecuritySchemes *SpecSecuritySchemes
+	logger                Logger
 }
 
-// newAPIAuthenticator allows for the creation of a new authenticator
-func newAPIAuthenticator(globalSecuritySchemes *SpecSecuritySchemes) specAuthenticator {
+// newAPIAuthenticator allows for the creation of a new authenticator. If the logger is nil the default logger will be used
+func newAPIAuthenticator(globalSecuritySchemes *SpecSecuritySchemes, logger Logger) specAuthenticator {
 	return apiAuth{
 		globalSecuritySchemes: globalSecuritySchemes,
+		logger:                logger,
 	}
 }
 
+// getLogger returns the logger configured in the authenticator or the default logger if none was provided
+func (oa apiAuth) getLogger() Logger {
+	return loggerOrDefault(oa.logger)
+}
+
 // Check if the operation contains any security policy. In the case where the operation contains multiple security
 // policies, the first one found in the list will be the one returned.
 // For more information about multiple api keys refer to https://swagger.io/docs/specification/authentication/api-keys/#multiple
 func (oa apiAuth) authRequired(url string, operationSecuritySchemes SpecSecuritySchemes) (bool, SpecSecuritySchemes) {
 	// TODO: check in the OpenAPI spec whether operation overrides global schemes or can complement global configuration?
 	if len(operationSecuritySchemes) != 0 {
-		log.Printf("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes)
+		oa.getLogger().Debug(fmt.Sprintf("operation security policies found for '%s' (overriding global security config if applicable). Selected the following based on order of appearance in the list %+v", url, operationSecuritySchemes), "url", url)
 		return true, operationSecuritySchemes
 	}
-	log.Printf("operation security schemes missing, falling back to global security schemes (if there's any)")
+	oa.getLogger().Debug("operation security schemes missing, falling back to global security schemes (if there's any)", "url", url)
 	if oa.globalSecuritySchemes != nil && len(*oa.globalSecuritySchemes) != 0 {
-		log.Printf("the global configuration contains security schemes, selected the following based on order of appearance in the list %+v", oa.globalSecuritySchemes)
+		oa.getLogger().Debug(fmt.Sprintf("the global configuration contains security schemes, selected the following based on order of appearance in the list %+v", oa.globalSecuritySchemes), "url", url)
 		return true, *oa.globalSecuritySchemes
 	}
 	return false, nil
@@ -67,6 +73,7 @@ func (oa apiAuth) prepareAuth(url string, operationSecuritySchemes SpecSecurityS
 			if err := authenticator.prepareAuth(authContext); err != nil {
 				return authContext, err
 			}
+			authContext.authenticators = append(authContext.authenticators, authenticator)
 		}
 	}
 	return authContext, nil
@@ -130,7 +130,7 @@ func TestPrepareAuth(t *testing.T) {
 	}{
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_header_auth' of type apiKeyHeader that matches one defined in the provider configuration (which contains the value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}},
 			inputProviderConfig: providerConfiguration{
@@ -149,7 +149,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation contains a security scheme 'apikey_query_auth' of type apiKeyQuery that matches one defined in the provider configuration (which contains the value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_query_auth"}},
 			inputProviderConfig: providerConfiguration{
@@ -168,7 +168,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation containing multiple mixed security schemes (apikey_header_auth and apikey_query_auth) that matches security definitions defined in the provider configuration (containing their value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apikey_header_auth"}, SpecSecurityScheme{Name: "apikey_query_auth"}},
 			inputProviderConfig: providerConfiguration{
@@ -193,7 +193,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation containing multiple apiKey security schemes (api_key and app_id) that matches security definitions defined in the provider configuration (containing their value)",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}, SpecSecurityScheme{Name: "app_id"}},
 			inputProviderConfig: providerConfiguration{
@@ -219,7 +219,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes that match security definitions defined in the provider configuration and the operation does not override the global security",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
+			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{},
 			inputProviderConfig: providerConfiguration{
@@ -238,7 +238,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration and the operation overrides the global security schemes with (apiKeyOverride)",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
+			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "apiKeyOverride"}},
 			inputProviderConfig: providerConfiguration{
@@ -263,7 +263,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes 'apiKey' that are not defined in the provider configuration and the operation does not have any specific security scheme",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}),
+			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}}, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{},
 			inputProviderConfig: providerConfiguration{
@@ -282,7 +282,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation having specific security scheme that are not defined in the provider configuration ",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "not_defined_scheme"}},
 			inputProviderConfig: providerConfiguration{
@@ -301,7 +301,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with global security schemes 'api_key' that match security definitions defined in the provider configuration but it's missing the value",
-			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}),
+			apiAuthenticator:              newAPIAuthenticator(&SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}}, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{},
 			inputProviderConfig: providerConfiguration{
@@ -321,7 +321,7 @@ func TestPrepareAuth(t *testing.T) {
 		},
 		{
 			name:                          "apiAuthenticator set up with no global security schemes and the operation has a security scheme that matches one security definition defined in the provider configuration but it's missing the value",
-			apiAuthenticator:              newAPIAuthenticator(nil),
+			apiAuthenticator:              newAPIAuthenticator(nil, nil),
 			inputURL:                      "https://www.host.com/v1/resource",
 			inputOperationSecuritySchemes: SpecSecuritySchemes{SpecSecurityScheme{Name: "api_key"}},
 			inputProviderConfig: providerConfiguration{
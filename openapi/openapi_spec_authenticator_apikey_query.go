@@ -31,7 +31,7 @@ func (a apiKeyQueryAuthenticator) getType() authType {
 // provides the opportunity to inject some headers if needed.
 func (a apiKeyQueryAuthenticator) prepareAuth(authContext *authContext) error {
 	apiKey := a.getContext().(apiKey)
-	authContext.url = fmt.Sprintf("%s?%s=%s", authContext.url, apiKey.name, apiKey.value)
+	authContext.url = appendQueryParameters(authContext.url, queryParameter{name: apiKey.name, values: []string{apiKey.value}, collectionFormat: collectionFormatCSV})
 	return nil
 }
 
@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauth2TokenExpiryDelta is subtracted from the access token lifetime so the token is refreshed before it actually
+// expires, avoiding requests being sent with a token that expires while the request is in flight. The delta is capped
+// to half of the lifetime for short-lived tokens (see getTokenExpiryDelta)
+const oauth2TokenExpiryDelta = 10 * time.Second
+const oauth2TokenRequestTimeout = 30 * time.Second
+
+// oauth2TokenResponse describes the token response returned by the token URL as defined in https://tools.ietf.org/html/rfc6749#section-5.1
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// apiOAuth2ClientCredentialsAuthenticator is an authenticator which obtains the access token from the token URL using the
+// OAuth2 client credentials grant. The access token is cached and a new one is requested once it expires (or when the
+// API rejects it). It is safe for concurrent use.
+type apiOAuth2ClientCredentialsAuthenticator struct {
+	terraformConfigurationName string
+	clientID                   string
+	clientSecret               string
+	tokenURL                   string
+	scopes                     []string
+	httpClient                 *http.Client
+
+	mutex       sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+	// now returns the current time, configurable for testing purposes
+	now func() time.Time
+}
+
+func newAPIOAuth2ClientCredentialsAuthenticator(secDef SpecSecurityDefinition, clientID, clientSecret string) *apiOAuth2ClientCredentialsAuthenticator {
+	tokenURL, _ := secDef.getAPIKey().Metadata[oauth2TokenURLKey].(string)
+	scopes, _ := secDef.getAPIKey().Metadata[oauth2ScopesKey].([]string)
+	return &apiOAuth2ClientCredentialsAuthenticator{
+		terraformConfigurationName: secDef.getTerraformConfigurationName(),
+		clientID:                   clientID,
+		clientSecret:               clientSecret,
+		tokenURL:                   tokenURL,
+		scopes:                     scopes,
+		httpClient:                 &http.Client{Timeout: oauth2TokenRequestTimeout},
+		now:                        time.Now,
+	}
+}
+
+func (a *apiOAuth2ClientCredentialsAuthenticator) getContext() interface{} {
+	return apiKey{name: authorizationHeader}
+}
+
+func (a *apiOAuth2ClientCredentialsAuthenticator) getType() authType {
+	return authTypeAPIKeyHeader
+}
+
+// prepareAuth adds the Authorization header containing the access token (using the Bearer scheme) to the auth context.
+// The access token is requested to the token URL if there is no cached token or the cached one has expired
+func (a *apiOAuth2ClientCredentialsAuthenticator) prepareAuth(authContext *authContext) error {
+	accessToken, err := a.getAccessToken()
+	if err != nil {
+		return err
+	}
+	if authContext.headers == nil {
+		authContext.headers = map[string]string{}
+	}
+	authContext.headers[authorizationHeader] = fmt.Sprintf("%s %s", bearerScheme, accessToken)
+	return nil
+}
+
+func (a *apiOAuth2ClientCredentialsAuthenticator) validate() error {
+	if a.clientID == "" || a.clientSecret == "" {
+		return fmt.Errorf("required security definition '%s' is missing the client credentials. Please make sure the properties '%s_%s' and '%s_%s' are configured with a value in the provider's terraform configuration", a.terraformConfigurationName, a.terraformConfigurationName, oauth2ClientIDPropertySuffix, a.terraformConfigurationName, oauth2ClientSecretPropertySuffix)
+	}
+	return nil
+}
+
+// invalidate discards the cached access token so a new one is requested to the token URL for the next request
+func (a *apiOAuth2ClientCredentialsAuthenticator) invalidate() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.accessToken = ""
+	a.expiresAt = time.Time{}
+}
+
+// getAccessToken returns the cached access token if it has not expired; otherwise a new access token is requested to the
+// token URL and cached. Tokens returned without an expiry are cached until invalidated
+func (a *apiOAuth2ClientCredentialsAuthenticator) getAccessToken() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if a.accessToken != "" && (a.expiresAt.IsZero() || a.now().Before(a.expiresAt)) {
+		return a.accessToken, nil
+	}
+	tokenResponse, err := a.requestAccessToken()
+	if err != nil {
+		return "", err
+	}
+	a.accessToken = tokenResponse.AccessToken
+	a.expiresAt = time.Time{}
+	if tokenResponse.ExpiresIn > 0 {
+		lifetime := time.Duration(tokenResponse.ExpiresIn) * time.Second
+		a.expiresAt = a.now().Add(lifetime - getTokenExpiryDelta(lifetime))
+	}
+	return a.accessToken, nil
+}
+
+// getTokenExpiryDelta returns the time subtracted from the given token lifetime so the token is refreshed before it
+// expires. The delta is capped to half of the lifetime so the short-lived tokens (lifetime lower than twice the
+// oauth2TokenExpiryDelta) are still cached instead of being considered expired as soon as they are received
+func getTokenExpiryDelta(lifetime time.Duration) time.Duration {
+	if lifetime < 2*oauth2TokenExpiryDelta {
+		return lifetime / 2
+	}
+	return oauth2TokenExpiryDelta
+}
+
+// requestAccessToken performs the access token request as described in https://tools.ietf.org/html/rfc6749#section-4.4.2
+// authenticating the client with HTTP basic authentication
+func (a *apiOAuth2ClientCredentialsAuthenticator) requestAccessToken() (*oauth2TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(a.scopes) > 0 {
+		form.Set("scope", strings.Join(a.scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, a.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(url.QueryEscape(a.clientID), url.QueryEscape(a.clientSecret))
+	req.Header.Set(contentType, "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oauth2 token POST request '%s' for security definition '%s' failed: %s", a.tokenURL, a.terraformConfigurationName, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the oauth2 token POST response '%s': %s", a.tokenURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth2 token POST response '%s' status code '%d' not matching expected response status code [%d]: %s", a.tokenURL, resp.StatusCode, http.StatusOK, strings.TrimSpace(string(body)))
+	}
+	tokenResponse := &oauth2TokenResponse{}
+	if err := json.Unmarshal(body, tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the oauth2 token POST response '%s': %s", a.tokenURL, err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2 token POST response '%s' is missing the access token", a.tokenURL)
+	}
+	if tokenResponse.TokenType != "" && !strings.EqualFold(tokenResponse.TokenType, bearerScheme) {
+		return nil, fmt.Errorf("oauth2 token POST response '%s' token type '%s' is not supported, only '%s' tokens are supported", a.tokenURL, tokenResponse.TokenType, bearerScheme)
+	}
+	return tokenResponse, nil
+}
@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newOAuth2TokenTestServer(t *testing.T, requests *int, response string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		clientID, clientSecret, _ := r.BasicAuth()
+		if r.Method != http.MethodPost || clientID != "someClientID" || clientSecret != "someClientSecret" || r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set(contentType, "application/json")
+		fmt.Fprint(w, response)
+	}))
+}
+
+func newOAuth2ClientCredentialsTestAuthenticator(tokenURL string) *apiOAuth2ClientCredentialsAuthenticator {
+	secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", tokenURL, []string{"read", "write"})
+	return newAPIOAuth2ClientCredentialsAuthenticator(secDef, "someClientID", "someClientSecret")
+}
+
+func TestAPIOAuth2ClientCredentialsAuthenticatorPrepareAuth(t *testing.T) {
+	t.Run("happy path -- the access token is requested and cached until it expires", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken","token_type":"bearer","expires_in":3600}`)
+		defer server.Close()
+		now := time.Now()
+		authenticator := newOAuth2ClientCredentialsTestAuthenticator(server.URL)
+		authenticator.now = func() time.Time { return now }
+
+		ctx := &authContext{}
+		err := authenticator.prepareAuth(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer someAccessToken", ctx.headers[authorizationHeader])
+
+		err = authenticator.prepareAuth(&authContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, requests, "the cached access token should be used")
+
+		now = now.Add(time.Hour)
+		err = authenticator.prepareAuth(&authContext{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, requests, "a new access token should be requested once the cached one has expired")
+	})
+
+	t.Run("happy path -- short-lived access tokens are cached for half of their lifetime", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken","token_type":"bearer","expires_in":10}`)
+		defer server.Close()
+		now := time.Now()
+		authenticator := newOAuth2ClientCredentialsTestAuthenticator(server.URL)
+		authenticator.now = func() time.Time { return now }
+
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		now = now.Add(4 * time.Second)
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		assert.Equal(t, 1, requests, "the cached access token should be used even if it expires in less than the expiry delta")
+
+		now = now.Add(time.Second)
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		assert.Equal(t, 2, requests, "a new access token should be requested once half of the lifetime has passed")
+	})
+
+	t.Run("happy path -- a new access token is requested once the cached one is invalidated", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken"}`)
+		defer server.Close()
+		authenticator := newOAuth2ClientCredentialsTestAuthenticator(server.URL)
+
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		assert.Equal(t, 1, requests, "access tokens without expiry should be cached until invalidated")
+
+		var _ refreshableAuthenticator = authenticator
+		authenticator.invalidate()
+		assert.NoError(t, authenticator.prepareAuth(&authContext{}))
+		assert.Equal(t, 2, requests)
+	})
+
+	t.Run("crappy path -- the token URL returns a non expected response status code", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, "")
+		defer server.Close()
+		secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", server.URL, []string{"read", "write"})
+		authenticator := newAPIOAuth2ClientCredentialsAuthenticator(secDef, "someClientID", "wrongClientSecret")
+		ctx := &authContext{}
+		err := authenticator.prepareAuth(ctx)
+		assert.EqualError(t, err, fmt.Sprintf("oauth2 token POST response '%s' status code '401' not matching expected response status code [200]: ", server.URL))
+		assert.Empty(t, ctx.headers[authorizationHeader])
+	})
+
+	t.Run("crappy path -- the token URL response is missing the access token", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"token_type":"bearer"}`)
+		defer server.Close()
+		err := newOAuth2ClientCredentialsTestAuthenticator(server.URL).prepareAuth(&authContext{})
+		assert.EqualError(t, err, fmt.Sprintf("oauth2 token POST response '%s' is missing the access token", server.URL))
+	})
+
+	t.Run("crappy path -- the token URL response contains a token type not supported", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken","token_type":"mac"}`)
+		defer server.Close()
+		err := newOAuth2ClientCredentialsTestAuthenticator(server.URL).prepareAuth(&authContext{})
+		assert.EqualError(t, err, fmt.Sprintf("oauth2 token POST response '%s' token type 'mac' is not supported, only 'Bearer' tokens are supported", server.URL))
+	})
+
+	t.Run("crappy path -- the token URL response is not valid json", func(t *testing.T) {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `not json`)
+		defer server.Close()
+		err := newOAuth2ClientCredentialsTestAuthenticator(server.URL).prepareAuth(&authContext{})
+		assert.Contains(t, err.Error(), fmt.Sprintf("failed to unmarshal the oauth2 token POST response '%s'", server.URL))
+	})
+}
+
+func TestAPIOAuth2ClientCredentialsAuthenticatorValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		clientID      string
+		clientSecret  string
+		expectedError error
+	}{
+		{
+			name:          "validate passes since the client credentials are populated",
+			clientID:      "someClientID",
+			clientSecret:  "someClientSecret",
+			expectedError: nil,
+		},
+		{
+			name:          "validate does not pass since the client id is NOT populated",
+			clientID:      "",
+			clientSecret:  "someClientSecret",
+			expectedError: errors.New("required security definition 'oauth2_auth' is missing the client credentials. Please make sure the properties 'oauth2_auth_client_id' and 'oauth2_auth_client_secret' are configured with a value in the provider's terraform configuration"),
+		},
+		{
+			name:          "validate does not pass since the client secret is NOT populated",
+			clientID:      "someClientID",
+			clientSecret:  "",
+			expectedError: errors.New("required security definition 'oauth2_auth' is missing the client credentials. Please make sure the properties 'oauth2_auth_client_id' and 'oauth2_auth_client_secret' are configured with a value in the provider's terraform configuration"),
+		},
+	}
+	for _, tc := range testCases {
+		secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token", nil)
+		err := newAPIOAuth2ClientCredentialsAuthenticator(secDef, tc.clientID, tc.clientSecret).validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
@@ -0,0 +1,58 @@
+package openapi
+
+import "fmt"
+
+// refreshableAuthenticator defines the behaviour for authenticators whose credentials can be refreshed, for instance
+// when the API rejects the current ones
+type refreshableAuthenticator interface {
+	invalidate()
+}
+
+// tokenSource defines the behaviour for the sources the security definition tokens can be obtained from (e,g: the token
+// command or the token file configured in the plugin configuration)
+type tokenSource interface {
+	getToken() (string, error)
+	invalidate()
+}
+
+// apiTokenCommandAuthenticator is an api key authenticator which value is obtained from the token source (token command
+// or token file) configured for the security definition in the plugin configuration
+type apiTokenCommandAuthenticator struct {
+	secDef      SpecSecurityDefinition
+	tokenSource tokenSource
+}
+
+func newAPITokenCommandAuthenticator(secDef SpecSecurityDefinition, tokenSource tokenSource) *apiTokenCommandAuthenticator {
+	return &apiTokenCommandAuthenticator{
+		secDef:      secDef,
+		tokenSource: tokenSource,
+	}
+}
+
+func (a *apiTokenCommandAuthenticator) getContext() interface{} {
+	return createAPIKeyAuthenticator(a.secDef, "").getContext()
+}
+
+func (a *apiTokenCommandAuthenticator) getType() authType {
+	return createAPIKeyAuthenticator(a.secDef, "").getType()
+}
+
+// prepareAuth obtains the token from the token source and delegates the preparation of the auth context to the
+// authenticator corresponding to the security definition type (e,g: header, query, bearer)
+func (a *apiTokenCommandAuthenticator) prepareAuth(authContext *authContext) error {
+	token, err := a.tokenSource.getToken()
+	if err != nil {
+		return fmt.Errorf("failed to obtain the value for security definition '%s': %s", a.secDef.getTerraformConfigurationName(), err)
+	}
+	return createAPIKeyAuthenticator(a.secDef, token).prepareAuth(authContext)
+}
+
+// validate always succeeds since the value is obtained from the token source when the auth is prepared
+func (a *apiTokenCommandAuthenticator) validate() error {
+	return nil
+}
+
+// invalidate discards the current token so a new one is obtained from the token source for the next request
+func (a *apiTokenCommandAuthenticator) invalidate() {
+	a.tokenSource.invalidate()
+}
@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPITokenCommandAuthenticator(t *testing.T) {
+	Convey("Given a bearer security definition and a token command", t, func() {
+		secDef := newAPIKeyHeaderBearerSecurityDefinition("bearer_auth")
+		tokenCommand := newTokenCommand("bearer_auth", TokenCommandConfiguration{Command: []string{"echo", "someToken"}, Timeout: 5 * time.Second}, nil)
+		Convey("When newAPITokenCommandAuthenticator is called", func() {
+			authenticator := newAPITokenCommandAuthenticator(secDef, tokenCommand)
+			Convey("Then the authenticator should comply with specAPIKeyAuthenticator and refreshableAuthenticator interfaces", func() {
+				var _ specAPIKeyAuthenticator = authenticator
+				var _ refreshableAuthenticator = authenticator
+			})
+			Convey("And the authenticator type should be the one of the security definition", func() {
+				So(authenticator.getType(), ShouldEqual, authTypeAPIKeyHeader)
+			})
+			Convey("And the validate method should not return an error even though the value is obtained later on", func() {
+				So(authenticator.validate(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestAPITokenCommandAuthenticatorPrepareAuth(t *testing.T) {
+	Convey("Given a token command authenticator for a bearer security definition", t, func() {
+		authenticator := newAPITokenCommandAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), newTokenCommand("bearer_auth", TokenCommandConfiguration{Command: []string{"echo", "someToken"}, Timeout: 5 * time.Second}, nil))
+		Convey("When prepareAuth is called", func() {
+			ctx := &authContext{headers: map[string]string{}}
+			err := authenticator.prepareAuth(ctx)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the auth context should contain the Authorization header with the token returned by the command", func() {
+				So(ctx.headers, ShouldContainKey, authorizationHeader)
+				So(ctx.headers[authorizationHeader], ShouldEqual, "Bearer someToken")
+			})
+		})
+	})
+	Convey("Given a token command authenticator for a query security definition", t, func() {
+		authenticator := newAPITokenCommandAuthenticator(newAPIKeyQuerySecurityDefinition("apikey_auth", "api_key"), newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"echo", "someToken"}, Timeout: 5 * time.Second}, nil))
+		Convey("When prepareAuth is called", func() {
+			ctx := &authContext{headers: map[string]string{}, url: "http://www.host.com/v1/resource"}
+			err := authenticator.prepareAuth(ctx)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the auth context url should contain the token returned by the command", func() {
+				So(ctx.url, ShouldEqual, "http://www.host.com/v1/resource?api_key=someToken")
+			})
+		})
+	})
+	Convey("Given a token command authenticator which token command fails", t, func() {
+		authenticator := newAPITokenCommandAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), newTokenCommand("bearer_auth", TokenCommandConfiguration{Command: []string{"cat", "nonexistingfile"}, Timeout: 5 * time.Second}, nil))
+		Convey("When prepareAuth is called", func() {
+			err := authenticator.prepareAuth(&authContext{headers: map[string]string{}})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldStartWith, "failed to obtain the value for security definition 'bearer_auth': provider schema property 'bearer_auth' token command failed: command '[cat nonexistingfile]' failed")
+			})
+		})
+	})
+}
@@ -8,4 +8,6 @@ type SpecBackendConfiguration interface {
 	getHostByRegion(region string) (string, error)
 	isMultiRegion() (bool, string, []string, error)
 	getDefaultRegion([]string) (string, error)
+	getAWSSigV4Scope() (*awsSigV4Scope, error)
+	getHealthCheckPath() string
 }
@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// specDocumentCache keeps on disk the OpenAPI documents retrieved from the APIs. Each document is stored along with
+// its metadata (the ETag and Last-Modified response headers and the time it was retrieved) in files named after the
+// SHA-256 of the document URL
+type specDocumentCache struct {
+	directory      string
+	ttl            time.Duration
+	httpClient     *http.Client
+	swaggerRequest *SwaggerRequestConfiguration
+	now            func() time.Time
+	logger         Logger
+}
+
+// specDocumentCacheMetadata contains the metadata of a cached OpenAPI document
+type specDocumentCacheMetadata struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// newSpecDocumentCache creates a specDocumentCache that retrieves the documents using the swagger request configuration
+// and the service transport provided (both may be nil). The messages are logged with the logger provided (the standard
+// logger is used if the logger is nil)
+func newSpecDocumentCache(specCacheConfiguration *SpecCacheConfiguration, swaggerRequestConfiguration *SwaggerRequestConfiguration, serviceTransport *http.Transport, logger Logger) (*specDocumentCache, error) {
+	directory, err := specCacheConfiguration.getDirectory()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := swaggerRequestConfiguration.newHTTPClient(serviceTransport)
+	if err != nil {
+		return nil, err
+	}
+	return &specDocumentCache{
+		directory:      directory,
+		ttl:            specCacheConfiguration.getTTL(),
+		httpClient:     httpClient,
+		swaggerRequest: swaggerRequestConfiguration,
+		now:            time.Now,
+		logger:         logger,
+	}, nil
+}
+
+// getLogger returns the logger configured in the cache or the default logger if none was provided
+func (c *specDocumentCache) getLogger() Logger {
+	return loggerOrDefault(c.logger)
+}
+
+// getDocument returns the OpenAPI document served at the given URL. The cached document is returned if it is fresher
+// than the TTL or the API confirms it has not been modified (304 response to the conditional request). Otherwise, the
+// document is retrieved and cached. If the API can not be reached, the stale cached document (if any) is returned
+func (c *specDocumentCache) getDocument(url string) ([]byte, error) {
+	documentFile, metadataFile := c.getCacheFiles(url)
+	metadata, document := c.read(url, documentFile, metadataFile)
+	if metadata != nil && c.now().Sub(metadata.FetchedAt) < c.ttl {
+		c.getLogger().Debug(fmt.Sprintf("using the cached OpenAPI document of '%s' retrieved at %s", url, metadata.FetchedAt))
+		return document, nil
+	}
+	req, err := c.swaggerRequest.newRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil {
+		if metadata.ETag != "" {
+			req.Header.Set("If-None-Match", metadata.ETag)
+		}
+		if metadata.LastModified != "" {
+			req.Header.Set("If-Modified-Since", metadata.LastModified)
+		}
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if metadata != nil {
+			c.getLogger().Warn(fmt.Sprintf("failed to revalidate the cached OpenAPI document of '%s', using the cached document retrieved at %s: %s", url, metadata.FetchedAt, err))
+			return document, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && metadata != nil {
+		c.getLogger().Debug(fmt.Sprintf("the cached OpenAPI document of '%s' has not been modified", url))
+		metadata.FetchedAt = c.now()
+		c.write(metadataFile, metadata)
+		return document, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not access document at %q [%s]", url, resp.Status)
+	}
+	document, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(c.directory, 0700); err != nil {
+		c.getLogger().Warn(fmt.Sprintf("failed to create the spec cache directory '%s': %s", c.directory, err))
+		return document, nil
+	}
+	if err := writeFileAtomically(documentFile, document); err != nil {
+		c.getLogger().Warn(fmt.Sprintf("failed to cache the OpenAPI document of '%s': %s", url, err))
+		return document, nil
+	}
+	c.write(metadataFile, &specDocumentCacheMetadata{
+		URL:          url,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		FetchedAt:    c.now(),
+	})
+	return document, nil
+}
+
+func (c *specDocumentCache) getCacheFiles(url string) (documentFile, metadataFile string) {
+	hash := sha256.Sum256([]byte(url))
+	key := hex.EncodeToString(hash[:])
+	return filepath.Join(c.directory, key+".spec"), filepath.Join(c.directory, key+".meta.json")
+}
+
+// read returns the cached document and its metadata, nil metadata is returned if the document is not cached or the
+// cache files are not valid
+func (c *specDocumentCache) read(url, documentFile, metadataFile string) (*specDocumentCacheMetadata, []byte) {
+	rawMetadata, err := ioutil.ReadFile(metadataFile)
+	if err != nil {
+		return nil, nil
+	}
+	metadata := &specDocumentCacheMetadata{}
+	if err := json.Unmarshal(rawMetadata, metadata); err != nil || metadata.URL != url {
+		c.getLogger().Warn(fmt.Sprintf("ignoring the not valid spec cache metadata file '%s'", metadataFile))
+		return nil, nil
+	}
+	document, err := ioutil.ReadFile(documentFile)
+	if err != nil {
+		return nil, nil
+	}
+	return metadata, document
+}
+
+func (c *specDocumentCache) write(metadataFile string, metadata *specDocumentCacheMetadata) {
+	rawMetadata, err := json.Marshal(metadata)
+	if err == nil {
+		err = writeFileAtomically(metadataFile, rawMetadata)
+	}
+	if err != nil {
+		c.getLogger().Warn(fmt.Sprintf("failed to write the spec cache metadata file '%s': %s", metadataFile, err))
+	}
+}
+
+// writeFileAtomically writes the data into a temporary file that is then renamed to the given file, so concurrent
+// terraform invocations never read partially written files
+func writeFileAtomically(file string, data []byte) error {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), file)
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSpecDocumentCacheGetDocument(t *testing.T) {
+	Convey("Given a server serving an OpenAPI document with an ETag and a specDocumentCache", t, func() {
+		var requests []*http.Request
+		document := `swagger: "2.0"`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(document))
+		}))
+		defer server.Close()
+		directory, _ := ioutil.TempDir("", "spec_cache")
+		defer os.RemoveAll(directory)
+		now := time.Now()
+		logger := &loggerStub{}
+		specCache := &specDocumentCache{directory: directory, ttl: time.Hour, httpClient: http.DefaultClient, now: func() time.Time { return now }, logger: logger}
+		Convey("When getDocument is called for the first time", func() {
+			cachedDocument, err := specCache.getDocument(server.URL)
+			Convey("Then the document should be retrieved from the server", func() {
+				So(err, ShouldBeNil)
+				So(string(cachedDocument), ShouldEqual, document)
+				So(requests, ShouldHaveLength, 1)
+				So(requests[0].Header.Get("If-None-Match"), ShouldBeEmpty)
+			})
+			Convey("And when getDocument is called again before the TTL expires", func() {
+				cachedDocument, err := specCache.getDocument(server.URL)
+				Convey("Then the cached document should be returned without calling the server", func() {
+					So(err, ShouldBeNil)
+					So(string(cachedDocument), ShouldEqual, document)
+					So(requests, ShouldHaveLength, 1)
+				})
+			})
+			Convey("And when getDocument is called again after the TTL expires", func() {
+				now = now.Add(2 * time.Hour)
+				cachedDocument, err := specCache.getDocument(server.URL)
+				Convey("Then the cached document should be revalidated with a conditional request", func() {
+					So(err, ShouldBeNil)
+					So(string(cachedDocument), ShouldEqual, document)
+					So(requests, ShouldHaveLength, 2)
+					So(requests[1].Header.Get("If-None-Match"), ShouldEqual, `"v1"`)
+				})
+				Convey("And the revalidated document should be used until the TTL expires again", func() {
+					_, err := specCache.getDocument(server.URL)
+					So(err, ShouldBeNil)
+					So(requests, ShouldHaveLength, 2)
+				})
+			})
+			Convey("And when getDocument is called again after the TTL expires and the server is down", func() {
+				now = now.Add(2 * time.Hour)
+				server.Close()
+				cachedDocument, err := specCache.getDocument(server.URL)
+				Convey("Then the stale cached document should be returned", func() {
+					So(err, ShouldBeNil)
+					So(string(cachedDocument), ShouldEqual, document)
+				})
+				Convey("And the failed revalidation should be logged with the cache logger", func() {
+					So(logger.messages, ShouldNotBeEmpty)
+					lastMessage := logger.messages[len(logger.messages)-1]
+					So(lastMessage.level, ShouldEqual, "WARN")
+					So(lastMessage.msg, ShouldStartWith, "failed to revalidate the cached OpenAPI document of '"+server.URL+"', using the cached document retrieved at")
+				})
+			})
+		})
+	})
+
+	Convey("Given a server that fails to serve the OpenAPI document and a specDocumentCache", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		directory, _ := ioutil.TempDir("", "spec_cache")
+		defer os.RemoveAll(directory)
+		specCache := &specDocumentCache{directory: directory, ttl: time.Hour, httpClient: http.DefaultClient, now: time.Now}
+		Convey("When getDocument is called", func() {
+			_, err := specCache.getDocument(server.URL)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "could not access document at \""+server.URL+"\" [500 Internal Server Error]")
+			})
+		})
+	})
+}
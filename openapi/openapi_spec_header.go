@@ -10,6 +10,11 @@ type SpecHeaderParam struct {
 	Name          string
 	TerraformName string
 	IsRequired    bool
+	// IsResourceAttribute defines whether the header value is configured in the resource that calls the operation
+	// ('x-terraform-header-mode' extension set to 'resource') instead of the provider
+	IsResourceAttribute bool
+	// value contains the value configured in the resource for the headers that are resource attributes
+	value string
 }
 
 // GetHeaderTerraformConfigurationName returns the terraform compliant name of the header. If the header TerraformName
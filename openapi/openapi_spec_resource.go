@@ -16,6 +16,26 @@ type SpecResource interface {
 	// getParentResourceInfo returns a struct populated with relevant parentResourceInfo if the resource is considered
 	// a subresource; nil otherwise.
 	getParentResourceInfo() *parentResourceInfo
+	// getImportLookupProperty returns the name of the property that can be used to look up the resource when importing
+	// it with a value other than the id; empty string if the resource does not support import look ups.
+	getImportLookupProperty() string
+	// getImportIDFormat returns the template describing how the import ID maps to the parent IDs and the instance ID
+	// (e,g: {zone_id}:{record_id}); empty string if the resource uses the default import ID format.
+	getImportIDFormat() string
+	// shouldRemoveOnNotFound returns true if the resource should be removed from the state when the API responds with
+	// 404 Not Found on read (e,g: the resource was deleted outside terraform); false if the read should fail instead.
+	shouldRemoveOnNotFound() bool
+	// isReadOnlyResource returns true if the resource can only be read; hence create, update and delete are not supported.
+	isReadOnlyResource() bool
+	// getStateMigrations returns the migrations that upgrade the resource state from previous schema versions to the
+	// current one; empty if the resource does not declare any migration.
+	getStateMigrations() (specStateMigrations, error)
+	// isProtocolV6Resource returns true if the resource should be served by the plugin framework provider when the
+	// provider is served with the plugin protocol version 6, in which case the objects are exposed as nested attributes.
+	isProtocolV6Resource() bool
+	// getResourceTags returns the tags of the resource; these are the tags of the root path POST operation (e,g: billing)
+	// which enable grouping the resources, for instance when overriding the endpoints per tag group.
+	getResourceTags() []string
 }
 
 type specTimeouts struct {
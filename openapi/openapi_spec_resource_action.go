@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// resourceActionTriggerSuffix is appended to the action names to build the names of the resource attributes that trigger
+// the actions, e,g: reboot_trigger
+const resourceActionTriggerSuffix = "_trigger"
+
+var resourceActionNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// specResourceAction describes an operation of the resource instances that is not part of the CRUD operations, e,g:
+// POST /v1/servers/{id}/reboot ('x-terraform-resource-action' extension). The action is called every time the value of
+// its trigger attribute changes
+type specResourceAction struct {
+	name   string
+	method httpMethodSupported
+	// pathSuffix is the part of the action path following the resource instance path, e,g: /reboot
+	pathSuffix string
+	// body is the request body template (refer to renderBodyTemplateValue), nil if no body is sent
+	body map[string]interface{}
+	// operation contains the settings of the operation defined in the spec (e,g: the security schemes and headers)
+	operation *specResourceOperation
+}
+
+// newSpecResourceActionFromExtension returns the action defined in the object value of the 'x-terraform-resource-action'
+// extension, which must contain the action name (snake case) and optionally the request body
+func newSpecResourceActionFromExtension(object map[string]interface{}) (*specResourceAction, error) {
+	action := &specResourceAction{}
+	for name, value := range object {
+		switch name {
+		case "name":
+			actionName, ok := value.(string)
+			if !ok || !resourceActionNameRegex.MatchString(actionName) {
+				return nil, fmt.Errorf("name must be a snake case string (%v)", value)
+			}
+			action.name = actionName
+		case "body":
+			body, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("body must be an object (%v)", value)
+			}
+			action.body = body
+		default:
+			return nil, fmt.Errorf("field '%s' not supported", name)
+		}
+	}
+	if action.name == "" {
+		return nil, fmt.Errorf("name must be configured")
+	}
+	return action, nil
+}
+
+// getTriggerPropertyName returns the name of the resource attribute that triggers the action when its value changes
+func (a *specResourceAction) getTriggerPropertyName() string {
+	return a.name + resourceActionTriggerSuffix
+}
@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSpecResourceActionFromExtension(t *testing.T) {
+	Convey("Given the object value of the x-terraform-resource-action extension", t, func() {
+		Convey("When newSpecResourceActionFromExtension is called with the name and body", func() {
+			action, err := newSpecResourceActionFromExtension(map[string]interface{}{"name": "reboot", "body": map[string]interface{}{"type": "soft"}})
+			Convey("Then the action returned should contain the name and body", func() {
+				So(err, ShouldBeNil)
+				So(action, ShouldResemble, &specResourceAction{name: "reboot", body: map[string]interface{}{"type": "soft"}})
+			})
+			Convey("And the trigger property name should be the action name followed by the trigger suffix", func() {
+				So(action.getTriggerPropertyName(), ShouldEqual, "reboot_trigger")
+			})
+		})
+		Convey("When newSpecResourceActionFromExtension is called with values that are not valid", func() {
+			testCases := []struct {
+				value         map[string]interface{}
+				expectedError string
+			}{
+				{value: map[string]interface{}{}, expectedError: "name must be configured"},
+				{value: map[string]interface{}{"name": "Reboot"}, expectedError: "name must be a snake case string (Reboot)"},
+				{value: map[string]interface{}{"name": true}, expectedError: "name must be a snake case string (true)"},
+				{value: map[string]interface{}{"name": "reboot", "body": "soft"}, expectedError: "body must be an object (soft)"},
+				{value: map[string]interface{}{"name": "reboot", "method": "post"}, expectedError: "field 'method' not supported"},
+			}
+			Convey("Then the errors returned should describe the problem", func() {
+				for _, tc := range testCases {
+					_, err := newSpecResourceActionFromExtension(tc.value)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// specDeletePoll describes how the provider verifies the resources are actually gone after the DELETE request succeeds
+// ('x-terraform-resource-delete-poll' extension). The resource is read until the API responds with 404 Not Found or
+// 410 Gone, or the resource status is one of the deleted statuses
+type specDeletePoll struct {
+	// deletedStatuses contains the values of the resource status field that mean the resource is deleted (e,g: the APIs
+	// that soft delete the resources), empty if only the 404 Not Found and 410 Gone responses mean the resource is deleted
+	deletedStatuses []string
+	// interval is the time waited between reads, nil to use the provider default poll interval
+	interval *time.Duration
+}
+
+// newSpecDeletePollFromExtension returns the delete poll defined in the object value of the
+// 'x-terraform-resource-delete-poll' extension
+func newSpecDeletePollFromExtension(object map[string]interface{}) (*specDeletePoll, error) {
+	deletePoll := &specDeletePoll{}
+	for name, value := range object {
+		switch name {
+		case "deleted_statuses":
+			switch v := value.(type) {
+			case string:
+				deletePoll.deletedStatuses = strings.Split(strings.Replace(v, " ", "", -1), ",")
+			case []interface{}:
+				for _, item := range v {
+					status, ok := item.(string)
+					if !ok {
+						return nil, fmt.Errorf("deleted_statuses must be a string with comma separated values or a list of strings (%v)", value)
+					}
+					deletePoll.deletedStatuses = append(deletePoll.deletedStatuses, strings.TrimSpace(status))
+				}
+			default:
+				return nil, fmt.Errorf("deleted_statuses must be a string with comma separated values or a list of strings (%v)", value)
+			}
+		case "interval":
+			v, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("interval must be a string (%v)", value)
+			}
+			interval, err := time.ParseDuration(v)
+			if err != nil || interval < 0 {
+				return nil, fmt.Errorf("interval must be a valid positive duration (%s)", v)
+			}
+			deletePoll.interval = &interval
+		default:
+			return nil, fmt.Errorf("field '%s' not supported", name)
+		}
+	}
+	return deletePoll, nil
+}
+
+// isDeletedStatus returns true if the given resource status means the resource is deleted
+func (p *specDeletePoll) isDeletedStatus(status string) bool {
+	for _, deletedStatus := range p.deletedStatuses {
+		if deletedStatus == status {
+			return true
+		}
+	}
+	return false
+}
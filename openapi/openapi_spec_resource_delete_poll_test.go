@@ -0,0 +1,47 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSpecDeletePollFromExtension(t *testing.T) {
+	Convey("Given the object value of the x-terraform-resource-delete-poll extension", t, func() {
+		Convey("When newSpecDeletePollFromExtension is called with the deleted statuses as a list and the interval", func() {
+			deletePoll, err := newSpecDeletePollFromExtension(map[string]interface{}{"deleted_statuses": []interface{}{"deleted", " purged"}, "interval": "2s"})
+			Convey("Then the delete poll returned should contain the settings configured", func() {
+				interval := 2 * time.Second
+				So(err, ShouldBeNil)
+				So(deletePoll, ShouldResemble, &specDeletePoll{deletedStatuses: []string{"deleted", "purged"}, interval: &interval})
+			})
+		})
+		Convey("When newSpecDeletePollFromExtension is called with the deleted statuses as comma separated values", func() {
+			deletePoll, err := newSpecDeletePollFromExtension(map[string]interface{}{"deleted_statuses": "deleted, purged"})
+			Convey("Then the delete poll returned should contain the deleted statuses", func() {
+				So(err, ShouldBeNil)
+				So(deletePoll.deletedStatuses, ShouldResemble, []string{"deleted", "purged"})
+				So(deletePoll.interval, ShouldBeNil)
+			})
+		})
+		Convey("When newSpecDeletePollFromExtension is called with values that are not valid", func() {
+			testCases := []struct {
+				value         map[string]interface{}
+				expectedError string
+			}{
+				{value: map[string]interface{}{"deleted_statuses": []interface{}{1}}, expectedError: "deleted_statuses must be a string with comma separated values or a list of strings ([1])"},
+				{value: map[string]interface{}{"deleted_statuses": true}, expectedError: "deleted_statuses must be a string with comma separated values or a list of strings (true)"},
+				{value: map[string]interface{}{"interval": float64(5)}, expectedError: "interval must be a string (5)"},
+				{value: map[string]interface{}{"interval": "-5s"}, expectedError: "interval must be a valid positive duration (-5s)"},
+				{value: map[string]interface{}{"timeout": "5m"}, expectedError: "field 'timeout' not supported"},
+			}
+			Convey("Then the errors returned should describe the problem", func() {
+				for _, tc := range testCases {
+					_, err := newSpecDeletePollFromExtension(tc.value)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+	})
+}
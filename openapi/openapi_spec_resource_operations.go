@@ -1,11 +1,48 @@
 package openapi
 
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultLocationHeader is the response header used to obtain the location of the resource created when the POST
+// response payload does not contain the resource identifier
+const defaultLocationHeader = "Location"
+
+// The update strategies supported by the PATCH operations ('x-terraform-update-strategy' extension)
+const (
+	// updateStrategyMergePatch sends the attributes changed as a JSON merge patch (RFC 7386)
+	updateStrategyMergePatch = "merge-patch"
+	// updateStrategyJSONPatch sends the attributes changed as a JSON patch (RFC 6902)
+	updateStrategyJSONPatch = "json-patch"
+)
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
 type specResourceOperations struct {
 	List   *specResourceOperation
 	Post   *specResourceOperation
 	Get    *specResourceOperation
 	Put    *specResourceOperation
+	Patch  *specResourceOperation
 	Delete *specResourceOperation
+	// Actions contains the operations of the resource instances that are not part of the CRUD operations
+	// ('x-terraform-resource-action' extension)
+	Actions []*specResourceAction
+}
+
+// getUpdateOperation returns the operation used to update the resource along with its HTTP method. The PUT operation is
+// used by default, the PATCH operation is used instead if the resource does not expose a PUT operation or the PATCH
+// operation explicitly configures the update strategy ('x-terraform-update-strategy' extension). Nil is returned if the
+// resource can not be updated
+func (o specResourceOperations) getUpdateOperation() (*specResourceOperation, httpMethodSupported) {
+	if o.Patch != nil && (o.Put == nil || o.Patch.updateStrategy != "") {
+		return o.Patch, httpPatch
+	}
+	return o.Put, httpPut
 }
 
 // specResourceOperation defines a resource operation
@@ -13,4 +50,208 @@ type specResourceOperation struct {
 	SecuritySchemes  SpecSecuritySchemes
 	HeaderParameters SpecHeaderParameters
 	responses        specResponses
+	// alternativeSecuritySchemes contains the security requirements (in order of preference) that can be used instead
+	// of the SecuritySchemes if the provider is not configured with the values they require
+	alternativeSecuritySchemes []SpecSecuritySchemes
+	// queryParameters contains the static query parameters that should be appended to the operation request URL. Query
+	// parameters holding arrays contain all the values
+	queryParameters map[string][]string
+	// collectionFormats contains the format of the array query parameters of the operation keyed by the query parameter
+	// name. Query parameters not present are sent using the default collectionFormatCSV
+	collectionFormats map[string]collectionFormat
+	// filterParameters contains the names of the query parameters ('x-terraform-filter-param' extension) that can be
+	// used to filter the list of resources server side keyed by the name of the property they filter by (only
+	// applicable to List operations)
+	filterParameters map[string]string
+	// locationHeader contains the name of the response header holding the location of the resource created (only
+	// applicable to POST operations). If empty, the defaultLocationHeader is used
+	locationHeader string
+	// retry contains the retry policy configured in the operation ('x-terraform-resource-retry' extension) overriding
+	// the plugin configuration retry policy, nil if the operation does not configure any
+	retry *RetryConfiguration
+	// pagination describes how the list of resources is paginated ('x-terraform-pagination' extension), nil if the
+	// results are not paginated (only applicable to List operations)
+	pagination *specPagination
+	// updateStrategy contains the format of the patch document sent in the request ('x-terraform-update-strategy'
+	// extension), empty if the operation does not configure it. Only applicable to PATCH operations
+	updateStrategy string
+	// responseWrapperProperty contains the dot separated path to the resource in the response payload of the APIs that
+	// wrap the resource in an envelope (e,g: data for {"data": {...}, "meta": {...}}), empty if the response payload is
+	// the resource itself ('x-terraform-response-wrapper-property' extension)
+	responseWrapperProperty string
+	// requestWrapperProperty contains the dot separated path of the property the resource is nested under in the request
+	// payload of the APIs that expect the resource wrapped in an envelope (e,g: server for {"server": {...}}), empty if
+	// the request payload is the resource itself ('x-terraform-request-wrapper-property' extension)
+	requestWrapperProperty string
+	// optimisticLocking describes how the operation makes sure the resource has not been modified since it was last read
+	// ('x-terraform-optimistic-locking' extension), nil if the operation does not configure it. Only applicable to PUT,
+	// PATCH and DELETE operations
+	optimisticLocking *specOptimisticLocking
+	// conditionalRead is true if the read requests send the ETag of the resource in the If-None-Match header so the API
+	// can respond with 304 Not Modified if the resource has not changed ('x-terraform-conditional-read' extension). Only
+	// applicable to GET operations
+	conditionalRead bool
+	// readAfterCreateRetries is the number of times the resource is read again if the API responds with 404 Not Found
+	// right after the resource is created ('x-terraform-read-after-create-retries' extension). Only applicable to POST
+	// operations
+	readAfterCreateRetries int
+	// deletePoll describes how to verify the resource is actually gone after the DELETE request succeeds, nil if the
+	// deletion is not verified ('x-terraform-resource-delete-poll' extension). Only applicable to DELETE operations
+	deletePoll *specDeletePoll
+	// deleteBodyProperties contains the names of the properties of the body parameter of the operation, which values are
+	// sourced from the resource attributes with the same names. Only applicable to DELETE operations
+	deleteBodyProperties []string
+	// deleteBodyTemplate is the request body sent in the delete requests ('x-terraform-delete-body' extension). The string
+	// values with the form {property_name} are replaced with the value of the resource attribute. Only applicable to
+	// DELETE operations
+	deleteBodyTemplate map[string]interface{}
+	// preDeleteOperation is the operation called before the DELETE request, nil if none ('x-terraform-pre-delete-operation'
+	// extension). Only applicable to DELETE operations
+	preDeleteOperation *specPreDeleteOperation
+	// requestMediaType is the media type of the request body of the operations that do not consume application/json
+	// (application/xml, text/xml, multipart/form-data or application/x-www-form-urlencoded), empty if the request body
+	// is sent as JSON. Only applicable to POST and PUT operations
+	requestMediaType string
+	// responseMediaType is the media type of the response body of the operations that do not produce application/json
+	// (application/xml, text/xml or application/octet-stream), empty if the response body is JSON. Only applicable to
+	// POST, PUT and GET operations (application/octet-stream is only applicable to GET operations)
+	responseMediaType string
+	// xmlSchema describes how the request and response payloads are represented as XML documents, nil if the operation
+	// does not consume nor produce XML
+	xmlSchema *specXMLSchema
+	// responseHeaderProperties contains the names of the computed properties populated with the values of the response
+	// headers, keyed by the header name ('x-terraform-response-header-property' extension). Only applicable to POST, PUT
+	// and PATCH operations
+	responseHeaderProperties map[string]string
+}
+
+// usesXML returns true if the request or the response body of the operation is an XML document
+func (o *specResourceOperation) usesXML() bool {
+	return o != nil && (isXMLMediaType(o.requestMediaType) || isXMLMediaType(o.responseMediaType))
+}
+
+// returnsBinary returns true if the response body of the operation is a binary blob (application/octet-stream)
+func (o *specResourceOperation) returnsBinary() bool {
+	return o != nil && o.responseMediaType == mediaTypeOctetStream
+}
+
+// sendsMultipartFormData returns true if the request body of the operation is sent as multipart/form-data
+func (o *specResourceOperation) sendsMultipartFormData() bool {
+	return o != nil && o.requestMediaType == mediaTypeMultipartFormData
+}
+
+// getSecurityRequirements returns the operation security requirements in order of preference, nil if the operation
+// does not define any security policy (hence the global security requirements apply)
+func (o *specResourceOperation) getSecurityRequirements() []SpecSecuritySchemes {
+	if len(o.SecuritySchemes) == 0 {
+		return nil
+	}
+	return append([]SpecSecuritySchemes{o.SecuritySchemes}, o.alternativeSecuritySchemes...)
+}
+
+// getRetryConfiguration returns the retry policy that applies to the operation: the operation retry policy (with the
+// settings not configured taken from the retry policy provided) or the retry policy provided if the operation does not
+// configure any. Nil is returned if the requests should not be retried
+func (o *specResourceOperation) getRetryConfiguration(retryConfiguration *RetryConfiguration) *RetryConfiguration {
+	if o == nil || o.retry == nil {
+		return retryConfiguration
+	}
+	if o.retry.disabled {
+		return nil
+	}
+	return o.retry.merge(retryConfiguration)
+}
+
+// getLocationHeader returns the name of the response header holding the location of the resource created
+func (o *specResourceOperation) getLocationHeader() string {
+	if o == nil || o.locationHeader == "" {
+		return defaultLocationHeader
+	}
+	return o.locationHeader
+}
+
+// getQueryParameter returns the query parameter with the given values encoded using the collection format of the
+// operation query parameter, collectionFormatCSV by default
+func (o *specResourceOperation) getQueryParameter(name string, values []string) queryParameter {
+	format := collectionFormatCSV
+	if o != nil {
+		if f, exists := o.collectionFormats[name]; exists {
+			format = f
+		}
+	}
+	return queryParameter{name: name, values: values, collectionFormat: format}
+}
+
+// getUpdateStrategy returns the format of the patch document sent in the PATCH requests, updateStrategyMergePatch by default
+func (o *specResourceOperation) getUpdateStrategy() string {
+	if o == nil || o.updateStrategy == "" {
+		return updateStrategyMergePatch
+	}
+	return o.updateStrategy
+}
+
+// getPatchContentType returns the content type of the patch document sent in the PATCH requests
+func (o *specResourceOperation) getPatchContentType() string {
+	if o.getUpdateStrategy() == updateStrategyJSONPatch {
+		return jsonPatchContentType
+	}
+	return mergePatchContentType
+}
+
+// usesETagLocking returns true if the operation is configured with the etag optimistic locking
+func (o *specResourceOperation) usesETagLocking() bool {
+	return o != nil && o.optimisticLocking.usesETag()
+}
+
+// isConditionalRead returns true if the operation is configured with conditional reads
+func (o *specResourceOperation) isConditionalRead() bool {
+	return o != nil && o.conditionalRead
+}
+
+// unwrapResponsePayload returns the resource contained in the response payload envelope ('x-terraform-response-wrapper-property'
+// extension). The payload is returned as is if the operation does not configure the wrapper property or the payload does
+// not contain it (e,g: empty responses)
+func (o *specResourceOperation) unwrapResponsePayload(payload map[string]interface{}) (map[string]interface{}, error) {
+	if o == nil || o.responseWrapperProperty == "" {
+		return payload, nil
+	}
+	value, exists := getPayloadValue(payload, o.responseWrapperProperty)
+	if !exists {
+		return payload, nil
+	}
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response payload property '%s' containing the resource is not an object", o.responseWrapperProperty)
+	}
+	return resource, nil
+}
+
+// wrapRequestPayload returns the request payload nested under the property configured in the
+// 'x-terraform-request-wrapper-property' extension. The JSON patch documents are not nested, the paths of their
+// operations are prefixed with the wrapper property instead. The payload is returned as is if the operation does not
+// configure the wrapper property
+func (o *specResourceOperation) wrapRequestPayload(payload interface{}) interface{} {
+	if o == nil || o.requestWrapperProperty == "" {
+		return payload
+	}
+	names := strings.Split(o.requestWrapperProperty, ".")
+	if operations, ok := payload.([]jsonPatchOperation); ok {
+		prefix := ""
+		for _, name := range names {
+			prefix += "/" + escapeJSONPointerToken(name)
+		}
+		wrappedOperations := make([]jsonPatchOperation, len(operations))
+		for i, operation := range operations {
+			operation.Path = prefix + operation.Path
+			wrappedOperations[i] = operation
+		}
+		return wrappedOperations
+	}
+	for i := len(names) - 1; i >= 0; i-- {
+		payload = map[string]interface{}{names[i]: payload}
+	}
+	return payload
 }
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	// optimisticLockingETag sends the ETag returned by the API in the If-Match header of the requests
+	optimisticLockingETag = "etag"
+	// optimisticLockingVersion sends the value of the resource version property in the update request payloads
+	optimisticLockingVersion = "version"
+)
+
+// specOptimisticLocking describes how the update and delete operations of a resource make sure the resource has not
+// been modified since it was last read ('x-terraform-optimistic-locking' extension)
+type specOptimisticLocking struct {
+	// strategy is one of optimisticLockingETag or optimisticLockingVersion
+	strategy string
+	// versionProperty is the name of the resource property containing the version of the resource (only applicable to
+	// the version strategy)
+	versionProperty string
+	// maxRetries is the number of times the request is retried with the current version of the resource when the API
+	// responds with 409 Conflict or 412 Precondition Failed. The conflicts are not retried by default
+	maxRetries int
+}
+
+// newSpecOptimisticLockingFromExtension returns the optimistic locking defined in the object value of the
+// 'x-terraform-optimistic-locking' extension
+func newSpecOptimisticLockingFromExtension(object map[string]interface{}) (*specOptimisticLocking, error) {
+	optimisticLocking := &specOptimisticLocking{}
+	for name, value := range object {
+		switch name {
+		case "type":
+			strategy, ok := value.(string)
+			if !ok || (strategy != optimisticLockingETag && strategy != optimisticLockingVersion) {
+				return nil, fmt.Errorf("type must be one of %s or %s (%v)", optimisticLockingETag, optimisticLockingVersion, value)
+			}
+			optimisticLocking.strategy = strategy
+		case "version_property":
+			versionProperty, ok := value.(string)
+			if !ok || versionProperty == "" {
+				return nil, fmt.Errorf("version_property must be a non empty string (%v)", value)
+			}
+			optimisticLocking.versionProperty = versionProperty
+		case "max_retries":
+			maxRetries, ok := value.(float64)
+			if !ok || maxRetries != float64(int(maxRetries)) || maxRetries < 0 {
+				return nil, fmt.Errorf("max_retries must be a positive integer (%v)", value)
+			}
+			optimisticLocking.maxRetries = int(maxRetries)
+		default:
+			return nil, fmt.Errorf("field '%s' not supported", name)
+		}
+	}
+	// The version property implies the version strategy
+	if optimisticLocking.strategy == "" && optimisticLocking.versionProperty != "" {
+		optimisticLocking.strategy = optimisticLockingVersion
+	}
+	switch optimisticLocking.strategy {
+	case "":
+		return nil, fmt.Errorf("type must be one of %s or %s", optimisticLockingETag, optimisticLockingVersion)
+	case optimisticLockingVersion:
+		if optimisticLocking.versionProperty == "" {
+			return nil, fmt.Errorf("version_property must be configured along with the %s type", optimisticLockingVersion)
+		}
+	case optimisticLockingETag:
+		if optimisticLocking.versionProperty != "" {
+			return nil, fmt.Errorf("version_property is not supported by the %s type", optimisticLockingETag)
+		}
+	}
+	return optimisticLocking, nil
+}
+
+// usesETag returns true if the ETag of the resource is sent in the If-Match header of the requests
+func (l *specOptimisticLocking) usesETag() bool {
+	return l != nil && l.strategy == optimisticLockingETag
+}
+
+// usesVersionProperty returns true if the value of the resource version property is sent in the update request payloads
+func (l *specOptimisticLocking) usesVersionProperty() bool {
+	return l != nil && l.strategy == optimisticLockingVersion
+}
+
+// shouldRetry returns true if the API responded with a conflict (409 Conflict or 412 Precondition Failed) and the
+// number of retries attempted so far has not reached the configured max retries
+func (l *specOptimisticLocking) shouldRetry(res *http.Response, retries int) bool {
+	if l == nil || res == nil || retries >= l.maxRetries {
+		return false
+	}
+	return res.StatusCode == http.StatusConflict || res.StatusCode == http.StatusPreconditionFailed
+}
+
+// addVersion returns the update request payload containing the given version of the resource. The JSON patch documents
+// get a test operation prepended instead, so the API only applies the patch if the version matches. The payload is
+// returned as is if the version property is not used or the version is not known
+func (l *specOptimisticLocking) addVersion(payload interface{}, version interface{}) interface{} {
+	if !l.usesVersionProperty() || version == nil {
+		return payload
+	}
+	switch p := payload.(type) {
+	case map[string]interface{}:
+		payloadWithVersion := make(map[string]interface{}, len(p)+1)
+		for name, value := range p {
+			payloadWithVersion[name] = value
+		}
+		payloadWithVersion[l.versionProperty] = version
+		return payloadWithVersion
+	case []jsonPatchOperation:
+		return append([]jsonPatchOperation{{Op: "test", Path: "/" + escapeJSONPointerToken(l.versionProperty), Value: version}}, p...)
+	}
+	return payload
+}
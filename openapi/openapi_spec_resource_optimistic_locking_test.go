@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSpecOptimisticLockingFromExtension(t *testing.T) {
+	Convey("Given the object value of the x-terraform-optimistic-locking extension", t, func() {
+		Convey("When newSpecOptimisticLockingFromExtension is called with the etag type and max retries", func() {
+			optimisticLocking, err := newSpecOptimisticLockingFromExtension(map[string]interface{}{"type": "etag", "max_retries": float64(2)})
+			Convey("Then the optimistic locking returned should use the ETag and retry the conflicts", func() {
+				So(err, ShouldBeNil)
+				So(optimisticLocking, ShouldResemble, &specOptimisticLocking{strategy: optimisticLockingETag, maxRetries: 2})
+			})
+		})
+		Convey("When newSpecOptimisticLockingFromExtension is called with the version property only", func() {
+			optimisticLocking, err := newSpecOptimisticLockingFromExtension(map[string]interface{}{"version_property": "resourceVersion"})
+			Convey("Then the optimistic locking returned should use the version property", func() {
+				So(err, ShouldBeNil)
+				So(optimisticLocking, ShouldResemble, &specOptimisticLocking{strategy: optimisticLockingVersion, versionProperty: "resourceVersion"})
+			})
+		})
+		Convey("When newSpecOptimisticLockingFromExtension is called with values that are not valid", func() {
+			testCases := []struct {
+				value         map[string]interface{}
+				expectedError string
+			}{
+				{value: map[string]interface{}{}, expectedError: "type must be one of etag or version"},
+				{value: map[string]interface{}{"type": "lock"}, expectedError: "type must be one of etag or version (lock)"},
+				{value: map[string]interface{}{"type": "version"}, expectedError: "version_property must be configured along with the version type"},
+				{value: map[string]interface{}{"type": "etag", "version_property": "version"}, expectedError: "version_property is not supported by the etag type"},
+				{value: map[string]interface{}{"type": "etag", "max_retries": float64(-1)}, expectedError: "max_retries must be a positive integer (-1)"},
+				{value: map[string]interface{}{"type": "etag", "header": "If-Match"}, expectedError: "field 'header' not supported"},
+			}
+			Convey("Then the errors returned should describe the problem", func() {
+				for _, tc := range testCases {
+					_, err := newSpecOptimisticLockingFromExtension(tc.value)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+	})
+}
+
+func TestSpecOptimisticLockingShouldRetry(t *testing.T) {
+	Convey("Given an optimistic locking configured with one retry", t, func() {
+		optimisticLocking := &specOptimisticLocking{strategy: optimisticLockingETag, maxRetries: 1}
+		Convey("When shouldRetry is called with the conflict responses and no retries attempted", func() {
+			Convey("Then the conflicts should be retried", func() {
+				So(optimisticLocking.shouldRetry(&http.Response{StatusCode: http.StatusConflict}, 0), ShouldBeTrue)
+				So(optimisticLocking.shouldRetry(&http.Response{StatusCode: http.StatusPreconditionFailed}, 0), ShouldBeTrue)
+			})
+		})
+		Convey("When shouldRetry is called with a conflict response once the retries are exhausted", func() {
+			Convey("Then the conflict should not be retried", func() {
+				So(optimisticLocking.shouldRetry(&http.Response{StatusCode: http.StatusConflict}, 1), ShouldBeFalse)
+			})
+		})
+		Convey("When shouldRetry is called with a response that is not a conflict", func() {
+			Convey("Then the response should not be retried", func() {
+				So(optimisticLocking.shouldRetry(&http.Response{StatusCode: http.StatusInternalServerError}, 0), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a nil optimistic locking", t, func() {
+		var optimisticLocking *specOptimisticLocking
+		Convey("When shouldRetry is called with a conflict response", func() {
+			Convey("Then the conflict should not be retried", func() {
+				So(optimisticLocking.shouldRetry(&http.Response{StatusCode: http.StatusConflict}, 0), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestSpecOptimisticLockingAddVersion(t *testing.T) {
+	Convey("Given an optimistic locking using the version property", t, func() {
+		optimisticLocking := &specOptimisticLocking{strategy: optimisticLockingVersion, versionProperty: "version"}
+		Convey("When addVersion is called with a payload", func() {
+			payload := map[string]interface{}{"name": "someName"}
+			payloadWithVersion := optimisticLocking.addVersion(payload, 3)
+			Convey("Then the payload returned should contain the version", func() {
+				So(payloadWithVersion, ShouldResemble, map[string]interface{}{"name": "someName", "version": 3})
+			})
+			Convey("And the given payload should not be modified", func() {
+				So(payload, ShouldResemble, map[string]interface{}{"name": "someName"})
+			})
+		})
+		Convey("When addVersion is called with a JSON patch document", func() {
+			payloadWithVersion := optimisticLocking.addVersion([]jsonPatchOperation{{Op: "replace", Path: "/name", Value: "someName"}}, 3)
+			Convey("Then the JSON patch returned should test the version first", func() {
+				So(payloadWithVersion, ShouldResemble, []jsonPatchOperation{{Op: "test", Path: "/version", Value: 3}, {Op: "replace", Path: "/name", Value: "someName"}})
+			})
+		})
+		Convey("When addVersion is called with an unknown version", func() {
+			payloadWithVersion := optimisticLocking.addVersion(map[string]interface{}{"name": "someName"}, nil)
+			Convey("Then the payload should be returned as is", func() {
+				So(payloadWithVersion, ShouldResemble, map[string]interface{}{"name": "someName"})
+			})
+		})
+	})
+	Convey("Given an optimistic locking using the ETag", t, func() {
+		optimisticLocking := &specOptimisticLocking{strategy: optimisticLockingETag}
+		Convey("When addVersion is called with a payload", func() {
+			payloadWithVersion := optimisticLocking.addVersion(map[string]interface{}{"name": "someName"}, `"v1"`)
+			Convey("Then the payload should be returned as is", func() {
+				So(payloadWithVersion, ShouldResemble, map[string]interface{}{"name": "someName"})
+			})
+		})
+	})
+}
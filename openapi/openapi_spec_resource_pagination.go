@@ -0,0 +1,108 @@
+package openapi
+
+import "fmt"
+
+const (
+	// paginationPage pages through the list incrementing the page number query parameter until an empty (or partial)
+	// page is returned
+	paginationPage = "page"
+	// paginationCursor pages through the list sending the cursor returned in the response payload as query parameter
+	// until no cursor is returned
+	paginationCursor = "cursor"
+	// paginationLinkHeader pages through the list following the rel="next" URL of the Link response header (RFC 8288)
+	paginationLinkHeader = "link-header"
+)
+
+const (
+	defaultPaginationPageParam   = "page"
+	defaultPaginationStartPage   = 1
+	defaultPaginationCursorParam = "cursor"
+	defaultPaginationCursorField = "next_cursor"
+	defaultPaginationItemsField  = "items"
+)
+
+// specPagination describes how the List operation of a resource paginates the results ('x-terraform-pagination'
+// extension) so all the pages can be fetched
+type specPagination struct {
+	// strategy is one of paginationPage, paginationCursor or paginationLinkHeader
+	strategy string
+	// pageParam is the name of the query parameter containing the page number and startPage the number of the first
+	// page (only applicable to the page strategy)
+	pageParam string
+	startPage int
+	// cursorParam is the name of the query parameter the cursor is sent in and cursorField the name of the response
+	// payload field containing the cursor to the next page (only applicable to the cursor strategy)
+	cursorParam string
+	cursorField string
+	// itemsField is the name of the response payload field containing the items when the API wraps the list in a JSON
+	// object. If empty, the response payload is expected to be a JSON array
+	itemsField string
+	// limitParam is the name of the query parameter containing the page size (limit). If empty, the page size is not
+	// sent to the API. If limit is configured, a page containing fewer items is considered the last one
+	limitParam string
+	limit      int
+}
+
+// newSpecPagination returns the pagination for the given strategy with the default settings
+func newSpecPagination(strategy string) (*specPagination, error) {
+	switch strategy {
+	case paginationPage:
+		return &specPagination{strategy: strategy, pageParam: defaultPaginationPageParam, startPage: defaultPaginationStartPage}, nil
+	case paginationCursor:
+		return &specPagination{strategy: strategy, cursorParam: defaultPaginationCursorParam, cursorField: defaultPaginationCursorField, itemsField: defaultPaginationItemsField}, nil
+	case paginationLinkHeader:
+		return &specPagination{strategy: strategy}, nil
+	}
+	return nil, fmt.Errorf("pagination type '%s' not supported, supported types are: %s, %s and %s", strategy, paginationCursor, paginationPage, paginationLinkHeader)
+}
+
+// newSpecPaginationFromExtension returns the pagination defined in the object value of the 'x-terraform-pagination'
+// extension. The settings not defined take the default values of the pagination type
+func newSpecPaginationFromExtension(object map[string]interface{}) (*specPagination, error) {
+	strategy, ok := object["type"].(string)
+	if !ok {
+		return nil, fmt.Errorf("type must be one of %s, %s or %s (%v)", paginationCursor, paginationPage, paginationLinkHeader, object["type"])
+	}
+	pagination, err := newSpecPagination(strategy)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range object {
+		switch name {
+		case "type":
+		case "page_param", "cursor_param", "cursor_field", "items_field", "limit_param":
+			v, ok := value.(string)
+			if !ok || v == "" {
+				return nil, fmt.Errorf("%s must be a non empty string (%v)", name, value)
+			}
+			switch name {
+			case "page_param":
+				pagination.pageParam = v
+			case "cursor_param":
+				pagination.cursorParam = v
+			case "cursor_field":
+				pagination.cursorField = v
+			case "items_field":
+				pagination.itemsField = v
+			case "limit_param":
+				pagination.limitParam = v
+			}
+		case "start_page", "limit":
+			v, ok := value.(float64)
+			if !ok || v != float64(int(v)) || v < 0 {
+				return nil, fmt.Errorf("%s must be a positive integer (%v)", name, value)
+			}
+			if name == "start_page" {
+				pagination.startPage = int(v)
+			} else {
+				pagination.limit = int(v)
+			}
+		default:
+			return nil, fmt.Errorf("field '%s' not supported", name)
+		}
+	}
+	if pagination.limitParam != "" && pagination.limit == 0 {
+		return nil, fmt.Errorf("limit must be configured along with the limit_param")
+	}
+	return pagination, nil
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSpecPaginationFromExtension(t *testing.T) {
+	testCases := []struct {
+		name               string
+		extension          map[string]interface{}
+		expectedPagination *specPagination
+		expectedError      error
+	}{
+		{
+			name:               "page pagination with the default settings",
+			extension:          map[string]interface{}{"type": "page"},
+			expectedPagination: &specPagination{strategy: paginationPage, pageParam: "page", startPage: 1},
+		},
+		{
+			name:               "page pagination with custom settings",
+			extension:          map[string]interface{}{"type": "page", "page_param": "p", "start_page": float64(0), "limit_param": "per_page", "limit": float64(50)},
+			expectedPagination: &specPagination{strategy: paginationPage, pageParam: "p", startPage: 0, limitParam: "per_page", limit: 50},
+		},
+		{
+			name:               "cursor pagination with the default settings",
+			extension:          map[string]interface{}{"type": "cursor"},
+			expectedPagination: &specPagination{strategy: paginationCursor, cursorParam: "cursor", cursorField: "next_cursor", itemsField: "items"},
+		},
+		{
+			name:               "cursor pagination with custom settings",
+			extension:          map[string]interface{}{"type": "cursor", "cursor_param": "after", "cursor_field": "next", "items_field": "data"},
+			expectedPagination: &specPagination{strategy: paginationCursor, cursorParam: "after", cursorField: "next", itemsField: "data"},
+		},
+		{
+			name:               "link header pagination",
+			extension:          map[string]interface{}{"type": "link-header", "limit": float64(100)},
+			expectedPagination: &specPagination{strategy: paginationLinkHeader, limit: 100},
+		},
+		{
+			name:          "missing type",
+			extension:     map[string]interface{}{"page_param": "p"},
+			expectedError: errors.New("type must be one of cursor, page or link-header (<nil>)"),
+		},
+		{
+			name:          "type not supported",
+			extension:     map[string]interface{}{"type": "offset"},
+			expectedError: errors.New("pagination type 'offset' not supported, supported types are: cursor, page and link-header"),
+		},
+		{
+			name:          "string setting with the wrong type",
+			extension:     map[string]interface{}{"type": "page", "page_param": float64(1)},
+			expectedError: errors.New("page_param must be a non empty string (1)"),
+		},
+		{
+			name:          "integer setting with the wrong value",
+			extension:     map[string]interface{}{"type": "page", "limit": float64(1.5)},
+			expectedError: errors.New("limit must be a positive integer (1.5)"),
+		},
+		{
+			name:          "limit param without limit",
+			extension:     map[string]interface{}{"type": "page", "limit_param": "per_page"},
+			expectedError: errors.New("limit must be configured along with the limit_param"),
+		},
+		{
+			name:          "setting not supported",
+			extension:     map[string]interface{}{"type": "page", "offset_param": "offset"},
+			expectedError: errors.New("field 'offset_param' not supported"),
+		},
+	}
+	for _, tc := range testCases {
+		pagination, err := newSpecPaginationFromExtension(tc.extension)
+		assert.Equal(t, tc.expectedError, err, tc.name)
+		assert.Equal(t, tc.expectedPagination, pagination, tc.name)
+	}
+}
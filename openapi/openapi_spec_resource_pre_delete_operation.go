@@ -0,0 +1,92 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// specPreDeleteOperation describes the operation called before the DELETE request of the resources that require a two
+// step teardown, e,g: POST /v1/resource/{id}/detach ('x-terraform-pre-delete-operation' extension)
+type specPreDeleteOperation struct {
+	method httpMethodSupported
+	// path is the path of the operation as defined in the spec, e,g: /v1/resource/{id}/detach
+	path string
+	// pathSuffix is the part of the path following the resource instance path, e,g: /detach
+	pathSuffix string
+	// body is the request body template (refer to renderBodyTemplateValue), nil if no body is sent
+	body map[string]interface{}
+	// operation contains the settings of the operation defined in the spec (e,g: the security schemes and headers)
+	operation *specResourceOperation
+}
+
+// newSpecPreDeleteOperationFromExtension returns the pre delete operation defined in the object value of the
+// 'x-terraform-pre-delete-operation' extension. The path must be a sub path of the given resource instance path (e,g:
+// /v1/resource/{id}/detach for the resource /v1/resource) and the method defaults to POST
+func newSpecPreDeleteOperationFromExtension(object map[string]interface{}, resourcePath string) (*specPreDeleteOperation, error) {
+	preDeleteOperation := &specPreDeleteOperation{method: httpPost}
+	for name, value := range object {
+		switch name {
+		case "path":
+			path, ok := value.(string)
+			if !ok || path == "" {
+				return nil, fmt.Errorf("path must be a non empty string (%v)", value)
+			}
+			preDeleteOperation.path = path
+		case "method":
+			method, _ := value.(string)
+			switch httpMethodSupported(strings.ToUpper(method)) {
+			case httpPost, httpPut, httpPatch:
+				preDeleteOperation.method = httpMethodSupported(strings.ToUpper(method))
+			default:
+				return nil, fmt.Errorf("method must be one of post, put or patch (%v)", value)
+			}
+		case "body":
+			body, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("body must be an object (%v)", value)
+			}
+			preDeleteOperation.body = body
+		default:
+			return nil, fmt.Errorf("field '%s' not supported", name)
+		}
+	}
+	if preDeleteOperation.path == "" {
+		return nil, fmt.Errorf("path must be configured")
+	}
+	pathSuffix, err := getInstancePathSuffix(preDeleteOperation.path, resourcePath)
+	if err != nil {
+		return nil, err
+	}
+	preDeleteOperation.pathSuffix = pathSuffix
+	return preDeleteOperation, nil
+}
+
+// getInstancePathSuffix returns the part of the given path following the instance path of the resource (e,g: /detach
+// for the path /v1/resource/{id}/detach and the resource path /v1/resource)
+func getInstancePathSuffix(path, resourcePath string) (string, error) {
+	instancePathPrefix := strings.TrimSuffix(resourcePath, "/") + "/{"
+	if !strings.HasPrefix(path, instancePathPrefix) {
+		return "", fmt.Errorf("path '%s' is not a sub path of the resource instance path '%s{id}'", path, instancePathPrefix[:len(instancePathPrefix)-1])
+	}
+	remainingPath := strings.TrimPrefix(path, instancePathPrefix)
+	idx := strings.Index(remainingPath, "}")
+	if idx < 0 || len(remainingPath) <= idx+2 || remainingPath[idx+1] != '/' {
+		return "", fmt.Errorf("path '%s' is not a sub path of the resource instance path '%s{id}'", path, instancePathPrefix[:len(instancePathPrefix)-1])
+	}
+	return remainingPath[idx+1:], nil
+}
+
+// getPathItemOperation returns the operation of the path item for the given method, nil if the path does not define it
+func getPathItemOperation(pathItem spec.PathItem, method httpMethodSupported) *spec.Operation {
+	switch method {
+	case httpPost:
+		return pathItem.Post
+	case httpPut:
+		return pathItem.Put
+	case httpPatch:
+		return pathItem.Patch
+	}
+	return nil
+}
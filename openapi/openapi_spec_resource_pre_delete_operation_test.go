@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSpecPreDeleteOperationFromExtension(t *testing.T) {
+	Convey("Given the object value of the x-terraform-pre-delete-operation extension", t, func() {
+		Convey("When newSpecPreDeleteOperationFromExtension is called with the path only", func() {
+			preDeleteOperation, err := newSpecPreDeleteOperationFromExtension(map[string]interface{}{"path": "/v1/resource/{id}/detach"}, "/v1/resource")
+			Convey("Then the pre delete operation returned should be a POST to the path suffix", func() {
+				So(err, ShouldBeNil)
+				So(preDeleteOperation, ShouldResemble, &specPreDeleteOperation{method: httpPost, path: "/v1/resource/{id}/detach", pathSuffix: "/detach"})
+			})
+		})
+		Convey("When newSpecPreDeleteOperationFromExtension is called with the method and body of a sub-resource operation", func() {
+			preDeleteOperation, err := newSpecPreDeleteOperationFromExtension(map[string]interface{}{"path": "/v1/cdns/{cdn_id}/firewalls/{id}/actions/disable", "method": "put", "body": map[string]interface{}{"enabled": false}}, "/v1/cdns/{cdn_id}/firewalls")
+			Convey("Then the pre delete operation returned should contain the settings configured", func() {
+				So(err, ShouldBeNil)
+				So(preDeleteOperation, ShouldResemble, &specPreDeleteOperation{method: httpPut, path: "/v1/cdns/{cdn_id}/firewalls/{id}/actions/disable", pathSuffix: "/actions/disable", body: map[string]interface{}{"enabled": false}})
+			})
+		})
+		Convey("When newSpecPreDeleteOperationFromExtension is called with values that are not valid", func() {
+			testCases := []struct {
+				value         map[string]interface{}
+				expectedError string
+			}{
+				{value: map[string]interface{}{}, expectedError: "path must be configured"},
+				{value: map[string]interface{}{"path": ""}, expectedError: "path must be a non empty string ()"},
+				{value: map[string]interface{}{"path": "/v1/resource/{id}/detach", "method": "delete"}, expectedError: "method must be one of post, put or patch (delete)"},
+				{value: map[string]interface{}{"path": "/v1/resource/{id}/detach", "body": "force"}, expectedError: "body must be an object (force)"},
+				{value: map[string]interface{}{"path": "/v1/resource/{id}/detach", "headers": "X-Request-ID"}, expectedError: "field 'headers' not supported"},
+				{value: map[string]interface{}{"path": "/v1/other/{id}/detach"}, expectedError: "path '/v1/other/{id}/detach' is not a sub path of the resource instance path '/v1/resource/{id}'"},
+				{value: map[string]interface{}{"path": "/v1/resource/{id}"}, expectedError: "path '/v1/resource/{id}' is not a sub path of the resource instance path '/v1/resource/{id}'"},
+			}
+			Convey("Then the errors returned should describe the problem", func() {
+				for _, tc := range testCases {
+					_, err := newSpecPreDeleteOperationFromExtension(tc.value, "/v1/resource")
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+	})
+}
@@ -1,11 +1,161 @@
 package openapi
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultErrorFieldKey = "field"
+const defaultErrorMessageKey = "message"
+const defaultAsyncOperationLocationHeader = "Location"
+const defaultAsyncOperationStatusField = "status"
+
+var defaultAsyncOperationSuccessStatuses = []string{"succeeded"}
+var defaultAsyncOperationFailureStatuses = []string{"failed", "canceled", "cancelled"}
+
 type specResponses map[int]*specResponse
 
 type specResponse struct {
 	isPollingEnabled    bool
 	pollTargetStatuses  []string
 	pollPendingStatuses []string
+	// pollInterval, pollMinTimeout and pollDelay override the default polling settings when polling the resource or the
+	// asynchronous operation after receiving this response, nil if the default settings should be used
+	pollInterval   *time.Duration
+	pollMinTimeout *time.Duration
+	pollDelay      *time.Duration
+	fieldErrors    *specResponseFieldErrors
+	// asyncOperation describes the operation endpoint that should be polled when the API responds with this response
+	// ('x-terraform-async-operation' extension), nil if the response does not return an asynchronous operation
+	asyncOperation *specAsyncOperation
+}
+
+// specAsyncOperation describes an asynchronous operation returned by the API (e,g: 202 Accepted along with the operation
+// URL in the Location header) which has to be polled until it reports that it succeeded or failed
+type specAsyncOperation struct {
+	// locationHeader is the name of the response header holding the operation URL
+	locationHeader string
+	// urlField is the dot separated path to the operation URL in the response payload. If set, it takes preference over
+	// the locationHeader
+	urlField string
+	// statusField is the dot separated path to the operation status in the operation payload
+	statusField string
+	// successStatuses and failureStatuses contain the operation statuses (case insensitive) that mean that the operation
+	// has finished. Any other status means that the operation is still in progress
+	successStatuses []string
+	failureStatuses []string
+	// errorField is the dot separated path to the error message in the operation payload, used to describe why the
+	// operation failed
+	errorField string
+}
+
+func newSpecAsyncOperation() *specAsyncOperation {
+	return &specAsyncOperation{
+		locationHeader:  defaultAsyncOperationLocationHeader,
+		statusField:     defaultAsyncOperationStatusField,
+		successStatuses: defaultAsyncOperationSuccessStatuses,
+		failureStatuses: defaultAsyncOperationFailureStatuses,
+	}
+}
+
+// getOperationURL returns the operation URL from the response payload (if the urlField is configured) or the response
+// location header
+func (a specAsyncOperation) getOperationURL(resp *http.Response, responsePayload map[string]interface{}) (string, error) {
+	if a.urlField != "" {
+		value, _ := getPayloadValue(responsePayload, a.urlField)
+		operationURL, ok := value.(string)
+		if !ok || operationURL == "" {
+			return "", fmt.Errorf("response payload does not contain the asynchronous operation URL field '%s'", a.urlField)
+		}
+		return operationURL, nil
+	}
+	operationURL := resp.Header.Get(a.locationHeader)
+	if operationURL == "" {
+		return "", fmt.Errorf("response does not contain the asynchronous operation URL header '%s'", a.locationHeader)
+	}
+	return operationURL, nil
+}
+
+// getStatus returns the operation status contained in the operation payload
+func (a specAsyncOperation) getStatus(operationPayload map[string]interface{}) (string, error) {
+	value, exists := getPayloadValue(operationPayload, a.statusField)
+	if !exists {
+		return "", fmt.Errorf("asynchronous operation payload does not contain the status field '%s'", a.statusField)
+	}
+	status, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("asynchronous operation status field '%s' is not a string (%v)", a.statusField, value)
+	}
+	return status, nil
+}
+
+func (a specAsyncOperation) isSucceeded(status string) bool {
+	return containsStatus(a.successStatuses, status)
+}
+
+func (a specAsyncOperation) isFailed(status string) bool {
+	return containsStatus(a.failureStatuses, status)
+}
+
+// getErrorMessage returns the error message contained in the operation payload, empty if the errorField is not configured
+// or the payload does not contain it
+func (a specAsyncOperation) getErrorMessage(operationPayload map[string]interface{}) string {
+	if a.errorField == "" {
+		return ""
+	}
+	value, exists := getPayloadValue(operationPayload, a.errorField)
+	if !exists || value == nil {
+		return ""
+	}
+	if message, ok := value.(string); ok {
+		return message
+	}
+	message, _ := json.Marshal(value)
+	return string(message)
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPayloadValue returns the value located in the given dot separated path of the payload. False is returned if the
+// payload does not contain the path
+func getPayloadValue(payload map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = payload
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// specResponseFieldErrors describes where the field level errors are located in an error response payload
+type specResponseFieldErrors struct {
+	// path is the dot separated path to the list of field errors in the response payload (e,g: errors or error.details)
+	path string
+	// fieldKey is the key of the field error object that holds the path of the field the error refers to
+	fieldKey string
+	// messageKey is the key of the field error object that holds the error message
+	messageKey string
+}
+
+// fieldError represents an error returned by the API for a specific field of the request payload
+type fieldError struct {
+	field   string
+	message string
 }
 
 func (s specResponses) getResponse(responseStatusCode int) *specResponse {
@@ -15,3 +165,37 @@ func (s specResponses) getResponse(responseStatusCode int) *specResponse {
 	}
 	return response
 }
+
+// getFieldErrors extracts the field errors from the given response body. Items that do not contain the field and the
+// message are ignored
+func (s specResponseFieldErrors) getFieldErrors(responseBody []byte) ([]fieldError, error) {
+	var payload interface{}
+	if err := json.Unmarshal(responseBody, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal error response body: %s", err)
+	}
+	for _, key := range strings.Split(s.path, ".") {
+		object, ok := payload.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("error response body does not contain the field errors path '%s'", s.path)
+		}
+		payload = object[key]
+	}
+	items, ok := payload.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error response body field errors path '%s' is not a list", s.path)
+	}
+	var fieldErrors []fieldError
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field, fieldOk := object[s.fieldKey].(string)
+		message, messageOk := object[s.messageKey].(string)
+		if !fieldOk || !messageOk || field == "" {
+			continue
+		}
+		fieldErrors = append(fieldErrors, fieldError{field: field, message: message})
+	}
+	return fieldErrors, nil
+}
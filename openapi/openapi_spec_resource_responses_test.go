@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecResponsesGetResponse(t *testing.T) {
+	responses := specResponses{422: &specResponse{}}
+	assert.NotNil(t, responses.getResponse(422))
+	assert.Nil(t, responses.getResponse(400))
+}
+
+func TestSpecResponseFieldErrorsGetFieldErrors(t *testing.T) {
+	testCases := []struct {
+		name                string
+		fieldErrors         specResponseFieldErrors
+		responseBody        string
+		expectedFieldErrors []fieldError
+		expectedError       string
+	}{
+		{
+			name:         "top level field errors",
+			fieldErrors:  specResponseFieldErrors{path: "errors", fieldKey: defaultErrorFieldKey, messageKey: defaultErrorMessageKey},
+			responseBody: `{"errors":[{"field":"label","message":"must not be empty"},{"field":"size","message":"must be greater than 0"}]}`,
+			expectedFieldErrors: []fieldError{
+				{field: "label", message: "must not be empty"},
+				{field: "size", message: "must be greater than 0"},
+			},
+		},
+		{
+			name:                "nested field errors with custom keys",
+			fieldErrors:         specResponseFieldErrors{path: "error.details", fieldKey: "pointer", messageKey: "detail"},
+			responseBody:        `{"error":{"details":[{"pointer":"/label","detail":"must not be empty"}]}}`,
+			expectedFieldErrors: []fieldError{{field: "/label", message: "must not be empty"}},
+		},
+		{
+			name:                "field errors missing the field or message are ignored",
+			fieldErrors:         specResponseFieldErrors{path: "errors", fieldKey: defaultErrorFieldKey, messageKey: defaultErrorMessageKey},
+			responseBody:        `{"errors":[{"message":"something went wrong"},{"field":"label"},"not an object",{"field":"label","message":"must not be empty"}]}`,
+			expectedFieldErrors: []fieldError{{field: "label", message: "must not be empty"}},
+		},
+		{
+			name:          "response body is not json",
+			fieldErrors:   specResponseFieldErrors{path: "errors", fieldKey: defaultErrorFieldKey, messageKey: defaultErrorMessageKey},
+			responseBody:  `some error`,
+			expectedError: "failed to unmarshal error response body: invalid character 's' looking for beginning of value",
+		},
+		{
+			name:          "response body does not contain the path",
+			fieldErrors:   specResponseFieldErrors{path: "error.details", fieldKey: defaultErrorFieldKey, messageKey: defaultErrorMessageKey},
+			responseBody:  `{"error":"some error"}`,
+			expectedError: "error response body does not contain the field errors path 'error.details'",
+		},
+		{
+			name:          "response body path is not a list",
+			fieldErrors:   specResponseFieldErrors{path: "errors", fieldKey: defaultErrorFieldKey, messageKey: defaultErrorMessageKey},
+			responseBody:  `{"errors":"some error"}`,
+			expectedError: "error response body field errors path 'errors' is not a list",
+		},
+	}
+	for _, tc := range testCases {
+		fieldErrors, err := tc.fieldErrors.getFieldErrors([]byte(tc.responseBody))
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedFieldErrors, fieldErrors, tc.name)
+	}
+}
+
+func TestSpecAsyncOperationGetOperationURL(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Location": []string{"/v1/operations/1"}, "Operation-Location": []string{"/v1/operations/2"}}}
+	testCases := []struct {
+		name                 string
+		asyncOperation       *specAsyncOperation
+		responsePayload      map[string]interface{}
+		expectedOperationURL string
+		expectedError        string
+	}{
+		{
+			name:                 "operation URL in the default location header",
+			asyncOperation:       newSpecAsyncOperation(),
+			expectedOperationURL: "/v1/operations/1",
+		},
+		{
+			name:                 "operation URL in a custom location header",
+			asyncOperation:       &specAsyncOperation{locationHeader: "Operation-Location"},
+			expectedOperationURL: "/v1/operations/2",
+		},
+		{
+			name:                 "operation URL in the response payload",
+			asyncOperation:       &specAsyncOperation{locationHeader: defaultAsyncOperationLocationHeader, urlField: "operation.href"},
+			responsePayload:      map[string]interface{}{"operation": map[string]interface{}{"href": "https://host.com/v1/operations/3"}},
+			expectedOperationURL: "https://host.com/v1/operations/3",
+		},
+		{
+			name:           "operation URL header missing",
+			asyncOperation: &specAsyncOperation{locationHeader: "Azure-AsyncOperation"},
+			expectedError:  "response does not contain the asynchronous operation URL header 'Azure-AsyncOperation'",
+		},
+		{
+			name:            "operation URL field missing",
+			asyncOperation:  &specAsyncOperation{urlField: "operation.href"},
+			responsePayload: map[string]interface{}{"operation": "1"},
+			expectedError:   "response payload does not contain the asynchronous operation URL field 'operation.href'",
+		},
+	}
+	for _, tc := range testCases {
+		operationURL, err := tc.asyncOperation.getOperationURL(resp, tc.responsePayload)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedOperationURL, operationURL, tc.name)
+	}
+}
+
+func TestSpecAsyncOperationStatus(t *testing.T) {
+	asyncOperation := specAsyncOperation{statusField: "properties.status", successStatuses: defaultAsyncOperationSuccessStatuses, failureStatuses: defaultAsyncOperationFailureStatuses, errorField: "error"}
+
+	status, err := asyncOperation.getStatus(map[string]interface{}{"properties": map[string]interface{}{"status": "Succeeded"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Succeeded", status)
+	assert.True(t, asyncOperation.isSucceeded(status), "statuses should be case insensitive")
+	assert.False(t, asyncOperation.isFailed(status))
+	assert.True(t, asyncOperation.isFailed("Canceled"))
+	assert.False(t, asyncOperation.isSucceeded("Running"))
+	assert.False(t, asyncOperation.isFailed("Running"))
+
+	_, err = asyncOperation.getStatus(map[string]interface{}{"status": "Succeeded"})
+	assert.EqualError(t, err, "asynchronous operation payload does not contain the status field 'properties.status'")
+	_, err = asyncOperation.getStatus(map[string]interface{}{"properties": map[string]interface{}{"status": 1}})
+	assert.EqualError(t, err, "asynchronous operation status field 'properties.status' is not a string (1)")
+
+	assert.Equal(t, "quota exceeded", asyncOperation.getErrorMessage(map[string]interface{}{"error": "quota exceeded"}))
+	assert.Equal(t, `{"code":"QuotaExceeded"}`, asyncOperation.getErrorMessage(map[string]interface{}{"error": map[string]interface{}{"code": "QuotaExceeded"}}))
+	assert.Equal(t, "", asyncOperation.getErrorMessage(map[string]interface{}{}))
+	assert.Equal(t, "", specAsyncOperation{}.getErrorMessage(map[string]interface{}{"error": "quota exceeded"}))
+}
@@ -2,8 +2,11 @@ package openapi
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // specSchemaDefinitionProperties defines a collection of schema definition properties
@@ -12,10 +15,53 @@ type specSchemaDefinitionProperties []*specSchemaDefinitionProperty
 // SpecSchemaDefinition defines a struct for a schema definition
 type specSchemaDefinition struct {
 	Properties specSchemaDefinitionProperties
+	// Discriminator is the name of the property that identifies the variant of a discriminated union (oneOf/anyOf with
+	// a discriminator). If set, each of the Properties represents one of the variants and only one can be configured
+	Discriminator string
+	// logger (optional) is used to log the payload values that can not be mapped into the schema
+	logger Logger
+}
+
+// getLogger returns the logger configured in the schema definition or the default logger if none was provided
+func (s *specSchemaDefinition) getLogger() Logger {
+	return loggerOrDefault(s.logger)
 }
 
 func (s *specSchemaDefinition) createResourceSchema() (map[string]*schema.Schema, error) {
-	return s.createResourceSchemaIgnoreID(true)
+	terraformSchema, err := s.createResourceSchemaIgnoreID(true)
+	if err != nil {
+		return nil, err
+	}
+	s.setDiscriminatedUnionConflicts(terraformSchema)
+	return terraformSchema, nil
+}
+
+// setDiscriminatedUnionConflicts configures the variants of the top level discriminated unions (represented as nested
+// blocks of the union block) to conflict with each other so configuring more than one variant fails at plan time. The
+// unions nested in other objects or lists are validated when the payload is created instead
+func (s *specSchemaDefinition) setDiscriminatedUnionConflicts(terraformSchema map[string]*schema.Schema) {
+	for _, property := range s.Properties {
+		if property.Type != typeObject || !property.SpecSchemaDefinition.isDiscriminatedUnion() {
+			continue
+		}
+		propertyName := property.getTerraformCompliantPropertyName()
+		propertySchema, exists := terraformSchema[propertyName]
+		if !exists {
+			continue
+		}
+		elem, isResource := propertySchema.Elem.(*schema.Resource)
+		if !isResource {
+			continue
+		}
+		variants := property.SpecSchemaDefinition.getVariantTerraformNames()
+		for _, variant := range variants {
+			for _, otherVariant := range variants {
+				if otherVariant != variant {
+					elem.Schema[variant].ConflictsWith = append(elem.Schema[variant].ConflictsWith, fmt.Sprintf("%s.0.%s", propertyName, otherVariant))
+				}
+			}
+		}
+	}
 }
 
 func (s *specSchemaDefinition) createDataSourceSchema() (map[string]*schema.Schema, error) {
@@ -94,14 +140,31 @@ func (s *specSchemaDefinition) getImmutableProperties() []string {
 	return immutableProperties
 }
 
+// forceNewImmutableProperties configures the immutable properties (including the ones in nested objects) to force a new
+// resource when their values change instead of aborting the update. Computed properties are left untouched since their
+// values are not configured by the user
+func (s *specSchemaDefinition) forceNewImmutableProperties() {
+	for _, property := range s.Properties {
+		if property.Immutable && !property.isReadOnly() {
+			property.Immutable = false
+			property.ForceNew = true
+		}
+		if property.SpecSchemaDefinition != nil {
+			property.SpecSchemaDefinition.forceNewImmutableProperties()
+		}
+	}
+}
+
 //// getResourceIdentifier returns the property name that is supposed to be used as the identifier. The resource id
 //// is selected as follows:
 //// 1.If the given schema definition contains a property configured with metadata 'x-terraform-id' set to true, that property value
 //// will be used to set the state ID of the resource. Additionally, the value will be used when performing GET/PUT/DELETE requests to
 //// identify the resource in question.
-//// 2. If none of the properties of the given schema definition contain such metadata, it is expected that the payload
+//// 2. If none of the top level properties contain such metadata but a property nested in an object property does, the
+//// dot separated path to the nested property is returned (e,g: metadata.uid)
+//// 3. If none of the properties of the given schema definition contain such metadata, it is expected that the payload
 //// will have a property named 'id'
-//// 3. If none of the above requirements is met, an error will be returned
+//// 4. If none of the above requirements is met, an error will be returned
 func (s *specSchemaDefinition) getResourceIdentifier() (string, error) {
 	identifierProperty := ""
 	for _, property := range s.Properties {
@@ -110,10 +173,12 @@ func (s *specSchemaDefinition) getResourceIdentifier() (string, error) {
 			continue
 		}
 		if property.IsIdentifier {
-			identifierProperty = property.Name
-			break
+			return property.Name, nil
 		}
 	}
+	if nestedIdentifierProperty := s.getNestedResourceIdentifier(); nestedIdentifierProperty != "" {
+		return nestedIdentifierProperty, nil
+	}
 	// if the identifier property is missing, there is not way for the resource to be identified and therefore an error is returned
 	if identifierProperty == "" {
 		return "", fmt.Errorf("could not find any identifier property in the resource schema definition")
@@ -121,6 +186,36 @@ func (s *specSchemaDefinition) getResourceIdentifier() (string, error) {
 	return identifierProperty, nil
 }
 
+// containsIdentifier returns true if the schema definition contains either a property named 'id' or a property marked as
+// the identifier
+func (s *specSchemaDefinition) containsIdentifier() bool {
+	for _, property := range s.Properties {
+		if property.isPropertyNamedID() || property.IsIdentifier {
+			return true
+		}
+	}
+	return s.getNestedResourceIdentifier() != ""
+}
+
+// getNestedResourceIdentifier returns the dot separated path to the property marked as the identifier that is nested
+// in one of the object properties (e,g: metadata.uid), empty if none of the nested properties is marked as the identifier
+func (s *specSchemaDefinition) getNestedResourceIdentifier() string {
+	for _, property := range s.Properties {
+		if !property.isObjectProperty() || property.SpecSchemaDefinition == nil {
+			continue
+		}
+		for _, nestedProperty := range property.SpecSchemaDefinition.Properties {
+			if nestedProperty.IsIdentifier {
+				return fmt.Sprintf("%s.%s", property.Name, nestedProperty.Name)
+			}
+		}
+		if nestedIdentifierProperty := property.SpecSchemaDefinition.getNestedResourceIdentifier(); nestedIdentifierProperty != "" {
+			return fmt.Sprintf("%s.%s", property.Name, nestedIdentifierProperty)
+		}
+	}
+	return ""
+}
+
 // getStatusIdentifier returns the property name that is supposed to be used as the status field. The status field
 // is selected as follows:
 // 1.If the given schema definition contains a property configured with metadata 'x-terraform-field-status' set to true, that property
@@ -179,6 +274,18 @@ func (s *specSchemaDefinition) getProperty(name string) (*specSchemaDefinitionPr
 	return nil, fmt.Errorf("property with name '%s' not existing in resource schema definition", name)
 }
 
+// getPropertyBasedOnResponseFieldName returns the property which value is held by the given response field name. Properties
+// configured with a ResponseFieldName are matched against it; otherwise the property name is used. Hence, a property configured
+// with a ResponseFieldName will not match its request name (Name) so the value read from the response is always the aliased one.
+func (s *specSchemaDefinition) getPropertyBasedOnResponseFieldName(responseFieldName string) (*specSchemaDefinitionProperty, error) {
+	for _, property := range s.Properties {
+		if property.getResponseFieldName() == responseFieldName {
+			return property, nil
+		}
+	}
+	return nil, fmt.Errorf("property with response field name '%s' not existing in resource schema definition", responseFieldName)
+}
+
 func (s *specSchemaDefinition) getPropertyBasedOnTerraformName(terraformName string) (*specSchemaDefinitionProperty, error) {
 	for _, property := range s.Properties {
 		if property.getTerraformCompliantPropertyName() == terraformName {
@@ -187,3 +294,194 @@ func (s *specSchemaDefinition) getPropertyBasedOnTerraformName(terraformName str
 	}
 	return nil, fmt.Errorf("property with terraform name '%s' not existing in resource schema definition", terraformName)
 }
+
+// redactedValue is the value logged in place of the sensitive property values
+const redactedValue = "(sensitive)"
+
+// redactSensitiveValues returns a copy of the given payload where the values of the sensitive properties (including the
+// ones in nested objects) are replaced with a redacted value so they can be logged safely. The payload keys are matched
+// against both the property name and the response field name so it works for request as well as response payloads
+func (s *specSchemaDefinition) redactSensitiveValues(payload map[string]interface{}) map[string]interface{} {
+	if payload == nil {
+		return nil
+	}
+	redactedPayload := make(map[string]interface{}, len(payload))
+	for key, value := range payload {
+		redactedPayload[key] = value
+		property := s.getPropertyMatchingPayloadKey(key)
+		if property == nil || value == nil {
+			continue
+		}
+		if property.Sensitive {
+			redactedPayload[key] = redactedValue
+			continue
+		}
+		if property.isMapOfObjectsProperty() {
+			redactedPayload[key] = property.SpecSchemaDefinition.redactSensitiveMapOfObjectsValues(value)
+			continue
+		}
+		if property.SpecSchemaDefinition != nil {
+			redactedPayload[key] = property.SpecSchemaDefinition.redactSensitiveNestedValues(value)
+		}
+	}
+	return redactedPayload
+}
+
+// getSensitivePropertyNames returns the names of the payload fields holding the values of the sensitive properties
+// (the request and the response field names), including the ones of the nested objects
+func (s *specSchemaDefinition) getSensitivePropertyNames() []string {
+	var names []string
+	for _, property := range s.Properties {
+		if property.Sensitive {
+			names = append(names, property.Name)
+			if responseFieldName := property.getResponseFieldName(); responseFieldName != property.Name {
+				names = append(names, responseFieldName)
+			}
+			continue
+		}
+		if property.SpecSchemaDefinition != nil {
+			names = append(names, property.SpecSchemaDefinition.getSensitivePropertyNames()...)
+		}
+	}
+	return names
+}
+
+// redactSensitiveNestedValues handles the values of nested objects which could be either a single object or a list of them
+func (s *specSchemaDefinition) redactSensitiveNestedValues(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.isDiscriminatedUnion() {
+			return s.redactSensitiveUnionValues(v)
+		}
+		return s.redactSensitiveValues(v)
+	case []interface{}:
+		redactedItems := make([]interface{}, len(v))
+		for i, item := range v {
+			redactedItems[i] = s.redactSensitiveNestedValues(item)
+		}
+		return redactedItems
+	}
+	return value
+}
+
+// redactSensitiveUnionValues redacts the payload of a discriminated union based on the properties of the variant matching the
+// discriminator value. The payload is returned as is if the discriminator value is unknown
+func (s *specSchemaDefinition) redactSensitiveUnionValues(payload map[string]interface{}) map[string]interface{} {
+	discriminatorValue, _ := payload[s.Discriminator].(string)
+	for _, variant := range s.Properties {
+		if variant.Name == discriminatorValue && variant.SpecSchemaDefinition != nil {
+			return variant.SpecSchemaDefinition.redactSensitiveValues(payload)
+		}
+	}
+	return payload
+}
+
+// redactSensitiveMapOfObjectsValues redacts each of the object values of a map of objects
+func (s *specSchemaDefinition) redactSensitiveMapOfObjectsValues(value interface{}) interface{} {
+	mapValue, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+	redactedMap := make(map[string]interface{}, len(mapValue))
+	for key, objectValue := range mapValue {
+		redactedMap[key] = s.redactSensitiveNestedValues(objectValue)
+	}
+	return redactedMap
+}
+
+func (s *specSchemaDefinition) getPropertyMatchingPayloadKey(key string) *specSchemaDefinitionProperty {
+	for _, property := range s.Properties {
+		if property.Name == key || property.getResponseFieldName() == key {
+			return property
+		}
+	}
+	return nil
+}
+
+// getTerraformAttributePath translates the given API field path into the corresponding terraform attribute path (e,g:
+// nestedObject.someProperty -> nested_object.0.some_property). Field paths can be dot separated (list indexes included
+// as separate segments or using brackets) or JSON pointers (e,g: /nestedObject/someProperty). False is returned if the
+// field path can not be correlated to any of the resource attributes
+func (s *specSchemaDefinition) getTerraformAttributePath(fieldPath string) (string, bool) {
+	attributePath, ok := s.getTerraformAttributeCtyPath(fieldPath)
+	if !ok {
+		return "", false
+	}
+	return formatAttributePath(attributePath), true
+}
+
+// getTerraformAttributeCtyPath behaves as getTerraformAttributePath but returns the terraform attribute path as a cty.Path
+// so it can be attached to the diagnostics returned to Terraform (e,g: nestedObject.someProperty ->
+// GetAttr(nested_object).Index(0).GetAttr(some_property))
+func (s *specSchemaDefinition) getTerraformAttributeCtyPath(fieldPath string) (cty.Path, bool) {
+	segments := splitFieldPath(fieldPath)
+	if len(segments) == 0 {
+		return nil, false
+	}
+	attributePath := cty.Path{}
+	schemaDefinition := s
+	// mapKeys is set when the previous segment is a simple object, whose properties are represented in terraform as
+	// map keys
+	mapKeys := false
+	for i := 0; i < len(segments); i++ {
+		if schemaDefinition == nil {
+			return nil, false
+		}
+		property := schemaDefinition.getPropertyMatchingPayloadKey(segments[i])
+		if property == nil {
+			return nil, false
+		}
+		if mapKeys {
+			attributePath = attributePath.IndexString(property.getTerraformCompliantPropertyName())
+		} else {
+			attributePath = attributePath.GetAttr(property.getTerraformCompliantPropertyName())
+		}
+		mapKeys = false
+		hasNext := i+1 < len(segments)
+		switch {
+		case property.isObjectProperty():
+			// complex objects are represented in terraform as a list of one element and simple objects as maps
+			if property.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() {
+				if hasNext {
+					attributePath = attributePath.IndexInt(0)
+				}
+			} else {
+				mapKeys = true
+			}
+			schemaDefinition = property.SpecSchemaDefinition
+		case property.isArrayProperty():
+			if hasNext {
+				index, err := strconv.Atoi(segments[i+1])
+				if err != nil {
+					return nil, false
+				}
+				attributePath = attributePath.IndexInt(index)
+				i++
+			}
+			schemaDefinition = nil
+			if property.isArrayOfObjectsProperty() {
+				schemaDefinition = property.SpecSchemaDefinition
+			}
+		default:
+			schemaDefinition = nil
+		}
+	}
+	return attributePath, true
+}
+
+// splitFieldPath splits the given field path into its segments supporting dot separated paths (including list indexes in
+// brackets, e,g: list[0].property) as well as JSON pointers (e,g: /list/0/property)
+func splitFieldPath(fieldPath string) []string {
+	separator := "."
+	if strings.HasPrefix(fieldPath, "/") {
+		separator = "/"
+	}
+	fieldPath = strings.NewReplacer("[", separator, "]", "").Replace(fieldPath)
+	var segments []string
+	for _, segment := range strings.Split(fieldPath, separator) {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
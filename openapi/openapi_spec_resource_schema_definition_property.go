@@ -1,10 +1,13 @@
 package openapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // schemaDefinitionPropertyType defines the type of a property
@@ -17,18 +20,77 @@ const (
 	typeBool   schemaDefinitionPropertyType = "boolean"
 	typeList   schemaDefinitionPropertyType = "list"
 	typeObject schemaDefinitionPropertyType = "object"
+	typeMap    schemaDefinitionPropertyType = "map"
 )
 
 const idDefaultPropertyName = "id"
+
+// nullValueSentinel defines the value that users can set in nullable string properties to send a JSON null value to the API.
+// This is needed since Terraform does not differentiate between a null value and a value that is not set in the configuration.
+const nullValueSentinel = "null"
 const statusDefaultPropertyName = "status"
 
+// mapOfObjectsKeyPropertyName defines the name of the property holding the map key in the blocks representing the values
+// of the maps of objects
+const mapOfObjectsKeyPropertyName = "key"
+
+// Normalizations supported by the x-terraform-normalize extension
+const (
+	normalizeLowercase         = "lowercase"
+	normalizeTrim              = "trim"
+	normalizeTrimTrailingSlash = "trim-trailing-slash"
+	normalizeCollapseSpaces    = "collapse-whitespace"
+	normalizeJSON              = "json"
+)
+
+// File contents supported by the x-terraform-file-content extension
+const (
+	// fileContentPath means the value of the property is the path of the file sent
+	fileContentPath = "path"
+	// fileContentBase64 means the value of the property is the base64 encoded content of the file sent
+	fileContentBase64 = "base64"
+)
+
+// supportedNormalizations lists the normalizations supported by the x-terraform-normalize extension
+var supportedNormalizations = []string{normalizeLowercase, normalizeTrim, normalizeTrimTrailingSlash, normalizeCollapseSpaces, normalizeJSON}
+
+// normalizers contains the functions applying each of the supported normalizations to a string value
+var normalizers = map[string]func(string) string{
+	normalizeLowercase:         strings.ToLower,
+	normalizeTrim:              strings.TrimSpace,
+	normalizeTrimTrailingSlash: func(value string) string { return strings.TrimRight(value, "/") },
+	normalizeCollapseSpaces:    func(value string) string { return strings.Join(strings.Fields(value), " ") },
+	normalizeJSON:              normalizeJSONValue,
+}
+
+// normalizeJSONValue returns the canonical representation of the given JSON document (keys sorted and no insignificant
+// white spaces) so equivalent documents are considered the same. Values that are not valid JSON are returned as is
+func normalizeJSONValue(value string) string {
+	var document interface{}
+	if err := json.Unmarshal([]byte(value), &document); err != nil {
+		return value
+	}
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return value
+	}
+	return string(normalized)
+}
+
 // specSchemaDefinitionProperty defines the attributes for a schema property
 type specSchemaDefinitionProperty struct {
-	Name           string
-	PreferredName  string
-	Type           schemaDefinitionPropertyType
-	ArrayItemsType schemaDefinitionPropertyType
-	Required       bool
+	Name          string
+	PreferredName string
+	// ResponseFieldName defines the name of the field in the API responses that holds the value of the property when it
+	// differs from the name used in the requests (Name). If empty, the Name is used for both requests and responses.
+	ResponseFieldName string
+	Type              schemaDefinitionPropertyType
+	ArrayItemsType    schemaDefinitionPropertyType
+	// AdditionalPropertiesType defines the type of the values allowed in the additionalProperties of the object. For
+	// properties of type map this is the element type of the map; for objects with fixed properties it is the type used
+	// for keys that are not part of the object's fixed properties.
+	AdditionalPropertiesType schemaDefinitionPropertyType
+	Required                 bool
 	// ReadOnly properties are included in responses but not in request
 	ReadOnly bool
 	// Computed properties describe properties where the value is computed by the API
@@ -41,11 +103,34 @@ type specSchemaDefinitionProperty struct {
 	Immutable          bool
 	IsIdentifier       bool
 	IsStatusIdentifier bool
+	// Nullable defines whether the property accepts null as a value. If the user explicitly sets a nullable string property
+	// to the nullValueSentinel, the property will be sent to the API with a JSON null value.
+	Nullable bool
+	// OmitWhenEmpty defines whether the property should be omitted from the request payloads when its value is empty. Only
+	// applies to optional properties, required properties are always included.
+	OmitWhenEmpty bool
+	// WriteOnly defines whether the property is only used in the requests and never returned by the API, in which case the
+	// value configured by the user is kept in the state when reading the resource.
+	WriteOnly bool
+	// FileContent defines whether the value of the property is the path (fileContentPath) or the base64 encoded content
+	// (fileContentBase64) of the file sent as a file part in the multipart/form-data requests, empty if the property is
+	// not a file. Only applies to string properties.
+	FileContent string
 	// EnableLegacyComplexObjectBlockConfiguration defines whether this specSchemaDefinitionProperty should be handled with special treatment following
 	// the recommendation from hashi maintainers (https://github.com/hashicorp/terraform/issues/22511#issuecomment-522655851)
 	// to support complex object types with the legacy SDK (objects that contain properties with different types and configurations
 	// like computed properties).
 	EnableLegacyComplexObjectBlockConfiguration bool
+	// RequiredIf maps the names of other properties of the resource to the values that make this property required. The
+	// property is required only when all the conditions are met; otherwise it remains optional. Only applies to optional
+	// properties.
+	RequiredIf map[string]interface{}
+	// Normalizations contains the normalizations (e,g: lowercase, trim) the API applies to the value of the property, in
+	// the order they should be applied. Only applies to string properties.
+	Normalizations []string
+	// Constraints contains the constraints (e,g: enum, pattern, minimum) the values of the property must comply with, which
+	// are validated at plan time. Only applies to primitive properties.
+	Constraints *specSchemaDefinitionPropertyConstraints
 	// Default field is only for informative purposes to know what the openapi spec for the property stated the default value is
 	// As per the openapi spec default attributes, the value is expected to be computed by the API
 	Default interface{}
@@ -53,6 +138,39 @@ type specSchemaDefinitionProperty struct {
 	SpecSchemaDefinition *specSchemaDefinition
 }
 
+// shouldOmitWhenEmpty returns true if the property is optional, it is configured to be omitted when empty and the given
+// payload value is empty (zero value, empty string, empty list or empty object). Note nil values are not considered empty
+// since they are only present in the payload when the user explicitly sets a nullable property to null
+func (s *specSchemaDefinitionProperty) shouldOmitWhenEmpty(value interface{}) bool {
+	if !s.OmitWhenEmpty || s.isRequired() || value == nil {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	}
+	return reflect.DeepEqual(value, reflect.Zero(v.Type()).Interface())
+}
+
+// normalize returns the value after applying the property's normalizations in the order they were declared
+func (s *specSchemaDefinitionProperty) normalize(value string) string {
+	for _, normalization := range s.Normalizations {
+		if normalizer, supported := normalizers[normalization]; supported {
+			value = normalizer(value)
+		}
+	}
+	return value
+}
+
+// normalizeDiffSuppressFunc returns a DiffSuppressFunc that suppresses the diff when the old and new values are the same
+// once normalized, which avoids perpetual diffs when the API normalizes the values provided by the user
+func (s *specSchemaDefinitionProperty) normalizeDiffSuppressFunc() schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return s.normalize(old) == s.normalize(new)
+	}
+}
+
 func (s *specSchemaDefinitionProperty) isPrimitiveProperty() bool {
 	if s.Type == typeString || s.Type == typeInt || s.Type == typeFloat || s.Type == typeBool {
 		return true
@@ -60,6 +178,14 @@ func (s *specSchemaDefinitionProperty) isPrimitiveProperty() bool {
 	return false
 }
 
+// getResponseFieldName returns the name of the field in the API responses that holds the value of the property
+func (s *specSchemaDefinitionProperty) getResponseFieldName() string {
+	if s.ResponseFieldName != "" {
+		return s.ResponseFieldName
+	}
+	return s.Name
+}
+
 func (s *specSchemaDefinitionProperty) getTerraformCompliantPropertyName() string {
 	if s.PreferredName != "" {
 		return s.PreferredName
@@ -82,18 +208,26 @@ func (s *specSchemaDefinitionProperty) isLegacyComplexObjectExtensionEnabled() b
 	return false
 }
 
+// isPropertyWithNestedObjects returns true if the object property contains at least one nested property that is not a
+// primitive (objects, lists or maps), which can not be represented as a terraform map of strings
 func (s *specSchemaDefinitionProperty) isPropertyWithNestedObjects() bool {
 	if !s.isObjectProperty() || s.SpecSchemaDefinition == nil {
 		return false
 	}
 	for _, p := range s.SpecSchemaDefinition.Properties {
-		if p.isObjectProperty() {
+		if p.isObjectProperty() || p.isArrayProperty() || p.isMapProperty() {
 			return true
 		}
 	}
 	return false
 }
 
+// isTerraformMapOfStringsObject returns true if the object property is represented in terraform as a map of strings,
+// which is the case of the objects that only contain primitive properties and are not configured as blocks
+func (s *specSchemaDefinitionProperty) isTerraformMapOfStringsObject() bool {
+	return s.isObjectProperty() && !s.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects()
+}
+
 func (s *specSchemaDefinitionProperty) isPropertyNamedID() bool {
 	return s.getTerraformCompliantPropertyName() == idDefaultPropertyName
 }
@@ -106,6 +240,38 @@ func (s *specSchemaDefinitionProperty) isObjectProperty() bool {
 	return s.Type == typeObject
 }
 
+func (s *specSchemaDefinitionProperty) isMapProperty() bool {
+	return s.Type == typeMap
+}
+
+// allowsAdditionalProperties returns true if the property is a map or an object with additionalProperties where keys
+// not defined in the fixed properties are accepted
+func (s *specSchemaDefinitionProperty) allowsAdditionalProperties() bool {
+	return s.AdditionalPropertiesType != ""
+}
+
+// newAdditionalProperty returns a specSchemaDefinitionProperty for the given additional property key. The property
+// created inherits the type defined in AdditionalPropertiesType so values can be converted accordingly.
+func (s *specSchemaDefinitionProperty) newAdditionalProperty(name string) *specSchemaDefinitionProperty {
+	additionalProperty := &specSchemaDefinitionProperty{
+		Name:          name,
+		PreferredName: name,
+		Type:          s.AdditionalPropertiesType,
+	}
+	// The values of the maps of objects are represented as blocks so their properties keep their types
+	if s.isMapOfObjectsProperty() {
+		additionalProperty.SpecSchemaDefinition = s.SpecSchemaDefinition
+		additionalProperty.EnableLegacyComplexObjectBlockConfiguration = true
+	}
+	return additionalProperty
+}
+
+// isMapOfObjectsProperty returns true if the property is a map which values are objects (additionalProperties with an
+// object schema), in which case SpecSchemaDefinition describes the values schema
+func (s *specSchemaDefinitionProperty) isMapOfObjectsProperty() bool {
+	return s.isMapProperty() && s.AdditionalPropertiesType == typeObject
+}
+
 func (s *specSchemaDefinitionProperty) isArrayProperty() bool {
 	return s.Type == typeList
 }
@@ -114,6 +280,17 @@ func (s *specSchemaDefinitionProperty) isArrayOfObjectsProperty() bool {
 	return s.Type == typeList && s.ArrayItemsType == typeObject
 }
 
+// isNullValue returns true if the property is nullable and the given value matches the null sentinel value
+func (s *specSchemaDefinitionProperty) isNullValue(value interface{}) bool {
+	if !s.Nullable || s.Type != typeString {
+		return false
+	}
+	if v, ok := value.(string); ok && v == nullValueSentinel {
+		return true
+	}
+	return false
+}
+
 func (s *specSchemaDefinitionProperty) isReadOnly() bool {
 	return s.ReadOnly
 }
@@ -145,9 +322,16 @@ func (s *specSchemaDefinitionProperty) isOptionalComputed() bool {
 	return s.isOptional() && !s.isReadOnly() && s.Computed && s.Default == nil
 }
 
+// isComputedWithDefault returns true for optional properties that are not primitives (lists, objects and maps) and have a
+// default value. These properties are computed since terraform only supports default values in primitive properties,
+// hence the default value set by the API is stored in the state when the user does not provide a value
+func (s *specSchemaDefinitionProperty) isComputedWithDefault() bool {
+	return s.isOptional() && !s.isReadOnly() && s.Default != nil && !s.isPrimitiveProperty()
+}
+
 func (s *specSchemaDefinitionProperty) terraformType() (schema.ValueType, error) {
 	switch s.Type {
-	case typeObject:
+	case typeObject, typeMap:
 		return schema.TypeMap, nil
 	case typeString:
 		return schema.TypeString, nil
@@ -164,7 +348,11 @@ func (s *specSchemaDefinitionProperty) terraformType() (schema.ValueType, error)
 }
 
 func (s *specSchemaDefinitionProperty) isTerraformListOfSimpleValues() (bool, *schema.Schema) {
-	switch s.ArrayItemsType {
+	return s.terraformPrimitiveElemSchema(s.ArrayItemsType)
+}
+
+func (s *specSchemaDefinitionProperty) terraformPrimitiveElemSchema(elemType schemaDefinitionPropertyType) (bool, *schema.Schema) {
+	switch elemType {
 	case typeString:
 		return true, &schema.Schema{Type: schema.TypeString}
 	case typeInt:
@@ -195,7 +383,7 @@ func (s *specSchemaDefinitionProperty) terraformObjectSchema() (*schema.Resource
 }
 
 // shouldUseLegacyTerraformSDKBlockApproachForComplexObjects returns true if one of the following scenarios match:
-// - the specSchemaDefinitionProperty is of type object and in turn contains at least one nested property that is an object.
+// - the specSchemaDefinitionProperty is of type object and in turn contains at least one nested property that is an object, list or map.
 // - the specSchemaDefinitionProperty is of type object and also has the EnableLegacyComplexObjectBlockConfiguration set to true
 // In both cases, in order to represent complex objects with the current version of the Terraform SDK (<= v0.12.2), the workaround
 // suggested by hashi maintainers is to use TypeList limiting the MaxItems to 1.
@@ -203,7 +391,7 @@ func (s *specSchemaDefinitionProperty) terraformObjectSchema() (*schema.Resource
 // - https://github.com/hashicorp/terraform/issues/21217#issuecomment-489699737
 // - https://github.com/hashicorp/terraform/issues/22511#issuecomment-522655851
 func (s *specSchemaDefinitionProperty) shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() bool {
-	// is of type object and in turn contains at least one nested property that is an object.
+	// is of type object and in turn contains at least one nested property that is an object, list or map.
 	if s.isPropertyWithNestedObjects() {
 		return true
 	}
@@ -227,12 +415,42 @@ func (s *specSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 		if s.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() {
 			terraformSchema.Type = schema.TypeList
 			terraformSchema.MaxItems = 1
+			objectSchema, err := s.terraformObjectSchema()
+			if err != nil {
+				return nil, err
+			}
+			terraformSchema.Elem = objectSchema
+		} else {
+			// The Terraform SDK v2 does not support TypeMap with Elem *Resource, hence the simple objects are represented
+			// as maps of strings. Note this is the same schema Terraform was already using for these properties with the
+			// previous SDK version, so the configurations and states in place remain compatible
+			if s.SpecSchemaDefinition == nil {
+				return nil, fmt.Errorf("missing spec schema definition for property '%s' of type '%s'", s.Name, s.Type)
+			}
+			terraformSchema.Elem = &schema.Schema{Type: schema.TypeString}
 		}
-		objectSchema, err := s.terraformObjectSchema()
-		if err != nil {
-			return nil, err
+
+	case typeMap:
+		// The Terraform SDK v2 does not support TypeMap with Elem *Resource, hence the maps of objects are represented as a
+		// set of blocks containing the properties of the object values along with the key of the map
+		if s.isMapOfObjectsProperty() && s.SpecSchemaDefinition != nil {
+			terraformSchema.Type = schema.TypeSet
+			objectSchema, err := s.SpecSchemaDefinition.createResourceSchemaKeepID()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := objectSchema[mapOfObjectsKeyPropertyName]; exists {
+				return nil, fmt.Errorf("map property '%s' values can not contain a property named '%s' as it is reserved for the map keys", s.Name, mapOfObjectsKeyPropertyName)
+			}
+			objectSchema[mapOfObjectsKeyPropertyName] = &schema.Schema{Type: schema.TypeString, Required: true}
+			terraformSchema.Elem = &schema.Resource{Schema: objectSchema}
+			break
 		}
-		terraformSchema.Elem = objectSchema
+		isMapOfPrimitives, elemSchema := s.terraformPrimitiveElemSchema(s.AdditionalPropertiesType)
+		if !isMapOfPrimitives {
+			return nil, fmt.Errorf("map property '%s' has a non supported additionalProperties type '%s'", s.Name, s.AdditionalPropertiesType)
+		}
+		terraformSchema.Elem = elemSchema
 
 	case typeList:
 		if isListOfPrimitives, elemSchema := s.isTerraformListOfSimpleValues(); isListOfPrimitives {
@@ -250,7 +468,7 @@ func (s *specSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	// - property that is set as readOnly in the openapi spec
 	// - property that is not readOnly, but it is an optional computed property. The following will comply with optional computed:
 	//   - the property is not readOnly and default is nil (only possible when 'x-terraform-computed' extension is set)
-	terraformSchema.Computed = s.isComputed()
+	terraformSchema.Computed = s.isComputed() || s.isComputedWithDefault()
 
 	// A sensitive property means that the expectedValue will not be disclosed in the state file, preventing secrets from
 	// being leaked
@@ -267,14 +485,19 @@ func (s *specSchemaDefinitionProperty) terraformSchema() (*schema.Schema, error)
 	}
 
 	// ValidateFunc is not yet supported on lists or sets
-	if !s.isArrayProperty() && !s.isObjectProperty() {
+	if !s.isArrayProperty() && !s.isObjectProperty() && !s.isMapProperty() {
 		terraformSchema.ValidateFunc = s.validateFunc()
 	}
 
+	// Values normalized by the API (e,g: lowercased) are compared once normalized so they don't show up as a diff
+	if s.Type == typeString && len(s.Normalizations) > 0 {
+		terraformSchema.DiffSuppressFunc = s.normalizeDiffSuppressFunc()
+	}
+
 	// Don't populate Default if property is readOnly as the property is expected to be computed by the API. Terraform does
 	// not allow properties with Computed = true having the Default field populated, otherwise the following error will be
 	// thrown at runtime: Default must be nil if computed
-	if !s.isComputed() {
+	if !terraformSchema.Computed {
 		terraformSchema.Default = s.Default
 	}
 
@@ -289,6 +512,9 @@ func (s *specSchemaDefinitionProperty) validateFunc() schema.SchemaValidateFunc
 		if s.Required && s.ReadOnly {
 			errors = append(errors, fmt.Errorf("property '%s' is configured as required and can not be configured as computed too", s.Name))
 		}
+		if err := s.validateConstraints(v); err != nil {
+			errors = append(errors, fmt.Errorf("%s: %s", k, err))
+		}
 		return
 	}
 }
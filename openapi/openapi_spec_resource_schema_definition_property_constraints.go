@@ -0,0 +1,149 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// specSchemaDefinitionPropertyConstraints defines the constraints documented in the OpenAPI document for the values of a
+// primitive property (enum, pattern, minimum/maximum and minLength/maxLength), which are validated at plan time
+type specSchemaDefinitionPropertyConstraints struct {
+	// Enum contains the values allowed, if empty any value is allowed
+	Enum []interface{}
+	// Pattern is the regular expression string values must match
+	Pattern *regexp.Regexp
+	// Minimum and Maximum define the range allowed for numeric values, the limits are excluded from the range if
+	// ExclusiveMinimum and ExclusiveMaximum are set respectively
+	Minimum          *float64
+	Maximum          *float64
+	ExclusiveMinimum bool
+	ExclusiveMaximum bool
+	// MinLength and MaxLength define the length allowed for string values
+	MinLength *int64
+	MaxLength *int64
+}
+
+// validateConstraints returns an error if the given value does not comply with the constraints of the property. The null
+// value sentinel of nullable properties is always valid
+func (s *specSchemaDefinitionProperty) validateConstraints(value interface{}) error {
+	if s.isNullValue(value) {
+		return nil
+	}
+	return s.Constraints.validate(value)
+}
+
+// validate returns an error describing the first constraint the given value does not comply with
+func (c *specSchemaDefinitionPropertyConstraints) validate(value interface{}) error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Enum) > 0 && !c.isEnumValue(value) {
+		var values []string
+		for _, enumValue := range c.Enum {
+			values = append(values, fmt.Sprintf("%v", enumValue))
+		}
+		return fmt.Errorf("expected value to be one of [%s], got %v", strings.Join(values, ", "), value)
+	}
+	switch v := value.(type) {
+	case string:
+		if c.Pattern != nil && !c.Pattern.MatchString(v) {
+			return fmt.Errorf("expected value to match the pattern '%s', got %s", c.Pattern, v)
+		}
+		length := int64(len([]rune(v)))
+		if c.MinLength != nil && length < *c.MinLength {
+			return fmt.Errorf("expected length of value to be at least %d, got %d", *c.MinLength, length)
+		}
+		if c.MaxLength != nil && length > *c.MaxLength {
+			return fmt.Errorf("expected length of value to be at most %d, got %d", *c.MaxLength, length)
+		}
+	case int, float64:
+		number := toFloat64(v)
+		if c.Minimum != nil && (number < *c.Minimum || c.ExclusiveMinimum && number == *c.Minimum) {
+			return fmt.Errorf("expected value to be %s %v, got %v", c.comparison(c.ExclusiveMinimum, "greater"), *c.Minimum, v)
+		}
+		if c.Maximum != nil && (number > *c.Maximum || c.ExclusiveMaximum && number == *c.Maximum) {
+			return fmt.Errorf("expected value to be %s %v, got %v", c.comparison(c.ExclusiveMaximum, "less"), *c.Maximum, v)
+		}
+	}
+	return nil
+}
+
+func (c *specSchemaDefinitionPropertyConstraints) isEnumValue(value interface{}) bool {
+	for _, enumValue := range c.Enum {
+		switch v := value.(type) {
+		case int, float64:
+			if enumNumber, ok := enumValue.(float64); ok && enumNumber == toFloat64(v) {
+				return true
+			}
+			if enumNumber, ok := enumValue.(int); ok && float64(enumNumber) == toFloat64(v) {
+				return true
+			}
+		default:
+			if enumValue == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *specSchemaDefinitionPropertyConstraints) comparison(exclusive bool, comparison string) string {
+	if exclusive {
+		return comparison + " than"
+	}
+	return comparison + " than or equal to"
+}
+
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+// constraintsValidator validates the values of the framework attributes comply with the constraints of the property
+type constraintsValidator struct {
+	property *specSchemaDefinitionProperty
+}
+
+func (v constraintsValidator) Description(ctx context.Context) string {
+	return "value must comply with the constraints defined in the OpenAPI document"
+}
+
+func (v constraintsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v constraintsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := v.property.validateConstraints(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid attribute value", err.Error())
+	}
+}
+
+func (v constraintsValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := v.property.validateConstraints(int(req.ConfigValue.ValueInt64())); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid attribute value", err.Error())
+	}
+}
+
+func (v constraintsValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := v.property.validateConstraints(req.ConfigValue.ValueFloat64()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid attribute value", err.Error())
+	}
+}
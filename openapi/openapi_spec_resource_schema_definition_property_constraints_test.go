@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecSchemaDefinitionPropertyConstraintsValidate(t *testing.T) {
+	minimum := float64(1)
+	maximum := float64(10)
+	minLength := int64(2)
+	maxLength := int64(4)
+	testCases := []struct {
+		name          string
+		constraints   *specSchemaDefinitionPropertyConstraints
+		value         interface{}
+		expectedError string
+	}{
+		{name: "no constraints", constraints: nil, value: "value"},
+		{name: "string enum value", constraints: &specSchemaDefinitionPropertyConstraints{Enum: []interface{}{"a", "b"}}, value: "b"},
+		{name: "string value not in the enum", constraints: &specSchemaDefinitionPropertyConstraints{Enum: []interface{}{"a", "b"}}, value: "c", expectedError: "expected value to be one of [a, b], got c"},
+		{name: "int enum value parsed as a JSON number", constraints: &specSchemaDefinitionPropertyConstraints{Enum: []interface{}{float64(1), float64(2)}}, value: 2},
+		{name: "int value not in the enum", constraints: &specSchemaDefinitionPropertyConstraints{Enum: []interface{}{float64(1), float64(2)}}, value: 3, expectedError: "expected value to be one of [1, 2], got 3"},
+		{name: "value matching the pattern", constraints: &specSchemaDefinitionPropertyConstraints{Pattern: regexp.MustCompile("^[a-z]+$")}, value: "abc"},
+		{name: "value not matching the pattern", constraints: &specSchemaDefinitionPropertyConstraints{Pattern: regexp.MustCompile("^[a-z]+$")}, value: "ABC", expectedError: "expected value to match the pattern '^[a-z]+$', got ABC"},
+		{name: "value shorter than the min length", constraints: &specSchemaDefinitionPropertyConstraints{MinLength: &minLength}, value: "a", expectedError: "expected length of value to be at least 2, got 1"},
+		{name: "value longer than the max length", constraints: &specSchemaDefinitionPropertyConstraints{MaxLength: &maxLength}, value: "abcde", expectedError: "expected length of value to be at most 4, got 5"},
+		{name: "value within the length range", constraints: &specSchemaDefinitionPropertyConstraints{MinLength: &minLength, MaxLength: &maxLength}, value: "abc"},
+		{name: "int value within the range", constraints: &specSchemaDefinitionPropertyConstraints{Minimum: &minimum, Maximum: &maximum}, value: 10},
+		{name: "int value lower than the minimum", constraints: &specSchemaDefinitionPropertyConstraints{Minimum: &minimum}, value: 0, expectedError: "expected value to be greater than or equal to 1, got 0"},
+		{name: "float value greater than the maximum", constraints: &specSchemaDefinitionPropertyConstraints{Maximum: &maximum}, value: 10.5, expectedError: "expected value to be less than or equal to 10, got 10.5"},
+		{name: "value equal to the exclusive minimum", constraints: &specSchemaDefinitionPropertyConstraints{Minimum: &minimum, ExclusiveMinimum: true}, value: 1, expectedError: "expected value to be greater than 1, got 1"},
+		{name: "value equal to the exclusive maximum", constraints: &specSchemaDefinitionPropertyConstraints{Maximum: &maximum, ExclusiveMaximum: true}, value: 10.0, expectedError: "expected value to be less than 10, got 10"},
+	}
+	for _, tc := range testCases {
+		err := tc.constraints.validate(tc.value)
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
+
+func TestValidateConstraints(t *testing.T) {
+	s := newStringSchemaDefinitionPropertyWithDefaults("status", "", false, false, nil)
+	s.Nullable = true
+	s.Constraints = &specSchemaDefinitionPropertyConstraints{Enum: []interface{}{"active"}}
+	assert.NoError(t, s.validateConstraints(nullValueSentinel), "null value sentinel of nullable properties")
+	assert.EqualError(t, s.validateConstraints("inactive"), "expected value to be one of [active], got inactive")
+
+	_, errs := s.validateFunc()("inactive", "status")
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "status: expected value to be one of [active], got inactive")
+}
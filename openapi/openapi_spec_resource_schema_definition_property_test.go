@@ -6,7 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -267,6 +267,91 @@ func TestIsArrayProperty(t *testing.T) {
 	})
 }
 
+func TestIsNullValue(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       *specSchemaDefinitionProperty
+		value          interface{}
+		expectedResult bool
+	}{
+		{name: "nullable string property with the null sentinel value", property: &specSchemaDefinitionProperty{Type: typeString, Nullable: true}, value: nullValueSentinel, expectedResult: true},
+		{name: "nullable string property with a non null value", property: &specSchemaDefinitionProperty{Type: typeString, Nullable: true}, value: "some value", expectedResult: false},
+		{name: "nullable string property with an empty value", property: &specSchemaDefinitionProperty{Type: typeString, Nullable: true}, value: "", expectedResult: false},
+		{name: "non nullable string property with the null sentinel value", property: &specSchemaDefinitionProperty{Type: typeString}, value: nullValueSentinel, expectedResult: false},
+		{name: "nullable int property with an int value", property: &specSchemaDefinitionProperty{Type: typeInt, Nullable: true}, value: 0, expectedResult: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedResult, tc.property.isNullValue(tc.value), tc.name)
+	}
+}
+
+func TestShouldOmitWhenEmpty(t *testing.T) {
+	testCases := []struct {
+		name           string
+		property       *specSchemaDefinitionProperty
+		value          interface{}
+		expectedResult bool
+	}{
+		{name: "optional omit when empty property with an empty string value", property: &specSchemaDefinitionProperty{Type: typeString, OmitWhenEmpty: true}, value: "", expectedResult: true},
+		{name: "optional omit when empty property with a zero int value", property: &specSchemaDefinitionProperty{Type: typeInt, OmitWhenEmpty: true}, value: int64(0), expectedResult: true},
+		{name: "optional omit when empty property with a zero float value", property: &specSchemaDefinitionProperty{Type: typeFloat, OmitWhenEmpty: true}, value: float64(0), expectedResult: true},
+		{name: "optional omit when empty property with a false bool value", property: &specSchemaDefinitionProperty{Type: typeBool, OmitWhenEmpty: true}, value: false, expectedResult: true},
+		{name: "optional omit when empty property with an empty list value", property: &specSchemaDefinitionProperty{Type: typeList, OmitWhenEmpty: true}, value: []interface{}{}, expectedResult: true},
+		{name: "optional omit when empty property with an empty object value", property: &specSchemaDefinitionProperty{Type: typeObject, OmitWhenEmpty: true}, value: map[string]interface{}{}, expectedResult: true},
+		{name: "optional omit when empty property with a non empty value", property: &specSchemaDefinitionProperty{Type: typeString, OmitWhenEmpty: true}, value: "some value", expectedResult: false},
+		{name: "optional omit when empty property with a null value", property: &specSchemaDefinitionProperty{Type: typeString, Nullable: true, OmitWhenEmpty: true}, value: nil, expectedResult: false},
+		{name: "required omit when empty property with an empty value", property: &specSchemaDefinitionProperty{Type: typeInt, Required: true, OmitWhenEmpty: true}, value: 0, expectedResult: false},
+		{name: "optional property not configured to be omitted when empty with an empty value", property: &specSchemaDefinitionProperty{Type: typeString}, value: "", expectedResult: false},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedResult, tc.property.shouldOmitWhenEmpty(tc.value), tc.name)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		name           string
+		normalizations []string
+		value          string
+		expectedResult string
+	}{
+		{name: "lowercase normalization", normalizations: []string{normalizeLowercase}, value: "Some VALUE", expectedResult: "some value"},
+		{name: "trim normalization", normalizations: []string{normalizeTrim}, value: "  some value \n", expectedResult: "some value"},
+		{name: "trim trailing slash normalization", normalizations: []string{normalizeTrimTrailingSlash}, value: "https://domain.com/path//", expectedResult: "https://domain.com/path"},
+		{name: "normalizations are composed in the declared order", normalizations: []string{normalizeTrim, normalizeTrimTrailingSlash, normalizeLowercase}, value: " HTTPS://Domain.com/ ", expectedResult: "https://domain.com"},
+		{name: "normalizations declared in a different order may produce a different result", normalizations: []string{normalizeTrimTrailingSlash, normalizeTrim}, value: "https://domain.com/ ", expectedResult: "https://domain.com/"},
+		{name: "collapse whitespace normalization", normalizations: []string{normalizeCollapseSpaces}, value: " some \t  value\n", expectedResult: "some value"},
+		{name: "json normalization", normalizations: []string{normalizeJSON}, value: "{\n  \"b\": [1, 2],\n  \"a\": \"value\"\n}", expectedResult: `{"a":"value","b":[1,2]}`},
+		{name: "json normalization with a value that is not valid json", normalizations: []string{normalizeJSON}, value: "{not json", expectedResult: "{not json"},
+		{name: "no normalizations", normalizations: nil, value: " Some Value/", expectedResult: " Some Value/"},
+	}
+	for _, tc := range testCases {
+		property := &specSchemaDefinitionProperty{Type: typeString, Normalizations: tc.normalizations}
+		assert.Equal(t, tc.expectedResult, property.normalize(tc.value), tc.name)
+	}
+}
+
+func TestNormalizeDiffSuppressFunc(t *testing.T) {
+	testCases := []struct {
+		name             string
+		normalizations   []string
+		oldValue         string
+		newValue         string
+		expectedSuppress bool
+	}{
+		{name: "lowercase normalized values are the same", normalizations: []string{normalizeLowercase}, oldValue: "some value", newValue: "Some Value", expectedSuppress: true},
+		{name: "trim normalized values are the same", normalizations: []string{normalizeTrim}, oldValue: "some value", newValue: " some value ", expectedSuppress: true},
+		{name: "trim trailing slash normalized values are the same", normalizations: []string{normalizeTrimTrailingSlash}, oldValue: "/v1/path", newValue: "/v1/path/", expectedSuppress: true},
+		{name: "composed normalized values are the same", normalizations: []string{normalizeTrim, normalizeLowercase}, oldValue: "some value", newValue: " Some Value ", expectedSuppress: true},
+		{name: "normalized values are different", normalizations: []string{normalizeLowercase}, oldValue: "some value", newValue: "Other Value", expectedSuppress: false},
+		{name: "values differing on a normalization that is not configured", normalizations: []string{normalizeLowercase}, oldValue: "some value", newValue: " some value", expectedSuppress: false},
+	}
+	for _, tc := range testCases {
+		property := &specSchemaDefinitionProperty{Type: typeString, Normalizations: tc.normalizations}
+		assert.Equal(t, tc.expectedSuppress, property.normalizeDiffSuppressFunc()("property", tc.oldValue, tc.newValue, nil), tc.name)
+	}
+}
+
 func TestIsReadOnly(t *testing.T) {
 	Convey("Given a specSchemaDefinitionProperty that is readOnly", t, func() {
 		s := &specSchemaDefinitionProperty{
@@ -487,6 +572,20 @@ func TestSchemaDefinitionPropertyIsOptionalComputed(t *testing.T) {
 }
 
 func TestTerraformType(t *testing.T) {
+	Convey("Given a swagger schema definition that has a property of type map", t, func() {
+		s := &specSchemaDefinitionProperty{
+			Type: typeMap,
+		}
+		Convey("When terraformType method is called", func() {
+			valueType, err := s.terraformType()
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And value type should be map", func() {
+				So(valueType, ShouldEqual, schema.TypeMap)
+			})
+		})
+	})
 	Convey("Given a swagger schema definition that has a property of type string", t, func() {
 		s := &specSchemaDefinitionProperty{
 			Type: typeString,
@@ -832,6 +931,28 @@ func TestSpecSchemaDefinitionIsPropertyWithNestedObjects(t *testing.T) {
 				},
 			},
 			expected: true},
+		{name: "swagger schema definition property that has nested lists",
+			schemaDefinitionPropertyType: typeObject,
+			specSchemaDefinition: &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					&specSchemaDefinitionProperty{
+						Type:           typeList,
+						ArrayItemsType: typeString,
+					},
+				},
+			},
+			expected: true},
+		{name: "swagger schema definition property that has nested maps",
+			schemaDefinitionPropertyType: typeObject,
+			specSchemaDefinition: &specSchemaDefinition{
+				Properties: specSchemaDefinitionProperties{
+					&specSchemaDefinitionProperty{
+						Type:                     typeMap,
+						AdditionalPropertiesType: typeString,
+					},
+				},
+			},
+			expected: true},
 		{name: "swagger schema definition property that DOES NOT have nested objects",
 			specSchemaDefinition: &specSchemaDefinition{
 				Properties: specSchemaDefinitionProperties{
@@ -859,6 +980,60 @@ func TestSpecSchemaDefinitionIsPropertyWithNestedObjects(t *testing.T) {
 }
 
 func TestTerraformSchema(t *testing.T) {
+	Convey("Given a swagger schema definition that has a property of type map with values of type string", t, func() {
+		s := newMapSchemaDefinitionPropertyWithDefaults("metadata", "", false, false, nil, typeString)
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resulting tfPropSchema should be of type map with elements of type string", func() {
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeMap)
+				So(tfPropSchema.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
+				So(tfPropSchema.Optional, ShouldBeTrue)
+				So(tfPropSchema.ValidateFunc, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a swagger schema definition that has a property of type map with values of type object", t, func() {
+		s := newMapSchemaDefinitionPropertyWithDefaults("origins", "", false, false, nil, typeObject)
+		s.SpecSchemaDefinition = &specSchemaDefinition{Properties: specSchemaDefinitionProperties{newIntSchemaDefinitionPropertyWithDefaults("port", "", true, false, nil)}}
+		Convey("When terraformSchema method is called", func() {
+			tfPropSchema, err := s.terraformSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resulting tfPropSchema should be a set of blocks containing the key and the object properties", func() {
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeSet)
+				So(tfPropSchema.Optional, ShouldBeTrue)
+				elem := tfPropSchema.Elem.(*schema.Resource)
+				So(elem.Schema, ShouldContainKey, mapOfObjectsKeyPropertyName)
+				So(elem.Schema[mapOfObjectsKeyPropertyName].Type, ShouldEqual, schema.TypeString)
+				So(elem.Schema[mapOfObjectsKeyPropertyName].Required, ShouldBeTrue)
+				So(elem.Schema["port"].Type, ShouldEqual, schema.TypeInt)
+				So(elem.Schema["port"].Required, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a swagger schema definition that has a property of type map with object values containing a property named key", t, func() {
+		s := newMapSchemaDefinitionPropertyWithDefaults("origins", "", false, false, nil, typeObject)
+		s.SpecSchemaDefinition = &specSchemaDefinition{Properties: specSchemaDefinitionProperties{newStringSchemaDefinitionPropertyWithDefaults("key", "", false, false, nil)}}
+		Convey("When terraformSchema method is called", func() {
+			_, err := s.terraformSchema()
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "map property 'origins' values can not contain a property named 'key' as it is reserved for the map keys")
+			})
+		})
+	})
+	Convey("Given a swagger schema definition that has a property of type map with values of a non supported type", t, func() {
+		s := newMapSchemaDefinitionPropertyWithDefaults("metadata", "", false, false, nil, typeObject)
+		Convey("When terraformSchema method is called", func() {
+			_, err := s.terraformSchema()
+			Convey("Then the error returned should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "map property 'metadata' has a non supported additionalProperties type 'object'")
+			})
+		})
+	})
 	Convey("Given a swagger schema definition that has two nested properties - one being a simple object and the other one a primitive", t, func() {
 		expectedNestedObjectPropertyName := "nested_object1"
 		s := &specSchemaDefinitionProperty{
@@ -896,7 +1071,7 @@ func TestTerraformSchema(t *testing.T) {
 				nestedObject1 := tfPropSchema.Elem.(*schema.Resource).Schema["nested_object1"]
 				So(nestedObject1, ShouldNotBeNil)
 				So(nestedObject1.Type, ShouldEqual, schema.TypeMap)
-				So(nestedObject1.Elem.(*schema.Resource).Schema["string_property_1"].Type, ShouldEqual, schema.TypeString)
+				So(nestedObject1.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 			Convey("And the returned terraform schema contains the 'nested_float_2' with the right configuration", func() {
 				nestedObject2 := tfPropSchema.Elem.(*schema.Resource).Schema["nested_float_2"]
@@ -950,13 +1125,13 @@ func TestTerraformSchema(t *testing.T) {
 				nestedObject1 := tfPropSchema.Elem.(*schema.Resource).Schema[expectedNestedObjectPropertyName1]
 				So(nestedObject1, ShouldNotBeNil)
 				So(nestedObject1.Type, ShouldEqual, schema.TypeMap)
-				So(nestedObject1.Elem.(*schema.Resource).Schema["string_property_1"].Type, ShouldEqual, schema.TypeString)
+				So(nestedObject1.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 			Convey("And the returned terraform schema contains the schema for the Second nested object property with the right configuration", func() {
 				nestedObject2 := tfPropSchema.Elem.(*schema.Resource).Schema[expectedNestedObjectPropertyName2]
 				So(nestedObject2, ShouldNotBeNil)
 				So(nestedObject2.Type, ShouldEqual, schema.TypeMap)
-				So(nestedObject2.Elem.(*schema.Resource).Schema["string_property_2"].Type, ShouldEqual, schema.TypeString)
+				So(nestedObject2.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 		})
 	})
@@ -1099,10 +1274,10 @@ func TestTerraformSchema(t *testing.T) {
 			}}
 		Convey("When terraformSchema method is called", func() {
 			tfPropSchema, err := s.terraformSchema()
-			Convey("Then the resulting tfPropSchema should match the following using TypeMap as type", func() {
+			Convey("Then the resulting tfPropSchema should match the following using TypeMap of strings as type", func() {
 				So(err, ShouldBeNil)
 				So(tfPropSchema.Type, ShouldEqual, schema.TypeMap)
-				So(tfPropSchema.Elem.(*schema.Resource).Schema["my_int_prop"].Computed, ShouldBeTrue)
+				So(tfPropSchema.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 		})
 	})
@@ -1339,18 +1514,20 @@ func TestTerraformSchema(t *testing.T) {
 			Convey("And the tf resource schema returned should not be nil", func() {
 				So(tfPropSchema, ShouldNotBeNil)
 			})
-			Convey("And the tf resource schema returned should contained the sub property - 'message'", func() {
-				So(tfPropSchema.Elem.(*schema.Resource).Schema, ShouldContainKey, "message")
+			Convey("And the tf resource schema returned should be a map of strings", func() {
+				So(tfPropSchema.Type, ShouldEqual, schema.TypeMap)
+				So(tfPropSchema.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 		})
 	})
 
-	Convey("Given a swagger schema definition that has a property of type object that has nested schema and property named id", t, func() {
+	Convey("Given a swagger schema definition that has a property of type object using the legacy block configuration that has nested schema and property named id", t, func() {
 		s := &specSchemaDefinitionProperty{
 			Name:     "object_prop",
 			Type:     typeObject,
 			ReadOnly: false,
 			Required: true,
+			EnableLegacyComplexObjectBlockConfiguration: true,
 			SpecSchemaDefinition: &specSchemaDefinition{
 				Properties: specSchemaDefinitionProperties{
 					&specSchemaDefinitionProperty{
@@ -1509,6 +1686,21 @@ func TestTerraformSchema(t *testing.T) {
 		})
 	})
 
+	Convey("Given a schemaDefinitionProperty of type list that is optional and does have a default value", t, func() {
+		s := newListSchemaDefinitionPropertyWithDefaults("propertyName", "", false, false, false, []interface{}{"value"}, typeString, nil)
+		Convey("When terraformSchema is called", func() {
+			terraformPropertySchema, err := s.terraformSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema returned should be optional and computed with no default value since terraform does not support defaults in lists", func() {
+				So(terraformPropertySchema.Optional, ShouldBeTrue)
+				So(terraformPropertySchema.Computed, ShouldBeTrue)
+				So(terraformPropertySchema.Default, ShouldBeNil)
+			})
+		})
+	})
+
 	Convey("Given a schemaDefinitionProperty that is forceNew and immutable ", t, func() {
 		s := newStringSchemaDefinitionProperty("propertyName", "", false, false, false, true, false, true, false, false, "")
 		Convey("When terraformSchema is called with a schema definition property that validation fails due to immutable and forceNew set", func() {
@@ -1554,6 +1746,33 @@ func TestTerraformSchema(t *testing.T) {
 	})
 }
 
+func TestTerraformSchemaNormalizations(t *testing.T) {
+	Convey("Given a string schemaDefinitionProperty with normalizations", t, func() {
+		s := &specSchemaDefinitionProperty{Name: "propertyName", Type: typeString, Normalizations: []string{normalizeLowercase}}
+		Convey("When terraformSchema is called", func() {
+			terraformPropertySchema, err := s.terraformSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema returned should suppress the diffs of values that are the same once normalized", func() {
+				So(terraformPropertySchema.DiffSuppressFunc, ShouldNotBeNil)
+				So(terraformPropertySchema.DiffSuppressFunc("property_name", "some value", "Some Value", nil), ShouldBeTrue)
+				So(terraformPropertySchema.DiffSuppressFunc("property_name", "some value", "Other Value", nil), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a string schemaDefinitionProperty without normalizations", t, func() {
+		s := &specSchemaDefinitionProperty{Name: "propertyName", Type: typeString}
+		Convey("When terraformSchema is called", func() {
+			terraformPropertySchema, err := s.terraformSchema()
+			Convey("Then the error returned should be nil and the schema returned should not have a diff suppress function", func() {
+				So(err, ShouldBeNil)
+				So(terraformPropertySchema.DiffSuppressFunc, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestValidateFunc(t *testing.T) {
 
 	Convey("Given a schemaDefinitionProperty that is computed and has a default value set", t, func() {
@@ -4,7 +4,8 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -159,11 +160,8 @@ func TestCreateDataSourceSchema_ForNestedObjects(t *testing.T) {
 		assertDataSourceSchemaProperty(t, objectResource.Schema["id"], schema.TypeString)
 		assertDataSourceSchemaProperty(t, objectResource.Schema["nested_object"], schema.TypeMap)
 
-		// 2^ level
-		nestedObjectResource := objectResource.Schema["nested_object"].Elem.(*schema.Resource)
-		assert.Equal(t, 2, len(nestedObjectResource.Schema))
-		assertDataSourceSchemaProperty(t, nestedObjectResource.Schema["origin_port"], schema.TypeInt)
-		assertDataSourceSchemaProperty(t, nestedObjectResource.Schema["protocol"], schema.TypeString)
+		// 2^ level (simple objects are represented as maps of strings)
+		assert.Equal(t, schema.TypeString, objectResource.Schema["nested_object"].Elem.(*schema.Schema).Type)
 	})
 }
 
@@ -286,9 +284,8 @@ func TestCreateResourceSchema(t *testing.T) {
 			Convey("And the resulted tfResourceSchema status field be of type map", func() {
 				So(tfResourceSchema[statusDefaultPropertyName].Type, ShouldEqual, schema.TypeMap)
 			})
-			Convey("And the resulted tfResourceSchema status field should contain all the sub-properties including the id property", func() {
-				So(tfResourceSchema[statusDefaultPropertyName].Elem.(*schema.Resource).Schema, ShouldContainKey, "id")
-				So(tfResourceSchema[statusDefaultPropertyName].Elem.(*schema.Resource).Schema, ShouldContainKey, "name")
+			Convey("And the resulted tfResourceSchema status field should be a map of strings", func() {
+				So(tfResourceSchema[statusDefaultPropertyName].Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 			})
 		})
 	})
@@ -418,6 +415,42 @@ func TestCreateResourceSchema(t *testing.T) {
 	})
 }
 
+func TestForceNewImmutableProperties(t *testing.T) {
+	Convey("Given a schemaDefinition containing immutable properties including nested ones and a computed one", t, func() {
+		nestedImmutableProperty := &specSchemaDefinitionProperty{Name: "nested_immutable_property", Type: typeString, Immutable: true}
+		s := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				&specSchemaDefinitionProperty{Name: "immutable_property", Type: typeString, Immutable: true},
+				&specSchemaDefinitionProperty{Name: "computed_immutable_property", Type: typeString, ReadOnly: true, Immutable: true},
+				&specSchemaDefinitionProperty{Name: "mutable_property", Type: typeString},
+				&specSchemaDefinitionProperty{
+					Name: "object_property",
+					Type: typeObject,
+					SpecSchemaDefinition: &specSchemaDefinition{
+						Properties: specSchemaDefinitionProperties{nestedImmutableProperty},
+					},
+				},
+			},
+		}
+		Convey("When forceNewImmutableProperties method is called", func() {
+			s.forceNewImmutableProperties()
+			Convey("Then the immutable properties should be configured to force a new resource instead", func() {
+				So(s.Properties[0].ForceNew, ShouldBeTrue)
+				So(s.Properties[0].Immutable, ShouldBeFalse)
+				So(nestedImmutableProperty.ForceNew, ShouldBeTrue)
+				So(nestedImmutableProperty.Immutable, ShouldBeFalse)
+				So(s.getImmutableProperties(), ShouldResemble, []string{"computed_immutable_property"})
+			})
+			Convey("And the computed and mutable properties should be left untouched", func() {
+				So(s.Properties[1].ForceNew, ShouldBeFalse)
+				So(s.Properties[1].Immutable, ShouldBeTrue)
+				So(s.Properties[2].ForceNew, ShouldBeFalse)
+				So(s.Properties[3].ForceNew, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestGetImmutableProperties(t *testing.T) {
 	Convey("Given resource info is configured with schemaDefinition that contains a property 'immutable_property' that is immutable", t, func() {
 		s := &specSchemaDefinition{
@@ -473,6 +506,23 @@ func TestGetImmutableProperties(t *testing.T) {
 
 }
 
+func TestContainsIdentifier(t *testing.T) {
+	testCases := []struct {
+		name               string
+		properties         specSchemaDefinitionProperties
+		expectedIdentifier bool
+	}{
+		{name: "property named id", properties: specSchemaDefinitionProperties{&specSchemaDefinitionProperty{Name: "id"}}, expectedIdentifier: true},
+		{name: "property marked as identifier", properties: specSchemaDefinitionProperties{&specSchemaDefinitionProperty{Name: "cdnId", IsIdentifier: true}}, expectedIdentifier: true},
+		{name: "no identifier property", properties: specSchemaDefinitionProperties{&specSchemaDefinitionProperty{Name: "name"}}, expectedIdentifier: false},
+		{name: "nested property marked as identifier", properties: specSchemaDefinitionProperties{&specSchemaDefinitionProperty{Name: "metadata", Type: typeObject, SpecSchemaDefinition: &specSchemaDefinition{Properties: specSchemaDefinitionProperties{&specSchemaDefinitionProperty{Name: "uid", IsIdentifier: true}}}}}, expectedIdentifier: true},
+	}
+	for _, tc := range testCases {
+		s := &specSchemaDefinition{Properties: tc.properties}
+		assert.Equal(t, tc.expectedIdentifier, s.containsIdentifier(), tc.name)
+	}
+}
+
 func TestGetResourceIdentifier(t *testing.T) {
 	Convey("Given a specSchemaDefinition containing a field named id", t, func() {
 		s := &specSchemaDefinition{
@@ -517,6 +567,40 @@ func TestGetResourceIdentifier(t *testing.T) {
 		})
 	})
 
+	Convey("Given a specSchemaDefinition containing a field named id and an object property with a nested property tagged as IsIdentifier", t, func() {
+		s := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				&specSchemaDefinitionProperty{
+					Name: "id",
+					Type: typeString,
+				},
+				&specSchemaDefinitionProperty{
+					Name: "metadata",
+					Type: typeObject,
+					SpecSchemaDefinition: &specSchemaDefinition{
+						Properties: specSchemaDefinitionProperties{
+							&specSchemaDefinitionProperty{
+								Name:         "uid",
+								Type:         typeString,
+								ReadOnly:     true,
+								IsIdentifier: true,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceIdentifier method is called", func() {
+			id, err := s.getResourceIdentifier()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the id returned should be the dot separated path to the nested property", func() {
+				So(id, ShouldEqual, "metadata.uid")
+			})
+		})
+	})
+
 	Convey("Given a specSchemaDefinition not containing a field named id nor tagged as identifier", t, func() {
 		s := &specSchemaDefinition{
 			Properties: specSchemaDefinitionProperties{
@@ -800,3 +884,317 @@ func TestGetPropertyBasedOnTerraformName(t *testing.T) {
 	assert.EqualError(t, err, "property with terraform name 'badTerraformPropertyName' not existing in resource schema definition")
 
 }
+
+func TestGetPropertyBasedOnResponseFieldName(t *testing.T) {
+	s := &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			&specSchemaDefinitionProperty{
+				Name: "label",
+				Type: typeString,
+			},
+			&specSchemaDefinitionProperty{
+				Name:              "password",
+				ResponseFieldName: "password_hash",
+				Type:              typeString,
+			},
+		},
+	}
+	property, err := s.getPropertyBasedOnResponseFieldName("label")
+	assert.Nil(t, err)
+	assert.Equal(t, "label", property.Name)
+
+	property, err = s.getPropertyBasedOnResponseFieldName("password_hash")
+	assert.Nil(t, err)
+	assert.Equal(t, "password", property.Name)
+
+	_, err = s.getPropertyBasedOnResponseFieldName("password")
+	assert.EqualError(t, err, "property with response field name 'password' not existing in resource schema definition")
+}
+
+func TestRedactSensitiveValues(t *testing.T) {
+	s := &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			&specSchemaDefinitionProperty{
+				Name: "label",
+				Type: typeString,
+			},
+			&specSchemaDefinitionProperty{
+				Name:      "password",
+				Type:      typeString,
+				Sensitive: true,
+			},
+			&specSchemaDefinitionProperty{
+				Name:              "token",
+				ResponseFieldName: "access_token",
+				Type:              typeString,
+				Sensitive:         true,
+			},
+			&specSchemaDefinitionProperty{
+				Name: "credentials",
+				Type: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "user", Type: typeString},
+						&specSchemaDefinitionProperty{Name: "secret", Type: typeString, Sensitive: true},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name:           "keys",
+				Type:           typeList,
+				ArrayItemsType: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "secret", Type: typeString, Sensitive: true},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name:                     "origins",
+				Type:                     typeMap,
+				AdditionalPropertiesType: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "secret", Type: typeString, Sensitive: true},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name: "pet",
+				Type: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Discriminator: "kind",
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{
+							Name: "dog",
+							Type: typeObject,
+							SpecSchemaDefinition: &specSchemaDefinition{
+								Properties: specSchemaDefinitionProperties{
+									&specSchemaDefinitionProperty{Name: "chip_id", Type: typeString, Sensitive: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	payload := map[string]interface{}{
+		"label":        "some label",
+		"password":     "some password",
+		"access_token": "some token",
+		"credentials": map[string]interface{}{
+			"user":   "some user",
+			"secret": "some secret",
+		},
+		"keys": []interface{}{
+			map[string]interface{}{"secret": "some secret"},
+		},
+		"origins": map[string]interface{}{
+			"primary": map[string]interface{}{"secret": "some secret"},
+		},
+		"pet":     map[string]interface{}{"kind": "dog", "chip_id": "some chip id"},
+		"unknown": "some value",
+	}
+	redactedPayload := s.redactSensitiveValues(payload)
+	assert.Equal(t, map[string]interface{}{
+		"label":        "some label",
+		"password":     redactedValue,
+		"access_token": redactedValue,
+		"credentials": map[string]interface{}{
+			"user":   "some user",
+			"secret": redactedValue,
+		},
+		"keys": []interface{}{
+			map[string]interface{}{"secret": redactedValue},
+		},
+		"origins": map[string]interface{}{
+			"primary": map[string]interface{}{"secret": redactedValue},
+		},
+		"pet":     map[string]interface{}{"kind": "dog", "chip_id": redactedValue},
+		"unknown": "some value",
+	}, redactedPayload)
+	// the original payload must not be modified
+	assert.Equal(t, "some password", payload["password"])
+	assert.Equal(t, "some secret", payload["credentials"].(map[string]interface{})["secret"])
+	assert.Nil(t, s.redactSensitiveValues(nil))
+}
+
+func TestGetSensitivePropertyNames(t *testing.T) {
+	Convey("Given a specSchemaDefinition with sensitive properties, some of them nested and with response field names", t, func() {
+		s := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				&specSchemaDefinitionProperty{Name: "label", Type: typeString},
+				&specSchemaDefinitionProperty{Name: "password", Type: typeString, Sensitive: true},
+				&specSchemaDefinitionProperty{Name: "token", ResponseFieldName: "access_token", Type: typeString, Sensitive: true},
+				&specSchemaDefinitionProperty{
+					Name: "credentials",
+					Type: typeObject,
+					SpecSchemaDefinition: &specSchemaDefinition{
+						Properties: specSchemaDefinitionProperties{
+							&specSchemaDefinitionProperty{Name: "user", Type: typeString},
+							&specSchemaDefinitionProperty{Name: "secret", Type: typeString, Sensitive: true},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getSensitivePropertyNames is called", func() {
+			names := s.getSensitivePropertyNames()
+			Convey("Then the names returned should contain the request and response field names of the sensitive properties", func() {
+				So(names, ShouldResemble, []string{"password", "token", "access_token", "secret"})
+			})
+		})
+	})
+}
+
+func TestGetTerraformAttributePath(t *testing.T) {
+	s := &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			&specSchemaDefinitionProperty{Name: "label", Type: typeString},
+			&specSchemaDefinitionProperty{Name: "displayName", Type: typeString},
+			&specSchemaDefinitionProperty{Name: "tags", Type: typeList, ArrayItemsType: typeString},
+			&specSchemaDefinitionProperty{
+				Name: "simpleObject",
+				Type: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "origin", Type: typeString},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name: "complexObject",
+				Type: typeObject,
+				EnableLegacyComplexObjectBlockConfiguration: true,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "hostName", Type: typeString},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name:           "listeners",
+				Type:           typeList,
+				ArrayItemsType: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "port", PreferredName: "listener_port", Type: typeInt},
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name                  string
+		fieldPath             string
+		expectedAttributePath string
+		expectedOK            bool
+	}{
+		{name: "top level property", fieldPath: "label", expectedAttributePath: "label", expectedOK: true},
+		{name: "top level property with non terraform compliant name", fieldPath: "displayName", expectedAttributePath: "display_name", expectedOK: true},
+		{name: "list of primitives item", fieldPath: "tags[1]", expectedAttributePath: "tags.1", expectedOK: true},
+		{name: "simple object property", fieldPath: "simpleObject.origin", expectedAttributePath: "simple_object.origin", expectedOK: true},
+		{name: "complex object property", fieldPath: "complexObject.hostName", expectedAttributePath: "complex_object.0.host_name", expectedOK: true},
+		{name: "list of objects item property", fieldPath: "listeners.0.port", expectedAttributePath: "listeners.0.listener_port", expectedOK: true},
+		{name: "list of objects item property using brackets", fieldPath: "listeners[2].port", expectedAttributePath: "listeners.2.listener_port", expectedOK: true},
+		{name: "JSON pointer", fieldPath: "/listeners/0/port", expectedAttributePath: "listeners.0.listener_port", expectedOK: true},
+		{name: "list of objects itself", fieldPath: "listeners", expectedAttributePath: "listeners", expectedOK: true},
+		{name: "unknown property", fieldPath: "unknown", expectedOK: false},
+		{name: "unknown nested property", fieldPath: "simpleObject.unknown", expectedOK: false},
+		{name: "list accessed without index", fieldPath: "listeners.port", expectedOK: false},
+		{name: "primitive property accessed as an object", fieldPath: "label.something", expectedOK: false},
+		{name: "empty path", fieldPath: "", expectedOK: false},
+	}
+	for _, tc := range testCases {
+		attributePath, ok := s.getTerraformAttributePath(tc.fieldPath)
+		assert.Equal(t, tc.expectedOK, ok, tc.name)
+		assert.Equal(t, tc.expectedAttributePath, attributePath, tc.name)
+	}
+}
+
+func TestGetTerraformAttributeCtyPath(t *testing.T) {
+	s := &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			&specSchemaDefinitionProperty{Name: "label", Type: typeString},
+			&specSchemaDefinitionProperty{
+				Name: "simpleObject",
+				Type: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "originPort", Type: typeInt},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name: "complexObject",
+				Type: typeObject,
+				EnableLegacyComplexObjectBlockConfiguration: true,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "hostName", Type: typeString},
+					},
+				},
+			},
+			&specSchemaDefinitionProperty{
+				Name:           "listeners",
+				Type:           typeList,
+				ArrayItemsType: typeObject,
+				SpecSchemaDefinition: &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "port", Type: typeInt},
+					},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name                  string
+		fieldPath             string
+		expectedAttributePath cty.Path
+		expectedOK            bool
+	}{
+		{name: "top level property", fieldPath: "label", expectedAttributePath: cty.GetAttrPath("label"), expectedOK: true},
+		{name: "simple object property is a map key", fieldPath: "simpleObject.originPort", expectedAttributePath: cty.GetAttrPath("simple_object").IndexString("origin_port"), expectedOK: true},
+		{name: "complex object property is the attribute of the first item", fieldPath: "complexObject.hostName", expectedAttributePath: cty.GetAttrPath("complex_object").IndexInt(0).GetAttr("host_name"), expectedOK: true},
+		{name: "list of objects item property", fieldPath: "/listeners/3/port", expectedAttributePath: cty.GetAttrPath("listeners").IndexInt(3).GetAttr("port"), expectedOK: true},
+		{name: "unknown property", fieldPath: "unknown", expectedAttributePath: nil, expectedOK: false},
+	}
+	for _, tc := range testCases {
+		attributePath, ok := s.getTerraformAttributeCtyPath(tc.fieldPath)
+		assert.Equal(t, tc.expectedOK, ok, tc.name)
+		assert.Equal(t, tc.expectedAttributePath, attributePath, tc.name)
+	}
+}
+
+func TestCreateResourceSchemaDiscriminatedUnion(t *testing.T) {
+	Convey("Given a schema definition containing a discriminated union property", t, func() {
+		s := &specSchemaDefinition{
+			Properties: specSchemaDefinitionProperties{
+				newObjectSchemaDefinitionPropertyWithDefaults("pet", "", false, false, false, nil, newUnionSchemaDefinitionStub()),
+			},
+		}
+		Convey("When createResourceSchema method is called", func() {
+			tfResourceSchema, err := s.createResourceSchema()
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the union should be a block containing an optional block per variant", func() {
+				So(tfResourceSchema["pet"].Type, ShouldEqual, schema.TypeList)
+				So(tfResourceSchema["pet"].MaxItems, ShouldEqual, 1)
+				variants := tfResourceSchema["pet"].Elem.(*schema.Resource).Schema
+				So(variants["cat"].Optional, ShouldBeTrue)
+				So(variants["cat"].MaxItems, ShouldEqual, 1)
+				So(variants["dog"].Optional, ShouldBeTrue)
+			})
+			Convey("And the variants should conflict with each other", func() {
+				variants := tfResourceSchema["pet"].Elem.(*schema.Resource).Schema
+				So(variants["cat"].ConflictsWith, ShouldResemble, []string{"pet.0.dog"})
+				So(variants["dog"].ConflictsWith, ShouldResemble, []string{"pet.0.cat"})
+			})
+			Convey("And the resulting resource schema should be valid", func() {
+				So((&schema.Resource{Schema: tfResourceSchema}).InternalValidate(nil, true), ShouldBeNil)
+			})
+		})
+	})
+}
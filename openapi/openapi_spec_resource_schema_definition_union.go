@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// isDiscriminatedUnion returns true if the schema definition represents a discriminated union, where each property is
+// one of the variants of the union
+func (s *specSchemaDefinition) isDiscriminatedUnion() bool {
+	return s != nil && s.Discriminator != ""
+}
+
+// toUnionPayload returns the API payload for the discriminated union given the payloads of the variants keyed by their
+// discriminator value. The payload returned is the one of the variant configured including the discriminator property,
+// e,g: {"dog": {"name": "Rex"}} results in {"kind": "dog", "name": "Rex"}. An error is returned if more than one variant
+// is configured
+func (s *specSchemaDefinition) toUnionPayload(variants map[string]interface{}) (map[string]interface{}, error) {
+	var configured []string
+	for name, variantPayload := range variants {
+		if variantPayload != nil {
+			configured = append(configured, name)
+		}
+	}
+	payload := map[string]interface{}{}
+	if len(configured) == 0 {
+		return payload, nil
+	}
+	if len(configured) > 1 {
+		var configuredNames []string
+		for _, name := range configured {
+			if variant, err := s.getProperty(name); err == nil {
+				name = variant.getTerraformCompliantPropertyName()
+			}
+			configuredNames = append(configuredNames, name)
+		}
+		sort.Strings(configuredNames)
+		return nil, fmt.Errorf("only one of the %s variants can be configured, found: %s", strings.Join(s.getVariantTerraformNames(), ", "), strings.Join(configuredNames, ", "))
+	}
+	if variantPayload, ok := variants[configured[0]].(map[string]interface{}); ok {
+		for name, value := range variantPayload {
+			payload[name] = value
+		}
+	}
+	payload[s.Discriminator] = configured[0]
+	return payload, nil
+}
+
+// fromUnionPayload returns the payload returned by the API for the discriminated union keyed by the discriminator value
+// of the variant, e,g: {"kind": "dog", "name": "Rex"} results in {"dog": {"name": "Rex"}}. Payloads with unknown
+// discriminator values are ignored
+func (s *specSchemaDefinition) fromUnionPayload(payload map[string]interface{}) map[string]interface{} {
+	discriminatorValue, _ := payload[s.Discriminator].(string)
+	for _, variant := range s.Properties {
+		if variant.Name != discriminatorValue {
+			continue
+		}
+		variantPayload := map[string]interface{}{}
+		for name, value := range payload {
+			if name != s.Discriminator {
+				variantPayload[name] = value
+			}
+		}
+		return map[string]interface{}{variant.Name: variantPayload}
+	}
+	s.getLogger().Warn(fmt.Sprintf("ignoring discriminated union payload with unknown '%s' value '%v'", s.Discriminator, payload[s.Discriminator]))
+	return map[string]interface{}{}
+}
+
+func (s *specSchemaDefinition) getVariantTerraformNames() []string {
+	var names []string
+	for _, variant := range s.Properties {
+		names = append(names, variant.getTerraformCompliantPropertyName())
+	}
+	sort.Strings(names)
+	return names
+}
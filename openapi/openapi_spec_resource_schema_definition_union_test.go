@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newUnionSchemaDefinitionStub() *specSchemaDefinition {
+	cat := newObjectSchemaDefinitionPropertyWithDefaults("Cat", "", false, false, false, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{newIntSchemaDefinitionPropertyWithDefaults("lives", "", false, false, nil)}})
+	cat.EnableLegacyComplexObjectBlockConfiguration = true
+	dog := newObjectSchemaDefinitionPropertyWithDefaults("dog", "", false, false, false, nil, &specSchemaDefinition{Properties: specSchemaDefinitionProperties{newBoolSchemaDefinitionPropertyWithDefaults("bark", "", false, false, nil)}})
+	dog.EnableLegacyComplexObjectBlockConfiguration = true
+	return &specSchemaDefinition{
+		Discriminator: "kind",
+		Properties:    specSchemaDefinitionProperties{cat, dog},
+	}
+}
+
+func TestToUnionPayload(t *testing.T) {
+	testCases := []struct {
+		name            string
+		variants        map[string]interface{}
+		expectedPayload map[string]interface{}
+		expectedError   string
+	}{
+		{
+			name:            "no variant configured",
+			variants:        map[string]interface{}{},
+			expectedPayload: map[string]interface{}{},
+		},
+		{
+			name:            "one variant configured",
+			variants:        map[string]interface{}{"dog": map[string]interface{}{"bark": true}},
+			expectedPayload: map[string]interface{}{"kind": "dog", "bark": true},
+		},
+		{
+			name:          "more than one variant configured",
+			variants:      map[string]interface{}{"dog": map[string]interface{}{"bark": true}, "Cat": map[string]interface{}{"lives": 7}},
+			expectedError: "only one of the cat, dog variants can be configured, found: cat, dog",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := newUnionSchemaDefinitionStub().toUnionPayload(tc.variants)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPayload, payload)
+		})
+	}
+}
+
+func TestFromUnionPayload(t *testing.T) {
+	testCases := []struct {
+		name             string
+		payload          map[string]interface{}
+		expectedVariants map[string]interface{}
+		expectedWarning  string
+	}{
+		{
+			name:             "known discriminator value",
+			payload:          map[string]interface{}{"kind": "Cat", "lives": 7},
+			expectedVariants: map[string]interface{}{"Cat": map[string]interface{}{"lives": 7}},
+		},
+		{
+			name:             "unknown discriminator value",
+			payload:          map[string]interface{}{"kind": "bird", "wings": 2},
+			expectedVariants: map[string]interface{}{},
+			expectedWarning:  "ignoring discriminated union payload with unknown 'kind' value 'bird'",
+		},
+		{
+			name:             "missing discriminator",
+			payload:          map[string]interface{}{"bark": true},
+			expectedVariants: map[string]interface{}{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := &loggerStub{}
+			s := newUnionSchemaDefinitionStub()
+			s.logger = logger
+			assert.Equal(t, tc.expectedVariants, s.fromUnionPayload(tc.payload))
+			if tc.expectedWarning != "" {
+				assert.True(t, logger.containsMessage("WARN", tc.expectedWarning))
+			}
+		})
+	}
+}
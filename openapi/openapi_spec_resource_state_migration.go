@@ -0,0 +1,151 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// specStateMigration describes how the state of a resource is upgraded from a schema version to the next one
+type specStateMigration struct {
+	// renames maps the previous terraform property names to the new ones
+	renames map[string]string
+	// typeChanges maps the terraform property names (after the renames are applied) to the type the values stored in
+	// the state should be coerced to
+	typeChanges map[string]schemaDefinitionPropertyType
+}
+
+// specStateMigrations contains the state migrations of a resource in order. The migration at index i upgrades the state
+// from schema version i to i+1; hence the current schema version of the resource is the number of migrations
+type specStateMigrations []specStateMigration
+
+func (s specStateMigrations) getSchemaVersion() int {
+	return len(s)
+}
+
+// createStateUpgraders returns the terraform state upgraders for the migrations. The schema of the previous versions
+// needed by terraform to decode legacy (flatmap) states is derived from the current resource schema by reverting the
+// migrations one by one
+func (s specStateMigrations) createStateUpgraders(resource *schema.Resource) []schema.StateUpgrader {
+	if len(s) == 0 {
+		return nil
+	}
+	upgraders := make([]schema.StateUpgrader, len(s))
+	attributeTypes := resource.CoreConfigSchema().ImpliedType().AttributeTypes()
+	for version := len(s) - 1; version >= 0; version-- {
+		attributeTypes = s[version].getPreviousAttributeTypes(attributeTypes)
+		upgraders[version] = schema.StateUpgrader{
+			Version: version,
+			Type:    cty.Object(attributeTypes),
+			Upgrade: s[version].upgrade,
+		}
+	}
+	return upgraders
+}
+
+// getPreviousAttributeTypes returns the attribute types the state had before the migration was applied. The previous
+// type of the properties which type changed is not known, so string is used since legacy states store the primitive
+// values as strings anyway
+func (m specStateMigration) getPreviousAttributeTypes(attributeTypes map[string]cty.Type) map[string]cty.Type {
+	previousAttributeTypes := map[string]cty.Type{}
+	for name, attributeType := range attributeTypes {
+		previousAttributeTypes[name] = attributeType
+	}
+	for propertyName := range m.typeChanges {
+		previousAttributeTypes[propertyName] = cty.String
+	}
+	for previousName, newName := range m.renames {
+		if attributeType, exists := previousAttributeTypes[newName]; exists {
+			delete(previousAttributeTypes, newName)
+			previousAttributeTypes[previousName] = attributeType
+		}
+	}
+	return previousAttributeTypes
+}
+
+// upgrade renames the properties and coerces the values of the properties which type changed. Properties not present
+// in the state as well as null values are left as is
+func (m specStateMigration) upgrade(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+	renamedValues := map[string]interface{}{}
+	for previousName, newName := range m.renames {
+		if value, exists := rawState[previousName]; exists {
+			renamedValues[newName] = value
+			delete(rawState, previousName)
+		}
+	}
+	for newName, value := range renamedValues {
+		rawState[newName] = value
+	}
+	for propertyName, propertyType := range m.typeChanges {
+		value, exists := rawState[propertyName]
+		if !exists || value == nil {
+			continue
+		}
+		coercedValue, err := coerceStateValue(value, propertyType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate state property '%s': %s", propertyName, err)
+		}
+		rawState[propertyName] = coercedValue
+	}
+	return rawState, nil
+}
+
+// coerceStateValue converts the given state value (decoded using the default json types) into the property type
+func coerceStateValue(value interface{}, propertyType schemaDefinitionPropertyType) (interface{}, error) {
+	switch propertyType {
+	case typeString:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case typeInt:
+		switch v := value.(type) {
+		case float64:
+			if v == math.Trunc(v) {
+				return v, nil
+			}
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("value '%s' can not be converted to %s", v, propertyType)
+			}
+			return float64(i), nil
+		}
+	case typeFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("value '%s' can not be converted to %s", v, propertyType)
+			}
+			return f, nil
+		}
+	case typeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("value '%s' can not be converted to %s", v, propertyType)
+			}
+			return b, nil
+		}
+	default:
+		return nil, fmt.Errorf("type '%s' is not supported, supported types are: %s, %s, %s and %s", propertyType, typeString, typeInt, typeFloat, typeBool)
+	}
+	return nil, fmt.Errorf("value '%v' can not be converted to %s", value, propertyType)
+}
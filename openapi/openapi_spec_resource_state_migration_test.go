@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateStateUpgraders(t *testing.T) {
+	Convey("Given a resource and no state migrations", t, func() {
+		resource := &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}}}
+		migrations := specStateMigrations{}
+		Convey("When createStateUpgraders is called", func() {
+			upgraders := migrations.createStateUpgraders(resource)
+			Convey("Then the upgraders returned should be nil and the schema version 0", func() {
+				So(upgraders, ShouldBeNil)
+				So(migrations.getSchemaVersion(), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a resource and state migrations renaming a property and changing the type of another one", t, func() {
+		resource := &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":      {Type: schema.TypeString, Optional: true},
+				"full_name": {Type: schema.TypeString, Optional: true},
+				"port":      {Type: schema.TypeInt, Optional: true},
+			},
+		}
+		migrations := specStateMigrations{
+			{renames: map[string]string{"label": "name"}},
+			{renames: map[string]string{"name_v1": "full_name"}, typeChanges: map[string]schemaDefinitionPropertyType{"port": typeInt}},
+		}
+		Convey("When createStateUpgraders is called", func() {
+			upgraders := migrations.createStateUpgraders(resource)
+			resource.SchemaVersion = migrations.getSchemaVersion()
+			resource.StateUpgraders = upgraders
+			Convey("Then the schema version should match the number of migrations", func() {
+				So(resource.SchemaVersion, ShouldEqual, 2)
+			})
+			Convey("And there should be an upgrader per migration with the corresponding version", func() {
+				So(len(upgraders), ShouldEqual, 2)
+				So(upgraders[0].Version, ShouldEqual, 0)
+				So(upgraders[1].Version, ShouldEqual, 1)
+			})
+			Convey("And the upgraders types should describe the schema of the previous versions", func() {
+				So(upgraders[1].Type.AttributeTypes(), ShouldResemble, map[string]cty.Type{"id": cty.String, "name": cty.String, "name_v1": cty.String, "port": cty.String})
+				So(upgraders[0].Type.AttributeTypes(), ShouldResemble, map[string]cty.Type{"id": cty.String, "label": cty.String, "name_v1": cty.String, "port": cty.String})
+			})
+			Convey("And the resource should be valid", func() {
+				So(resource.InternalValidate(nil, true), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestStateMigrationUpgrade(t *testing.T) {
+	Convey("Given a state migration renaming properties and changing the type of a property", t, func() {
+		migration := specStateMigration{
+			renames:     map[string]string{"label": "name", "first": "second", "second": "first"},
+			typeChanges: map[string]schemaDefinitionPropertyType{"port": typeInt, "missing": typeString, "nullable": typeString},
+		}
+		Convey("When upgrade is called with a state containing the previous properties", func() {
+			state, err := migration.upgrade(context.Background(), map[string]interface{}{"id": "someID", "label": "someName", "first": "1", "second": "2", "port": "8080", "nullable": nil}, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the state returned should contain the properties renamed and the values coerced", func() {
+				So(state, ShouldResemble, map[string]interface{}{"id": "someID", "name": "someName", "first": "2", "second": "1", "port": float64(8080), "nullable": nil})
+			})
+		})
+		Convey("When upgrade is called with a state containing a value that can not be coerced", func() {
+			_, err := migration.upgrade(context.Background(), map[string]interface{}{"port": "not a number"}, nil)
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to migrate state property 'port': value 'not a number' can not be converted to integer")
+			})
+		})
+		Convey("When upgrade is called with a nil state", func() {
+			state, err := migration.upgrade(context.Background(), nil, nil)
+			Convey("Then the state returned should be nil and no error returned", func() {
+				So(err, ShouldBeNil)
+				So(state, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCoerceStateValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         interface{}
+		propertyType  schemaDefinitionPropertyType
+		expectedValue interface{}
+		expectedError string
+	}{
+		{name: "number to string", value: float64(8080), propertyType: typeString, expectedValue: "8080"},
+		{name: "float to string", value: 1.5, propertyType: typeString, expectedValue: "1.5"},
+		{name: "bool to string", value: true, propertyType: typeString, expectedValue: "true"},
+		{name: "string to string", value: "value", propertyType: typeString, expectedValue: "value"},
+		{name: "string to integer", value: "8080", propertyType: typeInt, expectedValue: float64(8080)},
+		{name: "whole number to integer", value: float64(2), propertyType: typeInt, expectedValue: float64(2)},
+		{name: "fractional number to integer", value: 1.5, propertyType: typeInt, expectedError: "value '1.5' can not be converted to integer"},
+		{name: "non numeric string to integer", value: "1.5", propertyType: typeInt, expectedError: "value '1.5' can not be converted to integer"},
+		{name: "string to number", value: "1.5", propertyType: typeFloat, expectedValue: 1.5},
+		{name: "non numeric string to number", value: "abc", propertyType: typeFloat, expectedError: "value 'abc' can not be converted to number"},
+		{name: "string to boolean", value: "true", propertyType: typeBool, expectedValue: true},
+		{name: "non boolean string to boolean", value: "yes", propertyType: typeBool, expectedError: "value 'yes' can not be converted to boolean"},
+		{name: "number to boolean", value: float64(1), propertyType: typeBool, expectedError: "value '1' can not be converted to boolean"},
+		{name: "unsupported type", value: "value", propertyType: typeList, expectedError: "type 'list' is not supported, supported types are: string, integer, number and boolean"},
+	}
+	for _, tc := range testCases {
+		value, err := coerceStateValue(tc.value, tc.propertyType)
+		if tc.expectedError == "" {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, tc.expectedValue, value, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
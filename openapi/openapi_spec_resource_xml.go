@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
+	"github.com/go-openapi/spec"
+)
+
+// specXMLSchema describes how the payloads of the operations that consume or produce XML (application/xml or text/xml)
+// are represented as XML documents, based on the xml hints of the spec schemas (name, namespace, prefix, attribute and
+// wrapped)
+type specXMLSchema struct {
+	// name is the name of the element (or attribute) representing the value
+	name      string
+	namespace string
+	prefix    string
+	// attribute is true if the value is represented as an attribute of the parent element (only applicable to
+	// primitive values)
+	attribute bool
+	// wrapped is true if the items of the list are wrapped in an element with the list name (only applicable to lists)
+	wrapped bool
+	// schemaType is the type of the value as per the spec (e,g: string, integer, object, array), used to convert the
+	// text of the elements into the type expected
+	schemaType string
+	// properties contains the schemas of the object properties keyed by the property name (only applicable to objects)
+	properties map[string]*specXMLSchema
+	// items contains the schema of the list items (only applicable to lists)
+	items *specXMLSchema
+}
+
+// newSpecXMLSchema returns the XML schema of the given spec schema, the name is the default name of the element if the
+// schema does not define its xml name. The references to other definitions are resolved
+func newSpecXMLSchema(name string, schema spec.Schema, definitions map[string]spec.Schema) *specXMLSchema {
+	return newSpecXMLSchemaWithRefs(name, schema, definitions, map[string]bool{})
+}
+
+// newSpecXMLSchemaWithRefs returns the XML schema of the given spec schema, the refs contains the references being
+// resolved so recursive definitions are only resolved once
+func newSpecXMLSchemaWithRefs(name string, schema spec.Schema, definitions map[string]spec.Schema, refs map[string]bool) *specXMLSchema {
+	if ref := schema.Ref.String(); ref != "" {
+		definition, err := openapiutils.GetSchemaDefinition(definitions, ref)
+		if err != nil || refs[ref] {
+			return &specXMLSchema{name: name}
+		}
+		refsWithDefinition := map[string]bool{ref: true}
+		for r := range refs {
+			refsWithDefinition[r] = true
+		}
+		refs = refsWithDefinition
+		schema = *definition
+	}
+	xmlSchema := &specXMLSchema{name: name}
+	if schema.XML != nil {
+		if schema.XML.Name != "" {
+			xmlSchema.name = schema.XML.Name
+		}
+		xmlSchema.namespace = schema.XML.Namespace
+		xmlSchema.prefix = schema.XML.Prefix
+		xmlSchema.attribute = schema.XML.Attribute
+		xmlSchema.wrapped = schema.XML.Wrapped
+	}
+	if len(schema.Type) > 0 {
+		xmlSchema.schemaType = schema.Type[0]
+	}
+	if len(schema.Properties) > 0 {
+		xmlSchema.schemaType = "object"
+		xmlSchema.properties = map[string]*specXMLSchema{}
+		for propertyName, property := range schema.Properties {
+			xmlSchema.properties[propertyName] = newSpecXMLSchemaWithRefs(propertyName, property, definitions, refs)
+		}
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		xmlSchema.schemaType = "array"
+		xmlSchema.items = newSpecXMLSchemaWithRefs(xmlSchema.name, *schema.Items.Schema, definitions, refs)
+	}
+	return xmlSchema
+}
+
+// getProperty returns the schema of the given object property, a schema with the property name as the element name is
+// returned if the property is not described
+func (s *specXMLSchema) getProperty(propertyName string) *specXMLSchema {
+	if s != nil {
+		if property, exists := s.properties[propertyName]; exists {
+			return property
+		}
+	}
+	return &specXMLSchema{name: propertyName}
+}
+
+// getItems returns the schema of the list items, a schema with the list name as the element name is returned if the
+// items are not described
+func (s *specXMLSchema) getItems() *specXMLSchema {
+	if s.items != nil {
+		return s.items
+	}
+	return &specXMLSchema{name: s.name}
+}
+
+// isXMLMediaType returns true if the given media type is one of the XML media types supported
+func isXMLMediaType(mediaType string) bool {
+	return mediaType == mediaTypeXML || mediaType == mediaTypeTextXML
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewSpecXMLSchema(t *testing.T) {
+	Convey("Given a spec schema with xml hints and references to other definitions", t, func() {
+		definitions := map[string]spec.Schema{
+			"Tag": {
+				SchemaProps:        spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"label": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}}}},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Name: "tag"}},
+			},
+		}
+		schema := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"id":   {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Attribute: true}}},
+					"name": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Name: "fullName"}}},
+					"tags": {
+						SchemaProps:        spec.SchemaProps{Type: spec.StringOrArray{"array"}, Items: &spec.SchemaOrArray{Schema: spec.RefSchema("#/definitions/Tag")}},
+						SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Wrapped: true}},
+					},
+				},
+			},
+			SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Name: "user", Namespace: "https://api.server.com/schema", Prefix: "api"}},
+		}
+		Convey("When newSpecXMLSchema is called", func() {
+			xmlSchema := newSpecXMLSchema("User", schema, definitions)
+			Convey("Then the root element should be described as per the xml hints", func() {
+				So(xmlSchema.name, ShouldEqual, "user")
+				So(xmlSchema.namespace, ShouldEqual, "https://api.server.com/schema")
+				So(xmlSchema.prefix, ShouldEqual, "api")
+				So(xmlSchema.schemaType, ShouldEqual, "object")
+			})
+			Convey("And the properties should be described as per their xml hints", func() {
+				So(xmlSchema.getProperty("id"), ShouldResemble, &specXMLSchema{name: "id", attribute: true, schemaType: "integer"})
+				So(xmlSchema.getProperty("name"), ShouldResemble, &specXMLSchema{name: "fullName", schemaType: "string"})
+			})
+			Convey("And the items of the lists should be described by the definitions referenced", func() {
+				tags := xmlSchema.getProperty("tags")
+				So(tags.wrapped, ShouldBeTrue)
+				So(tags.schemaType, ShouldEqual, "array")
+				So(tags.getItems().name, ShouldEqual, "tag")
+				So(tags.getItems().getProperty("label").schemaType, ShouldEqual, "string")
+			})
+			Convey("And the properties that are not described should be named after the property", func() {
+				So(xmlSchema.getProperty("other"), ShouldResemble, &specXMLSchema{name: "other"})
+			})
+		})
+	})
+	Convey("Given a recursive definition", t, func() {
+		definitions := map[string]spec.Schema{
+			"Node": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"child": *spec.RefSchema("#/definitions/Node")}}},
+		}
+		Convey("When newSpecXMLSchema is called with a schema referencing the definition", func() {
+			xmlSchema := newSpecXMLSchema("Node", *spec.RefSchema("#/definitions/Node"), definitions)
+			Convey("Then the definition should only be resolved once", func() {
+				So(xmlSchema.schemaType, ShouldEqual, "object")
+				So(xmlSchema.getProperty("child"), ShouldResemble, &specXMLSchema{name: "child"})
+			})
+		})
+	})
+}
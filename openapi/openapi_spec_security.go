@@ -10,4 +10,7 @@ type SpecSecurity interface {
 	// GetGlobalSecuritySchemes returns all the global security schemes from the OpenAPI document and translates those
 	// into SpecSecuritySchemes
 	GetGlobalSecuritySchemes() (SpecSecuritySchemes, error)
+	// GetGlobalSecurityRequirements returns all the global security requirements from the OpenAPI document (in order of
+	// preference) and translates those into SpecSecuritySchemes
+	GetGlobalSecurityRequirements() ([]SpecSecuritySchemes, error)
 }
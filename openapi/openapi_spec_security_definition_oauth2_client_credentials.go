@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+)
+
+const oauth2ClientIDPropertySuffix = "client_id"
+const oauth2ClientSecretPropertySuffix = "client_secret"
+
+type specOAuth2ClientCredentialsSecurityDefinition struct {
+	name     string
+	tokenURL string
+	scopes   []string
+}
+
+// newOAuth2ClientCredentialsSecurityDefinition constructs a SpecSecurityDefinition for the OAuth2 client credentials flow
+// (flow 'application' in OpenAPI 2.0). The secDefName value is the identifier of the security definition, the tokenURL is
+// the URL where the access token is requested and the scopes are the scopes requested along with the access token
+func newOAuth2ClientCredentialsSecurityDefinition(secDefName string, tokenURL string, scopes []string) specOAuth2ClientCredentialsSecurityDefinition {
+	return specOAuth2ClientCredentialsSecurityDefinition{secDefName, tokenURL, scopes}
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getName() string {
+	return s.name
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getType() securityDefinitionType {
+	return securityDefinitionOAuth2ClientCredentials
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) getTerraformConfigurationName() string {
+	return terraformutils.ConvertToTerraformCompliantName(s.name)
+}
+
+// getClientIDTerraformConfigurationName returns the name of the provider property containing the OAuth2 client id (e,g: oauth2_auth_client_id)
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientIDTerraformConfigurationName() string {
+	return fmt.Sprintf("%s_%s", s.getTerraformConfigurationName(), oauth2ClientIDPropertySuffix)
+}
+
+// getClientSecretTerraformConfigurationName returns the name of the provider property containing the OAuth2 client secret (e,g: oauth2_auth_client_secret)
+func (s specOAuth2ClientCredentialsSecurityDefinition) getClientSecretTerraformConfigurationName() string {
+	return fmt.Sprintf("%s_%s", s.getTerraformConfigurationName(), oauth2ClientSecretPropertySuffix)
+}
+
+// getAPIKey returns the Authorization header where the access token obtained from the token URL is sent
+func (s specOAuth2ClientCredentialsSecurityDefinition) getAPIKey() specAPIKey {
+	apiKey := newAPIKeyHeader(authorizationHeader)
+	apiKey.Metadata = map[apiKeyMetadataKey]interface{}{
+		oauth2TokenURLKey: s.tokenURL,
+		oauth2ScopesKey:   s.scopes,
+	}
+	return apiKey
+}
+
+// buildValue returns the access token using the Bearer scheme
+func (s specOAuth2ClientCredentialsSecurityDefinition) buildValue(accessToken string) string {
+	if !strings.Contains(accessToken, bearerScheme) {
+		accessToken = fmt.Sprintf("Bearer %s", accessToken)
+	}
+	return accessToken
+}
+
+func (s specOAuth2ClientCredentialsSecurityDefinition) validate() error {
+	if s.name == "" {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name")
+	}
+	if s.tokenURL == "" {
+		return fmt.Errorf("specOAuth2ClientCredentialsSecurityDefinition '%s' missing mandatory token URL", s.name)
+	}
+	if !isURL(s.tokenURL) {
+		return fmt.Errorf("oauth2 security definition '%s' token URL '%s' must be a valid URL", s.name, s.tokenURL)
+	}
+	return nil
+}
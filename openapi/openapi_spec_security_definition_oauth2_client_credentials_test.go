@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuth2ClientCredentialsSecurityDefinition(t *testing.T) {
+	secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2Auth", "https://api.iam.com/oauth2/token", []string{"read"})
+	var _ SpecSecurityDefinition = secDef
+	assert.Equal(t, "oauth2Auth", secDef.getName())
+	assert.Equal(t, securityDefinitionOAuth2ClientCredentials, secDef.getType())
+	assert.Equal(t, "oauth2_auth", secDef.getTerraformConfigurationName())
+	assert.Equal(t, "oauth2_auth_client_id", secDef.getClientIDTerraformConfigurationName())
+	assert.Equal(t, "oauth2_auth_client_secret", secDef.getClientSecretTerraformConfigurationName())
+	assert.Equal(t, "Bearer someAccessToken", secDef.buildValue("someAccessToken"))
+	assert.Equal(t, "Bearer someAccessToken", secDef.buildValue("Bearer someAccessToken"))
+
+	apiKey := secDef.getAPIKey()
+	assert.Equal(t, authorizationHeader, apiKey.Name)
+	assert.Equal(t, inHeader, apiKey.In)
+	assert.Equal(t, "https://api.iam.com/oauth2/token", apiKey.Metadata[oauth2TokenURLKey])
+	assert.Equal(t, []string{"read"}, apiKey.Metadata[oauth2ScopesKey])
+}
+
+func TestOAuth2ClientCredentialsSecurityDefinitionValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		secDef        specOAuth2ClientCredentialsSecurityDefinition
+		expectedError string
+	}{
+		{
+			name:   "security definition with name and token URL",
+			secDef: newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token", nil),
+		},
+		{
+			name:          "security definition missing the name",
+			secDef:        newOAuth2ClientCredentialsSecurityDefinition("", "https://api.iam.com/oauth2/token", nil),
+			expectedError: "specOAuth2ClientCredentialsSecurityDefinition missing mandatory security definition name",
+		},
+		{
+			name:          "security definition missing the token URL",
+			secDef:        newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "", nil),
+			expectedError: "specOAuth2ClientCredentialsSecurityDefinition 'oauth2_auth' missing mandatory token URL",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.secDef.validate()
+		if tc.expectedError == "" {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+		}
+	}
+}
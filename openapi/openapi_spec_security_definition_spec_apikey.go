@@ -11,6 +11,8 @@ type apiKeyMetadataKey string
 
 const (
 	refreshTokenURLKey apiKeyMetadataKey = "refreshTokenURL"
+	oauth2TokenURLKey  apiKeyMetadataKey = "oauth2TokenURL"
+	oauth2ScopesKey    apiKeyMetadataKey = "oauth2Scopes"
 )
 
 type specAPIKey struct {
@@ -15,8 +15,9 @@ func (s SpecSecurityDefinitions) findSecurityDefinitionFor(securitySchemeName st
 type securityDefinitionType string
 
 const (
-	securityDefinitionAPIKey             securityDefinitionType = "apiKey"
-	securityDefinitionAPIKeyRefreshToken securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionAPIKey                  securityDefinitionType = "apiKey"
+	securityDefinitionAPIKeyRefreshToken      securityDefinitionType = "apiKeyRefreshToken"
+	securityDefinitionOAuth2ClientCredentials securityDefinitionType = "oauth2ClientCredentials"
 )
 
 // SpecSecurityDefinition defines the behaviour expected for security definition implementations. This interface creates
@@ -1,6 +1,10 @@
 package openapi
 
-import "github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+import (
+	"sort"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
+)
 
 // SpecSecuritySchemes groups a list of SpecSecurityScheme
 type SpecSecuritySchemes []SpecSecurityScheme
@@ -19,6 +23,27 @@ func createSecuritySchemes(securitySchemes []map[string][]string) SpecSecuritySc
 	return schemes
 }
 
+// createSecurityRequirements returns all the security requirements in the order defined by the service provider. Each
+// security requirement contains the security schemes that must be used together (AND) and the security requirements
+// are alternatives of each other (OR), the first one having the highest priority. The security schemes within a
+// requirement are sorted by name so the requirements are deterministic
+func createSecurityRequirements(securityRequirements []map[string][]string) []SpecSecuritySchemes {
+	var requirements []SpecSecuritySchemes
+	for _, securityRequirement := range securityRequirements {
+		var names []string
+		for securitySchemeName := range securityRequirement {
+			names = append(names, securitySchemeName)
+		}
+		sort.Strings(names)
+		schemes := SpecSecuritySchemes{}
+		for _, name := range names {
+			schemes = append(schemes, SpecSecurityScheme{Name: name})
+		}
+		requirements = append(requirements, schemes)
+	}
+	return requirements
+}
+
 func (s SpecSecuritySchemes) securitySchemeExists(secDef SpecSecurityDefinition) bool {
 	for _, securityScheme := range s {
 		if securityScheme.getTerraformConfigurationName() == secDef.getTerraformConfigurationName() {
@@ -28,6 +28,39 @@ func TestSpecSecuritySchemeGetTerraformConfigurationName(t *testing.T) {
 	})
 }
 
+func TestCreateSecurityRequirements(t *testing.T) {
+	Convey("Given a list of security requirements containing alternative requirements", t, func() {
+		securityRequirements := []map[string][]string{
+			{
+				"query_auth":  {},
+				"header_auth": {},
+			},
+			{
+				"bearer_auth": {},
+			},
+			{},
+		}
+		Convey("When createSecurityRequirements method is called", func() {
+			requirements := createSecurityRequirements(securityRequirements)
+			Convey("Then the requirements returned should keep the order defined and the schemes within each requirement should be sorted by name", func() {
+				So(requirements, ShouldResemble, []SpecSecuritySchemes{
+					{SpecSecurityScheme{Name: "header_auth"}, SpecSecurityScheme{Name: "query_auth"}},
+					{SpecSecurityScheme{Name: "bearer_auth"}},
+					{},
+				})
+			})
+		})
+	})
+	Convey("Given an empty list of security requirements", t, func() {
+		Convey("When createSecurityRequirements method is called", func() {
+			requirements := createSecurityRequirements([]map[string][]string{})
+			Convey("Then the requirements returned should be empty", func() {
+				So(requirements, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
 func TestSecuritySchemeExists(t *testing.T) {
 	Convey("Given a list of specSecuritySchemes", t, func() {
 		securitySchemes := []map[string][]string{
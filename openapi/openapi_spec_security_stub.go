@@ -3,7 +3,9 @@ package openapi
 type specSecurityStub struct {
 	securityDefinitions   *SpecSecurityDefinitions
 	globalSecuritySchemes SpecSecuritySchemes
-	error                 error
+	// globalSecurityRequirements defaults to the globalSecuritySchemes (if any) when not populated
+	globalSecurityRequirements []SpecSecuritySchemes
+	error                      error
 }
 
 func (s *specSecurityStub) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
@@ -19,3 +21,13 @@ func (s *specSecurityStub) GetGlobalSecuritySchemes() (SpecSecuritySchemes, erro
 	}
 	return s.globalSecuritySchemes, nil
 }
+
+func (s *specSecurityStub) GetGlobalSecurityRequirements() ([]SpecSecuritySchemes, error) {
+	if s.error != nil {
+		return nil, s.error
+	}
+	if s.globalSecurityRequirements == nil && len(s.globalSecuritySchemes) > 0 {
+		return []SpecSecuritySchemes{s.globalSecuritySchemes}, nil
+	}
+	return s.globalSecurityRequirements, nil
+}
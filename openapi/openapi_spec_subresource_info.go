@@ -3,10 +3,15 @@ package openapi
 import "fmt"
 
 type parentResourceInfo struct {
+	// parentResourceNames contains the terraform names of the parent resources (e,g: cdns_v1 and cdns_v1_firewalls_v1 for
+	// /v1/cdns/{cdn_id}/v1/firewalls/{firewall_id}/v1/rules), the parent properties are named after them
 	parentResourceNames    []string
 	fullParentResourceName string
 	parentURIs             []string
 	parentInstanceURIs     []string
+	// parentPathParameters contains the names of the path parameters holding the parent IDs (e,g: zone_id for
+	// /v1/zones/{zone_id}/records) in the same order as the parentResourceNames
+	parentPathParameters []string
 }
 
 // getParentPropertiesNames is responsible to building the parent properties names for a resource that is a subresource
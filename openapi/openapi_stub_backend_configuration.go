@@ -15,6 +15,9 @@ type specStubBackendConfiguration struct {
 	hostErr          error
 	defaultRegionErr error
 	hostByRegionErr  error
+	awsSigV4Scope    *awsSigV4Scope
+	awsSigV4ScopeErr error
+	healthCheckPath  string
 
 	getHTTPSchemeBehavior func() (string, error)
 }
@@ -81,3 +84,14 @@ func (s *specStubBackendConfiguration) isMultiRegion() (bool, string, []string,
 	}
 	return false, "", nil, nil
 }
+
+func (s *specStubBackendConfiguration) getAWSSigV4Scope() (*awsSigV4Scope, error) {
+	if s.awsSigV4ScopeErr != nil {
+		return nil, s.awsSigV4ScopeErr
+	}
+	return s.awsSigV4Scope, nil
+}
+
+func (s *specStubBackendConfiguration) getHealthCheckPath() string {
+	return s.healthCheckPath
+}
@@ -11,13 +11,25 @@ type specStubResource struct {
 	resourcePostOperation   *specResourceOperation
 	resourceListOperation   *specResourceOperation
 	resourcePutOperation    *specResourceOperation
+	resourcePatchOperation  *specResourceOperation
 	resourceDeleteOperation *specResourceOperation
+	resourceActions         []*specResourceAction
 	timeouts                *specTimeouts
 
 	parentResourceNames    []string
 	parentPropertyNames    []string
 	fullParentResourceName string
 
+	importLookupProperty string
+	importIDFormat       string
+	// keepOnNotFound disables removing the resource from the state when the API responds with 404 Not Found on read
+	keepOnNotFound       bool
+	parentPathParameters []string
+	readOnly             bool
+	protocolV6           bool
+	tags                 []string
+	stateMigrations      specStateMigrations
+
 	funcGetResourcePath   func(parentIDs []string) (string, error)
 	funcGetResourceSchema func() (*specSchemaDefinition, error)
 	error                 error
@@ -62,13 +74,25 @@ func (s *specStubResource) getResourceSchema() (*specSchemaDefinition, error) {
 
 func (s *specStubResource) shouldIgnoreResource() bool { return s.shouldIgnore }
 
+func (s *specStubResource) isReadOnlyResource() bool { return s.readOnly }
+
+func (s *specStubResource) isProtocolV6Resource() bool { return s.protocolV6 }
+
+func (s *specStubResource) getResourceTags() []string { return s.tags }
+
+func (s *specStubResource) getStateMigrations() (specStateMigrations, error) {
+	return s.stateMigrations, nil
+}
+
 func (s *specStubResource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
-		List:   s.resourceListOperation,
-		Post:   s.resourcePostOperation,
-		Get:    s.resourceGetOperation,
-		Put:    s.resourcePutOperation,
-		Delete: s.resourceDeleteOperation,
+		List:    s.resourceListOperation,
+		Post:    s.resourcePostOperation,
+		Get:     s.resourceGetOperation,
+		Put:     s.resourcePutOperation,
+		Patch:   s.resourcePatchOperation,
+		Delete:  s.resourceDeleteOperation,
+		Actions: s.resourceActions,
 	}
 }
 
@@ -85,7 +109,20 @@ func (s *specStubResource) getParentResourceInfo() *parentResourceInfo {
 	if len(s.parentResourceNames) > 0 && s.fullParentResourceName != "" {
 		subRes.parentResourceNames = s.parentResourceNames
 		subRes.fullParentResourceName = s.fullParentResourceName
+		subRes.parentPathParameters = s.parentPathParameters
 		return &subRes
 	}
 	return nil
 }
+
+func (s *specStubResource) getImportLookupProperty() string {
+	return s.importLookupProperty
+}
+
+func (s *specStubResource) getImportIDFormat() string {
+	return s.importIDFormat
+}
+
+func (s *specStubResource) shouldRemoveOnNotFound() bool {
+	return !s.keepOnNotFound
+}
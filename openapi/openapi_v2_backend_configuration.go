@@ -3,7 +3,6 @@ package openapi
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
@@ -12,10 +11,19 @@ import (
 
 const extTfProviderMultiRegionFQDN = "x-terraform-provider-multiregion-fqdn"
 const extTfProviderRegions = "x-terraform-provider-regions"
+const extTfAWSSigV4 = "x-terraform-aws-sigv4"
+const extTfAuthenticator = "x-terraform-authenticator"
+const extTfProviderHealthCheck = "x-terraform-provider-health-check"
+
+// authenticatorAWSV4 is the x-terraform-authenticator extension value that requires all the API requests to be signed
+// with AWS Signature Version 4
+const authenticatorAWSV4 = "awsv4"
 
 type specV2BackendConfiguration struct {
 	openAPIDocumentURL string
 	spec               *spec.Swagger
+	// logger (optional) is used to log the fallbacks applied when the backend configuration is not specified
+	logger Logger
 }
 
 func newOpenAPIBackendConfigurationV2(spec *spec.Swagger, openAPIDocumentURL string) (*specV2BackendConfiguration, error) {
@@ -25,12 +33,12 @@ func newOpenAPIBackendConfigurationV2(spec *spec.Swagger, openAPIDocumentURL str
 	if openAPIDocumentURL == "" {
 		return nil, fmt.Errorf("missing mandatory parameter openAPIDocumentURL")
 	}
-	return &specV2BackendConfiguration{openAPIDocumentURL, spec}, nil
+	return &specV2BackendConfiguration{openAPIDocumentURL: openAPIDocumentURL, spec: spec}, nil
 }
 
 func (o specV2BackendConfiguration) getHost() (string, error) {
 	if o.spec.Host == "" {
-		log.Printf("[WARN] host field not specified in the swagger configuration, falling back to retrieving the host from where the OpenAPI document is served: '%s'", o.openAPIDocumentURL)
+		loggerOrDefault(o.logger).Warn(fmt.Sprintf("host field not specified in the swagger configuration, falling back to retrieving the host from where the OpenAPI document is served: '%s'", o.openAPIDocumentURL))
 		hostFromURL := openapiutils.GetHostFromURL(o.openAPIDocumentURL)
 		if hostFromURL == "" {
 			return "", fmt.Errorf("could not find valid host from URL provided: '%s'", o.openAPIDocumentURL)
@@ -115,6 +123,44 @@ func (o specV2BackendConfiguration) getProviderRegions() ([]string, error) {
 	return regions, nil
 }
 
+// getAWSSigV4Scope returns the region and service used to sign the API requests with AWS SigV4 as configured in the
+// root level x-terraform-aws-sigv4 extension; nil if the extension is not present. If the root level
+// x-terraform-authenticator extension is set to 'awsv4' the scope returned is marked as required, so the requests will
+// be signed even if the user does not configure the aws_sigv4 property
+func (o specV2BackendConfiguration) getAWSSigV4Scope() (*awsSigV4Scope, error) {
+	required := false
+	if authenticator, exists := o.spec.Extensions[extTfAuthenticator]; exists {
+		if authenticator != authenticatorAWSV4 {
+			return nil, fmt.Errorf("'%s' extension value '%v' is not supported, the supported values are: [%s]", extTfAuthenticator, authenticator, authenticatorAWSV4)
+		}
+		required = true
+	}
+	value, exists := o.spec.Extensions[extTfAWSSigV4]
+	if !exists {
+		if required {
+			return &awsSigV4Scope{Required: true}, nil
+		}
+		return nil, nil
+	}
+	scope, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'%s' extension must be an object containing the '%s' and/or '%s' of the API", extTfAWSSigV4, awsSigV4PropertyRegion, awsSigV4PropertyService)
+	}
+	region, regionOk := scope[awsSigV4PropertyRegion].(string)
+	service, serviceOk := scope[awsSigV4PropertyService].(string)
+	if (scope[awsSigV4PropertyRegion] != nil && !regionOk) || (scope[awsSigV4PropertyService] != nil && !serviceOk) {
+		return nil, fmt.Errorf("'%s' extension '%s' and '%s' values must be strings", extTfAWSSigV4, awsSigV4PropertyRegion, awsSigV4PropertyService)
+	}
+	return &awsSigV4Scope{Region: region, Service: service, Required: required}, nil
+}
+
+// getHealthCheckPath returns the path configured in the root level x-terraform-provider-health-check extension; empty if
+// the extension is not present
+func (o specV2BackendConfiguration) getHealthCheckPath() string {
+	path, _ := o.spec.Extensions.GetString(extTfProviderHealthCheck)
+	return path
+}
+
 func (o specV2BackendConfiguration) getBasePath() string {
 	return o.spec.BasePath
 }
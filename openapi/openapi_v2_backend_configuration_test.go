@@ -598,3 +598,103 @@ func TestGetHTTPSchemes(t *testing.T) {
 
 	}
 }
+
+func TestGetAWSSigV4Scope(t *testing.T) {
+	Convey("Given a specV2BackendConfiguration with the x-terraform-aws-sigv4 extension containing the region and service", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAWSSigV4: map[string]interface{}{"region": "us-west-2", "service": "execute-api"}}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			scope, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be nil and the scope should be the expected", func() {
+				So(err, ShouldBeNil)
+				So(scope, ShouldResemble, &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"})
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration without the x-terraform-aws-sigv4 extension", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			scope, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be nil and the scope should be nil", func() {
+				So(err, ShouldBeNil)
+				So(scope, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration with a x-terraform-aws-sigv4 extension which value is not an object", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAWSSigV4: "us-west-2"}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			_, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "'x-terraform-aws-sigv4' extension must be an object containing the 'region' and/or 'service' of the API")
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration with a x-terraform-aws-sigv4 extension which region is not a string", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAWSSigV4: map[string]interface{}{"region": 1}}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			_, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "'x-terraform-aws-sigv4' extension 'region' and 'service' values must be strings")
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration with the x-terraform-authenticator extension set to awsv4 and the x-terraform-aws-sigv4 extension", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAuthenticator: "awsv4", extTfAWSSigV4: map[string]interface{}{"region": "us-west-2", "service": "execute-api"}}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			scope, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be nil and the scope should be required", func() {
+				So(err, ShouldBeNil)
+				So(scope, ShouldResemble, &awsSigV4Scope{Region: "us-west-2", Service: "execute-api", Required: true})
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration with the x-terraform-authenticator extension set to awsv4 only", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAuthenticator: "awsv4"}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			scope, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be nil and the scope should be required without region and service", func() {
+				So(err, ShouldBeNil)
+				So(scope, ShouldResemble, &awsSigV4Scope{Required: true})
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration with the x-terraform-authenticator extension set to a non supported value", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAuthenticator: "hmac"}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getAWSSigV4Scope method is called", func() {
+			_, err := specV2BackendConfiguration.getAWSSigV4Scope()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "'x-terraform-authenticator' extension value 'hmac' is not supported, the supported values are: [awsv4]")
+			})
+		})
+	})
+}
+
+func TestGetHealthCheckPath(t *testing.T) {
+	Convey("Given a specV2BackendConfiguration with the x-terraform-provider-health-check extension", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfProviderHealthCheck: "/me"}}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getHealthCheckPath method is called", func() {
+			path := specV2BackendConfiguration.getHealthCheckPath()
+			Convey("Then the path returned should be the one configured in the extension", func() {
+				So(path, ShouldEqual, "/me")
+			})
+		})
+	})
+	Convey("Given a specV2BackendConfiguration without the x-terraform-provider-health-check extension", t, func() {
+		spec := &spec.Swagger{SwaggerProps: spec.SwaggerProps{Swagger: "2.0"}}
+		specV2BackendConfiguration, _ := newOpenAPIBackendConfigurationV2(spec, "www.domain.com")
+		Convey("When getHealthCheckPath method is called", func() {
+			path := specV2BackendConfiguration.getHealthCheckPath()
+			Convey("Then the path returned should be empty", func() {
+				So(path, ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -6,6 +6,11 @@ import (
 )
 
 const extTfHeader = "x-terraform-header"
+const extTfHeaderMode = "x-terraform-header-mode"
+
+// headerModeResource is the 'x-terraform-header-mode' extension value that makes the header an optional attribute of
+// the resources calling the operation instead of a provider property
+const headerModeResource = "resource"
 
 type parameterGroups [][]spec.Parameter
 
@@ -29,11 +34,14 @@ func getHeaderConfigurationsForParameterGroups(parametersGroup parameterGroups)
 				headers[parameter.Name] = parameter.Name
 				switch parameter.In {
 				case "header":
+					headerParameter := SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required}
 					if preferredName, exists := parameter.Extensions.GetString(extTfHeader); exists {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, TerraformName: preferredName, IsRequired: parameter.Required})
-					} else {
-						headerParameters = append(headerParameters, SpecHeaderParam{Name: parameter.Name, IsRequired: parameter.Required})
+						headerParameter.TerraformName = preferredName
+					}
+					if mode, exists := parameter.Extensions.GetString(extTfHeaderMode); exists && mode == headerModeResource {
+						headerParameter.IsResourceAttribute = true
 					}
+					headerParameters = append(headerParameters, headerParameter)
 				}
 			} else {
 				log.Printf("[DEBUG] found duplicate header '%s' for an operation, ignoring it as it has been registered already", parameter.Name)
@@ -50,14 +58,20 @@ func getPathHeaderParams(path spec.PathItem) SpecHeaderParameters {
 	parametersGroup = appendOperationParametersIfPresent(parametersGroup, path.Post)
 	parametersGroup = appendOperationParametersIfPresent(parametersGroup, path.Get)
 	parametersGroup = appendOperationParametersIfPresent(parametersGroup, path.Put)
+	parametersGroup = appendOperationParametersIfPresent(parametersGroup, path.Patch)
 	parametersGroup = appendOperationParametersIfPresent(parametersGroup, path.Delete)
 	return getHeaderConfigurationsForParameterGroups(parametersGroup)
 }
 
+// getAllHeaderParameters returns the headers configured in the provider, the headers that are resource attributes
+// ('x-terraform-header-mode' extension set to 'resource') are not included
 func getAllHeaderParameters(paths map[string]spec.PathItem) SpecHeaderParameters {
 	specHeaderParameters := SpecHeaderParameters{}
 	for _, path := range paths {
 		for _, headerParam := range getPathHeaderParams(path) {
+			if headerParam.IsResourceAttribute {
+				continue
+			}
 			// The below statement avoids dup headers in the list. Note subsequent encounters with a header type that has
 			// already been registered will be ignored
 			if !specHeaderParameters.specHeaderExists(headerParam) {
@@ -228,6 +228,40 @@ func TestGetAllHeaderParameters(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a swagger doc containing a path with a header type parameter that is a resource attribute", t, func() {
+		paths := map[string]spec.PathItem{
+			"/v1/cdns": {
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Parameters: []spec.Parameter{
+								{
+									VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfHeaderMode: headerModeResource}},
+									ParamProps:       spec.ParamProps{Name: "X-Request-Tier", In: "header"},
+								},
+								{
+									ParamProps: spec.ParamProps{Name: "X-Request-ID", In: "header"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getPathHeaderParams method is called", func() {
+			headerConfigProps := getPathHeaderParams(paths["/v1/cdns"])
+			Convey("Then the header that is a resource attribute should be flagged as such", func() {
+				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Request-Tier", IsResourceAttribute: true})
+				So(headerConfigProps, ShouldContain, SpecHeaderParam{Name: "X-Request-ID"})
+			})
+		})
+		Convey("When getAllHeaderParameters method is called", func() {
+			headerConfigProps := getAllHeaderParameters(paths)
+			Convey("Then the header that is a resource attribute should not be returned as it is not configured in the provider", func() {
+				So(headerConfigProps, ShouldResemble, SpecHeaderParameters{{Name: "X-Request-ID"}})
+			})
+		})
+	})
 	Convey("Given a swagger doc containing paths with header type parameters and same header names", t, func() {
 		spec := &spec.Swagger{
 			SwaggerProps: spec.SwaggerProps{
@@ -2,16 +2,18 @@ package openapi
 
 import (
 	"fmt"
-	"log"
+	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
+	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 	"github.com/go-openapi/spec"
 )
 
-const pathParameterRegex = "/({[\\w]*})*/"
+const pathParameterRegex = "/({[\\w-]*})*/"
 
 // resourceVersionRegexTemplate is used to identify the version attached to the given resource. The parameter in the
 // template will be replaced with the actual resource name so if there is a match the version grabbed is assured to belong
@@ -44,28 +46,75 @@ const resourceNameRegex = "((/[\\w-]*[/]?))+$"
 // matches[1][1]: Group 1. /v2/firewalls
 // matches[1][2]: Group 2. v2
 // matches[1][3]: Group 3. firewalls
-const resourceParentNameRegex = `(\/(?:\w+\/)?(?:v\d+\/)?\w+)\/{\w+}`
+const resourceParentNameRegex = `(\/(?:[\w-]+\/)?(?:v\d+\/)?[\w-]+)\/{[\w-]+}`
 
 const resourceInstanceRegex = "((?:.*)){.*}"
 
 // Definition level extensions
 const extTfImmutable = "x-terraform-immutable"
 const extTfForceNew = "x-terraform-force-new"
+const extTfImmutableForceNew = "x-terraform-immutable-force-new"
 const extTfSensitive = "x-terraform-sensitive"
 const extTfFieldName = "x-terraform-field-name"
 const extTfFieldStatus = "x-terraform-field-status"
 const extTfID = "x-terraform-id"
 const extTfComputed = "x-terraform-computed"
+const extTfForceComputed = "x-terraform-force-computed"
 const extTfComplexObjectType = "x-terraform-complex-object-legacy-config"
+const extNullable = "x-nullable"
+const extTfOmitWhenEmpty = "x-terraform-omit-when-empty"
+const extTfResponseFieldName = "x-terraform-response-field-name"
+const extTfNormalize = "x-terraform-normalize"
+const extTfWriteOnly = "x-terraform-write-only"
+const extTfFileContent = "x-terraform-file-content"
 
 // Operation level extensions
 const extTfResourceTimeout = "x-terraform-resource-timeout"
+const extTfResourceTimeoutCreate = "x-terraform-resource-timeout-create"
+const extTfResourceTimeoutRead = "x-terraform-resource-timeout-read"
+const extTfResourceTimeoutUpdate = "x-terraform-resource-timeout-update"
+const extTfResourceTimeoutDelete = "x-terraform-resource-timeout-delete"
 const extTfResourcePollEnabled = "x-terraform-resource-poll-enabled"
 const extTfResourcePollTargetStatuses = "x-terraform-resource-poll-completed-statuses"
 const extTfResourcePollPendingStatuses = "x-terraform-resource-poll-pending-statuses"
+const extTfResourcePollInterval = "x-terraform-resource-poll-interval"
+const extTfResourcePollMinTimeout = "x-terraform-resource-poll-min-timeout"
+const extTfResourcePollDelay = "x-terraform-resource-poll-delay"
+const extTfErrorFields = "x-terraform-error-fields"
+const extTfErrorFieldKey = "x-terraform-error-field-key"
+const extTfErrorMessageKey = "x-terraform-error-message-key"
 const extTfExcludeResource = "x-terraform-exclude-resource"
 const extTfResourceName = "x-terraform-resource-name"
+const extTfResourceVersionSuffix = "x-terraform-resource-version-suffix"
 const extTfResourceURL = "x-terraform-resource-host"
+const extTfImportLookup = "x-terraform-import-lookup"
+const extTfImportIDFormat = "x-terraform-import-id-format"
+const extTfRemoveOnNotFound = "x-terraform-remove-on-not-found"
+const extTfReadOnlyResource = "x-terraform-read-only-resource"
+const extTfStateMigration = "x-terraform-state-migration"
+const extTfQueryParams = "x-terraform-query-params"
+const extTfResourceLocationHeader = "x-terraform-resource-location-header"
+const extTfRequiredIf = "x-terraform-required-if"
+const extTfResourceRetry = "x-terraform-resource-retry"
+const extTfAsyncOperation = "x-terraform-async-operation"
+const extTfResourceProtocolV6 = "x-terraform-resource-protocol-v6"
+const extTfFilterParam = "x-terraform-filter-param"
+const extTfPagination = "x-terraform-pagination"
+const extTfUpdateStrategy = "x-terraform-update-strategy"
+const extTfResponseWrapperProperty = "x-terraform-response-wrapper-property"
+const extTfRequestWrapperProperty = "x-terraform-request-wrapper-property"
+const extTfOptimisticLocking = "x-terraform-optimistic-locking"
+const extTfConditionalRead = "x-terraform-conditional-read"
+const extTfReadAfterCreateRetries = "x-terraform-read-after-create-retries"
+const extTfResourceDeletePoll = "x-terraform-resource-delete-poll"
+const extTfDeleteBody = "x-terraform-delete-body"
+const extTfPreDeleteOperation = "x-terraform-pre-delete-operation"
+const extTfResourceAction = "x-terraform-resource-action"
+const extTfResponseHeaderProperty = "x-terraform-response-header-property"
+
+// formatPassword is the OpenAPI string format used to describe secret values
+const formatPassword = "password"
+const formatUUID = "uuid"
 
 // SpecV2Resource defines a struct that implements the SpecResource interface and it's based on OpenAPI v2 specification
 type SpecV2Resource struct {
@@ -85,6 +134,10 @@ type SpecV2Resource struct {
 	SchemaDefinitions map[string]spec.Schema
 
 	Paths map[string]spec.PathItem
+
+	// logger (optional) is used to log the messages produced when building the resource from the OpenAPI document (e,g:
+	// extensions ignored since their values are not valid)
+	logger Logger
 }
 
 // newSpecV2Resource creates a SpecV2Resource with no region and default host
@@ -142,6 +195,11 @@ func newSpecV2ResourceWithConfig(region, path string, schemaDefinition spec.Sche
 	return resource, nil
 }
 
+// getLogger returns the logger configured in the resource or the default logger if none was provided
+func (o *SpecV2Resource) getLogger() Logger {
+	return loggerOrDefault(o.logger)
+}
+
 func (o *SpecV2Resource) getResourceName() string {
 	if o.Region != "" {
 		return fmt.Sprintf("%s_%s", o.Name, o.Region)
@@ -157,7 +215,7 @@ func (o *SpecV2Resource) buildResourceName() (string, error) {
 	if preferred := o.getResourceTerraformName(); preferred != "" {
 		preferredName = preferred
 	}
-	fullResourceName, err := o.buildResourceNameFromPath(o.Path, preferredName)
+	fullResourceName, err := o.buildResourceNameFromPath(o.Path, preferredName, o.shouldAppendVersion(o.RootPathItem))
 	if err != nil {
 		return "", err
 	}
@@ -177,7 +235,8 @@ func (o *SpecV2Resource) buildResourceName() (string, error) {
 // /cdns/{id} and preferred name being cdn -> cdn
 // /v1/cdns/{id} -> cdns_v1
 // /v1/cdns/{id} and preferred name being cdn -> cdn_v1
-func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName string) (string, error) {
+// /v1/cdns/{id} and appendVersion being false -> cdns
+func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName string, appendVersion bool) (string, error) {
 	nameRegex, _ := regexp.Compile(resourceNameRegex)
 	var resourceName string
 	matches := nameRegex.FindStringSubmatch(resourcePath)
@@ -195,7 +254,7 @@ func (o *SpecV2Resource) buildResourceNameFromPath(resourcePath, preferredName s
 
 	fullResourceName := resourceName
 	v := versionRegex.FindAllStringSubmatch(resourcePath, -1)
-	if len(v) > 0 {
+	if len(v) > 0 && appendVersion {
 		version := v[0][1]
 		fullResourceName = fmt.Sprintf("%s_%s", resourceName, version)
 	}
@@ -254,14 +313,68 @@ func (o *SpecV2Resource) getHost() (string, error) {
 
 func (o *SpecV2Resource) getResourceOperations() specResourceOperations {
 	return specResourceOperations{
-		List:   o.createResourceOperation(o.RootPathItem.Get),
-		Post:   o.createResourceOperation(o.RootPathItem.Post),
-		Get:    o.createResourceOperation(o.InstancePathItem.Get),
-		Put:    o.createResourceOperation(o.InstancePathItem.Put),
-		Delete: o.createResourceOperation(o.InstancePathItem.Delete),
+		List:    o.createResourceOperation(o.RootPathItem.Get),
+		Post:    o.createResourceOperation(o.RootPathItem.Post),
+		Get:     o.createResourceOperation(o.InstancePathItem.Get),
+		Put:     o.createResourceOperation(o.InstancePathItem.Put),
+		Patch:   o.createResourceOperation(o.InstancePathItem.Patch),
+		Delete:  o.createResourceOperation(o.InstancePathItem.Delete),
+		Actions: o.getResourceActions(),
 	}
 }
 
+// getResourceActions returns the operations of the paths under the resource instance path (e,g: POST
+// /v1/servers/{id}/reboot) containing the 'x-terraform-resource-action' extension. The extension value can be a string
+// (the action name) or an object containing the name and body settings. Actions which value is not valid or with
+// duplicated names are ignored
+func (o *SpecV2Resource) getResourceActions() []*specResourceAction {
+	var paths []string
+	for path := range o.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var actions []*specResourceAction
+	actionNames := map[string]bool{}
+	for _, path := range paths {
+		pathSuffix, err := getInstancePathSuffix(path, o.Path)
+		if err != nil {
+			continue
+		}
+		for _, method := range []httpMethodSupported{httpPost, httpPut, httpPatch} {
+			operation := getPathItemOperation(o.Paths[path], method)
+			if operation == nil {
+				continue
+			}
+			value, exists := operation.Extensions[extTfResourceAction]
+			if !exists {
+				continue
+			}
+			var action *specResourceAction
+			switch v := value.(type) {
+			case string:
+				action, err = newSpecResourceActionFromExtension(map[string]interface{}{"name": v})
+			case map[string]interface{}:
+				action, err = newSpecResourceActionFromExtension(v)
+			default:
+				err = fmt.Errorf("the value is not a string or an object (%v)", value)
+			}
+			if err == nil && actionNames[action.name] {
+				err = fmt.Errorf("action '%s' is already defined", action.name)
+			}
+			if err != nil {
+				o.getLogger().Warn(fmt.Sprintf("ignoring %s extension of %s %s since the value is not valid: %s", extTfResourceAction, method, path, err))
+				continue
+			}
+			action.method = method
+			action.pathSuffix = pathSuffix
+			action.operation = o.createResourceOperation(operation)
+			actionNames[action.name] = true
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
 // shouldIgnoreResource checks whether the POST operation for a given resource as the 'x-terraform-exclude-resource' extension
 // defined with true value. If so, the resource will not be exposed to the OpenAPI Terraform provider; otherwise it will
 // be exposed and users will be able to manage such resource via terraform.
@@ -277,6 +390,12 @@ func (o *SpecV2Resource) shouldIgnoreResource() bool {
 	return false
 }
 
+// isReadOnlyResource returns true if the resource instance path is marked as read only with the 'x-terraform-read-only-resource'
+// extension, meaning that the resource can only be read (e,g: reference data) and not created, updated or deleted
+func (o *SpecV2Resource) isReadOnlyResource() bool {
+	return isReadOnlyResourceInstancePath(o.InstancePathItem)
+}
+
 func (o *SpecV2Resource) getParentResourceInfo() *parentResourceInfo {
 	resourceParentRegex, _ := regexp.Compile(resourceParentNameRegex)
 	parentMatches := resourceParentRegex.FindAllStringSubmatch(o.Path, -1)
@@ -284,7 +403,7 @@ func (o *SpecV2Resource) getParentResourceInfo() *parentResourceInfo {
 		var parentURI string
 		var parentInstanceURI string
 
-		var parentResourceNames, parentURIs, parentInstanceURIs []string
+		var parentResourceNames, parentURIs, parentInstanceURIs, parentPathParameters []string
 		for _, match := range parentMatches {
 			fullMatch := match[0]
 			rootPath := match[1]
@@ -292,45 +411,116 @@ func (o *SpecV2Resource) getParentResourceInfo() *parentResourceInfo {
 			parentInstanceURI = parentInstanceURI + fullMatch
 			parentURIs = append(parentURIs, parentURI)
 			parentInstanceURIs = append(parentInstanceURIs, parentInstanceURI)
+			parentPathParameters = append(parentPathParameters, strings.Trim(fullMatch[len(rootPath)+1:], "{}"))
 		}
 
 		fullParentResourceName := ""
-		preferredParentName := ""
 		for _, parentURI := range parentURIs {
+			preferredParentName := ""
+			appendParentVersion := true
 			// `o.Paths` is used to read the preferred name over that resource if `x-terraform-preferred-name` is set
 			if o.Paths != nil {
 				if parent, ok := o.Paths[parentURI]; ok {
 					preferredParentName = o.getPreferredName(parent)
+					appendParentVersion = o.shouldAppendVersion(parent)
 				} else {
 					// Falling back to checking path with trailing slash
 					if parent, ok := o.Paths[parentURI+"/"]; ok {
 						preferredParentName = o.getPreferredName(parent)
+						appendParentVersion = o.shouldAppendVersion(parent)
 					}
 				}
 			}
-			parentResourceName, err := o.buildResourceNameFromPath(parentURI, preferredParentName)
+			parentResourceName, err := o.buildResourceNameFromPath(parentURI, preferredParentName, appendParentVersion)
 			if err != nil {
-				log.Printf("[ERROR] could not build parent resource info due to the following error: %s", err)
+				o.getLogger().Error(fmt.Sprintf("could not build parent resource info due to the following error: %s", err))
 				return nil //untested
 			}
+			// The parent resource names are the final terraform names of the parents (which are prefixed with the names
+			// of their own parents), so the parent properties always match the names of the parent resources
+			if fullParentResourceName != "" {
+				parentResourceName = fullParentResourceName + "_" + parentResourceName
+			}
 			parentResourceNames = append(parentResourceNames, parentResourceName)
-			fullParentResourceName = fullParentResourceName + parentResourceName + "_"
+			fullParentResourceName = parentResourceName
 		}
-		fullParentResourceName = strings.TrimRight(fullParentResourceName, "_")
 
 		sub := &parentResourceInfo{
 			parentResourceNames:    parentResourceNames,
 			fullParentResourceName: fullParentResourceName,
 			parentURIs:             parentURIs,
 			parentInstanceURIs:     parentInstanceURIs,
+			parentPathParameters:   parentPathParameters,
 		}
 		return sub
 	}
 	return nil
 }
 
+// schemaDefinitionErrors contains all the errors found when processing the properties of a schema definition
+type schemaDefinitionErrors []error
+
+// Error returns the error message when there is just one error; otherwise all the error messages are listed
+func (e schemaDefinitionErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = fmt.Sprintf("%d) %s", i+1, err)
+	}
+	return fmt.Sprintf("found %d issues: %s", len(e), strings.Join(messages, "; "))
+}
+
 func (o *SpecV2Resource) getResourceSchema() (*specSchemaDefinition, error) {
-	return o.getSchemaDefinitionWithOptions(&o.SchemaDefinition, true)
+	schemaDefinition, err := o.getSchemaDefinitionWithOptions(&o.SchemaDefinition, true)
+	if err != nil {
+		return nil, err
+	}
+	if o.isReadOnlyResource() {
+		// Read only resources can not be created nor updated so all the properties are computed except for the parent
+		// properties which are needed to read the resource and therefore force a new resource when changed
+		for _, property := range schemaDefinition.Properties {
+			if property.IsParentProperty {
+				property.ForceNew = true
+				continue
+			}
+			property.ReadOnly = true
+			property.Computed = true
+			property.Required = false
+		}
+	}
+	if o.isImmutableForceNewResource() {
+		// Changes to the immutable properties re-create the resource instead of failing the update
+		schemaDefinition.forceNewImmutableProperties()
+	}
+	if !schemaDefinition.containsIdentifier() {
+		if identifier, _ := getResourceIdentifierByConvention(o.getIdentifierResourceNames(), &o.SchemaDefinition, o.getLogger()); identifier != "" {
+			if property, err := schemaDefinition.getProperty(identifier); err == nil {
+				property.IsIdentifier = true
+			}
+		}
+	}
+	return schemaDefinition, nil
+}
+
+// getIdentifierResourceNames returns the names of the resource used to look up the resource identifier by convention:
+// the last segment of the resource root path and the preferred name (x-terraform-resource-name) if any, along with their
+// singular forms (e,g: cdns and cdn for the /v1/cdns resource)
+func (o *SpecV2Resource) getIdentifierResourceNames() []string {
+	var names []string
+	pathSegments := strings.Split(strings.Trim(o.Path, "/"), "/")
+	for _, name := range []string{pathSegments[len(pathSegments)-1], o.getResourceTerraformName()} {
+		if name == "" {
+			continue
+		}
+		name = terraformutils.ConvertToTerraformCompliantName(name)
+		names = append(names, name)
+		if singularName := strings.TrimSuffix(name, "s"); singularName != name && singularName != "" {
+			names = append(names, singularName)
+		}
+	}
+	return names
 }
 
 func (o *SpecV2Resource) getSchemaDefinition(schema *spec.Schema) (*specSchemaDefinition, error) {
@@ -341,18 +531,37 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 	if schema == nil {
 		return nil, fmt.Errorf("schema argument must not be nil")
 	}
-	schemaDefinition := &specSchemaDefinition{}
+	if o.isDiscriminatedUnion(*schema) {
+		return o.getDiscriminatedUnionSchemaDefinition(*schema)
+	}
+	resolvedSchema, err := o.resolveAllOf(*schema)
+	if err != nil {
+		return nil, err
+	}
+	schema = &resolvedSchema
+	schemaDefinition := &specSchemaDefinition{logger: o.logger}
 	schemaDefinition.Properties = specSchemaDefinitionProperties{}
 
 	// This map ensures no duplicates will happen if the schema happens to have a parent id property. if so, it will be overridden with the expected parent property configuration (e,g: making the prop required)
 	schemaProps := map[string]*specSchemaDefinitionProperty{}
-	for propertyName, property := range schema.Properties {
-		schemaDefinitionProperty, err := o.createSchemaDefinitionProperty(propertyName, property, schema.Required)
+	// All the properties are processed (in order) even if some of them fail so all the issues are reported at once
+	var errs schemaDefinitionErrors
+	propertyNames := make([]string, 0, len(schema.Properties))
+	for propertyName := range schema.Properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		schemaDefinitionProperty, err := o.createSchemaDefinitionProperty(propertyName, schema.Properties[propertyName], schema.Required)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
+			continue
 		}
 		schemaProps[propertyName] = schemaDefinitionProperty
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 	if addParentProps {
 		parentResourceInfo := o.getParentResourceInfo()
 		if parentResourceInfo != nil {
@@ -374,23 +583,73 @@ func (o *SpecV2Resource) getSchemaDefinitionWithOptions(schema *spec.Schema, add
 func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, property spec.Schema, requiredProperties []string) (*specSchemaDefinitionProperty, error) {
 	schemaDefinitionProperty := &specSchemaDefinitionProperty{}
 
-	if isObject, schemaDefinition, err := o.isObjectProperty(property); isObject || err != nil {
+	// Schemas composed with allOf are merged into a single object schema, same goes for the array items
+	property, err := o.resolveAllOf(property)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process property '%s': %s", propertyName, err)
+	}
+	if property.Items != nil && property.Items.Schema != nil {
+		itemsSchema, err := o.resolveAllOf(*property.Items.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process array type property '%s' items: %s", propertyName, err)
+		}
+		property.Items = &spec.SchemaOrArray{Schema: &itemsSchema}
+	}
+	if property.AdditionalProperties != nil && property.AdditionalProperties.Schema != nil {
+		valuesSchema, err := o.resolveAllOf(*property.AdditionalProperties.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process map type property '%s' values: %s", propertyName, err)
+		}
+		property.AdditionalProperties = &spec.SchemaOrBool{Allows: property.AdditionalProperties.Allows, Schema: &valuesSchema}
+	}
+
+	if err := o.validateSupportedSchemaConstructs(property); err != nil {
+		return nil, fmt.Errorf("failed to process property '%s': %s", propertyName, err)
+	}
+
+	if isMap, valuesType, err := o.isMapProperty(property); isMap || err != nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to process map type property '%s': %s", propertyName, err)
+		}
+		schemaDefinitionProperty.AdditionalPropertiesType = valuesType
+		if valuesType == typeObject {
+			_, valuesSchema, err := o.isObjectProperty(*property.AdditionalProperties.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process map type property '%s': %s", propertyName, err)
+			}
+			valuesSchemaDefinition, err := o.getSchemaDefinition(valuesSchema)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process map type property '%s' values: %s", propertyName, err)
+			}
+			schemaDefinitionProperty.SpecSchemaDefinition = valuesSchemaDefinition
+		}
+		o.getLogger().Debug(fmt.Sprintf("found map type property '%s' with values of type '%s'", propertyName, valuesType))
+	} else if isObject, schemaDefinition, err := o.isObjectProperty(property); isObject || err != nil {
 		if err != nil {
 			return nil, fmt.Errorf("failed to process object type property '%s': %s", propertyName, err)
 		}
 		objectSchemaDefinition, err := o.getSchemaDefinition(schemaDefinition)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to process object type property '%s': %s", propertyName, err)
 		}
 		schemaDefinitionProperty.SpecSchemaDefinition = objectSchemaDefinition
-		log.Printf("[DEBUG] found object type property '%s'", propertyName)
+		// Objects with fixed properties may also allow additional keys, in which case the values for those keys will be
+		// handled using the type specified in the additionalProperties schema
+		if o.allowsAdditionalProperties(*schemaDefinition) {
+			valuesType, err := o.getAdditionalPropertiesType(*schemaDefinition.AdditionalProperties)
+			if err != nil {
+				return nil, fmt.Errorf("failed to process object type property '%s' additionalProperties: %s", propertyName, err)
+			}
+			schemaDefinitionProperty.AdditionalPropertiesType = valuesType
+		}
+		o.getLogger().Debug(fmt.Sprintf("found object type property '%s'", propertyName))
 	} else if isArray, itemsType, itemsSchema, err := o.isArrayProperty(property); isArray || err != nil {
 		if err != nil {
 			return nil, fmt.Errorf("failed to process array type property '%s': %s", propertyName, err)
 		}
 		schemaDefinitionProperty.ArrayItemsType = itemsType
 		schemaDefinitionProperty.SpecSchemaDefinition = itemsSchema // only diff than nil if type is object
-		log.Printf("[DEBUG] found array type property '%s' with items of type '%s'", propertyName, itemsType)
+		o.getLogger().Debug(fmt.Sprintf("found array type property '%s' with items of type '%s'", propertyName, itemsType))
 	}
 
 	propertyType, err := o.getPropertyType(property)
@@ -405,6 +664,12 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.PreferredName = preferredPropertyName
 	}
 
+	// The response field name allows the API to return the value of the property in a different field than the one
+	// used in the requests (e,g: request 'password', response 'password_hash')
+	if responseFieldName, exists := property.Extensions.GetString(extTfResponseFieldName); exists {
+		schemaDefinitionProperty.ResponseFieldName = responseFieldName
+	}
+
 	// Set the property as required (if not required the property will be considered optional)
 	required := o.isRequired(propertyName, requiredProperties)
 	if required {
@@ -432,6 +697,67 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 	// schemaDefinitionProperty.ReadOnly is set to true if the property is explicitly readOnly OR if it's not readOnly but still considered optional computed
 	schemaDefinitionProperty.ReadOnly = property.ReadOnly
 
+	// A force computed property is writable as per the spec but its value is always set by the API, hence it is treated
+	// as a readOnly property: the property is computed and it is never sent in the request payloads
+	if o.isBoolExtensionEnabled(property.Extensions, extTfForceComputed) {
+		if required {
+			return nil, fmt.Errorf("failed to process property '%s': a required property cannot be marked with the %s extension", propertyName, extTfForceComputed)
+		}
+		schemaDefinitionProperty.ReadOnly = true
+		schemaDefinitionProperty.Computed = true
+	}
+
+	// A write-only property is sent in the requests but it is never returned by the API (e,g: passwords), hence the value
+	// configured by the user is kept in the state instead of the one (if any) returned by the API
+	if o.isBoolExtensionEnabled(property.Extensions, extTfWriteOnly) {
+		if schemaDefinitionProperty.ReadOnly {
+			return nil, fmt.Errorf("failed to process property '%s': a readOnly property cannot be marked with the %s extension", propertyName, extTfWriteOnly)
+		}
+		schemaDefinitionProperty.WriteOnly = true
+	}
+
+	// A file content property holds the path or the base64 encoded content of a file sent as a file part in the
+	// multipart/form-data requests (e,g: certificate uploads). The API does not return the file, hence the property is
+	// write-only
+	if fileContent, exists := property.Extensions.GetString(extTfFileContent); exists {
+		if fileContent != fileContentPath && fileContent != fileContentBase64 {
+			return nil, fmt.Errorf("failed to process property '%s': the %s extension value must be one of %s or %s", propertyName, extTfFileContent, fileContentPath, fileContentBase64)
+		}
+		if propertyType != typeString || schemaDefinitionProperty.ReadOnly {
+			return nil, fmt.Errorf("failed to process property '%s': the %s extension is only supported in string properties that are not readOnly", propertyName, extTfFileContent)
+		}
+		schemaDefinitionProperty.FileContent = fileContent
+		schemaDefinitionProperty.WriteOnly = true
+	}
+
+	// A conditionally required property is only required when other properties of the resource have specific values
+	// (e,g: 'bucket' is required when 'storage_type' is 's3'), the conditions are enforced at plan time
+	requiredIf, err := o.getRequiredIfConditions(property)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process property '%s': %s", propertyName, err)
+	}
+	if requiredIf != nil && (required || property.ReadOnly) {
+		return nil, fmt.Errorf("failed to process property '%s': the %s extension is only supported in optional properties", propertyName, extTfRequiredIf)
+	}
+	schemaDefinitionProperty.RequiredIf = requiredIf
+
+	// The normalizations applied by the API to the value of the property (e,g: lowercase), used to suppress the diffs
+	// between the values provided by the user and the normalized values returned by the API
+	normalizations, err := o.getNormalizations(property)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process property '%s': %s", propertyName, err)
+	}
+	if normalizations != nil && propertyType != typeString {
+		return nil, fmt.Errorf("failed to process property '%s': the %s extension is only supported in string properties", propertyName, extTfNormalize)
+	}
+	schemaDefinitionProperty.Normalizations = normalizations
+
+	// The constraints of the values (e,g: enum, pattern, minimum) are validated at plan time instead of waiting for the API
+	// to reject the requests
+	if schemaDefinitionProperty.isPrimitiveProperty() {
+		schemaDefinitionProperty.Constraints = o.getConstraints(propertyName, property)
+	}
+
 	// If the value of the property is changed, it will force the deletion of the previous generated resource and
 	// a new resource with this new value will be created
 	if o.isBoolExtensionEnabled(property.Extensions, extTfForceNew) {
@@ -439,8 +765,8 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 	}
 
 	// A sensitive property means that the value will not be disclosed in the state file, preventing secrets from
-	// being leaked
-	if o.isBoolExtensionEnabled(property.Extensions, extTfSensitive) {
+	// being leaked. Properties with format password are considered sensitive too
+	if o.isBoolExtensionEnabled(property.Extensions, extTfSensitive) || property.Format == formatPassword {
 		schemaDefinitionProperty.Sensitive = true
 	}
 
@@ -462,14 +788,69 @@ func (o *SpecV2Resource) createSchemaDefinitionProperty(propertyName string, pro
 		schemaDefinitionProperty.EnableLegacyComplexObjectBlockConfiguration = true
 	}
 
+	// A nullable property accepts null as a value, which enables the user to explicitly send null (e,g: to clear the
+	// field in the API) as opposed to omitting the property from the payload
+	if o.isNullable(property) {
+		schemaDefinitionProperty.Nullable = true
+	}
+
+	// Optional properties with an empty value (zero value, empty string, empty list or empty object) are omitted from the
+	// request payloads, this is needed for APIs that interpret empty values as the user setting the field to empty
+	if o.isBoolExtensionEnabled(property.Extensions, extTfOmitWhenEmpty) {
+		schemaDefinitionProperty.OmitWhenEmpty = true
+	}
+
 	// Use the default keyword in the parameter schema to specify the default value for an optional parameter. The default
 	// value is the one that the server uses if the client does not supply the parameter value in the request.
 	// Link: https://swagger.io/docs/specification/describing-parameters#default
-	schemaDefinitionProperty.Default = property.Default
+	schemaDefinitionProperty.Default = o.getDefaultValue(propertyName, schemaDefinitionProperty.Type, property.Default)
 
 	return schemaDefinitionProperty, nil
 }
 
+// getDefaultValue returns the default value of the property converted to the type expected by terraform for the property
+// type (e,g: JSON numbers are parsed as float64 whereas terraform int properties expect int values). Default values not
+// matching the property type are ignored, in which case the property has no default value
+func (o *SpecV2Resource) getDefaultValue(propertyName string, propertyType schemaDefinitionPropertyType, defaultValue interface{}) interface{} {
+	if defaultValue == nil {
+		return nil
+	}
+	switch propertyType {
+	case typeString:
+		if value, ok := defaultValue.(string); ok {
+			return value
+		}
+	case typeBool:
+		if value, ok := defaultValue.(bool); ok {
+			return value
+		}
+	case typeInt:
+		switch value := defaultValue.(type) {
+		case int:
+			return value
+		case int64:
+			return int(value)
+		case float64:
+			if value == float64(int(value)) {
+				return int(value)
+			}
+		}
+	case typeFloat:
+		switch value := defaultValue.(type) {
+		case float64:
+			return value
+		case int:
+			return float64(value)
+		case int64:
+			return float64(value)
+		}
+	default:
+		return defaultValue
+	}
+	o.getLogger().Warn(fmt.Sprintf("ignoring default value '%v' of property '%s' as it is not of type '%s'", defaultValue, propertyName, propertyType), "property", propertyName)
+	return nil
+}
+
 func (o *SpecV2Resource) isBoolExtensionEnabled(extensions spec.Extensions, extension string) bool {
 	if extensions != nil {
 		if enabled, ok := extensions.GetBool(extension); ok && enabled {
@@ -479,6 +860,87 @@ func (o *SpecV2Resource) isBoolExtensionEnabled(extensions spec.Extensions, exte
 	return false
 }
 
+// getRequiredIfConditions returns the conditions configured in the x-terraform-required-if extension, nil if the
+// extension is not present. The extension value must be an object mapping property names to primitive values
+func (o *SpecV2Resource) getRequiredIfConditions(property spec.Schema) (map[string]interface{}, error) {
+	value, exists := property.Extensions[extTfRequiredIf]
+	if !exists {
+		return nil, nil
+	}
+	conditions, ok := value.(map[string]interface{})
+	if !ok || len(conditions) == 0 {
+		return nil, fmt.Errorf("%s extension must be an object containing the property names and the values that make the property required", extTfRequiredIf)
+	}
+	for conditionPropertyName, conditionValue := range conditions {
+		switch conditionValue.(type) {
+		case string, bool, int, float64:
+		default:
+			return nil, fmt.Errorf("%s extension value for property '%s' must be a string, number or boolean", extTfRequiredIf, conditionPropertyName)
+		}
+	}
+	return conditions, nil
+}
+
+// getNormalizations returns the normalizations configured in the x-terraform-normalize extension, nil if the extension
+// is not present. The extension value must be a list containing any of the supported normalizations
+func (o *SpecV2Resource) getNormalizations(property spec.Schema) ([]string, error) {
+	value, exists := property.Extensions[extTfNormalize]
+	if !exists {
+		return nil, nil
+	}
+	values, ok := value.([]interface{})
+	if !ok || len(values) == 0 {
+		return nil, fmt.Errorf("%s extension must be a list containing any of the following normalizations: %s", extTfNormalize, strings.Join(supportedNormalizations, ", "))
+	}
+	var normalizations []string
+	for _, v := range values {
+		normalization, _ := v.(string)
+		if _, supported := normalizers[normalization]; !supported {
+			return nil, fmt.Errorf("%s extension contains a non supported normalization '%v'", extTfNormalize, v)
+		}
+		normalizations = append(normalizations, normalization)
+	}
+	return normalizations, nil
+}
+
+// getConstraints returns the constraints documented in the property schema, or nil if the schema does not define any. Patterns
+// that are not supported by golang regular expressions are ignored
+func (o *SpecV2Resource) getConstraints(propertyName string, property spec.Schema) *specSchemaDefinitionPropertyConstraints {
+	constraints := &specSchemaDefinitionPropertyConstraints{
+		Enum:             property.Enum,
+		Minimum:          property.Minimum,
+		Maximum:          property.Maximum,
+		ExclusiveMinimum: property.ExclusiveMinimum,
+		ExclusiveMaximum: property.ExclusiveMaximum,
+		MinLength:        property.MinLength,
+		MaxLength:        property.MaxLength,
+	}
+	if property.Pattern != "" {
+		pattern, err := regexp.Compile(property.Pattern)
+		if err != nil {
+			o.getLogger().Warn(fmt.Sprintf("ignoring pattern '%s' of property '%s' as it is not a supported regular expression: %s", property.Pattern, propertyName, err))
+		} else {
+			constraints.Pattern = pattern
+		}
+	}
+	if len(constraints.Enum) == 0 && constraints.Pattern == nil && constraints.Minimum == nil && constraints.Maximum == nil && constraints.MinLength == nil && constraints.MaxLength == nil {
+		return nil
+	}
+	return constraints
+}
+
+// isNullable returns true if the property is marked as nullable either using the 'x-nullable' extension (OpenAPI 2.0)
+// or the 'nullable' attribute (OpenAPI 3.0)
+func (o *SpecV2Resource) isNullable(property spec.Schema) bool {
+	if o.isBoolExtensionEnabled(property.Extensions, extNullable) {
+		return true
+	}
+	if nullable, ok := property.ExtraProps["nullable"].(bool); ok && nullable {
+		return true
+	}
+	return false
+}
+
 func (o *SpecV2Resource) isOptionalComputedProperty(propertyName string, property spec.Schema, requiredProperties []string) (bool, error) {
 	required := o.isRequired(propertyName, requiredProperties)
 	if required {
@@ -553,6 +1015,9 @@ func (o *SpecV2Resource) validateArrayItems(property spec.Schema) (schemaDefinit
 	if o.isArrayTypeProperty(*property.Items.Schema) {
 		return "", fmt.Errorf("array property can not have items of type 'array'")
 	}
+	if err := o.validateSupportedSchemaConstructs(*property.Items.Schema); err != nil {
+		return "", fmt.Errorf("array items schema not supported: %s", err)
+	}
 	itemsType, err := o.getPropertyType(*property.Items.Schema)
 	if err != nil {
 		return "", err
@@ -563,9 +1028,33 @@ func (o *SpecV2Resource) validateArrayItems(property spec.Schema) (schemaDefinit
 	return itemsType, nil
 }
 
+// validateSupportedSchemaConstructs checks that the property schema does not make use of schema composition keywords
+// which can not be translated into a terraform schema. Schemas composed with allOf are expected to be resolved already
+// and oneOf/anyOf are only supported along with a discriminator
+func (o *SpecV2Resource) validateSupportedSchemaConstructs(property spec.Schema) error {
+	var construct string
+	switch {
+	case o.isDiscriminatedUnion(property):
+		return nil
+	case len(property.AllOf) > 0:
+		construct = "allOf"
+	case len(property.OneOf) > 0:
+		construct = "oneOf"
+	case len(property.AnyOf) > 0:
+		construct = "anyOf"
+	case property.Not != nil:
+		construct = "not"
+	default:
+		return nil
+	}
+	return fmt.Errorf("the '%s' construct is not supported, please define the property with an explicit type instead (e,g: 'type: object' with 'properties')", construct)
+}
+
 func (o *SpecV2Resource) getPropertyType(property spec.Schema) (schemaDefinitionPropertyType, error) {
 	if o.isArrayTypeProperty(property) {
 		return typeList, nil
+	} else if isMap, _, err := o.isMapProperty(property); isMap || err != nil {
+		return typeMap, err
 	} else if isObject, _, err := o.isObjectProperty(property); isObject || err != nil {
 		return typeObject, err
 	} else if property.Type.Contains("string") {
@@ -581,6 +1070,10 @@ func (o *SpecV2Resource) getPropertyType(property spec.Schema) (schemaDefinition
 }
 
 func (o *SpecV2Resource) isObjectProperty(property spec.Schema) (bool, *spec.Schema, error) {
+	// Case of discriminated union, the variants are represented as nested objects
+	if o.isDiscriminatedUnion(property) {
+		return true, &property, nil
+	}
 	if o.isObjectTypeProperty(property) || property.Ref.Ref.GetURL() != nil {
 		// Case of nested object schema
 		if len(property.Properties) != 0 {
@@ -599,6 +1092,56 @@ func (o *SpecV2Resource) isObjectProperty(property spec.Schema) (bool, *spec.Sch
 	return false, nil, nil
 }
 
+// isMapProperty returns true if the property is a free-form object which does not define fixed properties but does
+// specify additionalProperties. Example:
+//
+// metadata:
+//  type: "object"
+//  additionalProperties:
+//    type: "string"
+func (o *SpecV2Resource) isMapProperty(property spec.Schema) (bool, schemaDefinitionPropertyType, error) {
+	if len(property.Properties) != 0 || property.Ref.Ref.GetURL() != nil {
+		return false, "", nil
+	}
+	if len(property.Type) != 0 && !o.isObjectTypeProperty(property) {
+		return false, "", nil
+	}
+	if !o.allowsAdditionalProperties(property) {
+		return false, "", nil
+	}
+	// Case of map of objects, where the additionalProperties schema describes the object values
+	if valuesSchema := property.AdditionalProperties.Schema; valuesSchema != nil {
+		if valuesType, err := o.getPropertyType(*valuesSchema); err == nil && valuesType == typeObject {
+			return true, typeObject, nil
+		}
+	}
+	valuesType, err := o.getAdditionalPropertiesType(*property.AdditionalProperties)
+	if err != nil {
+		return true, "", err
+	}
+	return true, valuesType, nil
+}
+
+func (o *SpecV2Resource) allowsAdditionalProperties(property spec.Schema) bool {
+	return property.AdditionalProperties != nil && (property.AdditionalProperties.Allows || property.AdditionalProperties.Schema != nil)
+}
+
+// getAdditionalPropertiesType returns the type of the values allowed by additionalProperties. If additionalProperties
+// is set to true without a schema the values are considered strings.
+func (o *SpecV2Resource) getAdditionalPropertiesType(additionalProperties spec.SchemaOrBool) (schemaDefinitionPropertyType, error) {
+	if additionalProperties.Schema == nil {
+		return typeString, nil
+	}
+	valuesType, err := o.getPropertyType(*additionalProperties.Schema)
+	if err != nil {
+		return "", err
+	}
+	if !o.isArrayItemPrimitiveType(valuesType) {
+		return "", fmt.Errorf("additionalProperties type '%s' not supported, only primitive and object types are supported", valuesType)
+	}
+	return valuesType, nil
+}
+
 func (o *SpecV2Resource) isArrayProperty(property spec.Schema) (bool, schemaDefinitionPropertyType, *specSchemaDefinition, error) {
 	if o.isArrayTypeProperty(property) {
 		itemsType, err := o.validateArrayItems(property)
@@ -657,6 +1200,166 @@ func (o *SpecV2Resource) getPreferredName(path spec.PathItem) string {
 	return preferredName
 }
 
+// shouldAppendVersion returns false if the path (or its POST operation) is configured with the 'x-terraform-resource-version-suffix'
+// extension set to false, in which case the version of the path (e,g: v1) is not appended to the resource name
+func (o *SpecV2Resource) shouldAppendVersion(path spec.PathItem) bool {
+	if versionSuffix, exists := path.Extensions.GetBool(extTfResourceVersionSuffix); exists {
+		return versionSuffix
+	}
+	if path.Post != nil {
+		if versionSuffix, exists := path.Post.Extensions.GetBool(extTfResourceVersionSuffix); exists {
+			return versionSuffix
+		}
+	}
+	return true
+}
+
+// getImportLookupProperty returns the value of the 'x-terraform-import-lookup' extension which can be defined either
+// in the resource root path or in the root path POST operation
+func (o *SpecV2Resource) getImportLookupProperty() string {
+	importLookupProperty := o.getExtensionStringValue(o.RootPathItem.Extensions, extTfImportLookup)
+	if importLookupProperty == "" && o.RootPathItem.Post != nil {
+		importLookupProperty = o.getExtensionStringValue(o.RootPathItem.Post.Extensions, extTfImportLookup)
+	}
+	return importLookupProperty
+}
+
+// getImportIDFormat returns the value of the 'x-terraform-import-id-format' extension which can be defined either in the
+// resource root path or in the root path POST operation
+func (o *SpecV2Resource) getImportIDFormat() string {
+	importIDFormat := o.getExtensionStringValue(o.RootPathItem.Extensions, extTfImportIDFormat)
+	if importIDFormat == "" && o.RootPathItem.Post != nil {
+		importIDFormat = o.getExtensionStringValue(o.RootPathItem.Post.Extensions, extTfImportIDFormat)
+	}
+	return importIDFormat
+}
+
+// shouldRemoveOnNotFound returns false if the 'x-terraform-remove-on-not-found' extension is disabled either in the
+// resource root path or in the instance path GET operation; true otherwise
+func (o *SpecV2Resource) shouldRemoveOnNotFound() bool {
+	if enabled, ok := o.RootPathItem.Extensions.GetBool(extTfRemoveOnNotFound); ok && !enabled {
+		return false
+	}
+	if o.InstancePathItem.Get != nil {
+		if enabled, ok := o.InstancePathItem.Get.Extensions.GetBool(extTfRemoveOnNotFound); ok && !enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// isImmutableForceNewResource returns true if the 'x-terraform-immutable-force-new' extension is enabled either in the
+// resource root path or in the root path POST operation
+func (o *SpecV2Resource) isImmutableForceNewResource() bool {
+	if o.isBoolExtensionEnabled(o.RootPathItem.Extensions, extTfImmutableForceNew) {
+		return true
+	}
+	return o.RootPathItem.Post != nil && o.isBoolExtensionEnabled(o.RootPathItem.Post.Extensions, extTfImmutableForceNew)
+}
+
+// isProtocolV6Resource returns true if the 'x-terraform-resource-protocol-v6' extension is enabled either in the resource
+// root path or in the root path POST operation
+func (o *SpecV2Resource) isProtocolV6Resource() bool {
+	if o.isBoolExtensionEnabled(o.RootPathItem.Extensions, extTfResourceProtocolV6) {
+		return true
+	}
+	return o.RootPathItem.Post != nil && o.isBoolExtensionEnabled(o.RootPathItem.Post.Extensions, extTfResourceProtocolV6)
+}
+
+// getResourceTags returns the tags of the root path POST operation
+func (o *SpecV2Resource) getResourceTags() []string {
+	if o.RootPathItem.Post == nil {
+		return nil
+	}
+	return o.RootPathItem.Post.Tags
+}
+
+// getStateMigrations returns the state migrations declared in the 'x-terraform-state-migration' extension of the root
+// path POST operation. The extension value must be a list where each item describes the changes from a schema version
+// to the next one (the first item migrates the state from version 0 to 1 and so forth) containing the property renames
+// (previous name: new name) and/or the type changes (property name: new type). For instance:
+//
+//	x-terraform-state-migration:
+//	- renames:
+//	    label: name
+//	  type_changes:
+//	    port: integer
+func (o *SpecV2Resource) getStateMigrations() (specStateMigrations, error) {
+	if o.RootPathItem.Post == nil {
+		return nil, nil
+	}
+	value, exists := o.RootPathItem.Post.Extensions[extTfStateMigration]
+	if !exists {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s extension value must be a list of migrations", extTfStateMigration)
+	}
+	var migrations specStateMigrations
+	for version, item := range items {
+		migration, err := o.createStateMigration(item)
+		if err != nil {
+			return nil, fmt.Errorf("%s extension migration from version %d to %d is not valid: %s", extTfStateMigration, version, version+1, err)
+		}
+		migrations = append(migrations, *migration)
+	}
+	return migrations, nil
+}
+
+func (o *SpecV2Resource) createStateMigration(item interface{}) (*specStateMigration, error) {
+	migrationItem, ok := item.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("migration must be an object containing renames and/or type_changes")
+	}
+	renames, err := o.getStateMigrationNames(migrationItem, "renames")
+	if err != nil {
+		return nil, err
+	}
+	typeChanges, err := o.getStateMigrationNames(migrationItem, "type_changes")
+	if err != nil {
+		return nil, err
+	}
+	migration := &specStateMigration{
+		renames:     renames,
+		typeChanges: map[string]schemaDefinitionPropertyType{},
+	}
+	for propertyName, propertyType := range typeChanges {
+		switch schemaDefinitionPropertyType(propertyType) {
+		case typeString, typeInt, typeFloat, typeBool:
+			migration.typeChanges[propertyName] = schemaDefinitionPropertyType(propertyType)
+		default:
+			return nil, fmt.Errorf("property '%s' type '%s' is not supported, supported types are: %s, %s, %s and %s", propertyName, propertyType, typeString, typeInt, typeFloat, typeBool)
+		}
+	}
+	return migration, nil
+}
+
+// getStateMigrationNames returns the object found in the given key of the migration item. The keys of the object are
+// converted to terraform compliant names and so are the values of the renames
+func (o *SpecV2Resource) getStateMigrationNames(migrationItem map[string]interface{}, key string) (map[string]string, error) {
+	names := map[string]string{}
+	value, exists := migrationItem[key]
+	if !exists {
+		return names, nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object", key)
+	}
+	for name, v := range object {
+		stringValue, ok := v.(string)
+		if !ok || stringValue == "" {
+			return nil, fmt.Errorf("%s '%s' value must be a non empty string", key, name)
+		}
+		if key == "renames" {
+			stringValue = terraformutils.ConvertToTerraformCompliantName(stringValue)
+		}
+		names[terraformutils.ConvertToTerraformCompliantName(name)] = stringValue
+	}
+	return names, nil
+}
+
 func (o *SpecV2Resource) getExtensionStringValue(extensions spec.Extensions, key string) string {
 	if value, exists := extensions.GetString(key); exists && value != "" {
 		return value
@@ -670,13 +1373,474 @@ func (o *SpecV2Resource) createResourceOperation(operation *spec.Operation) *spe
 	}
 	headerParameters := getHeaderConfigurations(operation.Parameters)
 	securitySchemes := createSecuritySchemes(operation.Security)
+	var alternativeSecuritySchemes []SpecSecuritySchemes
+	if securityRequirements := createSecurityRequirements(operation.Security); len(securityRequirements) > 1 {
+		alternativeSecuritySchemes = securityRequirements[1:]
+	}
 	return &specResourceOperation{
-		HeaderParameters: headerParameters,
-		SecuritySchemes:  securitySchemes,
-		responses:        o.createResponses(operation),
+		HeaderParameters:           headerParameters,
+		SecuritySchemes:            securitySchemes,
+		alternativeSecuritySchemes: alternativeSecuritySchemes,
+		responses:                  o.createResponses(operation),
+		queryParameters:            o.getQueryParameters(operation),
+		collectionFormats:          o.getCollectionFormats(operation),
+		filterParameters:           o.getFilterParameters(operation),
+		locationHeader:             o.getLocationHeader(operation),
+		retry:                      o.getRetryConfiguration(operation),
+		pagination:                 o.getPagination(operation),
+		updateStrategy:             o.getUpdateStrategy(operation),
+		responseWrapperProperty:    o.getResponseWrapperProperty(operation),
+		requestWrapperProperty:     o.getRequestWrapperProperty(operation),
+		optimisticLocking:          o.getOptimisticLocking(operation),
+		conditionalRead:            o.isBoolExtensionEnabled(operation.Extensions, extTfConditionalRead),
+		readAfterCreateRetries:     o.getReadAfterCreateRetries(operation),
+		deletePoll:                 o.getDeletePoll(operation),
+		deleteBodyProperties:       o.getBodyParameterProperties(operation),
+		deleteBodyTemplate:         o.getDeleteBodyTemplate(operation),
+		preDeleteOperation:         o.getPreDeleteOperation(operation),
+		requestMediaType:           o.getRequestMediaType(operation),
+		responseMediaType:          o.getResponseMediaType(operation),
+		xmlSchema:                  o.getXMLSchema(operation),
+		responseHeaderProperties:   o.getResponseHeaderProperties(operation),
 	}
 }
 
+// getRequestMediaType returns the media type the request body of the operation must be sent as when the operation does
+// not consume application/json (which is preferred when supported), in order of preference: application/xml, text/xml,
+// multipart/form-data or application/x-www-form-urlencoded. Empty is returned if the request body is sent as JSON
+func (o *SpecV2Resource) getRequestMediaType(operation *spec.Operation) string {
+	return selectMediaType(operation.Consumes, mediaTypeXML, mediaTypeTextXML, mediaTypeMultipartFormData, mediaTypeFormURLEncoded)
+}
+
+// getResponseMediaType returns the media type of the response body of the operation when the operation does not produce
+// application/json (which is preferred when supported), in order of preference: application/xml, text/xml or
+// application/octet-stream. Empty is returned if the response body is JSON
+func (o *SpecV2Resource) getResponseMediaType(operation *spec.Operation) string {
+	return selectMediaType(operation.Produces, mediaTypeXML, mediaTypeTextXML, mediaTypeOctetStream)
+}
+
+// selectMediaType returns the first of the supported media types included in the given media types, empty if the media
+// types include application/json or none of the supported ones. The media type parameters (e,g: charset) are ignored
+func selectMediaType(mediaTypes []string, supportedMediaTypes ...string) string {
+	included := map[string]bool{}
+	for _, mediaType := range mediaTypes {
+		included[strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))] = true
+	}
+	if included[mediaTypeJSON] {
+		return ""
+	}
+	for _, mediaType := range supportedMediaTypes {
+		if included[mediaType] {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// getXMLSchema returns the XML schema of the payloads of the operations that consume or produce XML, nil otherwise. The
+// schema of the body parameter is used if the operation has one (the root element is named after the definition
+// referenced), otherwise the resource schema (the root element is named after the resource)
+func (o *SpecV2Resource) getXMLSchema(operation *spec.Operation) *specXMLSchema {
+	if !isXMLMediaType(o.getRequestMediaType(operation)) && !isXMLMediaType(o.getResponseMediaType(operation)) {
+		return nil
+	}
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "body" || parameter.Schema == nil {
+			continue
+		}
+		name := parameter.Name
+		if ref := parameter.Schema.Ref.String(); ref != "" {
+			name = ref[strings.LastIndex(ref, "/")+1:]
+		}
+		return newSpecXMLSchema(name, *parameter.Schema, o.SchemaDefinitions)
+	}
+	return newSpecXMLSchema(o.Name, o.SchemaDefinition, o.SchemaDefinitions)
+}
+
+// getUpdateStrategy returns the format of the patch document defined in the 'x-terraform-update-strategy' extension of
+// the operation (merge-patch or json-patch), empty if the extension is not present or not valid
+func (o *SpecV2Resource) getUpdateStrategy(operation *spec.Operation) string {
+	value, exists := operation.Extensions.GetString(extTfUpdateStrategy)
+	if !exists {
+		return ""
+	}
+	switch value {
+	case updateStrategyMergePatch, updateStrategyJSONPatch:
+		return value
+	}
+	o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: '%s' is not a supported update strategy (%s, %s)", extTfUpdateStrategy, value, updateStrategyMergePatch, updateStrategyJSONPatch))
+	return ""
+}
+
+// getPagination returns the pagination defined in the 'x-terraform-pagination' extension of the operation, nil if the
+// extension is not present or not valid. The extension value can be a string containing the pagination type (cursor,
+// page or link-header) or an object containing the type along with any of the pagination settings
+func (o *SpecV2Resource) getPagination(operation *spec.Operation) *specPagination {
+	value, exists := operation.Extensions[extTfPagination]
+	if !exists {
+		return nil
+	}
+	var pagination *specPagination
+	var err error
+	switch v := value.(type) {
+	case string:
+		pagination, err = newSpecPagination(v)
+	case map[string]interface{}:
+		pagination, err = newSpecPaginationFromExtension(v)
+	default:
+		err = fmt.Errorf("the value is not a string or an object (%v)", value)
+	}
+	if err != nil {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfPagination, err))
+		return nil
+	}
+	return pagination
+}
+
+// getRetryConfiguration returns the retry policy defined in the 'x-terraform-resource-retry' extension of the operation,
+// nil if the extension is not present or not valid. The extension value can be a boolean (true enables the retries using
+// the plugin configuration retry policy or the default one; false disables the retries) or an object containing any of the
+// retry settings: max_retries, backoff, max_backoff and status_codes
+func (o *SpecV2Resource) getRetryConfiguration(operation *spec.Operation) *RetryConfiguration {
+	value, exists := operation.Extensions[extTfResourceRetry]
+	if !exists {
+		return nil
+	}
+	switch v := value.(type) {
+	case bool:
+		return &RetryConfiguration{disabled: !v}
+	case map[string]interface{}:
+		retryConfiguration, err := newRetryConfigurationFromExtension(v)
+		if err != nil {
+			o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfResourceRetry, err))
+			return nil
+		}
+		return retryConfiguration
+	}
+	o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not a boolean or an object (%v)", extTfResourceRetry, value))
+	return nil
+}
+
+// newRetryConfigurationFromExtension returns the retry configuration defined in the object value of the
+// 'x-terraform-resource-retry' extension
+func newRetryConfigurationFromExtension(object map[string]interface{}) (*RetryConfiguration, error) {
+	retryConfiguration := &RetryConfiguration{}
+	for name, value := range object {
+		switch name {
+		case "max_retries":
+			maxRetries, ok := value.(float64)
+			if !ok || maxRetries != float64(int(maxRetries)) {
+				return nil, fmt.Errorf("max_retries must be an integer (%v)", value)
+			}
+			maxRetriesValue := int(maxRetries)
+			retryConfiguration.MaxRetries = &maxRetriesValue
+		case "backoff", "max_backoff":
+			duration, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string (%v)", name, value)
+			}
+			if name == "backoff" {
+				retryConfiguration.Backoff = duration
+			} else {
+				retryConfiguration.MaxBackoff = duration
+			}
+		case "status_codes":
+			statusCodes, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("status_codes must be a list of integers (%v)", value)
+			}
+			for _, statusCode := range statusCodes {
+				code, ok := statusCode.(float64)
+				if !ok || code != float64(int(code)) {
+					return nil, fmt.Errorf("status_codes must be a list of integers (%v)", value)
+				}
+				retryConfiguration.StatusCodes = append(retryConfiguration.StatusCodes, int(code))
+			}
+		default:
+			return nil, fmt.Errorf("'%s' is not a supported retry setting", name)
+		}
+	}
+	if err := retryConfiguration.Validate(); err != nil {
+		return nil, err
+	}
+	return retryConfiguration, nil
+}
+
+// getResponseWrapperProperty returns the path to the resource in the response payloads configured in the
+// 'x-terraform-response-wrapper-property' extension of the operation, falling back to the extension value configured at
+// the resource root path level (which applies to all the resource operations). Empty if the extension is not present
+func (o *SpecV2Resource) getResponseWrapperProperty(operation *spec.Operation) string {
+	if wrapperProperty, _ := operation.Extensions.GetString(extTfResponseWrapperProperty); wrapperProperty != "" {
+		return wrapperProperty
+	}
+	wrapperProperty, _ := o.RootPathItem.Extensions.GetString(extTfResponseWrapperProperty)
+	return wrapperProperty
+}
+
+// getOptimisticLocking returns the optimistic locking defined in the 'x-terraform-optimistic-locking' extension of the
+// operation, falling back to the extension value configured at the resource root path level. Nil if the extension is not
+// present or not valid. The extension value can be a string (the optimistic locking type, e,g: etag) or an object
+// containing the type, version_property and max_retries settings
+func (o *SpecV2Resource) getOptimisticLocking(operation *spec.Operation) *specOptimisticLocking {
+	value, exists := operation.Extensions[extTfOptimisticLocking]
+	if !exists {
+		if value, exists = o.RootPathItem.Extensions[extTfOptimisticLocking]; !exists {
+			return nil
+		}
+	}
+	var optimisticLocking *specOptimisticLocking
+	var err error
+	switch v := value.(type) {
+	case string:
+		optimisticLocking, err = newSpecOptimisticLockingFromExtension(map[string]interface{}{"type": v})
+	case map[string]interface{}:
+		optimisticLocking, err = newSpecOptimisticLockingFromExtension(v)
+	default:
+		err = fmt.Errorf("the value is not a string or an object (%v)", value)
+	}
+	if err != nil {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfOptimisticLocking, err))
+		return nil
+	}
+	return optimisticLocking
+}
+
+// getDeletePoll returns the delete poll defined in the 'x-terraform-resource-delete-poll' extension of the operation, nil
+// if the extension is not present, disabled or not valid. The extension value can be a boolean (the resource is read
+// until the API responds with 404 Not Found or 410 Gone) or an object containing the deleted_statuses and interval settings
+func (o *SpecV2Resource) getDeletePoll(operation *spec.Operation) *specDeletePoll {
+	value, exists := operation.Extensions[extTfResourceDeletePoll]
+	if !exists {
+		return nil
+	}
+	switch v := value.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+		return &specDeletePoll{}
+	case map[string]interface{}:
+		deletePoll, err := newSpecDeletePollFromExtension(v)
+		if err != nil {
+			o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfResourceDeletePoll, err))
+			return nil
+		}
+		return deletePoll
+	}
+	o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not a boolean or an object (%v)", extTfResourceDeletePoll, value))
+	return nil
+}
+
+// getBodyParameterProperties returns the names of the properties of the schema of the operation body parameter, nil if
+// the operation does not have a body parameter or its schema can not be resolved
+func (o *SpecV2Resource) getBodyParameterProperties(operation *spec.Operation) []string {
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "body" || parameter.Schema == nil {
+			continue
+		}
+		bodySchema := parameter.Schema
+		if bodySchema.Ref.String() != "" {
+			var err error
+			if bodySchema, err = openapiutils.GetSchemaDefinition(o.SchemaDefinitions, bodySchema.Ref.String()); err != nil {
+				o.getLogger().Warn(fmt.Sprintf("ignoring the body parameter '%s' since its schema could not be resolved: %s", parameter.Name, err))
+				return nil
+			}
+		}
+		var properties []string
+		for propertyName := range bodySchema.Properties {
+			properties = append(properties, propertyName)
+		}
+		sort.Strings(properties)
+		return properties
+	}
+	return nil
+}
+
+// getDeleteBodyTemplate returns the request body template defined in the 'x-terraform-delete-body' extension of the
+// operation, nil if the extension is not present or the value is not an object
+func (o *SpecV2Resource) getDeleteBodyTemplate(operation *spec.Operation) map[string]interface{} {
+	value, exists := operation.Extensions[extTfDeleteBody]
+	if !exists {
+		return nil
+	}
+	template, ok := value.(map[string]interface{})
+	if !ok {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not an object (%v)", extTfDeleteBody, value))
+		return nil
+	}
+	return template
+}
+
+// getPreDeleteOperation returns the operation defined in the 'x-terraform-pre-delete-operation' extension of the
+// operation, nil if the extension is not present or not valid. The extension value can be a string (the path of the
+// operation, e,g: /v1/resource/{id}/detach, called with POST) or an object containing the path, method and body settings.
+// The operation must be defined in the spec
+func (o *SpecV2Resource) getPreDeleteOperation(operation *spec.Operation) *specPreDeleteOperation {
+	value, exists := operation.Extensions[extTfPreDeleteOperation]
+	if !exists {
+		return nil
+	}
+	var preDeleteOperation *specPreDeleteOperation
+	var err error
+	switch v := value.(type) {
+	case string:
+		preDeleteOperation, err = newSpecPreDeleteOperationFromExtension(map[string]interface{}{"path": v}, o.Path)
+	case map[string]interface{}:
+		preDeleteOperation, err = newSpecPreDeleteOperationFromExtension(v, o.Path)
+	default:
+		err = fmt.Errorf("the value is not a string or an object (%v)", value)
+	}
+	if err != nil {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfPreDeleteOperation, err))
+		return nil
+	}
+	pathOperation := getPathItemOperation(o.Paths[preDeleteOperation.path], preDeleteOperation.method)
+	if pathOperation == nil {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the operation %s %s is not defined in the spec", extTfPreDeleteOperation, preDeleteOperation.method, preDeleteOperation.path))
+		return nil
+	}
+	preDeleteOperation.operation = o.createResourceOperation(pathOperation)
+	return preDeleteOperation
+}
+
+// getRequestWrapperProperty returns the path of the property the resource is nested under in the request payloads
+// configured in the 'x-terraform-request-wrapper-property' extension of the operation, falling back to the extension
+// value configured at the resource root path level. Empty if the extension is not present
+func (o *SpecV2Resource) getRequestWrapperProperty(operation *spec.Operation) string {
+	if wrapperProperty, _ := operation.Extensions.GetString(extTfRequestWrapperProperty); wrapperProperty != "" {
+		return wrapperProperty
+	}
+	wrapperProperty, _ := o.RootPathItem.Extensions.GetString(extTfRequestWrapperProperty)
+	return wrapperProperty
+}
+
+// getReadAfterCreateRetries returns the number of retries defined in the 'x-terraform-read-after-create-retries'
+// extension of the operation, zero if the extension is not present or the value is not a positive integer
+func (o *SpecV2Resource) getReadAfterCreateRetries(operation *spec.Operation) int {
+	value, exists := operation.Extensions[extTfReadAfterCreateRetries]
+	if !exists {
+		return 0
+	}
+	retries, ok := value.(float64)
+	if !ok || retries != float64(int(retries)) || retries < 0 {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not a positive integer (%v)", extTfReadAfterCreateRetries, value))
+		return 0
+	}
+	return int(retries)
+}
+
+// getLocationHeader returns the name of the response header configured in the 'x-terraform-resource-location-header'
+// extension of the operation, empty if the extension is not present
+func (o *SpecV2Resource) getLocationHeader(operation *spec.Operation) string {
+	locationHeader, _ := operation.Extensions.GetString(extTfResourceLocationHeader)
+	return locationHeader
+}
+
+// getQueryParameters returns the static query parameters defined in the 'x-terraform-query-params' extension of the
+// operation. The extension value must be an object containing the query parameter names and their values. Values that
+// are not strings (e,g: numbers) are converted to their string representation and arrays contain all the values of the
+// query parameter
+func (o *SpecV2Resource) getQueryParameters(operation *spec.Operation) map[string][]string {
+	value, exists := operation.Extensions[extTfQueryParams]
+	if !exists {
+		return nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not an object (%v)", extTfQueryParams, value))
+		return nil
+	}
+	queryParameters := map[string][]string{}
+	for name, v := range object {
+		items, isArray := v.([]interface{})
+		if !isArray {
+			items = []interface{}{v}
+		}
+		values := make([]string, len(items))
+		for i, item := range items {
+			values[i] = fmt.Sprintf("%v", item)
+		}
+		queryParameters[name] = values
+	}
+	return queryParameters
+}
+
+// getCollectionFormats returns the collectionFormat of the array query parameters of the operation keyed by the query
+// parameter name. Query parameters configured with a collectionFormat that is not supported are ignored so the default
+// collectionFormatCSV is used instead
+func (o *SpecV2Resource) getCollectionFormats(operation *spec.Operation) map[string]collectionFormat {
+	var collectionFormats map[string]collectionFormat
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "query" || parameter.Type != "array" {
+			continue
+		}
+		format, err := newCollectionFormat(parameter.CollectionFormat)
+		if err != nil {
+			o.getLogger().Warn(fmt.Sprintf("ignoring collectionFormat of query parameter '%s': %s", parameter.Name, err))
+			continue
+		}
+		if collectionFormats == nil {
+			collectionFormats = map[string]collectionFormat{}
+		}
+		collectionFormats[parameter.Name] = format
+	}
+	return collectionFormats
+}
+
+// getResponseHeaderProperties returns the names of the properties populated with the values of the response headers as
+// defined in the 'x-terraform-response-header-property' extension of the operation, keyed by the header name (e,g:
+// {"X-Resource-Id": "resource_id"}). Nil is returned if the extension is not present or not valid, and the headers which
+// property name is not a non empty string are ignored
+func (o *SpecV2Resource) getResponseHeaderProperties(operation *spec.Operation) map[string]string {
+	value, exists := operation.Extensions[extTfResponseHeaderProperty]
+	if !exists {
+		return nil
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not an object (%v)", extTfResponseHeaderProperty, value))
+		return nil
+	}
+	responseHeaderProperties := map[string]string{}
+	for header, v := range object {
+		propertyName, ok := v.(string)
+		if !ok || propertyName == "" {
+			o.getLogger().Warn(fmt.Sprintf("ignoring the header '%s' of the %s extension since the property name is not a non empty string (%v)", header, extTfResponseHeaderProperty, v))
+			continue
+		}
+		responseHeaderProperties[header] = propertyName
+	}
+	return responseHeaderProperties
+}
+
+// getFilterParameters returns the query parameters of the operation marked with the 'x-terraform-filter-param' extension
+// keyed by the name of the schema property they filter by. The extension value can be the name of the property or true
+// if the query parameter is named after the property
+func (o *SpecV2Resource) getFilterParameters(operation *spec.Operation) map[string]string {
+	var filterParameters map[string]string
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "query" {
+			continue
+		}
+		propertyName := parameter.Name
+		switch value := parameter.Extensions[extTfFilterParam].(type) {
+		case string:
+			propertyName = value
+		case bool:
+			if !value {
+				continue
+			}
+		default:
+			continue
+		}
+		if filterParameters == nil {
+			filterParameters = map[string]string{}
+		}
+		filterParameters[propertyName] = parameter.Name
+	}
+	return filterParameters
+}
+
 func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponses {
 	responses := specResponses{}
 	for statusCode, response := range operation.Responses.StatusCodeResponses { //panics on ImportState if the swagger doesn't define status code responses
@@ -684,6 +1848,11 @@ func (o *SpecV2Resource) createResponses(operation *spec.Operation) specResponse
 			isPollingEnabled:    o.isResourcePollingEnabled(response),
 			pollTargetStatuses:  o.getResourcePollTargetStatuses(response),
 			pollPendingStatuses: o.getResourcePollPendingStatuses(response),
+			pollInterval:        o.getPollDuration(response, extTfResourcePollInterval),
+			pollMinTimeout:      o.getPollDuration(response, extTfResourcePollMinTimeout),
+			pollDelay:           o.getPollDuration(response, extTfResourcePollDelay),
+			fieldErrors:         o.getResponseFieldErrors(statusCode, response),
+			asyncOperation:      o.getAsyncOperation(response),
 		}
 	}
 	return responses
@@ -699,6 +1868,103 @@ func (o *SpecV2Resource) isResourcePollingEnabled(response spec.Response) bool {
 	return false
 }
 
+// getResponseFieldErrors returns the configuration describing where the field level errors are located in the response
+// payload if the given response is a 4xx response with the 'x-terraform-error-fields' extension; nil otherwise
+func (o *SpecV2Resource) getResponseFieldErrors(statusCode int, response spec.Response) *specResponseFieldErrors {
+	if statusCode < http.StatusBadRequest || statusCode >= http.StatusInternalServerError {
+		return nil
+	}
+	fieldErrorsPath, exists := response.Extensions.GetString(extTfErrorFields)
+	if !exists || fieldErrorsPath == "" {
+		return nil
+	}
+	fieldErrors := &specResponseFieldErrors{
+		path:       fieldErrorsPath,
+		fieldKey:   defaultErrorFieldKey,
+		messageKey: defaultErrorMessageKey,
+	}
+	if fieldKey, exists := response.Extensions.GetString(extTfErrorFieldKey); exists && fieldKey != "" {
+		fieldErrors.fieldKey = fieldKey
+	}
+	if messageKey, exists := response.Extensions.GetString(extTfErrorMessageKey); exists && messageKey != "" {
+		fieldErrors.messageKey = messageKey
+	}
+	return fieldErrors
+}
+
+// getAsyncOperation returns the asynchronous operation defined in the 'x-terraform-async-operation' extension of the
+// response, nil if the extension is not present, disabled or not valid. The extension value can be a boolean (true
+// enables the asynchronous operation polling using the default settings) or an object containing any of the settings:
+// location_header, url_field, status_field, success_statuses, failure_statuses and error_field
+func (o *SpecV2Resource) getAsyncOperation(response spec.Response) *specAsyncOperation {
+	value, exists := response.Extensions[extTfAsyncOperation]
+	if !exists {
+		return nil
+	}
+	switch v := value.(type) {
+	case bool:
+		if !v {
+			return nil
+		}
+		return newSpecAsyncOperation()
+	case map[string]interface{}:
+		asyncOperation, err := newSpecAsyncOperationFromExtension(v)
+		if err != nil {
+			o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not valid: %s", extTfAsyncOperation, err))
+			return nil
+		}
+		return asyncOperation
+	}
+	o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value is not a boolean or an object (%v)", extTfAsyncOperation, value))
+	return nil
+}
+
+// newSpecAsyncOperationFromExtension returns the asynchronous operation defined in the object value of the
+// 'x-terraform-async-operation' extension. The settings not defined take the default values
+func newSpecAsyncOperationFromExtension(object map[string]interface{}) (*specAsyncOperation, error) {
+	asyncOperation := newSpecAsyncOperation()
+	for name, value := range object {
+		switch name {
+		case "location_header", "url_field", "status_field", "error_field":
+			v, ok := value.(string)
+			if !ok || v == "" {
+				return nil, fmt.Errorf("%s must be a non empty string (%v)", name, value)
+			}
+			switch name {
+			case "location_header":
+				asyncOperation.locationHeader = v
+			case "url_field":
+				asyncOperation.urlField = v
+			case "status_field":
+				asyncOperation.statusField = v
+			case "error_field":
+				asyncOperation.errorField = v
+			}
+		case "success_statuses", "failure_statuses":
+			items, ok := value.([]interface{})
+			if !ok || len(items) == 0 {
+				return nil, fmt.Errorf("%s must be a non empty list of strings (%v)", name, value)
+			}
+			var statuses []string
+			for _, item := range items {
+				status, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s must be a non empty list of strings (%v)", name, value)
+				}
+				statuses = append(statuses, status)
+			}
+			if name == "success_statuses" {
+				asyncOperation.successStatuses = statuses
+			} else {
+				asyncOperation.failureStatuses = statuses
+			}
+		default:
+			return nil, fmt.Errorf("'%s' is not a supported asynchronous operation setting", name)
+		}
+	}
+	return asyncOperation, nil
+}
+
 func (o *SpecV2Resource) getResourcePollTargetStatuses(response spec.Response) []string {
 	return o.getPollingStatuses(response, extTfResourcePollTargetStatuses)
 }
@@ -707,31 +1973,61 @@ func (o *SpecV2Resource) getResourcePollPendingStatuses(response spec.Response)
 	return o.getPollingStatuses(response, extTfResourcePollPendingStatuses)
 }
 
+// getPollingStatuses returns the statuses defined in the given extension of the response. The extension value can be
+// either a string with comma separated values (e,g: "deploy_pending, deploy_in_progress") or a list of strings
 func (o *SpecV2Resource) getPollingStatuses(response spec.Response, extension string) []string {
 	var statuses []string
 	if resourcePollTargets, exists := response.Extensions.GetString(extension); exists {
 		spaceTrimmedTargets := strings.Replace(resourcePollTargets, " ", "", -1)
 		statuses = strings.Split(spaceTrimmedTargets, ",")
+	} else if values, ok := response.Extensions[extension].([]interface{}); ok {
+		for _, value := range values {
+			status, ok := value.(string)
+			if !ok {
+				o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value contains an item that is not a string (%v)", extension, value))
+				return nil
+			}
+			statuses = append(statuses, strings.TrimSpace(status))
+		}
 	}
 	return statuses
 }
 
+// getPollDuration returns the duration (e,g: 500ms or 5s) defined in the given polling extension of the response, nil
+// if the extension is not present or the value is not a valid positive duration
+func (o *SpecV2Resource) getPollDuration(response spec.Response, extension string) *time.Duration {
+	value, exists := response.Extensions.GetString(extension)
+	if !exists {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		o.getLogger().Warn(fmt.Sprintf("ignoring %s extension since the value '%s' is not a valid positive duration", extension, value))
+		return nil
+	}
+	return &duration
+}
+
 func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	var postTimeout *time.Duration
 	var getTimeout *time.Duration
 	var putTimeout *time.Duration
 	var deleteTimeout *time.Duration
 	var err error
-	if postTimeout, err = o.getResourceTimeout(o.RootPathItem.Post); err != nil {
+	if postTimeout, err = o.getOperationTimeout(o.RootPathItem.Post, extTfResourceTimeoutCreate); err != nil {
 		return nil, err
 	}
-	if getTimeout, err = o.getResourceTimeout(o.InstancePathItem.Get); err != nil {
+	if getTimeout, err = o.getOperationTimeout(o.InstancePathItem.Get, extTfResourceTimeoutRead); err != nil {
 		return nil, err
 	}
-	if putTimeout, err = o.getResourceTimeout(o.InstancePathItem.Put); err != nil {
+	updateOperation := o.InstancePathItem.Put
+	if updateOperation == nil {
+		updateOperation = o.InstancePathItem.Patch
+	}
+	if putTimeout, err = o.getOperationTimeout(updateOperation, extTfResourceTimeoutUpdate); err != nil {
 		return nil, err
 	}
-	if deleteTimeout, err = o.getResourceTimeout(o.InstancePathItem.Delete); err != nil {
+	if deleteTimeout, err = o.getOperationTimeout(o.InstancePathItem.Delete, extTfResourceTimeoutDelete); err != nil {
 		return nil, err
 	}
 	return &specTimeouts{
@@ -742,6 +2038,24 @@ func (o *SpecV2Resource) getTimeouts() (*specTimeouts, error) {
 	}, nil
 }
 
+// getOperationTimeout returns the timeout defined in the 'x-terraform-resource-timeout' extension of the operation. If the
+// operation does not define it, the timeout defined in the given resource level extension (e,g: 'x-terraform-resource-timeout-create')
+// is returned instead. The resource level extensions can be defined either in the resource root path or in the root path
+// POST operation. Nil is returned if none of the extensions are present
+func (o *SpecV2Resource) getOperationTimeout(operation *spec.Operation, resourceTimeoutExtension string) (*time.Duration, error) {
+	timeout, err := o.getResourceTimeout(operation)
+	if err != nil || timeout != nil {
+		return timeout, err
+	}
+	if timeout, err = o.getTimeDuration(o.RootPathItem.Extensions, resourceTimeoutExtension); err != nil || timeout != nil {
+		return timeout, err
+	}
+	if o.RootPathItem.Post != nil {
+		return o.getTimeDuration(o.RootPathItem.Post.Extensions, resourceTimeoutExtension)
+	}
+	return nil, nil
+}
+
 func (o *SpecV2Resource) getResourceTimeout(operation *spec.Operation) (*time.Duration, error) {
 	if operation == nil {
 		return nil, nil
@@ -779,3 +2093,59 @@ func getResourceOverrideHost(rootPathItemPost *spec.Operation) string {
 	}
 	return ""
 }
+
+// getResourceIdentifierByConvention returns the name of the property that should be used as the resource identifier when
+// the schema does not contain a property named 'id' nor a property with the 'x-terraform-id' extension, along with the
+// convention that was matched. The properties are looked up in the following order:
+// 1. A property which name is id in a different casing (e,g: ID)
+// 2. A property named after the resource followed by id (e,g: cdnId or cdn_id for the /v1/cdns resource)
+// 3. The only read only property with uuid format
+// Empty string is returned if no property matches or if the match is ambiguous (more than one read only uuid property),
+// the latter being logged with the logger provided
+func getResourceIdentifierByConvention(resourceNames []string, schema *spec.Schema, logger Logger) (string, string) {
+	propertyNames := make([]string, 0, len(schema.Properties))
+	for propertyName := range schema.Properties {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+	for _, propertyName := range propertyNames {
+		if terraformutils.ConvertToTerraformCompliantName(propertyName) == idDefaultPropertyName {
+			return propertyName, "property named id"
+		}
+	}
+	for _, resourceName := range resourceNames {
+		for _, propertyName := range propertyNames {
+			if terraformutils.ConvertToTerraformCompliantName(propertyName) == fmt.Sprintf("%s_%s", resourceName, idDefaultPropertyName) {
+				return propertyName, fmt.Sprintf("property named after the resource name '%s'", resourceName)
+			}
+		}
+	}
+	var uuidPropertyNames []string
+	for _, propertyName := range propertyNames {
+		property := schema.Properties[propertyName]
+		if property.ReadOnly && property.Format == formatUUID {
+			uuidPropertyNames = append(uuidPropertyNames, propertyName)
+		}
+	}
+	if len(uuidPropertyNames) == 1 {
+		return uuidPropertyNames[0], "only read only property with uuid format"
+	}
+	if len(uuidPropertyNames) > 1 {
+		loggerOrDefault(logger).Warn(fmt.Sprintf("could not select the resource identifier by convention since there are multiple read only properties with uuid format %s, please use the %s extension to mark the property that identifies the resource", uuidPropertyNames, extTfID))
+	}
+	return "", ""
+}
+
+// isReadOnlyResourceInstancePath checks if the x-terraform-read-only-resource extension is enabled either in the resource
+// instance path or in its GET operation
+func isReadOnlyResourceInstancePath(instancePathItem spec.PathItem) bool {
+	if enabled, ok := instancePathItem.Extensions.GetBool(extTfReadOnlyResource); ok && enabled {
+		return true
+	}
+	if instancePathItem.Get != nil {
+		if enabled, ok := instancePathItem.Get.Extensions.GetBool(extTfReadOnlyResource); ok && enabled {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
+	"github.com/go-openapi/spec"
+)
+
+// resolveAllOf returns the schema resulting from merging the schemas listed in the allOf construct (if any) into a
+// single object schema. The properties, required properties and extensions of the sub-schemas are merged in order and
+// the ones defined in the schema itself take precedence. Sub-schemas defining the same property with different types
+// are considered a conflict and an error is returned
+func (o *SpecV2Resource) resolveAllOf(schema spec.Schema) (spec.Schema, error) {
+	return o.mergeAllOf(schema, map[string]bool{})
+}
+
+func (o *SpecV2Resource) mergeAllOf(schema spec.Schema, visitedRefs map[string]bool) (spec.Schema, error) {
+	if len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+	resolved := schema
+	resolved.AllOf = nil
+	resolved.Properties = map[string]spec.Schema{}
+	resolved.Required = nil
+	resolved.Extensions = spec.Extensions{}
+	for idx, subSchema := range schema.AllOf {
+		dereferenced, err := o.dereferenceSchema(subSchema, visitedRefs)
+		if err != nil {
+			return spec.Schema{}, fmt.Errorf("allOf schema %d: %s", idx, err)
+		}
+		if len(dereferenced.OneOf) > 0 || len(dereferenced.AnyOf) > 0 || dereferenced.Not != nil {
+			return spec.Schema{}, fmt.Errorf("allOf schema %d: only object schemas (or allOf compositions of them) can be composed", idx)
+		}
+		merged, err := o.mergeAllOf(*dereferenced, visitedRefs)
+		if err != nil {
+			return spec.Schema{}, err
+		}
+		if len(merged.Type) != 0 && !o.isObjectTypeProperty(merged) {
+			return spec.Schema{}, fmt.Errorf("allOf schema %d is of type '%s', only object schemas can be composed", idx, strings.Join(merged.Type, ","))
+		}
+		if err := mergeSchemaProperties(resolved.Properties, merged.Properties); err != nil {
+			return spec.Schema{}, err
+		}
+		resolved.Required = appendMissing(resolved.Required, merged.Required...)
+		for key, value := range merged.Extensions {
+			resolved.Extensions[key] = value
+		}
+		if resolved.AdditionalProperties == nil {
+			resolved.AdditionalProperties = merged.AdditionalProperties
+		}
+	}
+	if err := mergeSchemaProperties(resolved.Properties, schema.Properties); err != nil {
+		return spec.Schema{}, err
+	}
+	resolved.Required = appendMissing(resolved.Required, schema.Required...)
+	for key, value := range schema.Extensions {
+		resolved.Extensions[key] = value
+	}
+	if len(resolved.Type) == 0 {
+		resolved.Type = spec.StringOrArray{"object"}
+	}
+	return resolved, nil
+}
+
+// dereferenceSchema returns the schema the given schema points to if it is a ref; otherwise the schema is returned as is.
+// The refs already visited are tracked to detect circular compositions
+func (o *SpecV2Resource) dereferenceSchema(schema spec.Schema, visitedRefs map[string]bool) (*spec.Schema, error) {
+	if schema.Ref.Ref.GetURL() == nil {
+		return &schema, nil
+	}
+	ref := schema.Ref.String()
+	if visitedRefs[ref] {
+		return nil, fmt.Errorf("circular ref '%s' found in the schema composition", ref)
+	}
+	visitedRefs[ref] = true
+	dereferenced, err := openapiutils.GetSchemaDefinition(o.SchemaDefinitions, ref)
+	if err != nil {
+		return nil, fmt.Errorf("ref is pointing to a non existing schema definition: %s", err)
+	}
+	return dereferenced, nil
+}
+
+// mergeSchemaProperties adds the given properties into the target properties, overriding the ones already present as
+// long as the types match
+func mergeSchemaProperties(target, properties map[string]spec.Schema) error {
+	for name, property := range properties {
+		if existing, exists := target[name]; exists && len(existing.Type) != 0 && len(property.Type) != 0 && strings.Join(existing.Type, ",") != strings.Join(property.Type, ",") {
+			return fmt.Errorf("property '%s' is defined with conflicting types '%s' and '%s' in the allOf schemas", name, strings.Join(existing.Type, ","), strings.Join(property.Type, ","))
+		}
+		target[name] = property
+	}
+	return nil
+}
+
+func appendMissing(values []string, newValues ...string) []string {
+	for _, newValue := range newValues {
+		found := false
+		for _, value := range values {
+			if value == newValue {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, newValue)
+		}
+	}
+	return values
+}
+
+// isDiscriminatedUnion returns true if the schema is a polymorphic schema (oneOf or anyOf) with a discriminator
+// property that identifies the variant of the objects
+func (o *SpecV2Resource) isDiscriminatedUnion(schema spec.Schema) bool {
+	return (len(schema.OneOf) > 0 || len(schema.AnyOf) > 0) && schema.Discriminator != ""
+}
+
+// getDiscriminatedUnionSchemaDefinition returns the schema definition for the given discriminated union. Each variant
+// is represented as a nested optional object property named after its discriminator value, which does not include the
+// discriminator property as its value is implied by the variant configured. Only one of the variants can be configured.
+//
+// The discriminator value of a variant is the single enum value of its discriminator property; if not present, the name
+// of the schema definition the variant refers to is used instead (known via the ref or, once the document refs are
+// expanded, via the extDefinitionName extension)
+func (o *SpecV2Resource) getDiscriminatedUnionSchemaDefinition(schema spec.Schema) (*specSchemaDefinition, error) {
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+	discriminator := schema.Discriminator
+	schemaDefinition := &specSchemaDefinition{Discriminator: discriminator, Properties: specSchemaDefinitionProperties{}, logger: o.logger}
+	discriminatorValues := map[string]bool{}
+	for idx, variant := range variants {
+		dereferenced, err := o.dereferenceSchema(variant, map[string]bool{})
+		if err != nil {
+			return nil, fmt.Errorf("discriminated union variant %d: %s", idx, err)
+		}
+		resolved, err := o.resolveAllOf(*dereferenced)
+		if err != nil {
+			return nil, fmt.Errorf("discriminated union variant %d: %s", idx, err)
+		}
+		if len(resolved.Properties) == 0 {
+			return nil, fmt.Errorf("discriminated union variant %d must be an object schema with properties", idx)
+		}
+		discriminatorValue, err := o.getDiscriminatorValue(variant, resolved, discriminator)
+		if err != nil {
+			return nil, fmt.Errorf("discriminated union variant %d: %s", idx, err)
+		}
+		if discriminatorValues[discriminatorValue] {
+			return nil, fmt.Errorf("discriminated union variant %d: duplicated discriminator value '%s'", idx, discriminatorValue)
+		}
+		discriminatorValues[discriminatorValue] = true
+
+		// The discriminator property is set by the provider based on the variant configured
+		variantProperties := map[string]spec.Schema{}
+		for name, property := range resolved.Properties {
+			if name != discriminator {
+				variantProperties[name] = property
+			}
+		}
+		resolved.Properties = variantProperties
+		var required []string
+		for _, name := range resolved.Required {
+			if name != discriminator {
+				required = append(required, name)
+			}
+		}
+		resolved.Required = required
+
+		variantSchemaDefinition, err := o.getSchemaDefinition(&resolved)
+		if err != nil {
+			return nil, fmt.Errorf("discriminated union variant '%s': %s", discriminatorValue, err)
+		}
+		schemaDefinition.Properties = append(schemaDefinition.Properties, &specSchemaDefinitionProperty{
+			Name:                 discriminatorValue,
+			Type:                 typeObject,
+			SpecSchemaDefinition: variantSchemaDefinition,
+			EnableLegacyComplexObjectBlockConfiguration: true,
+		})
+	}
+	sort.Slice(schemaDefinition.Properties, func(i, j int) bool {
+		return schemaDefinition.Properties[i].Name < schemaDefinition.Properties[j].Name
+	})
+	return schemaDefinition, nil
+}
+
+func (o *SpecV2Resource) getDiscriminatorValue(variant, resolved spec.Schema, discriminator string) (string, error) {
+	if discriminatorProperty, exists := resolved.Properties[discriminator]; exists && len(discriminatorProperty.Enum) == 1 {
+		if value, ok := discriminatorProperty.Enum[0].(string); ok && value != "" {
+			return value, nil
+		}
+	}
+	if definitionName, ok := variant.Extensions.GetString(extDefinitionName); ok && definitionName != "" {
+		return definitionName, nil
+	}
+	if variant.Ref.Ref.GetURL() != nil {
+		if fragments := strings.Split(variant.Ref.String(), "/"); fragments[len(fragments)-1] != "" {
+			return fragments[len(fragments)-1], nil
+		}
+	}
+	return "", fmt.Errorf("unable to determine the discriminator value, the '%s' property must define a single enum value", discriminator)
+}
@@ -0,0 +1,237 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/go-openapi/jsonreference"
+	"github.com/go-openapi/spec"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResolveAllOf(t *testing.T) {
+	Convey("Given a SpecV2Resource with a Pet schema definition", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinitions: map[string]spec.Schema{
+				"Pet": {
+					SchemaProps: spec.SchemaProps{
+						Type:     spec.StringOrArray{"object"},
+						Required: []string{"name"},
+						Properties: map[string]spec.Schema{
+							"id":   *spec.StringProperty(),
+							"name": *spec.StringProperty(),
+						},
+					},
+				},
+			},
+		}
+		Convey("When resolveAllOf is called with a schema that does not use the allOf construct", func() {
+			schema := spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"name": *spec.StringProperty()}}}
+			resolved, err := r.resolveAllOf(schema)
+			Convey("Then the schema returned should be the same", func() {
+				So(err, ShouldBeNil)
+				So(resolved, ShouldResemble, schema)
+			})
+		})
+		Convey("When resolveAllOf is called with a schema composed of a ref and an inline schema", func() {
+			schema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					AllOf: []spec.Schema{
+						{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Pet")}}},
+						{
+							SchemaProps: spec.SchemaProps{
+								Type:     spec.StringOrArray{"object"},
+								Required: []string{"breed"},
+								Properties: map[string]spec.Schema{
+									"breed": *spec.StringProperty(),
+								},
+							},
+							VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfImmutableForceNew: true}},
+						},
+					},
+				},
+			}
+			resolved, err := r.resolveAllOf(schema)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resolved schema should be an object containing the properties of all the schemas", func() {
+				So(resolved.AllOf, ShouldBeNil)
+				So(resolved.Type, ShouldResemble, spec.StringOrArray{"object"})
+				So(resolved.Properties, ShouldContainKey, "id")
+				So(resolved.Properties, ShouldContainKey, "name")
+				So(resolved.Properties, ShouldContainKey, "breed")
+			})
+			Convey("And the required properties and extensions should be merged", func() {
+				So(resolved.Required, ShouldResemble, []string{"name", "breed"})
+				So(resolved.Extensions, ShouldContainKey, extTfImmutableForceNew)
+			})
+		})
+		Convey("When resolveAllOf is called with a schema that overrides a property defined in the allOf schemas", func() {
+			readOnlyName := *spec.StringProperty()
+			readOnlyName.ReadOnly = true
+			schema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					AllOf:      []spec.Schema{{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Pet")}}}},
+					Properties: map[string]spec.Schema{"name": readOnlyName},
+				},
+			}
+			resolved, err := r.resolveAllOf(schema)
+			Convey("Then the property defined in the schema itself should take precedence", func() {
+				So(err, ShouldBeNil)
+				So(resolved.Properties["name"].ReadOnly, ShouldBeTrue)
+			})
+		})
+		Convey("When resolveAllOf is called with schemas defining the same property with different types", func() {
+			schema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					AllOf: []spec.Schema{
+						{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Pet")}}},
+						{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"name": *spec.Int64Property()}}},
+					},
+				},
+			}
+			_, err := r.resolveAllOf(schema)
+			Convey("Then the error returned should describe the conflict", func() {
+				So(err.Error(), ShouldEqual, "property 'name' is defined with conflicting types 'string' and 'integer' in the allOf schemas")
+			})
+		})
+		Convey("When resolveAllOf is called with a schema composing a non object schema", func() {
+			schema := spec.Schema{SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{*spec.StringProperty()}}}
+			_, err := r.resolveAllOf(schema)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "allOf schema 0 is of type 'string', only object schemas can be composed")
+			})
+		})
+		Convey("When resolveAllOf is called with a schema composing a oneOf schema", func() {
+			schema := spec.Schema{SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{{SchemaProps: spec.SchemaProps{OneOf: []spec.Schema{*spec.StringProperty()}}}}}}
+			_, err := r.resolveAllOf(schema)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "allOf schema 0: only object schemas (or allOf compositions of them) can be composed")
+			})
+		})
+		Convey("When resolveAllOf is called with a schema composing a ref that does not exist", func() {
+			schema := spec.Schema{SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/NonExisting")}}}}}}
+			_, err := r.resolveAllOf(schema)
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a schema definition composed of itself", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinitions: map[string]spec.Schema{
+				"Pet": {SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Pet")}}}}}},
+			},
+		}
+		Convey("When resolveAllOf is called with the schema definition", func() {
+			_, err := r.resolveAllOf(r.SchemaDefinitions["Pet"])
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "allOf schema 0: circular ref '#/definitions/Pet' found in the schema composition")
+			})
+		})
+	})
+}
+
+func TestGetDiscriminatedUnionSchemaDefinition(t *testing.T) {
+	Convey("Given a SpecV2Resource with Cat and Dog schema definitions", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinitions: map[string]spec.Schema{
+				"Cat": {
+					SchemaProps: spec.SchemaProps{
+						Type:     spec.StringOrArray{"object"},
+						Required: []string{"kind", "lives"},
+						Properties: map[string]spec.Schema{
+							"kind":  *spec.StringProperty(),
+							"lives": *spec.Int64Property(),
+						},
+					},
+				},
+			},
+		}
+		dog := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}, Enum: []interface{}{"dog"}}},
+					"bark": *spec.BoolProperty(),
+				},
+			},
+		}
+		Convey("When createSchemaDefinitionProperty is called with a oneOf property with a discriminator", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					OneOf: []spec.Schema{dog, {SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Cat")}}}},
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "kind"},
+			}
+			property, err := r.createSchemaDefinitionProperty("pet", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the property should be an optional object with the discriminator configured", func() {
+				So(property.Type, ShouldEqual, typeObject)
+				So(property.Required, ShouldBeFalse)
+				So(property.SpecSchemaDefinition.Discriminator, ShouldEqual, "kind")
+			})
+			Convey("And each variant should be an optional nested object named after its discriminator value", func() {
+				So(len(property.SpecSchemaDefinition.Properties), ShouldEqual, 2)
+				cat := property.SpecSchemaDefinition.Properties[0]
+				So(cat.Name, ShouldEqual, "Cat")
+				So(cat.Type, ShouldEqual, typeObject)
+				So(cat.Required, ShouldBeFalse)
+				So(cat.isLegacyComplexObjectExtensionEnabled(), ShouldBeTrue)
+				dog := property.SpecSchemaDefinition.Properties[1]
+				So(dog.Name, ShouldEqual, "dog")
+			})
+			Convey("And the variants should not contain the discriminator property", func() {
+				cat := property.SpecSchemaDefinition.Properties[0].SpecSchemaDefinition
+				_, err := cat.getProperty("kind")
+				So(err, ShouldNotBeNil)
+				lives, err := cat.getProperty("lives")
+				So(err, ShouldBeNil)
+				So(lives.Required, ShouldBeTrue)
+			})
+		})
+		Convey("When createSchemaDefinitionProperty is called with an anyOf property with a discriminator where the variants have the same discriminator value", func() {
+			propertySchema := spec.Schema{
+				SchemaProps:        spec.SchemaProps{AnyOf: []spec.Schema{dog, dog}},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "kind"},
+			}
+			_, err := r.createSchemaDefinitionProperty("pet", propertySchema, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to process object type property 'pet': discriminated union variant 1: duplicated discriminator value 'dog'")
+			})
+		})
+		Convey("When createSchemaDefinitionProperty is called with a oneOf property with a discriminator where a variant does not define the discriminator value", func() {
+			propertySchema := spec.Schema{
+				SchemaProps:        spec.SchemaProps{OneOf: []spec.Schema{{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"object"}, Properties: map[string]spec.Schema{"bark": *spec.BoolProperty()}}}}},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{Discriminator: "kind"},
+			}
+			_, err := r.createSchemaDefinitionProperty("pet", propertySchema, []string{})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "failed to process object type property 'pet': discriminated union variant 0: unable to determine the discriminator value, the 'kind' property must define a single enum value")
+			})
+		})
+		Convey("When createSchemaDefinitionProperty is called with an array property with items composed with allOf", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{Schema: &spec.Schema{SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{
+						{SchemaProps: spec.SchemaProps{Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Cat")}}},
+						{SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"name": *spec.StringProperty()}}},
+					}}}},
+				},
+			}
+			property, err := r.createSchemaDefinitionProperty("cats", propertySchema, []string{})
+			Convey("Then the property should be a list of objects with the merged properties", func() {
+				So(err, ShouldBeNil)
+				So(property.Type, ShouldEqual, typeList)
+				So(property.ArrayItemsType, ShouldEqual, typeObject)
+				So(len(property.SpecSchemaDefinition.Properties), ShouldEqual, 3)
+			})
+			Convey("And the items schema of the property passed in should not be modified", func() {
+				So(propertySchema.Items.Schema.AllOf, ShouldHaveLength, 2)
+			})
+		})
+	})
+}
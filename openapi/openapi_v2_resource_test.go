@@ -279,6 +279,62 @@ func TestNewSpecV2ResourceWithRegion(t *testing.T) {
 	})
 }
 
+func TestIsReadOnlyResource(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource configured with an instance path item that contains the %s extension", extTfReadOnlyResource), t, func() {
+		r := SpecV2Resource{
+			InstancePathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfReadOnlyResource: true,
+					},
+				},
+			},
+		}
+		Convey("When isReadOnlyResource is called", func() {
+			isReadOnlyResource := r.isReadOnlyResource()
+			Convey("Then the result should be true", func() {
+				So(isReadOnlyResource, ShouldBeTrue)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource configured with an instance path item GET operation that contains the %s extension", extTfReadOnlyResource), t, func() {
+		r := SpecV2Resource{
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfReadOnlyResource: true,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When isReadOnlyResource is called", func() {
+			isReadOnlyResource := r.isReadOnlyResource()
+			Convey("Then the result should be true", func() {
+				So(isReadOnlyResource, ShouldBeTrue)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource configured with an instance path item that does not contain the %s extension", extTfReadOnlyResource), t, func() {
+		r := SpecV2Resource{
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When isReadOnlyResource is called", func() {
+			isReadOnlyResource := r.isReadOnlyResource()
+			Convey("Then the result should be false", func() {
+				So(isReadOnlyResource, ShouldBeFalse)
+			})
+		})
+	})
+}
+
 func TestShouldIgnoreResource(t *testing.T) {
 	Convey("Given a SpecV2Resource configured with a root path item that does not contain the post operation defined", t, func() {
 		r := SpecV2Resource{
@@ -431,6 +487,42 @@ func TestBuildResourceName(t *testing.T) {
 			expectedResourceName: "cdn_v1",
 			expectedError:        nil,
 		},
+		{
+			name:  "resource name without the version suffix",
+			path:  "/v1/cdns",
+			paths: nil,
+			rootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfResourceName:          "cdn",
+						extTfResourceVersionSuffix: false,
+					},
+				},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+			expectedResourceName: "cdn",
+			expectedError:        nil,
+		},
+		{
+			name: "first level sub-resource with a parent configured without the version suffix",
+			path: "/v1/cdns/{id}/v1/firewalls",
+			paths: map[string]spec.PathItem{
+				"/v1/cdns": {
+					VendorExtensible: spec.VendorExtensible{
+						Extensions: spec.Extensions{
+							extTfResourceVersionSuffix: false,
+						},
+					},
+					PathItemProps: spec.PathItemProps{
+						Post: &spec.Operation{},
+					},
+				},
+			},
+			expectedResourceName: "cdns_firewalls_v1",
+			expectedError:        nil,
+		},
 		{
 			name: "first level sub-resource with no preferred parent names",
 			path: "/cdns/{id}/firewalls",
@@ -551,6 +643,7 @@ func TestBuildResourceNameFromPath(t *testing.T) {
 		path                 string
 		expectedResourceName string
 		preferredName        string
+		excludeVersion       bool
 		expectedError        error
 	}{
 		{
@@ -667,13 +760,27 @@ func TestBuildResourceNameFromPath(t *testing.T) {
 			expectedResourceName: "iamgroup_v1",
 			expectedError:        nil,
 		},
+		{
+			path:                 "/v1/cdns",
+			preferredName:        "",
+			excludeVersion:       true,
+			expectedResourceName: "cdns",
+			expectedError:        nil,
+		},
+		{
+			path:                 "/api/v1/group/",
+			preferredName:        "iamgroup",
+			excludeVersion:       true,
+			expectedResourceName: "iamgroup",
+			expectedError:        nil,
+		},
 	}
 
 	for _, tc := range testCases {
 		Convey("Given a SpecV2Resource", t, func() {
 			r := SpecV2Resource{}
 			Convey("When buildResourceName is called with the given path and preferred name", func() {
-				resourceName, err := r.buildResourceNameFromPath(tc.path, tc.preferredName)
+				resourceName, err := r.buildResourceNameFromPath(tc.path, tc.preferredName, !tc.excludeVersion)
 				if tc.expectedError != nil {
 					Convey("Then the error returned should be the expected one", func() {
 						So(err.Error(), ShouldEqual, tc.expectedError.Error())
@@ -743,6 +850,23 @@ func TestParentResourceInfo(t *testing.T) {
 				So(len(parentResourceInfo.parentInstanceURIs), ShouldEqual, 1)
 				So(parentResourceInfo.parentInstanceURIs[0], ShouldEqual, "/v1/cdns/{id}")
 			})
+			Convey("And the parentPathParameters contain the expected path parameter names", func() {
+				So(parentResourceInfo.parentPathParameters, ShouldResemble, []string{"id"})
+			})
+		})
+	})
+
+	Convey("Given a SpecV2Resource configured with a path that is a sub-resource of a sub-resource with named path parameters", t, func() {
+		r := SpecV2Resource{
+			Path:  "/v1/zones/{zone_id}/records/{record_id}/tags",
+			Paths: map[string]spec.PathItem{},
+		}
+		Convey("When parentResourceInfo is called", func() {
+			parentResourceInfo := r.getParentResourceInfo()
+			Convey("Then the parentPathParameters contain the names of the path parameters in order", func() {
+				So(parentResourceInfo.parentPathParameters, ShouldResemble, []string{"zone_id", "record_id"})
+				So(parentResourceInfo.getParentPropertiesNames(), ShouldResemble, []string{"zones_v1_id", "zones_v1_records_id"})
+			})
 		})
 	})
 
@@ -844,7 +968,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdns_v1")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewalls_v2")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdns_v1_firewalls_v2")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdns_v1_firewalls_v2")
@@ -886,7 +1010,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdns_v1")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewalls")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdns_v1_firewalls")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdns_v1_firewalls")
@@ -995,7 +1119,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdns")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewalls")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdns_firewalls")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdns_firewalls")
@@ -1036,7 +1160,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdns_v1")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewalls_v2")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdns_v1_firewalls_v2")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdns_v1_firewalls_v2")
@@ -1053,6 +1177,61 @@ func TestParentResourceInfo(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a SpecV2Resource configured with a path that is a sub-resource of three levels with hyphenated names and only the first parent having a preferred name", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/vms/{vm-id}/disks/{diskId}/disk-snapshots/{snapshot_id}/backups",
+			Paths: map[string]spec.PathItem{
+				"/v1/vms": {
+					PathItemProps: spec.PathItemProps{
+						Post: &spec.Operation{
+							VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: "vm"}},
+						},
+					},
+				},
+			},
+		}
+		Convey("When parentResourceInfo is called", func() {
+			parentResourceInfo := r.getParentResourceInfo()
+			Convey("Then the parentResourceInfo struct returned should contain the three parents", func() {
+				So(parentResourceInfo, ShouldNotBeNil)
+				So(parentResourceInfo.parentResourceNames, ShouldResemble, []string{"vm_v1", "vm_v1_disks", "vm_v1_disks_disk_snapshots"})
+				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "vm_v1_disks_disk_snapshots")
+				So(parentResourceInfo.getParentPropertiesNames(), ShouldResemble, []string{"vm_v1_id", "vm_v1_disks_id", "vm_v1_disks_disk_snapshots_id"})
+			})
+			Convey("And the parentURIs and parentInstanceURIs should contain the expected URIs", func() {
+				So(parentResourceInfo.parentURIs, ShouldResemble, []string{"/v1/vms", "/v1/vms/{vm-id}/disks", "/v1/vms/{vm-id}/disks/{diskId}/disk-snapshots"})
+				So(parentResourceInfo.parentInstanceURIs, ShouldResemble, []string{"/v1/vms/{vm-id}", "/v1/vms/{vm-id}/disks/{diskId}", "/v1/vms/{vm-id}/disks/{diskId}/disk-snapshots/{snapshot_id}"})
+			})
+			Convey("And the parentPathParameters should contain the names of the parent path parameters", func() {
+				So(parentResourceInfo.parentPathParameters, ShouldResemble, []string{"vm-id", "diskId", "snapshot_id"})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource configured with a path that is a sub-resource of a renamed sub-resource", t, func() {
+		paths := map[string]spec.PathItem{
+			"/v1/cdns": {
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: "cdn"}},
+			},
+			"/v1/cdns/{cdn_id}/v1/firewalls": {
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: "fw"}},
+					},
+				},
+			},
+		}
+		r := SpecV2Resource{Path: "/v1/cdns/{cdn_id}/v1/firewalls/{firewall_id}/rules", Paths: paths}
+		parent := SpecV2Resource{Path: "/v1/cdns/{cdn_id}/v1/firewalls", RootPathItem: paths["/v1/cdns/{cdn_id}/v1/firewalls"], Paths: paths}
+		Convey("When parentResourceInfo is called", func() {
+			parentResourceInfo := r.getParentResourceInfo()
+			Convey("Then the parent properties should be named after the final terraform names of the parent resources", func() {
+				So(parentResourceInfo.getParentPropertiesNames(), ShouldResemble, []string{"cdn_v1_id", "cdn_v1_fw_v1_id"})
+				parentName, err := parent.buildResourceName()
+				So(err, ShouldBeNil)
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, parentName)
+			})
+		})
+	})
 	Convey("Given a SpecV2Resource configured with a path that is a subresource but the path is wrongly structured not following best restful practises for building subresource paths (the 'firewalls' parent in the path is missing the id path param)", t, func() {
 		r := SpecV2Resource{
 			Path: "/v1/cdns/{id}/v2/firewalls/v3/rules",
@@ -1118,7 +1297,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdn_v1")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewall_v2")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdn_v1_firewall_v2")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdn_v1_firewall_v2")
@@ -1163,7 +1342,7 @@ func TestParentResourceInfo(t *testing.T) {
 			Convey("And the parentResourceNames should not be empty and contain the right items", func() {
 				So(len(parentResourceInfo.parentResourceNames), ShouldEqual, 2)
 				So(parentResourceInfo.parentResourceNames[0], ShouldEqual, "cdn_v1")
-				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "firewall_v2")
+				So(parentResourceInfo.parentResourceNames[1], ShouldEqual, "cdn_v1_firewall_v2")
 			})
 			Convey("And the fullParentResourceName should match the expected name", func() {
 				So(parentResourceInfo.fullParentResourceName, ShouldEqual, "cdn_v1_firewall_v2")
@@ -1252,6 +1431,95 @@ func TestGetResourceSchema(t *testing.T) {
 		})
 	})
 
+	Convey("Given a read only SpecV2Resource containing a required and an optional property in the schema", t, func() {
+		r := &SpecV2Resource{
+			Path: "/regions",
+			InstancePathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfReadOnlyResource: true,
+					},
+				},
+			},
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Required: []string{"string_required_prop"},
+					Properties: map[string]spec.Schema{
+						"string_required_prop": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+						"bool_prop": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"boolean"},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceSchema is called", func() {
+			specSchemaDefinition, err := r.getResourceSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And all the properties should be read only and computed", func() {
+				assertSchemaProperty(specSchemaDefinition, "string_required_prop", typeString, false, true, true)
+				assertSchemaProperty(specSchemaDefinition, "bool_prop", typeBool, false, true, true)
+			})
+		})
+	})
+
+	Convey(fmt.Sprintf("Given a SpecV2Resource with the %s extension containing an immutable property in the schema", extTfImmutableForceNew), t, func() {
+		r := &SpecV2Resource{
+			Path: "/v1/cdns",
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfImmutableForceNew: true,
+					},
+				},
+			},
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"id": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+							SwaggerSchemaProps: spec.SwaggerSchemaProps{
+								ReadOnly: true,
+							},
+						},
+						"label": {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: spec.Extensions{
+									extTfImmutable: true,
+								},
+							},
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceSchema is called", func() {
+			specSchemaDefinition, err := r.getResourceSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the immutable property should force a new resource when updated", func() {
+				property, err := specSchemaDefinition.getProperty("label")
+				So(err, ShouldBeNil)
+				So(property.ForceNew, ShouldBeTrue)
+				So(property.Immutable, ShouldBeFalse)
+			})
+		})
+	})
+
 	Convey("Given a SpecV2Resource containing a sub-resource path (one level) that has a weird sub-resource path (firewalls is missing firewalls/{id}) and a schema definition with a property", t, func() {
 		r := &SpecV2Resource{
 			Path: "/v1/cdns/{id}/firewalls/v1/rules",
@@ -1390,7 +1658,7 @@ func TestGetResourceSchema(t *testing.T) {
 			})
 			Convey("And the specSchemaDefinition returned should also include the parents properties", func() {
 				assertSchemaParentProperty(specSchemaDefinition, "parent_id")
-				assertSchemaParentProperty(specSchemaDefinition, "subparent_id")
+				assertSchemaParentProperty(specSchemaDefinition, "parent_subparent_id")
 			})
 		})
 	})
@@ -1500,7 +1768,7 @@ func TestGetResourceSchema(t *testing.T) {
 			})
 			Convey("And the specSchemaDefinition returned should be configured with the parent id property too", func() {
 				assertSchemaParentProperty(specSchemaDefinition, "cdns_v1_id")
-				assertSchemaParentProperty(specSchemaDefinition, "firewalls_v2_id")
+				assertSchemaParentProperty(specSchemaDefinition, "cdns_v1_firewalls_v2_id")
 			})
 		})
 	})
@@ -1633,6 +1901,37 @@ func TestGetSchemaDefinitionWithOptions(t *testing.T) {
 				So(d, ShouldBeNil)
 			})
 		})
+		Convey("When getSchemaDefinitionWithOptions is called passing a schema with multiple properties using unsupported constructs", func() {
+			schema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"string_prop": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+						"one_of_prop": {
+							SchemaProps: spec.SchemaProps{
+								OneOf: []spec.Schema{*spec.StringProperty(), *spec.Int64Property()},
+							},
+						},
+						"all_of_prop": {
+							SchemaProps: spec.SchemaProps{
+								AllOf: []spec.Schema{*spec.StringProperty()},
+							},
+						},
+					},
+				},
+			}
+			d, e := r.getSchemaDefinitionWithOptions(&schema, true)
+			Convey("Then the error returned should list all the issues found", func() {
+				So(e, ShouldNotBeNil)
+				So(e.Error(), ShouldEqual, "found 2 issues: 1) failed to process property 'all_of_prop': allOf schema 0 is of type 'string', only object schemas can be composed; 2) failed to process property 'one_of_prop': the 'oneOf' construct is not supported, please define the property with an explicit type instead (e,g: 'type: object' with 'properties')")
+			})
+			Convey("And the schema definition returned is nil", func() {
+				So(d, ShouldBeNil)
+			})
+		})
 	})
 
 	Convey("Given a SpecV2Resource containing a sub-resource path (one level) with a schema containing a property that matches the parent property id", t, func() {
@@ -1805,6 +2104,25 @@ func TestGetResourcePath(t *testing.T) {
 		})
 	})
 
+	Convey("Given a SpecV2Resource with path resource that is parameterised (three levels sub-resource with hyphenated path parameters)", t, func() {
+		r := SpecV2Resource{
+			Path: "/vms/{vm-id}/disks/{disk-id}/snapshots/{snapshot-id}/backups",
+		}
+		Convey("When getResourcePath is called with a list of IDs", func() {
+			resourcePath, err := r.getResourcePath([]string{"vmID", "diskID", "snapshotID"})
+			Convey("Then the returned resource path should contain all the parent IDs", func() {
+				So(err, ShouldBeNil)
+				So(resourcePath, ShouldEqual, "/vms/vmID/disks/diskID/snapshots/snapshotID/backups")
+			})
+		})
+		Convey("When getResourcePath is called with a list of IDs missing the last parent ID", func() {
+			_, err := r.getResourcePath([]string{"vmID", "diskID"})
+			Convey("Then the error returned should not be nil", func() {
+				So(err.Error(), ShouldEqual, "could not resolve sub-resource path correctly '/vms/{vm-id}/disks/{disk-id}/snapshots/{snapshot-id}/backups' with the given ids - missing ids to resolve the path params properly: [vmID diskID]")
+			})
+		})
+	})
+
 	Convey("Given a SpecV2Resource with path resource that is parameterised (few levels sub-resource)", t, func() {
 		r := SpecV2Resource{
 			Path: "/v1/cdns/{cdn_id}/v1/firewalls/{fw_id}/rules",
@@ -2004,12 +2322,13 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
-		Convey("When createSchemaDefinitionProperty is called with a propertyName and non required propertySchema of type array with items of type string", func() {
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with NO nested properties and additionalProperties of type string", func() {
 			propertyName := "propertyName"
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
-					Items: &spec.SchemaOrArray{
+					Type: spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Allows: true,
 						Schema: &spec.Schema{
 							SchemaProps: spec.SchemaProps{
 								Type: spec.StringOrArray{"string"},
@@ -2022,34 +2341,201 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should be configured with the right name and type", func() {
+			Convey("And the schema definition property should be configured with the right name, map type and values type string", func() {
 				So(schemaDefinitionProperty.Name, ShouldEqual, propertyName)
-				So(schemaDefinitionProperty.Type, ShouldEqual, typeList)
-				So(schemaDefinitionProperty.ArrayItemsType, ShouldEqual, typeString)
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeMap)
+				So(schemaDefinitionProperty.AdditionalPropertiesType, ShouldEqual, typeString)
 				So(schemaDefinitionProperty.SpecSchemaDefinition, ShouldBeNil)
 			})
-			Convey("And the schema definition property should not be readOnly", func() {
-				So(schemaDefinitionProperty.isReadOnly(), ShouldBeFalse)
-			})
-			Convey("And the schema definition property should not be computed", func() {
-				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with NO nested properties and additionalProperties set to true", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:                 spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{Allows: true},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should not be required", func() {
-				So(schemaDefinitionProperty.isRequired(), ShouldBeFalse)
+			Convey("And the schema definition property should be configured as a map with values of type string", func() {
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeMap)
+				So(schemaDefinitionProperty.AdditionalPropertiesType, ShouldEqual, typeString)
 			})
 		})
 
-		Convey("When createSchemaDefinitionProperty is called with a propertyName and propertySchema non required of type array with items of type object (nested)", func() {
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with NO nested properties and additionalProperties of type object", func() {
 			propertyName := "propertyName"
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
-					Items: &spec.SchemaOrArray{
+					Type: spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Allows: true,
 						Schema: &spec.Schema{
 							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"object"},
+								Type:     spec.StringOrArray{"object"},
+								Required: []string{"host"},
 								Properties: map[string]spec.Schema{
-									"prop1": {
+									"host": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+									"port": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}},
+								},
+							},
+						},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be configured as a map with values of type object described by the spec schema definition", func() {
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeMap)
+				So(schemaDefinitionProperty.AdditionalPropertiesType, ShouldEqual, typeObject)
+				So(schemaDefinitionProperty.isMapOfObjectsProperty(), ShouldBeTrue)
+				host, err := schemaDefinitionProperty.SpecSchemaDefinition.getProperty("host")
+				So(err, ShouldBeNil)
+				So(host.Required, ShouldBeTrue)
+				port, err := schemaDefinitionProperty.SpecSchemaDefinition.getProperty("port")
+				So(err, ShouldBeNil)
+				So(port.Type, ShouldEqual, typeInt)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with additionalProperties of a non supported type", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Allows: true,
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+								},
+							},
+						},
+					},
+				},
+			}
+			_, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the error message should equal", func() {
+				So(err.Error(), ShouldEqual, "failed to process map type property 'propertyName': additionalProperties type 'list' not supported, only primitive and object types are supported")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with nested properties and additionalProperties of type string", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"objectProperty": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+					AdditionalProperties: &spec.SchemaOrBool{
+						Allows: true,
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be of type object keeping the fixed properties and allowing additional properties of type string", func() {
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeObject)
+				So(schemaDefinitionProperty.SpecSchemaDefinition.Properties, ShouldHaveLength, 1)
+				So(schemaDefinitionProperty.AdditionalPropertiesType, ShouldEqual, typeString)
+				So(schemaDefinitionProperty.allowsAdditionalProperties(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName, propertySchema of type object with nested properties and additionalProperties set to false", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"objectProperty": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+					AdditionalProperties: &spec.SchemaOrBool{Allows: false},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be of type object that does not allow additional properties", func() {
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeObject)
+				So(schemaDefinitionProperty.allowsAdditionalProperties(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName and non required propertySchema of type array with items of type string", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty(propertyName, propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be configured with the right name and type", func() {
+				So(schemaDefinitionProperty.Name, ShouldEqual, propertyName)
+				So(schemaDefinitionProperty.Type, ShouldEqual, typeList)
+				So(schemaDefinitionProperty.ArrayItemsType, ShouldEqual, typeString)
+				So(schemaDefinitionProperty.SpecSchemaDefinition, ShouldBeNil)
+			})
+			Convey("And the schema definition property should not be readOnly", func() {
+				So(schemaDefinitionProperty.isReadOnly(), ShouldBeFalse)
+			})
+			Convey("And the schema definition property should not be computed", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
+			})
+			Convey("And the schema definition property should not be required", func() {
+				So(schemaDefinitionProperty.isRequired(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a propertyName and propertySchema non required of type array with items of type object (nested)", func() {
+			propertyName := "propertyName"
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"prop1": {
 										SchemaProps: spec.SchemaProps{
 											Type: spec.StringOrArray{"string"},
 										},
@@ -2348,6 +2834,30 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-response-field-name' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfResponseFieldName: "password_hash",
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("password", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be configured with the response field name", func() {
+				So(schemaDefinitionProperty.ResponseFieldName, ShouldEqual, "password_hash")
+				So(schemaDefinitionProperty.getResponseFieldName(), ShouldEqual, "password_hash")
+			})
+			Convey("And the schema definition property name used in the requests should not change", func() {
+				So(schemaDefinitionProperty.Name, ShouldEqual, "password")
+			})
+		})
+
 		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-force-new' extension", func() {
 			expectedForceNewValue := true
 			propertySchema := spec.Schema{
@@ -2420,39 +2930,50 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			})
 		})
 
-		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-id' extension", func() {
-			expectedIsIdentifierValue := true
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the password format", func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
-				},
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: spec.Extensions{
-						extTfID: expectedIsIdentifierValue,
-					},
+					Type:   spec.StringOrArray{"string"},
+					Format: "password",
 				},
 			}
 			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should be marked as identifier", func() {
-				So(schemaDefinitionProperty.IsIdentifier, ShouldEqual, expectedIsIdentifierValue)
+			Convey("And the schema definition property should be sensitive", func() {
+				So(schemaDefinitionProperty.Sensitive, ShouldBeTrue)
 			})
-			Convey("And the schema definition property should not be computed", func() {
-				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a readOnly property schema that has the password format", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:   spec.StringOrArray{"string"},
+					Format: "password",
+				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be computed and sensitive", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeTrue)
+				So(schemaDefinitionProperty.Sensitive, ShouldBeTrue)
 			})
 		})
 
-		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-immutable' extension", func() {
-			expectedIsImmutableValue := true
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-nullable' extension", func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
 					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfImmutable: expectedIsImmutableValue,
+						extNullable: true,
 					},
 				},
 			}
@@ -2460,23 +2981,19 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should be immutable", func() {
-				So(schemaDefinitionProperty.Immutable, ShouldEqual, expectedIsImmutableValue)
-			})
-			Convey("And the schema definition property should not be computed", func() {
-				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
+			Convey("And the schema definition property should be nullable", func() {
+				So(schemaDefinitionProperty.Nullable, ShouldBeTrue)
 			})
 		})
 
-		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-field-status' extension", func() {
-			expectedIsStatusFieldValue := true
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-omit-when-empty' extension", func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
 					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfFieldStatus: expectedIsStatusFieldValue,
+						extTfOmitWhenEmpty: true,
 					},
 				},
 			}
@@ -2484,25 +3001,19 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should be marked as the status field", func() {
-				So(schemaDefinitionProperty.IsStatusIdentifier, ShouldEqual, expectedIsStatusFieldValue)
-			})
-			Convey("And the schema definition property should not be computed", func() {
-				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
+			Convey("And the schema definition property should be omitted when empty", func() {
+				So(schemaDefinitionProperty.OmitWhenEmpty, ShouldBeTrue)
 			})
 		})
 
-		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that has the %s extension (this means the property is optional-computed, and the value computed is not known at runtime)", extTfComputed), func() {
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-required-if' extension", func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
 					Type: spec.StringOrArray{"string"},
 				},
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfRequiredIf: map[string]interface{}{"storageType": "s3", "replicas": float64(2)},
 					},
 				},
 			}
@@ -2510,335 +3021,380 @@ func TestCreateSchemaDefinitionProperty(t *testing.T) {
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the schema definition property should be optional", func() {
-				So(schemaDefinitionProperty.isRequired(), ShouldBeFalse)
-			})
-			Convey("And the schema definition property should not be readOnly", func() {
-				So(schemaDefinitionProperty.isReadOnly(), ShouldBeFalse)
-			})
-			Convey("And the schema definition property should be computed", func() {
-				So(schemaDefinitionProperty.isComputed(), ShouldBeTrue)
-			})
-			Convey("And the schema definition property should have a nil default value", func() {
-				So(schemaDefinitionProperty.Default, ShouldBeNil)
+			Convey("And the schema definition property should contain the conditions and remain optional", func() {
+				So(schemaDefinitionProperty.RequiredIf, ShouldResemble, map[string]interface{}{"storageType": "s3", "replicas": float64(2)})
+				So(schemaDefinitionProperty.isOptional(), ShouldBeTrue)
 			})
 		})
 
-		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that violates one optional-computed validation (properties with default attributes cannot have the %s extension too, that does not make any sense)", extTfComputed), func() {
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has a 'x-terraform-required-if' extension which value is not an object", func() {
 			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type:    spec.StringOrArray{"string"},
-					Default: "someDefaultValue",
+					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfRequiredIf: "storageType",
 					},
 				},
 			}
-			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
-			})
-			Convey(fmt.Sprintf("Then the error message returned should state that properties with the %s extension can not have a default value attached", extTfComputed), func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties with default attributes should not have 'x-terraform-computed' extension too")
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': x-terraform-required-if extension must be an object containing the property names and the values that make the property required")
 			})
-			Convey("And the schema definition property returned should be nil", func() {
-				So(schemaDefinitionProperty, ShouldBeNil)
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has a 'x-terraform-required-if' extension with a condition value that is not primitive", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfRequiredIf: map[string]interface{}{"storageType": []interface{}{"s3"}},
+					},
+				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': x-terraform-required-if extension value for property 'storageType' must be a string, number or boolean")
 			})
 		})
 
-		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that violates one optional-computed validation (properties with %s extension, should not be readOnly)", extTfComputed), func() {
+		Convey("When createSchemaDefinitionProperty is called with a required property schema that has the 'x-terraform-required-if' extension", func() {
 			propertySchema := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: true,
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfRequiredIf: map[string]interface{}{"storageType": "s3"},
+					},
 				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{"propertyName"})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': the x-terraform-required-if extension is only supported in optional properties")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a writable property schema that has the 'x-terraform-force-computed' extension", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
 					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfForceComputed: true,
 					},
 				},
 			}
 			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+				So(err, ShouldBeNil)
 			})
-			Convey(fmt.Sprintf("Then the error message returned should state that properties with the %s extension can not be readOnly", extTfComputed), func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
+			Convey("And the schema definition property should be treated as readOnly and computed", func() {
+				So(schemaDefinitionProperty.isReadOnly(), ShouldBeTrue)
+				So(schemaDefinitionProperty.isComputed(), ShouldBeTrue)
 			})
-			Convey("And the schema definition property returned should be nil", func() {
-				So(schemaDefinitionProperty, ShouldBeNil)
+			Convey("And the terraform schema should be computed", func() {
+				terraformSchema, err := schemaDefinitionProperty.terraformSchema()
+				So(err, ShouldBeNil)
+				So(terraformSchema.Computed, ShouldBeTrue)
+				So(terraformSchema.Required, ShouldBeFalse)
 			})
 		})
-	})
-}
 
-func TestIsBoolExtensionEnabled(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		testCases := []struct {
-			name           string
-			extensions     spec.Extensions
-			extension      string
-			expectedResult bool
-		}{
-			{name: "nil extensions", extensions: nil, extension: "", expectedResult: false},
-			{name: "empty extensions", extensions: spec.Extensions{}, extension: "", expectedResult: false},
-			{name: "populated extensions but empty extension", extensions: spec.Extensions{"some-extension": true}, extension: "", expectedResult: false},
-			{name: "populated extensions and matching bool extension with value true", extensions: spec.Extensions{"some-extension": true}, extension: "some-extension", expectedResult: true},
-			{name: "populated extensions and matching bool extension with value false", extensions: spec.Extensions{"some-extension": false}, extension: "some-extension", expectedResult: false},
-			{name: "populated extensions and matching non bool extension", extensions: spec.Extensions{"some-extension": "some value"}, extension: "some-extension", expectedResult: false},
-		}
-		for _, tc := range testCases {
-			Convey(fmt.Sprintf("When isBoolExtensionEnabled method is called: %s", tc.name), func() {
-				isEnabled := r.isBoolExtensionEnabled(tc.extensions, tc.extension)
-				Convey("Then the result returned should be the expected one", func() {
-					So(isEnabled, ShouldEqual, tc.expectedResult)
-				})
-			})
-		}
-	})
-}
-
-func TestIsOptionalComputedProperty(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isOptionalComputedProperty method is called with a property that is NOT optional", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-force-computed' extension set to false", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: "some_defaul_value",
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfForceComputed: false,
+					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_required_property_name", property, []string{"some_required_property_name"})
-			Convey("Then the error returned should be nil", func() {
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil and the schema definition property should remain purely optional", func() {
 				So(err, ShouldBeNil)
-			})
-			Convey("AND the result returned should be false since the property is not optional", func() {
-				So(isOptionalComputedProperty, ShouldBeFalse)
+				So(schemaDefinitionProperty.isReadOnly(), ShouldBeFalse)
+				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
 			})
 		})
-		Convey("When isOptionalComputedProperty method is called with a property that is optional, and matches the OptionalComputedWithDefault requirements (no computed and has a default value)", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
+
+		Convey("When createSchemaDefinitionProperty is called with a required property schema that has the 'x-terraform-force-computed' extension", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: "some_defaul_value",
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfForceComputed: true,
+					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("AND the result returned should be true since the property is optional computed ", func() {
-				So(isOptionalComputedProperty, ShouldBeTrue)
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{"propertyName"})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': a required property cannot be marked with the x-terraform-force-computed extension")
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputedProperty method is called with a property that is optional, and matches the isOptionalComputed requirements (no computed and has the %s extension)", extTfComputed), func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-write-only' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfWriteOnly: true,
 					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{"propertyName"})
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("AND the result returned should be true since the property is optional computed ", func() {
-				So(isOptionalComputedProperty, ShouldBeTrue)
+			Convey("And the schema definition property should be write-only", func() {
+				So(schemaDefinitionProperty.WriteOnly, ShouldBeTrue)
+				So(schemaDefinitionProperty.isRequired(), ShouldBeTrue)
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputedProperty method is called with a property that is optional, and DOES NOT pass the validation as far as isOptionalComputed requirements is concerned (properties with %s extension cannot be readOnly)", extTfComputed), func() {
-			property := spec.Schema{
+
+		Convey("When createSchemaDefinitionProperty is called with a readOnly property schema that has the 'x-terraform-write-only' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
 				SwaggerSchemaProps: spec.SwaggerSchemaProps{
 					ReadOnly: true,
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfWriteOnly: true,
 					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
-			Convey("Then the error returned should not be nil", func() {
-				So(err, ShouldNotBeNil)
-			})
-			Convey("Then the error message returned should not be the expected one", func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'some_optional_property_name': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
-			})
-			Convey("AND the result returned should be false since the property is NOT optional computed ", func() {
-				So(isOptionalComputedProperty, ShouldBeFalse)
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': a readOnly property cannot be marked with the x-terraform-write-only extension")
 			})
 		})
-		Convey("When isOptionalComputedProperty method is called with a property that not optional computed at all (e,g: property is just computed)", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: true,
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-file-content' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfFileContent: fileContentPath,
+					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("AND the result returned should be true since the property is NOT optional computed ", func() {
-				So(isOptionalComputedProperty, ShouldBeFalse)
+			Convey("And the schema definition property should be a write-only file content", func() {
+				So(schemaDefinitionProperty.FileContent, ShouldEqual, fileContentPath)
+				So(schemaDefinitionProperty.WriteOnly, ShouldBeTrue)
 			})
 		})
-	})
-}
 
-func TestIsOptionalComputedWithDefault(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isOptionalComputedWithDefault method is called with a property that is NOT readOnly and has a default attribute", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
+		Convey("When createSchemaDefinitionProperty is called with property schemas that have a 'x-terraform-file-content' extension that is not valid", func() {
+			testCases := []struct {
+				propertyType  string
+				readOnly      bool
+				fileContent   interface{}
+				expectedError string
+			}{
+				{propertyType: "string", fileContent: "hex", expectedError: "failed to process property 'propertyName': the x-terraform-file-content extension value must be one of path or base64"},
+				{propertyType: "integer", fileContent: fileContentBase64, expectedError: "failed to process property 'propertyName': the x-terraform-file-content extension is only supported in string properties that are not readOnly"},
+				{propertyType: "string", readOnly: true, fileContent: fileContentBase64, expectedError: "failed to process property 'propertyName': the x-terraform-file-content extension is only supported in string properties that are not readOnly"},
+			}
+			Convey("Then the errors returned should be the expected", func() {
+				for _, tc := range testCases {
+					propertySchema := spec.Schema{
+						SchemaProps:        spec.SchemaProps{Type: spec.StringOrArray{tc.propertyType}},
+						SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: tc.readOnly},
+						VendorExtensible:   spec.VendorExtensible{Extensions: spec.Extensions{extTfFileContent: tc.fileContent}},
+					}
+					_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				}
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-normalize' extension", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: "some_defaul_value",
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfNormalize: []interface{}{"trim", "lowercase"},
+					},
 				},
 			}
-			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("Then the result returned should be true since the property matches the requirements to be an optional computed property", func() {
-				So(isOptionalComputedWithDefault, ShouldBeTrue)
+			Convey("And the schema definition property should contain the normalizations in the declared order", func() {
+				So(schemaDefinitionProperty.Normalizations, ShouldResemble, []string{normalizeTrim, normalizeLowercase})
 			})
 		})
-		Convey("When isOptionalComputedWithDefault method is called with a property that is readOnly and has a default attribute", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: true,
-				},
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has a 'x-terraform-normalize' extension which value is not a list", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: "some_defaul_value",
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfNormalize: "lowercase",
+					},
 				},
 			}
-			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputedWithDefault, ShouldBeFalse)
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': x-terraform-normalize extension must be a list containing any of the following normalizations: lowercase, trim, trim-trailing-slash, collapse-whitespace, json")
 			})
 		})
-		Convey("When isOptionalComputedWithDefault method is called with a property that is NOT readOnly and has NO default attribute", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has a 'x-terraform-normalize' extension with a non supported normalization", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: nil,
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfNormalize: []interface{}{"lowercase", "uppercase"},
+					},
 				},
 			}
-			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputedWithDefault, ShouldBeFalse)
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': x-terraform-normalize extension contains a non supported normalization 'uppercase'")
 			})
 		})
-		Convey("When isOptionalComputedWithDefault method is called with a property that is just readOnly", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: true,
+
+		Convey("When createSchemaDefinitionProperty is called with a non string property schema that has the 'x-terraform-normalize' extension", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"integer"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfNormalize: []interface{}{"lowercase"},
+					},
 				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': the x-terraform-normalize extension is only supported in string properties")
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'nullable' attribute", func() {
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: nil,
+					Type: spec.StringOrArray{"string"},
+				},
+				ExtraProps: map[string]interface{}{
+					"nullable": true,
 				},
 			}
-			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputedWithDefault, ShouldBeFalse)
+			Convey("And the schema definition property should be nullable", func() {
+				So(schemaDefinitionProperty.Nullable, ShouldBeTrue)
 			})
 		})
-		Convey("When isOptionalComputedWithDefault method is called with a property that does not pass the validation phase since it has a default value AND the extension, this is wrong documentation", func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
-				},
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-id' extension", func() {
+			expectedIsIdentifierValue := true
+			propertySchema := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Default: "some_value",
+					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfID: expectedIsIdentifierValue,
 					},
 				},
 			}
-			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
 			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+				So(err, ShouldBeNil)
 			})
-			Convey("Then the error message returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties with default attributes should not have 'x-terraform-computed' extension too")
+			Convey("And the schema definition property should be marked as identifier", func() {
+				So(schemaDefinitionProperty.IsIdentifier, ShouldEqual, expectedIsIdentifierValue)
 			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputedWithDefault, ShouldBeFalse)
+			Convey("And the schema definition property should not be computed", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
 			})
 		})
-	})
-}
 
-func TestIsOptionalComputed(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is NOT computed (readOnly) and has the extension %s with value true", extTfComputed), func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-immutable' extension", func() {
+			expectedIsImmutableValue := true
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: true,
+						extTfImmutable: expectedIsImmutableValue,
 					},
 				},
 			}
-			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
-			Convey("Then the result returned should not return an error", func() {
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("Then the result returned should be true since the property matches the requirements to be an optional computed property", func() {
-				So(isOptionalComputed, ShouldBeTrue)
+			Convey("And the schema definition property should be immutable", func() {
+				So(schemaDefinitionProperty.Immutable, ShouldEqual, expectedIsImmutableValue)
+			})
+			Convey("And the schema definition property should not be computed", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is NOT computed (readOnly) and has the extension %s with value false", extTfComputed), func() {
-			property := spec.Schema{
-				VendorExtensible: spec.VendorExtensible{
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema that has the 'x-terraform-field-status' extension", func() {
+			expectedIsStatusFieldValue := true
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfComputed: false,
+						extTfFieldStatus: expectedIsStatusFieldValue,
 					},
 				},
 			}
-			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
-			Convey("Then the result returned should not return an error", func() {
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputed, ShouldBeFalse)
+			Convey("And the schema definition property should be marked as the status field", func() {
+				So(schemaDefinitionProperty.IsStatusIdentifier, ShouldEqual, expectedIsStatusFieldValue)
+			})
+			Convey("And the schema definition property should not be computed", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeFalse)
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is computed (readOnly) and has the extension %s with value true", extTfComputed), func() {
-			property := spec.Schema{
+
+		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that has the %s extension (this means the property is optional-computed, and the value computed is not known at runtime)", extTfComputed), func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
 				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: true, // this specifies that the property is computed
+					ReadOnly: false,
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
@@ -2846,24 +3402,55 @@ func TestIsOptionalComputed(t *testing.T) {
 					},
 				},
 			}
-			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
-			Convey(fmt.Sprintf("Then the result returned should not be nil since properties with the %s extension cannot be computed,", extTfComputed), func() {
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema definition property should be optional", func() {
+				So(schemaDefinitionProperty.isRequired(), ShouldBeFalse)
+			})
+			Convey("And the schema definition property should not be readOnly", func() {
+				So(schemaDefinitionProperty.isReadOnly(), ShouldBeFalse)
+			})
+			Convey("And the schema definition property should be computed", func() {
+				So(schemaDefinitionProperty.isComputed(), ShouldBeTrue)
+			})
+			Convey("And the schema definition property should have a nil default value", func() {
+				So(schemaDefinitionProperty.Default, ShouldBeNil)
+			})
+		})
+
+		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that violates one optional-computed validation (properties with default attributes cannot have the %s extension too, that does not make any sense)", extTfComputed), func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type:    spec.StringOrArray{"string"},
+					Default: "someDefaultValue",
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
+					},
+				},
+			}
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
-			Convey("And the error message should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
+			Convey(fmt.Sprintf("Then the error message returned should state that properties with the %s extension can not have a default value attached", extTfComputed), func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties with default attributes should not have 'x-terraform-computed' extension too")
 			})
-			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
-				So(isOptionalComputed, ShouldBeFalse)
+			Convey("And the schema definition property returned should be nil", func() {
+				So(schemaDefinitionProperty, ShouldBeNil)
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is optional, and DOES NOT pass the validation as far as isOptionalComputed requirements is concerned (properties with %s extension cannot have default value populated)", extTfComputed), func() {
-			property := spec.Schema{
+
+		Convey(fmt.Sprintf("When createSchemaDefinitionProperty is called with an optional property schema that violates one optional-computed validation (properties with %s extension, should not be readOnly)", extTfComputed), func() {
+			propertySchema := spec.Schema{
 				SwaggerSchemaProps: spec.SwaggerSchemaProps{
-					ReadOnly: false,
+					ReadOnly: true,
 				},
 				SchemaProps: spec.SchemaProps{
-					Default: "some_defaul_value",
+					Type: spec.StringOrArray{"string"},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
@@ -2871,1487 +3458,3491 @@ func TestIsOptionalComputed(t *testing.T) {
 					},
 				},
 			}
-			isOptionalComputedProperty, err := r.isOptionalComputed("some_optional_property_name", property)
-			Convey("Then the error returned should not be nil", func() {
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
-			Convey("Then the error message returned should not be the expected one", func() {
-				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'some_optional_property_name': optional computed properties marked with 'x-terraform-computed' can not have the default value as the value is not known at plan time. If the value is known, then this extension should not be used, and rather the 'default' attribute should be populated")
+			Convey(fmt.Sprintf("Then the error message returned should state that properties with the %s extension can not be readOnly", extTfComputed), func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
 			})
-			Convey("AND the result returned should be false since the property is NOT optional computed ", func() {
-				So(isOptionalComputedProperty, ShouldBeFalse)
+			Convey("And the schema definition property returned should be nil", func() {
+				So(schemaDefinitionProperty, ShouldBeNil)
 			})
 		})
-		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that DOES NOT have the extension %s present", extTfComputed), func() {
-			property := spec.Schema{
-				SwaggerSchemaProps: spec.SwaggerSchemaProps{},
+
+		Convey("When createSchemaDefinitionProperty is called with a property schema using the oneOf construct", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					OneOf: []spec.Schema{*spec.StringProperty(), *spec.Int64Property()},
+				},
 			}
-			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
-			Convey("Then the result returned should not return an error", func() {
-				So(err, ShouldBeNil)
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error message returned should name the property and the unsupported construct", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to process property 'propertyName': the 'oneOf' construct is not supported, please define the property with an explicit type instead (e,g: 'type: object' with 'properties')")
 			})
-			Convey("Then the result returned should be false", func() {
-				So(isOptionalComputed, ShouldBeFalse)
+			Convey("And the schema definition property returned should be nil", func() {
+				So(schemaDefinitionProperty, ShouldBeNil)
+			})
+		})
+
+		Convey("When createSchemaDefinitionProperty is called with an object property schema containing a nested property using the not construct", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"nested_prop": {
+							SchemaProps: spec.SchemaProps{
+								Not: spec.StringProperty(),
+							},
+						},
+					},
+				},
+			}
+			_, err := r.createSchemaDefinitionProperty("propertyName", propertySchema, []string{})
+			Convey("Then the error message returned should name the object property, the nested property and the unsupported construct", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "failed to process object type property 'propertyName': failed to process property 'nested_prop': the 'not' construct is not supported, please define the property with an explicit type instead (e,g: 'type: object' with 'properties')")
 			})
 		})
 	})
 }
 
-func TestIsArrayItemPrimitiveType(t *testing.T) {
+func TestIsBoolExtensionEnabled(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeString", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeString)
-			Convey("Then the result returned should be true", func() {
-				So(isPrimitive, ShouldBeTrue)
-			})
-		})
-		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeInt", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeInt)
-			Convey("Then the result returned should be true", func() {
-				So(isPrimitive, ShouldBeTrue)
-			})
-		})
-		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeFloat", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeFloat)
-			Convey("Then the result returned should be true", func() {
-				So(isPrimitive, ShouldBeTrue)
-			})
-		})
-		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeBool", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeBool)
-			Convey("Then the result returned should be true", func() {
-				So(isPrimitive, ShouldBeTrue)
-			})
-		})
-		Convey("When isArrayItemPrimitiveType method is called with a NON primitive type typeList", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeList)
-			Convey("Then the result returned should be false", func() {
-				So(isPrimitive, ShouldBeFalse)
-			})
-		})
-		Convey("When isArrayItemPrimitiveType method is called with a NON primitive type typeObject", func() {
-			isPrimitive := r.isArrayItemPrimitiveType(typeObject)
-			Convey("Then the result returned should be false", func() {
-				So(isPrimitive, ShouldBeFalse)
+		testCases := []struct {
+			name           string
+			extensions     spec.Extensions
+			extension      string
+			expectedResult bool
+		}{
+			{name: "nil extensions", extensions: nil, extension: "", expectedResult: false},
+			{name: "empty extensions", extensions: spec.Extensions{}, extension: "", expectedResult: false},
+			{name: "populated extensions but empty extension", extensions: spec.Extensions{"some-extension": true}, extension: "", expectedResult: false},
+			{name: "populated extensions and matching bool extension with value true", extensions: spec.Extensions{"some-extension": true}, extension: "some-extension", expectedResult: true},
+			{name: "populated extensions and matching bool extension with value false", extensions: spec.Extensions{"some-extension": false}, extension: "some-extension", expectedResult: false},
+			{name: "populated extensions and matching non bool extension", extensions: spec.Extensions{"some-extension": "some value"}, extension: "some-extension", expectedResult: false},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When isBoolExtensionEnabled method is called: %s", tc.name), func() {
+				isEnabled := r.isBoolExtensionEnabled(tc.extensions, tc.extension)
+				Convey("Then the result returned should be the expected one", func() {
+					So(isEnabled, ShouldEqual, tc.expectedResult)
+				})
 			})
-		})
+		}
 	})
 }
 
-func TestValidateArrayItems(t *testing.T) {
+func TestIsOptionalComputedProperty(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When validateArrayItems method is called with a property that does not have items", func() {
-			property := spec.Schema{}
-			_, err := r.validateArrayItems(property)
-			Convey("The error should not be nil", func() {
-				So(err, ShouldNotBeNil)
-			})
-			Convey("And the error message should be the expected", func() {
-				So(err.Error(), ShouldEqual, "array property is missing items schema definition")
-			})
-		})
-		Convey("When validateArrayItems method is called with a property that does have items but they lack the schema", func() {
+		Convey("When isOptionalComputedProperty method is called with a property that is NOT optional", func() {
 			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
 				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						// no schema :(
-					},
+					Default: "some_defaul_value",
 				},
 			}
-			_, err := r.validateArrayItems(property)
-			Convey("The error should not be nil", func() {
-				So(err, ShouldNotBeNil)
+			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_required_property_name", property, []string{"some_required_property_name"})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("And the error message should be the expected", func() {
-				So(err.Error(), ShouldEqual, "array property is missing items schema definition")
+			Convey("AND the result returned should be false since the property is not optional", func() {
+				So(isOptionalComputedProperty, ShouldBeFalse)
 			})
 		})
-		Convey("When validateArrayItems method is called with a property that does have items and a schema BUT the items are of type array (this is not supported at the moment)", func() {
+		Convey("When isOptionalComputedProperty method is called with a property that is optional, and matches the OptionalComputedWithDefault requirements (no computed and has a default value)", func() {
 			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
 				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{
-							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"array"},
-							},
-						},
-					},
+					Default: "some_defaul_value",
 				},
 			}
-			_, err := r.validateArrayItems(property)
-			Convey("The error should not be nil", func() {
-				So(err, ShouldNotBeNil)
+			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("And the error message should be the expected", func() {
-				So(err.Error(), ShouldEqual, "array property can not have items of type 'array'")
+			Convey("AND the result returned should be true since the property is optional computed ", func() {
+				So(isOptionalComputedProperty, ShouldBeTrue)
 			})
 		})
-		Convey("When validateArrayItems method is called with an array of unknown type items", func() {
+		Convey(fmt.Sprintf("When isOptionalComputedProperty method is called with a property that is optional, and matches the isOptionalComputed requirements (no computed and has the %s extension)", extTfComputed), func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{
-							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"unknown"},
-							},
-						},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
 					},
 				},
 			}
-			_, err := r.validateArrayItems(property)
-			Convey("The error should not be nil", func() {
-				So(err, ShouldNotBeNil)
+			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("And the error message should be the expected", func() {
-				So(err.Error(), ShouldEqual, "non supported '[unknown]' type")
+			Convey("AND the result returned should be true since the property is optional computed ", func() {
+				So(isOptionalComputedProperty, ShouldBeTrue)
 			})
 		})
-		Convey("When validateArrayItems method is called with a valid array property that has items of type string", func() {
+		Convey(fmt.Sprintf("When isOptionalComputedProperty method is called with a property that is optional, and DOES NOT pass the validation as far as isOptionalComputed requirements is concerned (properties with %s extension cannot be readOnly)", extTfComputed), func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{
-							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"string"},
-							},
-						},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
 					},
 				},
 			}
-			itemsPropType, err := r.validateArrayItems(property)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
+			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
 			})
-			Convey("And the type of the items should match the expected string", func() {
-				So(itemsPropType, ShouldEqual, typeString)
+			Convey("Then the error message returned should not be the expected one", func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'some_optional_property_name': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
+			})
+			Convey("AND the result returned should be false since the property is NOT optional computed ", func() {
+				So(isOptionalComputedProperty, ShouldBeFalse)
 			})
 		})
-		Convey("When validateArrayItems method is called with a valid array property that has items of type object", func() {
+		Convey("When isOptionalComputedProperty method is called with a property that not optional computed at all (e,g: property is just computed)", func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{
-							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"object"},
-								Properties: map[string]spec.Schema{
-									"prop1": {},
-								},
-							},
-						},
-					},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
 				},
 			}
-			itemsPropType, err := r.validateArrayItems(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputedProperty, err := r.isOptionalComputedProperty("some_optional_property_name", property, []string{"some_required_property_name"})
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the type of the items should match the expected object", func() {
-				So(itemsPropType, ShouldEqual, typeObject)
+			Convey("AND the result returned should be true since the property is NOT optional computed ", func() {
+				So(isOptionalComputedProperty, ShouldBeFalse)
 			})
 		})
 	})
 }
 
-func TestGetPropertyType(t *testing.T) {
+func TestIsOptionalComputedWithDefault(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When getPropertyType method is called with a property of type array", func() {
+		Convey("When isOptionalComputedWithDefault method is called with a property that is NOT readOnly and has a default attribute", func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
 				},
-			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("And the type of the items should match the expected array", func() {
-				So(itemsPropType, ShouldEqual, typeList)
-			})
-		})
-
-		Convey("When getPropertyType method is called with a property of type object", func() {
-			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
-					Properties: map[string]spec.Schema{
-						"prop1": {},
-					},
+					Default: "some_defaul_value",
 				},
 			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the type of the items should match the expected object", func() {
-				So(itemsPropType, ShouldEqual, typeObject)
+			Convey("Then the result returned should be true since the property matches the requirements to be an optional computed property", func() {
+				So(isOptionalComputedWithDefault, ShouldBeTrue)
 			})
 		})
-
-		Convey("When getPropertyType method is called with a property of type string", func() {
+		Convey("When isOptionalComputedWithDefault method is called with a property that is readOnly and has a default attribute", func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
 				},
-			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("And the type of the items should match the expected string", func() {
-				So(itemsPropType, ShouldEqual, typeString)
-			})
-		})
-
-		Convey("When getPropertyType method is called with a property of type integer", func() {
-			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"integer"},
+					Default: "some_defaul_value",
 				},
 			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the type of the items should match the expected integer", func() {
-				So(itemsPropType, ShouldEqual, typeInt)
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputedWithDefault, ShouldBeFalse)
 			})
 		})
-
-		Convey("When getPropertyType method is called with a property of type float", func() {
+		Convey("When isOptionalComputedWithDefault method is called with a property that is NOT readOnly and has NO default attribute", func() {
 			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"number"},
+					Default: nil,
 				},
 			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the type of the items should match the expected float", func() {
-				So(itemsPropType, ShouldEqual, typeFloat)
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputedWithDefault, ShouldBeFalse)
 			})
 		})
-
-		Convey("When getPropertyType method is called with a property of type bool", func() {
+		Convey("When isOptionalComputedWithDefault method is called with a property that is just readOnly", func() {
 			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true,
+				},
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"boolean"},
+					Default: nil,
 				},
 			}
-			itemsPropType, err := r.getPropertyType(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the type of the items should match the expected bool", func() {
-				So(itemsPropType, ShouldEqual, typeBool)
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputedWithDefault, ShouldBeFalse)
 			})
 		})
-
-		Convey("When getPropertyType method is called with a property of type non supported", func() {
+		Convey("When isOptionalComputedWithDefault method is called with a property that does not pass the validation phase since it has a default value AND the extension, this is wrong documentation", func() {
 			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"non supported"},
+					Default: "some_value",
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
+					},
 				},
 			}
-			_, err := r.getPropertyType(property)
-			Convey("The error should NOT be nil", func() {
+			isOptionalComputedWithDefault, err := r.isOptionalComputedWithDefault("propertyName", property)
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
-			Convey("And the error returned should be ", func() {
-				So(err.Error(), ShouldEqual, "non supported '[non supported]' type")
+			Convey("Then the error message returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties with default attributes should not have 'x-terraform-computed' extension too")
+			})
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputedWithDefault, ShouldBeFalse)
 			})
 		})
 	})
 }
 
-func TestResourceIsObjectProperty(t *testing.T) {
+func TestIsOptionalComputed(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When isObjectProperty method is called with a property of type object that has nested properties", func() {
+		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is NOT computed (readOnly) and has the extension %s with value true", extTfComputed), func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
-					Properties: map[string]spec.Schema{
-						"prop1": {},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
 					},
 				},
 			}
-			isObject, objectSchema, err := r.isObjectProperty(property)
-			Convey("The error should be nil", func() {
+			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
+			Convey("Then the result returned should not return an error", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the result your be true", func() {
-				So(isObject, ShouldBeTrue)
-			})
-			Convey("And the object schema should not be nil", func() {
-				So(objectSchema, ShouldNotBeNil)
+			Convey("Then the result returned should be true since the property matches the requirements to be an optional computed property", func() {
+				So(isOptionalComputed, ShouldBeTrue)
 			})
 		})
-		Convey("When isObjectProperty method is called with a property of type object that has a ref to an external schema but is missing the type", func() {
-			r := SpecV2Resource{
-				SchemaDefinitions: map[string]spec.Schema{
-					"Listeners": {
-						SchemaProps: spec.SchemaProps{
-							Type: spec.StringOrArray{"object"},
-							Properties: map[string]spec.Schema{
-								"protocol": {
-									SchemaProps: spec.SchemaProps{
-										Type: spec.StringOrArray{"string"},
-									},
-								},
-							},
-						},
+		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is NOT computed (readOnly) and has the extension %s with value false", extTfComputed), func() {
+			property := spec.Schema{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: false,
 					},
 				},
 			}
-			propertySchema := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					//Type: spec.StringOrArray{"object"}, // Missing type info but still should be considered as object
-					Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
-				},
-			}
-			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
-			Convey("The error should be nil", func() {
+			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
+			Convey("Then the result returned should not return an error", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the result your be true", func() {
-				So(isObject, ShouldBeTrue)
-			})
-			Convey("And the object schema should not be nil", func() {
-				So(objectSchema, ShouldNotBeNil)
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputed, ShouldBeFalse)
 			})
 		})
-		Convey("When isObjectProperty method is called with a property of type object that has a ref to an external schema and also has the type", func() {
-			r := SpecV2Resource{
-				SchemaDefinitions: map[string]spec.Schema{
-					"Listeners": {
-						SchemaProps: spec.SchemaProps{
-							Type: spec.StringOrArray{"object"},
-							Properties: map[string]spec.Schema{
-								"protocol": {
-									SchemaProps: spec.SchemaProps{
-										Type: spec.StringOrArray{"string"},
-									},
-								},
-							},
-						},
+		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is computed (readOnly) and has the extension %s with value true", extTfComputed), func() {
+			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: true, // this specifies that the property is computed
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
 					},
 				},
 			}
-			propertySchema := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
-					Ref:  spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
-				},
-			}
-			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
+			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
+			Convey(fmt.Sprintf("Then the result returned should not be nil since properties with the %s extension cannot be computed,", extTfComputed), func() {
+				So(err, ShouldNotBeNil)
 			})
-			Convey("And the result your be true", func() {
-				So(isObject, ShouldBeTrue)
+			Convey("And the error message should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'propertyName': optional computed properties marked with 'x-terraform-computed' can not be readOnly")
 			})
-			Convey("And the object schema should not be nil", func() {
-				So(objectSchema, ShouldNotBeNil)
+			Convey("Then the result returned should be false since the property DOES NOT match the requirements to be an optional computed property", func() {
+				So(isOptionalComputed, ShouldBeFalse)
 			})
 		})
-
-		Convey("When isObjectProperty method is called with a property of type object that has a ref to a non existing schema", func() {
-			propertySchema := spec.Schema{
+		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that is optional, and DOES NOT pass the validation as far as isOptionalComputed requirements is concerned (properties with %s extension cannot have default value populated)", extTfComputed), func() {
+			property := spec.Schema{
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{
+					ReadOnly: false,
+				},
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
-					Ref:  spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/nonExisting")},
+					Default: "some_defaul_value",
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfComputed: true,
+					},
 				},
 			}
-			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
-			Convey("The error should NOT be nil", func() {
+			isOptionalComputedProperty, err := r.isOptionalComputed("some_optional_property_name", property)
+			Convey("Then the error returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
-			Convey("The error message should be the expected", func() {
-				So(err.Error(), ShouldEqual, "object ref is poitning to a non existing schema definition: missing schema definition in the swagger file with the supplied ref '#/definitions/nonExisting'")
-			})
-			Convey("And the result your be true", func() {
-				So(isObject, ShouldBeTrue)
+			Convey("Then the error message returned should not be the expected one", func() {
+				So(err.Error(), ShouldEqual, "optional computed property validation failed for property 'some_optional_property_name': optional computed properties marked with 'x-terraform-computed' can not have the default value as the value is not known at plan time. If the value is known, then this extension should not be used, and rather the 'default' attribute should be populated")
 			})
-			Convey("And the object schema should be nil", func() {
-				So(objectSchema, ShouldBeNil)
+			Convey("AND the result returned should be false since the property is NOT optional computed ", func() {
+				So(isOptionalComputedProperty, ShouldBeFalse)
 			})
 		})
-
-		Convey("When isObjectProperty method is called with a property that has nested schema with no properties", func() {
+		Convey(fmt.Sprintf("When isOptionalComputed method is called with a property that DOES NOT have the extension %s present", extTfComputed), func() {
 			property := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Items: &spec.SchemaOrArray{
-						Schema: &spec.Schema{},
-					},
-				},
+				SwaggerSchemaProps: spec.SwaggerSchemaProps{},
 			}
-			isObject, objectSchema, err := r.isObjectProperty(property)
-			Convey("The error should NOT be nil", func() {
+			isOptionalComputed, err := r.isOptionalComputed("propertyName", property)
+			Convey("Then the result returned should not return an error", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the result your be false", func() {
-				So(isObject, ShouldBeFalse)
-			})
-			Convey("And the object schema should be nil", func() {
-				So(objectSchema, ShouldBeNil)
+			Convey("Then the result returned should be false", func() {
+				So(isOptionalComputed, ShouldBeFalse)
 			})
 		})
+	})
+}
 
-		Convey("When isObjectProperty method is called with a property of type string", func() {
-			propertySchema := spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
-				},
-			}
-			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
+func TestIsArrayItemPrimitiveType(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeString", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeString)
+			Convey("Then the result returned should be true", func() {
+				So(isPrimitive, ShouldBeTrue)
 			})
-			Convey("And the result your be false", func() {
-				So(isObject, ShouldBeFalse)
+		})
+		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeInt", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeInt)
+			Convey("Then the result returned should be true", func() {
+				So(isPrimitive, ShouldBeTrue)
 			})
-			Convey("And the object schema should be nil", func() {
-				So(objectSchema, ShouldBeNil)
+		})
+		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeFloat", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeFloat)
+			Convey("Then the result returned should be true", func() {
+				So(isPrimitive, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayItemPrimitiveType method is called with a primitive type typeBool", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeBool)
+			Convey("Then the result returned should be true", func() {
+				So(isPrimitive, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayItemPrimitiveType method is called with a NON primitive type typeList", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeList)
+			Convey("Then the result returned should be false", func() {
+				So(isPrimitive, ShouldBeFalse)
+			})
+		})
+		Convey("When isArrayItemPrimitiveType method is called with a NON primitive type typeObject", func() {
+			isPrimitive := r.isArrayItemPrimitiveType(typeObject)
+			Convey("Then the result returned should be false", func() {
+				So(isPrimitive, ShouldBeFalse)
 			})
 		})
-
 	})
 }
 
-func TestResourceIsArrayProperty(t *testing.T) {
+func TestValidateArrayItems(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When isArrayProperty is called with an array type property that has items of type object (nested)", func() {
-			propertySchema := spec.Schema{
+		Convey("When validateArrayItems method is called with a property that has items using the anyOf construct", func() {
+			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
 					Items: &spec.SchemaOrArray{
 						Schema: &spec.Schema{
 							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"object"},
-								Properties: map[string]spec.Schema{
-									"prop1": {
-										SchemaProps: spec.SchemaProps{
-											Type: spec.StringOrArray{"string"},
-										},
-									},
-									"prop2": {
-										SchemaProps: spec.SchemaProps{
-											Type: spec.StringOrArray{"integer"},
-										},
-									},
-								},
+								AnyOf: []spec.Schema{*spec.StringProperty(), *spec.Int64Property()},
 							},
 						},
 					},
 				},
 			}
-			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
+			_, err := r.validateArrayItems(property)
+			Convey("The error should not be nil", func() {
+				So(err, ShouldNotBeNil)
 			})
-			Convey("And the result your be true", func() {
-				So(isArray, ShouldBeTrue)
+			Convey("And the error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "array items schema not supported: the 'anyOf' construct is not supported, please define the property with an explicit type instead (e,g: 'type: object' with 'properties')")
 			})
-			Convey("And the array items should be of type object", func() {
-				So(arrayItemType, ShouldEqual, typeObject)
+		})
+		Convey("When validateArrayItems method is called with a property that does not have items", func() {
+			property := spec.Schema{}
+			_, err := r.validateArrayItems(property)
+			Convey("The error should not be nil", func() {
+				So(err, ShouldNotBeNil)
 			})
-			Convey("And the object schema should not be nil", func() {
-				So(objectItemSchema, ShouldNotBeNil)
-				exists, _ := assertPropertyExists(objectItemSchema.Properties, "prop1")
-				So(exists, ShouldBeTrue)
-				exists, _ = assertPropertyExists(objectItemSchema.Properties, "prop2")
-				So(exists, ShouldBeTrue)
+			Convey("And the error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "array property is missing items schema definition")
 			})
 		})
-		Convey("When isArrayProperty is called with an array type property that has items of type primitive (e,g: string)", func() {
-			propertySchema := spec.Schema{
+		Convey("When validateArrayItems method is called with a property that does have items but they lack the schema", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Items: &spec.SchemaOrArray{
+						// no schema :(
+					},
+				},
+			}
+			_, err := r.validateArrayItems(property)
+			Convey("The error should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "array property is missing items schema definition")
+			})
+		})
+		Convey("When validateArrayItems method is called with a property that does have items and a schema BUT the items are of type array (this is not supported at the moment)", func() {
+			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
 					Items: &spec.SchemaOrArray{
 						Schema: &spec.Schema{
 							SchemaProps: spec.SchemaProps{
-								Type: spec.StringOrArray{"string"},
+								Type: spec.StringOrArray{"array"},
 							},
 						},
 					},
 				},
 			}
-			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
-			Convey("The error should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("And the result your be true", func() {
-				So(isArray, ShouldBeTrue)
-			})
-			Convey("And the array items should be of type string", func() {
-				So(arrayItemType, ShouldEqual, typeString)
+			_, err := r.validateArrayItems(property)
+			Convey("The error should not be nil", func() {
+				So(err, ShouldNotBeNil)
 			})
-			Convey("And the object schema should be nil", func() {
-				So(objectItemSchema, ShouldBeNil)
+			Convey("And the error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "array property can not have items of type 'array'")
 			})
 		})
-		Convey("When isArrayProperty is called with an array type property that has items of type object (ref)", func() {
-			r := SpecV2Resource{
-				SchemaDefinitions: map[string]spec.Schema{
-					"Listeners": {
-						SchemaProps: spec.SchemaProps{
-							Type: spec.StringOrArray{"object"},
-							Properties: map[string]spec.Schema{
-								"protocol": {
-									SchemaProps: spec.SchemaProps{
-										Type: spec.StringOrArray{"string"},
-									},
-								},
+		Convey("When validateArrayItems method is called with an array of unknown type items", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"unknown"},
 							},
 						},
 					},
 				},
 			}
-			propertySchema := spec.Schema{
+			_, err := r.validateArrayItems(property)
+			Convey("The error should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("And the error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "non supported '[unknown]' type")
+			})
+		})
+		Convey("When validateArrayItems method is called with a valid array property that has items of type string", func() {
+			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
 					Items: &spec.SchemaOrArray{
 						Schema: &spec.Schema{
 							SchemaProps: spec.SchemaProps{
-								Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
+								Type: spec.StringOrArray{"string"},
 							},
 						},
 					},
 				},
 			}
-			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			itemsPropType, err := r.validateArrayItems(property)
 			Convey("The error should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the result your be true", func() {
-				So(isArray, ShouldBeTrue)
-			})
-			Convey("And the array items should be of type object", func() {
-				So(arrayItemType, ShouldEqual, typeObject)
-			})
-			Convey("And the object schema should not be nil", func() {
-				So(objectItemSchema, ShouldNotBeNil)
-				exists, _ := assertPropertyExists(objectItemSchema.Properties, "protocol")
-				So(exists, ShouldBeTrue)
+			Convey("And the type of the items should match the expected string", func() {
+				So(itemsPropType, ShouldEqual, typeString)
 			})
 		})
-		Convey("When isArrayProperty is called with a NON array property type", func() {
-			propertySchema := spec.Schema{
+		Convey("When validateArrayItems method is called with a valid array property that has items of type object", func() {
+			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"prop1": {},
+								},
+							},
+						},
+					},
 				},
 			}
-			isArray, _, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			itemsPropType, err := r.validateArrayItems(property)
 			Convey("The error should be nil", func() {
 				So(err, ShouldBeNil)
 			})
-			Convey("And the result your be false", func() {
-				So(isArray, ShouldBeFalse)
-			})
-			Convey("And the object schema should be nil", func() {
-				So(objectItemSchema, ShouldBeNil)
+			Convey("And the type of the items should match the expected object", func() {
+				So(itemsPropType, ShouldEqual, typeObject)
 			})
 		})
 	})
 }
 
-func TestIsObjectTypeProperty(t *testing.T) {
+func TestGetPropertyType(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := &SpecV2Resource{}
-		Convey("When isObjectTypeProperty method is called a property of type object", func() {
+		Convey("When getPropertyType method is called with a property of type array", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
+					Type: spec.StringOrArray{"array"},
 				},
 			}
-			isArrayType := r.isObjectTypeProperty(property)
-			Convey("Then the result returned should be true", func() {
-				So(isArrayType, ShouldBeTrue)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected array", func() {
+				So(itemsPropType, ShouldEqual, typeList)
 			})
 		})
-		Convey("When isObjectTypeProperty method is called a property that IS NOT of type object", func() {
+
+		Convey("When getPropertyType method is called with a property of type object", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"prop1": {},
+					},
 				},
 			}
-			isArrayType := r.isObjectTypeProperty(property)
-			Convey("Then the result returned should be false", func() {
-				So(isArrayType, ShouldBeFalse)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected object", func() {
+				So(itemsPropType, ShouldEqual, typeObject)
 			})
 		})
-	})
-}
 
-func TestIsArrayTypeProperty(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isArrayTypeProperty method is called a property of type array", func() {
+		Convey("When getPropertyType method is called with a property of type string", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"array"},
+					Type: spec.StringOrArray{"string"},
 				},
 			}
-			isArrayType := r.isArrayTypeProperty(property)
-			Convey("Then the result returned should be true", func() {
-				So(isArrayType, ShouldBeTrue)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected string", func() {
+				So(itemsPropType, ShouldEqual, typeString)
 			})
 		})
-		Convey("When isArrayTypeProperty method is called a property that IS NOT of type array", func() {
+
+		Convey("When getPropertyType method is called with a property of type integer", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"object"},
+					Type: spec.StringOrArray{"integer"},
 				},
 			}
-			isArrayType := r.isArrayTypeProperty(property)
-			Convey("Then the result returned should be false", func() {
-				So(isArrayType, ShouldBeFalse)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected integer", func() {
+				So(itemsPropType, ShouldEqual, typeInt)
 			})
 		})
-	})
-}
 
-func TestIsOfType(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isOfType method is called a property of the expected type", func() {
+		Convey("When getPropertyType method is called with a property of type float", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
+					Type: spec.StringOrArray{"number"},
 				},
 			}
-			isString := r.isOfType(property, "string")
-			Convey("Then the result returned should be true", func() {
-				So(isString, ShouldBeTrue)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected float", func() {
+				So(itemsPropType, ShouldEqual, typeFloat)
 			})
 		})
-		Convey("When isArrayTypeProperty method is called a property that IS NOT of the expected type", func() {
+
+		Convey("When getPropertyType method is called with a property of type bool", func() {
 			property := spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Type: spec.StringOrArray{"string"},
+					Type: spec.StringOrArray{"boolean"},
 				},
 			}
-			isInteger := r.isOfType(property, "integer")
-			Convey("Then the result returned should be false", func() {
-				So(isInteger, ShouldBeFalse)
+			itemsPropType, err := r.getPropertyType(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the type of the items should match the expected bool", func() {
+				So(itemsPropType, ShouldEqual, typeBool)
 			})
 		})
-	})
-}
 
-func TestSwaggerPropIsRequired(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := &SpecV2Resource{}
-		Convey("When isRequired is called with a required prop", func() {
-			requiredProp := "requiredProp"
-			requiredProps := []string{requiredProp}
-			isRequired := r.isRequired(requiredProp, requiredProps)
-			Convey("Then the result returned should be true", func() {
-				So(isRequired, ShouldBeTrue)
+		Convey("When getPropertyType method is called with a property of type non supported", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"non supported"},
+				},
+			}
+			_, err := r.getPropertyType(property)
+			Convey("The error should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
 			})
-		})
-		Convey("When isRequired is called with a NON required prop", func() {
-			requiredProps := []string{"requiredProp"}
-			isRequired := r.isRequired("nonRequired", requiredProps)
-			Convey("Then the result returned should be true", func() {
-				So(isRequired, ShouldBeFalse)
+			Convey("And the error returned should be ", func() {
+				So(err.Error(), ShouldEqual, "non supported '[non supported]' type")
 			})
 		})
 	})
 }
 
-func TestGetResourceTerraformName(t *testing.T) {
-	Convey("Given a SpecV2Resource with a root path item containing a post operation with the extension 'x-terraform-resource-name'", t, func() {
-		extensions := spec.Extensions{}
-		expectedResourceName := "example"
-		extensions.Add(extTfResourceName, expectedResourceName)
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: extensions,
-						},
+func TestResourceIsObjectProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isObjectProperty method is called with a property of type object that has nested properties", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: map[string]spec.Schema{
+						"prop1": {},
 					},
 				},
-			},
-		}
-		Convey("When getResourceTerraformName method is called an existing extension", func() {
-			value := r.getResourceTerraformName()
-			Convey("Then the value returned should match the value in the extension", func() {
-				So(value, ShouldEqual, expectedResourceName)
+			}
+			isObject, objectSchema, err := r.isObjectProperty(property)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-		})
-	})
-	Convey("Given a SpecV2Resource with a root path item containing the extension 'x-terraform-resource-name'", t, func() {
-		extensions := spec.Extensions{}
-		expectedResourceName := "rootLevelPreferredName"
-		extensions.Add(extTfResourceName, expectedResourceName)
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: extensions,
-				},
-			},
-		}
-		Convey("When getResourceTerraformName method is called an existing extension", func() {
-			value := r.getResourceTerraformName()
-			Convey("Then the value returned should match the value in the extension", func() {
-				So(value, ShouldEqual, expectedResourceName)
+			Convey("And the result your be true", func() {
+				So(isObject, ShouldBeTrue)
 			})
-		})
-	})
-	Convey("Given a SpecV2Resource without a rootPathItem", t, func() {
-		r := SpecV2Resource{}
-		Convey("When getResourceTerraformName method is called", func() {
-			value := r.getResourceTerraformName()
-			Convey("Then the value returned should be empty since the resource does not have such extension defined", func() {
-				So(value, ShouldEqual, "")
+			Convey("And the object schema should not be nil", func() {
+				So(objectSchema, ShouldNotBeNil)
 			})
 		})
-	})
-}
-
-func TestGetPreferredName(t *testing.T) {
-	testCases := []struct {
-		name                 string
-		inputPathItem        spec.PathItem
-		expectedResourceName string
-	}{
-		{
-			name: "path item with the extension 'x-terraform-resource-name' on the POST level",
-			inputPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{extTfResourceName: "postLevelPreferredName"},
+		Convey("When isObjectProperty method is called with a property of type object that has a ref to an external schema but is missing the type", func() {
+			r := SpecV2Resource{
+				SchemaDefinitions: map[string]spec.Schema{
+					"Listeners": {
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"object"},
+							Properties: map[string]spec.Schema{
+								"protocol": {
+									SchemaProps: spec.SchemaProps{
+										Type: spec.StringOrArray{"string"},
+									},
+								},
+							},
 						},
 					},
 				},
-			},
-			expectedResourceName: "postLevelPreferredName",
-		},
-		{
-			name: "path item with the extension 'x-terraform-resource-name' on the root level",
-			inputPathItem: spec.PathItem{
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: spec.Extensions{extTfResourceName: "rootLevelPreferredName"},
+			}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					//Type: spec.StringOrArray{"object"}, // Missing type info but still should be considered as object
+					Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
 				},
-			},
-			expectedResourceName: "rootLevelPreferredName",
-		},
-		{
-			name: "path item with the extension 'x-terraform-resource-name' on the POST and a different extension on the root level",
-			inputPathItem: spec.PathItem{
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: spec.Extensions{
-						"x-something": "something ext value",
-					},
+			}
+			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be true", func() {
+				So(isObject, ShouldBeTrue)
+			})
+			Convey("And the object schema should not be nil", func() {
+				So(objectSchema, ShouldNotBeNil)
+			})
+		})
+		Convey("When isObjectProperty method is called with a property of type object that has a ref to an external schema and also has the type", func() {
+			r := SpecV2Resource{
+				SchemaDefinitions: map[string]spec.Schema{
+					"Listeners": {
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"object"},
+							Properties: map[string]spec.Schema{
+								"protocol": {
+									SchemaProps: spec.SchemaProps{
+										Type: spec.StringOrArray{"string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Ref:  spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
+				},
+			}
+			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be true", func() {
+				So(isObject, ShouldBeTrue)
+			})
+			Convey("And the object schema should not be nil", func() {
+				So(objectSchema, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When isObjectProperty method is called with a property of type object that has a ref to a non existing schema", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Ref:  spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/nonExisting")},
+				},
+			}
+			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
+			Convey("The error should NOT be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("The error message should be the expected", func() {
+				So(err.Error(), ShouldEqual, "object ref is poitning to a non existing schema definition: missing schema definition in the swagger file with the supplied ref '#/definitions/nonExisting'")
+			})
+			Convey("And the result your be true", func() {
+				So(isObject, ShouldBeTrue)
+			})
+			Convey("And the object schema should be nil", func() {
+				So(objectSchema, ShouldBeNil)
+			})
+		})
+
+		Convey("When isObjectProperty method is called with a property that has nested schema with no properties", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{},
+					},
+				},
+			}
+			isObject, objectSchema, err := r.isObjectProperty(property)
+			Convey("The error should NOT be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be false", func() {
+				So(isObject, ShouldBeFalse)
+			})
+			Convey("And the object schema should be nil", func() {
+				So(objectSchema, ShouldBeNil)
+			})
+		})
+
+		Convey("When isObjectProperty method is called with a property of type string", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+			}
+			isObject, objectSchema, err := r.isObjectProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be false", func() {
+				So(isObject, ShouldBeFalse)
+			})
+			Convey("And the object schema should be nil", func() {
+				So(objectSchema, ShouldBeNil)
+			})
+		})
+
+	})
+}
+
+func TestResourceIsArrayProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isArrayProperty is called with an array type property that has items of type object (nested)", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"prop1": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"string"},
+										},
+									},
+									"prop2": {
+										SchemaProps: spec.SchemaProps{
+											Type: spec.StringOrArray{"integer"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be true", func() {
+				So(isArray, ShouldBeTrue)
+			})
+			Convey("And the array items should be of type object", func() {
+				So(arrayItemType, ShouldEqual, typeObject)
+			})
+			Convey("And the object schema should not be nil", func() {
+				So(objectItemSchema, ShouldNotBeNil)
+				exists, _ := assertPropertyExists(objectItemSchema.Properties, "prop1")
+				So(exists, ShouldBeTrue)
+				exists, _ = assertPropertyExists(objectItemSchema.Properties, "prop2")
+				So(exists, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayProperty is called with an array type property that has items of type primitive (e,g: string)", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"string"},
+							},
+						},
+					},
+				},
+			}
+			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be true", func() {
+				So(isArray, ShouldBeTrue)
+			})
+			Convey("And the array items should be of type string", func() {
+				So(arrayItemType, ShouldEqual, typeString)
+			})
+			Convey("And the object schema should be nil", func() {
+				So(objectItemSchema, ShouldBeNil)
+			})
+		})
+		Convey("When isArrayProperty is called with an array type property that has items of type object (ref)", func() {
+			r := SpecV2Resource{
+				SchemaDefinitions: map[string]spec.Schema{
+					"Listeners": {
+						SchemaProps: spec.SchemaProps{
+							Type: spec.StringOrArray{"object"},
+							Properties: map[string]spec.Schema{
+								"protocol": {
+									SchemaProps: spec.SchemaProps{
+										Type: spec.StringOrArray{"string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+					Items: &spec.SchemaOrArray{
+						Schema: &spec.Schema{
+							SchemaProps: spec.SchemaProps{
+								Ref: spec.Ref{Ref: jsonreference.MustCreateRef("#/definitions/Listeners")},
+							},
+						},
+					},
+				},
+			}
+			isArray, arrayItemType, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be true", func() {
+				So(isArray, ShouldBeTrue)
+			})
+			Convey("And the array items should be of type object", func() {
+				So(arrayItemType, ShouldEqual, typeObject)
+			})
+			Convey("And the object schema should not be nil", func() {
+				So(objectItemSchema, ShouldNotBeNil)
+				exists, _ := assertPropertyExists(objectItemSchema.Properties, "protocol")
+				So(exists, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayProperty is called with a NON array property type", func() {
+			propertySchema := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+			}
+			isArray, _, objectItemSchema, err := r.isArrayProperty(propertySchema)
+			Convey("The error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the result your be false", func() {
+				So(isArray, ShouldBeFalse)
+			})
+			Convey("And the object schema should be nil", func() {
+				So(objectItemSchema, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestIsObjectTypeProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isObjectTypeProperty method is called a property of type object", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+				},
+			}
+			isArrayType := r.isObjectTypeProperty(property)
+			Convey("Then the result returned should be true", func() {
+				So(isArrayType, ShouldBeTrue)
+			})
+		})
+		Convey("When isObjectTypeProperty method is called a property that IS NOT of type object", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+				},
+			}
+			isArrayType := r.isObjectTypeProperty(property)
+			Convey("Then the result returned should be false", func() {
+				So(isArrayType, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestIsArrayTypeProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isArrayTypeProperty method is called a property of type array", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"array"},
+				},
+			}
+			isArrayType := r.isArrayTypeProperty(property)
+			Convey("Then the result returned should be true", func() {
+				So(isArrayType, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayTypeProperty method is called a property that IS NOT of type array", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+				},
+			}
+			isArrayType := r.isArrayTypeProperty(property)
+			Convey("Then the result returned should be false", func() {
+				So(isArrayType, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestIsOfType(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isOfType method is called a property of the expected type", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+			}
+			isString := r.isOfType(property, "string")
+			Convey("Then the result returned should be true", func() {
+				So(isString, ShouldBeTrue)
+			})
+		})
+		Convey("When isArrayTypeProperty method is called a property that IS NOT of the expected type", func() {
+			property := spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"string"},
+				},
+			}
+			isInteger := r.isOfType(property, "integer")
+			Convey("Then the result returned should be false", func() {
+				So(isInteger, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestSwaggerPropIsRequired(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := &SpecV2Resource{}
+		Convey("When isRequired is called with a required prop", func() {
+			requiredProp := "requiredProp"
+			requiredProps := []string{requiredProp}
+			isRequired := r.isRequired(requiredProp, requiredProps)
+			Convey("Then the result returned should be true", func() {
+				So(isRequired, ShouldBeTrue)
+			})
+		})
+		Convey("When isRequired is called with a NON required prop", func() {
+			requiredProps := []string{"requiredProp"}
+			isRequired := r.isRequired("nonRequired", requiredProps)
+			Convey("Then the result returned should be true", func() {
+				So(isRequired, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetResourceTerraformName(t *testing.T) {
+	Convey("Given a SpecV2Resource with a root path item containing a post operation with the extension 'x-terraform-resource-name'", t, func() {
+		extensions := spec.Extensions{}
+		expectedResourceName := "example"
+		extensions.Add(extTfResourceName, expectedResourceName)
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: extensions,
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceTerraformName method is called an existing extension", func() {
+			value := r.getResourceTerraformName()
+			Convey("Then the value returned should match the value in the extension", func() {
+				So(value, ShouldEqual, expectedResourceName)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root path item containing the extension 'x-terraform-resource-name'", t, func() {
+		extensions := spec.Extensions{}
+		expectedResourceName := "rootLevelPreferredName"
+		extensions.Add(extTfResourceName, expectedResourceName)
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			},
+		}
+		Convey("When getResourceTerraformName method is called an existing extension", func() {
+			value := r.getResourceTerraformName()
+			Convey("Then the value returned should match the value in the extension", func() {
+				So(value, ShouldEqual, expectedResourceName)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without a rootPathItem", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourceTerraformName method is called", func() {
+			value := r.getResourceTerraformName()
+			Convey("Then the value returned should be empty since the resource does not have such extension defined", func() {
+				So(value, ShouldEqual, "")
+			})
+		})
+	})
+}
+
+func TestGetPreferredName(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		inputPathItem        spec.PathItem
+		expectedResourceName string
+	}{
+		{
+			name: "path item with the extension 'x-terraform-resource-name' on the POST level",
+			inputPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{extTfResourceName: "postLevelPreferredName"},
+						},
+					},
+				},
+			},
+			expectedResourceName: "postLevelPreferredName",
+		},
+		{
+			name: "path item with the extension 'x-terraform-resource-name' on the root level",
+			inputPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{extTfResourceName: "rootLevelPreferredName"},
+				},
+			},
+			expectedResourceName: "rootLevelPreferredName",
+		},
+		{
+			name: "path item with the extension 'x-terraform-resource-name' on the POST and a different extension on the root level",
+			inputPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						"x-something": "something ext value",
+					},
+				},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{extTfResourceName: "postLevelPreferredName"},
+						},
+					},
+				},
+			},
+			expectedResourceName: "postLevelPreferredName",
+		},
+		{
+			name: "path item with the extension 'x-terraform-resource-name' on both the POST and root levels",
+			inputPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfResourceName: "rootLevelPreferredName",
+					},
+				},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceName: "postPreferredName",
+							},
+						},
+					},
+				},
+			},
+			expectedResourceName: "rootLevelPreferredName",
+		},
+		{
+			name:                 " an empty path item",
+			inputPathItem:        spec.PathItem{},
+			expectedResourceName: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		specV2Resource := SpecV2Resource{}
+		value := specV2Resource.getPreferredName(tc.inputPathItem)
+		assert.Equal(t, tc.expectedResourceName, value, tc.name)
+	}
+}
+
+func TestGetExtensionStringValue(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getExtensionStringValue method is called an existing extension", func() {
+			extensions := spec.Extensions{}
+			expectedExtensionValue := "example"
+			extensions.Add(extTfResourceName, expectedExtensionValue)
+			value := r.getExtensionStringValue(extensions, extTfResourceName)
+			Convey("Then the value returned should match the value in the extension", func() {
+				So(value, ShouldEqual, expectedExtensionValue)
+			})
+		})
+		Convey("When getExtensionStringValue method is called a NON existing extension", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceName, "example")
+			value := r.getExtensionStringValue(extensions, "somethingOtherExtensionName")
+			Convey("Then the value returned should match the value in the extension", func() {
+				So(value, ShouldEqual, "")
+			})
+		})
+	})
+}
+
+func TestGetAsyncOperation(t *testing.T) {
+	newResponse := func(value interface{}) spec.Response {
+		return spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfAsyncOperation: value}}}
+	}
+	Convey(fmt.Sprintf("Given a SpecV2Resource and a response containing the %s extension set to true", extTfAsyncOperation), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getAsyncOperation method is called", func() {
+			asyncOperation := r.getAsyncOperation(newResponse(true))
+			Convey("Then the asynchronous operation returned should contain the default settings", func() {
+				So(asyncOperation, ShouldResemble, newSpecAsyncOperation())
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and a response containing the %s extension with an object value", extTfAsyncOperation), t, func() {
+		r := SpecV2Resource{}
+		value := map[string]interface{}{
+			"location_header":  "Operation-Location",
+			"url_field":        "operation.href",
+			"status_field":     "properties.status",
+			"success_statuses": []interface{}{"done"},
+			"failure_statuses": []interface{}{"error", "aborted"},
+			"error_field":      "error.message",
+		}
+		Convey("When getAsyncOperation method is called", func() {
+			asyncOperation := r.getAsyncOperation(newResponse(value))
+			Convey("Then the asynchronous operation returned should contain the settings configured in the extension", func() {
+				So(asyncOperation, ShouldResemble, &specAsyncOperation{
+					locationHeader:  "Operation-Location",
+					urlField:        "operation.href",
+					statusField:     "properties.status",
+					successStatuses: []string{"done"},
+					failureStatuses: []string{"error", "aborted"},
+					errorField:      "error.message",
+				})
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and a response containing the %s extension with a partial object value", extTfAsyncOperation), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getAsyncOperation method is called", func() {
+			asyncOperation := r.getAsyncOperation(newResponse(map[string]interface{}{"location_header": "Operation-Location"}))
+			Convey("Then the settings not configured should take the default values", func() {
+				So(asyncOperation.locationHeader, ShouldEqual, "Operation-Location")
+				So(asyncOperation.statusField, ShouldEqual, defaultAsyncOperationStatusField)
+				So(asyncOperation.successStatuses, ShouldResemble, defaultAsyncOperationSuccessStatuses)
+				So(asyncOperation.failureStatuses, ShouldResemble, defaultAsyncOperationFailureStatuses)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and responses containing the %s extension disabled or with values that are not valid", extTfAsyncOperation), t, func() {
+		r := SpecV2Resource{}
+		values := []interface{}{
+			false,
+			"yes",
+			map[string]interface{}{"status_field": ""},
+			map[string]interface{}{"location_header": true},
+			map[string]interface{}{"success_statuses": "done"},
+			map[string]interface{}{"failure_statuses": []interface{}{}},
+			map[string]interface{}{"failure_statuses": []interface{}{1}},
+			map[string]interface{}{"unknown": "value"},
+		}
+		Convey("When getAsyncOperation method is called", func() {
+			Convey("Then the extension should be ignored", func() {
+				for _, value := range values {
+					So(r.getAsyncOperation(newResponse(value)), ShouldBeNil)
+				}
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and a response that does not contain the %s extension", extTfAsyncOperation), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getAsyncOperation method is called", func() {
+			asyncOperation := r.getAsyncOperation(spec.Response{})
+			Convey("Then the asynchronous operation returned should be nil", func() {
+				So(asyncOperation, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateResponses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When createResponses method is called with an operation that has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
+			expectedTarget := "deployed"
+			expectedStatus := "deploy_pending"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, true)
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
+			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{
+								http.StatusAccepted: {
+									VendorExtensible: spec.VendorExtensible{
+										Extensions: extensions,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the spec responses map returned should not be empty", func() {
+				So(specResponses, ShouldNotBeEmpty)
+			})
+			Convey("Then there should be an existing key for response code 202", func() {
+				So(specResponses, ShouldContainKey, http.StatusAccepted)
+			})
+			Convey("And the response should meet the configuration", func() {
+				So(specResponses[http.StatusAccepted].isPollingEnabled, ShouldBeTrue)
+				So(specResponses[http.StatusAccepted].pollTargetStatuses, ShouldContain, expectedTarget)
+				So(specResponses[http.StatusAccepted].pollPendingStatuses, ShouldContain, expectedStatus)
+			})
+		})
+
+		Convey("When createResponses method is called with an operation that has 4xx responses with the 'x-terraform-error-fields' extension", func() {
+			defaultKeysExtensions := spec.Extensions{}
+			defaultKeysExtensions.Add(extTfErrorFields, "errors")
+			customKeysExtensions := spec.Extensions{}
+			customKeysExtensions.Add(extTfErrorFields, "error.details")
+			customKeysExtensions.Add(extTfErrorFieldKey, "pointer")
+			customKeysExtensions.Add(extTfErrorMessageKey, "detail")
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{
+								http.StatusBadRequest:          {VendorExtensible: spec.VendorExtensible{Extensions: customKeysExtensions}},
+								http.StatusUnprocessableEntity: {VendorExtensible: spec.VendorExtensible{Extensions: defaultKeysExtensions}},
+								http.StatusInternalServerError: {VendorExtensible: spec.VendorExtensible{Extensions: defaultKeysExtensions}},
+								http.StatusConflict:            {},
+							},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the 4xx responses with the extension should contain the field errors configuration", func() {
+				So(specResponses[http.StatusUnprocessableEntity].fieldErrors, ShouldResemble, &specResponseFieldErrors{path: "errors", fieldKey: "field", messageKey: "message"})
+				So(specResponses[http.StatusBadRequest].fieldErrors, ShouldResemble, &specResponseFieldErrors{path: "error.details", fieldKey: "pointer", messageKey: "detail"})
+			})
+			Convey("And the responses that are not 4xx or do not have the extension should not contain the field errors configuration", func() {
+				So(specResponses[http.StatusInternalServerError].fieldErrors, ShouldBeNil)
+				So(specResponses[http.StatusConflict].fieldErrors, ShouldBeNil)
+			})
+		})
+
+		Convey("When createResponses method is called with an operation does not have any status responses", func() {
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Responses: &spec.Responses{
+						ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{},
+						},
+					},
+				},
+			}
+			specResponses := r.createResponses(operation)
+			Convey("Then the spec responses map returned should not be empty", func() {
+				So(specResponses, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestIsResourcePollingEnabled(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, true)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the bool returned should be true", func() {
+				So(isResourcePollingEnabled, ShouldBeTrue)
+			})
+		})
+		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to false", func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollEnabled, false)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the bool returned should be false", func() {
+				So(isResourcePollingEnabled, ShouldBeFalse)
+			})
+		})
+		Convey("When isResourcePollingEnabled method is called with list of responses where non of the codes match the given response http code", func() {
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusOK: {},
+					},
+				},
+			}
+			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusOK])
+			Convey("Then bool returned should be false", func() {
+				So(isResourcePollingEnabled, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetResourcePollTargetStatuses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourcePollTargetStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
+			expectedTarget := "deployed"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
+			responses := &spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getResourcePollTargetStatuses(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the status returned should contain", func() {
+				So(statuses, ShouldContain, expectedTarget)
+			})
+		})
+	})
+}
+
+func TestGetResourcePollPendingStatuses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourcePollPendingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-pending-statuses'", func() {
+			expectedStatus := "deploy_pending"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getResourcePollPendingStatuses(responses.StatusCodeResponses[http.StatusAccepted])
+			Convey("Then the status returned should contain", func() {
+				So(statuses, ShouldContain, expectedStatus)
+			})
+		})
+	})
+}
+
+func TestGetPollingStatuses(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
+			expectedTarget := "deployed"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
+			Convey("Then the statuses returned should contain", func() {
+				So(statuses, ShouldContain, expectedTarget)
+			})
+		})
+
+		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses' containing multiple targets (comma separated with spaces)", func() {
+			expectedTargets := "deployed, completed, done"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTargets)
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
+			Convey("Then the statuses returned should contain expected targets", func() {
+				// the expected Targets are a list of targets with no spaces whatsoever, hence why the removal of spaces
+				for _, expectedTarget := range strings.Split(strings.Replace(expectedTargets, " ", "", -1), ",") {
+					So(statuses, ShouldContain, expectedTarget)
+				}
+			})
+		})
+
+		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses' containing multiple targets (comma separated with no spaces)", func() {
+			expectedTargets := "deployed,completed,done"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourcePollTargetStatuses, expectedTargets)
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: extensions,
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
+			Convey("Then the statuses returned should contain expected targets", func() {
+				for _, expectedTarget := range strings.Split(expectedTargets, ",") {
+					So(statuses, ShouldContain, expectedTarget)
+				}
+			})
+		})
+
+		Convey("When getPollingStatuses method is called with a response that has does not have a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
+			responses := spec.Responses{
+				ResponsesProps: spec.ResponsesProps{
+					StatusCodeResponses: map[int]spec.Response{
+						http.StatusAccepted: {
+							VendorExtensible: spec.VendorExtensible{
+								Extensions: spec.Extensions{},
+							},
+						},
+					},
+				},
+			}
+			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
+			Convey("Then the status returned should be empty", func() {
+				So(statuses, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When getPollingStatuses method is called with a response that has the extension 'x-terraform-resource-poll-completed-statuses' with a list of statuses", func() {
+			response := spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourcePollTargetStatuses: []interface{}{"deployed", " ready "}}}}
+			statuses := r.getPollingStatuses(response, extTfResourcePollTargetStatuses)
+			Convey("Then the statuses returned should contain the statuses in the list", func() {
+				So(statuses, ShouldResemble, []string{"deployed", "ready"})
+			})
+		})
+
+		Convey("When getPollingStatuses method is called with a response that has the extension 'x-terraform-resource-poll-completed-statuses' with a list containing items that are not strings", func() {
+			response := spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourcePollTargetStatuses: []interface{}{"deployed", 1}}}}
+			statuses := r.getPollingStatuses(response, extTfResourcePollTargetStatuses)
+			Convey("Then the status returned should be empty", func() {
+				So(statuses, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetPollDuration(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		newResponse := func(value interface{}) spec.Response {
+			return spec.Response{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourcePollInterval: value}}}
+		}
+		Convey(fmt.Sprintf("When getPollDuration method is called with a response that has the extension '%s' with a valid duration", extTfResourcePollInterval), func() {
+			duration := r.getPollDuration(newResponse("500ms"), extTfResourcePollInterval)
+			Convey("Then the duration returned should be the expected one", func() {
+				So(*duration, ShouldEqual, 500*time.Millisecond)
+			})
+		})
+		Convey(fmt.Sprintf("When getPollDuration method is called with a response that has the extension '%s' with values that are not valid", extTfResourcePollInterval), func() {
+			Convey("Then the duration returned should be nil", func() {
+				for _, value := range []interface{}{"wrong", "-1s", 5} {
+					So(r.getPollDuration(newResponse(value), extTfResourcePollInterval), ShouldBeNil)
+				}
+			})
+		})
+		Convey(fmt.Sprintf("When getPollDuration method is called with a response that does not have the extension '%s'", extTfResourcePollInterval), func() {
+			duration := r.getPollDuration(spec.Response{}, extTfResourcePollInterval)
+			Convey("Then the duration returned should be nil", func() {
+				So(duration, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetTimeouts(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		expectedTimeout := "30s"
+		extensions := spec.Extensions{}
+		extensions.Add(extTfResourceTimeout, expectedTimeout)
+		op := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: extensions,
+			},
+		}
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: op,
+				},
+			},
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Put:    op,
+					Get:    op,
+					Delete: op,
+				},
+			},
+		}
+		Convey("When getTimeouts method is called ", func() {
+			timeouts, err := r.getTimeouts()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should contain the expected duration from the operation", func() {
+				So(*timeouts.Post, ShouldEqual, time.Duration(30*time.Second))
+				So(*timeouts.Get, ShouldEqual, time.Duration(30*time.Second))
+				So(*timeouts.Put, ShouldEqual, time.Duration(30*time.Second))
+				So(*timeouts.Delete, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+	})
+}
+
+func TestGetTimeoutsResourceLevelExtensions(t *testing.T) {
+	Convey("Given a SpecV2Resource with the resource level timeout extensions and an operation that has its own timeout", t, func() {
+		rootPathExtensions := spec.Extensions{}
+		rootPathExtensions.Add(extTfResourceTimeoutCreate, "30m")
+		rootPathExtensions.Add(extTfResourceTimeoutUpdate, "20m")
+		postExtensions := spec.Extensions{}
+		postExtensions.Add(extTfResourceTimeoutDelete, "1h")
+		postExtensions.Add(extTfResourceTimeoutUpdate, "5m")
+		putExtensions := spec.Extensions{}
+		putExtensions.Add(extTfResourceTimeout, "15m")
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{Extensions: rootPathExtensions},
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: postExtensions}},
+				},
+			},
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get:    &spec.Operation{},
+					Put:    &spec.Operation{VendorExtensible: spec.VendorExtensible{Extensions: putExtensions}},
+					Delete: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getTimeouts method is called ", func() {
+			timeouts, err := r.getTimeouts()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the timeouts returned should be the ones defined in the resource level extensions", func() {
+				So(*timeouts.Post, ShouldEqual, 30*time.Minute)
+				So(*timeouts.Delete, ShouldEqual, time.Hour)
+			})
+			Convey("And the operation timeout should take preference over the resource level extensions", func() {
+				So(*timeouts.Put, ShouldEqual, 15*time.Minute)
+			})
+			Convey("And the timeouts not defined should be nil", func() {
+				So(timeouts.Get, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a resource level timeout extension that is not valid", t, func() {
+		rootPathExtensions := spec.Extensions{}
+		rootPathExtensions.Add(extTfResourceTimeoutRead, "-1m")
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{Extensions: rootPathExtensions},
+			},
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getTimeouts method is called ", func() {
+			_, err := r.getTimeouts()
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "invalid duration value: '-1m'")
+			})
+		})
+	})
+}
+
+func TestGetResourceTimeout(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getResourceTimeout method is called with an operation that has the extension '%s'", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			post := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: extensions,
+				},
+			}
+			duration, err := r.getResourceTimeout(post)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should contain", func() {
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+	})
+}
+
+func TestGetTimeDuration(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in seconds", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should contain", func() {
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in minutes (using fractions)", extTfResourceTimeout), func() {
+			expectedTimeout := "20.5m"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should contain", func() {
+				So(*duration, ShouldEqual, time.Duration((20*time.Minute)+(30*time.Second)))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in hours", extTfResourceTimeout), func() {
+			expectedTimeout := "1h"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should contain", func() {
+				So(*duration, ShouldEqual, time.Duration(1*time.Hour))
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES NOT contain the extension passed in '%s'", extTfResourceTimeout), func() {
+			expectedTimeout := "30s"
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, expectedTimeout)
+			duration, err := r.getTimeDuration(extensions, "nonExistingExtension")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the duration returned should be nil", func() {
+				So(duration, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is an empty string", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("Then the duration returned should be nil", func() {
+				So(duration, ShouldBeNil)
+			})
+			Convey("And the error message should be", func() {
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: ''. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is a negative duration", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "-1.5h")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("Then the duration returned should be nil", func() {
+				So(duration, ShouldBeNil)
+			})
+			Convey("And the error message should be", func() {
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: '-1.5h'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+			})
+		})
+		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is NOT supported (distinct than s,m and h)", extTfResourceTimeout), func() {
+			extensions := spec.Extensions{}
+			extensions.Add(extTfResourceTimeout, "300ms")
+			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+			Convey("Then the duration returned should be nil", func() {
+				So(duration, ShouldBeNil)
+			})
+			Convey("And the error message should be", func() {
+				So(err.Error(), ShouldContainSubstring, "invalid duration value: '300ms'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+			})
+		})
+	})
+}
+
+func TestGetDuration(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getDuration method is called a valid formatted time'", func() {
+			duration, err := r.getDuration("30s")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the statuses returned should contain", func() {
+				fmt.Println(duration)
+				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			})
+		})
+		Convey("When getDuration method is called a invalid formatted time'", func() {
+			_, err := r.getDuration("some invalid formatted time")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestSpecV2ResourceGetHost(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the host returned should be the override host", func() {
+				So(host, ShouldEqual, "www.some-host.com")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without an override host specified", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the host returned should be the override host", func() {
+				So(host, ShouldEqual, "")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource that doesn't have a POST operation specified", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: nil,
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the host returned should be an empty string", func() {
+				So(host, ShouldEqual, "")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource that is multi region but region isn't specified", t, func() {
+		r := SpecV2Resource{
+			Region: "",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.${region}.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be as expected", func() {
+				So(err.Error(), ShouldEqual, "region can not be empty for multiregion resources")
+			})
+			Convey("Then the host returned should be an empty string", func() {
+				So(host, ShouldEqual, "")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource that is multi region with region specified", t, func() {
+		r := SpecV2Resource{
+			Region: "rst1",
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: "www.${region}.some-host.com",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getHost is called", func() {
+			host, err := r.getHost()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("Then the host returned should be the override host", func() {
+				So(host, ShouldEqual, "www.rst1.some-host.com")
+			})
+		})
+	})
+}
+
+func TestGetResourceOverrideHost(t *testing.T) {
+	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a non parametrized host containing the host to use", t, func() {
+		expectedHost := "some.api.domain.com"
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: expectedHost,
+							},
+						},
+					},
 				},
+			},
+		}
+		Convey("When getResourceOverrideHost method is called", func() {
+			host := getResourceOverrideHost(r.RootPathItem.Post)
+			Convey("Then the value returned should be the host value", func() {
+				So(host, ShouldEqual, expectedHost)
+			})
+		})
+	})
+
+	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a parametrized host containing the host to use", t, func() {
+		expectedHost := "some.api.${serviceProviderName}.domain.com"
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
 					Post: &spec.Operation{
 						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{extTfResourceName: "postLevelPreferredName"},
+							Extensions: spec.Extensions{
+								extTfResourceURL: expectedHost,
+							},
 						},
 					},
 				},
 			},
-			expectedResourceName: "postLevelPreferredName",
-		},
-		{
-			name: "path item with the extension 'x-terraform-resource-name' on both the POST and root levels",
-			inputPathItem: spec.PathItem{
+		}
+		Convey("When getResourceOverrideHost method is called", func() {
+			host := getResourceOverrideHost(r.RootPathItem.Post)
+			Convey("Then the value returned should be the host value", func() {
+				So(host, ShouldEqual, expectedHost)
+			})
+		})
+	})
+
+	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with an empty string value", t, func() {
+		expectedHost := ""
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceURL: expectedHost,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getResourceOverrideHost method is called", func() {
+			host := getResourceOverrideHost(r.RootPathItem.Post)
+			Convey("Then the value returned should be the host value", func() {
+				So(host, ShouldEqual, expectedHost)
+			})
+		})
+	})
+
+	Convey("Given a terraform resource that doesn't have a POST operation", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: nil,
+				},
+			},
+		}
+		Convey("When getResourceOverrideHost method is called", func() {
+			host := getResourceOverrideHost(r.RootPathItem.Post)
+			Convey("Then the value returned should be an empty string", func() {
+				So(host, ShouldEqual, "")
+			})
+		})
+	})
+}
+
+func TestIsProtocolV6Resource(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root path containing the %s extension", extTfResourceProtocolV6), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						extTfResourceName: "rootLevelPreferredName",
+						extTfResourceProtocolV6: true,
+					},
+				},
+			},
+		}
+		Convey("When isProtocolV6Resource method is called", func() {
+			isProtocolV6Resource := r.isProtocolV6Resource()
+			Convey("Then the result should be true", func() {
+				So(isProtocolV6Resource, ShouldBeTrue)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root POST operation containing the %s extension", extTfResourceProtocolV6), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfResourceProtocolV6: true,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When isProtocolV6Resource method is called", func() {
+			isProtocolV6Resource := r.isProtocolV6Resource()
+			Convey("Then the result should be true", func() {
+				So(isProtocolV6Resource, ShouldBeTrue)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root path that does not contain the %s extension", extTfResourceProtocolV6), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When isProtocolV6Resource method is called", func() {
+			isProtocolV6Resource := r.isProtocolV6Resource()
+			Convey("Then the result should be false", func() {
+				So(isProtocolV6Resource, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetImportLookupProperty(t *testing.T) {
+	Convey("Given a SpecV2Resource with a root path containing the x-terraform-import-lookup extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfImportLookup: "name",
+					},
+				},
+			},
+		}
+		Convey("When getImportLookupProperty method is called", func() {
+			importLookupProperty := r.getImportLookupProperty()
+			Convey("Then the value returned should be the extension value", func() {
+				So(importLookupProperty, ShouldEqual, "name")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root POST operation containing the x-terraform-import-lookup extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfImportLookup: "label",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getImportLookupProperty method is called", func() {
+			importLookupProperty := r.getImportLookupProperty()
+			Convey("Then the value returned should be the extension value", func() {
+				So(importLookupProperty, ShouldEqual, "label")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without the x-terraform-import-lookup extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getImportLookupProperty method is called", func() {
+			importLookupProperty := r.getImportLookupProperty()
+			Convey("Then the value returned should be empty", func() {
+				So(importLookupProperty, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetImportIDFormat(t *testing.T) {
+	Convey("Given a SpecV2Resource with a root path containing the x-terraform-import-id-format extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfImportIDFormat: "{zone_id}:{id}",
+					},
+				},
+			},
+		}
+		Convey("When getImportIDFormat method is called", func() {
+			importIDFormat := r.getImportIDFormat()
+			Convey("Then the value returned should be the extension value", func() {
+				So(importIDFormat, ShouldEqual, "{zone_id}:{id}")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root POST operation containing the x-terraform-import-id-format extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfImportIDFormat: "{zone_id}/{id}",
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When getImportIDFormat method is called", func() {
+			importIDFormat := r.getImportIDFormat()
+			Convey("Then the value returned should be the extension value", func() {
+				So(importIDFormat, ShouldEqual, "{zone_id}/{id}")
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource without the x-terraform-import-id-format extension", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When getImportIDFormat method is called", func() {
+			importIDFormat := r.getImportIDFormat()
+			Convey("Then the value returned should be empty", func() {
+				So(importIDFormat, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestShouldRemoveOnNotFound(t *testing.T) {
+	Convey("Given a SpecV2Resource without the x-terraform-remove-on-not-found extension", t, func() {
+		r := SpecV2Resource{
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{},
+				},
+			},
+		}
+		Convey("When shouldRemoveOnNotFound method is called", func() {
+			Convey("Then the value returned should be true", func() {
+				So(r.shouldRemoveOnNotFound(), ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root path containing the x-terraform-remove-on-not-found extension disabled", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfRemoveOnNotFound: false,
+					},
+				},
+			},
+		}
+		Convey("When shouldRemoveOnNotFound method is called", func() {
+			Convey("Then the value returned should be false", func() {
+				So(r.shouldRemoveOnNotFound(), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with an instance GET operation containing the x-terraform-remove-on-not-found extension disabled", t, func() {
+		r := SpecV2Resource{
+			InstancePathItem: spec.PathItem{
+				PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								extTfRemoveOnNotFound: false,
+							},
+						},
+					},
+				},
+			},
+		}
+		Convey("When shouldRemoveOnNotFound method is called", func() {
+			Convey("Then the value returned should be false", func() {
+				So(r.shouldRemoveOnNotFound(), ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root path containing the x-terraform-remove-on-not-found extension enabled", t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						extTfRemoveOnNotFound: true,
 					},
 				},
+			},
+		}
+		Convey("When shouldRemoveOnNotFound method is called", func() {
+			Convey("Then the value returned should be true", func() {
+				So(r.shouldRemoveOnNotFound(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetStateMigrations(t *testing.T) {
+	newResource := func(migrations interface{}) *SpecV2Resource {
+		return &SpecV2Resource{
+			RootPathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
 					Post: &spec.Operation{
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								extTfResourceName: "postPreferredName",
+								extTfStateMigration: migrations,
 							},
 						},
 					},
 				},
 			},
-			expectedResourceName: "rootLevelPreferredName",
-		},
-		{
-			name:                 " an empty path item",
-			inputPathItem:        spec.PathItem{},
-			expectedResourceName: "",
-		},
-	}
-
-	for _, tc := range testCases {
-		specV2Resource := SpecV2Resource{}
-		value := specV2Resource.getPreferredName(tc.inputPathItem)
-		assert.Equal(t, tc.expectedResourceName, value, tc.name)
+		}
 	}
-}
-
-func TestGetExtensionStringValue(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey("When getExtensionStringValue method is called an existing extension", func() {
-			extensions := spec.Extensions{}
-			expectedExtensionValue := "example"
-			extensions.Add(extTfResourceName, expectedExtensionValue)
-			value := r.getExtensionStringValue(extensions, extTfResourceName)
-			Convey("Then the value returned should match the value in the extension", func() {
-				So(value, ShouldEqual, expectedExtensionValue)
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root path POST operation containing the %s extension", extTfStateMigration), t, func() {
+		r := newResource([]interface{}{
+			map[string]interface{}{
+				"renames": map[string]interface{}{"label": "name", "oldDescription": "newDescription"},
+			},
+			map[string]interface{}{
+				"type_changes": map[string]interface{}{"port": "integer"},
+			},
+		})
+		Convey("When getStateMigrations method is called", func() {
+			migrations, err := r.getStateMigrations()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the migrations returned should contain the renames and type changes with terraform compliant names", func() {
+				So(migrations.getSchemaVersion(), ShouldEqual, 2)
+				So(migrations[0].renames, ShouldResemble, map[string]string{"label": "name", "old_description": "new_description"})
+				So(migrations[0].typeChanges, ShouldBeEmpty)
+				So(migrations[1].renames, ShouldBeEmpty)
+				So(migrations[1].typeChanges, ShouldResemble, map[string]schemaDefinitionPropertyType{"port": typeInt})
 			})
 		})
-		Convey("When getExtensionStringValue method is called a NON existing extension", func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceName, "example")
-			value := r.getExtensionStringValue(extensions, "somethingOtherExtensionName")
-			Convey("Then the value returned should match the value in the extension", func() {
-				So(value, ShouldEqual, "")
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root path POST operation that does not contain the %s extension", extTfStateMigration), t, func() {
+		r := SpecV2Resource{RootPathItem: spec.PathItem{PathItemProps: spec.PathItemProps{Post: &spec.Operation{}}}}
+		Convey("When getStateMigrations method is called", func() {
+			migrations, err := r.getStateMigrations()
+			Convey("Then no migrations nor error should be returned", func() {
+				So(err, ShouldBeNil)
+				So(migrations, ShouldBeEmpty)
 			})
 		})
 	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource with %s extension values that are not valid", extTfStateMigration), t, func() {
+		testCases := []struct {
+			migrations    interface{}
+			expectedError string
+		}{
+			{migrations: "not a list", expectedError: "x-terraform-state-migration extension value must be a list of migrations"},
+			{migrations: []interface{}{"not an object"}, expectedError: "x-terraform-state-migration extension migration from version 0 to 1 is not valid: migration must be an object containing renames and/or type_changes"},
+			{migrations: []interface{}{map[string]interface{}{"renames": "not an object"}}, expectedError: "x-terraform-state-migration extension migration from version 0 to 1 is not valid: renames must be an object"},
+			{migrations: []interface{}{map[string]interface{}{}, map[string]interface{}{"renames": map[string]interface{}{"label": ""}}}, expectedError: "x-terraform-state-migration extension migration from version 1 to 2 is not valid: renames 'label' value must be a non empty string"},
+			{migrations: []interface{}{map[string]interface{}{"type_changes": map[string]interface{}{"port": "object"}}}, expectedError: "x-terraform-state-migration extension migration from version 0 to 1 is not valid: property 'port' type 'object' is not supported, supported types are: string, integer, number and boolean"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When getStateMigrations method is called with %v", tc.migrations), func() {
+				_, err := newResource(tc.migrations).getStateMigrations()
+				Convey("Then the error returned should be the expected", func() {
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				})
+			})
+		}
+	})
 }
 
-func TestCreateResponses(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
+func TestGetLocationHeader(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfResourceLocationHeader), t, func() {
 		r := SpecV2Resource{}
-		Convey("When createResponses method is called with an operation that has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
-			expectedTarget := "deployed"
-			expectedStatus := "deploy_pending"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, true)
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
-			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
-			operation := &spec.Operation{
-				OperationProps: spec.OperationProps{
-					Responses: &spec.Responses{
-						ResponsesProps: spec.ResponsesProps{
-							StatusCodeResponses: map[int]spec.Response{
-								http.StatusAccepted: {
-									VendorExtensible: spec.VendorExtensible{
-										Extensions: extensions,
-									},
-								},
-							},
-						},
-					},
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfResourceLocationHeader: "X-Resource-Location",
 				},
-			}
-			specResponses := r.createResponses(operation)
-			Convey("Then the spec responses map returned should not be empty", func() {
-				So(specResponses, ShouldNotBeEmpty)
-			})
-			Convey("Then there should be an existing key for response code 202", func() {
-				So(specResponses, ShouldContainKey, http.StatusAccepted)
+			},
+			OperationProps: spec.OperationProps{
+				Responses: &spec.Responses{},
+			},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation location header should be the one configured in the extension", func() {
+				So(resourceOperation.getLocationHeader(), ShouldEqual, "X-Resource-Location")
 			})
-			Convey("And the response should meet the configuration", func() {
-				So(specResponses[http.StatusAccepted].isPollingEnabled, ShouldBeTrue)
-				So(specResponses[http.StatusAccepted].pollTargetStatuses, ShouldContain, expectedTarget)
-				So(specResponses[http.StatusAccepted].pollPendingStatuses, ShouldContain, expectedStatus)
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain the %s extension", extTfResourceLocationHeader), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation location header should be the default one", func() {
+				So(resourceOperation.getLocationHeader(), ShouldEqual, defaultLocationHeader)
 			})
 		})
+	})
+}
 
-		Convey("When createResponses method is called with an operation does not have any status responses", func() {
+func TestGetResponseWrapperProperty(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource with the %s extension at the root path level", extTfResponseWrapperProperty), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResponseWrapperProperty: "data"}}},
+		}
+		Convey("When createResourceOperation method is called with an operation that does not contain the extension", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the resource operation response wrapper property should be the one configured at the root path level", func() {
+				So(resourceOperation.responseWrapperProperty, ShouldEqual, "data")
+			})
+		})
+		Convey("When createResourceOperation method is called with an operation containing the extension", func() {
 			operation := &spec.Operation{
-				OperationProps: spec.OperationProps{
-					Responses: &spec.Responses{
-						ResponsesProps: spec.ResponsesProps{
-							StatusCodeResponses: map[int]spec.Response{},
-						},
-					},
-				},
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResponseWrapperProperty: "result.item"}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
 			}
-			specResponses := r.createResponses(operation)
-			Convey("Then the spec responses map returned should not be empty", func() {
-				So(specResponses, ShouldBeEmpty)
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation response wrapper property should be the one configured in the operation", func() {
+				So(resourceOperation.responseWrapperProperty, ShouldEqual, "result.item")
 			})
 		})
 	})
 }
 
-func TestIsResourcePollingEnabled(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to true", func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, true)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the bool returned should be true", func() {
-				So(isResourcePollingEnabled, ShouldBeTrue)
+func TestUnwrapResponsePayload(t *testing.T) {
+	Convey("Given a resource operation configured with a response wrapper property", t, func() {
+		operation := &specResourceOperation{responseWrapperProperty: "result.item"}
+		Convey("When unwrapResponsePayload is called with a payload wrapping the resource in the property", func() {
+			payload, err := operation.unwrapResponsePayload(map[string]interface{}{
+				"result": map[string]interface{}{"item": map[string]interface{}{"id": "someID"}},
+				"meta":   map[string]interface{}{"request_id": "someRequestID"},
+			})
+			Convey("Then the resource should be returned", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"id": "someID"})
 			})
 		})
-		Convey("When isResourcePollingEnabled method is called with a list of responses where one of the responses matches the response status received and has the 'x-terraform-resource-poll-enabled' extension set to false", func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollEnabled, false)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the bool returned should be false", func() {
-				So(isResourcePollingEnabled, ShouldBeFalse)
+		Convey("When unwrapResponsePayload is called with a payload without the property", func() {
+			payload, err := operation.unwrapResponsePayload(map[string]interface{}{})
+			Convey("Then the payload should be returned as is", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldBeEmpty)
 			})
 		})
-		Convey("When isResourcePollingEnabled method is called with list of responses where non of the codes match the given response http code", func() {
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusOK: {},
-					},
-				},
-			}
-			isResourcePollingEnabled := r.isResourcePollingEnabled(responses.StatusCodeResponses[http.StatusOK])
-			Convey("Then bool returned should be false", func() {
-				So(isResourcePollingEnabled, ShouldBeFalse)
+		Convey("When unwrapResponsePayload is called with a payload where the property is null", func() {
+			payload, err := operation.unwrapResponsePayload(map[string]interface{}{"result": map[string]interface{}{"item": nil}})
+			Convey("Then an empty payload should be returned", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldBeEmpty)
+			})
+		})
+		Convey("When unwrapResponsePayload is called with a payload where the property is not an object", func() {
+			_, err := operation.unwrapResponsePayload(map[string]interface{}{"result": map[string]interface{}{"item": "someValue"}})
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "response payload property 'result.item' containing the resource is not an object")
+			})
+		})
+	})
+	Convey("Given a resource operation that is not configured with a response wrapper property", t, func() {
+		operation := &specResourceOperation{}
+		Convey("When unwrapResponsePayload is called", func() {
+			payload, err := operation.unwrapResponsePayload(map[string]interface{}{"data": map[string]interface{}{"id": "someID"}})
+			Convey("Then the payload should be returned as is", func() {
+				So(err, ShouldBeNil)
+				So(payload, ShouldResemble, map[string]interface{}{"data": map[string]interface{}{"id": "someID"}})
 			})
 		})
 	})
 }
 
-func TestGetResourcePollTargetStatuses(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey("When getResourcePollTargetStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
-			expectedTarget := "deployed"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
-			responses := &spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
+func TestGetRequestWrapperProperty(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource with the %s extension at the root path level", extTfRequestWrapperProperty), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfRequestWrapperProperty: "server"}}},
+		}
+		Convey("When createResourceOperation method is called with an operation that does not contain the extension", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the resource operation request wrapper property should be the one configured at the root path level", func() {
+				So(resourceOperation.requestWrapperProperty, ShouldEqual, "server")
+			})
+		})
+		Convey("When createResourceOperation method is called with an operation containing the extension", func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfRequestWrapperProperty: "server.update"}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
 			}
-			statuses := r.getResourcePollTargetStatuses(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the status returned should contain", func() {
-				So(statuses, ShouldContain, expectedTarget)
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation request wrapper property should be the one configured in the operation", func() {
+				So(resourceOperation.requestWrapperProperty, ShouldEqual, "server.update")
 			})
 		})
 	})
 }
 
-func TestGetResourcePollPendingStatuses(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{}
-		Convey("When getResourcePollPendingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-pending-statuses'", func() {
-			expectedStatus := "deploy_pending"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollPendingStatuses, expectedStatus)
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
+func TestGetOptimisticLocking(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource with the %s extension at the root path level", extTfOptimisticLocking), t, func() {
+		r := SpecV2Resource{
+			RootPathItem: spec.PathItem{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfOptimisticLocking: "etag"}}},
+		}
+		Convey("When createResourceOperation method is called with an operation that does not contain the extension", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the resource operation optimistic locking should be the one configured at the root path level", func() {
+				So(resourceOperation.optimisticLocking, ShouldResemble, &specOptimisticLocking{strategy: optimisticLockingETag})
+			})
+		})
+		Convey("When createResourceOperation method is called with an operation containing the extension", func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfOptimisticLocking: map[string]interface{}{"version_property": "version", "max_retries": float64(3)}}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
 			}
-			statuses := r.getResourcePollPendingStatuses(responses.StatusCodeResponses[http.StatusAccepted])
-			Convey("Then the status returned should contain", func() {
-				So(statuses, ShouldContain, expectedStatus)
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation optimistic locking should be the one configured in the operation", func() {
+				So(resourceOperation.optimisticLocking, ShouldResemble, &specOptimisticLocking{strategy: optimisticLockingVersion, versionProperty: "version", maxRetries: 3})
+			})
+		})
+		Convey("When createResourceOperation method is called with an operation containing a value that is not valid", func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfOptimisticLocking: true}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+			}
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the extension should be ignored", func() {
+				So(resourceOperation.optimisticLocking, ShouldBeNil)
 			})
 		})
 	})
 }
 
-func TestGetPollingStatuses(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
+func TestCreateResourceOperationConditionalRead(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfConditionalRead), t, func() {
 		r := SpecV2Resource{}
-		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
-			expectedTarget := "deployed"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTarget)
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
-			}
-			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
-			Convey("Then the statuses returned should contain", func() {
-				So(statuses, ShouldContain, expectedTarget)
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfConditionalRead: true}},
+			OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should be a conditional read", func() {
+				So(resourceOperation.isConditionalRead(), ShouldBeTrue)
 			})
 		})
-
-		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses' containing multiple targets (comma separated with spaces)", func() {
-			expectedTargets := "deployed, completed, done"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTargets)
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
+		Convey("When createResourceOperation method is called with an operation that does not contain the extension", func() {
+			resourceOperation := r.createResourceOperation(&spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}})
+			Convey("Then the resource operation should not be a conditional read", func() {
+				So(resourceOperation.isConditionalRead(), ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestGetReadAfterCreateRetries(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfReadAfterCreateRetries), t, func() {
+		r := SpecV2Resource{}
+		Convey("When createResourceOperation method is called with a positive integer", func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfReadAfterCreateRetries: float64(5)}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
 			}
-			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
-			Convey("Then the statuses returned should contain expected targets", func() {
-				// the expected Targets are a list of targets with no spaces whatsoever, hence why the removal of spaces
-				for _, expectedTarget := range strings.Split(strings.Replace(expectedTargets, " ", "", -1), ",") {
-					So(statuses, ShouldContain, expectedTarget)
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation read after create retries should be the ones configured in the extension", func() {
+				So(resourceOperation.readAfterCreateRetries, ShouldEqual, 5)
+			})
+		})
+		Convey("When createResourceOperation method is called with values that are not positive integers", func() {
+			Convey("Then the extension should be ignored", func() {
+				for _, value := range []interface{}{float64(-1), float64(1.5), "5"} {
+					operation := &spec.Operation{
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfReadAfterCreateRetries: value}},
+						OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+					}
+					So(r.createResourceOperation(operation).readAfterCreateRetries, ShouldEqual, 0)
 				}
 			})
 		})
+	})
+}
 
-		Convey("When getPollingStatuses method is called with a response that has a given extension 'x-terraform-resource-poll-completed-statuses' containing multiple targets (comma separated with no spaces)", func() {
-			expectedTargets := "deployed,completed,done"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourcePollTargetStatuses, expectedTargets)
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: extensions,
-							},
-						},
-					},
-				},
+func TestGetDeletePoll(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfResourceDeletePoll), t, func() {
+		r := SpecV2Resource{}
+		newOperation := func(value interface{}) *spec.Operation {
+			return &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceDeletePoll: value}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
 			}
-			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
-			Convey("Then the statuses returned should contain expected targets", func() {
-				for _, expectedTarget := range strings.Split(expectedTargets, ",") {
-					So(statuses, ShouldContain, expectedTarget)
-				}
+		}
+		Convey("When createResourceOperation method is called with the extension enabled", func() {
+			resourceOperation := r.createResourceOperation(newOperation(true))
+			Convey("Then the resource operation should verify the resource is gone", func() {
+				So(resourceOperation.deletePoll, ShouldResemble, &specDeletePoll{})
+			})
+		})
+		Convey("When createResourceOperation method is called with the extension configured with the deleted statuses", func() {
+			resourceOperation := r.createResourceOperation(newOperation(map[string]interface{}{"deleted_statuses": "deleted"}))
+			Convey("Then the resource operation delete poll should contain the deleted statuses", func() {
+				So(resourceOperation.deletePoll, ShouldResemble, &specDeletePoll{deletedStatuses: []string{"deleted"}})
+			})
+		})
+		Convey("When createResourceOperation method is called with the extension disabled or with a value that is not valid", func() {
+			Convey("Then the extension should be ignored", func() {
+				So(r.createResourceOperation(newOperation(false)).deletePoll, ShouldBeNil)
+				So(r.createResourceOperation(newOperation("true")).deletePoll, ShouldBeNil)
+				So(r.createResourceOperation(newOperation(map[string]interface{}{"timeout": "5m"})).deletePoll, ShouldBeNil)
 			})
 		})
+	})
+}
 
-		Convey("When getPollingStatuses method is called with a response that has does not have a given extension 'x-terraform-resource-poll-completed-statuses'", func() {
-			responses := spec.Responses{
-				ResponsesProps: spec.ResponsesProps{
-					StatusCodeResponses: map[int]spec.Response{
-						http.StatusAccepted: {
-							VendorExtensible: spec.VendorExtensible{
-								Extensions: spec.Extensions{},
-							},
-						},
-					},
+func TestCreateResourceOperationDeleteBody(t *testing.T) {
+	Convey("Given a SpecV2Resource with schema definitions", t, func() {
+		r := SpecV2Resource{
+			SchemaDefinitions: map[string]spec.Schema{
+				"DeleteOptions": {SchemaProps: spec.SchemaProps{Properties: map[string]spec.Schema{"reason": {}, "force": {}}}},
+			},
+		}
+		Convey("When createResourceOperation method is called with an operation that has a body parameter referencing a schema definition", func() {
+			operation := &spec.Operation{
+				OperationProps: spec.OperationProps{
+					Parameters: []spec.Parameter{{ParamProps: spec.ParamProps{Name: "body", In: "body", Schema: spec.RefSchema("#/definitions/DeleteOptions")}}},
+					Responses:  &spec.Responses{},
 				},
 			}
-			statuses := r.getPollingStatuses(responses.StatusCodeResponses[http.StatusAccepted], extTfResourcePollTargetStatuses)
-			Convey("Then the status returned should be empty", func() {
-				So(statuses, ShouldBeEmpty)
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation delete body properties should be the properties of the schema definition", func() {
+				So(resourceOperation.deleteBodyProperties, ShouldResemble, []string{"force", "reason"})
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation containing the %s extension", extTfDeleteBody), func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfDeleteBody: map[string]interface{}{"force": true}}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+			}
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation delete body template should be the one configured in the extension", func() {
+				So(resourceOperation.deleteBodyTemplate, ShouldResemble, map[string]interface{}{"force": true})
+				So(resourceOperation.deleteBodyProperties, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation containing the %s extension with a value that is not an object", extTfDeleteBody), func() {
+			operation := &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfDeleteBody: "force=true"}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+			}
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the extension should be ignored", func() {
+				So(resourceOperation.deleteBodyTemplate, ShouldBeNil)
 			})
 		})
 	})
 }
 
-func TestGetTimeouts(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		expectedTimeout := "30s"
-		extensions := spec.Extensions{}
-		extensions.Add(extTfResourceTimeout, expectedTimeout)
-		op := &spec.Operation{
-			VendorExtensible: spec.VendorExtensible{
-				Extensions: extensions,
+func TestGetPreDeleteOperation(t *testing.T) {
+	Convey("Given a SpecV2Resource which paths contain a detach operation", t, func() {
+		r := SpecV2Resource{
+			Path: "/v1/resource",
+			Paths: map[string]spec.PathItem{
+				"/v1/resource/{id}/detach": {PathItemProps: spec.PathItemProps{Post: &spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}}}},
 			},
 		}
+		newOperation := func(value interface{}) *spec.Operation {
+			return &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfPreDeleteOperation: value}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+			}
+		}
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation containing the %s extension pointing at the detach operation", extTfPreDeleteOperation), func() {
+			resourceOperation := r.createResourceOperation(newOperation("/v1/resource/{id}/detach"))
+			Convey("Then the resource operation pre delete operation should be the detach operation", func() {
+				So(resourceOperation.preDeleteOperation, ShouldNotBeNil)
+				So(resourceOperation.preDeleteOperation.method, ShouldEqual, httpPost)
+				So(resourceOperation.preDeleteOperation.pathSuffix, ShouldEqual, "/detach")
+				So(resourceOperation.preDeleteOperation.operation, ShouldNotBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation containing the %s extension pointing at an operation that is not defined", extTfPreDeleteOperation), func() {
+			resourceOperation := r.createResourceOperation(newOperation(map[string]interface{}{"path": "/v1/resource/{id}/detach", "method": "put"}))
+			Convey("Then the extension should be ignored", func() {
+				So(resourceOperation.preDeleteOperation, ShouldBeNil)
+			})
+		})
+		Convey(fmt.Sprintf("When createResourceOperation method is called with an operation containing the %s extension with a value that is not valid", extTfPreDeleteOperation), func() {
+			resourceOperation := r.createResourceOperation(newOperation(true))
+			Convey("Then the extension should be ignored", func() {
+				So(resourceOperation.preDeleteOperation, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestGetResourceActions(t *testing.T) {
+	Convey("Given a SpecV2Resource which paths contain operations configured as resource actions", t, func() {
+		newOperation := func(value interface{}) *spec.Operation {
+			return &spec.Operation{
+				VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceAction: value}},
+				OperationProps:   spec.OperationProps{Responses: &spec.Responses{}},
+			}
+		}
 		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: op,
-				},
-			},
-			InstancePathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Put:    op,
-					Get:    op,
-					Delete: op,
-				},
+			Path: "/v1/servers",
+			Paths: map[string]spec.PathItem{
+				"/v1/servers/{id}/reboot":  {PathItemProps: spec.PathItemProps{Post: newOperation("reboot")}},
+				"/v1/servers/{id}/resize":  {PathItemProps: spec.PathItemProps{Put: newOperation(map[string]interface{}{"name": "resize", "body": map[string]interface{}{"size": "{size}"}})}},
+				"/v1/servers/{id}/restart": {PathItemProps: spec.PathItemProps{Post: newOperation("reboot")}},
+				"/v1/servers/{id}/stop":    {PathItemProps: spec.PathItemProps{Post: newOperation(true)}},
+				"/v1/servers/{id}/start":   {PathItemProps: spec.PathItemProps{Post: &spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}}}},
+				"/v1/volumes/{id}/detach":  {PathItemProps: spec.PathItemProps{Post: newOperation("detach")}},
 			},
 		}
-		Convey("When getTimeouts method is called ", func() {
-			timeouts, err := r.getTimeouts()
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("Then the duration returned should contain the expected duration from the operation", func() {
-				So(*timeouts.Post, ShouldEqual, time.Duration(30*time.Second))
-				So(*timeouts.Get, ShouldEqual, time.Duration(30*time.Second))
-				So(*timeouts.Put, ShouldEqual, time.Duration(30*time.Second))
-				So(*timeouts.Delete, ShouldEqual, time.Duration(30*time.Second))
+		Convey("When getResourceActions method is called", func() {
+			actions := r.getResourceActions()
+			Convey("Then the actions returned should be the valid ones under the resource instance path", func() {
+				So(actions, ShouldHaveLength, 2)
+				So(actions[0].name, ShouldEqual, "reboot")
+				So(actions[0].method, ShouldEqual, httpPost)
+				So(actions[0].pathSuffix, ShouldEqual, "/reboot")
+				So(actions[0].operation, ShouldNotBeNil)
+				So(actions[1].name, ShouldEqual, "resize")
+				So(actions[1].method, ShouldEqual, httpPut)
+				So(actions[1].pathSuffix, ShouldEqual, "/resize")
+				So(actions[1].body, ShouldResemble, map[string]interface{}{"size": "{size}"})
 			})
 		})
 	})
 }
 
-func TestGetResourceTimeout(t *testing.T) {
+func TestGetRequestMediaType(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey(fmt.Sprintf("When getResourceTimeout method is called with an operation that has the extension '%s'", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			post := &spec.Operation{
-				VendorExtensible: spec.VendorExtensible{
-					Extensions: extensions,
-				},
+		Convey("When getRequestMediaType method is called with operations consuming different media types", func() {
+			testCases := []struct {
+				consumes []string
+				expected string
+			}{
+				{consumes: nil, expected: ""},
+				{consumes: []string{"application/json"}, expected: ""},
+				{consumes: []string{"multipart/form-data"}, expected: mediaTypeMultipartFormData},
+				{consumes: []string{"Multipart/Form-Data; charset=utf-8"}, expected: mediaTypeMultipartFormData},
+				{consumes: []string{"multipart/form-data", "application/json"}, expected: ""},
+				{consumes: []string{"application/x-www-form-urlencoded"}, expected: mediaTypeFormURLEncoded},
+				{consumes: []string{"application/x-www-form-urlencoded", "multipart/form-data"}, expected: mediaTypeMultipartFormData},
+				{consumes: []string{"application/xml", "multipart/form-data"}, expected: mediaTypeXML},
+				{consumes: []string{"text/xml"}, expected: mediaTypeTextXML},
+				{consumes: []string{"application/xml", "application/json"}, expected: ""},
 			}
-			duration, err := r.getResourceTimeout(post)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("Then the duration returned should contain", func() {
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+			Convey("Then the media type returned should be the media type preferred when application/json is not consumed", func() {
+				for _, tc := range testCases {
+					So(r.getRequestMediaType(&spec.Operation{OperationProps: spec.OperationProps{Consumes: tc.consumes}}), ShouldEqual, tc.expected)
+				}
 			})
 		})
 	})
 }
 
-func TestGetTimeDuration(t *testing.T) {
+func TestGetResponseMediaType(t *testing.T) {
 	Convey("Given a SpecV2Resource", t, func() {
 		r := SpecV2Resource{}
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in seconds", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
-			})
-			Convey("Then the duration returned should contain", func() {
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+		Convey("When getResponseMediaType method is called with operations producing different media types", func() {
+			testCases := []struct {
+				produces []string
+				expected string
+			}{
+				{produces: nil, expected: ""},
+				{produces: []string{"application/json"}, expected: ""},
+				{produces: []string{"application/xml"}, expected: mediaTypeXML},
+				{produces: []string{"text/xml; charset=utf-8"}, expected: mediaTypeTextXML},
+				{produces: []string{"text/xml", "application/xml"}, expected: mediaTypeXML},
+				{produces: []string{"application/xml", "application/json"}, expected: ""},
+				{produces: []string{"text/plain"}, expected: ""},
+				{produces: []string{"application/octet-stream"}, expected: mediaTypeOctetStream},
+				{produces: []string{"application/octet-stream", "application/xml"}, expected: mediaTypeXML},
+			}
+			Convey("Then the media type returned should be the media type preferred when application/json is not produced", func() {
+				for _, tc := range testCases {
+					So(r.getResponseMediaType(&spec.Operation{OperationProps: spec.OperationProps{Produces: tc.produces}}), ShouldEqual, tc.expected)
+				}
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in minutes (using fractions)", extTfResourceTimeout), func() {
-			expectedTimeout := "20.5m"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+	})
+}
+
+func TestGetXMLSchema(t *testing.T) {
+	Convey("Given a SpecV2Resource with a schema definition and a definition referenced by the body parameters", t, func() {
+		userSchema := spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: spec.StringOrArray{"object"},
+				Properties: map[string]spec.Schema{
+					"id":   {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{XML: &spec.XMLObject{Attribute: true}}},
+					"name": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+				},
+			},
+		}
+		r := SpecV2Resource{
+			Name:              "users",
+			SchemaDefinition:  userSchema,
+			SchemaDefinitions: map[string]spec.Schema{"User": userSchema},
+		}
+		Convey("When getXMLSchema method is called with an operation that consumes XML and has a body parameter referencing a definition", func() {
+			operation := &spec.Operation{OperationProps: spec.OperationProps{
+				Consumes:   []string{"application/xml"},
+				Parameters: []spec.Parameter{{ParamProps: spec.ParamProps{In: "body", Name: "body", Schema: spec.RefSchema("#/definitions/User")}}},
+			}}
+			xmlSchema := r.getXMLSchema(operation)
+			Convey("Then the root element should be named after the definition referenced", func() {
+				So(xmlSchema.name, ShouldEqual, "User")
+				So(xmlSchema.getProperty("id").attribute, ShouldBeTrue)
+				So(xmlSchema.getProperty("name").attribute, ShouldBeFalse)
 			})
-			Convey("Then the duration returned should contain", func() {
-				So(*duration, ShouldEqual, time.Duration((20*time.Minute)+(30*time.Second)))
+		})
+		Convey("When getXMLSchema method is called with an operation that produces XML and does not have body parameters", func() {
+			xmlSchema := r.getXMLSchema(&spec.Operation{OperationProps: spec.OperationProps{Produces: []string{"application/xml"}}})
+			Convey("Then the root element should be named after the resource", func() {
+				So(xmlSchema.name, ShouldEqual, "users")
+				So(xmlSchema.getProperty("id").attribute, ShouldBeTrue)
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that contains the extension passed in '%s' with value in hours", extTfResourceTimeout), func() {
-			expectedTimeout := "1h"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		Convey("When getXMLSchema method is called with an operation that consumes and produces JSON", func() {
+			xmlSchema := r.getXMLSchema(&spec.Operation{OperationProps: spec.OperationProps{Consumes: []string{"application/json"}, Produces: []string{"application/json"}}})
+			Convey("Then the XML schema returned should be nil", func() {
+				So(xmlSchema, ShouldBeNil)
 			})
-			Convey("Then the duration returned should contain", func() {
-				So(*duration, ShouldEqual, time.Duration(1*time.Hour))
+		})
+	})
+}
+
+func TestWrapRequestPayload(t *testing.T) {
+	Convey("Given a resource operation configured with a request wrapper property", t, func() {
+		operation := &specResourceOperation{requestWrapperProperty: "data.server"}
+		Convey("When wrapRequestPayload is called with a payload", func() {
+			payload := operation.wrapRequestPayload(map[string]interface{}{"name": "someName"})
+			Convey("Then the payload should be nested under the wrapper property", func() {
+				So(payload, ShouldResemble, map[string]interface{}{"data": map[string]interface{}{"server": map[string]interface{}{"name": "someName"}}})
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES NOT contain the extension passed in '%s'", extTfResourceTimeout), func() {
-			expectedTimeout := "30s"
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, expectedTimeout)
-			duration, err := r.getTimeDuration(extensions, "nonExistingExtension")
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		Convey("When wrapRequestPayload is called with a JSON patch document", func() {
+			payload := operation.wrapRequestPayload([]jsonPatchOperation{{Op: "replace", Path: "/name", Value: "someName"}, {Op: "remove", Path: "/label"}})
+			Convey("Then the paths of the operations should be prefixed with the wrapper property", func() {
+				So(payload, ShouldResemble, []jsonPatchOperation{{Op: "replace", Path: "/data/server/name", Value: "someName"}, {Op: "remove", Path: "/data/server/label"}})
 			})
-			Convey("Then the duration returned should be nil", func() {
-				So(duration, ShouldBeNil)
+		})
+	})
+	Convey("Given a resource operation that is not configured with a request wrapper property", t, func() {
+		operation := &specResourceOperation{}
+		Convey("When wrapRequestPayload is called", func() {
+			payload := operation.wrapRequestPayload(map[string]interface{}{"name": "someName"})
+			Convey("Then the payload should be returned as is", func() {
+				So(payload, ShouldResemble, map[string]interface{}{"name": "someName"})
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is an empty string", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+	})
+}
+
+func TestGetRetryConfigurationExtension(t *testing.T) {
+	newOperation := func(value interface{}) *spec.Operation {
+		return &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfResourceRetry: value,
+				},
+			},
+			OperationProps: spec.OperationProps{
+				Responses: &spec.Responses{},
+			},
+		}
+	}
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with an object value", extTfResourceRetry), t, func() {
+		r := SpecV2Resource{}
+		operation := newOperation(map[string]interface{}{"max_retries": float64(5), "backoff": "2s", "max_backoff": "1m", "status_codes": []interface{}{float64(409), float64(503)}})
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation retry policy should contain the settings configured in the extension", func() {
+				So(resourceOperation.retry, ShouldResemble, &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s", MaxBackoff: "1m", StatusCodes: []int{409, 503}})
 			})
-			Convey("Then the duration returned should be nil", func() {
-				So(duration, ShouldBeNil)
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension set to true", extTfResourceRetry), t, func() {
+		r := SpecV2Resource{}
+		operation := newOperation(true)
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation retry policy should be enabled with the default settings", func() {
+				So(resourceOperation.retry, ShouldResemble, &RetryConfiguration{})
 			})
-			Convey("And the error message should be", func() {
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: ''. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension set to false", extTfResourceRetry), t, func() {
+		r := SpecV2Resource{}
+		operation := newOperation(false)
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation retry policy should be disabled", func() {
+				So(resourceOperation.retry, ShouldResemble, &RetryConfiguration{disabled: true})
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is a negative duration", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "-1.5h")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and operations containing the %s extension with values that are not valid", extTfResourceRetry), t, func() {
+		r := SpecV2Resource{}
+		values := []interface{}{
+			"yes",
+			map[string]interface{}{"max_retries": "5"},
+			map[string]interface{}{"max_retries": float64(1.5)},
+			map[string]interface{}{"backoff": float64(2)},
+			map[string]interface{}{"max_backoff": "wrong"},
+			map[string]interface{}{"status_codes": []interface{}{"503"}},
+			map[string]interface{}{"unknown": true},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			Convey("Then the extension should be ignored", func() {
+				for _, value := range values {
+					So(r.createResourceOperation(newOperation(value)).retry, ShouldBeNil)
+				}
 			})
-			Convey("Then the duration returned should be nil", func() {
-				So(duration, ShouldBeNil)
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain the %s extension", extTfResourceRetry), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{OperationProps: spec.OperationProps{Responses: &spec.Responses{}}}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation retry policy should be nil", func() {
+				So(resourceOperation.retry, ShouldBeNil)
 			})
-			Convey("And the error message should be", func() {
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: '-1.5h'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+		})
+	})
+}
+
+func TestGetResponseHeaderProperties(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfResponseHeaderProperty), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfResponseHeaderProperty: map[string]interface{}{"X-Resource-Id": "resource_id", "Location": "location", "X-Request-Id": true},
+				},
+			},
+			OperationProps: spec.OperationProps{
+				Responses: &spec.Responses{},
+			},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should contain the property names keyed by header ignoring the ones that are not strings", func() {
+				So(resourceOperation.responseHeaderProperties, ShouldResemble, map[string]string{"X-Resource-Id": "resource_id", "Location": "location"})
 			})
 		})
-		Convey(fmt.Sprintf("When getTimeDuration method is called with a list of extensions that DOES contain the extension passed in '%s' BUT the value is NOT supported (distinct than s,m and h)", extTfResourceTimeout), func() {
-			extensions := spec.Extensions{}
-			extensions.Add(extTfResourceTimeout, "300ms")
-			duration, err := r.getTimeDuration(extensions, extTfResourceTimeout)
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with a value that is not an object", extTfResponseHeaderProperty), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfResponseHeaderProperty: "X-Resource-Id",
+				},
+			},
+		}
+		Convey("When getResponseHeaderProperties method is called", func() {
+			Convey("Then the response header properties returned should be nil", func() {
+				So(r.getResponseHeaderProperties(operation), ShouldBeNil)
 			})
-			Convey("Then the duration returned should be nil", func() {
-				So(duration, ShouldBeNil)
+		})
+	})
+}
+
+func TestGetCollectionFormats(t *testing.T) {
+	Convey("Given a SpecV2Resource and an operation containing array query parameters", t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			OperationProps: spec.OperationProps{
+				Parameters: []spec.Parameter{
+					{ParamProps: spec.ParamProps{Name: "tags", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array"}},
+					{ParamProps: spec.ParamProps{Name: "status", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "multi"}},
+					{ParamProps: spec.ParamProps{Name: "names", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "pipes"}},
+					{ParamProps: spec.ParamProps{Name: "invalid", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "json"}},
+					{ParamProps: spec.ParamProps{Name: "label", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "string"}},
+					{ParamProps: spec.ParamProps{Name: "X-Tags", In: "header"}, SimpleSchema: spec.SimpleSchema{Type: "array", CollectionFormat: "ssv"}},
+				},
+				Responses: &spec.Responses{},
+			},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should contain the collection formats of the array query parameters, csv by default and ignoring the ones not supported", func() {
+				So(resourceOperation.collectionFormats, ShouldResemble, map[string]collectionFormat{"tags": collectionFormatCSV, "status": collectionFormatMulti, "names": collectionFormatPipes})
 			})
-			Convey("And the error message should be", func() {
-				So(err.Error(), ShouldContainSubstring, "invalid duration value: '300ms'. The value must be a sequence of decimal numbers each with optional fraction and a unit suffix (negative durations are not allowed). The value must be formatted either in seconds (s), minutes (m) or hours (h)")
+		})
+	})
+	Convey("Given a SpecV2Resource and an operation that does not contain array query parameters", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getCollectionFormats method is called", func() {
+			collectionFormats := r.getCollectionFormats(&spec.Operation{})
+			Convey("Then the collection formats returned should be nil", func() {
+				So(collectionFormats, ShouldBeNil)
 			})
 		})
 	})
 }
 
-func TestGetDuration(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
+func TestGetQueryParameters(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension", extTfQueryParams), t, func() {
 		r := SpecV2Resource{}
-		Convey("When getDuration method is called a valid formatted time'", func() {
-			duration, err := r.getDuration("30s")
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfQueryParams: map[string]interface{}{"apiVersion": "2023-01-01", "limit": float64(10), "status": []interface{}{"active", "pending"}},
+				},
+			},
+			OperationProps: spec.OperationProps{
+				Responses: &spec.Responses{},
+			},
+		}
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should contain the query parameters with their values as strings", func() {
+				So(resourceOperation.queryParameters, ShouldResemble, map[string][]string{"apiVersion": {"2023-01-01"}, "limit": {"10"}, "status": {"active", "pending"}})
 			})
-			Convey("Then the statuses returned should contain", func() {
-				fmt.Println(duration)
-				So(*duration, ShouldEqual, time.Duration(30*time.Second))
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with a value that is not an object", extTfQueryParams), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfQueryParams: "apiVersion=2023-01-01",
+				},
+			},
+		}
+		Convey("When getQueryParameters method is called", func() {
+			queryParameters := r.getQueryParameters(operation)
+			Convey("Then the query parameters returned should be nil", func() {
+				So(queryParameters, ShouldBeNil)
 			})
 		})
-		Convey("When getDuration method is called a invalid formatted time'", func() {
-			_, err := r.getDuration("some invalid formatted time")
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldNotBeNil)
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain the %s extension", extTfQueryParams), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getQueryParameters method is called", func() {
+			queryParameters := r.getQueryParameters(&spec.Operation{})
+			Convey("Then the query parameters returned should be nil", func() {
+				So(queryParameters, ShouldBeNil)
 			})
 		})
 	})
 }
 
-func TestSpecV2ResourceGetHost(t *testing.T) {
-	Convey("Given a SpecV2Resource", t, func() {
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: "www.some-host.com",
-							},
-						},
+func TestGetFilterParameters(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing query parameters with the %s extension", extTfFilterParam), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			OperationProps: spec.OperationProps{
+				Parameters: []spec.Parameter{
+					{
+						ParamProps:       spec.ParamProps{Name: "label", In: "query"},
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFilterParam: true}},
+					},
+					{
+						ParamProps:       spec.ParamProps{Name: "name_eq", In: "query"},
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFilterParam: "name"}},
+					},
+					{
+						ParamProps:       spec.ParamProps{Name: "disabled", In: "query"},
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFilterParam: false}},
+					},
+					{
+						ParamProps: spec.ParamProps{Name: "limit", In: "query"},
+					},
+					{
+						ParamProps:       spec.ParamProps{Name: "X-Header", In: "header"},
+						VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfFilterParam: true}},
 					},
 				},
+				Responses: &spec.Responses{},
 			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should map the filter property names to the query parameter names", func() {
+				So(resourceOperation.filterParameters, ShouldResemble, map[string]string{"label": "label", "name": "name_eq"})
 			})
-			Convey("Then the host returned should be the override host", func() {
-				So(host, ShouldEqual, "www.some-host.com")
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain query parameters with the %s extension", extTfFilterParam), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getFilterParameters method is called", func() {
+			filterParameters := r.getFilterParameters(&spec.Operation{})
+			Convey("Then the filter parameters returned should be nil", func() {
+				So(filterParameters, ShouldBeNil)
 			})
 		})
 	})
-	Convey("Given a SpecV2Resource without an override host specified", t, func() {
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{},
+}
+
+func TestGetPagination(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with the pagination type", extTfPagination), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfPagination: "cursor",
 				},
 			},
+			OperationProps: spec.OperationProps{
+				Responses: &spec.Responses{},
+			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		Convey("When createResourceOperation method is called", func() {
+			resourceOperation := r.createResourceOperation(operation)
+			Convey("Then the resource operation should contain the pagination with the default settings", func() {
+				So(resourceOperation.pagination, ShouldResemble, &specPagination{strategy: paginationCursor, cursorParam: "cursor", cursorField: "next_cursor", itemsField: "items"})
 			})
-			Convey("Then the host returned should be the override host", func() {
-				So(host, ShouldEqual, "")
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with an object", extTfPagination), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfPagination: map[string]interface{}{"type": "page", "page_param": "p"},
+				},
+			},
+		}
+		Convey("When getPagination method is called", func() {
+			pagination := r.getPagination(operation)
+			Convey("Then the pagination returned should contain the settings configured", func() {
+				So(pagination, ShouldResemble, &specPagination{strategy: paginationPage, pageParam: "p", startPage: 1})
 			})
 		})
 	})
-	Convey("Given a SpecV2Resource that doesn't have a POST operation specified", t, func() {
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: nil,
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with a value that is not valid", extTfPagination), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfPagination: true,
 				},
 			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the error returned should be nil", func() {
-				So(err, ShouldBeNil)
+		Convey("When getPagination method is called", func() {
+			pagination := r.getPagination(operation)
+			Convey("Then the pagination returned should be nil", func() {
+				So(pagination, ShouldBeNil)
 			})
-			Convey("Then the host returned should be an empty string", func() {
-				So(host, ShouldEqual, "")
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain the %s extension", extTfPagination), t, func() {
+		r := SpecV2Resource{}
+		Convey("When getPagination method is called", func() {
+			pagination := r.getPagination(&spec.Operation{})
+			Convey("Then the pagination returned should be nil", func() {
+				So(pagination, ShouldBeNil)
 			})
 		})
 	})
-	Convey("Given a SpecV2Resource that is multi region but region isn't specified", t, func() {
+}
+
+func TestGetDefaultValue(t *testing.T) {
+	testCases := []struct {
+		name          string
+		propertyType  schemaDefinitionPropertyType
+		defaultValue  interface{}
+		expectedValue interface{}
+	}{
+		{name: "no default value", propertyType: typeString, defaultValue: nil, expectedValue: nil},
+		{name: "string default value", propertyType: typeString, defaultValue: "value", expectedValue: "value"},
+		{name: "bool default value", propertyType: typeBool, defaultValue: true, expectedValue: true},
+		{name: "int default value parsed as a JSON number", propertyType: typeInt, defaultValue: float64(60), expectedValue: 60},
+		{name: "int default value", propertyType: typeInt, defaultValue: 60, expectedValue: 60},
+		{name: "int default value with decimals", propertyType: typeInt, defaultValue: 1.5, expectedValue: nil},
+		{name: "float default value", propertyType: typeFloat, defaultValue: 1.5, expectedValue: 1.5},
+		{name: "float default value defined as an int", propertyType: typeFloat, defaultValue: 1, expectedValue: float64(1)},
+		{name: "default value not matching the property type", propertyType: typeBool, defaultValue: "true", expectedValue: nil},
+		{name: "list default value", propertyType: typeList, defaultValue: []interface{}{"a"}, expectedValue: []interface{}{"a"}},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{}
+		assert.Equal(t, tc.expectedValue, r.getDefaultValue("propertyName", tc.propertyType, tc.defaultValue), tc.name)
+	}
+
+	logger := &loggerStub{}
+	r := SpecV2Resource{logger: logger}
+	assert.Nil(t, r.getDefaultValue("propertyName", typeBool, "true"))
+	assert.True(t, logger.containsMessage("WARN", "ignoring default value 'true' of property 'propertyName' as it is not of type 'boolean'"), "the default value ignored should be logged with the resource logger")
+}
+
+func TestGetConstraints(t *testing.T) {
+	Convey("Given a SpecV2Resource", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getConstraints is called with a property schema with no constraints", func() {
+			constraints := r.getConstraints("propertyName", *spec.StringProperty())
+			Convey("Then the constraints returned should be nil", func() {
+				So(constraints, ShouldBeNil)
+			})
+		})
+		Convey("When getConstraints is called with a property schema with constraints", func() {
+			propertySchema := spec.StringProperty().WithEnum("a", "bb").WithPattern("^[a-z]+$").WithMinLength(1).WithMaxLength(2)
+			constraints := r.getConstraints("propertyName", *propertySchema)
+			Convey("Then the constraints returned should contain the constraints of the schema", func() {
+				So(constraints.Enum, ShouldResemble, []interface{}{"a", "bb"})
+				So(constraints.Pattern.String(), ShouldEqual, "^[a-z]+$")
+				So(*constraints.MinLength, ShouldEqual, 1)
+				So(*constraints.MaxLength, ShouldEqual, 2)
+			})
+		})
+		Convey("When getConstraints is called with a property schema with a pattern not supported", func() {
+			propertySchema := spec.StringProperty().WithPattern("^(?=a).*$")
+			constraints := r.getConstraints("propertyName", *propertySchema)
+			Convey("Then the pattern should be ignored", func() {
+				So(constraints, ShouldBeNil)
+			})
+		})
+		Convey("When createSchemaDefinitionProperty is called with an integer property schema with a minimum and maximum", func() {
+			propertySchema := spec.Int64Property().WithMinimum(1, false).WithMaximum(10, true)
+			schemaDefinitionProperty, err := r.createSchemaDefinitionProperty("propertyName", *propertySchema, []string{})
+			Convey("Then the schema definition property should contain the constraints", func() {
+				So(err, ShouldBeNil)
+				So(*schemaDefinitionProperty.Constraints.Minimum, ShouldEqual, 1)
+				So(*schemaDefinitionProperty.Constraints.Maximum, ShouldEqual, 10)
+				So(schemaDefinitionProperty.Constraints.ExclusiveMaximum, ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestGetIdentifierResourceNames(t *testing.T) {
+	testCases := []struct {
+		name          string
+		path          string
+		preferredName string
+		expectedNames []string
+	}{
+		{name: "versioned plural path", path: "/v1/cdns", expectedNames: []string{"cdns", "cdn"}},
+		{name: "singular path with trailing slash", path: "/cdn/", expectedNames: []string{"cdn"}},
+		{name: "path with hyphens", path: "/v1/load-balancers", expectedNames: []string{"load_balancers", "load_balancer"}},
+		{name: "subresource path", path: "/v1/cdns/{cdn_id}/v1/firewalls", expectedNames: []string{"firewalls", "firewall"}},
+		{name: "path with preferred name", path: "/v1/cdns", preferredName: "contentDelivery", expectedNames: []string{"cdns", "cdn", "content_delivery"}},
+	}
+	for _, tc := range testCases {
+		r := SpecV2Resource{Path: tc.path}
+		if tc.preferredName != "" {
+			r.RootPathItem = spec.PathItem{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: tc.preferredName}}}
+		}
+		assert.Equal(t, tc.expectedNames, r.getIdentifierResourceNames(), tc.name)
+	}
+}
+
+func TestGetResourceIdentifierByConvention(t *testing.T) {
+	readOnlyUUID := spec.Schema{SchemaProps: spec.SchemaProps{Format: formatUUID}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}}
+	testCases := []struct {
+		name               string
+		properties         map[string]spec.Schema
+		expectedIdentifier string
+		expectedConvention string
+	}{
+		{name: "property named id in upper case", properties: map[string]spec.Schema{"ID": {}, "cdnId": {}}, expectedIdentifier: "ID", expectedConvention: "property named id"},
+		{name: "property named after the resource in camel case", properties: map[string]spec.Schema{"cdnId": {}, "uuid": readOnlyUUID}, expectedIdentifier: "cdnId", expectedConvention: "property named after the resource name 'cdn'"},
+		{name: "property named after the resource in snake case", properties: map[string]spec.Schema{"cdns_id": {}}, expectedIdentifier: "cdns_id", expectedConvention: "property named after the resource name 'cdns'"},
+		{name: "only read only uuid property", properties: map[string]spec.Schema{"uuid": readOnlyUUID, "name": {}}, expectedIdentifier: "uuid", expectedConvention: "only read only property with uuid format"},
+		{name: "uuid property that is not read only", properties: map[string]spec.Schema{"uuid": {SchemaProps: spec.SchemaProps{Format: formatUUID}}}, expectedIdentifier: ""},
+		{name: "multiple read only uuid properties", properties: map[string]spec.Schema{"uuid": readOnlyUUID, "otherUUID": readOnlyUUID}, expectedIdentifier: ""},
+		{name: "no property matching", properties: map[string]spec.Schema{"name": {}}, expectedIdentifier: ""},
+	}
+	for _, tc := range testCases {
+		identifier, convention := getResourceIdentifierByConvention([]string{"cdns", "cdn"}, &spec.Schema{SchemaProps: spec.SchemaProps{Properties: tc.properties}}, nil)
+		assert.Equal(t, tc.expectedIdentifier, identifier, tc.name)
+		assert.Equal(t, tc.expectedConvention, convention, tc.name)
+	}
+}
+
+func TestGetResourceSchemaIdentifierByConvention(t *testing.T) {
+	Convey("Given a SpecV2Resource which schema does not contain an id property but contains a property named after the resource", t, func() {
 		r := SpecV2Resource{
-			Region: "",
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: "www.${region}.some-host.com",
-							},
-						},
+			Path: "/v1/cdns",
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"cdnId": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+						"name":  {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
 					},
 				},
 			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the error returned should be as expected", func() {
-				So(err.Error(), ShouldEqual, "region can not be empty for multiregion resources")
+		Convey("When getResourceSchema method is called", func() {
+			schemaDefinition, err := r.getResourceSchema()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("Then the host returned should be an empty string", func() {
-				So(host, ShouldEqual, "")
+			Convey("And the property named after the resource should be used as the resource identifier", func() {
+				identifier, err := schemaDefinition.getResourceIdentifier()
+				So(err, ShouldBeNil)
+				So(identifier, ShouldEqual, "cdnId")
 			})
 		})
 	})
-	Convey("Given a SpecV2Resource that is multi region with region specified", t, func() {
+	Convey(fmt.Sprintf("Given a SpecV2Resource which schema contains a property named after the resource and a property with the %s extension", extTfID), t, func() {
 		r := SpecV2Resource{
-			Region: "rst1",
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: "www.${region}.some-host.com",
-							},
-						},
+			Path: "/v1/cdns",
+			SchemaDefinition: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"cdnId":  {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+						"someID": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfID: true}}},
 					},
 				},
 			},
 		}
-		Convey("When getHost is called", func() {
-			host, err := r.getHost()
-			Convey("Then the error returned should be nil", func() {
+		Convey("When getResourceSchema method is called", func() {
+			schemaDefinition, err := r.getResourceSchema()
+			Convey("Then the property with the extension should be used as the resource identifier", func() {
 				So(err, ShouldBeNil)
-			})
-			Convey("Then the host returned should be the override host", func() {
-				So(host, ShouldEqual, "www.rst1.some-host.com")
+				identifier, err := schemaDefinition.getResourceIdentifier()
+				So(err, ShouldBeNil)
+				So(identifier, ShouldEqual, "someID")
+				cdnIDProperty, _ := schemaDefinition.getProperty("cdnId")
+				So(cdnIDProperty.IsIdentifier, ShouldBeFalse)
 			})
 		})
 	})
 }
 
-func TestGetResourceOverrideHost(t *testing.T) {
-	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a non parametrized host containing the host to use", t, func() {
-		expectedHost := "some.api.domain.com"
+func TestGetUpdateStrategy(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource and a PATCH operation containing the %s extension with the json-patch value", extTfUpdateStrategy), t, func() {
 		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
+			InstancePathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
+					Patch: &spec.Operation{
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								extTfResourceURL: expectedHost,
+								extTfUpdateStrategy: "json-patch",
 							},
 						},
+						OperationProps: spec.OperationProps{
+							Responses: &spec.Responses{},
+						},
 					},
 				},
 			},
 		}
-		Convey("When getResourceOverrideHost method is called", func() {
-			host := getResourceOverrideHost(r.RootPathItem.Post)
-			Convey("Then the value returned should be the host value", func() {
-				So(host, ShouldEqual, expectedHost)
+		Convey("When getResourceOperations method is called", func() {
+			operations := r.getResourceOperations()
+			Convey("Then the PATCH operation should be configured with the json-patch update strategy", func() {
+				So(operations.Patch, ShouldNotBeNil)
+				So(operations.Patch.updateStrategy, ShouldEqual, updateStrategyJSONPatch)
+				So(operations.Patch.getPatchContentType(), ShouldEqual, "application/json-patch+json")
+			})
+			Convey("And the update operation should be the PATCH operation since the resource does not support PUT", func() {
+				operation, method := operations.getUpdateOperation()
+				So(operation, ShouldEqual, operations.Patch)
+				So(method, ShouldEqual, httpPatch)
 			})
 		})
 	})
-
-	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with a parametrized host containing the host to use", t, func() {
-		expectedHost := "some.api.${serviceProviderName}.domain.com"
-		r := SpecV2Resource{
-			RootPathItem: spec.PathItem{
-				PathItemProps: spec.PathItemProps{
-					Post: &spec.Operation{
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								extTfResourceURL: expectedHost,
-							},
-						},
-					},
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation that does not contain the %s extension", extTfUpdateStrategy), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{}
+		Convey("When getUpdateStrategy method is called", func() {
+			updateStrategy := r.getUpdateStrategy(operation)
+			Convey("Then the update strategy should be empty and the operation should default to merge-patch", func() {
+				So(updateStrategy, ShouldBeEmpty)
+				resourceOperation := &specResourceOperation{updateStrategy: updateStrategy}
+				So(resourceOperation.getUpdateStrategy(), ShouldEqual, updateStrategyMergePatch)
+				So(resourceOperation.getPatchContentType(), ShouldEqual, "application/merge-patch+json")
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource and an operation containing the %s extension with a value that is not supported", extTfUpdateStrategy), t, func() {
+		r := SpecV2Resource{}
+		operation := &spec.Operation{
+			VendorExtensible: spec.VendorExtensible{
+				Extensions: spec.Extensions{
+					extTfUpdateStrategy: "strategic-merge-patch",
 				},
 			},
 		}
-		Convey("When getResourceOverrideHost method is called", func() {
-			host := getResourceOverrideHost(r.RootPathItem.Post)
-			Convey("Then the value returned should be the host value", func() {
-				So(host, ShouldEqual, expectedHost)
+		Convey("When getUpdateStrategy method is called", func() {
+			updateStrategy := r.getUpdateStrategy(operation)
+			Convey("Then the extension should be ignored", func() {
+				So(updateStrategy, ShouldBeEmpty)
 			})
 		})
 	})
+}
 
-	Convey("Given a terraform compliant resource that has a POST operation containing the x-terraform-resource-host with an empty string value", t, func() {
-		expectedHost := ""
+func TestGetUpdateOperation(t *testing.T) {
+	putOperation := &specResourceOperation{}
+	Convey("Given the operations of a resource that supports PUT and PATCH without update strategy", t, func() {
+		operations := specResourceOperations{Put: putOperation, Patch: &specResourceOperation{}}
+		Convey("When getUpdateOperation method is called", func() {
+			operation, method := operations.getUpdateOperation()
+			Convey("Then the PUT operation should be used", func() {
+				So(operation, ShouldEqual, putOperation)
+				So(method, ShouldEqual, httpPut)
+			})
+		})
+	})
+	Convey("Given the operations of a resource that supports PUT and PATCH configured with an update strategy", t, func() {
+		patchOperation := &specResourceOperation{updateStrategy: updateStrategyMergePatch}
+		operations := specResourceOperations{Put: putOperation, Patch: patchOperation}
+		Convey("When getUpdateOperation method is called", func() {
+			operation, method := operations.getUpdateOperation()
+			Convey("Then the PATCH operation should be used", func() {
+				So(operation, ShouldEqual, patchOperation)
+				So(method, ShouldEqual, httpPatch)
+			})
+		})
+	})
+	Convey("Given the operations of a resource that does not support PUT nor PATCH", t, func() {
+		operations := specResourceOperations{}
+		Convey("When getUpdateOperation method is called", func() {
+			operation, _ := operations.getUpdateOperation()
+			Convey("Then the operation returned should be nil", func() {
+				So(operation, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestIsImmutableForceNewResource(t *testing.T) {
+	Convey(fmt.Sprintf("Given a SpecV2Resource with a root POST operation containing the %s extension", extTfImmutableForceNew), t, func() {
 		r := SpecV2Resource{
 			RootPathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
 					Post: &spec.Operation{
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
-								extTfResourceURL: expectedHost,
+								extTfImmutableForceNew: true,
 							},
 						},
 					},
 				},
 			},
 		}
-		Convey("When getResourceOverrideHost method is called", func() {
-			host := getResourceOverrideHost(r.RootPathItem.Post)
-			Convey("Then the value returned should be the host value", func() {
-				So(host, ShouldEqual, expectedHost)
+		Convey("When isImmutableForceNewResource method is called", func() {
+			isImmutableForceNewResource := r.isImmutableForceNewResource()
+			Convey("Then the result should be true", func() {
+				So(isImmutableForceNewResource, ShouldBeTrue)
+			})
+		})
+	})
+	Convey(fmt.Sprintf("Given a SpecV2Resource that does not contain the %s extension", extTfImmutableForceNew), t, func() {
+		r := SpecV2Resource{}
+		Convey("When isImmutableForceNewResource method is called", func() {
+			isImmutableForceNewResource := r.isImmutableForceNewResource()
+			Convey("Then the result should be false", func() {
+				So(isImmutableForceNewResource, ShouldBeFalse)
 			})
 		})
 	})
+}
 
-	Convey("Given a terraform resource that doesn't have a POST operation", t, func() {
+func TestGetResourceTags(t *testing.T) {
+	Convey("Given a SpecV2Resource with a root POST operation containing tags", t, func() {
 		r := SpecV2Resource{
 			RootPathItem: spec.PathItem{
 				PathItemProps: spec.PathItemProps{
-					Post: nil,
+					Post: &spec.Operation{
+						OperationProps: spec.OperationProps{
+							Tags: []string{"billing", "invoices"},
+						},
+					},
 				},
 			},
 		}
-		Convey("When getResourceOverrideHost method is called", func() {
-			host := getResourceOverrideHost(r.RootPathItem.Post)
-			Convey("Then the value returned should be an empty string", func() {
-				So(host, ShouldEqual, "")
+		Convey("When getResourceTags method is called", func() {
+			tags := r.getResourceTags()
+			Convey("Then the tags returned should be the POST operation ones", func() {
+				So(tags, ShouldResemble, []string{"billing", "invoices"})
+			})
+		})
+	})
+	Convey("Given a SpecV2Resource with a root path without POST operation", t, func() {
+		r := SpecV2Resource{}
+		Convey("When getResourceTags method is called", func() {
+			tags := r.getResourceTags()
+			Convey("Then the tags returned should be empty", func() {
+				So(tags, ShouldBeEmpty)
 			})
 		})
 	})
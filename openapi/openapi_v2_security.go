@@ -2,6 +2,8 @@ package openapi
 
 import (
 	"fmt"
+	"sort"
+
 	"github.com/go-openapi/spec"
 )
 
@@ -14,7 +16,7 @@ type specV2Security struct {
 }
 
 // GetAPIKeySecurityDefinitions returns a list of SpecSecurityDefinition after looping through the SecurityDefinitions
-// and selecting only the SecurityDefinitions of type apiKey
+// and selecting only the SecurityDefinitions of type apiKey and oauth2 using the client credentials flow ('application')
 func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinitions, error) {
 	securityDefinitions := &SpecSecurityDefinitions{}
 	for secDefName, secDef := range s.SecurityDefinitions {
@@ -42,11 +44,33 @@ func (s *specV2Security) GetAPIKeySecurityDefinitions() (*SpecSecurityDefinition
 				return nil, err
 			}
 			*securityDefinitions = append(*securityDefinitions, securityDefinition)
+		} else if s.isOAuth2ClientCredentials(secDef) {
+			securityDefinition := newOAuth2ClientCredentialsSecurityDefinition(secDefName, secDef.TokenURL, s.getOAuth2Scopes(secDef))
+			if err := securityDefinition.validate(); err != nil {
+				return nil, err
+			}
+			*securityDefinitions = append(*securityDefinitions, securityDefinition)
 		}
 	}
 	return securityDefinitions, nil
 }
 
+// isOAuth2ClientCredentials returns true if the security definition is of type oauth2 using the client credentials flow,
+// which is named 'application' in OpenAPI 2.0
+func (s *specV2Security) isOAuth2ClientCredentials(secDef *spec.SecurityScheme) bool {
+	return secDef.Type == "oauth2" && secDef.Flow == "application"
+}
+
+// getOAuth2Scopes returns the scopes declared in the security definition sorted alphabetically
+func (s *specV2Security) getOAuth2Scopes(secDef *spec.SecurityScheme) []string {
+	var scopes []string
+	for scope := range secDef.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
 func (s *specV2Security) isBearerScheme(secDef *spec.SecurityScheme) bool {
 	authScheme, enabled := secDef.Extensions.GetBool(extTfAuthenticationSchemeBearer)
 	if authScheme && enabled {
@@ -78,3 +102,21 @@ func (s *specV2Security) GetGlobalSecuritySchemes() (SpecSecuritySchemes, error)
 	}
 	return securitySchemes, nil
 }
+
+// GetGlobalSecurityRequirements returns all the global security requirements (in order of preference) which security
+// schemes have their corresponding SpecSecurityDefinition
+func (s *specV2Security) GetGlobalSecurityRequirements() ([]SpecSecuritySchemes, error) {
+	securityRequirements := createSecurityRequirements(s.GlobalSecurity)
+	secDefs, err := s.GetAPIKeySecurityDefinitions()
+	if err != nil {
+		return nil, err
+	}
+	for _, securityRequirement := range securityRequirements {
+		for _, securityScheme := range securityRequirement {
+			if secDefs.findSecurityDefinitionFor(securityScheme.Name) == nil {
+				return nil, fmt.Errorf("global security scheme '%s' not found or not matching supported 'apiKey' type", securityScheme.Name)
+			}
+		}
+	}
+	return securityRequirements, nil
+}
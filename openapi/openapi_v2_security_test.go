@@ -267,6 +267,79 @@ func TestGetAPIKeySecurityDefinitions(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a specV2Security loaded with a security definition of type oauth2 using the application flow", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:     "oauth2",
+						Flow:     "application",
+						TokenURL: "https://api.iam.com/oauth2/token",
+						Scopes:   map[string]string{"write": "write access", "read": "read access"},
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			secDefs := *securityDefinitions
+			Convey("Then the the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the security schemes should be of type oauth2 client credentials containing the token URL and scopes", func() {
+				So(secDefs, ShouldHaveLength, 1)
+				So(secDefs[0], ShouldHaveSameTypeAs, specOAuth2ClientCredentialsSecurityDefinition{})
+				So(secDefs[0].getAPIKey().Name, ShouldEqual, authorizationHeader)
+				So(secDefs[0].getAPIKey().Metadata[oauth2TokenURLKey], ShouldEqual, "https://api.iam.com/oauth2/token")
+				So(secDefs[0].getAPIKey().Metadata[oauth2ScopesKey], ShouldResemble, []string{"read", "write"})
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with security definitions of type oauth2 using flows other than application", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_implicit_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:             "oauth2",
+						Flow:             "implicit",
+						AuthorizationURL: "https://api.iam.com/oauth2/authorize",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			securityDefinitions, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the the error returned should be nil and the security definitions should be ignored", func() {
+				So(err, ShouldBeNil)
+				So(*securityDefinitions, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a specV2Security loaded with a security definition of type oauth2 using the application flow with a wrong token URL", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"oauth2_auth": &spec.SecurityScheme{
+					SecuritySchemeProps: spec.SecuritySchemeProps{
+						Type:     "oauth2",
+						Flow:     "application",
+						TokenURL: "not a url",
+					},
+				},
+			},
+		}
+		Convey("When GetAPIKeySecurityDefinitions method is called", func() {
+			_, err := specV2Security.GetAPIKeySecurityDefinitions()
+			Convey("Then the error should match the expected one", func() {
+				So(err.Error(), ShouldEqual, "oauth2 security definition 'oauth2_auth' token URL 'not a url' must be a valid URL")
+			})
+		})
+	})
 }
 
 func TestGetGlobalSecuritySchemes(t *testing.T) {
@@ -330,6 +403,58 @@ func TestGetGlobalSecuritySchemes(t *testing.T) {
 	})
 }
 
+func TestGetGlobalSecurityRequirements(t *testing.T) {
+	apiKeySecurityScheme := &spec.SecurityScheme{
+		SecuritySchemeProps: spec.SecuritySchemeProps{
+			In:   "header",
+			Type: "apiKey",
+			Name: authorizationHeader,
+		},
+	}
+	Convey("Given a specV2Security loaded with alternative global security requirements which are defined in the security definitions", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{
+				{"apikey_auth": []string{}, "app_id": []string{}},
+				{"bearer_auth": []string{}},
+			},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"apikey_auth": apiKeySecurityScheme,
+				"app_id":      apiKeySecurityScheme,
+				"bearer_auth": apiKeySecurityScheme,
+			},
+		}
+		Convey("When GetGlobalSecurityRequirements method is called", func() {
+			securityRequirements, err := specV2Security.GetGlobalSecurityRequirements()
+			Convey("Then the the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And all the security requirements should be returned in order", func() {
+				So(securityRequirements, ShouldResemble, []SpecSecuritySchemes{
+					{SpecSecurityScheme{Name: "apikey_auth"}, SpecSecurityScheme{Name: "app_id"}},
+					{SpecSecurityScheme{Name: "bearer_auth"}},
+				})
+			})
+		})
+	})
+	Convey("Given a specV2Security loaded with an alternative global security requirement containing a NON defined security scheme", t, func() {
+		specV2Security := specV2Security{
+			GlobalSecurity: []map[string][]string{
+				{"apikey_auth": []string{}},
+				{"nonExistingScheme": []string{}},
+			},
+			SecurityDefinitions: spec.SecurityDefinitions{
+				"apikey_auth": apiKeySecurityScheme,
+			},
+		}
+		Convey("When GetGlobalSecurityRequirements method is called", func() {
+			_, err := specV2Security.GetGlobalSecurityRequirements()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "global security scheme 'nonExistingScheme' not found or not matching supported 'apiKey' type")
+			})
+		})
+	})
+}
+
 func TestIsBearerScheme(t *testing.T) {
 	Convey("Given a specV2Security", t, func() {
 		specV2Security := specV2Security{
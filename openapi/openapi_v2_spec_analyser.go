@@ -1,12 +1,15 @@
 package openapi
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
@@ -16,12 +19,24 @@ import (
 
 const extTfResourceRegionsFmt = "x-terraform-resource-regions-%s"
 
+// extDefinitionName is the extension added internally to the schema definitions of the document containing the name of the
+// definition, so the name of the definitions referenced is still known once the refs are expanded (see annotateDefinitionNames)
+const extDefinitionName = "x-terraform-provider-openapi-definition-name"
+
 // specV2Analyser defines an SpecAnalyser implementation for OpenAPI v2 specification
 // Forcing creation of this object via constructor so proper input validation is performed before creating the struct
 // instance
 type specV2Analyser struct {
 	openAPIDocumentURL string
 	d                  *loads.Document
+
+	// resourcesOnce makes sure the terraform compliant resources are only analysed once
+	resourcesOnce sync.Once
+	resources     []SpecResource
+
+	// logger (optional) is used to log the messages produced when analysing the document, it's also passed along to the
+	// resources and data sources found
+	logger Logger
 }
 
 // newSpecAnalyserV2 creates an instance of specV2Analyser which implements the SpecAnalyser interface
@@ -30,10 +45,29 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 	if openAPIDocumentFilename == "" {
 		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
 	}
-	apiSpec, err := loads.JSONSpec(openAPIDocumentFilename)
+	document, err := loadOpenAPIDocument(openAPIDocumentFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	return newSpecAnalyserV2FromDocument(openAPIDocumentFilename, document, nil)
+}
+
+// newSpecAnalyserV2FromDocument creates an instance of specV2Analyser from the OpenAPI v2 document already retrieved
+// from the openAPIDocumentFilename. The messages are logged with the logger provided (the standard logger is used if
+// the logger is nil)
+func newSpecAnalyserV2FromDocument(openAPIDocumentFilename string, document []byte, logger Logger) (*specV2Analyser, error) {
+	apiSpec, err := loads.Analyzed(document, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	// the document is analysed again once annotated since the refs are expanded from the raw (JSON) document
+	annotatedDocument, err := annotateDefinitionNames(apiSpec.Raw())
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
 	}
+	if apiSpec, err = loads.Analyzed(annotatedDocument, ""); err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
 	apiSpec, err = apiSpec.Expanded()
 	if err != nil {
 		return nil, fmt.Errorf("failed to expand the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
@@ -41,9 +75,49 @@ func newSpecAnalyserV2(openAPIDocumentFilename string) (*specV2Analyser, error)
 	return &specV2Analyser{
 		d:                  apiSpec,
 		openAPIDocumentURL: openAPIDocumentFilename,
+		logger:             logger,
 	}, nil
 }
 
+// getLogger returns the logger configured in the analyser or the default logger if none was provided
+func (specAnalyser *specV2Analyser) getLogger() Logger {
+	return loggerOrDefault(specAnalyser.logger)
+}
+
+// annotateDefinitionNames returns the document with each schema definition containing its own name in the
+// extDefinitionName extension. The refs of the document are expanded when the document is analysed, so this is the only way
+// to know which definition a schema was referring to (e,g: the variants of a discriminated union named after the definition)
+func annotateDefinitionNames(document json.RawMessage) (json.RawMessage, error) {
+	var root map[string]json.RawMessage
+	if err := json.Unmarshal(document, &root); err != nil {
+		return nil, err
+	}
+	rawDefinitions, exists := root["definitions"]
+	if !exists {
+		return document, nil
+	}
+	var definitions map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(rawDefinitions, &definitions); err != nil {
+		return nil, err
+	}
+	for name, definition := range definitions {
+		// definitions that are just a ref to another definition are left as they are since the ref siblings are ignored
+		if _, isRef := definition["$ref"]; definition == nil || isRef {
+			continue
+		}
+		value, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		definition[extDefinitionName] = value
+	}
+	var err error
+	if root["definitions"], err = json.Marshal(definitions); err != nil {
+		return nil, err
+	}
+	return json.Marshal(root)
+}
+
 func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string, resourceRootPath string, resourceRoot, pathItem spec.PathItem, resourcePayloadSchemaDef *spec.Schema) ([]SpecResource, error) {
 	var resources []SpecResource
 	for _, regionName := range regions {
@@ -51,7 +125,8 @@ func (specAnalyser *specV2Analyser) createMultiRegionResources(regions []string,
 		if err != nil {
 			return nil, fmt.Errorf("failed to create a resource with region: %s", err)
 		}
-		log.Printf("[INFO] multi region resource name = %s, region = '%s'", r.getResourceName(), regionName)
+		r.logger = specAnalyser.logger
+		specAnalyser.getLogger().Info(fmt.Sprintf("multi region resource name = %s, region = '%s'", r.getResourceName(), regionName))
 		resources = append(resources, r)
 	}
 	return resources, nil
@@ -64,67 +139,228 @@ func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSources() []SpecRes
 	for resourcePath, pathItem := range paths.Paths {
 		schemaDefinition, err := specAnalyser.isEndPointTerraformDataSourceCompliant(pathItem)
 		if err != nil {
-			log.Printf("[DEBUG] resource path '%s' not terraform data source compliant: %s", resourcePath, err)
+			specAnalyser.getLogger().Debug(fmt.Sprintf("resource path '%s' not terraform data source compliant: %s", resourcePath, err))
 			continue
 		}
 
 		d, err := newSpecV2DataSource(resourcePath, *schemaDefinition, pathItem, specAnalyser.d.Spec().Paths.Paths)
 		if err != nil {
-			log.Printf("[WARN] ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err)
+			specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring data source '%s' due to an error while creating a creating the SpecV2Resource: %s", resourcePath, err))
+			continue
+		}
+		d.logger = specAnalyser.logger
+
+		if _, err := d.getResourceSchema(); err != nil {
+			specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring data source name='%s' with rootPath='%s' due to the schema definition not being supported: %s", d.getResourceName(), resourcePath, err))
 			continue
 		}
 
-		log.Printf("[INFO] found terraform compliant data source [name='%s', rootPath='%s']", d.getResourceName(), resourcePath)
+		specAnalyser.getLogger().Info(fmt.Sprintf("found terraform compliant data source [name='%s', rootPath='%s']", d.getResourceName(), resourcePath))
 		dataSources = append(dataSources, d)
 	}
 	return dataSources
 }
 
-func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecResource, error) {
-	var resources []SpecResource
-	start := time.Now()
-	spec := specAnalyser.d.Spec()
-	paths := spec.Paths
+func (specAnalyser *specV2Analyser) GetTerraformCompliantDataSourceInstances() []SpecResource {
+	var dataSourceInstances []SpecResource
+	paths := specAnalyser.d.Spec().Paths
 	for resourcePath, pathItem := range paths.Paths {
-		resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
+		resourceRootPath, resourceRoot, schemaDefinition, err := specAnalyser.isEndPointGetOnlyTerraformDataSourceInstanceCompliant(resourcePath)
 		if err != nil {
-			log.Printf("[DEBUG] resource path '%s' not terraform compliant: %s", resourcePath, err)
+			specAnalyser.getLogger().Debug(fmt.Sprintf("resource path '%s' not terraform data source instance compliant: %s", resourcePath, err))
 			continue
 		}
 
-		isMultiRegion, regions, err := specAnalyser.isMultiRegionResource(resourceRoot, specAnalyser.d.Spec().Extensions)
+		d, err := newSpecV2Resource(resourceRootPath, *schemaDefinition, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, paths.Paths)
 		if err != nil {
-			log.Printf("multi region configuration for resource '%s' is not valid: ", err)
+			specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring data source instance '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err))
 			continue
 		}
-		if isMultiRegion {
-			log.Printf("[INFO] resource '%s' is configured with host override AND multi region; creating one reasource per region", resourceRootPath)
-			multiRegionResources, err := specAnalyser.createMultiRegionResources(regions, resourceRootPath, *resourceRoot, pathItem, resourcePayloadSchemaDef)
-			if err != nil {
-				log.Printf("[WARN] ignoring multiregion resource '%s' due to an error: %s", resourceRootPath, err)
-				continue
-			}
-			resources = append(resources, multiRegionResources...)
+		d.logger = specAnalyser.logger
+
+		if _, err := d.getResourceSchema(); err != nil {
+			specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring data source instance name='%s' with rootPath='%s' due to the schema definition not being supported: %s", d.getResourceName(), resourceRootPath, err))
 			continue
 		}
 
-		r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
-		if err != nil {
-			log.Printf("[WARN] ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err)
-			continue
+		specAnalyser.getLogger().Info(fmt.Sprintf("found terraform compliant data source instance [name='%s', rootPath='%s', instancePath='%s']", d.getResourceName(), resourceRootPath, resourcePath))
+		dataSourceInstances = append(dataSourceInstances, d)
+	}
+	return dataSourceInstances
+}
+
+// GetTerraformCompliantResources returns the terraform compliant resources found in the OpenAPI document. The paths are
+// analysed concurrently and only once, the resources found are kept so subsequent calls do not analyse the document again
+func (specAnalyser *specV2Analyser) GetTerraformCompliantResources() ([]SpecResource, error) {
+	specAnalyser.resourcesOnce.Do(func() {
+		specAnalyser.resources = specAnalyser.analyseTerraformCompliantResources()
+	})
+	return specAnalyser.resources, nil
+}
+
+func (specAnalyser *specV2Analyser) analyseTerraformCompliantResources() []SpecResource {
+	start := time.Now()
+	paths := specAnalyser.getSortedPaths()
+	pathResources := make([][]SpecResource, len(paths))
+	unsupportedSchemaErrs := make([]error, len(paths))
+	forEachConcurrently(len(paths), func(i int) {
+		pathResources[i], unsupportedSchemaErrs[i] = specAnalyser.analyseResourcePath(paths[i])
+	})
+	var resources []SpecResource
+	var unsupportedSchemaResources []string
+	for i := range paths {
+		resources = append(resources, pathResources[i]...)
+		if unsupportedSchemaErrs[i] != nil {
+			unsupportedSchemaResources = append(unsupportedSchemaResources, unsupportedSchemaErrs[i].Error())
 		}
+	}
+	if len(unsupportedSchemaResources) > 0 {
+		specAnalyser.getLogger().Warn(fmt.Sprintf("%d resources have been ignored due to their schema definitions not being supported:\n%s", len(unsupportedSchemaResources), strings.Join(unsupportedSchemaResources, "\n")))
+	}
+	resources = specAnalyser.resolveResourceNameCollisions(resources)
+	specAnalyser.getLogger().Info(fmt.Sprintf("found %d terraform compliant resources (time: %s)", len(resources), time.Since(start)))
+	return resources
+}
+
+// analyseResourcePath returns the terraform compliant resources of the given resource instance path (several resources
+// are returned for multi region resources). The error returned describes the schema definition issues that make the
+// resource not supported
+func (specAnalyser *specV2Analyser) analyseResourcePath(resourcePath string) ([]SpecResource, error) {
+	pathItem := specAnalyser.d.Spec().Paths.Paths[resourcePath]
+	resourceRootPath, resourceRoot, resourcePayloadSchemaDef, err := specAnalyser.isEndPointFullyTerraformResourceCompliant(resourcePath)
+	if err != nil {
+		specAnalyser.getLogger().Debug(fmt.Sprintf("resource path '%s' not terraform compliant: %s", resourcePath, err))
+		return nil, nil
+	}
 
-		err = specAnalyser.validateSubResourceTerraformCompliance(*r)
+	isMultiRegion, regions, err := specAnalyser.isMultiRegionResource(resourceRoot, specAnalyser.d.Spec().Extensions)
+	if err != nil {
+		specAnalyser.getLogger().Warn(fmt.Sprintf("multi region configuration for resource '%s' is not valid: %s", resourceRootPath, err))
+		return nil, nil
+	}
+	if isMultiRegion {
+		specAnalyser.getLogger().Info(fmt.Sprintf("resource '%s' is configured with host override AND multi region; creating one reasource per region", resourceRootPath))
+		multiRegionResources, err := specAnalyser.createMultiRegionResources(regions, resourceRootPath, *resourceRoot, pathItem, resourcePayloadSchemaDef)
 		if err != nil {
-			log.Printf("[WARN] ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.getResourceName(), resourceRootPath, err)
+			specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring multiregion resource '%s' due to an error: %s", resourceRootPath, err))
+			return nil, nil
+		}
+		if len(multiRegionResources) > 0 {
+			if err := specAnalyser.validateResourceSchema(multiRegionResources[0], resourceRootPath); err != nil {
+				return nil, err
+			}
+		}
+		return multiRegionResources, nil
+	}
+
+	r, err := newSpecV2Resource(resourceRootPath, *resourcePayloadSchemaDef, *resourceRoot, pathItem, specAnalyser.d.Spec().Definitions, specAnalyser.d.Spec().Paths.Paths)
+	if err != nil {
+		specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring resource '%s' due to an error while creating a creating the SpecV2Resource: %s", resourceRootPath, err))
+		return nil, nil
+	}
+	r.logger = specAnalyser.logger
+
+	err = specAnalyser.validateSubResourceTerraformCompliance(*r)
+	if err != nil {
+		specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring subresource name='%s' with rootPath='%s' due to not meeting validation requirements: %s", r.getResourceName(), resourceRootPath, err))
+		return nil, nil
+	}
+
+	if err := specAnalyser.validateResourceSchema(r, resourceRootPath); err != nil {
+		return nil, err
+	}
+
+	specAnalyser.getLogger().Info(fmt.Sprintf("found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.getResourceName(), resourceRootPath, resourcePath))
+	return []SpecResource{r}, nil
+}
+
+// getSortedPaths returns the paths of the OpenAPI document sorted alphabetically so the resources are always returned
+// in the same order
+func (specAnalyser *specV2Analyser) getSortedPaths() []string {
+	var paths []string
+	if specAnalyser.d.Spec().Paths == nil {
+		return paths
+	}
+	for resourcePath := range specAnalyser.d.Spec().Paths.Paths {
+		paths = append(paths, resourcePath)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// forEachConcurrently calls fn for each index from 0 to n-1 using as many goroutines as CPUs are available, returning
+// once all the calls are completed
+func forEachConcurrently(n int, fn func(i int)) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU() && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// resolveResourceNameCollisions handles the resources from different paths that map to the same resource name (e,g: /v1/cdns
+// and /api/v1/cdns both result into cdns_v1). If only one of the colliding resources has its name explicitly set with the
+// 'x-terraform-resource-name' extension, that resource keeps the name; otherwise, all the colliding resources are ignored
+// since there is no way to tell which one the user is referring to
+func (specAnalyser *specV2Analyser) resolveResourceNameCollisions(resources []SpecResource) []SpecResource {
+	resourcesByName := map[string][]SpecResource{}
+	for _, r := range resources {
+		if !r.shouldIgnoreResource() {
+			resourcesByName[r.getResourceName()] = append(resourcesByName[r.getResourceName()], r)
+		}
+	}
+	var resolvedResources []SpecResource
+	for _, r := range resources {
+		collidingResources := resourcesByName[r.getResourceName()]
+		if r.shouldIgnoreResource() || len(collidingResources) == 1 {
+			resolvedResources = append(resolvedResources, r)
 			continue
 		}
+		var paths, explicitlyNamedPaths []string
+		for _, collidingResource := range collidingResources {
+			path := getResourceRootPath(collidingResource)
+			paths = append(paths, path)
+			if v2Resource, ok := collidingResource.(*SpecV2Resource); ok && v2Resource.getResourceTerraformName() != "" {
+				explicitlyNamedPaths = append(explicitlyNamedPaths, path)
+			}
+		}
+		sort.Strings(paths)
+		if len(explicitlyNamedPaths) == 1 && explicitlyNamedPaths[0] == getResourceRootPath(r) {
+			specAnalyser.getLogger().Warn(fmt.Sprintf("resource name '%s' is used by multiple paths %s, keeping the resource with rootPath='%s' as its name is set with the '%s' extension", r.getResourceName(), paths, explicitlyNamedPaths[0], extTfResourceName))
+			resolvedResources = append(resolvedResources, r)
+			continue
+		}
+		specAnalyser.getLogger().Warn(fmt.Sprintf("'%s' is a duplicate resource name and is being removed from the provider (rootPath='%s'), the name is used by multiple paths %s, please use the '%s' extension to give them different names", r.getResourceName(), getResourceRootPath(r), paths, extTfResourceName))
+	}
+	return resolvedResources
+}
 
-		log.Printf("[INFO] found terraform compliant resource [name='%s', rootPath='%s', instancePath='%s']", r.getResourceName(), resourceRootPath, resourcePath)
-		resources = append(resources, r)
+func getResourceRootPath(r SpecResource) string {
+	if v2Resource, ok := r.(*SpecV2Resource); ok {
+		return v2Resource.Path
 	}
-	log.Printf("[INFO] found %d terraform compliant resources (time: %s)", len(resources), time.Since(start))
-	return resources, nil
+	return r.getResourceName()
+}
+
+// validateResourceSchema makes sure the resource schema definition can be translated into a terraform schema. The error
+// returned contains the resource name, root path and all the issues found in the schema definition properties.
+func (specAnalyser *specV2Analyser) validateResourceSchema(r SpecResource, resourceRootPath string) error {
+	if _, err := r.getResourceSchema(); err != nil {
+		specAnalyser.getLogger().Warn(fmt.Sprintf("ignoring resource name='%s' with rootPath='%s' due to the schema definition not being supported: %s", r.getResourceName(), resourceRootPath, err))
+		return fmt.Errorf("- resource name='%s' with rootPath='%s': %s", r.getResourceName(), resourceRootPath, err)
+	}
+	return nil
 }
 
 func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r SpecV2Resource) error {
@@ -141,19 +377,47 @@ func (specAnalyser *specV2Analyser) validateSubResourceTerraformCompliance(r Spe
 			if !parentPathExists {
 				return fmt.Errorf("subresource with path '%s' is missing parent root path definition '%s'", resourcePath, parentURI)
 			}
-			parentResource := SpecV2Resource{RootPathItem: parentPathItem}
+			parentResource := SpecV2Resource{RootPathItem: parentPathItem, logger: specAnalyser.logger}
 			if parentResource.shouldIgnoreResource() {
 				return fmt.Errorf("subresource with path '%s' contains a parent %s that is marked as ignored, therefore ignoring the subresource too", resourcePath, parentURI)
 			}
+			if names := specAnalyser.getParentResourceNames(parentURI); len(names) > 1 {
+				return fmt.Errorf("subresource with path '%s' contains a parent %s with ambiguous names %s configured in the '%s' extension, the parent properties are named after the parent resource name so the parent must be configured with one name only", resourcePath, parentURI, names, extTfResourceName)
+			}
 		}
 	}
 	return nil
 }
 
+// getParentResourceNames returns the different names configured in the 'x-terraform-resource-name' extension (at the
+// root path level or in the POST operation) of the given parent root path, including the path with trailing slash
+func (specAnalyser *specV2Analyser) getParentResourceNames(parentURI string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, path := range []string{parentURI, parentURI + "/"} {
+		pathItem, exists := specAnalyser.d.Spec().Paths.Paths[path]
+		if !exists {
+			continue
+		}
+		extensions := []spec.Extensions{pathItem.Extensions}
+		if pathItem.Post != nil {
+			extensions = append(extensions, pathItem.Post.Extensions)
+		}
+		for _, extension := range extensions {
+			if name, _ := extension.GetString(extTfResourceName); name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (specAnalyser *specV2Analyser) pathExists(path string) (bool, spec.PathItem) {
 	p, exists := specAnalyser.d.Spec().Paths.Paths[path]
 	if !exists {
-		log.Printf("[WARN] path %s not found, falling back to checking if the path with trailing slash %s/ exists", path, path)
+		specAnalyser.getLogger().Warn(fmt.Sprintf("path %s not found, falling back to checking if the path with trailing slash %s/ exists", path, path))
 		p, exists = specAnalyser.d.Spec().Paths.Paths[path+"/"]
 		if !exists {
 			return false, spec.PathItem{}
@@ -220,7 +484,12 @@ func (specAnalyser *specV2Analyser) GetAllHeaderParameters() (SpecHeaderParamete
 }
 
 func (specAnalyser *specV2Analyser) GetAPIBackendConfiguration() (SpecBackendConfiguration, error) {
-	return newOpenAPIBackendConfigurationV2(specAnalyser.d.Spec(), specAnalyser.openAPIDocumentURL)
+	backendConfiguration, err := newOpenAPIBackendConfigurationV2(specAnalyser.d.Spec(), specAnalyser.openAPIDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	backendConfiguration.logger = specAnalyser.logger
+	return backendConfiguration, nil
 }
 
 // isEndPointFullyTerraformResourceCompliant returns true only if:
@@ -274,22 +543,56 @@ func (specAnalyser *specV2Analyser) GetAPIBackendConfiguration() (SpecBackendCon
 // then the expected returned value is true. Otherwise if the above criteria is not met, it is considered that
 // the resourcePath provided is not terraform resource compliant.
 func (specAnalyser *specV2Analyser) isEndPointFullyTerraformResourceCompliant(resourcePath string) (string, *spec.PathItem, *spec.Schema, error) {
-	log.Printf("[DEBUG] validating end point terraform compatibility %s", resourcePath)
+	specAnalyser.getLogger().Debug(fmt.Sprintf("validating end point terraform compatibility %s", resourcePath))
 	err := specAnalyser.validateInstancePath(resourcePath)
 	if err != nil {
 		return "", nil, nil, err
 	}
+	if isReadOnlyResourceInstancePath(specAnalyser.d.Spec().Paths.Paths[resourcePath]) {
+		return specAnalyser.validateReadOnlyResourcePath(resourcePath)
+	}
 	resourceRootPath, resourceRootPathItem, resourceRootPostSchemaDef, err := specAnalyser.validateRootPath(resourcePath)
 	if err != nil {
 		return "", nil, nil, err
 	}
-	err = specAnalyser.validateResourceSchemaDefinition(resourceRootPostSchemaDef)
+	err = specAnalyser.validateResourceSchemaDefinition(resourceRootPostSchemaDef, specAnalyser.getIdentifierResourceNames(resourceRootPath)...)
 	if err != nil {
 		return "", nil, nil, err
 	}
 	return resourceRootPath, resourceRootPathItem, resourceRootPostSchemaDef, nil
 }
 
+// isEndPointGetOnlyTerraformDataSourceInstanceCompliant checks whether the given resource instance path is a GET-only
+// endpoint: the instance path exposes a GET operation but its root path does not expose a POST operation (and the
+// instance path is not marked as a read only resource, since those are already exposed as resources). The schema
+// returned is the one of the instance GET operation successful response
+func (specAnalyser *specV2Analyser) isEndPointGetOnlyTerraformDataSourceInstanceCompliant(resourcePath string) (string, *spec.PathItem, *spec.Schema, error) {
+	err := specAnalyser.validateInstancePath(resourcePath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if isReadOnlyResourceInstancePath(specAnalyser.d.Spec().Paths.Paths[resourcePath]) {
+		return "", nil, nil, fmt.Errorf("resource instance path '%s' is a read only resource", resourcePath)
+	}
+	resourceRootPath, err := specAnalyser.findMatchingResourceRootPath(resourcePath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if specAnalyser.postDefined(resourceRootPath) {
+		return "", nil, nil, fmt.Errorf("resource root path '%s' exposes a POST operation", resourceRootPath)
+	}
+	resourceRootPathItem := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]
+	resourceSchema, err := specAnalyser.getSuccessfulResponseDefinition(specAnalyser.d.Spec().Paths.Paths[resourcePath].Get)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("resource instance path '%s' GET operation error: %s", resourcePath, err)
+	}
+	err = specAnalyser.validateResourceSchemaDefinition(resourceSchema, specAnalyser.getIdentifierResourceNames(resourceRootPath)...)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("resource instance path '%s' GET operation validation error: %s", resourcePath, err)
+	}
+	return resourceRootPath, &resourceRootPathItem, resourceSchema, nil
+}
+
 func (specAnalyser *specV2Analyser) isEndPointTerraformDataSourceCompliant(path spec.PathItem) (*spec.Schema, error) {
 	if path.Get == nil {
 		return nil, errors.New("missing get operation")
@@ -363,6 +666,27 @@ func (specAnalyser *specV2Analyser) validateRootPath(resourcePath string) (strin
 	return resourceRootPath, &resourceRootPathItem, resourceRootPostSchemaDef, nil
 }
 
+// validateReadOnlyResourcePath validates the resources which instance path is marked as read only with the
+// 'x-terraform-read-only-resource' extension. These resources can only be read so the root path is not required to expose
+// a POST operation and the resource schema is the one returned by the resource instance GET operation
+func (specAnalyser *specV2Analyser) validateReadOnlyResourcePath(resourcePath string) (string, *spec.PathItem, *spec.Schema, error) {
+	resourceRootPath, err := specAnalyser.findMatchingResourceRootPath(resourcePath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	resourceRootPathItem, _ := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]
+	resourceInstanceGetOperation := specAnalyser.d.Spec().Paths.Paths[resourcePath].Get
+	resourceSchema, err := specAnalyser.getSuccessfulResponseDefinition(resourceInstanceGetOperation)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("read only resource instance path '%s' GET operation error: %s", resourcePath, err)
+	}
+	err = specAnalyser.validateResourceSchemaDefinition(resourceSchema, specAnalyser.getIdentifierResourceNames(resourceRootPath)...)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("read only resource instance path '%s' GET operation validation error: %s", resourcePath, err)
+	}
+	return resourceRootPath, &resourceRootPathItem, resourceSchema, nil
+}
+
 // getSuccessfulResponseDefinition is responsible for getting the model definition from the response that matches a successful
 // response (either 200, 201 or 202 whichever is found first). It is assumed that the the responses will only include one of the
 // aforementioned successful responses, if multiple are present the first one found will be selected and its corresponding schema
@@ -376,13 +700,16 @@ func (specAnalyser *specV2Analyser) getSuccessfulResponseDefinition(operation *s
 			if response.Schema == nil {
 				return nil, fmt.Errorf("operation response '%d' is missing the schema definition", responseStatusCode)
 			}
-			return response.Schema, nil
+			return specAnalyser.resolveSchemaComposition(response.Schema)
 		}
 	}
 	return nil, fmt.Errorf("operation is missing successful response")
 }
 
-func (specAnalyser *specV2Analyser) validateResourceSchemaDefWithOptions(schema *spec.Schema, shouldPropBeReadOnly bool) error {
+// validateResourceSchemaDefWithOptions validates the resource schema. If the schema does not contain a property named 'id'
+// nor a property with the 'x-terraform-id' extension, the resource names provided are used to look up the identifier by
+// convention (refer to getResourceIdentifierByConvention for more info)
+func (specAnalyser *specV2Analyser) validateResourceSchemaDefWithOptions(schema *spec.Schema, shouldPropBeReadOnly bool, resourceNames ...string) error {
 	containsIdentifier := false
 	for propertyName, property := range schema.Properties {
 		if propertyName == "id" {
@@ -396,14 +723,47 @@ func (specAnalyser *specV2Analyser) validateResourceSchemaDefWithOptions(schema
 			}
 		}
 	}
+	if containsIdentifier == false && containsNestedIdentifier(schema) {
+		containsIdentifier = true
+	}
 	if containsIdentifier == false {
+		if identifier, convention := getResourceIdentifierByConvention(resourceNames, schema, specAnalyser.getLogger()); identifier != "" {
+			specAnalyser.getLogger().Info(fmt.Sprintf("resource schema does not contain a property named 'id' nor a property with the extension '%s', property '%s' will be used as the resource identifier (%s)", extTfID, identifier, convention))
+			return nil
+		}
 		return fmt.Errorf("resource schema is missing a property that uniquely identifies the resource, either a property named 'id' or a property with the extension '%s' set to true", extTfID)
 	}
 	return nil
 }
 
-func (specAnalyser *specV2Analyser) validateResourceSchemaDefinition(schema *spec.Schema) error {
-	return specAnalyser.validateResourceSchemaDefWithOptions(schema, false)
+// containsNestedIdentifier returns true if any of the properties nested in the object properties of the given schema has
+// the 'x-terraform-id' extension set to true (e,g: metadata.uid)
+func containsNestedIdentifier(schema *spec.Schema) bool {
+	for _, property := range schema.Properties {
+		if len(property.Properties) == 0 {
+			continue
+		}
+		for _, nestedProperty := range property.Properties {
+			if exists, useAsIdentifier := nestedProperty.Extensions.GetBool(extTfID); exists && useAsIdentifier {
+				return true
+			}
+		}
+		if containsNestedIdentifier(&property) {
+			return true
+		}
+	}
+	return false
+}
+
+func (specAnalyser *specV2Analyser) validateResourceSchemaDefinition(schema *spec.Schema, resourceNames ...string) error {
+	return specAnalyser.validateResourceSchemaDefWithOptions(schema, false, resourceNames...)
+}
+
+// getIdentifierResourceNames returns the names of the resource with the given root path that are used to look up the
+// resource identifier by convention
+func (specAnalyser *specV2Analyser) getIdentifierResourceNames(resourceRootPath string) []string {
+	r := SpecV2Resource{Path: resourceRootPath, RootPathItem: specAnalyser.d.Spec().Paths.Paths[resourceRootPath], logger: specAnalyser.logger}
+	return r.getIdentifierResourceNames()
 }
 
 // postIsPresent checks if the given resource has a POST implementation returning true if the path is found
@@ -442,12 +802,30 @@ func (specAnalyser *specV2Analyser) getBodyParameterBodySchema(resourceRootPostO
 		return nil, fmt.Errorf("the operation ref was not expanded properly, check that the ref is valid (no cycles, bogus, etc)")
 	}
 
-	if len(bodyParameter.Schema.Properties) > 0 {
-		return bodyParameter.Schema, nil
+	bodySchema, err := specAnalyser.resolveSchemaComposition(bodyParameter.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if len(bodySchema.Properties) > 0 {
+		return bodySchema, nil
 	}
 	return nil, fmt.Errorf("POST operation contains an schema with no properties")
 }
 
+// resolveSchemaComposition returns the given schema with the schemas listed in the allOf construct (if any) merged into
+// a single object schema
+func (specAnalyser *specV2Analyser) resolveSchemaComposition(schema *spec.Schema) (*spec.Schema, error) {
+	if len(schema.AllOf) == 0 {
+		return schema, nil
+	}
+	r := SpecV2Resource{SchemaDefinitions: specAnalyser.d.Spec().Definitions, logger: specAnalyser.logger}
+	resolvedSchema, err := r.resolveAllOf(*schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the allOf schema composition: %s", err)
+	}
+	return &resolvedSchema, nil
+}
+
 // isResourceInstanceEndPoint checks if the given path is of form /resource/{id}
 func (specAnalyser *specV2Analyser) isResourceInstanceEndPoint(p string) (bool, error) {
 	r, _ := regexp.Compile("^.*{.+}[\\/]?$")
@@ -461,7 +839,7 @@ func (specAnalyser *specV2Analyser) isResourceInstanceEndPoint(p string) (bool,
 func (specAnalyser *specV2Analyser) findMatchingResourceRootPath(resourceInstancePath string) (string, error) {
 	r, _ := regexp.Compile(resourceInstanceRegex)
 	result := r.FindStringSubmatch(resourceInstancePath)
-	log.Printf("[DEBUG] resource '%s' root path match: %s", resourceInstancePath, result)
+	specAnalyser.getLogger().Debug(fmt.Sprintf("resource '%s' root path match: %s", resourceInstancePath, result))
 	if len(result) != 2 {
 		return "", fmt.Errorf("resource instance path '%s' missing valid resource root path, more than two results returned from match '%s'", resourceInstancePath, result)
 	}
@@ -469,14 +847,14 @@ func (specAnalyser *specV2Analyser) findMatchingResourceRootPath(resourceInstanc
 	resourceRootPath := result[1] // e,g: /v1/cdns/{id} /v1/cdns/
 
 	if _, exists := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]; exists {
-		log.Printf("[DEBUG] found resource root path with trailing '/' - %+s", resourceRootPath)
+		specAnalyser.getLogger().Debug(fmt.Sprintf("found resource root path with trailing '/' - %+s", resourceRootPath))
 		return resourceRootPath, nil
 	}
 
 	// Handles the case where the swagger file root path does not have a trailing slash in the path
 	resourceRootPath = strings.TrimRight(resourceRootPath, "/")
 	if _, exists := specAnalyser.d.Spec().Paths.Paths[resourceRootPath]; exists {
-		log.Printf("[DEBUG] found resource root path without trailing '/' - %+s", resourceRootPath)
+		specAnalyser.getLogger().Debug(fmt.Sprintf("found resource root path without trailing '/' - %+s", resourceRootPath))
 		return resourceRootPath, nil
 	}
 
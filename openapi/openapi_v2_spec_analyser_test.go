@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"log"
@@ -1644,6 +1645,32 @@ func TestValidateResourceSchemaDefWithOptions(t *testing.T) {
 				So(err, ShouldBeNil)
 			})
 		})
+		Convey("When validateResourceSchemaDefWithOptions method is called with a valid schema definition where the unique identifier is nested in an object property", func() {
+			schema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Type: spec.StringOrArray{"object"},
+								Properties: map[string]spec.Schema{
+									"uid": {
+										VendorExtensible: spec.VendorExtensible{
+											Extensions: spec.Extensions{
+												extTfID: true,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := a.validateResourceSchemaDefWithOptions(schema, false)
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
 		Convey("When validateResourceSchemaDefWithOptions method is called with a NON valid schema definition due to missing unique identifier'", func() {
 			schema := &spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1685,6 +1712,48 @@ func TestValidateResourceSchemaDefWithOptions(t *testing.T) {
 				So(err.Error(), ShouldEqual, "resource schema contains properties that are not just read only")
 			})
 		})
+		Convey("When validateResourceSchemaDefinition method is called with resource names and a schema definition containing a property named after the resource", func() {
+			schema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"cdnId": {SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+						"name":  {},
+					},
+				},
+			}
+			err := a.validateResourceSchemaDefinition(schema, "cdns", "cdn")
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When validateResourceSchemaDefinition method is called with a schema definition containing only one read only property with uuid format", func() {
+			schema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"uuid": {SchemaProps: spec.SchemaProps{Format: "uuid"}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+						"name": {},
+					},
+				},
+			}
+			err := a.validateResourceSchemaDefinition(schema, "cdns", "cdn")
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When validateResourceSchemaDefinition method is called with a schema definition containing multiple read only properties with uuid format", func() {
+			schema := &spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Properties: map[string]spec.Schema{
+						"uuid":      {SchemaProps: spec.SchemaProps{Format: "uuid"}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+						"otherUUID": {SchemaProps: spec.SchemaProps{Format: "uuid"}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+					},
+				},
+			}
+			err := a.validateResourceSchemaDefinition(schema, "cdns", "cdn")
+			Convey("Then the error returned should be the expected since the identifier is ambiguous", func() {
+				So(err.Error(), ShouldEqual, "resource schema is missing a property that uniquely identifies the resource, either a property named 'id' or a property with the extension 'x-terraform-id' set to true")
+			})
+		})
 	})
 }
 
@@ -2455,6 +2524,88 @@ definitions:
 			})
 		})
 	})
+
+	Convey("Given an specV2Analyser with a read only resource instance path '/regions/{id}' that only exposes a GET operation and the corresponding resource root path '/regions' does not expose a POST operation", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /regions:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/Region"
+  /regions/{id}:
+    x-terraform-read-only-resource: true
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Region"
+definitions:
+  Region:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+      name:
+        type: "string"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called ", func() {
+			resourceRootPath, _, resourceSchema, err := a.isEndPointFullyTerraformResourceCompliant("/regions/{id}")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource root path should be '/regions'", func() {
+				So(resourceRootPath, ShouldEqual, "/regions")
+			})
+			Convey("And the resource schema should be the one returned by the instance GET operation", func() {
+				So(resourceSchema.Properties, ShouldContainKey, "id")
+				So(resourceSchema.Properties, ShouldContainKey, "name")
+			})
+		})
+	})
+
+	Convey("Given an specV2Analyser with a read only resource instance path '/regions/{id}' which GET operation returns a schema missing the id property", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /regions:
+    get:
+      responses:
+        200:
+          description: "successful operation"
+  /regions/{id}:
+    get:
+      x-terraform-read-only-resource: true
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Region"
+definitions:
+  Region:
+    type: "object"
+    properties:
+      name:
+        type: "string"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When isEndPointFullyTerraformResourceCompliant method is called ", func() {
+			_, _, _, err := a.isEndPointFullyTerraformResourceCompliant("/regions/{id}")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "read only resource instance path '/regions/{id}' GET operation validation error: resource schema is missing a property that uniquely identifies the resource, either a property named 'id' or a property with the extension 'x-terraform-id' set to true")
+			})
+		})
+	})
 }
 
 func getExpectedResource(terraformCompliantResources []SpecResource, expectedResourceName string) SpecResource {
@@ -2523,6 +2674,72 @@ paths:
 		}
 	})
 
+	Convey("Given an specV2Analyser with parent paths configured with the x-terraform-resource-name extension", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /cdns:
+    x-terraform-resource-name: cdn
+    post:
+      x-terraform-resource-name: content_delivery_network
+  /cdns/{id}:
+  /zones:
+    x-terraform-resource-name: zone
+    post:
+      x-terraform-resource-name: zone
+  /zones/{id}:
+  /zones/{id}/records:
+    x-terraform-resource-name: record
+  /zones/{id}/records/:
+    x-terraform-resource-name: dns_record
+  /zones/{id}/records/{record_id}:`
+		a := initAPISpecAnalyser(swaggerContent)
+		testCases := testCasesDef{
+			{name: "subresource path where the parent is configured with different names at the root path level and in the POST operation", inputResource: SpecV2Resource{Path: "/cdns/{id}/firewalls"}, expectedError: "subresource with path '/cdns/{id}/firewalls' contains a parent /cdns with ambiguous names [cdn content_delivery_network] configured in the 'x-terraform-resource-name' extension, the parent properties are named after the parent resource name so the parent must be configured with one name only"},
+			{name: "subresource path where the parent is configured with the same name at the root path level and in the POST operation", inputResource: SpecV2Resource{Path: "/zones/{id}/records"}, expectedError: ""},
+			{name: "subresource path where the parent is configured with different names in the paths with and without trailing slash", inputResource: SpecV2Resource{Path: "/zones/{id}/records/{record_id}/tags"}, expectedError: "subresource with path '/zones/{id}/records/{record_id}/tags' contains a parent /zones/{id}/records with ambiguous names [dns_record record] configured in the 'x-terraform-resource-name' extension, the parent properties are named after the parent resource name so the parent must be configured with one name only"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When validateSubResourceTerraformCompliance method is called with a %s", tc.name), func() {
+				err := a.validateSubResourceTerraformCompliance(tc.inputResource)
+				Convey("Then the error returned should be the expected one (if any)", func() {
+					if tc.expectedError == "" {
+						So(err, ShouldBeNil)
+					} else {
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					}
+				})
+			})
+		}
+	})
+
+	Convey("Given an specV2Analyser with parent paths of three levels", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /vms:
+  /vms/{vm_id}:
+  /vms/{vm_id}/disks:
+  /vms/{vm_id}/disks/{disk_id}:
+  /vms/{vm_id}/disks/{disk_id}/snapshots:
+  /vms/{vm_id}/disks/{disk_id}/snapshots/{snapshot_id}:`
+		a := initAPISpecAnalyser(swaggerContent)
+		testCases := testCasesDef{
+			{name: "subresource path (containing three parents) where the parent paths exist in the swagger file", inputResource: SpecV2Resource{Path: "/vms/{vm_id}/disks/{disk_id}/snapshots/{snapshot_id}/backups"}, expectedError: ""},
+			{name: "subresource path (containing three parents) where the last parent instance path DOES NOT exist in the swagger file", inputResource: SpecV2Resource{Path: "/vms/{vm_id}/disks/{disk_id}/snapshots/{id}/backups"}, expectedError: "subresource with path '/vms/{vm_id}/disks/{disk_id}/snapshots/{id}/backups' is missing parent path instance definition '/vms/{vm_id}/disks/{disk_id}/snapshots/{id}'"},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When validateSubResourceTerraformCompliance method is called with a %s", tc.name), func() {
+				err := a.validateSubResourceTerraformCompliance(tc.inputResource)
+				Convey("Then the error returned should be the expected one (if any)", func() {
+					if tc.expectedError == "" {
+						So(err, ShouldBeNil)
+					} else {
+						So(err.Error(), ShouldEqual, tc.expectedError)
+					}
+				})
+			})
+		}
+	})
+
 	Convey("Given an specV2Analyser with a parent path (both the root and the instance paths with trailing paths)", t, func() {
 		swaggerContent := `swagger: "2.0"
 paths:
@@ -2771,6 +2988,161 @@ definitions:
 	}
 }
 
+func TestGetTerraformCompliantDataSourceInstances(t *testing.T) {
+	testCases := []struct {
+		name                        string
+		inputSwagger                string
+		expectedDataSourceInstances []string
+	}{
+		{
+			name: "happy path: GET-only endpoint is data source instance compliant",
+			inputSwagger: `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/regions:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/RegionV1"
+  /v1/regions/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/RegionV1"
+definitions:
+  RegionV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`,
+			expectedDataSourceInstances: []string{"regions_v1"},
+		},
+		{
+			name: "endpoints which root path exposes a POST operation are not GET-only",
+			inputSwagger: `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`,
+			expectedDataSourceInstances: []string{},
+		},
+		{
+			name: "endpoints marked as read only resources are not GET-only",
+			inputSwagger: `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/regions:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/RegionV1"
+  /v1/regions/{id}:
+    x-terraform-read-only-resource: true
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/RegionV1"
+definitions:
+  RegionV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`,
+			expectedDataSourceInstances: []string{},
+		},
+		{
+			name: "GET-only endpoints with a schema missing the identifier are not compliant",
+			inputSwagger: `swagger: "2.0"
+host: 127.0.0.1
+paths:
+  /v1/regions:
+    get:
+      responses:
+        200:
+          description: "successful operation"
+  /v1/regions/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/RegionV1"
+definitions:
+  RegionV1:
+    type: "object"
+    properties:
+      label:
+        type: "string"`,
+			expectedDataSourceInstances: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		a := initAPISpecAnalyser(tc.inputSwagger)
+		dataSourceInstances := a.GetTerraformCompliantDataSourceInstances()
+		var dataSourceInstanceNames []string
+		for _, dataSourceInstance := range dataSourceInstances {
+			dataSourceInstanceNames = append(dataSourceInstanceNames, dataSourceInstance.getResourceName())
+		}
+		assert.ElementsMatch(t, tc.expectedDataSourceInstances, dataSourceInstanceNames, tc.name)
+	}
+}
+
 func TestGetTerraformCompliantResources(t *testing.T) {
 
 	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant terraform subresource /v1/cdns/{id}/v1/firewalls but missing the parent resource resource description", t, func() {
@@ -3582,6 +3954,254 @@ definitions:
 			})
 		})
 	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing a resource composed with allOf and a property with a discriminated oneOf", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /v1/pets:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/PetV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/PetV1"
+  /v1/pets/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/PetV1"
+definitions:
+  Resource:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+  PetV1:
+    allOf:
+    - $ref: "#/definitions/Resource"
+    - type: "object"
+      required:
+      - name
+      properties:
+        name:
+          type: "string"
+        kind:
+          discriminator: "type"
+          oneOf:
+          - type: "object"
+            properties:
+              type:
+                type: "string"
+                enum:
+                - "cat"
+              lives:
+                type: "integer"
+          - type: "object"
+            properties:
+              type:
+                type: "string"
+                enum:
+                - "dog"
+              bark:
+                type: "boolean"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the error returned should be nil and the resource should be compliant", func() {
+				So(err, ShouldBeNil)
+				So(len(terraformCompliantResources), ShouldEqual, 1)
+			})
+			Convey("And the resource schema should contain the properties of all the allOf schemas", func() {
+				resourceSchema, err := terraformCompliantResources[0].getResourceSchema()
+				So(err, ShouldBeNil)
+				id, err := resourceSchema.getProperty("id")
+				So(err, ShouldBeNil)
+				So(id.ReadOnly, ShouldBeTrue)
+				name, err := resourceSchema.getProperty("name")
+				So(err, ShouldBeNil)
+				So(name.Required, ShouldBeTrue)
+			})
+			Convey("And the discriminated union property should contain a nested object per variant", func() {
+				resourceSchema, _ := terraformCompliantResources[0].getResourceSchema()
+				kind, err := resourceSchema.getProperty("kind")
+				So(err, ShouldBeNil)
+				So(kind.SpecSchemaDefinition.Discriminator, ShouldEqual, "type")
+				So(kind.SpecSchemaDefinition.getVariantTerraformNames(), ShouldResemble, []string{"cat", "dog"})
+			})
+		})
+	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing a resource with a discriminated oneOf property which variants are refs to definitions without a single enum discriminator value", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /v1/pets:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/PetV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/PetV1"
+  /v1/pets/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/PetV1"
+definitions:
+  PetV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      kind:
+        discriminator: "type"
+        oneOf:
+        - $ref: "#/definitions/Cat"
+        - $ref: "#/definitions/Dog"
+  Cat:
+    type: "object"
+    properties:
+      type:
+        type: "string"
+      lives:
+        type: "integer"
+  Dog:
+    type: "object"
+    properties:
+      type:
+        type: "string"
+      bark:
+        type: "boolean"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the error returned should be nil and the resource should be compliant", func() {
+				So(err, ShouldBeNil)
+				So(len(terraformCompliantResources), ShouldEqual, 1)
+			})
+			Convey("And the discriminated union property should contain a nested object per variant named after the definition the variant refers to", func() {
+				resourceSchema, err := terraformCompliantResources[0].getResourceSchema()
+				So(err, ShouldBeNil)
+				kind, err := resourceSchema.getProperty("kind")
+				So(err, ShouldBeNil)
+				So(kind.SpecSchemaDefinition.Discriminator, ShouldEqual, "type")
+				So(kind.SpecSchemaDefinition.getVariantTerraformNames(), ShouldResemble, []string{"cat", "dog"})
+			})
+		})
+	})
+	Convey("Given an specV2Analyser loaded with a swagger file containing a compliant resource and another resource with properties using unsupported schema constructs", t, func() {
+		swaggerContent := `swagger: "2.0"
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetworkV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetworkV1"
+  /v1/lbs:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/LBV1"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/LBV1"
+  /v1/lbs/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/LBV1"
+definitions:
+  ContentDeliveryNetworkV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+  LBV1:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      backend:
+        oneOf:
+        - type: "string"
+        - type: "integer"
+      targets:
+        type: "array"
+        items:
+          anyOf:
+          - type: "string"
+          - type: "integer"`
+		a := initAPISpecAnalyser(swaggerContent)
+		Convey("When GetTerraformCompliantResources method is called ", func() {
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			defer log.SetOutput(os.Stderr)
+			terraformCompliantResources, err := a.GetTerraformCompliantResources()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resources returned should only contain the compliant resource", func() {
+				So(len(terraformCompliantResources), ShouldEqual, 1)
+				So(terraformCompliantResources[0].getResourceName(), ShouldEqual, "cdns_v1")
+			})
+			Convey("And the logs should contain all the issues found in the ignored resource", func() {
+				So(buf.String(), ShouldContainSubstring, "[WARN] ignoring resource name='lbs_v1' with rootPath='/v1/lbs' due to the schema definition not being supported: found 2 issues: 1) failed to process property 'backend': the 'oneOf' construct is not supported")
+				So(buf.String(), ShouldContainSubstring, "2) failed to process array type property 'targets': array items schema not supported: the 'anyOf' construct is not supported")
+				So(buf.String(), ShouldContainSubstring, "[WARN] 1 resources have been ignored due to their schema definitions not being supported:\n- resource name='lbs_v1' with rootPath='/v1/lbs'")
+			})
+		})
+	})
 	Convey("Given an specV2Analyser loaded with a swagger file containing a non compliant terraform resource /v1/cdns because its missing the post operation", t, func() {
 		var swaggerJSON = `
 {
@@ -3882,14 +4502,14 @@ func assertPropertyExists(properties specSchemaDefinitionProperties, name string
 	return false, -1
 }
 
-func initAPISpecAnalyser(swaggerContent string) specV2Analyser {
+func initAPISpecAnalyser(swaggerContent string) *specV2Analyser {
 	file := initAPISpecFile(swaggerContent)
 	defer os.Remove(file.Name())
 	specV2Analyser, err := newSpecAnalyserV2(file.Name())
 	if err != nil {
 		log.Panic("newSpecAnalyserV2 failed: ", err)
 	}
-	return *specV2Analyser
+	return specV2Analyser
 }
 
 func createSwaggerWithExternalRef(filename string) string {
@@ -3952,3 +4572,146 @@ func createExternalSwaggerContent() string {
   }
 }`
 }
+
+func TestResolveResourceNameCollisions(t *testing.T) {
+	Convey("Given a specV2Analyser", t, func() {
+		logger := &loggerStub{}
+		specAnalyser := &specV2Analyser{logger: logger}
+		explicitlyNamed := spec.PathItem{VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfResourceName: "cdn"}}}
+		Convey("When resolveResourceNameCollisions is called with resources with different names", func() {
+			resources := specAnalyser.resolveResourceNameCollisions([]SpecResource{
+				&SpecV2Resource{Name: "cdn_v1", Path: "/v1/cdns"},
+				&SpecV2Resource{Name: "lb_v1", Path: "/v1/lbs"},
+			})
+			Convey("Then all the resources should be kept", func() {
+				So(resources, ShouldHaveLength, 2)
+			})
+		})
+		Convey("When resolveResourceNameCollisions is called with resources with the same name where only one of them is explicitly named", func() {
+			resources := specAnalyser.resolveResourceNameCollisions([]SpecResource{
+				&SpecV2Resource{Name: "cdn_v1", Path: "/v1/cdn"},
+				&SpecV2Resource{Name: "cdn_v1", Path: "/v1/cdns", RootPathItem: explicitlyNamed},
+				&SpecV2Resource{Name: "lb_v1", Path: "/v1/lbs"},
+			})
+			Convey("Then the explicitly named resource should be kept along with the resources that do not collide", func() {
+				So(resources, ShouldHaveLength, 2)
+				So(resources[0].(*SpecV2Resource).Path, ShouldEqual, "/v1/cdns")
+				So(resources[1].(*SpecV2Resource).Path, ShouldEqual, "/v1/lbs")
+			})
+		})
+		Convey("When resolveResourceNameCollisions is called with resources with the same name where none of them is explicitly named", func() {
+			resources := specAnalyser.resolveResourceNameCollisions([]SpecResource{
+				&SpecV2Resource{Name: "cdns_v1", Path: "/v1/cdns"},
+				&SpecV2Resource{Name: "cdns_v1", Path: "/api/v1/cdns"},
+			})
+			Convey("Then the colliding resources should be ignored", func() {
+				So(resources, ShouldBeEmpty)
+			})
+			Convey("And the removal of the colliding resources should be logged with the analyser logger", func() {
+				So(logger.containsMessage("WARN", "'cdns_v1' is a duplicate resource name and is being removed from the provider (rootPath='/v1/cdns'), the name is used by multiple paths [/api/v1/cdns /v1/cdns], please use the 'x-terraform-resource-name' extension to give them different names"), ShouldBeTrue)
+			})
+		})
+		Convey("When resolveResourceNameCollisions is called with resources with the same name where all of them are explicitly named", func() {
+			resources := specAnalyser.resolveResourceNameCollisions([]SpecResource{
+				&SpecV2Resource{Name: "cdn_v1", Path: "/v1/cdn", RootPathItem: explicitlyNamed},
+				&SpecV2Resource{Name: "cdn_v1", Path: "/v1/cdns", RootPathItem: explicitlyNamed},
+			})
+			Convey("Then the colliding resources should be ignored", func() {
+				So(resources, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestNewSpecAnalyserV2FromDocumentWithLogger(t *testing.T) {
+	Convey("Given a swagger document with a terraform compliant resource and a logger", t, func() {
+		document := `{"swagger": "2.0", "paths": {
+  "/v1/cdns": {"post": {"parameters": [{"in": "body", "name": "body", "schema": {"$ref": "#/definitions/ContentDeliveryNetwork"}}], "responses": {"201": {"schema": {"$ref": "#/definitions/ContentDeliveryNetwork"}}}}},
+  "/v1/cdns/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}], "responses": {"200": {"schema": {"$ref": "#/definitions/ContentDeliveryNetwork"}}}}}},
+  "definitions": {"ContentDeliveryNetwork": {"type": "object", "properties": {"id": {"type": "string", "readOnly": true}}}}}`
+		logger := &loggerStub{}
+		Convey("When newSpecAnalyserV2FromDocument is called and the terraform compliant resources are retrieved", func() {
+			specAnalyser, err := newSpecAnalyserV2FromDocument("https://api.example.com/swagger.json", []byte(document), logger)
+			So(err, ShouldBeNil)
+			resources, err := specAnalyser.GetTerraformCompliantResources()
+			Convey("Then the resources found should be logged with the logger provided", func() {
+				So(err, ShouldBeNil)
+				So(resources, ShouldHaveLength, 1)
+				So(logger.containsMessage("INFO", "found terraform compliant resource [name='cdns_v1', rootPath='/v1/cdns', instancePath='/v1/cdns/{id}']"), ShouldBeTrue)
+			})
+			Convey("And the resources should be configured with the logger provided", func() {
+				So(resources[0].(*SpecV2Resource).logger, ShouldEqual, logger)
+			})
+		})
+	})
+}
+
+func TestGetTerraformCompliantResourcesConcurrently(t *testing.T) {
+	Convey("Given a specV2Analyser initialized from a swagger doc with several resources", t, func() {
+		swaggerDoc := `swagger: "2.0"
+paths:`
+		for _, name := range []string{"cdns", "lbs", "monitors", "alerts", "users"} {
+			swaggerDoc += fmt.Sprintf(`
+  /v1/%[1]s:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/Resource"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Resource"
+  /v1/%[1]s/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Resource"`, name)
+		}
+		swaggerDoc += `
+definitions:
+  Resource:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`
+		a := initAPISpecAnalyser(swaggerDoc)
+		Convey("When GetTerraformCompliantResources is called", func() {
+			resources, err := a.GetTerraformCompliantResources()
+			Convey("Then all the resources should be returned sorted by path", func() {
+				So(err, ShouldBeNil)
+				var names []string
+				for _, r := range resources {
+					names = append(names, r.getResourceName())
+				}
+				So(names, ShouldResemble, []string{"alerts_v1", "cdns_v1", "lbs_v1", "monitors_v1", "users_v1"})
+			})
+			Convey("And when GetTerraformCompliantResources is called again the same resources should be returned without analysing the document again", func() {
+				a.d = nil
+				cachedResources, err := a.GetTerraformCompliantResources()
+				So(err, ShouldBeNil)
+				So(cachedResources, ShouldResemble, resources)
+			})
+		})
+	})
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	results := make([]int, 100)
+	forEachConcurrently(len(results), func(i int) {
+		results[i] = i * 2
+	})
+	for i, result := range results {
+		assert.Equal(t, i*2, result)
+	}
+	forEachConcurrently(0, func(i int) {
+		assert.Fail(t, "fn should not be called")
+	})
+}
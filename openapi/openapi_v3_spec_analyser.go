@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// specV3Analyser defines an SpecAnalyser implementation for OpenAPI v3 specification. The OpenAPI v3 document is
+// converted into the equivalent OpenAPI v2 document which is then analysed the same way as any other OpenAPI v2 document,
+// so both versions support the same features and extensions
+type specV3Analyser struct {
+	*specV2Analyser
+}
+
+// newSpecAnalyserV3 creates an instance of specV3Analyser which implements the SpecAnalyser interface
+// This implementation provides an analyser that understands an OpenAPI v3 document
+func newSpecAnalyserV3(openAPIDocumentFilename string) (*specV3Analyser, error) {
+	if openAPIDocumentFilename == "" {
+		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
+	}
+	document, err := loadOpenAPIDocument(openAPIDocumentFilename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	document, err = openAPIDocumentToJSON(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	return newSpecAnalyserV3FromDocument(openAPIDocumentFilename, document, nil)
+}
+
+// newSpecAnalyserV3FromDocument creates an instance of specV3Analyser from the OpenAPI v3 JSON document already
+// retrieved from the openAPIDocumentFilename. The messages are logged with the logger provided (the standard logger is
+// used if the logger is nil)
+func newSpecAnalyserV3FromDocument(openAPIDocumentFilename string, document []byte, logger Logger) (*specV3Analyser, error) {
+	converter, err := newOpenAPIV3Converter(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	converter.logger = logger
+	swagger, err := converter.convert()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the OpenAPI document from '%s' - error = %s", openAPIDocumentFilename, err)
+	}
+	specV2Analyser, err := newSpecAnalyserV2FromDocument(openAPIDocumentFilename, swagger, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &specV3Analyser{specV2Analyser: specV2Analyser}, nil
+}
@@ -0,0 +1,197 @@
+package openapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const openAPIV3CDNDocument = `openapi: "3.0.1"
+info:
+  title: "CDN API"
+  version: "1.0.0"
+servers:
+- url: "https://{environment}.api.server.com/api"
+  variables:
+    environment:
+      default: "production"
+security:
+- bearer_auth: []
+paths:
+  /v1/cdns:
+    post:
+      requestBody:
+        $ref: "#/components/requestBodies/ContentDeliveryNetwork"
+      responses:
+        201:
+          description: "created"
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ContentDeliveryNetworkV1"
+  /v1/cdns/{id}:
+    parameters:
+    - $ref: "#/components/parameters/ContentDeliveryNetworkId"
+    get:
+      responses:
+        200:
+          description: "found"
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ContentDeliveryNetworkV1"
+    put:
+      requestBody:
+        $ref: "#/components/requestBodies/ContentDeliveryNetwork"
+      responses:
+        200:
+          description: "updated"
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ContentDeliveryNetworkV1"
+    delete:
+      responses:
+        204:
+          description: "deleted"
+components:
+  parameters:
+    ContentDeliveryNetworkId:
+      name: "id"
+      in: "path"
+      required: true
+      schema:
+        type: "string"
+  requestBodies:
+    ContentDeliveryNetwork:
+      required: true
+      content:
+        application/json:
+          schema:
+            $ref: "#/components/schemas/ContentDeliveryNetworkV1"
+  securitySchemes:
+    bearer_auth:
+      type: "http"
+      scheme: "bearer"
+  schemas:
+    ContentDeliveryNetworkV1:
+      type: "object"
+      required:
+      - label
+      properties:
+        id:
+          type: "string"
+          readOnly: true
+        label:
+          type: "string"
+        origin:
+          $ref: "#/components/schemas/Origin"
+    Origin:
+      type: "object"
+      properties:
+        host:
+          type: "string"`
+
+func TestNewSpecAnalyserV3(t *testing.T) {
+	Convey("Given an OpenAPI v3 document describing a terraform compliant resource", t, func() {
+		openAPIFile := initAPISpecFile(openAPIV3CDNDocument)
+		defer os.Remove(openAPIFile.Name())
+		Convey("When newSpecAnalyserV3 method is called", func() {
+			specAnalyserV3, err := newSpecAnalyserV3(openAPIFile.Name())
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the backend configuration should be populated from the first server", func() {
+				backendConfiguration, err := specAnalyserV3.GetAPIBackendConfiguration()
+				So(err, ShouldBeNil)
+				host, err := backendConfiguration.getHost()
+				So(err, ShouldBeNil)
+				So(host, ShouldEqual, "production.api.server.com")
+				So(backendConfiguration.getBasePath(), ShouldEqual, "/api")
+				httpScheme, err := backendConfiguration.getHTTPScheme()
+				So(err, ShouldBeNil)
+				So(httpScheme, ShouldEqual, "https")
+			})
+			Convey("And the bearer security scheme should be converted into an api key header security definition", func() {
+				securityDefinitions, err := specAnalyserV3.GetSecurity().GetAPIKeySecurityDefinitions()
+				So(err, ShouldBeNil)
+				securityDefinition := securityDefinitions.findSecurityDefinitionFor("bearer_auth")
+				So(securityDefinition, ShouldNotBeNil)
+				So(securityDefinition.getAPIKey().Name, ShouldEqual, authorizationHeader)
+			})
+			Convey("And the resource should be terraform compliant with the properties defined in the components schema", func() {
+				resources, err := specAnalyserV3.GetTerraformCompliantResources()
+				So(err, ShouldBeNil)
+				So(len(resources), ShouldEqual, 1)
+				So(resources[0].getResourceName(), ShouldEqual, "cdns_v1")
+				resourceSchema, err := resources[0].getResourceSchema()
+				So(err, ShouldBeNil)
+				label, err := resourceSchema.getProperty("label")
+				So(err, ShouldBeNil)
+				So(label.Required, ShouldBeTrue)
+				id, err := resourceSchema.getProperty("id")
+				So(err, ShouldBeNil)
+				So(id.ReadOnly, ShouldBeTrue)
+				origin, err := resourceSchema.getProperty("origin")
+				So(err, ShouldBeNil)
+				So(origin.Type, ShouldEqual, typeObject)
+				_, err = origin.SpecSchemaDefinition.getProperty("host")
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given an OpenAPI v3.1 document describing a terraform compliant resource with JSON Schema 2020-12 keywords", t, func() {
+		openAPIFile := initAPISpecFile(strings.NewReplacer(`openapi: "3.0.1"`, `openapi: "3.1.0"`, `        label:
+          type: "string"`, `        label:
+          type: ["string", "null"]
+        kind:
+          const: "cdn"
+          type: "string"`).Replace(openAPIV3CDNDocument))
+		defer os.Remove(openAPIFile.Name())
+		Convey("When newSpecAnalyserV3 method is called", func() {
+			specAnalyserV3, err := newSpecAnalyserV3(openAPIFile.Name())
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource properties should be translated into their terraform equivalents", func() {
+				resources, err := specAnalyserV3.GetTerraformCompliantResources()
+				So(err, ShouldBeNil)
+				So(len(resources), ShouldEqual, 1)
+				resourceSchema, err := resources[0].getResourceSchema()
+				So(err, ShouldBeNil)
+				label, err := resourceSchema.getProperty("label")
+				So(err, ShouldBeNil)
+				So(label.Type, ShouldEqual, typeString)
+				So(label.Nullable, ShouldBeTrue)
+				kind, err := resourceSchema.getProperty("kind")
+				So(err, ShouldBeNil)
+				So(kind.Computed, ShouldBeTrue)
+				So(kind.Default, ShouldEqual, "cdn")
+			})
+		})
+	})
+
+	Convey("Given an OpenAPI v3 document with a request body referencing a component that does not exist", t, func() {
+		openAPIFile := initAPISpecFile(`{"openapi": "3.0.1", "paths": {"/v1/cdns": {"post": {"requestBody": {"$ref": "#/components/requestBodies/Missing"}, "responses": {}}}}}`)
+		defer os.Remove(openAPIFile.Name())
+		Convey("When newSpecAnalyserV3 method is called", func() {
+			_, err := newSpecAnalyserV3(openAPIFile.Name())
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "reference '#/components/requestBodies/Missing' could not be resolved")
+			})
+		})
+	})
+
+	Convey("Given an empty OpenAPI document filename", t, func() {
+		Convey("When newSpecAnalyserV3 method is called", func() {
+			_, err := newSpecAnalyserV3("")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "open api document filename argument empty, please provide the url of the OpenAPI document")
+			})
+		})
+	})
+}
@@ -0,0 +1,606 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// openAPIV3Operations contains the path item fields that describe operations in OpenAPI 3.0 documents
+var openAPIV3Operations = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// openAPIV3RefReplacer translates the OpenAPI 3.0 component references into their OpenAPI 2.0 equivalents
+var openAPIV3RefReplacer = strings.NewReplacer(
+	"#/components/schemas/", "#/definitions/",
+	"#/components/parameters/", "#/parameters/",
+	"#/components/responses/", "#/responses/",
+)
+
+// openAPIV3Converter translates an OpenAPI 3.0 (or 3.1) document into the equivalent OpenAPI 2.0 document so it can be
+// analysed by the specV2Analyser. Only the features used by the provider are translated:
+// - servers: the first server URL (with the variables replaced by their default values) is converted into the schemes,
+// host and basePath
+// - components: schemas, parameters, responses and securitySchemes are converted into definitions, parameters,
+// responses and securityDefinitions
+// - requestBody: the JSON content schema (or the form content schema, along with the consumes media type) is converted
+// into the body parameter
+// - responses: the JSON content schema is converted into the response schema
+// - schemas: the JSON Schema 2020-12 keywords used in OpenAPI 3.1 (type arrays, const, prefixItems and numeric
+// exclusiveMinimum/exclusiveMaximum) are converted into their OpenAPI 2.0 equivalents
+// The extensions (x-terraform-*) are kept as they are in the same objects they were defined
+type openAPIV3Converter struct {
+	document map[string]interface{}
+	// logger (optional) is used to log the parts of the document that are ignored since they are not supported
+	logger Logger
+}
+
+func newOpenAPIV3Converter(document json.RawMessage) (*openAPIV3Converter, error) {
+	var d map[string]interface{}
+	if err := json.Unmarshal(document, &d); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the OpenAPI document: %s", err)
+	}
+	return &openAPIV3Converter{document: d}, nil
+}
+
+// getLogger returns the logger configured in the converter or the default logger if none was provided
+func (c *openAPIV3Converter) getLogger() Logger {
+	return loggerOrDefault(c.logger)
+}
+
+// convert returns the OpenAPI 2.0 JSON document equivalent to the OpenAPI 3.0 document
+func (c *openAPIV3Converter) convert() (json.RawMessage, error) {
+	swagger := map[string]interface{}{"swagger": "2.0"}
+	for key, value := range c.document {
+		switch key {
+		case "openapi", "servers", "components", "paths", "webhooks", "jsonSchemaDialect":
+		default:
+			// info, security, tags, externalDocs and root level extensions are the same in both versions
+			swagger[key] = value
+		}
+	}
+	if err := c.convertServers(swagger); err != nil {
+		return nil, err
+	}
+	components, _ := c.document["components"].(map[string]interface{})
+	if schemas, ok := components["schemas"].(map[string]interface{}); ok {
+		for name, schema := range schemas {
+			schemas[name] = c.convertSchema(schema)
+		}
+		swagger["definitions"] = schemas
+	}
+	if parameters, ok := components["parameters"].(map[string]interface{}); ok {
+		convertedParameters := map[string]interface{}{}
+		for name, parameter := range parameters {
+			if p, ok := c.convertParameter(parameter); ok {
+				convertedParameters[name] = p
+			}
+		}
+		swagger["parameters"] = convertedParameters
+	}
+	if responses, ok := components["responses"].(map[string]interface{}); ok {
+		swagger["responses"] = c.convertResponses(responses)
+	}
+	if securitySchemes, ok := components["securitySchemes"].(map[string]interface{}); ok {
+		swagger["securityDefinitions"] = c.convertSecuritySchemes(securitySchemes)
+	}
+	paths := map[string]interface{}{}
+	if p, ok := c.document["paths"].(map[string]interface{}); ok {
+		for path, pathItem := range p {
+			convertedPathItem, err := c.convertPathItem(path, pathItem)
+			if err != nil {
+				return nil, err
+			}
+			paths[path] = convertedPathItem
+		}
+	}
+	swagger["paths"] = paths
+	return json.Marshal(c.convertRefs(swagger))
+}
+
+// convertServers converts the first server URL into the schemes, host and basePath. The server variables are replaced
+// by their default values. Relative URLs only populate the basePath, in which case the host will be the one serving the
+// OpenAPI document
+func (c *openAPIV3Converter) convertServers(swagger map[string]interface{}) error {
+	servers, _ := c.document["servers"].([]interface{})
+	if len(servers) == 0 {
+		return nil
+	}
+	if len(servers) > 1 {
+		c.getLogger().Warn("the OpenAPI document contains more than one server, only the first one will be used")
+	}
+	server, _ := servers[0].(map[string]interface{})
+	serverURL, _ := server["url"].(string)
+	if variables, ok := server["variables"].(map[string]interface{}); ok {
+		for name, variable := range variables {
+			if defaultValue, ok := variable.(map[string]interface{})["default"]; ok {
+				serverURL = strings.Replace(serverURL, fmt.Sprintf("{%s}", name), fmt.Sprintf("%v", defaultValue), -1)
+			}
+		}
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse the server url '%s': %s", serverURL, err)
+	}
+	if u.Scheme != "" {
+		swagger["schemes"] = []interface{}{u.Scheme}
+	}
+	if u.Host != "" {
+		swagger["host"] = u.Host
+	}
+	if basePath := strings.TrimRight(u.Path, "/"); basePath != "" {
+		swagger["basePath"] = basePath
+	}
+	return nil
+}
+
+func (c *openAPIV3Converter) convertPathItem(path string, pathItem interface{}) (map[string]interface{}, error) {
+	item, ok := pathItem.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("path '%s' is not a valid path item", path)
+	}
+	convertedPathItem := map[string]interface{}{}
+	for key, value := range item {
+		switch {
+		case key == "parameters":
+			convertedPathItem[key] = c.convertParameters(value)
+		case isOpenAPIV3Operation(key):
+			operation, err := c.convertOperation(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert the %s operation of the path '%s': %s", strings.ToUpper(key), path, err)
+			}
+			convertedPathItem[key] = operation
+		case key == "servers", key == "summary", key == "description", key == "trace":
+			// not supported in OpenAPI 2.0 path items
+		default:
+			convertedPathItem[key] = value
+		}
+	}
+	return convertedPathItem, nil
+}
+
+func (c *openAPIV3Converter) convertOperation(operation interface{}) (map[string]interface{}, error) {
+	op, ok := operation.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a valid operation")
+	}
+	convertedOperation := map[string]interface{}{}
+	for key, value := range op {
+		switch key {
+		case "parameters":
+			convertedOperation[key] = c.convertParameters(value)
+		case "requestBody":
+		case "responses":
+			responses, _ := value.(map[string]interface{})
+			convertedOperation[key] = c.convertResponses(responses)
+			if produces := getOpenAPIV3ResponsesMediaType(responses); produces != "" {
+				convertedOperation["produces"] = []interface{}{produces}
+			}
+		case "callbacks", "servers":
+			// not supported in OpenAPI 2.0 operations
+		default:
+			convertedOperation[key] = value
+		}
+	}
+	if requestBody, exists := op["requestBody"]; exists {
+		bodyParameter, consumes, err := c.convertRequestBody(requestBody)
+		if err != nil {
+			return nil, err
+		}
+		if bodyParameter != nil {
+			parameters, _ := convertedOperation["parameters"].([]interface{})
+			convertedOperation["parameters"] = append(parameters, bodyParameter)
+		}
+		if consumes != "" {
+			convertedOperation["consumes"] = []interface{}{consumes}
+		}
+	}
+	return convertedOperation, nil
+}
+
+// convertRequestBody returns the body parameter equivalent to the request body; nil if the request body does not have
+// JSON, XML (application/xml or text/xml) nor form (multipart/form-data or application/x-www-form-urlencoded) content.
+// The XML and form content is only considered if there is no JSON content, in which case the media type the operation
+// consumes is returned too. Request bodies
+// referencing components are resolved since OpenAPI 2.0 does not support them
+func (c *openAPIV3Converter) convertRequestBody(requestBody interface{}) (map[string]interface{}, string, error) {
+	body, _ := requestBody.(map[string]interface{})
+	if ref, ok := body["$ref"].(string); ok {
+		resolved, err := c.resolveComponent(ref, "requestBodies")
+		if err != nil {
+			return nil, "", err
+		}
+		body = resolved
+	}
+	consumes := ""
+	schema := getOpenAPIV3ContentSchema(body)
+	if schema == nil {
+		schema, consumes = getOpenAPIV3MediaTypeSchema(body, mediaTypeXML, mediaTypeTextXML, mediaTypeMultipartFormData, mediaTypeFormURLEncoded)
+	}
+	if schema == nil {
+		return nil, "", nil
+	}
+	bodyParameter := map[string]interface{}{"name": "body", "in": "body", "schema": c.convertSchema(schema)}
+	if required, ok := body["required"]; ok {
+		bodyParameter["required"] = required
+	}
+	if description, ok := body["description"]; ok {
+		bodyParameter["description"] = description
+	}
+	return bodyParameter, consumes, nil
+}
+
+func (c *openAPIV3Converter) resolveComponent(ref, componentType string) (map[string]interface{}, error) {
+	prefix := fmt.Sprintf("#/components/%s/", componentType)
+	if !strings.HasPrefix(ref, prefix) {
+		return nil, fmt.Errorf("reference '%s' is not supported, only local references to %s are supported", ref, prefix)
+	}
+	components, _ := c.document["components"].(map[string]interface{})
+	objects, _ := components[componentType].(map[string]interface{})
+	object, ok := objects[strings.TrimPrefix(ref, prefix)].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("reference '%s' could not be resolved", ref)
+	}
+	return object, nil
+}
+
+func (c *openAPIV3Converter) convertParameters(parameters interface{}) []interface{} {
+	params, _ := parameters.([]interface{})
+	convertedParameters := []interface{}{}
+	for _, parameter := range params {
+		if p, ok := c.convertParameter(parameter); ok {
+			convertedParameters = append(convertedParameters, p)
+		}
+	}
+	return convertedParameters
+}
+
+// convertParameter moves the parameter schema attributes (type, format, items, enum, default) into the parameter as
+// expected in OpenAPI 2.0. Cookie parameters are not supported in OpenAPI 2.0 and therefore ignored
+func (c *openAPIV3Converter) convertParameter(parameter interface{}) (map[string]interface{}, bool) {
+	param, ok := parameter.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if _, isRef := param["$ref"]; isRef {
+		return param, true
+	}
+	if param["in"] == "cookie" {
+		c.getLogger().Warn(fmt.Sprintf("ignoring cookie parameter '%v' since it is not supported", param["name"]))
+		return nil, false
+	}
+	convertedParameter := map[string]interface{}{}
+	for key, value := range param {
+		switch key {
+		case "schema":
+			schema, _ := c.convertSchema(value).(map[string]interface{})
+			for _, schemaKey := range []string{"type", "format", "items", "enum", "default", "minimum", "maximum", "pattern", "minLength", "maxLength"} {
+				if schemaValue, exists := schema[schemaKey]; exists {
+					convertedParameter[schemaKey] = schemaValue
+				}
+			}
+		case "style", "explode", "example", "examples", "deprecated", "allowReserved", "content":
+			// not supported in OpenAPI 2.0 parameters
+		default:
+			convertedParameter[key] = value
+		}
+	}
+	if convertedParameter["type"] == "array" {
+		if format, ok := c.convertCollectionFormat(param); ok {
+			convertedParameter["collectionFormat"] = format
+		}
+	}
+	return convertedParameter, true
+}
+
+// convertCollectionFormat returns the OpenAPI 2.0 collectionFormat equivalent to the style and explode of the array
+// parameter. The default style is form for query parameters and simple for path and header parameters, and explode
+// defaults to true only for the form style. Exploded arrays are sent as multiple parameter instances except for the
+// simple style which always separates the values with commas. Styles with no collectionFormat equivalent (e,g: label,
+// matrix or deepObject) are ignored
+func (c *openAPIV3Converter) convertCollectionFormat(param map[string]interface{}) (string, bool) {
+	style, _ := param["style"].(string)
+	if style == "" {
+		style = "simple"
+		if param["in"] == "query" {
+			style = "form"
+		}
+	}
+	explode, isSet := param["explode"].(bool)
+	if !isSet {
+		explode = style == "form"
+	}
+	var format collectionFormat
+	switch style {
+	case "simple", "form":
+		format = collectionFormatCSV
+	case "spaceDelimited":
+		format = collectionFormatSSV
+	case "pipeDelimited":
+		format = collectionFormatPipes
+	default:
+		c.getLogger().Warn(fmt.Sprintf("ignoring style '%s' of parameter '%v' since it is not supported", style, param["name"]))
+		return "", false
+	}
+	if explode && style != "simple" {
+		format = collectionFormatMulti
+	}
+	return string(format), true
+}
+
+func (c *openAPIV3Converter) convertResponses(responses map[string]interface{}) map[string]interface{} {
+	convertedResponses := map[string]interface{}{}
+	for code, response := range responses {
+		convertedResponses[code] = c.convertResponse(response)
+	}
+	return convertedResponses
+}
+
+// convertResponse replaces the response content with the schema of the JSON content, or the XML content if there is no
+// JSON content. The response extensions (e,g: polling extensions) are kept
+func (c *openAPIV3Converter) convertResponse(response interface{}) interface{} {
+	r, ok := response.(map[string]interface{})
+	if !ok {
+		return response
+	}
+	convertedResponse := map[string]interface{}{}
+	for key, value := range r {
+		switch key {
+		case "content", "links":
+		case "headers":
+			headers := map[string]interface{}{}
+			responseHeaders, _ := value.(map[string]interface{})
+			for name, header := range responseHeaders {
+				if h, ok := c.convertParameter(header); ok {
+					headers[name] = h
+				}
+			}
+			convertedResponse[key] = headers
+		default:
+			convertedResponse[key] = value
+		}
+	}
+	schema := getOpenAPIV3ContentSchema(r)
+	if schema == nil {
+		schema, _ = getOpenAPIV3MediaTypeSchema(r, mediaTypeXML, mediaTypeTextXML)
+	}
+	if schema != nil {
+		convertedResponse["schema"] = c.convertSchema(schema)
+	}
+	if _, isRef := r["$ref"]; !isRef {
+		if _, exists := convertedResponse["description"]; !exists {
+			convertedResponse["description"] = ""
+		}
+	}
+	return convertedResponse
+}
+
+// getOpenAPIV3MediaTypeSchema returns the schema of the first of the given media types included in the content of the
+// request body or response along with the media type, nil if the content does not include any of them
+func getOpenAPIV3MediaTypeSchema(object map[string]interface{}, mediaTypes ...string) (interface{}, string) {
+	content, _ := object["content"].(map[string]interface{})
+	for _, mediaType := range mediaTypes {
+		if mediaTypeObject, ok := content[mediaType].(map[string]interface{}); ok && mediaTypeObject["schema"] != nil {
+			return mediaTypeObject["schema"], mediaType
+		}
+	}
+	return nil, ""
+}
+
+// getOpenAPIV3ResponsesMediaType returns the media type the operation produces if the responses only have XML
+// (application/xml or text/xml) or binary (application/octet-stream) content, empty otherwise
+func getOpenAPIV3ResponsesMediaType(responses map[string]interface{}) string {
+	produces := ""
+	for _, response := range responses {
+		r, _ := response.(map[string]interface{})
+		if getOpenAPIV3ContentSchema(r) != nil {
+			return ""
+		}
+		content, _ := r["content"].(map[string]interface{})
+		for _, mediaType := range []string{mediaTypeXML, mediaTypeTextXML, mediaTypeOctetStream} {
+			if _, exists := content[mediaType]; exists && produces == "" {
+				produces = mediaType
+			}
+		}
+	}
+	return produces
+}
+
+// convertSecuritySchemes converts the security schemes into security definitions. The http bearer scheme is converted
+// into an apiKey header security definition using the 'x-terraform-authentication-scheme-bearer' extension and the oauth2
+// clientCredentials flow is converted into the oauth2 'application' flow. Security schemes that can not be represented in
+// OpenAPI 2.0 are ignored
+func (c *openAPIV3Converter) convertSecuritySchemes(securitySchemes map[string]interface{}) map[string]interface{} {
+	securityDefinitions := map[string]interface{}{}
+	for name, securityScheme := range securitySchemes {
+		scheme, _ := securityScheme.(map[string]interface{})
+		securityDefinition := map[string]interface{}{}
+		for key, value := range scheme {
+			if strings.HasPrefix(key, "x-") || key == "description" {
+				securityDefinition[key] = value
+			}
+		}
+		switch scheme["type"] {
+		case "apiKey":
+			securityDefinition["type"] = "apiKey"
+			securityDefinition["name"] = scheme["name"]
+			securityDefinition["in"] = scheme["in"]
+		case "http":
+			switch strings.ToLower(fmt.Sprintf("%v", scheme["scheme"])) {
+			case "bearer":
+				securityDefinition["type"] = "apiKey"
+				securityDefinition["name"] = authorizationHeader
+				securityDefinition["in"] = "header"
+				securityDefinition[extTfAuthenticationSchemeBearer] = true
+			case "basic":
+				securityDefinition["type"] = "basic"
+			default:
+				c.getLogger().Warn(fmt.Sprintf("ignoring security scheme '%s' since the http scheme '%v' is not supported", name, scheme["scheme"]))
+				continue
+			}
+		case "oauth2":
+			flows, _ := scheme["flows"].(map[string]interface{})
+			clientCredentials, ok := flows["clientCredentials"].(map[string]interface{})
+			if !ok {
+				c.getLogger().Warn(fmt.Sprintf("ignoring security scheme '%s' since only the oauth2 clientCredentials flow is supported", name))
+				continue
+			}
+			securityDefinition["type"] = "oauth2"
+			securityDefinition["flow"] = "application"
+			securityDefinition["tokenUrl"] = clientCredentials["tokenUrl"]
+			if scopes, ok := clientCredentials["scopes"]; ok {
+				securityDefinition["scopes"] = scopes
+			}
+		default:
+			c.getLogger().Warn(fmt.Sprintf("ignoring security scheme '%s' since the type '%v' is not supported", name, scheme["type"]))
+			continue
+		}
+		securityDefinitions[name] = securityDefinition
+	}
+	return securityDefinitions
+}
+
+// convertSchema converts recursively the JSON Schema 2020-12 keywords supported in OpenAPI 3.1 schemas into their
+// OpenAPI 2.0 equivalents:
+// - type arrays: the 'null' type is converted into the 'x-nullable' extension (e,g: ["string", "null"] is converted into
+// type 'string' and 'x-nullable' true)
+// - const: converted into the default value (so the property is computed with the const value if not provided) and a
+// single value enum
+// - prefixItems: converted into the items schema as long as all the items share the same schema
+// - exclusiveMinimum/exclusiveMaximum numbers: converted into the minimum/maximum along with the boolean flags
+// The schemas that do not use these keywords (e,g: OpenAPI 3.0 schemas) are returned as they are
+func (c *openAPIV3Converter) convertSchema(schema interface{}) interface{} {
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+	if types, ok := s["type"].([]interface{}); ok {
+		var nonNullTypes []interface{}
+		for _, t := range types {
+			if t == "null" {
+				s[extNullable] = true
+				continue
+			}
+			nonNullTypes = append(nonNullTypes, t)
+		}
+		switch len(nonNullTypes) {
+		case 0:
+			delete(s, "type")
+		case 1:
+			s["type"] = nonNullTypes[0]
+		default:
+			s["type"] = nonNullTypes
+		}
+	}
+	if constValue, ok := s["const"]; ok {
+		if _, exists := s["default"]; !exists {
+			s["default"] = constValue
+		}
+		if _, exists := s["enum"]; !exists {
+			s["enum"] = []interface{}{constValue}
+		}
+		delete(s, "const")
+	}
+	if prefixItems, ok := s["prefixItems"].([]interface{}); ok {
+		c.convertPrefixItems(s, prefixItems)
+	}
+	for _, keyword := range []string{"Minimum", "Maximum"} {
+		exclusiveKeyword := "exclusive" + keyword
+		if limit, ok := s[exclusiveKeyword].(float64); ok {
+			s[strings.ToLower(keyword)] = limit
+			s[exclusiveKeyword] = true
+		}
+	}
+	if properties, ok := s["properties"].(map[string]interface{}); ok {
+		for name, property := range properties {
+			properties[name] = c.convertSchema(property)
+		}
+	}
+	for _, keyword := range []string{"items", "additionalProperties", "not"} {
+		if subSchema, ok := s[keyword]; ok {
+			s[keyword] = c.convertSchema(subSchema)
+		}
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		if subSchemas, ok := s[keyword].([]interface{}); ok {
+			for i, subSchema := range subSchemas {
+				subSchemas[i] = c.convertSchema(subSchema)
+			}
+		}
+	}
+	return s
+}
+
+// convertPrefixItems replaces the prefixItems (tuple) of the array schema with the items schema, since OpenAPI 2.0 only
+// supports arrays whose items share the same schema. If the items schemas are different the items are removed, which
+// makes the property not supported
+func (c *openAPIV3Converter) convertPrefixItems(schema map[string]interface{}, prefixItems []interface{}) {
+	delete(schema, "prefixItems")
+	itemSchemas := prefixItems
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		itemSchemas = append(itemSchemas, items)
+	}
+	if len(itemSchemas) == 0 {
+		return
+	}
+	for _, itemSchema := range itemSchemas[1:] {
+		if !reflect.DeepEqual(itemSchema, itemSchemas[0]) {
+			c.getLogger().Warn("ignoring the array items since the prefixItems schemas are different and only arrays whose items share the same schema are supported")
+			delete(schema, "items")
+			return
+		}
+	}
+	schema["items"] = itemSchemas[0]
+}
+
+// convertRefs replaces recursively the component references with their OpenAPI 2.0 equivalents
+func (c *openAPIV3Converter) convertRefs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if ref, isString := item.(string); isString && key == "$ref" {
+				v[key] = openAPIV3RefReplacer.Replace(ref)
+				continue
+			}
+			v[key] = c.convertRefs(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = c.convertRefs(item)
+		}
+	}
+	return value
+}
+
+// getOpenAPIV3ContentSchema returns the schema of the JSON content (application/json or any other JSON media type like
+// application/vnd.api+json); nil if there is no JSON content
+func getOpenAPIV3ContentSchema(object map[string]interface{}) interface{} {
+	content, _ := object["content"].(map[string]interface{})
+	if mediaTypeObject, ok := content["application/json"].(map[string]interface{}); ok {
+		return mediaTypeObject["schema"]
+	}
+	var mediaTypes []string
+	for mediaType := range content {
+		if strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json") {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	sort.Strings(mediaTypes)
+	for _, mediaType := range mediaTypes {
+		if mediaTypeObject, ok := content[mediaType].(map[string]interface{}); ok {
+			return mediaTypeObject["schema"]
+		}
+	}
+	return nil
+}
+
+func isOpenAPIV3Operation(key string) bool {
+	for _, operation := range openAPIV3Operations {
+		if key == operation {
+			return true
+		}
+	}
+	return false
+}
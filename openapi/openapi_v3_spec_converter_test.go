@@ -0,0 +1,373 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func convertOpenAPIV3TestDocument(t *testing.T, document string) map[string]interface{} {
+	converter, err := newOpenAPIV3Converter(json.RawMessage(document))
+	assert.NoError(t, err)
+	swagger, err := converter.convert()
+	assert.NoError(t, err)
+	var converted map[string]interface{}
+	assert.NoError(t, json.Unmarshal(swagger, &converted))
+	return converted
+}
+
+func TestOpenAPIV3ConverterServers(t *testing.T) {
+	testCases := []struct {
+		name             string
+		servers          string
+		expectedSchemes  interface{}
+		expectedHost     interface{}
+		expectedBasePath interface{}
+	}{
+		{name: "no servers", servers: `[]`},
+		{name: "absolute server url", servers: `[{"url": "https://api.server.com/v1/"}]`, expectedSchemes: []interface{}{"https"}, expectedHost: "api.server.com", expectedBasePath: "/v1"},
+		{name: "only the first server is used", servers: `[{"url": "http://api.server.com"}, {"url": "https://other.server.com/v2"}]`, expectedSchemes: []interface{}{"http"}, expectedHost: "api.server.com"},
+		{name: "server variables are replaced by their defaults", servers: `[{"url": "https://{environment}.server.com:{port}/v1", "variables": {"environment": {"default": "api"}, "port": {"default": "8443"}}}]`, expectedSchemes: []interface{}{"https"}, expectedHost: "api.server.com:8443", expectedBasePath: "/v1"},
+		{name: "relative server url", servers: `[{"url": "/api/v1"}]`, expectedBasePath: "/api/v1"},
+	}
+	for _, tc := range testCases {
+		converted := convertOpenAPIV3TestDocument(t, `{"openapi": "3.0.1", "info": {"title": "test", "version": "1.0.0"}, "servers": `+tc.servers+`, "paths": {}}`)
+		assert.Equal(t, "2.0", converted["swagger"], tc.name)
+		assert.Equal(t, map[string]interface{}{"title": "test", "version": "1.0.0"}, converted["info"], tc.name)
+		assert.Equal(t, tc.expectedSchemes, converted["schemes"], tc.name)
+		assert.Equal(t, tc.expectedHost, converted["host"], tc.name)
+		assert.Equal(t, tc.expectedBasePath, converted["basePath"], tc.name)
+		assert.NotContains(t, converted, "openapi", tc.name)
+		assert.NotContains(t, converted, "servers", tc.name)
+	}
+}
+
+func TestOpenAPIV3ConverterLogger(t *testing.T) {
+	logger := &loggerStub{}
+	converter, err := newOpenAPIV3Converter(json.RawMessage(`{"openapi": "3.0.1", "servers": [{"url": "http://api.server.com"}, {"url": "https://other.server.com/v2"}], "paths": {}}`))
+	assert.NoError(t, err)
+	converter.logger = logger
+	_, err = converter.convert()
+	assert.NoError(t, err)
+	assert.True(t, logger.containsMessage("WARN", "the OpenAPI document contains more than one server, only the first one will be used"))
+}
+
+func TestOpenAPIV3ConverterComponents(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "x-terraform-provider-multiregion-fqdn": "api.${region}.server.com",
+  "paths": {},
+  "components": {
+    "schemas": {
+      "Cdn": {"type": "object", "properties": {"id": {"type": "string", "readOnly": true}, "origin": {"$ref": "#/components/schemas/Origin"}}},
+      "Origin": {"type": "object", "properties": {"host": {"type": "string"}}}
+    },
+    "parameters": {
+      "CdnId": {"name": "id", "in": "path", "required": true, "schema": {"type": "string"}, "style": "simple"},
+      "Session": {"name": "session", "in": "cookie", "schema": {"type": "string"}}
+    },
+    "responses": {
+      "NotFound": {"description": "not found"}
+    }
+  }
+}`)
+	assert.Equal(t, "api.${region}.server.com", converted["x-terraform-provider-multiregion-fqdn"])
+	definitions := converted["definitions"].(map[string]interface{})
+	assert.Equal(t, "#/definitions/Origin", definitions["Cdn"].(map[string]interface{})["properties"].(map[string]interface{})["origin"].(map[string]interface{})["$ref"])
+	assert.Equal(t, map[string]interface{}{"CdnId": map[string]interface{}{"name": "id", "in": "path", "required": true, "type": "string"}}, converted["parameters"])
+	assert.Equal(t, map[string]interface{}{"NotFound": map[string]interface{}{"description": "not found"}}, converted["responses"])
+}
+
+func TestOpenAPIV3ConverterOperations(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/v1/cdns": {
+      "post": {
+        "x-terraform-resource-timeout": "30s",
+        "requestBody": {"$ref": "#/components/requestBodies/Cdn"},
+        "responses": {
+          "202": {
+            "description": "accepted",
+            "x-terraform-resource-poll-enabled": true,
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Cdn"}}}
+          },
+          "404": {"$ref": "#/components/responses/NotFound"}
+        },
+        "callbacks": {}
+      }
+    },
+    "/v1/cdns/{id}": {
+      "summary": "cdn",
+      "parameters": [{"$ref": "#/components/parameters/CdnId"}],
+      "put": {
+        "parameters": [{"name": "session", "in": "cookie", "schema": {"type": "string"}}, {"name": "X-Request-ID", "in": "header", "schema": {"type": "string"}}],
+        "requestBody": {"required": true, "content": {"application/vnd.api+json": {"schema": {"$ref": "#/components/schemas/Cdn"}}}},
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Cdn"}}}}}
+      },
+      "delete": {
+        "requestBody": {"content": {"text/plain": {"schema": {"type": "string"}}}},
+        "responses": {"204": {"description": "deleted"}}
+      }
+    }
+  },
+  "components": {
+    "requestBodies": {
+      "Cdn": {"description": "cdn payload", "required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Cdn"}}}}
+    }
+  }
+}`)
+	paths := converted["paths"].(map[string]interface{})
+
+	post := paths["/v1/cdns"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "30s", post["x-terraform-resource-timeout"])
+	assert.NotContains(t, post, "requestBody")
+	assert.NotContains(t, post, "callbacks")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "body", "in": "body", "required": true, "description": "cdn payload", "schema": map[string]interface{}{"$ref": "#/definitions/Cdn"}}}, post["parameters"])
+	assert.Equal(t, map[string]interface{}{
+		"202": map[string]interface{}{"description": "accepted", "x-terraform-resource-poll-enabled": true, "schema": map[string]interface{}{"$ref": "#/definitions/Cdn"}},
+		"404": map[string]interface{}{"$ref": "#/responses/NotFound"},
+	}, post["responses"])
+
+	instancePath := paths["/v1/cdns/{id}"].(map[string]interface{})
+	assert.NotContains(t, instancePath, "summary")
+	assert.Equal(t, []interface{}{map[string]interface{}{"$ref": "#/parameters/CdnId"}}, instancePath["parameters"])
+
+	put := instancePath["put"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "X-Request-ID", "in": "header", "type": "string"},
+		map[string]interface{}{"name": "body", "in": "body", "required": true, "schema": map[string]interface{}{"$ref": "#/definitions/Cdn"}},
+	}, put["parameters"])
+	assert.Equal(t, map[string]interface{}{"200": map[string]interface{}{"description": "", "schema": map[string]interface{}{"$ref": "#/definitions/Cdn"}}}, put["responses"])
+
+	del := instancePath["delete"].(map[string]interface{})
+	assert.NotContains(t, del, "parameters")
+}
+
+func TestOpenAPIV3ConverterArrayParameters(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/v1/cdns/{ids}": {
+      "get": {
+        "parameters": [
+          {"name": "ids", "in": "path", "required": true, "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "default", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "form", "in": "query", "style": "form", "explode": false, "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "space", "in": "query", "style": "spaceDelimited", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "pipe", "in": "query", "style": "pipeDelimited", "explode": false, "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "exploded_pipe", "in": "query", "style": "pipeDelimited", "explode": true, "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "object", "in": "query", "style": "deepObject", "schema": {"type": "array", "items": {"type": "string"}}},
+          {"name": "label", "in": "query", "style": "form", "explode": false, "schema": {"type": "string"}}
+        ],
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`)
+	get := converted["paths"].(map[string]interface{})["/v1/cdns/{ids}"].(map[string]interface{})["get"].(map[string]interface{})
+	collectionFormats := map[string]interface{}{}
+	for _, p := range get["parameters"].([]interface{}) {
+		parameter := p.(map[string]interface{})
+		assert.NotContains(t, parameter, "style")
+		assert.NotContains(t, parameter, "explode")
+		if format, exists := parameter["collectionFormat"]; exists {
+			collectionFormats[parameter["name"].(string)] = format
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"ids":           "csv",
+		"default":       "multi",
+		"form":          "csv",
+		"space":         "ssv",
+		"pipe":          "pipes",
+		"exploded_pipe": "multi",
+	}, collectionFormats, "the collectionFormat should only be set for array parameters with a style supported in OpenAPI 2.0")
+}
+
+func TestOpenAPIV3ConverterFormRequestBody(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/v1/certificates": {
+      "post": {
+        "requestBody": {"content": {"multipart/form-data": {"schema": {"$ref": "#/components/schemas/Certificate"}}}},
+        "responses": {}
+      },
+      "put": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Certificate"}}, "multipart/form-data": {"schema": {"$ref": "#/components/schemas/Certificate"}}}},
+        "responses": {}
+      }
+    },
+    "/v1/tokens": {
+      "post": {
+        "requestBody": {"content": {"application/x-www-form-urlencoded": {"schema": {"$ref": "#/components/schemas/Token"}}}},
+        "responses": {}
+      }
+    }
+  }
+}`)
+	path := converted["paths"].(map[string]interface{})["/v1/certificates"].(map[string]interface{})
+
+	post := path["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "body", "in": "body", "schema": map[string]interface{}{"$ref": "#/definitions/Certificate"}}}, post["parameters"])
+	assert.Equal(t, []interface{}{"multipart/form-data"}, post["consumes"])
+
+	put := path["put"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "body", "in": "body", "schema": map[string]interface{}{"$ref": "#/definitions/Certificate"}}}, put["parameters"])
+	assert.NotContains(t, put, "consumes")
+
+	tokensPost := converted["paths"].(map[string]interface{})["/v1/tokens"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "body", "in": "body", "schema": map[string]interface{}{"$ref": "#/definitions/Token"}}}, tokensPost["parameters"])
+	assert.Equal(t, []interface{}{"application/x-www-form-urlencoded"}, tokensPost["consumes"])
+}
+
+func TestOpenAPIV3ConverterXMLContent(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/v1/users": {
+      "post": {
+        "requestBody": {"content": {"application/xml": {"schema": {"$ref": "#/components/schemas/User"}}, "multipart/form-data": {"schema": {"$ref": "#/components/schemas/User"}}}},
+        "responses": {"201": {"description": "created", "content": {"application/xml": {"schema": {"$ref": "#/components/schemas/User"}}}}}
+      },
+      "put": {
+        "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+        "responses": {"200": {"description": "updated", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}, "text/xml": {"schema": {"$ref": "#/components/schemas/User"}}}}}
+      }
+    }
+  }
+}`)
+	path := converted["paths"].(map[string]interface{})["/v1/users"].(map[string]interface{})
+
+	post := path["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "body", "in": "body", "schema": map[string]interface{}{"$ref": "#/definitions/User"}}}, post["parameters"])
+	assert.Equal(t, []interface{}{"application/xml"}, post["consumes"])
+	assert.Equal(t, []interface{}{"application/xml"}, post["produces"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/User"}, post["responses"].(map[string]interface{})["201"].(map[string]interface{})["schema"])
+
+	put := path["put"].(map[string]interface{})
+	assert.NotContains(t, put, "consumes")
+	assert.NotContains(t, put, "produces")
+}
+
+func TestOpenAPIV3ConverterBinaryContent(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "paths": {
+    "/v1/certificates/{id}": {
+      "get": {
+        "responses": {"200": {"description": "certificate", "content": {"application/octet-stream": {"schema": {"type": "string", "format": "binary"}}}}, "404": {"description": "not found"}}
+      }
+    }
+  }
+}`)
+	get := converted["paths"].(map[string]interface{})["/v1/certificates/{id}"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"application/octet-stream"}, get["produces"])
+	assert.NotContains(t, get["responses"].(map[string]interface{})["200"], "schema")
+}
+
+func TestOpenAPIV3ConverterRequestBodyErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requestBody   string
+		expectedError string
+	}{
+		{name: "external request body reference", requestBody: `{"$ref": "other.json#/components/requestBodies/Cdn"}`, expectedError: "failed to convert the POST operation of the path '/v1/cdns': reference 'other.json#/components/requestBodies/Cdn' is not supported, only local references to #/components/requestBodies/ are supported"},
+		{name: "missing request body component", requestBody: `{"$ref": "#/components/requestBodies/Missing"}`, expectedError: "failed to convert the POST operation of the path '/v1/cdns': reference '#/components/requestBodies/Missing' could not be resolved"},
+	}
+	for _, tc := range testCases {
+		converter, err := newOpenAPIV3Converter(json.RawMessage(`{"openapi": "3.0.1", "paths": {"/v1/cdns": {"post": {"requestBody": ` + tc.requestBody + `, "responses": {}}}}}`))
+		assert.NoError(t, err, tc.name)
+		_, err = converter.convert()
+		assert.EqualError(t, err, tc.expectedError, tc.name)
+	}
+}
+
+func TestOpenAPIV3ConverterSecuritySchemes(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.0.1",
+  "security": [{"apikey_auth": []}],
+  "paths": {},
+  "components": {
+    "securitySchemes": {
+      "apikey_auth": {"type": "apiKey", "name": "X-API-Key", "in": "header", "x-terraform-refresh-token-url": "https://api.server.com/token"},
+      "bearer_auth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+      "basic_auth": {"type": "http", "scheme": "basic"},
+      "digest_auth": {"type": "http", "scheme": "digest"},
+      "oauth2_auth": {"type": "oauth2", "flows": {}},
+      "oauth2_client_credentials_auth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://api.server.com/oauth2/token", "scopes": {"read": "read access"}}}}
+    }
+  }
+}`)
+	assert.Equal(t, []interface{}{map[string]interface{}{"apikey_auth": []interface{}{}}}, converted["security"])
+	assert.Equal(t, map[string]interface{}{
+		"apikey_auth":                    map[string]interface{}{"type": "apiKey", "name": "X-API-Key", "in": "header", "x-terraform-refresh-token-url": "https://api.server.com/token"},
+		"bearer_auth":                    map[string]interface{}{"type": "apiKey", "name": "Authorization", "in": "header", "x-terraform-authentication-scheme-bearer": true},
+		"basic_auth":                     map[string]interface{}{"type": "basic"},
+		"oauth2_client_credentials_auth": map[string]interface{}{"type": "oauth2", "flow": "application", "tokenUrl": "https://api.server.com/oauth2/token", "scopes": map[string]interface{}{"read": "read access"}},
+	}, converted["securityDefinitions"])
+}
+
+func TestOpenAPIV3ConverterJSONSchemaKeywords(t *testing.T) {
+	testCases := []struct {
+		name           string
+		schema         string
+		expectedSchema map[string]interface{}
+	}{
+		{name: "schema without JSON Schema 2020-12 keywords", schema: `{"type": "string", "nullable": true}`, expectedSchema: map[string]interface{}{"type": "string", "nullable": true}},
+		{name: "nullable type array", schema: `{"type": ["string", "null"]}`, expectedSchema: map[string]interface{}{"type": "string", "x-nullable": true}},
+		{name: "type array without null", schema: `{"type": ["integer"]}`, expectedSchema: map[string]interface{}{"type": "integer"}},
+		{name: "type array with more than one non null type", schema: `{"type": ["string", "integer", "null"]}`, expectedSchema: map[string]interface{}{"type": []interface{}{"string", "integer"}, "x-nullable": true}},
+		{name: "null type", schema: `{"type": ["null"]}`, expectedSchema: map[string]interface{}{"x-nullable": true}},
+		{name: "const", schema: `{"type": "string", "const": "v1"}`, expectedSchema: map[string]interface{}{"type": "string", "default": "v1", "enum": []interface{}{"v1"}}},
+		{name: "const with default", schema: `{"type": "integer", "const": 1, "default": 2}`, expectedSchema: map[string]interface{}{"type": "integer", "default": float64(2), "enum": []interface{}{float64(1)}}},
+		{name: "prefixItems with the same schema", schema: `{"type": "array", "prefixItems": [{"type": "string"}, {"type": "string"}]}`, expectedSchema: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+		{name: "prefixItems with the same schema as the items", schema: `{"type": "array", "prefixItems": [{"type": "string"}], "items": {"type": "string"}}`, expectedSchema: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}},
+		{name: "prefixItems with different schemas", schema: `{"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}]}`, expectedSchema: map[string]interface{}{"type": "array"}},
+		{name: "numeric exclusive minimum and maximum", schema: `{"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10}`, expectedSchema: map[string]interface{}{"type": "number", "minimum": float64(0), "exclusiveMinimum": true, "maximum": float64(10), "exclusiveMaximum": true}},
+		{name: "boolean exclusive minimum", schema: `{"type": "number", "minimum": 0, "exclusiveMinimum": true}`, expectedSchema: map[string]interface{}{"type": "number", "minimum": float64(0), "exclusiveMinimum": true}},
+		{name: "nested schemas", schema: `{"type": "object", "properties": {"tags": {"type": "array", "items": {"type": ["string", "null"]}}, "labels": {"type": "object", "additionalProperties": {"const": "a"}}, "origin": {"allOf": [{"type": ["object", "null"]}]}}}`, expectedSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+			"tags":   map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "x-nullable": true}},
+			"labels": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"default": "a", "enum": []interface{}{"a"}}},
+			"origin": map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"type": "object", "x-nullable": true}}},
+		}}},
+	}
+	for _, tc := range testCases {
+		converted := convertOpenAPIV3TestDocument(t, `{"openapi": "3.1.0", "paths": {}, "components": {"schemas": {"Cdn": `+tc.schema+`}}}`)
+		assert.Equal(t, tc.expectedSchema, converted["definitions"].(map[string]interface{})["Cdn"], tc.name)
+	}
+}
+
+func TestOpenAPIV3ConverterJSONSchemaKeywordsInOperations(t *testing.T) {
+	converted := convertOpenAPIV3TestDocument(t, `{
+  "openapi": "3.1.0",
+  "jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+  "webhooks": {"newCdn": {"post": {"responses": {"200": {"description": "ok"}}}}},
+  "paths": {
+    "/v1/cdns": {
+      "post": {
+        "parameters": [{"name": "X-Request-ID", "in": "header", "schema": {"type": ["string", "null"]}}],
+        "requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"label": {"type": ["string", "null"]}}}}}},
+        "responses": {"201": {"description": "created", "content": {"application/json": {"schema": {"type": "object", "properties": {"version": {"const": "v1"}}}}}}}
+      }
+    }
+  }
+}`)
+	assert.NotContains(t, converted, "jsonSchemaDialect")
+	assert.NotContains(t, converted, "webhooks")
+	post := converted["paths"].(map[string]interface{})["/v1/cdns"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "X-Request-ID", "in": "header", "type": "string"},
+		map[string]interface{}{"name": "body", "in": "body", "schema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"label": map[string]interface{}{"type": "string", "x-nullable": true}}}},
+	}, post["parameters"])
+	assert.Equal(t, map[string]interface{}{
+		"201": map[string]interface{}{"description": "created", "schema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{"version": map[string]interface{}{"default": "v1", "enum": []interface{}{"v1"}}}}},
+	}, post["responses"])
+}
+
+func TestNewOpenAPIV3ConverterInvalidDocument(t *testing.T) {
+	_, err := newOpenAPIV3Converter(json.RawMessage(`[]`))
+	assert.Error(t, err)
+}
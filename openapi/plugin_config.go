@@ -8,7 +8,6 @@ import (
 	"gopkg.in/yaml.v2"
 	"io"
 	"io/ioutil"
-	"log"
 	"os"
 	"strconv"
 	"strings"
@@ -20,6 +19,8 @@ const OpenAPIPluginConfigurationFileName = "terraform-provider-openapi.yaml"
 const otfVarSwaggerURL = "OTF_VAR_%s_SWAGGER_URL"
 const otfVarInsecureSkipVerify = "OTF_INSECURE_SKIP_VERIFY"
 const otfVarPluginConfigurationFile = "OTF_VAR_%s_PLUGIN_CONFIGURATION_FILE"
+const otfVarAllowedResources = "OTF_VAR_%s_ALLOWED_RESOURCES"
+const otfVarExcludedResources = "OTF_VAR_%s_EXCLUDED_RESOURCES"
 
 // PluginConfiguration defines the OpenAPI plugin's configuration
 type PluginConfiguration struct {
@@ -32,19 +33,31 @@ type PluginConfiguration struct {
 	// the former takes preference. This allows the user to override the url specified in the configuration file with
 	// the value provided in the OTF_VAR_<provider_name>_SWAGGER_URL
 	Configuration io.Reader
+	// Logger (optional) is used to log the plugin configuration messages and it's also passed along to the telemetry
+	// handler. If not provided the messages will be logged using the standard logger.
+	Logger Logger
+	// telemetryHandler is the handler used to submit the metrics when the service configuration is loaded, it is kept
+	// so the pending metrics can be flushed when the provider shuts down
+	telemetryHandler TelemetryHandler
 }
 
 // NewPluginConfiguration creates a new PluginConfiguration
 func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
+	return NewPluginConfigurationWithLogger(providerName, nil)
+}
+
+// NewPluginConfigurationWithLogger creates a new PluginConfiguration that logs the plugin configuration messages with
+// the logger provided (the standard logger is used if the logger is nil)
+func NewPluginConfigurationWithLogger(providerName string, logger Logger) (*PluginConfiguration, error) {
 	var configurationFile io.Reader
 	configurationFilePath, err := getPluginConfigurationPath(providerName)
 	if err != nil {
 		return nil, err
 	}
 	if _, err := os.Stat(configurationFilePath); os.IsNotExist(err) {
-		log.Printf("[INFO] open api plugin configuration not present at %s", configurationFilePath)
+		loggerOrDefault(logger).Info(fmt.Sprintf("open api plugin configuration not present at %s", configurationFilePath), "provider", providerName)
 	} else {
-		log.Printf("[INFO] found open api plugin configuration at %s", configurationFilePath)
+		loggerOrDefault(logger).Info(fmt.Sprintf("found open api plugin configuration at %s", configurationFilePath), "provider", providerName)
 		file, err := os.Open(configurationFilePath)
 		if err != nil {
 			return nil, err
@@ -54,6 +67,7 @@ func NewPluginConfiguration(providerName string) (*PluginConfiguration, error) {
 	return &PluginConfiguration{
 		ProviderName:  providerName,
 		Configuration: configurationFile,
+		Logger:        logger,
 	}, nil
 }
 
@@ -82,7 +96,8 @@ func getPluginConfigurationPath(providerName string) (string, error) {
 
 func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, error) {
 	var pluginConfig PluginConfigSchema
-	var pluginConfigV1 = &PluginConfigSchemaV1{}
+	var pluginConfigV1 = &PluginConfigSchemaV1{logger: p.Logger}
+	logger := loggerOrDefault(p.Logger)
 	var serviceConfig ServiceConfiguration
 	var err error
 
@@ -96,7 +111,7 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 	}
 	// Found OTF_VAR_%s_SWAGGER_URL env variable
 	if apiDiscoveryURL != "" {
-		log.Printf("[INFO] %s set with value %s", swaggerURLEnvVar, apiDiscoveryURL)
+		logger.Info(fmt.Sprintf("%s set with value %s", swaggerURLEnvVar, apiDiscoveryURL), "provider", p.ProviderName)
 		pluginConfigV1.Services = map[string]*ServiceConfigV1{}
 		pluginConfigV1.Services[p.ProviderName] = NewServiceConfigV1(apiDiscoveryURL, skipVerify)
 		serviceConfig, err = pluginConfigV1.GetServiceConfig(p.ProviderName)
@@ -127,11 +142,18 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 			telemetryHandler := pluginConfig.GetTelemetryHandler(p.ProviderName)
 			if telemetryHandler != nil {
 				telemetryHandler.SubmitMetrics()
+				p.telemetryHandler = telemetryHandler
 			}
 		}
 	}
 
-	log.Printf("[DEBUG] serviceConfig = %+v", serviceConfig)
+	logger.Debug(fmt.Sprintf("serviceConfig = %+v", serviceConfig), "provider", p.ProviderName)
+
+	if serviceConfigV1, ok := serviceConfig.(*ServiceConfigV1); ok {
+		if err = p.setResourcesFromEnvVars(serviceConfigV1); err != nil {
+			return nil, err
+		}
+	}
 
 	if serviceConfig == nil || serviceConfig.GetSwaggerURL() == "" {
 		return nil, fmt.Errorf("swagger url not provided, please export OTF_VAR_<provider_name>_SWAGGER_URL env variable with the URL where '%s' service provider is exposing the swagger file OR create a plugin configuration file at ~/.terraform.d/plugins following the Plugin configuration schema specifications", p.ProviderName)
@@ -143,3 +165,40 @@ func (p *PluginConfiguration) getServiceConfiguration() (ServiceConfiguration, e
 
 	return serviceConfig, err
 }
+
+// setResourcesFromEnvVars overrides the allowed and excluded resources of the service configuration with the comma
+// separated resource names (or glob patterns) set in the OTF_VAR_<provider_name>_ALLOWED_RESOURCES and
+// OTF_VAR_<provider_name>_EXCLUDED_RESOURCES environment variables, if present
+func (p *PluginConfiguration) setResourcesFromEnvVars(serviceConfig *ServiceConfigV1) error {
+	allowedResources, err := p.getResourcesFromEnvVar(otfVarAllowedResources)
+	if err != nil {
+		return err
+	}
+	if allowedResources != nil {
+		serviceConfig.AllowedResources = allowedResources
+	}
+	excludedResources, err := p.getResourcesFromEnvVar(otfVarExcludedResources)
+	if err != nil {
+		return err
+	}
+	if excludedResources != nil {
+		serviceConfig.ExcludedResources = excludedResources
+	}
+	return nil
+}
+
+func (p *PluginConfiguration) getResourcesFromEnvVar(envVarFormat string) ([]string, error) {
+	envVar := fmt.Sprintf(envVarFormat, p.ProviderName)
+	value, err := terraformutils.MultiEnvDefaultString([]string{envVar, strings.ToUpper(envVar)}, "")
+	if err != nil || value == "" {
+		return nil, err
+	}
+	var resources []string
+	for _, resource := range strings.Split(value, ",") {
+		if resource = strings.TrimSpace(resource); resource != "" {
+			resources = append(resources, resource)
+		}
+	}
+	loggerOrDefault(p.Logger).Info(fmt.Sprintf("%s set with value %s", envVar, value), "provider", p.ProviderName)
+	return resources, nil
+}
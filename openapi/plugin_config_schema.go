@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
 	"gopkg.in/yaml.v2"
-	"log"
+	"sync/atomic"
 )
 
 // ServiceConfigurations contains the map with all service configurations
@@ -42,6 +42,8 @@ type PluginConfigSchemaV1 struct {
 	Version         string                      `yaml:"version"`
 	TelemetryConfig *TelemetryConfig            `yaml:"telemetry,omitempty"`
 	Services        map[string]*ServiceConfigV1 `yaml:"services"`
+	// logger is used to log the telemetry messages and passed along to the telemetry handler and providers
+	logger Logger
 }
 
 // TelemetryConfig contains the configuration for the telemetry
@@ -50,6 +52,18 @@ type TelemetryConfig struct {
 	Graphite *TelemetryProviderGraphite `yaml:"graphite,omitempty"`
 	// HTTPEndpoint defines the configuration needed to ship telemetry to an http endpoint
 	HTTPEndpoint *TelemetryProviderHTTPEndpoint `yaml:"http_endpoint,omitempty"`
+	// Statsd defines the configuration needed to ship telemetry to a statsd agent over UDP
+	Statsd *TelemetryProviderStatsd `yaml:"statsd,omitempty"`
+	// OTLP defines the configuration needed to export telemetry to an OpenTelemetry collector
+	OTLP *TelemetryProviderOTLP `yaml:"otlp,omitempty"`
+	// PrometheusPush defines the configuration needed to push telemetry to a Prometheus Pushgateway
+	PrometheusPush *TelemetryProviderPrometheusPush `yaml:"prometheus_push,omitempty"`
+	// Async (optional) enables the metrics to be submitted asynchronously so the provider execution is not blocked by them
+	Async *TelemetryAsyncConfig `yaml:"async,omitempty"`
+	// Retry (optional) enables the failed metrics submissions to be retried with exponential backoff
+	Retry *TelemetryRetryConfig `yaml:"retry,omitempty"`
+	// CircuitBreaker (optional) disables the telemetry providers for the rest of the run after a number of consecutive failures
+	CircuitBreaker *TelemetryCircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
 }
 
 // NewPluginConfigSchemaV1 creates a new PluginConfigSchemaV1 that implements PluginConfigSchema interface
@@ -101,6 +115,25 @@ func (p *PluginConfigSchemaV1) Marshal() ([]byte, error) {
 	return out, err
 }
 
+// getLogger returns the logger configured in the plugin configuration or the default logger if none was provided
+func (p *PluginConfigSchemaV1) getLogger() Logger {
+	return loggerOrDefault(p.logger)
+}
+
+// getUserAgentSuffix returns the user agent suffix configured for the given provider name. If the service configuration
+// does not exist or the suffix is not valid an empty string is returned
+func (p *PluginConfigSchemaV1) getUserAgentSuffix(providerName string) string {
+	serviceConfig, exists := p.Services[providerName]
+	if !exists || serviceConfig == nil {
+		return ""
+	}
+	if err := validateUserAgentSuffix(serviceConfig.UserAgentSuffix); err != nil {
+		p.getLogger().Warn(fmt.Sprintf("ignoring user agent suffix for telemetry requests: %s", err), "provider", providerName)
+		return ""
+	}
+	return serviceConfig.UserAgentSuffix
+}
+
 // GetTelemetryHandler returns a handler containing validated telemetry providers
 func (p *PluginConfigSchemaV1) GetTelemetryHandler(providerName string) TelemetryHandler {
 	var telemetryProviders []TelemetryProvider
@@ -108,37 +141,120 @@ func (p *PluginConfigSchemaV1) GetTelemetryHandler(providerName string) Telemetr
 		if p.TelemetryConfig.Graphite != nil {
 			err := p.TelemetryConfig.Graphite.Validate()
 			if err != nil {
-				log.Printf("[WARN] ignoring graphite telemetry due to the following validation error: %s", err)
+				p.getLogger().Warn(fmt.Sprintf("ignoring graphite telemetry due to the following validation error: %s", err))
 			} else {
+				p.TelemetryConfig.Graphite.logger = p.logger
 				telemetryProviders = append(telemetryProviders, p.TelemetryConfig.Graphite)
-				log.Printf("[DEBUG] graphite telemetry provider enabled")
+				p.getLogger().Debug("graphite telemetry provider enabled")
 			}
 		} else {
-			log.Printf("[DEBUG] graphite telemetry configuration not present")
+			p.getLogger().Debug("graphite telemetry configuration not present")
 		}
 
 		if p.TelemetryConfig.HTTPEndpoint != nil {
 			err := p.TelemetryConfig.HTTPEndpoint.Validate()
 			if err != nil {
-				log.Printf("[WARN] ignoring http endpoint telemetry due to the following validation error: %s", err)
+				p.getLogger().Warn(fmt.Sprintf("ignoring http endpoint telemetry due to the following validation error: %s", err))
 			} else {
+				p.TelemetryConfig.HTTPEndpoint.userAgentSuffix = p.getUserAgentSuffix(providerName)
+				p.TelemetryConfig.HTTPEndpoint.logger = p.logger
+				if p.TelemetryConfig.HTTPEndpoint.ValidateConnectivity {
+					// Connectivity issues must never block the provider configuration so the telemetry is still enabled
+					if err := p.TelemetryConfig.HTTPEndpoint.HealthCheck(); err != nil {
+						p.getLogger().Warn(fmt.Sprintf("http endpoint telemetry connectivity check failed, metrics might not be submitted: %s", err))
+					}
+				}
 				telemetryProviders = append(telemetryProviders, p.TelemetryConfig.HTTPEndpoint)
-				log.Printf("[DEBUG] http endpoint telemetry provider enabled")
+				p.getLogger().Debug("http endpoint telemetry provider enabled")
+			}
+		} else {
+			p.getLogger().Debug("http endpoint telemetry configuration not present")
+		}
+
+		if p.TelemetryConfig.Statsd != nil {
+			err := p.TelemetryConfig.Statsd.Validate()
+			if err != nil {
+				p.getLogger().Warn(fmt.Sprintf("ignoring statsd telemetry due to the following validation error: %s", err))
+			} else {
+				p.TelemetryConfig.Statsd.logger = p.logger
+				telemetryProviders = append(telemetryProviders, p.TelemetryConfig.Statsd)
+				p.getLogger().Debug("statsd telemetry provider enabled")
+			}
+		} else {
+			p.getLogger().Debug("statsd telemetry configuration not present")
+		}
+
+		if p.TelemetryConfig.OTLP != nil {
+			err := p.TelemetryConfig.OTLP.Validate()
+			if err != nil {
+				p.getLogger().Warn(fmt.Sprintf("ignoring otlp telemetry due to the following validation error: %s", err))
+			} else {
+				p.TelemetryConfig.OTLP.userAgentSuffix = p.getUserAgentSuffix(providerName)
+				p.TelemetryConfig.OTLP.logger = p.logger
+				telemetryProviders = append(telemetryProviders, p.TelemetryConfig.OTLP)
+				p.getLogger().Debug("otlp telemetry provider enabled")
 			}
 		} else {
-			log.Printf("[DEBUG] http endpoint telemetry configuration not present")
+			p.getLogger().Debug("otlp telemetry configuration not present")
+		}
+
+		if p.TelemetryConfig.PrometheusPush != nil {
+			err := p.TelemetryConfig.PrometheusPush.Validate()
+			if err != nil {
+				p.getLogger().Warn(fmt.Sprintf("ignoring prometheus push telemetry due to the following validation error: %s", err))
+			} else {
+				p.TelemetryConfig.PrometheusPush.userAgentSuffix = p.getUserAgentSuffix(providerName)
+				p.TelemetryConfig.PrometheusPush.logger = p.logger
+				telemetryProviders = append(telemetryProviders, p.TelemetryConfig.PrometheusPush)
+				p.getLogger().Debug("prometheus push telemetry provider enabled")
+			}
+		} else {
+			p.getLogger().Debug("prometheus push telemetry configuration not present")
 		}
 	}
 
 	if len(telemetryProviders) == 0 {
-		log.Printf("[DEBUG] telemetry not configured")
+		p.getLogger().Debug("telemetry not configured")
 		return nil
 	}
 
-	return telemetryHandlerTimeoutSupport{
+	telemetryHandler := telemetryHandlerTimeoutSupport{
 		timeout:            telemetryTimeout,
 		providerName:       providerName,
 		openAPIVersion:     version.Version,
 		telemetryProviders: telemetryProviders,
+		logger:             p.logger,
+		terraformVersion:   &atomic.Value{},
+	}
+
+	if retryConfig := p.TelemetryConfig.Retry; retryConfig != nil {
+		if err := retryConfig.Validate(); err != nil {
+			p.getLogger().Warn(fmt.Sprintf("ignoring telemetry retry configuration due to the following validation error, failed metrics will not be retried: %s", err))
+		} else {
+			p.getLogger().Debug("telemetry retries enabled")
+			telemetryHandler.maxRetries = retryConfig.getMaxRetries()
+			telemetryHandler.retryBackoff = retryConfig.getBackoff()
+		}
+	}
+
+	if circuitBreakerConfig := p.TelemetryConfig.CircuitBreaker; circuitBreakerConfig != nil {
+		if err := circuitBreakerConfig.Validate(); err != nil {
+			p.getLogger().Warn(fmt.Sprintf("ignoring telemetry circuit_breaker configuration due to the following validation error: %s", err))
+		} else {
+			p.getLogger().Debug("telemetry circuit breaker enabled")
+			for range telemetryProviders {
+				telemetryHandler.circuitBreakers = append(telemetryHandler.circuitBreakers, newTelemetryCircuitBreaker(circuitBreakerConfig.getFailureThreshold()))
+			}
+		}
+	}
+
+	if asyncConfig := p.TelemetryConfig.Async; asyncConfig != nil {
+		if err := asyncConfig.Validate(); err != nil {
+			p.getLogger().Warn(fmt.Sprintf("ignoring telemetry async configuration due to the following validation error, metrics will be submitted synchronously: %s", err))
+			return telemetryHandler
+		}
+		p.getLogger().Debug("telemetry async submission enabled")
+		return newTelemetryHandlerAsync(telemetryHandler, asyncConfig.getBufferSize(), asyncConfig.getBatchSize(), asyncConfig.getFlushInterval(), asyncConfig.getFlushTimeout())
 	}
+	return telemetryHandler
 }
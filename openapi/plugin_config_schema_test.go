@@ -6,6 +6,8 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -385,6 +387,149 @@ func TestGetTelemetryHandler(t *testing.T) {
 			expectedType:    nil,
 			expectedLogging: []string{"[WARN] ignoring http endpoint telemetry due to the following validation error: http endpoint telemetry configuration is missing a value for the 'url property'"},
 		},
+		{
+			name: "handler is configured correctly with a statsd provider",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					Statsd: &TelemetryProviderStatsd{
+						Address: "localhost:8125",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{"[DEBUG] statsd telemetry provider enabled"},
+		},
+		{
+			name: "handler skips statsd telemetry due to the validation not passing",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					Statsd: &TelemetryProviderStatsd{
+						Address: "localhost", // Configuration is missing the port
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring statsd telemetry due to the following validation error: statsd telemetry configuration 'address' property value 'localhost' is not valid: address localhost: missing port in address"},
+		},
+		{
+			name: "handler is configured correctly with an otlp provider",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					OTLP: &TelemetryProviderOTLP{
+						Endpoint: "http://localhost:4318",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{"[DEBUG] otlp telemetry provider enabled"},
+		},
+		{
+			name: "handler skips otlp telemetry due to the validation not passing",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					OTLP: &TelemetryProviderOTLP{
+						Endpoint: "", // Configuration is missing the required endpoint
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring otlp telemetry due to the following validation error: otlp telemetry configuration is missing a value for the 'endpoint' property"},
+		},
+		{
+			name: "handler is configured correctly with a prometheus push provider",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					PrometheusPush: &TelemetryProviderPrometheusPush{
+						URL: "http://pushgateway.myhost.com:9091",
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{"[DEBUG] prometheus push telemetry provider enabled"},
+		},
+		{
+			name: "handler skips prometheus push telemetry due to the validation not passing",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					PrometheusPush: &TelemetryProviderPrometheusPush{
+						URL: "", // Configuration is missing the required url
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    nil,
+			expectedLogging: []string{"[WARN] ignoring prometheus push telemetry due to the following validation error: prometheus push telemetry configuration is missing a value for the 'url' property"},
+		},
+		{
+			name: "handler is configured to submit the metrics asynchronously",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+						URL: "http://telemetry.myhost.com/v1/metrics",
+					},
+					Async: &TelemetryAsyncConfig{
+						BufferSize: 10,
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    &telemetryHandlerAsync{},
+			expectedLogging: []string{"[DEBUG] http endpoint telemetry provider enabled", "[DEBUG] telemetry async submission enabled"},
+		},
+		{
+			name: "handler falls back to submit the metrics synchronously due to the async validation not passing",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+						URL: "http://telemetry.myhost.com/v1/metrics",
+					},
+					Async: &TelemetryAsyncConfig{
+						BufferSize: -1,
+					},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{"[WARN] ignoring telemetry async configuration due to the following validation error, metrics will be submitted synchronously: telemetry async buffer_size '-1' is not valid, the value must be a positive number"},
+		},
+		{
+			name: "handler is configured with retries and circuit breaker",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+						URL: "http://telemetry.myhost.com/v1/metrics",
+					},
+					Retry:          &TelemetryRetryConfig{MaxRetries: 3},
+					CircuitBreaker: &TelemetryCircuitBreakerConfig{},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{"[DEBUG] telemetry retries enabled", "[DEBUG] telemetry circuit breaker enabled"},
+		},
+		{
+			name: "handler ignores the retry and circuit breaker configuration due to the validation not passing",
+			pluginConfigSchemaV1: PluginConfigSchemaV1{
+				TelemetryConfig: &TelemetryConfig{
+					HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+						URL: "http://telemetry.myhost.com/v1/metrics",
+					},
+					Retry:          &TelemetryRetryConfig{MaxRetries: -1},
+					CircuitBreaker: &TelemetryCircuitBreakerConfig{FailureThreshold: -1},
+				},
+			},
+			inputPluginName: "pluginName",
+			expectedType:    telemetryHandlerTimeoutSupport{},
+			expectedLogging: []string{
+				"[WARN] ignoring telemetry retry configuration due to the following validation error, failed metrics will not be retried: telemetry retry max_retries '-1' is not valid, the value must be a positive number",
+				"[WARN] ignoring telemetry circuit_breaker configuration due to the following validation error: telemetry circuit_breaker failure_threshold '-1' is not valid, the value must be a positive number",
+			},
+		},
 		{
 			name: "TelemetryConfig is nil",
 			pluginConfigSchemaV1: PluginConfigSchemaV1{
@@ -405,3 +550,130 @@ func TestGetTelemetryHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestGetTelemetryHandlerWithUserAgentSuffix(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		userAgentSuffix         string
+		expectedUserAgentSuffix string
+	}{
+		{
+			name:                    "http endpoint provider is configured with the service user agent suffix",
+			userAgentSuffix:         "acme-cli/1.2",
+			expectedUserAgentSuffix: "acme-cli/1.2",
+		},
+		{
+			name:                    "http endpoint provider ignores the service user agent suffix if it contains control characters",
+			userAgentSuffix:         "acme-cli/1.2\n",
+			expectedUserAgentSuffix: "",
+		},
+	}
+	for _, tc := range testCases {
+		pluginConfigSchemaV1 := PluginConfigSchemaV1{
+			Services: map[string]*ServiceConfigV1{
+				"pluginName": {
+					SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+					UserAgentSuffix: tc.userAgentSuffix,
+				},
+			},
+			TelemetryConfig: &TelemetryConfig{
+				HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+					URL: "http://telemetry.myhost.com/v1/metrics",
+				},
+			},
+		}
+		telemetryHandler := pluginConfigSchemaV1.GetTelemetryHandler("pluginName")
+		assert.IsType(t, telemetryHandlerTimeoutSupport{}, telemetryHandler, tc.name)
+		httpEndpoint := telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProviders[0].(*TelemetryProviderHTTPEndpoint)
+		assert.Equal(t, tc.expectedUserAgentSuffix, httpEndpoint.userAgentSuffix, tc.name)
+	}
+}
+
+func TestGetTelemetryHandlerWithLogger(t *testing.T) {
+	logger := &loggerStub{}
+	pluginConfigSchemaV1 := PluginConfigSchemaV1{
+		TelemetryConfig: &TelemetryConfig{
+			Graphite: &TelemetryProviderGraphite{
+				Host: "telemetry.myhost.com",
+				Port: 8125,
+			},
+			HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+				URL: "http://telemetry.myhost.com/v1/metrics",
+			},
+		},
+		logger: logger,
+	}
+	telemetryHandler := pluginConfigSchemaV1.GetTelemetryHandler("pluginName")
+	assert.IsType(t, telemetryHandlerTimeoutSupport{}, telemetryHandler)
+	telemetryHandlerTimeoutSupport := telemetryHandler.(telemetryHandlerTimeoutSupport)
+	assert.Equal(t, logger, telemetryHandlerTimeoutSupport.logger)
+	assert.Equal(t, logger, telemetryHandlerTimeoutSupport.telemetryProviders[0].(*TelemetryProviderGraphite).logger)
+	assert.Equal(t, logger, telemetryHandlerTimeoutSupport.telemetryProviders[1].(*TelemetryProviderHTTPEndpoint).logger)
+	assert.True(t, logger.containsMessage("DEBUG", "graphite telemetry provider enabled"))
+	assert.True(t, logger.containsMessage("DEBUG", "http endpoint telemetry provider enabled"))
+}
+
+func TestGetTelemetryHandlerWithValidateConnectivity(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodHead, req.Method)
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+	unreachableAPI := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	unreachableURL := fmt.Sprintf("%s/v1/metrics", unreachableAPI.URL)
+	unreachableAPI.Close()
+
+	testCases := []struct {
+		name                 string
+		url                  string
+		validateConnectivity bool
+		expectWarning        bool
+	}{
+		{
+			name:                 "connectivity is validated and the endpoint is reachable",
+			url:                  fmt.Sprintf("%s/v1/metrics", api.URL),
+			validateConnectivity: true,
+			expectWarning:        false,
+		},
+		{
+			name:                 "connectivity is validated and the endpoint is not reachable",
+			url:                  unreachableURL,
+			validateConnectivity: true,
+			expectWarning:        true,
+		},
+		{
+			name:                 "connectivity is not validated and the endpoint is not reachable",
+			url:                  unreachableURL,
+			validateConnectivity: false,
+			expectWarning:        false,
+		},
+	}
+	for _, tc := range testCases {
+		logger := &loggerStub{}
+		pluginConfigSchemaV1 := PluginConfigSchemaV1{
+			TelemetryConfig: &TelemetryConfig{
+				HTTPEndpoint: &TelemetryProviderHTTPEndpoint{
+					URL:                  tc.url,
+					ValidateConnectivity: tc.validateConnectivity,
+				},
+			},
+			logger: logger,
+		}
+		telemetryHandler := pluginConfigSchemaV1.GetTelemetryHandler("pluginName")
+		// connectivity failures must never disable the telemetry
+		assert.IsType(t, telemetryHandlerTimeoutSupport{}, telemetryHandler, tc.name)
+		assert.Len(t, telemetryHandler.(telemetryHandlerTimeoutSupport).telemetryProviders, 1, tc.name)
+		var warnings []string
+		for _, m := range logger.messages {
+			if m.level == "WARN" {
+				warnings = append(warnings, m.msg)
+			}
+		}
+		if tc.expectWarning {
+			assert.Len(t, warnings, 1, tc.name)
+			assert.Contains(t, warnings[0], fmt.Sprintf("http endpoint telemetry connectivity check failed, metrics might not be submitted: request HEAD %s failed", unreachableURL), tc.name)
+		} else {
+			assert.Empty(t, warnings, tc.name)
+		}
+	}
+}
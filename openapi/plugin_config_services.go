@@ -1,9 +1,14 @@
 package openapi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"github.com/asaskevich/govalidator"
 	"os"
+	"path"
+	"time"
+	"unicode"
 )
 
 // ServiceConfiguration defines the interface/expected behaviour for ServiceConfiguration implementations.
@@ -12,11 +17,54 @@ type ServiceConfiguration interface {
 	GetSwaggerURL() string
 	// GetSPluginVersion returns the OpenAPI Plugin version
 	GetPluginVersion() string
+	// GetSwaggerSHA256 returns the SHA-256 checksum (hex encoded) the swagger document must match, empty if the document
+	// should not be verified
+	GetSwaggerSHA256() string
+	// GetAdditionalSwaggerDocuments returns the swagger documents merged into the provider along with the one exposed at
+	// the swagger URL, empty if the provider is built from a single document
+	GetAdditionalSwaggerDocuments() []SwaggerDocumentConfiguration
 	// IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
 	// otherwise
 	IsInsecureSkipVerifyEnabled() bool
 	// GetSchemaPropertyConfiguration returns the schema configuration for the given schemaPropertyName
 	GetSchemaPropertyConfiguration(schemaPropertyName string) ServiceSchemaPropertyConfiguration
+	// GetUserAgentSuffix returns the suffix that should be appended to the provider's default user agent
+	GetUserAgentSuffix() string
+	// GetHTTPTransportConfiguration returns the connection pooling settings to be used in the HTTP transport of the CRUD API requests
+	GetHTTPTransportConfiguration() HTTPTransportConfiguration
+	// GetClientTLSConfiguration returns the client certificate and CA bundle settings used in the HTTP requests (including
+	// the request fetching the swagger file)
+	GetClientTLSConfiguration() ClientTLSConfiguration
+	// GetAllowedResources returns the names (or glob patterns) of the resources that should be registered in the provider.
+	// If empty, all the terraform compliant resources will be registered
+	GetAllowedResources() []string
+	// GetExcludedResources returns the names (or glob patterns) of the resources that should not be registered in the
+	// provider, even if they are allowed
+	GetExcludedResources() []string
+	// IsGzipCompressionEnabled returns true if the request bodies should be compressed with gzip and gzip responses
+	// accepted in the CRUD API requests; false otherwise
+	IsGzipCompressionEnabled() bool
+	// GetRetryConfiguration returns the retry policy applied to the CRUD API requests, nil if the requests should not be
+	// retried
+	GetRetryConfiguration() *RetryConfiguration
+	// GetRateLimitConfiguration returns the rate limit applied to the API requests performed by a provider instance, nil
+	// if the requests should not be rate limited
+	GetRateLimitConfiguration() *RateLimitConfiguration
+	// GetPollingConfiguration returns the settings used when polling the resources and the asynchronous operations, nil
+	// if the default settings should be used
+	GetPollingConfiguration() *PollingConfiguration
+	// GetSpecCacheConfiguration returns the settings of the on-disk cache of the OpenAPI document, nil if the document
+	// should not be cached
+	GetSpecCacheConfiguration() *SpecCacheConfiguration
+	// GetSwaggerRequestConfiguration returns the authentication settings used when retrieving the swagger document, nil
+	// if the document should be retrieved without authentication
+	GetSwaggerRequestConfiguration() *SwaggerRequestConfiguration
+	// IsProtocolV6Enabled returns true if the provider should be served with the plugin protocol version 6, muxing the SDK
+	// provider with the plugin framework provider; false otherwise
+	IsProtocolV6Enabled() bool
+	// IsGetOnlyDataSourcesEnabled returns true if the GET-only endpoints (resource instance paths which root path does not
+	// expose a POST operation) should be exposed as data source instances; false otherwise
+	IsGetOnlyDataSourcesEnabled() bool
 	// Validate makes sure the configuration is valid
 	Validate(runningPluginVersion string) error
 }
@@ -25,11 +73,73 @@ type ServiceConfiguration interface {
 type ServiceConfigV1 struct {
 	// SwaggerURL defines where the swagger is located
 	SwaggerURL string `yaml:"swagger-url"`
+	// SwaggerSHA256 defines the SHA-256 checksum (hex encoded) of the swagger document. If set, the provider refuses to
+	// run if the swagger document retrieved does not match it
+	SwaggerSHA256 string `yaml:"swagger_sha256,omitempty"`
+	// AdditionalSwaggerDocuments defines the swagger documents (e,g: exposed by other microservices of the platform) whose
+	// resources and data sources are merged into the provider along with the ones from the SwaggerURL document
+	AdditionalSwaggerDocuments []SwaggerDocumentConfiguration `yaml:"additional_swagger_documents,omitempty"`
 	// PluginVersion defines the version of the OpenAPI Terraform plugin installed when generating the plugin configuration
 	PluginVersion string `yaml:"plugin_version,omitempty"`
 	// InsecureSkipVerify defines whether the internal http client used to fetch the swagger file should verify the server cert
 	// or not. This should only be used purposefully if the server is using a self-signed cert and only if the server is trusted
 	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+	// UserAgentSuffix defines a value that will be appended to the default user agent sent in all the API requests
+	// performed by the provider (including telemetry requests) so the tooling can be identified by the APIs
+	UserAgentSuffix string `yaml:"user_agent_suffix,omitempty"`
+	// MaxIdleConns defines the maximum number of idle (keep-alive) connections across all hosts kept by the HTTP transport
+	// used in the CRUD API requests. If not set, the default transport value is used (100)
+	MaxIdleConns int `yaml:"max_idle_conns,omitempty"`
+	// MaxIdleConnsPerHost defines the maximum number of idle (keep-alive) connections to keep per-host by the HTTP transport
+	// used in the CRUD API requests. If not set, the default transport value is used (2)
+	MaxIdleConnsPerHost int `yaml:"max_idle_conns_per_host,omitempty"`
+	// IdleConnTimeout defines the maximum amount of time an idle (keep-alive) connection will remain idle before closing
+	// itself (e,g: 90s). If not set, the default transport value is used (90s)
+	IdleConnTimeout string `yaml:"idle_conn_timeout,omitempty"`
+	// ClientCertificateFile defines the path to the PEM encoded client certificate presented in the HTTP requests (including
+	// the request fetching the swagger file) to the servers requiring mutual TLS. Requires the ClientKeyFile
+	ClientCertificateFile string `yaml:"client_certificate_file,omitempty"`
+	// ClientKeyFile defines the path to the PEM encoded private key of the client certificate. Requires the ClientCertificateFile
+	ClientKeyFile string `yaml:"client_key_file,omitempty"`
+	// CABundleFile defines the path to the PEM encoded CA certificates trusted (in addition to the system CAs) to verify
+	// the server certificates in the HTTP requests (including the request fetching the swagger file)
+	CABundleFile string `yaml:"ca_bundle_file,omitempty"`
+	// AllowedResources defines the names of the resources (e,g: cdn_v1) that should be exposed by the provider. The names
+	// can also be glob patterns (e,g: cdn_*). Resources not matching (and their data sources) will not be registered. If
+	// not set, all the terraform compliant resources are exposed
+	AllowedResources []string `yaml:"allowed_resources,omitempty"`
+	// ExcludedResources defines the names (or glob patterns) of the resources that should not be exposed by the provider,
+	// it takes preference over AllowedResources
+	ExcludedResources []string `yaml:"excluded_resources,omitempty"`
+	// GzipCompression defines whether the request bodies of the CRUD API requests should be compressed with gzip (sending
+	// the Content-Encoding: gzip header) and gzip responses should be accepted (sending the Accept-Encoding: gzip header)
+	GzipCompression bool `yaml:"gzip_compression,omitempty"`
+	// Retry defines the retry policy applied to the CRUD API requests that return a retryable status code (e,g: 429 or
+	// 503). If not set, the requests are not retried unless the operations enable it with the 'x-terraform-resource-retry'
+	// extension
+	Retry *RetryConfiguration `yaml:"retry,omitempty"`
+	// RateLimit defines the max rate of the API requests performed by a provider instance across all the resource
+	// operations. If not set, the requests are not rate limited
+	RateLimit *RateLimitConfiguration `yaml:"rate_limit,omitempty"`
+	// Polling defines the settings used when polling the resources and the asynchronous operations (e,g: the interval
+	// between polls). If not set, the default settings are used
+	Polling *PollingConfiguration `yaml:"polling,omitempty"`
+	// SpecCache defines the settings of the on-disk cache of the OpenAPI document (only applies to documents retrieved
+	// over HTTP). If not set, the document is retrieved on every terraform invocation
+	SpecCache *SpecCacheConfiguration `yaml:"spec_cache,omitempty"`
+	// SwaggerRequest defines the authentication settings (headers, bearer token, basic auth and TLS client certificate)
+	// used when retrieving the swagger document served over HTTP, separate from the API credentials. If not set, the
+	// document is retrieved without authentication
+	SwaggerRequest *SwaggerRequestConfiguration `yaml:"swagger_request,omitempty"`
+	// ProtocolV6 defines whether the provider should be served with the plugin protocol version 6 (requires Terraform
+	// v1.0 or later). The resources marked with the 'x-terraform-resource-protocol-v6' extension are then served by the
+	// plugin framework, exposing the objects as nested attributes, while the rest of resources and data sources remain
+	// served by the SDK. If not set, the provider is served with the plugin protocol version 5
+	ProtocolV6 bool `yaml:"protocol_v6,omitempty"`
+	// GetOnlyDataSources defines whether the GET-only endpoints (e,g: /v1/regions/{id} where /v1/regions does not expose
+	// a POST operation) should be exposed as data source instances so existing API objects can be looked up by id. If
+	// not set, the GET-only endpoints are only exposed as list data sources (when their root path returns an array)
+	GetOnlyDataSources bool `yaml:"get_only_data_sources,omitempty"`
 	// SchemaConfigurationV1 represents the list of schema property configurations
 	SchemaConfigurationV1 []ServiceSchemaPropertyConfigurationV1 `yaml:"schema_configuration"`
 }
@@ -53,12 +163,102 @@ func (s *ServiceConfigV1) GetPluginVersion() string {
 	return s.PluginVersion
 }
 
+// GetSwaggerSHA256 returns the SHA-256 checksum the swagger document must match
+func (s *ServiceConfigV1) GetSwaggerSHA256() string {
+	return s.SwaggerSHA256
+}
+
+// GetAdditionalSwaggerDocuments returns the swagger documents merged into the provider along with the SwaggerURL one
+func (s *ServiceConfigV1) GetAdditionalSwaggerDocuments() []SwaggerDocumentConfiguration {
+	return s.AdditionalSwaggerDocuments
+}
+
 // IsInsecureSkipVerifyEnabled returns true if the given provider's service configuration has InsecureSkipVerify enabled; false
 // otherwise
 func (s *ServiceConfigV1) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetUserAgentSuffix returns the suffix that should be appended to the provider's default user agent
+func (s *ServiceConfigV1) GetUserAgentSuffix() string {
+	return s.UserAgentSuffix
+}
+
+// GetHTTPTransportConfiguration returns the connection pooling settings to be used in the HTTP transport of the CRUD API
+// requests. The idle connection timeout is expected to have been validated already; if not valid, the default will be used
+func (s *ServiceConfigV1) GetHTTPTransportConfiguration() HTTPTransportConfiguration {
+	idleConnTimeout, _ := time.ParseDuration(s.IdleConnTimeout)
+	return HTTPTransportConfiguration{
+		MaxIdleConns:        s.MaxIdleConns,
+		MaxIdleConnsPerHost: s.MaxIdleConnsPerHost,
+		IdleConnTimeout:     idleConnTimeout,
+	}
+}
+
+// GetClientTLSConfiguration returns the client certificate and CA bundle settings used in the HTTP requests. The files are
+// expected to have been validated already
+func (s *ServiceConfigV1) GetClientTLSConfiguration() ClientTLSConfiguration {
+	return ClientTLSConfiguration{
+		ClientCertificateFile: s.ClientCertificateFile,
+		ClientKeyFile:         s.ClientKeyFile,
+		CABundleFile:          s.CABundleFile,
+	}
+}
+
+// GetAllowedResources returns the names of the resources that should be registered in the provider
+func (s *ServiceConfigV1) GetAllowedResources() []string {
+	return s.AllowedResources
+}
+
+// GetExcludedResources returns the names of the resources that should not be registered in the provider
+func (s *ServiceConfigV1) GetExcludedResources() []string {
+	return s.ExcludedResources
+}
+
+// IsGzipCompressionEnabled returns true if the given provider's service configuration has GzipCompression enabled; false
+// otherwise
+func (s *ServiceConfigV1) IsGzipCompressionEnabled() bool {
+	return s.GzipCompression
+}
+
+// GetRetryConfiguration returns the retry policy applied to the CRUD API requests, nil if not configured
+func (s *ServiceConfigV1) GetRetryConfiguration() *RetryConfiguration {
+	return s.Retry
+}
+
+// GetRateLimitConfiguration returns the rate limit applied to the API requests, nil if not configured
+func (s *ServiceConfigV1) GetRateLimitConfiguration() *RateLimitConfiguration {
+	return s.RateLimit
+}
+
+// GetPollingConfiguration returns the settings used when polling the resources and the asynchronous operations, nil if
+// not configured
+func (s *ServiceConfigV1) GetPollingConfiguration() *PollingConfiguration {
+	return s.Polling
+}
+
+// GetSpecCacheConfiguration returns the settings of the on-disk cache of the OpenAPI document, nil if not configured
+func (s *ServiceConfigV1) GetSpecCacheConfiguration() *SpecCacheConfiguration {
+	return s.SpecCache
+}
+
+// GetSwaggerRequestConfiguration returns the authentication settings used when retrieving the swagger document, nil if
+// not configured
+func (s *ServiceConfigV1) GetSwaggerRequestConfiguration() *SwaggerRequestConfiguration {
+	return s.SwaggerRequest
+}
+
+// IsProtocolV6Enabled returns true if the given provider's service configuration has ProtocolV6 enabled; false otherwise
+func (s *ServiceConfigV1) IsProtocolV6Enabled() bool {
+	return s.ProtocolV6
+}
+
+// IsGetOnlyDataSourcesEnabled returns true if the given provider's service configuration has GetOnlyDataSources enabled;
+// false otherwise
+func (s *ServiceConfigV1) IsGetOnlyDataSourcesEnabled() bool {
+	return s.GetOnlyDataSources
+}
+
 // GetSchemaPropertyConfiguration returns the external configuration for the given schema property name; nil is returned
 // if no such property exists
 func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName string) ServiceSchemaPropertyConfiguration {
@@ -72,11 +272,22 @@ func (s *ServiceConfigV1) GetSchemaPropertyConfiguration(schemaPropertyName stri
 
 // Validate makes sure the configuration is valid:
 // - if the user has specified an OpenAPI plugin version, and if the plugin does not match the version then something is off
+// - if the user has specified a swagger SHA-256 checksum, the value must be a hex encoded SHA-256 checksum
+// - if the user has specified additional swagger documents, the URLs must be valid and unique and the resource name prefixes valid
+// - if the user has specified a user agent suffix, the value must not contain control characters
+// - if the user has specified allowed or excluded resources, the values must be valid glob patterns
+// - if the user has specified connection pooling settings, the values must be positive (and idle_conn_timeout a valid duration)
+// - if the user has specified client certificate or CA bundle settings, the files must contain valid PEM encoded certificates (and key)
+// - if the user has specified a retry policy, max_retries must be positive, backoff and max_backoff valid durations and status_codes valid HTTP status codes
+// - if the user has specified a rate limit, requests_per_second must be greater than zero and burst positive
+// - if the user has specified polling settings, interval, min_timeout and delay must be valid durations
+// - if the user has specified spec cache settings, ttl must be a valid duration
+// - if the user has specified swagger request settings, the environment variables referenced must be set and the TLS files valid
+// - if the user has specified token command settings in the schema configuration, the values must be positive (and token_ttl a valid duration)
 func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
-	if !govalidator.IsURL(s.SwaggerURL) {
-		// fall back to try to load the swagger file from disk in case the path provided is a path to a file on disk
-		if _, err := os.Stat(s.SwaggerURL); os.IsNotExist(err) {
-			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk", s.SwaggerURL)
+	if s.SwaggerURL != openAPIDocumentStdin {
+		if err := validateSwaggerURL(s.SwaggerURL); err != nil {
+			return err
 		}
 	}
 	if s.PluginVersion != "" {
@@ -84,6 +295,98 @@ func (s *ServiceConfigV1) Validate(runningPluginVersion string) error {
 			return fmt.Errorf("plugin version '%s' in the plugin configuration file does not match the version of the OpenAPI plugin that is running '%s'", s.PluginVersion, runningPluginVersion)
 		}
 	}
+	if err := validateSwaggerSHA256(s.SwaggerSHA256); err != nil {
+		return err
+	}
+	if err := validateAdditionalSwaggerDocuments(s.SwaggerURL, s.AdditionalSwaggerDocuments); err != nil {
+		return err
+	}
+	if err := validateUserAgentSuffix(s.UserAgentSuffix); err != nil {
+		return err
+	}
+	if err := validateResourceNamePatterns("allowed_resources", s.AllowedResources); err != nil {
+		return err
+	}
+	if err := validateResourceNamePatterns("excluded_resources", s.ExcludedResources); err != nil {
+		return err
+	}
+	if err := validateHTTPTransportSettings(s.MaxIdleConns, s.MaxIdleConnsPerHost, s.IdleConnTimeout); err != nil {
+		return err
+	}
+	if err := validateClientTLSSettings(s.ClientCertificateFile, s.ClientKeyFile, s.CABundleFile); err != nil {
+		return err
+	}
+	if s.Retry != nil {
+		if err := s.Retry.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.RateLimit != nil {
+		if err := s.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.Polling != nil {
+		if err := s.Polling.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.SpecCache != nil {
+		if err := s.SpecCache.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.SwaggerRequest != nil {
+		if err := s.SwaggerRequest.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, schemaPropertyConfig := range s.SchemaConfigurationV1 {
+		if err := schemaPropertyConfig.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateResourceNamePatterns checks that the resource names configured are valid glob patterns
+func validateSwaggerURL(swaggerURL string) error {
+	if !govalidator.IsURL(swaggerURL) {
+		// fall back to try to load the swagger file from disk in case the path provided is a path (or file URL) to a file on disk
+		if _, err := os.Stat(getOpenAPIDocumentPath(swaggerURL)); os.IsNotExist(err) {
+			return fmt.Errorf("service swagger URL configuration not valid ('%s'). URL must be either a valid formed URL, a path to an existing swagger file stored in the disk or '-' to read the swagger file from the standard input", swaggerURL)
+		}
+	}
+	return nil
+}
+
+func validateSwaggerSHA256(swaggerSHA256 string) error {
+	if swaggerSHA256 == "" {
+		return nil
+	}
+	if checksum, err := hex.DecodeString(swaggerSHA256); err != nil || len(checksum) != sha256.Size {
+		return fmt.Errorf("swagger_sha256 '%s' is not valid, the value must be a hex encoded SHA-256 checksum (64 characters)", swaggerSHA256)
+	}
+	return nil
+}
 
+func validateResourceNamePatterns(configurationName string, resourceNamePatterns []string) error {
+	for _, resourceNamePattern := range resourceNamePatterns {
+		if _, err := path.Match(resourceNamePattern, ""); err != nil {
+			return fmt.Errorf("%s contains a not valid pattern '%s': %s", configurationName, resourceNamePattern, err)
+		}
+	}
+	return nil
+}
+
+// validateUserAgentSuffix checks that the user agent suffix does not contain control characters (e,g: new lines) which
+// are not allowed in header values
+func validateUserAgentSuffix(userAgentSuffix string) error {
+	for _, c := range userAgentSuffix {
+		if unicode.IsControl(c) {
+			return fmt.Errorf("user_agent_suffix %q contains control characters which are not allowed", userAgentSuffix)
+		}
+	}
 	return nil
 }
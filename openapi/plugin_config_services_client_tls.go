@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+)
+
+// ClientTLSConfiguration defines the files containing the PEM encoded client certificate and key used for mutual TLS as
+// well as the CA bundle used to verify the server certificates. Empty values mean that the settings are not configured.
+type ClientTLSConfiguration struct {
+	// ClientCertificateFile is the path to the file containing the PEM encoded client certificate
+	ClientCertificateFile string
+	// ClientKeyFile is the path to the file containing the PEM encoded private key of the client certificate
+	ClientKeyFile string
+	// CABundleFile is the path to the file containing the PEM encoded CA certificates trusted in addition to the system CAs
+	CABundleFile string
+}
+
+// isDefault returns true if none of the TLS settings have been configured
+func (c ClientTLSConfiguration) isDefault() bool {
+	return c == ClientTLSConfiguration{}
+}
+
+// load reads the files configured and returns the resulting client TLS configuration. If none of the settings are
+// configured nil is returned
+func (c ClientTLSConfiguration) load() (*clientTLSConfiguration, error) {
+	if c.isDefault() {
+		return nil, nil
+	}
+	readFile := func(name, path string) ([]byte, error) {
+		if path == "" {
+			return nil, nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the %s '%s': %s", name, path, err)
+		}
+		return content, nil
+	}
+	configuration := &clientTLSConfiguration{source: "plugin configuration tls settings"}
+	var err error
+	if configuration.ClientCertificate, err = readFile("client_certificate_file", c.ClientCertificateFile); err != nil {
+		return nil, err
+	}
+	if configuration.ClientKey, err = readFile("client_key_file", c.ClientKeyFile); err != nil {
+		return nil, err
+	}
+	if configuration.CABundle, err = readFile("ca_bundle_file", c.CABundleFile); err != nil {
+		return nil, err
+	}
+	if err := configuration.validate(); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+// newTLSConfig returns a copy of the tls config provided with the client certificate and the CA bundle configured
+func (c ClientTLSConfiguration) newTLSConfig(tlsConfig *tls.Config) (*tls.Config, error) {
+	configuration, err := c.load()
+	if err != nil || configuration == nil {
+		return tlsConfig, err
+	}
+	return configuration.apply(tlsConfig)
+}
+
+// validateClientTLSSettings checks that the TLS settings provided in the plugin configuration point to valid PEM encoded files
+func validateClientTLSSettings(clientCertificateFile, clientKeyFile, caBundleFile string) error {
+	_, err := ClientTLSConfiguration{ClientCertificateFile: clientCertificateFile, ClientKeyFile: clientKeyFile, CABundleFile: caBundleFile}.load()
+	return err
+}
@@ -0,0 +1,79 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientTLSConfigurationLoad(t *testing.T) {
+	certificate, key := newTestCertificatePEM(t)
+	dir, err := ioutil.TempDir("", "client_tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certificateFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, ioutil.WriteFile(certificateFile, certificate, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, key, 0600))
+
+	testCases := []struct {
+		name                  string
+		clientTLS             ClientTLSConfiguration
+		expectedConfiguration *clientTLSConfiguration
+		expectedError         string
+	}{
+		{
+			name:                  "no settings configured",
+			clientTLS:             ClientTLSConfiguration{},
+			expectedConfiguration: nil,
+		},
+		{
+			name:                  "client certificate, key and CA bundle files configured",
+			clientTLS:             ClientTLSConfiguration{ClientCertificateFile: certificateFile, ClientKeyFile: keyFile, CABundleFile: certificateFile},
+			expectedConfiguration: &clientTLSConfiguration{ClientCertificate: certificate, ClientKey: key, CABundle: certificate, source: "plugin configuration tls settings"},
+		},
+		{
+			name:          "client certificate file configured without the key file",
+			clientTLS:     ClientTLSConfiguration{ClientCertificateFile: certificateFile},
+			expectedError: "plugin configuration tls settings must contain both the client certificate and the client key to enable mutual TLS",
+		},
+		{
+			name:          "CA bundle file that does not exist",
+			clientTLS:     ClientTLSConfiguration{CABundleFile: filepath.Join(dir, "ca.pem")},
+			expectedError: "failed to read the ca_bundle_file '" + filepath.Join(dir, "ca.pem") + "'",
+		},
+		{
+			name:          "CA bundle file not containing certificates",
+			clientTLS:     ClientTLSConfiguration{CABundleFile: keyFile},
+			expectedError: "plugin configuration tls settings CA bundle does not contain any valid PEM encoded certificate",
+		},
+	}
+	for _, tc := range testCases {
+		configuration, err := tc.clientTLS.load()
+		if tc.expectedError != "" {
+			assert.Error(t, err, tc.name)
+			assert.Contains(t, err.Error(), tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedConfiguration, configuration, tc.name)
+	}
+
+	t.Run("newTLSConfig returns the tls config provided when no settings are configured", func(t *testing.T) {
+		original := &tls.Config{InsecureSkipVerify: true}
+		tlsConfig, err := ClientTLSConfiguration{}.newTLSConfig(original)
+		assert.NoError(t, err)
+		assert.Equal(t, original, tlsConfig)
+	})
+
+	t.Run("newTLSConfig returns a tls config containing the client certificate configured", func(t *testing.T) {
+		tlsConfig, err := ClientTLSConfiguration{ClientCertificateFile: certificateFile, ClientKeyFile: keyFile}.newTLSConfig(nil)
+		assert.NoError(t, err)
+		assert.Len(t, tlsConfig.Certificates, 1)
+	})
+}
@@ -0,0 +1,99 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// HTTPTransportConfiguration defines the connection pooling settings of the HTTP transport used to perform the CRUD API
+// requests. Zero values mean that the default transport settings will be used.
+type HTTPTransportConfiguration struct {
+	// MaxIdleConns controls the maximum number of idle (keep-alive) connections across all hosts
+	MaxIdleConns int
+	// MaxIdleConnsPerHost controls the maximum idle (keep-alive) connections to keep per-host
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is the maximum amount of time an idle (keep-alive) connection will remain idle before closing itself
+	IdleConnTimeout time.Duration
+}
+
+// isDefault returns true if none of the transport settings have been configured
+func (h HTTPTransportConfiguration) isDefault() bool {
+	return h == HTTPTransportConfiguration{}
+}
+
+// newTransport returns a copy of the default transport with the configured settings applied. If none of the settings
+// are configured nil is returned so the default transport is used as is.
+func (h HTTPTransportConfiguration) newTransport() http.RoundTripper {
+	if h.isDefault() {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if h.MaxIdleConns > 0 {
+		transport.MaxIdleConns = h.MaxIdleConns
+	}
+	if h.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = h.MaxIdleConnsPerHost
+	}
+	if h.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = h.IdleConnTimeout
+	}
+	return transport
+}
+
+// newServiceHTTPTransport returns the transport used to perform the requests to the service, that is the requests
+// retrieving the OpenAPI documents and the API requests. The transport is a copy of the default transport with the
+// connection pooling settings, the insecure skip verify and the client certificate and CA bundle configured in the
+// service configuration applied, as well as the client TLS configuration provided by the user (if any) on top of them.
+// If none of the settings are configured nil is returned so the default transport is used as is.
+func newServiceHTTPTransport(serviceConfiguration ServiceConfiguration, clientTLS *clientTLSConfiguration) (*http.Transport, error) {
+	var transport *http.Transport
+	insecureSkipVerify := false
+	if serviceConfiguration != nil {
+		if configuredTransport := serviceConfiguration.GetHTTPTransportConfiguration().newTransport(); configuredTransport != nil {
+			transport = configuredTransport.(*http.Transport)
+		}
+		pluginClientTLS, err := serviceConfiguration.GetClientTLSConfiguration().load()
+		if err != nil {
+			return nil, err
+		}
+		clientTLS = pluginClientTLS.merge(clientTLS)
+		insecureSkipVerify = serviceConfiguration.IsInsecureSkipVerifyEnabled()
+	}
+	if clientTLS == nil && !insecureSkipVerify {
+		return transport, nil
+	}
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+	if clientTLS != nil {
+		var err error
+		if tlsConfig, err = clientTLS.apply(tlsConfig); err != nil {
+			return nil, err
+		}
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// validateHTTPTransportSettings checks that the connection pooling settings provided in the plugin configuration are valid
+func validateHTTPTransportSettings(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout string) error {
+	if maxIdleConns < 0 {
+		return fmt.Errorf("max_idle_conns '%d' is not valid, the value must be a positive number", maxIdleConns)
+	}
+	if maxIdleConnsPerHost < 0 {
+		return fmt.Errorf("max_idle_conns_per_host '%d' is not valid, the value must be a positive number", maxIdleConnsPerHost)
+	}
+	if idleConnTimeout != "" {
+		timeout, err := time.ParseDuration(idleConnTimeout)
+		if err != nil {
+			return fmt.Errorf("idle_conn_timeout '%s' is not valid: %s", idleConnTimeout, err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("idle_conn_timeout '%s' is not valid, the value must be a positive duration", idleConnTimeout)
+		}
+	}
+	return nil
+}
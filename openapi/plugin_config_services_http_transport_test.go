@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPTransportConfigurationNewTransport(t *testing.T) {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	testCases := []struct {
+		name                        string
+		transportConfiguration      HTTPTransportConfiguration
+		expectedNil                 bool
+		expectedMaxIdleConns        int
+		expectedMaxIdleConnsPerHost int
+		expectedIdleConnTimeout     time.Duration
+	}{
+		{
+			name:                   "no settings configured returns nil so the default transport is used",
+			transportConfiguration: HTTPTransportConfiguration{},
+			expectedNil:            true,
+		},
+		{
+			name: "all settings configured",
+			transportConfiguration: HTTPTransportConfiguration{
+				MaxIdleConns:        500,
+				MaxIdleConnsPerHost: 100,
+				IdleConnTimeout:     30 * time.Second,
+			},
+			expectedMaxIdleConns:        500,
+			expectedMaxIdleConnsPerHost: 100,
+			expectedIdleConnTimeout:     30 * time.Second,
+		},
+		{
+			name: "only some settings configured keeps the default values for the rest",
+			transportConfiguration: HTTPTransportConfiguration{
+				MaxIdleConnsPerHost: 100,
+			},
+			expectedMaxIdleConns:        defaultTransport.MaxIdleConns,
+			expectedMaxIdleConnsPerHost: 100,
+			expectedIdleConnTimeout:     defaultTransport.IdleConnTimeout,
+		},
+	}
+	for _, tc := range testCases {
+		transport := tc.transportConfiguration.newTransport()
+		if tc.expectedNil {
+			assert.Nil(t, transport, tc.name)
+			continue
+		}
+		httpTransport := transport.(*http.Transport)
+		assert.Equal(t, tc.expectedMaxIdleConns, httpTransport.MaxIdleConns, tc.name)
+		assert.Equal(t, tc.expectedMaxIdleConnsPerHost, httpTransport.MaxIdleConnsPerHost, tc.name)
+		assert.Equal(t, tc.expectedIdleConnTimeout, httpTransport.IdleConnTimeout, tc.name)
+		assert.NotEqual(t, defaultTransport, httpTransport, tc.name)
+	}
+}
+
+// newTestServerCertificate returns a self-signed server certificate valid for 127.0.0.1
+func newTestServerCertificate(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestNewServiceHTTPTransport(t *testing.T) {
+	// the provider server uses its own certificate since all the httptest servers share the same one by default
+	newTLSServer := func(certificates ...tls.Certificate) (*httptest.Server, []byte) {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`swagger: "2.0"`))
+		}))
+		if len(certificates) > 0 {
+			server.TLS = &tls.Config{Certificates: certificates}
+		}
+		server.StartTLS()
+		return server, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	}
+	pluginServer, pluginServerCertificate := newTLSServer()
+	defer pluginServer.Close()
+	providerServer, providerServerCertificate := newTLSServer(newTestServerCertificate(t))
+	defer providerServer.Close()
+	caBundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caBundleFile, pluginServerCertificate, 0600))
+	// the default transport may set up its TLS config lazily (e,g: HTTP/2 settings) so only the TLS settings are checked
+	assertDefaultTransportNotModified := func(t *testing.T) {
+		if tlsConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig; tlsConfig != nil {
+			assert.False(t, tlsConfig.InsecureSkipVerify, "the default transport should not be modified")
+			assert.Nil(t, tlsConfig.RootCAs, "the default transport should not be modified")
+		}
+	}
+
+	t.Run("no settings configured returns nil so the default transport is used", func(t *testing.T) {
+		transport, err := newServiceHTTPTransport(&ServiceConfigStub{}, nil)
+		require.NoError(t, err)
+		assert.Nil(t, transport)
+		transport, err = newServiceHTTPTransport(nil, nil)
+		require.NoError(t, err)
+		assert.Nil(t, transport)
+	})
+
+	t.Run("insecure skip verify is applied to the service transport only", func(t *testing.T) {
+		transport, err := newServiceHTTPTransport(&ServiceConfigStub{InsecureSkipVerify: true, HTTPTransport: HTTPTransportConfiguration{MaxIdleConnsPerHost: 100}}, nil)
+		require.NoError(t, err)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+		document, err := getServiceOpenAPIDocument(pluginServer.URL, &ServiceConfigStub{}, transport, nil)
+		require.NoError(t, err)
+		assert.Equal(t, `swagger: "2.0"`, string(document))
+		assertDefaultTransportNotModified(t)
+	})
+
+	t.Run("the plugin CA bundle is used to retrieve the swagger file and the API requests", func(t *testing.T) {
+		serviceConfiguration := &ServiceConfigStub{ClientTLS: ClientTLSConfiguration{CABundleFile: caBundleFile}}
+		transport, err := newServiceHTTPTransport(serviceConfiguration, nil)
+		require.NoError(t, err)
+		_, err = getServiceOpenAPIDocument(pluginServer.URL, serviceConfiguration, transport, nil)
+		assert.NoError(t, err)
+		_, err = getServiceOpenAPIDocument(providerServer.URL, serviceConfiguration, transport, nil)
+		assert.Error(t, err, "the servers not trusted by the plugin CA bundle should be rejected")
+		assertDefaultTransportNotModified(t)
+	})
+
+	t.Run("the plugin and the provider CA bundles are both trusted", func(t *testing.T) {
+		transport, err := newServiceHTTPTransport(&ServiceConfigStub{ClientTLS: ClientTLSConfiguration{CABundleFile: caBundleFile}}, &clientTLSConfiguration{CABundle: providerServerCertificate})
+		require.NoError(t, err)
+		client := &http.Client{Transport: transport}
+		for _, url := range []string{pluginServer.URL, providerServer.URL} {
+			resp, err := client.Get(url)
+			require.NoError(t, err, url)
+			resp.Body.Close()
+		}
+	})
+
+	t.Run("an error is returned if the plugin CA bundle can not be read", func(t *testing.T) {
+		_, err := newServiceHTTPTransport(&ServiceConfigStub{ClientTLS: ClientTLSConfiguration{CABundleFile: filepath.Join(t.TempDir(), "missing.pem")}}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestValidateHTTPTransportSettings(t *testing.T) {
+	testCases := []struct {
+		name                string
+		maxIdleConns        int
+		maxIdleConnsPerHost int
+		idleConnTimeout     string
+		expectedErr         error
+	}{
+		{name: "no settings configured", expectedErr: nil},
+		{name: "valid settings", maxIdleConns: 100, maxIdleConnsPerHost: 10, idleConnTimeout: "90s", expectedErr: nil},
+		{name: "negative max_idle_conns", maxIdleConns: -1, expectedErr: errors.New("max_idle_conns '-1' is not valid, the value must be a positive number")},
+		{name: "negative max_idle_conns_per_host", maxIdleConnsPerHost: -1, expectedErr: errors.New("max_idle_conns_per_host '-1' is not valid, the value must be a positive number")},
+		{name: "idle_conn_timeout not a duration", idleConnTimeout: "90", expectedErr: errors.New("idle_conn_timeout '90' is not valid: time: missing unit in duration \"90\"")},
+		{name: "negative idle_conn_timeout", idleConnTimeout: "-5s", expectedErr: errors.New("idle_conn_timeout '-5s' is not valid, the value must be a positive duration")},
+	}
+	for _, tc := range testCases {
+		err := validateHTTPTransportSettings(tc.maxIdleConns, tc.maxIdleConnsPerHost, tc.idleConnTimeout)
+		assert.Equal(t, tc.expectedErr, err, tc.name)
+	}
+}
@@ -0,0 +1,70 @@
+package openapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// PollingConfiguration contains the configuration used when polling the resources and the asynchronous operations
+// returned by the API (e,g: to poll fast APIs more often or slow APIs less aggressively). The settings can be overridden
+// per operation response with the 'x-terraform-resource-poll-interval', 'x-terraform-resource-poll-min-timeout' and
+// 'x-terraform-resource-poll-delay' extensions
+type PollingConfiguration struct {
+	// Interval defines the time to wait between polls (e,g: 5s). If not provided the default interval (5s) will be used
+	Interval string `yaml:"interval,omitempty"`
+	// MinTimeout defines the min time to wait between polls when the interval is set to 0s, in which case the time
+	// between polls doubles after each poll (e,g: 10s). If not provided the default min timeout (10s) will be used
+	MinTimeout string `yaml:"min_timeout,omitempty"`
+	// Delay defines the time to wait before polling for the first time (e,g: 1s). If not provided the default delay (1s)
+	// will be used
+	Delay string `yaml:"delay,omitempty"`
+}
+
+// Validate checks whether the polling configuration is valid
+func (p *PollingConfiguration) Validate() error {
+	if err := validatePollingDuration("interval", p.Interval); err != nil {
+		return err
+	}
+	if err := validatePollingDuration("min_timeout", p.MinTimeout); err != nil {
+		return err
+	}
+	return validatePollingDuration("delay", p.Delay)
+}
+
+func validatePollingDuration(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("polling %s '%s' is not valid: %s", name, value, err)
+	}
+	if duration < 0 {
+		return fmt.Errorf("polling %s '%s' is not valid, the value must be a positive duration", name, value)
+	}
+	return nil
+}
+
+func (p *PollingConfiguration) getInterval(defaultInterval time.Duration) time.Duration {
+	return getPollingDuration(p.Interval, defaultInterval)
+}
+
+func (p *PollingConfiguration) getMinTimeout(defaultMinTimeout time.Duration) time.Duration {
+	return getPollingDuration(p.MinTimeout, defaultMinTimeout)
+}
+
+func (p *PollingConfiguration) getDelay(defaultDelay time.Duration) time.Duration {
+	return getPollingDuration(p.Delay, defaultDelay)
+}
+
+// getPollingDuration returns the duration value provided or the default value if the value is not set or not valid
+func getPollingDuration(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return defaultValue
+	}
+	return duration
+}
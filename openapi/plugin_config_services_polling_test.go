@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollingConfigurationValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		pollingConfig PollingConfiguration
+		expectedError error
+	}{
+		{
+			name:          "polling config with default values",
+			pollingConfig: PollingConfiguration{},
+			expectedError: nil,
+		},
+		{
+			name:          "polling config with all the settings",
+			pollingConfig: PollingConfiguration{Interval: "500ms", MinTimeout: "2s", Delay: "0s"},
+			expectedError: nil,
+		},
+		{
+			name:          "polling config with wrong interval",
+			pollingConfig: PollingConfiguration{Interval: "wrong"},
+			expectedError: errors.New("polling interval 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:          "polling config with negative min timeout",
+			pollingConfig: PollingConfiguration{MinTimeout: "-1s"},
+			expectedError: errors.New("polling min_timeout '-1s' is not valid, the value must be a positive duration"),
+		},
+		{
+			name:          "polling config with wrong delay",
+			pollingConfig: PollingConfiguration{Delay: "1"},
+			expectedError: errors.New("polling delay '1' is not valid: time: missing unit in duration \"1\""),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.pollingConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestPollingConfigurationGetters(t *testing.T) {
+	pollingConfig := PollingConfiguration{}
+	assert.Equal(t, defaultPollInterval, pollingConfig.getInterval(defaultPollInterval))
+	assert.Equal(t, defaultPollMinTimeout, pollingConfig.getMinTimeout(defaultPollMinTimeout))
+	assert.Equal(t, defaultPollDelay, pollingConfig.getDelay(defaultPollDelay))
+
+	pollingConfig = PollingConfiguration{Interval: "500ms", MinTimeout: "2s", Delay: "0s"}
+	assert.Equal(t, 500*time.Millisecond, pollingConfig.getInterval(defaultPollInterval))
+	assert.Equal(t, 2*time.Second, pollingConfig.getMinTimeout(defaultPollMinTimeout))
+	assert.Equal(t, time.Duration(0), pollingConfig.getDelay(defaultPollDelay))
+}
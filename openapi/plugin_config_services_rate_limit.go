@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+)
+
+// RateLimitConfiguration contains the configuration needed to limit the rate of the API requests performed by a provider
+// instance (e,g: to avoid the API throttling the requests when Terraform manages many resources in parallel). The limit
+// is enforced with a token bucket shared across all the resource operations
+type RateLimitConfiguration struct {
+	// RequestsPerSecond defines the max number of API requests per second (e,g: 10 or 0.5). Fractional values are
+	// allowed to limit the requests to less than one per second
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst defines the max number of requests that can be performed at once above the requests per second rate. If not
+	// provided, the requests per second (rounded up) will be used
+	Burst int `yaml:"burst,omitempty"`
+}
+
+// Validate checks whether the rate limit configuration is valid
+func (r *RateLimitConfiguration) Validate() error {
+	if r.RequestsPerSecond <= 0 {
+		return fmt.Errorf("rate_limit requests_per_second '%v' is not valid, the value must be greater than zero", r.RequestsPerSecond)
+	}
+	if r.Burst < 0 {
+		return fmt.Errorf("rate_limit burst '%d' is not valid, the value must be a positive number", r.Burst)
+	}
+	return nil
+}
+
+func (r *RateLimitConfiguration) getBurst() int {
+	if r.Burst == 0 {
+		return int(math.Ceil(r.RequestsPerSecond))
+	}
+	return r.Burst
+}
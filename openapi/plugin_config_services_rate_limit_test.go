@@ -0,0 +1,47 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitConfigurationValidate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rateLimitConfig RateLimitConfiguration
+		expectedError   error
+	}{
+		{
+			name:            "rate limit config with requests per second and burst",
+			rateLimitConfig: RateLimitConfiguration{RequestsPerSecond: 10, Burst: 20},
+			expectedError:   nil,
+		},
+		{
+			name:            "rate limit config with fractional requests per second",
+			rateLimitConfig: RateLimitConfiguration{RequestsPerSecond: 0.5},
+			expectedError:   nil,
+		},
+		{
+			name:            "rate limit config missing the requests per second",
+			rateLimitConfig: RateLimitConfiguration{Burst: 20},
+			expectedError:   errors.New("rate_limit requests_per_second '0' is not valid, the value must be greater than zero"),
+		},
+		{
+			name:            "rate limit config with negative burst",
+			rateLimitConfig: RateLimitConfiguration{RequestsPerSecond: 10, Burst: -1},
+			expectedError:   errors.New("rate_limit burst '-1' is not valid, the value must be a positive number"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.rateLimitConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestRateLimitConfigurationGetBurst(t *testing.T) {
+	assert.Equal(t, 20, (&RateLimitConfiguration{RequestsPerSecond: 10, Burst: 20}).getBurst())
+	assert.Equal(t, 10, (&RateLimitConfiguration{RequestsPerSecond: 10}).getBurst())
+	assert.Equal(t, 1, (&RateLimitConfiguration{RequestsPerSecond: 0.5}).getBurst())
+}
@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const retryDefaultMaxRetries = 3
+const retryDefaultBackoff = 1 * time.Second
+const retryDefaultMaxBackoff = 30 * time.Second
+const retryAfterHeader = "Retry-After"
+
+// retryDefaultStatusCodes contains the response status codes retried if the retry configuration does not specify any
+var retryDefaultStatusCodes = []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// RetryConfiguration contains the configuration needed to retry the CRUD API requests that return a retryable status
+// code (e,g: 429 Too Many Requests or 503 Service Unavailable). The time to wait between retries doubles after each
+// retry (exponential backoff) unless the API response specifies how long to wait in the Retry-After header
+type RetryConfiguration struct {
+	// MaxRetries defines the number of times a request is retried after the first attempt, zero meaning the requests are
+	// not retried. If not provided the default max retries (3) will be used
+	MaxRetries *int `yaml:"max_retries,omitempty"`
+	// Backoff defines the time to wait before the first retry (e,g: 1s). If not provided the default backoff (1s) will
+	// be used
+	Backoff string `yaml:"backoff,omitempty"`
+	// MaxBackoff defines the max time to wait between retries, including the time requested by the API in the
+	// Retry-After header (e,g: 30s). If not provided the default max backoff (30s) will be used
+	MaxBackoff string `yaml:"max_backoff,omitempty"`
+	// StatusCodes defines the response status codes that should be retried. If not provided the default status codes
+	// (429, 500, 502, 503 and 504) will be used
+	StatusCodes []int `yaml:"status_codes,flow,omitempty"`
+	// disabled is set when the retries have been explicitly disabled for an operation (x-terraform-resource-retry: false)
+	disabled bool
+}
+
+// Validate checks whether the retry configuration is valid
+func (r *RetryConfiguration) Validate() error {
+	if r.MaxRetries != nil && *r.MaxRetries < 0 {
+		return fmt.Errorf("retry max_retries '%d' is not valid, the value must be zero or a positive number", *r.MaxRetries)
+	}
+	if err := validateRetryDuration("backoff", r.Backoff); err != nil {
+		return err
+	}
+	if err := validateRetryDuration("max_backoff", r.MaxBackoff); err != nil {
+		return err
+	}
+	for _, statusCode := range r.StatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("retry status_codes '%d' is not valid, the value must be a valid HTTP status code", statusCode)
+		}
+	}
+	return nil
+}
+
+func validateRetryDuration(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("retry %s '%s' is not valid: %s", name, value, err)
+	}
+	if duration < 0 {
+		return fmt.Errorf("retry %s '%s' is not valid, the value must be a positive duration", name, value)
+	}
+	return nil
+}
+
+// merge returns the configuration resulting of overriding the settings of the configuration provided (e,g: the plugin
+// configuration) with the ones configured in the receiver (e,g: the operation configuration). The configuration provided
+// may be nil, in which case the default values are used for the settings not configured in the receiver
+func (r *RetryConfiguration) merge(other *RetryConfiguration) *RetryConfiguration {
+	if other == nil {
+		return r
+	}
+	merged := *other
+	merged.disabled = r.disabled
+	if r.MaxRetries != nil {
+		merged.MaxRetries = r.MaxRetries
+	}
+	if r.Backoff != "" {
+		merged.Backoff = r.Backoff
+	}
+	if r.MaxBackoff != "" {
+		merged.MaxBackoff = r.MaxBackoff
+	}
+	if len(r.StatusCodes) > 0 {
+		merged.StatusCodes = r.StatusCodes
+	}
+	return &merged
+}
+
+func (r *RetryConfiguration) getMaxRetries() int {
+	if r.MaxRetries == nil {
+		return retryDefaultMaxRetries
+	}
+	return *r.MaxRetries
+}
+
+func (r *RetryConfiguration) getBackoff() time.Duration {
+	backoff, err := time.ParseDuration(r.Backoff)
+	if err != nil || backoff == 0 {
+		return retryDefaultBackoff
+	}
+	return backoff
+}
+
+func (r *RetryConfiguration) getMaxBackoff() time.Duration {
+	maxBackoff, err := time.ParseDuration(r.MaxBackoff)
+	if err != nil || maxBackoff == 0 {
+		return retryDefaultMaxBackoff
+	}
+	return maxBackoff
+}
+
+// isRetryable returns true if the response status code is one of the status codes that should be retried
+func (r *RetryConfiguration) isRetryable(statusCode int) bool {
+	statusCodes := r.StatusCodes
+	if len(statusCodes) == 0 {
+		statusCodes = retryDefaultStatusCodes
+	}
+	for _, retryableStatusCode := range statusCodes {
+		if statusCode == retryableStatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// getWaitTime returns the time to wait before performing the given retry (starting at 0) of a request that received the
+// response provided. If the response contains a Retry-After header (either in seconds or as a HTTP date) its value is
+// honoured; otherwise the backoff is doubled for each retry. The wait time never exceeds the max backoff
+func (r *RetryConfiguration) getWaitTime(retry int, resp *http.Response, now time.Time) time.Duration {
+	maxBackoff := r.getMaxBackoff()
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get(retryAfterHeader), now); ok {
+			if retryAfter > maxBackoff {
+				return maxBackoff
+			}
+			return retryAfter
+		}
+	}
+	wait := r.getBackoff()
+	for i := 0; i < retry && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		return maxBackoff
+	}
+	return wait
+}
+
+// parseRetryAfter returns the time to wait specified in the Retry-After header value provided as per
+// https://tools.ietf.org/html/rfc7231#section-7.1.3. False is returned if the value is empty or not valid
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(now); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newRetryMaxRetries returns a pointer to the max retries value provided
+func newRetryMaxRetries(maxRetries int) *int {
+	return &maxRetries
+}
+
+func TestRetryConfigurationValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		retryConfig   RetryConfiguration
+		expectedError error
+	}{
+		{
+			name:          "retry config with default values",
+			retryConfig:   RetryConfiguration{},
+			expectedError: nil,
+		},
+		{
+			name:          "retry config with all the settings",
+			retryConfig:   RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "500ms", MaxBackoff: "1m", StatusCodes: []int{409, 503}},
+			expectedError: nil,
+		},
+		{
+			name:          "retry config with retries disabled",
+			retryConfig:   RetryConfiguration{MaxRetries: newRetryMaxRetries(0)},
+			expectedError: nil,
+		},
+		{
+			name:          "retry config with negative max retries",
+			retryConfig:   RetryConfiguration{MaxRetries: newRetryMaxRetries(-1)},
+			expectedError: errors.New("retry max_retries '-1' is not valid, the value must be zero or a positive number"),
+		},
+		{
+			name:          "retry config with wrong backoff",
+			retryConfig:   RetryConfiguration{Backoff: "wrong"},
+			expectedError: errors.New("retry backoff 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:          "retry config with negative max backoff",
+			retryConfig:   RetryConfiguration{MaxBackoff: "-1s"},
+			expectedError: errors.New("retry max_backoff '-1s' is not valid, the value must be a positive duration"),
+		},
+		{
+			name:          "retry config with wrong status code",
+			retryConfig:   RetryConfiguration{StatusCodes: []int{503, 1000}},
+			expectedError: errors.New("retry status_codes '1000' is not valid, the value must be a valid HTTP status code"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.retryConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestRetryConfigurationDefaults(t *testing.T) {
+	retryConfig := RetryConfiguration{}
+	assert.Equal(t, retryDefaultMaxRetries, retryConfig.getMaxRetries())
+	assert.Equal(t, retryDefaultBackoff, retryConfig.getBackoff())
+	assert.Equal(t, retryDefaultMaxBackoff, retryConfig.getMaxBackoff())
+	for _, statusCode := range []int{429, 500, 502, 503, 504} {
+		assert.True(t, retryConfig.isRetryable(statusCode), "status code %d should be retried by default", statusCode)
+	}
+	for _, statusCode := range []int{200, 400, 401, 404, 409, 501} {
+		assert.False(t, retryConfig.isRetryable(statusCode), "status code %d should not be retried by default", statusCode)
+	}
+
+	retryConfig = RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s", MaxBackoff: "1m", StatusCodes: []int{409}}
+	assert.Equal(t, 5, retryConfig.getMaxRetries())
+	assert.Equal(t, 2*time.Second, retryConfig.getBackoff())
+	assert.Equal(t, time.Minute, retryConfig.getMaxBackoff())
+	assert.True(t, retryConfig.isRetryable(409))
+	assert.False(t, retryConfig.isRetryable(503))
+
+	retryConfig = RetryConfiguration{MaxRetries: newRetryMaxRetries(0)}
+	assert.Equal(t, 0, retryConfig.getMaxRetries(), "zero max retries should disable the retries")
+}
+
+func TestRetryConfigurationMerge(t *testing.T) {
+	pluginRetryConfig := &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s", MaxBackoff: "1m", StatusCodes: []int{503}}
+
+	operationRetryConfig := &RetryConfiguration{Backoff: "100ms", StatusCodes: []int{409}}
+	assert.Equal(t, &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "100ms", MaxBackoff: "1m", StatusCodes: []int{409}}, operationRetryConfig.merge(pluginRetryConfig))
+	assert.Equal(t, operationRetryConfig, operationRetryConfig.merge(nil))
+
+	operationRetryConfig = &RetryConfiguration{MaxRetries: newRetryMaxRetries(0)}
+	assert.Equal(t, &RetryConfiguration{MaxRetries: newRetryMaxRetries(0), Backoff: "2s", MaxBackoff: "1m", StatusCodes: []int{503}}, operationRetryConfig.merge(pluginRetryConfig))
+	assert.Equal(t, &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s", MaxBackoff: "1m", StatusCodes: []int{503}}, pluginRetryConfig, "the configuration provided should not be modified")
+}
+
+func TestRetryConfigurationGetWaitTime(t *testing.T) {
+	now := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	newResponse := func(retryAfter string) *http.Response {
+		resp := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set(retryAfterHeader, retryAfter)
+		}
+		return resp
+	}
+	retryConfig := RetryConfiguration{Backoff: "1s", MaxBackoff: "10s"}
+	testCases := []struct {
+		name         string
+		retry        int
+		resp         *http.Response
+		expectedWait time.Duration
+	}{
+		{name: "first retry waits the backoff", retry: 0, resp: newResponse(""), expectedWait: time.Second},
+		{name: "the backoff doubles after each retry", retry: 2, resp: newResponse(""), expectedWait: 4 * time.Second},
+		{name: "the backoff does not exceed the max backoff", retry: 10, resp: newResponse(""), expectedWait: 10 * time.Second},
+		{name: "no response waits the backoff", retry: 1, resp: nil, expectedWait: 2 * time.Second},
+		{name: "retry after in seconds is honoured", retry: 0, resp: newResponse("3"), expectedWait: 3 * time.Second},
+		{name: "retry after in seconds does not exceed the max backoff", retry: 0, resp: newResponse("120"), expectedWait: 10 * time.Second},
+		{name: "retry after http date is honoured", retry: 0, resp: newResponse(now.Add(5 * time.Second).Format(http.TimeFormat)), expectedWait: 5 * time.Second},
+		{name: "retry after http date in the past retries straight away", retry: 0, resp: newResponse(now.Add(-5 * time.Second).Format(http.TimeFormat)), expectedWait: 0},
+		{name: "retry after not valid is ignored", retry: 1, resp: newResponse("soon"), expectedWait: 2 * time.Second},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedWait, retryConfig.getWaitTime(tc.retry, tc.resp, now), tc.name)
+	}
+}
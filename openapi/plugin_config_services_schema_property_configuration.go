@@ -15,6 +15,8 @@ import (
 type ServiceSchemaPropertyConfiguration interface {
 	GetDefaultValue() (string, error)
 	ExecuteCommand() error
+	GetTokenCommand() *TokenCommandConfiguration
+	GetTokenFile() *TokenFileConfiguration
 }
 
 const cmdTimeout = 10
@@ -28,6 +30,17 @@ type ServiceSchemaPropertyConfigurationV1 struct {
 	Command               []string                                     `yaml:"cmd,flow"`
 	CommandTimeout        int                                          `yaml:"cmd_timeout"`
 	ExternalConfiguration ServiceSchemaPropertyExternalConfigurationV1 `yaml:"schema_property_external_configuration"`
+	// TokenCommand defines the command to execute (using exec form) to obtain the value of the security definition property.
+	// The stdout of the command is used as the token and it's refreshed once the TokenTTL expires or when the API returns a 401
+	TokenCommand []string `yaml:"token_command,flow,omitempty"`
+	// TokenCommandTimeout defines the max timeout, in seconds, for the token command to execute (default 10s)
+	TokenCommandTimeout int `yaml:"token_command_timeout,omitempty"`
+	// TokenFile defines the path to the file containing the value of the security definition property. The file is read
+	// again once the TokenTTL expires or when the API returns a 401. It can not be configured along with the TokenCommand
+	TokenFile string `yaml:"token_file,omitempty"`
+	// TokenTTL defines how long the token returned by the token command (or read from the token file) is valid for (e,g: 5m).
+	// If not set, the token is only refreshed when the API returns a 401
+	TokenTTL string `yaml:"token_ttl,omitempty"`
 }
 
 // ServiceSchemaPropertyExternalConfigurationV1 defines the external configuration for a provider property.
@@ -82,6 +95,57 @@ func (s ServiceSchemaPropertyConfigurationV1) ExecuteCommand() error {
 	return nil
 }
 
+// GetTokenCommand returns the token command configuration if the 'TokenCommand' is configured in the
+// ServiceSchemaPropertyConfigurationV1 struct; nil otherwise. The token ttl is expected to have been validated already
+func (s ServiceSchemaPropertyConfigurationV1) GetTokenCommand() *TokenCommandConfiguration {
+	if len(s.TokenCommand) == 0 {
+		return nil
+	}
+	timeout := cmdTimeout
+	if s.TokenCommandTimeout > 0 {
+		timeout = s.TokenCommandTimeout
+	}
+	ttl, _ := time.ParseDuration(s.TokenTTL)
+	return &TokenCommandConfiguration{
+		Command: s.TokenCommand,
+		Timeout: time.Duration(timeout) * time.Second,
+		TTL:     ttl,
+	}
+}
+
+// GetTokenFile returns the token file configuration if the 'TokenFile' is configured in the
+// ServiceSchemaPropertyConfigurationV1 struct; nil otherwise. The token ttl is expected to have been validated already
+func (s ServiceSchemaPropertyConfigurationV1) GetTokenFile() *TokenFileConfiguration {
+	if s.TokenFile == "" {
+		return nil
+	}
+	ttl, _ := time.ParseDuration(s.TokenTTL)
+	return &TokenFileConfiguration{
+		File: s.TokenFile,
+		TTL:  ttl,
+	}
+}
+
+// validate checks that the token command and token file settings configured are valid
+func (s ServiceSchemaPropertyConfigurationV1) validate() error {
+	if len(s.TokenCommand) > 0 && s.TokenFile != "" {
+		return fmt.Errorf("schema property '%s' token_command and token_file can not be configured at the same time", s.SchemaPropertyName)
+	}
+	if s.TokenCommandTimeout < 0 {
+		return fmt.Errorf("schema property '%s' token_command_timeout '%d' is not valid, the value must be a positive number", s.SchemaPropertyName, s.TokenCommandTimeout)
+	}
+	if s.TokenTTL != "" {
+		ttl, err := time.ParseDuration(s.TokenTTL)
+		if err != nil {
+			return fmt.Errorf("schema property '%s' token_ttl '%s' is not valid: %s", s.SchemaPropertyName, s.TokenTTL, err)
+		}
+		if ttl < 0 {
+			return fmt.Errorf("schema property '%s' token_ttl '%s' is not valid, the value must be a positive duration", s.SchemaPropertyName, s.TokenTTL)
+		}
+	}
+	return nil
+}
+
 func (s ServiceSchemaPropertyConfigurationV1) exec(doneChan chan error) {
 	if len(s.Command) > 0 {
 		start := time.Now()
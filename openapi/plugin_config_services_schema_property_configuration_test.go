@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestServiceSchemaConfigurationV1(t *testing.T) {
@@ -429,3 +430,100 @@ func TestSchemaFileParserJsonGetValue(t *testing.T) {
 		})
 	})
 }
+
+func TestServiceSchemaConfigurationV1GetTokenCommand(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with no token command configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+		}
+		Convey("When GetTokenCommand method is called", func() {
+			tokenCommand := serviceSchemaConfigurationV1.GetTokenCommand()
+			Convey("Then the token command returned should be nil", func() {
+				So(tokenCommand, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a token command configured with the default timeout", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+			TokenCommand:       []string{"gcloud", "auth", "print-access-token"},
+			TokenTTL:           "5m",
+		}
+		Convey("When GetTokenCommand method is called", func() {
+			tokenCommand := serviceSchemaConfigurationV1.GetTokenCommand()
+			Convey("Then the token command returned should contain the expected configuration", func() {
+				So(tokenCommand.Command, ShouldResemble, []string{"gcloud", "auth", "print-access-token"})
+				So(tokenCommand.Timeout, ShouldEqual, 10*time.Second)
+				So(tokenCommand.TTL, ShouldEqual, 5*time.Minute)
+			})
+		})
+	})
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a token command configured with a timeout and no ttl", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName:  "some_property_name",
+			TokenCommand:        []string{"date"},
+			TokenCommandTimeout: 2,
+		}
+		Convey("When GetTokenCommand method is called", func() {
+			tokenCommand := serviceSchemaConfigurationV1.GetTokenCommand()
+			Convey("Then the token command returned should contain the expected configuration", func() {
+				So(tokenCommand.Timeout, ShouldEqual, 2*time.Second)
+				So(tokenCommand.TTL, ShouldEqual, 0)
+			})
+		})
+	})
+}
+
+func TestServiceSchemaConfigurationV1GetTokenFile(t *testing.T) {
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with no token file configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+		}
+		Convey("When GetTokenFile method is called", func() {
+			tokenFile := serviceSchemaConfigurationV1.GetTokenFile()
+			Convey("Then the token file returned should be nil", func() {
+				So(tokenFile, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a ServiceSchemaPropertyConfigurationV1 with a token file configured", t, func() {
+		serviceSchemaConfigurationV1 := ServiceSchemaPropertyConfigurationV1{
+			SchemaPropertyName: "some_property_name",
+			TokenFile:          "/var/run/secrets/token",
+			TokenTTL:           "15m",
+		}
+		Convey("When GetTokenFile method is called", func() {
+			tokenFile := serviceSchemaConfigurationV1.GetTokenFile()
+			Convey("Then the token file returned should contain the expected configuration", func() {
+				So(tokenFile.File, ShouldEqual, "/var/run/secrets/token")
+				So(tokenFile.TTL, ShouldEqual, 15*time.Minute)
+			})
+		})
+	})
+}
+
+func TestServiceSchemaConfigurationV1Validate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configuration ServiceSchemaPropertyConfigurationV1
+		expectedError string
+	}{
+		{name: "no token command settings", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth"}},
+		{name: "valid token command settings", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenCommand: []string{"date"}, TokenCommandTimeout: 5, TokenTTL: "1h"}},
+		{name: "negative token command timeout", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenCommandTimeout: -1}, expectedError: "schema property 'apikey_auth' token_command_timeout '-1' is not valid, the value must be a positive number"},
+		{name: "wrong token ttl", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenTTL: "wrong"}, expectedError: "schema property 'apikey_auth' token_ttl 'wrong' is not valid: time: invalid duration \"wrong\""},
+		{name: "valid token file settings", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenFile: "/var/run/secrets/token", TokenTTL: "15m"}},
+		{name: "both token command and token file", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenCommand: []string{"date"}, TokenFile: "/var/run/secrets/token"}, expectedError: "schema property 'apikey_auth' token_command and token_file can not be configured at the same time"},
+		{name: "negative token ttl", configuration: ServiceSchemaPropertyConfigurationV1{SchemaPropertyName: "apikey_auth", TokenTTL: "-1m"}, expectedError: "schema property 'apikey_auth' token_ttl '-1m' is not valid, the value must be a positive duration"},
+	}
+	for _, tc := range testCases {
+		Convey(fmt.Sprintf("Given a ServiceSchemaPropertyConfigurationV1 with %s", tc.name), t, func() {
+			err := tc.configuration.validate()
+			if tc.expectedError == "" {
+				So(err, ShouldBeNil)
+			} else {
+				So(err.Error(), ShouldEqual, tc.expectedError)
+			}
+		})
+	}
+}
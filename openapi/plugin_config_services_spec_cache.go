@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const defaultSpecCacheTTL = time.Hour
+
+// SpecCacheConfiguration contains the configuration of the on-disk cache of the OpenAPI document, which avoids retrieving
+// and parsing the whole document on every terraform invocation. The cached document is used while it is fresher than
+// the TTL and revalidated with the API afterwards (using the ETag and Last-Modified response headers)
+type SpecCacheConfiguration struct {
+	// Directory defines where the cached documents are stored. If not provided the terraform-provider-openapi folder in
+	// the user cache directory (e,g: ~/.cache/terraform-provider-openapi) will be used
+	Directory string `yaml:"directory,omitempty"`
+	// TTL defines for how long the cached document is used before revalidating it (e,g: 30m). If not provided the default
+	// TTL (1h) will be used. If set to 0s, the cached document is revalidated on every terraform invocation
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// Validate checks whether the spec cache configuration is valid
+func (c *SpecCacheConfiguration) Validate() error {
+	if c.TTL == "" {
+		return nil
+	}
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil {
+		return fmt.Errorf("spec_cache ttl '%s' is not valid: %s", c.TTL, err)
+	}
+	if ttl < 0 {
+		return fmt.Errorf("spec_cache ttl '%s' is not valid, the value must be a positive duration", c.TTL)
+	}
+	return nil
+}
+
+func (c *SpecCacheConfiguration) getTTL() time.Duration {
+	ttl, err := time.ParseDuration(c.TTL)
+	if err != nil || ttl < 0 {
+		return defaultSpecCacheTTL
+	}
+	return ttl
+}
+
+func (c *SpecCacheConfiguration) getDirectory() (string, error) {
+	if c.Directory != "" {
+		return c.Directory, nil
+	}
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the spec cache directory, please configure the spec_cache directory: %s", err)
+	}
+	return filepath.Join(userCacheDir, "terraform-provider-openapi"), nil
+}
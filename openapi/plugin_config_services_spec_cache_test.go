@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecCacheConfigurationValidate(t *testing.T) {
+	testCases := []struct {
+		name            string
+		specCacheConfig SpecCacheConfiguration
+		expectedError   error
+	}{
+		{
+			name:            "spec cache config with default values",
+			specCacheConfig: SpecCacheConfiguration{},
+			expectedError:   nil,
+		},
+		{
+			name:            "spec cache config with all the settings",
+			specCacheConfig: SpecCacheConfiguration{Directory: "/tmp/cache", TTL: "0s"},
+			expectedError:   nil,
+		},
+		{
+			name:            "spec cache config with wrong ttl",
+			specCacheConfig: SpecCacheConfiguration{TTL: "wrong"},
+			expectedError:   errors.New("spec_cache ttl 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:            "spec cache config with negative ttl",
+			specCacheConfig: SpecCacheConfiguration{TTL: "-1m"},
+			expectedError:   errors.New("spec_cache ttl '-1m' is not valid, the value must be a positive duration"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.specCacheConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestSpecCacheConfigurationGetters(t *testing.T) {
+	specCacheConfig := SpecCacheConfiguration{}
+	assert.Equal(t, defaultSpecCacheTTL, specCacheConfig.getTTL())
+	directory, err := specCacheConfig.getDirectory()
+	assert.Nil(t, err)
+	assert.Contains(t, directory, "terraform-provider-openapi")
+
+	specCacheConfig = SpecCacheConfiguration{Directory: "/tmp/cache", TTL: "10m"}
+	assert.Equal(t, 10*time.Minute, specCacheConfig.getTTL())
+	directory, err = specCacheConfig.getDirectory()
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/cache", directory)
+}
@@ -6,7 +6,22 @@ package openapi
 type ServiceConfigStub struct {
 	SwaggerURL          string
 	PluginVersion       string
+	SwaggerSHA256       string
+	AdditionalDocuments []SwaggerDocumentConfiguration
 	InsecureSkipVerify  bool
+	UserAgentSuffix     string
+	HTTPTransport       HTTPTransportConfiguration
+	ClientTLS           ClientTLSConfiguration
+	AllowedResources    []string
+	ExcludedResources   []string
+	GzipCompression     bool
+	Retry               *RetryConfiguration
+	RateLimit           *RateLimitConfiguration
+	Polling             *PollingConfiguration
+	SpecCache           *SpecCacheConfiguration
+	SwaggerRequest      *SwaggerRequestConfiguration
+	ProtocolV6          bool
+	GetOnlyDataSources  bool
 	SchemaConfiguration []*ServiceSchemaPropertyConfigurationStub
 	Err                 error
 }
@@ -19,6 +34,8 @@ type ServiceSchemaPropertyConfigurationStub struct {
 	Err                  error
 	GetDefaultValueFunc  func() (string, error)
 	ExecuteCommandCalled bool
+	TokenCommand         *TokenCommandConfiguration
+	TokenFile            *TokenFileConfiguration
 }
 
 // GetSwaggerURL returns the swagger URL value configured in the ServiceConfigStub.SwaggerURL field
@@ -31,11 +48,86 @@ func (s *ServiceConfigStub) GetPluginVersion() string {
 	return s.PluginVersion
 }
 
+// GetSwaggerSHA256 returns the checksum configured in the ServiceConfigStub.SwaggerSHA256 field
+func (s *ServiceConfigStub) GetSwaggerSHA256() string {
+	return s.SwaggerSHA256
+}
+
+// GetAdditionalSwaggerDocuments returns the documents configured in the ServiceConfigStub.AdditionalDocuments field
+func (s *ServiceConfigStub) GetAdditionalSwaggerDocuments() []SwaggerDocumentConfiguration {
+	return s.AdditionalDocuments
+}
+
 // IsInsecureSkipVerifyEnabled returns the bool configured in the ServiceConfigStub.InsecureSkipVerify field
 func (s *ServiceConfigStub) IsInsecureSkipVerifyEnabled() bool {
 	return s.InsecureSkipVerify
 }
 
+// GetUserAgentSuffix returns the user agent suffix configured in the ServiceConfigStub.UserAgentSuffix field
+func (s *ServiceConfigStub) GetUserAgentSuffix() string {
+	return s.UserAgentSuffix
+}
+
+// GetHTTPTransportConfiguration returns the transport configuration set in the ServiceConfigStub.HTTPTransport field
+func (s *ServiceConfigStub) GetHTTPTransportConfiguration() HTTPTransportConfiguration {
+	return s.HTTPTransport
+}
+
+// GetClientTLSConfiguration returns the TLS configuration set in the ServiceConfigStub.ClientTLS field
+func (s *ServiceConfigStub) GetClientTLSConfiguration() ClientTLSConfiguration {
+	return s.ClientTLS
+}
+
+// GetAllowedResources returns the resource names configured in the ServiceConfigStub.AllowedResources field
+func (s *ServiceConfigStub) GetAllowedResources() []string {
+	return s.AllowedResources
+}
+
+// GetExcludedResources returns the resource names configured in the ServiceConfigStub.ExcludedResources field
+func (s *ServiceConfigStub) GetExcludedResources() []string {
+	return s.ExcludedResources
+}
+
+// IsGzipCompressionEnabled returns the bool configured in the ServiceConfigStub.GzipCompression field
+func (s *ServiceConfigStub) IsGzipCompressionEnabled() bool {
+	return s.GzipCompression
+}
+
+// GetRetryConfiguration returns the retry configuration set in the ServiceConfigStub.Retry field
+func (s *ServiceConfigStub) GetRetryConfiguration() *RetryConfiguration {
+	return s.Retry
+}
+
+// GetRateLimitConfiguration returns the rate limit configuration set in the ServiceConfigStub.RateLimit field
+func (s *ServiceConfigStub) GetRateLimitConfiguration() *RateLimitConfiguration {
+	return s.RateLimit
+}
+
+// GetPollingConfiguration returns the polling configuration set in the ServiceConfigStub.Polling field
+func (s *ServiceConfigStub) GetPollingConfiguration() *PollingConfiguration {
+	return s.Polling
+}
+
+// GetSpecCacheConfiguration returns the spec cache configuration set in the ServiceConfigStub.SpecCache field
+func (s *ServiceConfigStub) GetSpecCacheConfiguration() *SpecCacheConfiguration {
+	return s.SpecCache
+}
+
+// GetSwaggerRequestConfiguration returns the swagger request configuration set in the ServiceConfigStub.SwaggerRequest field
+func (s *ServiceConfigStub) GetSwaggerRequestConfiguration() *SwaggerRequestConfiguration {
+	return s.SwaggerRequest
+}
+
+// IsProtocolV6Enabled returns the bool configured in the ServiceConfigStub.ProtocolV6 field
+func (s *ServiceConfigStub) IsProtocolV6Enabled() bool {
+	return s.ProtocolV6
+}
+
+// IsGetOnlyDataSourcesEnabled returns the bool configured in the ServiceConfigStub.GetOnlyDataSources field
+func (s *ServiceConfigStub) IsGetOnlyDataSourcesEnabled() bool {
+	return s.GetOnlyDataSources
+}
+
 // Validate returns an error if the ServiceConfigStub.Err field is set with an error
 func (s *ServiceConfigStub) Validate(runningPluginVersion string) error {
 	return s.Err
@@ -65,3 +157,13 @@ func (s *ServiceSchemaPropertyConfigurationStub) ExecuteCommand() error {
 	s.ExecuteCommandCalled = true
 	return s.Err
 }
+
+// GetTokenCommand returns the token command configuration set in the ServiceSchemaPropertyConfigurationStub.TokenCommand field
+func (s *ServiceSchemaPropertyConfigurationStub) GetTokenCommand() *TokenCommandConfiguration {
+	return s.TokenCommand
+}
+
+// GetTokenFile returns the token file configuration set in the ServiceSchemaPropertyConfigurationStub.TokenFile field
+func (s *ServiceSchemaPropertyConfigurationStub) GetTokenFile() *TokenFileConfiguration {
+	return s.TokenFile
+}
@@ -0,0 +1,48 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var resourceNamePrefixRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// SwaggerDocumentConfiguration defines a swagger document whose resources and data sources are merged into the provider
+// along with the ones from the document configured in the swagger-url. The resources are called against the host defined
+// in the document (unless overridden with the 'x-terraform-resource-host' extension)
+type SwaggerDocumentConfiguration struct {
+	// URL defines where the swagger document is located (a URL or a path to a file stored in the disk)
+	URL string `yaml:"url"`
+	// SwaggerSHA256 defines the SHA-256 checksum (hex encoded) the swagger document must match
+	SwaggerSHA256 string `yaml:"swagger_sha256,omitempty"`
+	// ResourceNamePrefix defines the prefix added to the names of the resources and data sources of the document (e,g:
+	// billing makes the resource 'invoice' available as 'billing_invoice'), which can be used to avoid name collisions
+	// across documents
+	ResourceNamePrefix string `yaml:"resource_name_prefix,omitempty"`
+}
+
+func validateAdditionalSwaggerDocuments(swaggerURL string, documents []SwaggerDocumentConfiguration) error {
+	urls := map[string]bool{swaggerURL: true}
+	for _, document := range documents {
+		if document.URL == "" {
+			return fmt.Errorf("additional_swagger_documents configuration is missing a value for the 'url' property")
+		}
+		if document.URL == openAPIDocumentStdin {
+			return fmt.Errorf("additional_swagger_documents url '%s' is not valid, only the swagger-url can be read from the standard input", document.URL)
+		}
+		if urls[document.URL] {
+			return fmt.Errorf("additional_swagger_documents url '%s' is configured multiple times", document.URL)
+		}
+		urls[document.URL] = true
+		if err := validateSwaggerURL(document.URL); err != nil {
+			return err
+		}
+		if err := validateSwaggerSHA256(document.SwaggerSHA256); err != nil {
+			return err
+		}
+		if document.ResourceNamePrefix != "" && !resourceNamePrefixRegex.MatchString(document.ResourceNamePrefix) {
+			return fmt.Errorf("additional_swagger_documents resource_name_prefix '%s' of url '%s' is not valid, the value must only contain lower case letters, numbers and underscores and start with a letter", document.ResourceNamePrefix, document.URL)
+		}
+	}
+	return nil
+}
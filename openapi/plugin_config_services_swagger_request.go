@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const swaggerRequestTimeout = 30 * time.Second
+
+// SwaggerRequestConfiguration contains the authentication settings used when retrieving the swagger document served over
+// HTTP (e,g: from an internal gateway), which are separate from the credentials used in the API requests. The headers,
+// bearer token and basic auth values may reference environment variables (e,g: ${SPEC_TOKEN}) so secrets do not need to
+// be stored in the plugin configuration file
+type SwaggerRequestConfiguration struct {
+	// Headers contains additional headers sent in the request
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// BearerToken is sent in the Authorization header as a bearer token
+	BearerToken string `yaml:"bearer_token,omitempty"`
+	// BasicAuth contains the credentials sent in the Authorization header using the basic authentication scheme
+	BasicAuth *SwaggerRequestBasicAuth `yaml:"basic_auth,omitempty"`
+	// ClientCertificateFile defines the path to the PEM encoded client certificate presented in the request, overriding
+	// the service client_certificate_file. Requires the ClientKeyFile
+	ClientCertificateFile string `yaml:"client_certificate_file,omitempty"`
+	// ClientKeyFile defines the path to the PEM encoded private key of the client certificate. Requires the ClientCertificateFile
+	ClientKeyFile string `yaml:"client_key_file,omitempty"`
+	// CABundleFile defines the path to the PEM encoded CA certificates trusted to verify the server certificate
+	CABundleFile string `yaml:"ca_bundle_file,omitempty"`
+}
+
+// SwaggerRequestBasicAuth defines the basic authentication credentials used to retrieve the swagger document
+type SwaggerRequestBasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password,omitempty"`
+}
+
+// Validate checks whether the swagger request configuration is valid, including that the environment variables
+// referenced are set and the TLS files contain valid PEM encoded certificates (and key)
+func (c *SwaggerRequestConfiguration) Validate() error {
+	if c.BearerToken != "" && c.BasicAuth != nil {
+		return errors.New("swagger_request configuration must not contain both 'bearer_token' and 'basic_auth' properties")
+	}
+	if c.BasicAuth != nil && c.BasicAuth.Username == "" {
+		return errors.New("swagger_request configuration 'basic_auth' property is missing a value for the 'username' property")
+	}
+	values := []string{c.BearerToken}
+	if c.BasicAuth != nil {
+		values = append(values, c.BasicAuth.Username, c.BasicAuth.Password)
+	}
+	for name, value := range c.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("swagger_request configuration contains an invalid header name '%s'", name)
+		}
+		if (c.BearerToken != "" || c.BasicAuth != nil) && http.CanonicalHeaderKey(name) == authorizationHeader {
+			return fmt.Errorf("swagger_request configuration header '%s' conflicts with the 'bearer_token'/'basic_auth' properties", name)
+		}
+		values = append(values, value)
+	}
+	for _, value := range values {
+		for _, match := range telemetryEnvVarRegex.FindAllStringSubmatch(value, -1) {
+			if _, ok := os.LookupEnv(match[1]); !ok {
+				return fmt.Errorf("swagger_request configuration references the environment variable '%s' which is not set", match[1])
+			}
+		}
+	}
+	_, err := c.getClientTLSConfiguration().load()
+	return err
+}
+
+func (c *SwaggerRequestConfiguration) getClientTLSConfiguration() ClientTLSConfiguration {
+	return ClientTLSConfiguration{
+		ClientCertificateFile: c.ClientCertificateFile,
+		ClientKeyFile:         c.ClientKeyFile,
+		CABundleFile:          c.CABundleFile,
+	}
+}
+
+// newRequest returns the request used to retrieve the swagger document served at the given URL, including the headers
+// configured (interpolating the environment variables referenced)
+func (c *SwaggerRequestConfiguration) newRequest(url string) (*http.Request, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil || c == nil {
+		return req, err
+	}
+	for name, value := range c.Headers {
+		req.Header.Set(name, expandTelemetryEnvVars(value))
+	}
+	if c.BearerToken != "" {
+		req.Header.Set(authorizationHeader, fmt.Sprintf("Bearer %s", expandTelemetryEnvVars(c.BearerToken)))
+	}
+	if c.BasicAuth != nil {
+		req.SetBasicAuth(expandTelemetryEnvVars(c.BasicAuth.Username), expandTelemetryEnvVars(c.BasicAuth.Password))
+	}
+	return req, nil
+}
+
+// newHTTPClient returns the client used to retrieve the swagger document. The client uses the service transport provided
+// (so the service TLS settings apply), or the default transport if nil, unless TLS settings are configured for the
+// swagger request
+func (c *SwaggerRequestConfiguration) newHTTPClient(serviceTransport *http.Transport) (*http.Client, error) {
+	client := &http.Client{Timeout: swaggerRequestTimeout}
+	if serviceTransport != nil {
+		client.Transport = serviceTransport
+	}
+	if c == nil || c.getClientTLSConfiguration().isDefault() {
+		return client, nil
+	}
+	if serviceTransport == nil {
+		serviceTransport = http.DefaultTransport.(*http.Transport)
+	}
+	transport := serviceTransport.Clone()
+	tlsConfig, err := c.getClientTLSConfiguration().newTLSConfig(transport.TLSClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+	client.Transport = transport
+	return client, nil
+}
+
+// fetchSwaggerDocument retrieves the swagger document served at the given URL using the swagger request configuration
+// and the service transport provided (both may be nil)
+func fetchSwaggerDocument(url string, swaggerRequestConfiguration *SwaggerRequestConfiguration, serviceTransport *http.Transport) ([]byte, error) {
+	client, err := swaggerRequestConfiguration.newHTTPClient(serviceTransport)
+	if err != nil {
+		return nil, err
+	}
+	req, err := swaggerRequestConfiguration.newRequest(url)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not access document at %q [%s]", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
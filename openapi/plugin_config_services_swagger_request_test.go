@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerRequestConfigurationValidate(t *testing.T) {
+	os.Setenv("SWAGGER_REQUEST_TEST_TOKEN", "secret")
+	defer os.Unsetenv("SWAGGER_REQUEST_TEST_TOKEN")
+	testCases := []struct {
+		name                 string
+		swaggerRequestConfig SwaggerRequestConfiguration
+		expectedError        error
+	}{
+		{
+			name:                 "swagger request config with headers and bearer token referencing env variables",
+			swaggerRequestConfig: SwaggerRequestConfiguration{Headers: map[string]string{"X-Gateway": "internal"}, BearerToken: "${SWAGGER_REQUEST_TEST_TOKEN}"},
+			expectedError:        nil,
+		},
+		{
+			name:                 "swagger request config with basic auth",
+			swaggerRequestConfig: SwaggerRequestConfiguration{BasicAuth: &SwaggerRequestBasicAuth{Username: "user", Password: "${SWAGGER_REQUEST_TEST_TOKEN}"}},
+			expectedError:        nil,
+		},
+		{
+			name:                 "swagger request config with both bearer token and basic auth",
+			swaggerRequestConfig: SwaggerRequestConfiguration{BearerToken: "token", BasicAuth: &SwaggerRequestBasicAuth{Username: "user"}},
+			expectedError:        errors.New("swagger_request configuration must not contain both 'bearer_token' and 'basic_auth' properties"),
+		},
+		{
+			name:                 "swagger request config with basic auth missing the username",
+			swaggerRequestConfig: SwaggerRequestConfiguration{BasicAuth: &SwaggerRequestBasicAuth{Password: "password"}},
+			expectedError:        errors.New("swagger_request configuration 'basic_auth' property is missing a value for the 'username' property"),
+		},
+		{
+			name:                 "swagger request config with an invalid header name",
+			swaggerRequestConfig: SwaggerRequestConfiguration{Headers: map[string]string{"X Gateway": "internal"}},
+			expectedError:        errors.New("swagger_request configuration contains an invalid header name 'X Gateway'"),
+		},
+		{
+			name:                 "swagger request config with an authorization header and a bearer token",
+			swaggerRequestConfig: SwaggerRequestConfiguration{Headers: map[string]string{"authorization": "Basic abc"}, BearerToken: "token"},
+			expectedError:        errors.New("swagger_request configuration header 'authorization' conflicts with the 'bearer_token'/'basic_auth' properties"),
+		},
+		{
+			name:                 "swagger request config referencing an env variable that is not set",
+			swaggerRequestConfig: SwaggerRequestConfiguration{BearerToken: "${SWAGGER_REQUEST_TEST_NOT_SET}"},
+			expectedError:        errors.New("swagger_request configuration references the environment variable 'SWAGGER_REQUEST_TEST_NOT_SET' which is not set"),
+		},
+		{
+			name:                 "swagger request config with a CA bundle file that does not exist",
+			swaggerRequestConfig: SwaggerRequestConfiguration{CABundleFile: "/non/existing/ca.pem"},
+			expectedError:        errors.New("failed to read the ca_bundle_file '/non/existing/ca.pem': open /non/existing/ca.pem: no such file or directory"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.swaggerRequestConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestFetchSwaggerDocument(t *testing.T) {
+	os.Setenv("SWAGGER_REQUEST_TEST_TOKEN", "secret")
+	defer os.Unsetenv("SWAGGER_REQUEST_TEST_TOKEN")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(authorizationHeader) != "Bearer secret" || r.Header.Get("X-Gateway") != "internal" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`swagger: "2.0"`))
+	}))
+	defer server.Close()
+
+	document, err := fetchSwaggerDocument(server.URL, &SwaggerRequestConfiguration{Headers: map[string]string{"X-Gateway": "internal"}, BearerToken: "${SWAGGER_REQUEST_TEST_TOKEN}"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `swagger: "2.0"`, string(document))
+
+	_, err = fetchSwaggerDocument(server.URL, nil, nil)
+	assert.EqualError(t, err, "could not access document at \""+server.URL+"\" [401 Unauthorized]")
+}
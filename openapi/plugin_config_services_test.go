@@ -1,10 +1,13 @@
 package openapi
 
 import (
-	. "github.com/smartystreets/goconvey/convey"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
 )
 
 func TestNewServiceConfigV1(t *testing.T) {
@@ -65,6 +68,212 @@ func TestServiceConfigV1IsSecureSkipVerifyEnabled(t *testing.T) {
 	})
 }
 
+func TestServiceConfigV1IsGzipCompressionEnabled(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing the gzip_compression enabled", t, func() {
+		serviceConfiguration := &ServiceConfigV1{GzipCompression: true}
+		Convey("When IsGzipCompressionEnabled method is called", func() {
+			isGzipCompressionEnabled := serviceConfiguration.IsGzipCompressionEnabled()
+			Convey("Then the value returned should be true", func() {
+				So(isGzipCompressionEnabled, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 that does not contain the gzip_compression", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When IsGzipCompressionEnabled method is called", func() {
+			isGzipCompressionEnabled := serviceConfiguration.IsGzipCompressionEnabled()
+			Convey("Then the value returned should be false", func() {
+				So(isGzipCompressionEnabled, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1IsProtocolV6Enabled(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing the protocol_v6 enabled", t, func() {
+		serviceConfiguration := &ServiceConfigV1{ProtocolV6: true}
+		Convey("When IsProtocolV6Enabled method is called", func() {
+			isProtocolV6Enabled := serviceConfiguration.IsProtocolV6Enabled()
+			Convey("Then the value returned should be true", func() {
+				So(isProtocolV6Enabled, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 that does not contain the protocol_v6", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When IsProtocolV6Enabled method is called", func() {
+			isProtocolV6Enabled := serviceConfiguration.IsProtocolV6Enabled()
+			Convey("Then the value returned should be false", func() {
+				So(isProtocolV6Enabled, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1IsGetOnlyDataSourcesEnabled(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing the get_only_data_sources enabled", t, func() {
+		serviceConfiguration := &ServiceConfigV1{GetOnlyDataSources: true}
+		Convey("When IsGetOnlyDataSourcesEnabled method is called", func() {
+			isGetOnlyDataSourcesEnabled := serviceConfiguration.IsGetOnlyDataSourcesEnabled()
+			Convey("Then the value returned should be true", func() {
+				So(isGetOnlyDataSourcesEnabled, ShouldBeTrue)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 that does not contain the get_only_data_sources", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When IsGetOnlyDataSourcesEnabled method is called", func() {
+			isGetOnlyDataSourcesEnabled := serviceConfiguration.IsGetOnlyDataSourcesEnabled()
+			Convey("Then the value returned should be false", func() {
+				So(isGetOnlyDataSourcesEnabled, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetUserAgentSuffix(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing a user agent suffix", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		expectedUserAgentSuffix := "acme-cli/1.2"
+		serviceConfiguration = &ServiceConfigV1{
+			UserAgentSuffix: expectedUserAgentSuffix,
+		}
+		Convey("When GetUserAgentSuffix method is called", func() {
+			userAgentSuffix := serviceConfiguration.GetUserAgentSuffix()
+			Convey("Then the user agent suffix returned should be equal to expected one", func() {
+				So(userAgentSuffix, ShouldEqual, expectedUserAgentSuffix)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetAllowedResources(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing allowed resources", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		expectedAllowedResources := []string{"cdn_v1", "lb_v1"}
+		serviceConfiguration = &ServiceConfigV1{
+			AllowedResources: expectedAllowedResources,
+		}
+		Convey("When GetAllowedResources method is called", func() {
+			allowedResources := serviceConfiguration.GetAllowedResources()
+			Convey("Then the allowed resources returned should be equal to expected ones", func() {
+				So(allowedResources, ShouldResemble, expectedAllowedResources)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetExcludedResources(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing excluded resources", t, func() {
+		var serviceConfiguration ServiceConfiguration
+		expectedExcludedResources := []string{"internal_*"}
+		serviceConfiguration = &ServiceConfigV1{
+			ExcludedResources: expectedExcludedResources,
+		}
+		Convey("When GetExcludedResources method is called", func() {
+			excludedResources := serviceConfiguration.GetExcludedResources()
+			Convey("Then the excluded resources returned should be equal to expected ones", func() {
+				So(excludedResources, ShouldResemble, expectedExcludedResources)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetHTTPTransportConfiguration(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing connection pooling settings", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			MaxIdleConns:        500,
+			MaxIdleConnsPerHost: 100,
+			IdleConnTimeout:     "30s",
+		}
+		Convey("When GetHTTPTransportConfiguration method is called", func() {
+			transportConfiguration := serviceConfiguration.GetHTTPTransportConfiguration()
+			Convey("Then the transport configuration returned should contain the expected settings", func() {
+				So(transportConfiguration, ShouldResemble, HTTPTransportConfiguration{MaxIdleConns: 500, MaxIdleConnsPerHost: 100, IdleConnTimeout: 30 * time.Second})
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 without connection pooling settings", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When GetHTTPTransportConfiguration method is called", func() {
+			transportConfiguration := serviceConfiguration.GetHTTPTransportConfiguration()
+			Convey("Then the transport configuration returned should be the default one", func() {
+				So(transportConfiguration.isDefault(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetClientTLSConfiguration(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing client certificate and CA bundle settings", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			ClientCertificateFile: "client.crt",
+			ClientKeyFile:         "client.key",
+			CABundleFile:          "ca.pem",
+		}
+		Convey("When GetClientTLSConfiguration method is called", func() {
+			clientTLSConfiguration := serviceConfiguration.GetClientTLSConfiguration()
+			Convey("Then the client TLS configuration returned should contain the expected settings", func() {
+				So(clientTLSConfiguration, ShouldResemble, ClientTLSConfiguration{ClientCertificateFile: "client.crt", ClientKeyFile: "client.key", CABundleFile: "ca.pem"})
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 without client certificate and CA bundle settings", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When GetClientTLSConfiguration method is called", func() {
+			clientTLSConfiguration := serviceConfiguration.GetClientTLSConfiguration()
+			Convey("Then the client TLS configuration returned should be the default one", func() {
+				So(clientTLSConfiguration.isDefault(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetRateLimitConfiguration(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing a rate limit", t, func() {
+		serviceConfiguration := &ServiceConfigV1{RateLimit: &RateLimitConfiguration{RequestsPerSecond: 10, Burst: 20}}
+		Convey("When GetRateLimitConfiguration method is called", func() {
+			rateLimitConfiguration := serviceConfiguration.GetRateLimitConfiguration()
+			Convey("Then the rate limit configuration returned should contain the expected settings", func() {
+				So(rateLimitConfiguration, ShouldResemble, &RateLimitConfiguration{RequestsPerSecond: 10, Burst: 20})
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetPollingConfiguration(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing polling settings", t, func() {
+		serviceConfiguration := &ServiceConfigV1{Polling: &PollingConfiguration{Interval: "2s", MinTimeout: "1s"}}
+		Convey("When GetPollingConfiguration method is called", func() {
+			pollingConfiguration := serviceConfiguration.GetPollingConfiguration()
+			Convey("Then the polling configuration returned should contain the expected settings", func() {
+				So(pollingConfiguration, ShouldResemble, &PollingConfiguration{Interval: "2s", MinTimeout: "1s"})
+			})
+		})
+	})
+}
+
+func TestServiceConfigV1GetRetryConfiguration(t *testing.T) {
+	Convey("Given a ServiceConfigV1 containing a retry policy", t, func() {
+		serviceConfiguration := &ServiceConfigV1{Retry: &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s"}}
+		Convey("When GetRetryConfiguration method is called", func() {
+			retryConfiguration := serviceConfiguration.GetRetryConfiguration()
+			Convey("Then the retry configuration returned should contain the expected settings", func() {
+				So(retryConfiguration, ShouldResemble, &RetryConfiguration{MaxRetries: newRetryMaxRetries(5), Backoff: "2s"})
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 without a retry policy", t, func() {
+		serviceConfiguration := &ServiceConfigV1{}
+		Convey("When GetRetryConfiguration method is called", func() {
+			retryConfiguration := serviceConfiguration.GetRetryConfiguration()
+			Convey("Then the retry configuration returned should be nil", func() {
+				So(retryConfiguration, ShouldBeNil)
+			})
+		})
+	})
+}
+
 func TestServiceConfigV1Validate(t *testing.T) {
 	Convey("Given a ServiceConfigV1 containing a valid swagger URL and a specific plugin version", t, func() {
 		var serviceConfiguration ServiceConfiguration
@@ -113,6 +322,101 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a ServiceConfigV1 containing a swagger URL that refers to the standard input", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: openAPIDocumentStdin,
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a file URL of a file that does not exist", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "file:///non/existing/swagger.yaml",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing a swagger SHA-256 checksum that is not valid", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:    "http://sevice-api.com/swagger.yaml",
+			SwaggerSHA256: "abc",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "swagger_sha256 'abc' is not valid, the value must be a hex encoded SHA-256 checksum (64 characters)")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing valid additional swagger documents", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			AdditionalSwaggerDocuments: []SwaggerDocumentConfiguration{
+				{URL: "http://billing-api.com/swagger.yaml", ResourceNamePrefix: "billing"},
+				{URL: "http://users-api.com/swagger.yaml"},
+			},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing additional swagger documents that are not valid", t, func() {
+		testCases := []struct {
+			name          string
+			documents     []SwaggerDocumentConfiguration
+			expectedError string
+		}{
+			{
+				name:          "missing url",
+				documents:     []SwaggerDocumentConfiguration{{ResourceNamePrefix: "billing"}},
+				expectedError: "additional_swagger_documents configuration is missing a value for the 'url' property",
+			},
+			{
+				name:          "url already configured as the swagger-url",
+				documents:     []SwaggerDocumentConfiguration{{URL: "http://sevice-api.com/swagger.yaml"}},
+				expectedError: "additional_swagger_documents url 'http://sevice-api.com/swagger.yaml' is configured multiple times",
+			},
+			{
+				name:          "standard input",
+				documents:     []SwaggerDocumentConfiguration{{URL: "-"}},
+				expectedError: "additional_swagger_documents url '-' is not valid, only the swagger-url can be read from the standard input",
+			},
+			{
+				name:          "invalid checksum",
+				documents:     []SwaggerDocumentConfiguration{{URL: "http://billing-api.com/swagger.yaml", SwaggerSHA256: "abc"}},
+				expectedError: "swagger_sha256 'abc' is not valid, the value must be a hex encoded SHA-256 checksum (64 characters)",
+			},
+			{
+				name:          "invalid resource name prefix",
+				documents:     []SwaggerDocumentConfiguration{{URL: "http://billing-api.com/swagger.yaml", ResourceNamePrefix: "Billing-"}},
+				expectedError: "additional_swagger_documents resource_name_prefix 'Billing-' of url 'http://billing-api.com/swagger.yaml' is not valid, the value must only contain lower case letters, numbers and underscores and start with a letter",
+			},
+		}
+		for _, tc := range testCases {
+			Convey(fmt.Sprintf("When Validate method is called with %s", tc.name), func() {
+				serviceConfiguration := &ServiceConfigV1{
+					SwaggerURL:                 "http://sevice-api.com/swagger.yaml",
+					AdditionalSwaggerDocuments: tc.documents,
+				}
+				err := serviceConfiguration.Validate("0.14.0")
+				Convey("Then the error returned should be the expected one", func() {
+					So(err, ShouldNotBeNil)
+					So(err.Error(), ShouldEqual, tc.expectedError)
+				})
+			})
+		}
+	})
 	Convey("Given a ServiceConfigV1 containing an invalid swagger URL", t, func() {
 		var serviceConfiguration ServiceConfiguration
 		expectedSwaggerURL := "htpt:/non-valid-url"
@@ -122,7 +426,7 @@ func TestServiceConfigV1Validate(t *testing.T) {
 		Convey("When Validate method is called", func() {
 			err := serviceConfiguration.Validate("0.14.0")
 			Convey("Then the error returned should be the expected one", func() {
-				So(err.Error(), ShouldEqual, "service swagger URL configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL or a path to an existing swagger file stored in the disk")
+				So(err.Error(), ShouldEqual, "service swagger URL configuration not valid ('htpt:/non-valid-url'). URL must be either a valid formed URL, a path to an existing swagger file stored in the disk or '-' to read the swagger file from the standard input")
 			})
 		})
 	})
@@ -143,4 +447,128 @@ func TestServiceConfigV1Validate(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a ServiceConfigV1 containing a valid user agent suffix", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+			UserAgentSuffix: "acme-cli/1.2",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing an invalid idle connection timeout", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+			IdleConnTimeout: "not-a-duration",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "idle_conn_timeout 'not-a-duration' is not valid")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a client certificate file that does not exist", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:            "http://sevice-api.com/swagger.yaml",
+			ClientCertificateFile: "/non/existing/client.crt",
+			ClientKeyFile:         "/non/existing/client.key",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "failed to read the client_certificate_file '/non/existing/client.crt'")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a schema configuration with an invalid token ttl", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			SchemaConfigurationV1: []ServiceSchemaPropertyConfigurationV1{
+				{
+					SchemaPropertyName: "apikey_auth",
+					TokenCommand:       []string{"date"},
+					TokenTTL:           "not-a-duration",
+				},
+			},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "schema property 'apikey_auth' token_ttl 'not-a-duration' is not valid")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a user agent suffix with control characters", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:      "http://sevice-api.com/swagger.yaml",
+			UserAgentSuffix: "acme-cli/1.2\r\nX-Injected: value",
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, `user_agent_suffix "acme-cli/1.2\r\nX-Injected: value" contains control characters which are not allowed`)
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing excluded resources with a not valid glob pattern", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL:        "http://sevice-api.com/swagger.yaml",
+			AllowedResources:  []string{"cdn_*"},
+			ExcludedResources: []string{"cdn_[v1"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "excluded_resources contains a not valid pattern 'cdn_[v1': syntax error in pattern")
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a retry policy with a wrong backoff", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Retry:      &RetryConfiguration{Backoff: "wrong"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, `retry backoff 'wrong' is not valid: time: invalid duration "wrong"`)
+			})
+		})
+	})
+
+	Convey("Given a ServiceConfigV1 containing a rate limit without the requests per second", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			RateLimit:  &RateLimitConfiguration{Burst: 5},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "rate_limit requests_per_second '0' is not valid, the value must be greater than zero")
+			})
+		})
+	})
+	Convey("Given a ServiceConfigV1 containing polling settings with a wrong interval", t, func() {
+		serviceConfiguration := &ServiceConfigV1{
+			SwaggerURL: "http://sevice-api.com/swagger.yaml",
+			Polling:    &PollingConfiguration{Interval: "wrong"},
+		}
+		Convey("When Validate method is called", func() {
+			err := serviceConfiguration.Validate("0.14.0")
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "polling interval 'wrong' is not valid: time: invalid duration \"wrong\"")
+			})
+		})
+	})
 }
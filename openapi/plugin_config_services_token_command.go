@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenCommandConfiguration defines the command that should be executed to obtain the token used as the value of a
+// security definition property
+type TokenCommandConfiguration struct {
+	// Command is the command to execute (using exec form). The stdout of the command (trimmed) is used as the token
+	Command []string
+	// Timeout is the maximum amount of time the command is allowed to run for
+	Timeout time.Duration
+	// TTL defines for how long the token is valid. Zero means the token does not expire and it's only refreshed when
+	// the API rejects it
+	TTL time.Duration
+}
+
+// tokenCommand executes the token command configured and caches the token returned until it expires or it gets
+// invalidated. It is safe for concurrent use.
+type tokenCommand struct {
+	schemaPropertyName string
+	config             TokenCommandConfiguration
+	logger             Logger
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenCommand(schemaPropertyName string, config TokenCommandConfiguration, logger Logger) *tokenCommand {
+	return &tokenCommand{
+		schemaPropertyName: schemaPropertyName,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+// getToken returns the cached token if still valid; otherwise the token command is executed to obtain a new token
+func (t *tokenCommand) getToken() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != "" && (t.config.TTL == 0 || time.Now().Before(t.expiresAt)) {
+		return t.token, nil
+	}
+	token, err := t.execute()
+	if err != nil {
+		return "", fmt.Errorf("provider schema property '%s' token command failed: %s", t.schemaPropertyName, err)
+	}
+	t.token = token
+	t.expiresAt = time.Now().Add(t.config.TTL)
+	return t.token, nil
+}
+
+// invalidate discards the cached token so the next call to getToken executes the token command again
+func (t *tokenCommand) invalidate() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.token = ""
+}
+
+// execute runs the token command and returns its stdout trimmed. Note the output of the command is never logged since
+// it contains the token
+func (t *tokenCommand) execute() (string, error) {
+	start := time.Now()
+	loggerOrDefault(t.logger).Debug(fmt.Sprintf("executing '%s' token command '%s'", t.schemaPropertyName, t.config.Command), "property", t.schemaPropertyName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, t.config.Command[0], t.config.Command[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("command '%s' did not finish executing within the expected time %s (%s)", t.config.Command, t.config.Timeout, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("command '%s' failed: %s(%s)", t.config.Command, strings.TrimSpace(stderr.String()), err)
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("command '%s' did not return any token", t.config.Command)
+	}
+	loggerOrDefault(t.logger).Debug(fmt.Sprintf("provider schema property '%s' token command '%s' executed successfully (time:%s)", t.schemaPropertyName, t.config.Command, time.Since(start)), "property", t.schemaPropertyName)
+	return token, nil
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newCountingTokenCommand returns a token command that outputs the number of times it has been executed
+func newCountingTokenCommand(t *testing.T, ttl time.Duration) (*tokenCommand, func()) {
+	counterFile, err := ioutil.TempFile("", "token_command_counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counterFile.Close()
+	command := []string{"sh", "-c", fmt.Sprintf("echo run >> %s; echo \"  token-$(wc -l < %s | tr -d ' ')  \"", counterFile.Name(), counterFile.Name())}
+	return newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: command, Timeout: 5 * time.Second, TTL: ttl}, nil), func() { os.Remove(counterFile.Name()) }
+}
+
+func TestTokenCommandGetToken(t *testing.T) {
+	Convey("Given a token command that outputs a token surrounded by white spaces and does not expire", t, func() {
+		tokenCommand, cleanUp := newCountingTokenCommand(t, 0)
+		defer cleanUp()
+		Convey("When getToken is called", func() {
+			token, err := tokenCommand.getToken()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the token returned should be the command output trimmed", func() {
+				So(token, ShouldEqual, "token-1")
+			})
+			Convey("And the token should be cached for subsequent calls", func() {
+				token, err := tokenCommand.getToken()
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-1")
+			})
+			Convey("And the token command should be executed again once the token has been invalidated", func() {
+				tokenCommand.invalidate()
+				token, err := tokenCommand.getToken()
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-2")
+			})
+		})
+	})
+
+	Convey("Given a token command with a token ttl", t, func() {
+		tokenCommand, cleanUp := newCountingTokenCommand(t, 50*time.Millisecond)
+		defer cleanUp()
+		Convey("When getToken is called after the token has expired", func() {
+			token, err := tokenCommand.getToken()
+			So(err, ShouldBeNil)
+			So(token, ShouldEqual, "token-1")
+			time.Sleep(100 * time.Millisecond)
+			token, err = tokenCommand.getToken()
+			Convey("Then the token command should have been executed again and the new token returned", func() {
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-2")
+			})
+		})
+	})
+
+	Convey("Given a token command that outputs a token", t, func() {
+		tokenCommand := newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"echo", "superSecretToken"}, Timeout: 5 * time.Second}, nil)
+		Convey("When getToken is called", func() {
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+			_, err := tokenCommand.getToken()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the token should never be logged", func() {
+				So(logs.String(), ShouldContainSubstring, "token command '[echo superSecretToken]' executed successfully")
+				So(logs.String(), ShouldNotContainSubstring, "[INFO]")
+				So(logs.String(), ShouldNotContainSubstring, "superSecretToken\n")
+			})
+		})
+	})
+
+	Convey("Given a token command configured with a logger that outputs a token", t, func() {
+		logger := &loggerStub{}
+		tokenCommand := newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"echo", "superSecretToken"}, Timeout: 5 * time.Second}, logger)
+		Convey("When getToken is called", func() {
+			_, err := tokenCommand.getToken()
+			Convey("Then the token command execution should be logged with the logger provided without the token", func() {
+				So(err, ShouldBeNil)
+				So(logger.containsMessage("DEBUG", "executing 'apikey_auth' token command '[echo superSecretToken]'"), ShouldBeTrue)
+				So(logger.messages, ShouldHaveLength, 2)
+				So(logger.messages[1].msg, ShouldStartWith, "provider schema property 'apikey_auth' token command '[echo superSecretToken]' executed successfully")
+			})
+		})
+	})
+
+	Convey("Given a token command that fails", t, func() {
+		tokenCommand := newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"cat", "nonexistingfile"}, Timeout: 5 * time.Second}, nil)
+		Convey("When getToken is called", func() {
+			_, err := tokenCommand.getToken()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "provider schema property 'apikey_auth' token command failed: command '[cat nonexistingfile]' failed: cat: nonexistingfile: No such file or directory(exit status 1)")
+			})
+		})
+	})
+
+	Convey("Given a token command that does not output anything", t, func() {
+		tokenCommand := newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"echo", "  "}, Timeout: 5 * time.Second}, nil)
+		Convey("When getToken is called", func() {
+			_, err := tokenCommand.getToken()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "provider schema property 'apikey_auth' token command failed: command '[echo   ]' did not return any token")
+			})
+		})
+	})
+
+	Convey("Given a token command that timeouts", t, func() {
+		tokenCommand := newTokenCommand("apikey_auth", TokenCommandConfiguration{Command: []string{"sleep", "2"}, Timeout: 100 * time.Millisecond}, nil)
+		Convey("When getToken is called", func() {
+			_, err := tokenCommand.getToken()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "provider schema property 'apikey_auth' token command failed: command '[sleep 2]' did not finish executing within the expected time 100ms (signal: killed)")
+			})
+		})
+	})
+}
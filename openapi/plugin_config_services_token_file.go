@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenFileConfiguration defines the file that should be read to obtain the token used as the value of a security
+// definition property. This is useful when the token is rotated by an external process (e,g: a credential helper or
+// an agent writing short-lived tokens to disk)
+type TokenFileConfiguration struct {
+	// File is the path to the file containing the token. The content of the file (trimmed) is used as the token
+	File string
+	// TTL defines for how long the token is valid. Zero means the token does not expire and the file is only read again
+	// when the API rejects the token
+	TTL time.Duration
+}
+
+// tokenFile reads the token file configured and caches the token until it expires or it gets invalidated. It is safe
+// for concurrent use.
+type tokenFile struct {
+	schemaPropertyName string
+	config             TokenFileConfiguration
+	logger             Logger
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newTokenFile(schemaPropertyName string, config TokenFileConfiguration, logger Logger) *tokenFile {
+	return &tokenFile{
+		schemaPropertyName: schemaPropertyName,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+// getToken returns the cached token if still valid; otherwise the token file is read again to obtain the current token
+func (t *tokenFile) getToken() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != "" && (t.config.TTL == 0 || time.Now().Before(t.expiresAt)) {
+		return t.token, nil
+	}
+	token, err := t.read()
+	if err != nil {
+		return "", fmt.Errorf("provider schema property '%s' token file failed: %s", t.schemaPropertyName, err)
+	}
+	t.token = token
+	t.expiresAt = time.Now().Add(t.config.TTL)
+	return t.token, nil
+}
+
+// invalidate discards the cached token so the next call to getToken reads the token file again
+func (t *tokenFile) invalidate() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.token = ""
+}
+
+// read returns the content of the token file trimmed. Note the content of the file is never logged since it contains
+// the token
+func (t *tokenFile) read() (string, error) {
+	content, err := getFileContent(t.config.File)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the file '%s': %s", t.config.File, err)
+	}
+	token := strings.TrimSpace(content)
+	if token == "" {
+		return "", fmt.Errorf("file '%s' does not contain any token", t.config.File)
+	}
+	loggerOrDefault(t.logger).Debug(fmt.Sprintf("provider schema property '%s' token read from file '%s'", t.schemaPropertyName, t.config.File), "property", t.schemaPropertyName)
+	return token, nil
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// newTestTokenFile returns a token file containing the token provided and a function to update the token in the file
+func newTestTokenFile(t *testing.T, token string, ttl time.Duration) (*tokenFile, func(string), func()) {
+	file, err := ioutil.TempFile("", "token_file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	writeToken := func(token string) {
+		if err := ioutil.WriteFile(file.Name(), []byte(token), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeToken(token)
+	return newTokenFile("apikey_auth", TokenFileConfiguration{File: file.Name(), TTL: ttl}, nil), writeToken, func() { os.Remove(file.Name()) }
+}
+
+func TestTokenFileGetToken(t *testing.T) {
+	Convey("Given a token file containing a token surrounded by white spaces and that does not expire", t, func() {
+		tokenFile, writeToken, cleanUp := newTestTokenFile(t, "  token-1\n", 0)
+		defer cleanUp()
+		Convey("When getToken is called", func() {
+			token, err := tokenFile.getToken()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the token returned should be the file content trimmed", func() {
+				So(token, ShouldEqual, "token-1")
+			})
+			Convey("And the token should be cached for subsequent calls even if the file changes", func() {
+				writeToken("token-2")
+				token, err := tokenFile.getToken()
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-1")
+			})
+			Convey("And the token file should be read again once the token has been invalidated", func() {
+				writeToken("token-2")
+				tokenFile.invalidate()
+				token, err := tokenFile.getToken()
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-2")
+			})
+		})
+	})
+
+	Convey("Given a token file with a token ttl", t, func() {
+		tokenFile, writeToken, cleanUp := newTestTokenFile(t, "token-1", 50*time.Millisecond)
+		defer cleanUp()
+		Convey("When getToken is called after the token has expired", func() {
+			token, err := tokenFile.getToken()
+			So(err, ShouldBeNil)
+			So(token, ShouldEqual, "token-1")
+			writeToken("token-2")
+			time.Sleep(100 * time.Millisecond)
+			token, err = tokenFile.getToken()
+			Convey("Then the token file should have been read again and the new token returned", func() {
+				So(err, ShouldBeNil)
+				So(token, ShouldEqual, "token-2")
+			})
+		})
+	})
+
+	Convey("Given a token file containing a token", t, func() {
+		tokenFile, _, cleanUp := newTestTokenFile(t, "superSecretToken", 0)
+		defer cleanUp()
+		Convey("When getToken is called", func() {
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+			_, err := tokenFile.getToken()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the token should never be logged", func() {
+				So(logs.String(), ShouldContainSubstring, "token read from file")
+				So(logs.String(), ShouldNotContainSubstring, "superSecretToken")
+			})
+		})
+	})
+
+	Convey("Given a token file containing a token configured with a logger", t, func() {
+		tokenFile, _, cleanUp := newTestTokenFile(t, "superSecretToken", 0)
+		defer cleanUp()
+		logger := &loggerStub{}
+		tokenFile.logger = logger
+		Convey("When getToken is called", func() {
+			_, err := tokenFile.getToken()
+			Convey("Then the token file read should be logged with the logger provided without the token", func() {
+				So(err, ShouldBeNil)
+				So(logger.containsMessage("DEBUG", "provider schema property 'apikey_auth' token read from file '"+tokenFile.config.File+"'"), ShouldBeTrue)
+				So(logger.messages, ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given a token file that is empty", t, func() {
+		tokenFile, _, cleanUp := newTestTokenFile(t, " \n", 0)
+		defer cleanUp()
+		Convey("When getToken is called", func() {
+			_, err := tokenFile.getToken()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "provider schema property 'apikey_auth' token file failed: file '"+tokenFile.config.File+"' does not contain any token")
+			})
+		})
+	})
+
+	Convey("Given a token file that does not exist", t, func() {
+		tokenFile := newTokenFile("apikey_auth", TokenFileConfiguration{File: "/non/existing/token"}, nil)
+		Convey("When getToken is called", func() {
+			_, err := tokenFile.getToken()
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "provider schema property 'apikey_auth' token file failed: failed to read the file '/non/existing/token'")
+			})
+		})
+	})
+}
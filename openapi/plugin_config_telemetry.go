@@ -1,12 +1,85 @@
 package openapi
 
-// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported. At the moment
-// only Graphite is supported.
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TelemetryProvider holds the behaviour expected to be implemented for the Telemetry Providers supported (Graphite, http
+// endpoint, statsd, otlp and prometheus pushgateway). The tags received contain the dimensions of the metric submitted
+// which the providers supporting tagged metrics ship along with the metric.
 type TelemetryProvider interface {
 	// Validate performs a check to confirm that the telemetry configuration is valid
 	Validate() error
 	// IncOpenAPIPluginVersionTotalRunsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for the OpenAPI plugin Version used
-	IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string) error
+	IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error
 	// IncServiceProviderTotalRunsCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for the service provider used
-	IncServiceProviderTotalRunsCounter(providerName string) error
+	IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error
+	// SubmitResourceOperationTimingMetric is the method responsible for submitting to the corresponding telemetry platform the time it took to perform the resource operation (create, read, update or delete)
+	SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error
+	// IncResourceOperationTotalCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for the resource operation performed
+	IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error
+	// IncResourceOperationErrorsTotalCounter is the method responsible for submitting to the corresponding telemetry platform the counter increase for the resource operation that failed
+	IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error
+}
+
+// TelemetryTags contains the tags (dimensions) describing the context in which the metric was submitted (e,g: provider
+// name, OpenAPI plugin version, terraform version, resource name, etc) so downstream systems can aggregate the metrics
+// across dimensions instead of parsing the metric names
+type TelemetryTags map[string]string
+
+const (
+	telemetryTagProviderName         = "provider_name"
+	telemetryTagOpenAPIPluginVersion = "openapi_plugin_version"
+	telemetryTagTerraformVersion     = "terraform_version"
+	telemetryTagResourceName         = "resource_name"
+	telemetryTagOperation            = "operation"
+)
+
+// sortedNames returns the tag names sorted alphabetically so the tags are always submitted in the same order
+func (t TelemetryTags) sortedNames() []string {
+	var names []string
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TelemetryResourceOperation describes the resource operations instrumented with telemetry
+type TelemetryResourceOperation string
+
+const (
+	// TelemetryResourceOperationCreate describes the resource create operation
+	TelemetryResourceOperationCreate TelemetryResourceOperation = "create"
+	// TelemetryResourceOperationRead describes the resource read operation
+	TelemetryResourceOperationRead TelemetryResourceOperation = "read"
+	// TelemetryResourceOperationUpdate describes the resource update operation
+	TelemetryResourceOperationUpdate TelemetryResourceOperation = "update"
+	// TelemetryResourceOperationDelete describes the resource delete operation
+	TelemetryResourceOperationDelete TelemetryResourceOperation = "delete"
+)
+
+// buildResourceOperationTimingMetricName returns the name of the timing metric 'terraform.providers.%s.resources.%s.%s.duration'
+// used by the telemetry providers that do not support tags/attributes
+func buildResourceOperationTimingMetricName(providerName, resourceName string, operation TelemetryResourceOperation) string {
+	return fmt.Sprintf("terraform.providers.%s.resources.%s.%s.duration", providerName, resourceName, operation)
+}
+
+// buildResourceOperationTotalCounterName returns the name of the counter 'terraform.providers.%s.%s.%s.total' used by
+// the telemetry providers that do not support tags/attributes
+func buildResourceOperationTotalCounterName(providerName, resourceName string, operation TelemetryResourceOperation) string {
+	return fmt.Sprintf("terraform.providers.%s.%s.%s.total", providerName, resourceName, operation)
+}
+
+// buildResourceOperationErrorsTotalCounterName returns the name of the counter 'terraform.providers.%s.%s.%s.errors.total'
+// used by the telemetry providers that do not support tags/attributes
+func buildResourceOperationErrorsTotalCounterName(providerName, resourceName string, operation TelemetryResourceOperation) string {
+	return fmt.Sprintf("terraform.providers.%s.%s.%s.errors.total", providerName, resourceName, operation)
+}
+
+// durationInMilliseconds returns the duration in milliseconds (including the fraction of milliseconds)
+func durationInMilliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
 }
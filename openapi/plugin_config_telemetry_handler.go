@@ -1,7 +1,8 @@
 package openapi
 
 import (
-	"log"
+	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,6 +13,18 @@ import (
 type TelemetryHandler interface {
 	// SubmitMetrics
 	SubmitMetrics()
+	// Flush makes sure the metrics submitted asynchronously are shipped before the provider shuts down. Handlers that
+	// submit the metrics synchronously do not need to do anything
+	Flush()
+	// SubmitResourceOperationTimingMetric submits the time it took to perform the resource operation to all the
+	// telemetry providers registered
+	SubmitResourceOperationTimingMetric(resourceName string, operation TelemetryResourceOperation, duration time.Duration)
+	// IncResourceOperationCounters increments the resource operation total counter (and the errors counter if the
+	// operation failed) in all the telemetry providers registered
+	IncResourceOperationCounters(resourceName string, operation TelemetryResourceOperation, failed bool)
+	// SetTerraformVersion sets the terraform version used to configure the provider so it can be tagged in the resource
+	// operation metrics submitted afterwards
+	SetTerraformVersion(terraformVersion string)
 }
 
 const telemetryTimeout = 2
@@ -21,24 +34,180 @@ type telemetryHandlerTimeoutSupport struct {
 	providerName       string
 	openAPIVersion     string
 	telemetryProviders []TelemetryProvider
+	logger             Logger
+	// terraformVersion holds the terraform version once terraform configures the provider. The value is shared by all
+	// the copies of the handler (e,g: the one referenced by the resource factories)
+	terraformVersion *atomic.Value
+	// maxRetries is the number of times a failed metric submission is retried, waiting retryBackoff before the first
+	// retry and doubling the wait after each retry
+	maxRetries   int
+	retryBackoff time.Duration
+	// circuitBreakers holds the circuit breaker of each of the telemetry providers (same order as telemetryProviders).
+	// If nil, the telemetry providers are never disabled
+	circuitBreakers []*telemetryCircuitBreaker
 }
 
 // MetricSubmitter is the function holding the logic that actually submits the metric
 type MetricSubmitter func() error
 
+// telemetryMetricSubmission holds the name of the metric and the submitter function that ships it
+type telemetryMetricSubmission struct {
+	name      string
+	submitter MetricSubmitter
+	// circuitBreaker (optional) is the circuit breaker of the telemetry provider the metric is submitted to
+	circuitBreaker *telemetryCircuitBreaker
+}
+
 func (t telemetryHandlerTimeoutSupport) SubmitMetrics() {
-	for _, telemetryProvider := range t.telemetryProviders {
-		t.submitMetric("IncServiceProviderTotalRunsCounter", func() error {
-			return telemetryProvider.IncServiceProviderTotalRunsCounter(t.providerName)
-		})
-		t.submitMetric("IncOpenAPIPluginVersionTotalRunsCounter", func() error {
-			return telemetryProvider.IncOpenAPIPluginVersionTotalRunsCounter(t.openAPIVersion)
-		})
+	for _, metric := range t.getMetrics() {
+		t.submit(metric)
+	}
+}
+
+// Flush does nothing since the metrics are submitted synchronously
+func (t telemetryHandlerTimeoutSupport) Flush() {}
+
+// SubmitResourceOperationTimingMetric submits the timing metric synchronously, each submission being bounded by the
+// timeout configured
+func (t telemetryHandlerTimeoutSupport) SubmitResourceOperationTimingMetric(resourceName string, operation TelemetryResourceOperation, duration time.Duration) {
+	for _, metric := range t.getResourceOperationTimingMetrics(resourceName, operation, duration) {
+		t.submit(metric)
 	}
 }
 
+// IncResourceOperationCounters submits the resource operation counters synchronously, each submission being bounded by
+// the timeout configured
+func (t telemetryHandlerTimeoutSupport) IncResourceOperationCounters(resourceName string, operation TelemetryResourceOperation, failed bool) {
+	for _, metric := range t.getResourceOperationCounterMetrics(resourceName, operation, failed) {
+		t.submit(metric)
+	}
+}
+
+// SetTerraformVersion stores the terraform version so it is tagged in the metrics submitted afterwards
+func (t telemetryHandlerTimeoutSupport) SetTerraformVersion(terraformVersion string) {
+	if t.terraformVersion != nil {
+		t.terraformVersion.Store(terraformVersion)
+	}
+}
+
+// getTags returns the tags shared by all the metrics: the provider name, the OpenAPI plugin version and the terraform
+// version (if known at the time the metric is submitted)
+func (t telemetryHandlerTimeoutSupport) getTags() TelemetryTags {
+	tags := TelemetryTags{
+		telemetryTagProviderName:         t.providerName,
+		telemetryTagOpenAPIPluginVersion: t.openAPIVersion,
+	}
+	if t.terraformVersion != nil {
+		if terraformVersion, ok := t.terraformVersion.Load().(string); ok && terraformVersion != "" {
+			tags[telemetryTagTerraformVersion] = terraformVersion
+		}
+	}
+	return tags
+}
+
+// getResourceOperationTags returns the tags shared by all the metrics plus the resource name and the operation
+func (t telemetryHandlerTimeoutSupport) getResourceOperationTags(resourceName string, operation TelemetryResourceOperation) TelemetryTags {
+	tags := t.getTags()
+	tags[telemetryTagResourceName] = resourceName
+	tags[telemetryTagOperation] = string(operation)
+	return tags
+}
+
+// getMetrics returns the metrics to be submitted for each of the telemetry providers configured
+func (t telemetryHandlerTimeoutSupport) getMetrics() []telemetryMetricSubmission {
+	var metrics []telemetryMetricSubmission
+	tags := t.getTags()
+	for i, telemetryProvider := range t.telemetryProviders {
+		telemetryProvider := telemetryProvider
+		circuitBreaker := t.getCircuitBreaker(i)
+		metrics = append(metrics,
+			telemetryMetricSubmission{name: "IncServiceProviderTotalRunsCounter", submitter: func() error {
+				return telemetryProvider.IncServiceProviderTotalRunsCounter(t.providerName, tags)
+			}, circuitBreaker: circuitBreaker},
+			telemetryMetricSubmission{name: "IncOpenAPIPluginVersionTotalRunsCounter", submitter: func() error {
+				return telemetryProvider.IncOpenAPIPluginVersionTotalRunsCounter(t.openAPIVersion, tags)
+			}, circuitBreaker: circuitBreaker})
+	}
+	return metrics
+}
+
+// getResourceOperationTimingMetrics returns the timing metrics to be submitted for each of the telemetry providers configured
+func (t telemetryHandlerTimeoutSupport) getResourceOperationTimingMetrics(resourceName string, operation TelemetryResourceOperation, duration time.Duration) []telemetryMetricSubmission {
+	var metrics []telemetryMetricSubmission
+	tags := t.getResourceOperationTags(resourceName, operation)
+	for i, telemetryProvider := range t.telemetryProviders {
+		telemetryProvider := telemetryProvider
+		circuitBreaker := t.getCircuitBreaker(i)
+		metrics = append(metrics, telemetryMetricSubmission{name: "SubmitResourceOperationTimingMetric", submitter: func() error {
+			return telemetryProvider.SubmitResourceOperationTimingMetric(t.providerName, resourceName, operation, duration, tags)
+		}, circuitBreaker: circuitBreaker})
+	}
+	return metrics
+}
+
+// getResourceOperationCounterMetrics returns the resource operation counters to be submitted for each of the telemetry
+// providers configured. The errors counter is only included if the operation failed
+func (t telemetryHandlerTimeoutSupport) getResourceOperationCounterMetrics(resourceName string, operation TelemetryResourceOperation, failed bool) []telemetryMetricSubmission {
+	var metrics []telemetryMetricSubmission
+	tags := t.getResourceOperationTags(resourceName, operation)
+	for i, telemetryProvider := range t.telemetryProviders {
+		telemetryProvider := telemetryProvider
+		circuitBreaker := t.getCircuitBreaker(i)
+		metrics = append(metrics, telemetryMetricSubmission{name: "IncResourceOperationTotalCounter", submitter: func() error {
+			return telemetryProvider.IncResourceOperationTotalCounter(t.providerName, resourceName, operation, tags)
+		}, circuitBreaker: circuitBreaker})
+		if failed {
+			metrics = append(metrics, telemetryMetricSubmission{name: "IncResourceOperationErrorsTotalCounter", submitter: func() error {
+				return telemetryProvider.IncResourceOperationErrorsTotalCounter(t.providerName, resourceName, operation, tags)
+			}, circuitBreaker: circuitBreaker})
+		}
+	}
+	return metrics
+}
+
+// getCircuitBreaker returns the circuit breaker of the telemetry provider at the given position, nil if the circuit
+// breaker is not configured
+func (t telemetryHandlerTimeoutSupport) getCircuitBreaker(telemetryProviderIndex int) *telemetryCircuitBreaker {
+	if telemetryProviderIndex < len(t.circuitBreakers) {
+		return t.circuitBreakers[telemetryProviderIndex]
+	}
+	return nil
+}
+
 func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSubmitter MetricSubmitter) {
-	doneChan := make(chan error)
+	t.submit(telemetryMetricSubmission{name: metricName, submitter: metricSubmitter})
+}
+
+// submit submits the metric retrying the failed attempts (if configured). The metric is not submitted if the circuit
+// breaker of the telemetry provider is open, and the failures are recorded in the circuit breaker
+func (t telemetryHandlerTimeoutSupport) submit(metric telemetryMetricSubmission) {
+	if metric.circuitBreaker.isOpen() {
+		loggerOrDefault(t.logger).Debug(fmt.Sprintf("metric '%s' not submitted since the telemetry provider has been disabled due to consecutive failures", metric.name))
+		return
+	}
+	backoff := t.retryBackoff
+	var err error
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if err = t.submitMetricAttempt(metric.name, metric.submitter); err == nil {
+			metric.circuitBreaker.recordSuccess()
+			return
+		}
+		if attempt < t.maxRetries {
+			loggerOrDefault(t.logger).Debug(fmt.Sprintf("%s, retrying in %s", err, backoff))
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	loggerOrDefault(t.logger).Debug(err.Error())
+	if metric.circuitBreaker.recordFailure() {
+		loggerOrDefault(t.logger).Warn(fmt.Sprintf("telemetry provider disabled for the rest of the run after %d consecutive failed submissions", metric.circuitBreaker.failureThreshold))
+	}
+}
+
+// submitMetricAttempt submits the metric returning an error if the submission failed or did not finish within the
+// timeout configured
+func (t telemetryHandlerTimeoutSupport) submitMetricAttempt(metricName string, metricSubmitter MetricSubmitter) error {
+	doneChan := make(chan error, 1)
 	go func() {
 		doneChan <- metricSubmitter()
 	}()
@@ -46,9 +215,10 @@ func (t telemetryHandlerTimeoutSupport) submitMetric(metricName string, metricSu
 	select {
 	case err := <-doneChan:
 		if err != nil {
-			log.Printf("metric '%s' submission failed: %s", metricName, err)
+			return fmt.Errorf("metric '%s' submission failed: %s", metricName, err)
 		}
+		return nil
 	case <-time.After(time.Duration(t.timeout) * time.Second):
-		log.Printf("metric '%s' submission did not finish within the expected time %ds", metricName, t.timeout)
+		return fmt.Errorf("metric '%s' submission did not finish within the expected time %ds", metricName, t.timeout)
 	}
 }
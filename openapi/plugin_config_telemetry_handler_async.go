@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const telemetryAsyncDefaultBufferSize = 100
+const telemetryAsyncDefaultFlushTimeout = 5 * time.Second
+const telemetryAsyncDefaultBatchSize = 10
+const telemetryAsyncDefaultFlushInterval = time.Second
+
+// TelemetryAsyncConfig contains the configuration needed to submit the telemetry metrics asynchronously. When present,
+// the metrics will be enqueued and shipped by a background worker so the provider execution does not wait for them
+type TelemetryAsyncConfig struct {
+	// BufferSize defines the maximum number of metrics that can be pending to be submitted. Metrics enqueued when the
+	// buffer is full will be dropped. If not provided the default buffer size (100) will be used
+	BufferSize int `yaml:"buffer_size,omitempty"`
+	// FlushTimeout defines the maximum amount of time to wait for the pending metrics to be submitted when the provider
+	// shuts down (e,g: 5s). If not provided the default flush timeout (5s) will be used
+	FlushTimeout string `yaml:"flush_timeout,omitempty"`
+	// BatchSize defines the maximum number of metrics submitted together by the background worker. The metrics in a
+	// batch are submitted concurrently. If not provided the default batch size (10) will be used
+	BatchSize int `yaml:"batch_size,omitempty"`
+	// FlushInterval defines how often the background worker submits the metrics batched so far even if the batch is not
+	// full (e,g: 1s). If not provided the default flush interval (1s) will be used
+	FlushInterval string `yaml:"flush_interval,omitempty"`
+}
+
+// Validate checks whether the async configuration is valid
+func (t *TelemetryAsyncConfig) Validate() error {
+	if t.BufferSize < 0 {
+		return fmt.Errorf("telemetry async buffer_size '%d' is not valid, the value must be a positive number", t.BufferSize)
+	}
+	if t.FlushTimeout != "" {
+		flushTimeout, err := time.ParseDuration(t.FlushTimeout)
+		if err != nil {
+			return fmt.Errorf("telemetry async flush_timeout '%s' is not valid: %s", t.FlushTimeout, err)
+		}
+		if flushTimeout < 0 {
+			return fmt.Errorf("telemetry async flush_timeout '%s' is not valid, the value must be a positive duration", t.FlushTimeout)
+		}
+	}
+	if t.BatchSize < 0 {
+		return fmt.Errorf("telemetry async batch_size '%d' is not valid, the value must be a positive number", t.BatchSize)
+	}
+	if t.FlushInterval != "" {
+		flushInterval, err := time.ParseDuration(t.FlushInterval)
+		if err != nil {
+			return fmt.Errorf("telemetry async flush_interval '%s' is not valid: %s", t.FlushInterval, err)
+		}
+		if flushInterval <= 0 {
+			return fmt.Errorf("telemetry async flush_interval '%s' is not valid, the value must be a positive duration", t.FlushInterval)
+		}
+	}
+	return nil
+}
+
+func (t *TelemetryAsyncConfig) getBufferSize() int {
+	if t.BufferSize == 0 {
+		return telemetryAsyncDefaultBufferSize
+	}
+	return t.BufferSize
+}
+
+func (t *TelemetryAsyncConfig) getFlushTimeout() time.Duration {
+	flushTimeout, err := time.ParseDuration(t.FlushTimeout)
+	if err != nil || flushTimeout == 0 {
+		return telemetryAsyncDefaultFlushTimeout
+	}
+	return flushTimeout
+}
+
+func (t *TelemetryAsyncConfig) getBatchSize() int {
+	if t.BatchSize == 0 {
+		return telemetryAsyncDefaultBatchSize
+	}
+	return t.BatchSize
+}
+
+func (t *TelemetryAsyncConfig) getFlushInterval() time.Duration {
+	flushInterval, err := time.ParseDuration(t.FlushInterval)
+	if err != nil || flushInterval <= 0 {
+		return telemetryAsyncDefaultFlushInterval
+	}
+	return flushInterval
+}
+
+// telemetryHandlerAsync is a TelemetryHandler that enqueues the metrics onto a bounded buffer which is drained by a
+// background worker. The worker groups the metrics in batches which are submitted when the batch is full, when the
+// flush interval elapses or when the handler is flushed. The metrics are submitted using the timeout support provided
+// by the wrapped handler.
+type telemetryHandlerAsync struct {
+	// droppedMetrics is kept as the first field so it's 64-bit aligned as required by the atomic operations
+	droppedMetrics uint64
+	handler        telemetryHandlerTimeoutSupport
+	metrics        chan telemetryMetricSubmission
+	// batchSize is the maximum number of metrics submitted together, values lower than 1 submit the metrics one by one
+	batchSize int
+	// flushInterval is how often the metrics batched are submitted, zero disables the periodic submission
+	flushInterval time.Duration
+	flushTimeout  time.Duration
+	done          chan struct{}
+	// mutex protects the metrics channel from being written once it's been closed by Flush
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// newTelemetryHandlerAsync creates a telemetryHandlerAsync and starts the background worker that submits the metrics
+func newTelemetryHandlerAsync(handler telemetryHandlerTimeoutSupport, bufferSize, batchSize int, flushInterval, flushTimeout time.Duration) *telemetryHandlerAsync {
+	t := &telemetryHandlerAsync{
+		handler:       handler,
+		metrics:       make(chan telemetryMetricSubmission, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		flushTimeout:  flushTimeout,
+		done:          make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// SubmitMetrics enqueues the metrics for all the telemetry providers without blocking. If the buffer is full the
+// metric is dropped
+func (t *telemetryHandlerAsync) SubmitMetrics() {
+	for _, metric := range t.handler.getMetrics() {
+		t.enqueue(metric)
+	}
+}
+
+// SubmitResourceOperationTimingMetric enqueues the timing metric for all the telemetry providers without blocking the
+// resource operation. If the buffer is full the metric is dropped
+func (t *telemetryHandlerAsync) SubmitResourceOperationTimingMetric(resourceName string, operation TelemetryResourceOperation, duration time.Duration) {
+	for _, metric := range t.handler.getResourceOperationTimingMetrics(resourceName, operation, duration) {
+		t.enqueue(metric)
+	}
+}
+
+// IncResourceOperationCounters enqueues the resource operation counters for all the telemetry providers without
+// blocking the resource operation. If the buffer is full the metrics are dropped
+func (t *telemetryHandlerAsync) IncResourceOperationCounters(resourceName string, operation TelemetryResourceOperation, failed bool) {
+	for _, metric := range t.handler.getResourceOperationCounterMetrics(resourceName, operation, failed) {
+		t.enqueue(metric)
+	}
+}
+
+// SetTerraformVersion stores the terraform version so it is tagged in the metrics enqueued afterwards
+func (t *telemetryHandlerAsync) SetTerraformVersion(terraformVersion string) {
+	t.handler.SetTerraformVersion(terraformVersion)
+}
+
+// Flush stops accepting new metrics and waits for the pending ones to be submitted up to the flush timeout configured
+func (t *telemetryHandlerAsync) Flush() {
+	t.mutex.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.metrics)
+	}
+	t.mutex.Unlock()
+
+	select {
+	case <-t.done:
+	case <-time.After(t.flushTimeout):
+		t.getLogger().Warn(fmt.Sprintf("telemetry flush did not finish within the expected time %s, pending metrics will be discarded", t.flushTimeout))
+	}
+	if dropped := t.getDroppedMetrics(); dropped > 0 {
+		t.getLogger().Warn(fmt.Sprintf("%d telemetry metrics were dropped and not submitted", dropped))
+	}
+}
+
+func (t *telemetryHandlerAsync) enqueue(metric telemetryMetricSubmission) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	if t.closed {
+		t.drop(metric, "the telemetry handler has already been flushed")
+		return
+	}
+	select {
+	case t.metrics <- metric:
+	default:
+		t.drop(metric, fmt.Sprintf("the telemetry buffer is full (buffer size %d)", cap(t.metrics)))
+	}
+}
+
+func (t *telemetryHandlerAsync) drop(metric telemetryMetricSubmission, reason string) {
+	atomic.AddUint64(&t.droppedMetrics, 1)
+	t.getLogger().Warn(fmt.Sprintf("metric '%s' dropped since %s", metric.name, reason))
+}
+
+func (t *telemetryHandlerAsync) getDroppedMetrics() uint64 {
+	return atomic.LoadUint64(&t.droppedMetrics)
+}
+
+// run drains the metrics buffer until it is closed, submitting the metrics in batches
+func (t *telemetryHandlerAsync) run() {
+	defer close(t.done)
+	var flushIntervalTicks <-chan time.Time
+	if t.flushInterval > 0 {
+		ticker := time.NewTicker(t.flushInterval)
+		defer ticker.Stop()
+		flushIntervalTicks = ticker.C
+	}
+	var batch []telemetryMetricSubmission
+	for {
+		select {
+		case metric, ok := <-t.metrics:
+			if !ok {
+				t.submitBatch(batch)
+				return
+			}
+			batch = append(batch, metric)
+			if len(batch) >= t.batchSize {
+				t.submitBatch(batch)
+				batch = nil
+			}
+		case <-flushIntervalTicks:
+			t.submitBatch(batch)
+			batch = nil
+		}
+	}
+}
+
+// submitBatch submits the metrics in the batch concurrently and waits for all the submissions to finish (each of them
+// being bounded by the timeout configured in the wrapped handler)
+func (t *telemetryHandlerAsync) submitBatch(batch []telemetryMetricSubmission) {
+	var wg sync.WaitGroup
+	for _, metric := range batch {
+		wg.Add(1)
+		go func(metric telemetryMetricSubmission) {
+			defer wg.Done()
+			t.handler.submit(metric)
+		}(metric)
+	}
+	wg.Wait()
+}
+
+func (t *telemetryHandlerAsync) getLogger() Logger {
+	return loggerOrDefault(t.handler.logger)
+}
@@ -0,0 +1,269 @@
+package openapi
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryAsyncConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		asyncConfig   TelemetryAsyncConfig
+		expectedError error
+	}{
+		{
+			name:          "async config with default values",
+			asyncConfig:   TelemetryAsyncConfig{},
+			expectedError: nil,
+		},
+		{
+			name:          "async config with buffer size and flush timeout",
+			asyncConfig:   TelemetryAsyncConfig{BufferSize: 10, FlushTimeout: "10s"},
+			expectedError: nil,
+		},
+		{
+			name:          "async config with negative buffer size",
+			asyncConfig:   TelemetryAsyncConfig{BufferSize: -1},
+			expectedError: errors.New("telemetry async buffer_size '-1' is not valid, the value must be a positive number"),
+		},
+		{
+			name:          "async config with wrong flush timeout",
+			asyncConfig:   TelemetryAsyncConfig{FlushTimeout: "wrong"},
+			expectedError: errors.New("telemetry async flush_timeout 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:          "async config with negative flush timeout",
+			asyncConfig:   TelemetryAsyncConfig{FlushTimeout: "-1s"},
+			expectedError: errors.New("telemetry async flush_timeout '-1s' is not valid, the value must be a positive duration"),
+		},
+		{
+			name:          "async config with batch size and flush interval",
+			asyncConfig:   TelemetryAsyncConfig{BatchSize: 5, FlushInterval: "500ms"},
+			expectedError: nil,
+		},
+		{
+			name:          "async config with negative batch size",
+			asyncConfig:   TelemetryAsyncConfig{BatchSize: -1},
+			expectedError: errors.New("telemetry async batch_size '-1' is not valid, the value must be a positive number"),
+		},
+		{
+			name:          "async config with wrong flush interval",
+			asyncConfig:   TelemetryAsyncConfig{FlushInterval: "wrong"},
+			expectedError: errors.New("telemetry async flush_interval 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:          "async config with zero flush interval",
+			asyncConfig:   TelemetryAsyncConfig{FlushInterval: "0s"},
+			expectedError: errors.New("telemetry async flush_interval '0s' is not valid, the value must be a positive duration"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.asyncConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestTelemetryAsyncConfigDefaults(t *testing.T) {
+	asyncConfig := TelemetryAsyncConfig{}
+	assert.Equal(t, telemetryAsyncDefaultBufferSize, asyncConfig.getBufferSize())
+	assert.Equal(t, telemetryAsyncDefaultFlushTimeout, asyncConfig.getFlushTimeout())
+
+	assert.Equal(t, telemetryAsyncDefaultBatchSize, asyncConfig.getBatchSize())
+	assert.Equal(t, telemetryAsyncDefaultFlushInterval, asyncConfig.getFlushInterval())
+
+	asyncConfig = TelemetryAsyncConfig{BufferSize: 5, FlushTimeout: "1m", BatchSize: 3, FlushInterval: "2s"}
+	assert.Equal(t, 5, asyncConfig.getBufferSize())
+	assert.Equal(t, time.Minute, asyncConfig.getFlushTimeout())
+	assert.Equal(t, 3, asyncConfig.getBatchSize())
+	assert.Equal(t, 2*time.Second, asyncConfig.getFlushInterval())
+}
+
+func TestTelemetryHandlerAsyncSubmitMetrics(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	logger := &loggerStub{}
+	handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{
+		timeout:            1,
+		providerName:       "providerName",
+		openAPIVersion:     "0.25.0",
+		telemetryProviders: []TelemetryProvider{stub},
+		logger:             logger,
+	}, 10, 1, 0, time.Second)
+
+	handler.SubmitMetrics()
+	handler.Flush()
+
+	assert.Equal(t, "0.25.0", stub.openAPIPluginVersionReceived)
+	assert.Equal(t, "providerName", stub.providerNameReceived)
+	assert.Equal(t, uint64(0), handler.getDroppedMetrics())
+	assert.Empty(t, logger.messages)
+}
+
+func TestTelemetryHandlerAsyncSubmitMetricsBufferFull(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	logger := &loggerStub{}
+	// The handler is created without starting the background worker so the buffer is not drained
+	handler := &telemetryHandlerAsync{
+		handler: telemetryHandlerTimeoutSupport{
+			timeout:            1,
+			providerName:       "providerName",
+			openAPIVersion:     "0.25.0",
+			telemetryProviders: []TelemetryProvider{stub},
+			logger:             logger,
+		},
+		metrics: make(chan telemetryMetricSubmission, 1),
+		done:    make(chan struct{}),
+	}
+
+	handler.SubmitMetrics()
+
+	assert.Equal(t, uint64(1), handler.getDroppedMetrics())
+	assert.True(t, logger.containsMessage("WARN", "metric 'IncOpenAPIPluginVersionTotalRunsCounter' dropped since the telemetry buffer is full (buffer size 1)"))
+	assert.Equal(t, "", stub.openAPIPluginVersionReceived)
+}
+
+func TestTelemetryHandlerAsyncFlush(t *testing.T) {
+	t.Run("flush submits the pending metrics", func(t *testing.T) {
+		submitted := make(chan string, 2)
+		logger := &loggerStub{}
+		handler := &telemetryHandlerAsync{
+			handler:      telemetryHandlerTimeoutSupport{timeout: 1, logger: logger},
+			metrics:      make(chan telemetryMetricSubmission, 2),
+			flushTimeout: time.Second,
+			done:         make(chan struct{}),
+		}
+		handler.enqueue(telemetryMetricSubmission{name: "someMetricName", submitter: func() error {
+			submitted <- "someMetricName"
+			return nil
+		}})
+		go handler.run()
+		handler.Flush()
+		assert.Equal(t, "someMetricName", <-submitted)
+		assert.Empty(t, logger.messages)
+	})
+
+	t.Run("flush does not wait longer than the flush timeout", func(t *testing.T) {
+		logger := &loggerStub{}
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 2, logger: logger}, 1, 1, 0, 10*time.Millisecond)
+		handler.enqueue(telemetryMetricSubmission{name: "someMetricName", submitter: func() error {
+			time.Sleep(time.Second)
+			return nil
+		}})
+		handler.Flush()
+		assert.True(t, logger.containsMessage("WARN", "telemetry flush did not finish within the expected time 10ms, pending metrics will be discarded"))
+	})
+
+	t.Run("metrics submitted after the handler has been flushed are dropped", func(t *testing.T) {
+		logger := &loggerStub{}
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 1, logger: logger}, 1, 1, 0, time.Second)
+		handler.Flush()
+		handler.enqueue(telemetryMetricSubmission{name: "someMetricName", submitter: func() error { return nil }})
+		handler.Flush()
+		assert.Equal(t, uint64(1), handler.getDroppedMetrics())
+		assert.True(t, logger.containsMessage("WARN", "metric 'someMetricName' dropped since the telemetry handler has already been flushed"))
+		assert.True(t, logger.containsMessage("WARN", "1 telemetry metrics were dropped and not submitted"))
+	})
+}
+
+func TestTelemetryHandlerAsyncSubmitResourceOperationTimingMetric(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{
+		timeout:            1,
+		providerName:       "providerName",
+		telemetryProviders: []TelemetryProvider{stub},
+	}, 10, 1, 0, time.Second)
+
+	handler.SubmitResourceOperationTimingMetric("cdn_v1", TelemetryResourceOperationDelete, time.Second)
+	handler.Flush()
+
+	assert.Equal(t, "providerName", stub.providerNameReceived)
+	assert.Equal(t, "cdn_v1", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationDelete, stub.resourceOperationReceived)
+	assert.Equal(t, time.Second, stub.durationReceived)
+}
+
+func TestTelemetryHandlerAsyncIncResourceOperationCounters(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{
+		timeout:            1,
+		providerName:       "providerName",
+		telemetryProviders: []TelemetryProvider{stub},
+	}, 10, 1, 0, time.Second)
+
+	handler.IncResourceOperationCounters("cdn_v1", TelemetryResourceOperationCreate, true)
+	handler.Flush()
+
+	assert.Equal(t, "cdn_v1", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationCreate, stub.resourceOperationReceived)
+	assert.Equal(t, 1, stub.resourceOperationTotalRuns)
+	assert.Equal(t, 1, stub.resourceOperationErrors)
+}
+
+func TestTelemetryHandlerAsyncBatching(t *testing.T) {
+	newMetric := func(name string, submitted chan string) telemetryMetricSubmission {
+		return telemetryMetricSubmission{name: name, submitter: func() error {
+			submitted <- name
+			return nil
+		}}
+	}
+
+	t.Run("the batch is submitted once it is full without waiting for the flush", func(t *testing.T) {
+		submitted := make(chan string, 2)
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 1, logger: &loggerStub{}}, 10, 2, 0, time.Second)
+		defer handler.Flush()
+		handler.enqueue(newMetric("metric1", submitted))
+		handler.enqueue(newMetric("metric2", submitted))
+		var received []string
+		for i := 0; i < 2; i++ {
+			select {
+			case metric := <-submitted:
+				received = append(received, metric)
+			case <-time.After(time.Second):
+				t.Fatal("the batch was not submitted once full")
+			}
+		}
+		assert.ElementsMatch(t, []string{"metric1", "metric2"}, received)
+	})
+
+	t.Run("the batch is submitted when the flush interval elapses", func(t *testing.T) {
+		submitted := make(chan string, 1)
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 1, logger: &loggerStub{}}, 10, 10, 10*time.Millisecond, time.Second)
+		defer handler.Flush()
+		handler.enqueue(newMetric("metric1", submitted))
+		select {
+		case metric := <-submitted:
+			assert.Equal(t, "metric1", metric)
+		case <-time.After(time.Second):
+			t.Fatal("the batch was not submitted when the flush interval elapsed")
+		}
+	})
+
+	t.Run("flush submits the metrics pending in the batch", func(t *testing.T) {
+		submitted := make(chan string, 1)
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 1, logger: &loggerStub{}}, 10, 10, time.Hour, time.Second)
+		handler.enqueue(newMetric("metric1", submitted))
+		handler.Flush()
+		assert.Equal(t, "metric1", <-submitted)
+	})
+
+	t.Run("the metrics in a batch are submitted concurrently", func(t *testing.T) {
+		logger := &loggerStub{}
+		var started sync.WaitGroup
+		started.Add(2)
+		// each submission only finishes once both submissions have started, hence the submissions would time out if
+		// they were submitted one after the other
+		submitter := func() error {
+			started.Done()
+			started.Wait()
+			return nil
+		}
+		handler := newTelemetryHandlerAsync(telemetryHandlerTimeoutSupport{timeout: 1, logger: logger}, 10, 2, 0, 2*time.Second)
+		handler.enqueue(telemetryMetricSubmission{name: "metric1", submitter: submitter})
+		handler.enqueue(telemetryMetricSubmission{name: "metric2", submitter: submitter})
+		handler.Flush()
+		assert.Empty(t, logger.messages)
+	})
+}
@@ -0,0 +1,125 @@
+package openapi
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const telemetryRetryDefaultMaxRetries = 2
+const telemetryRetryDefaultBackoff = 100 * time.Millisecond
+const telemetryCircuitBreakerDefaultFailureThreshold = 3
+
+// TelemetryRetryConfig contains the configuration needed to retry the metrics submissions that fail. The time to wait
+// between retries doubles after each retry (exponential backoff)
+type TelemetryRetryConfig struct {
+	// MaxRetries defines the number of times a metric submission is retried after the first attempt failed. If not
+	// provided the default max retries (2) will be used
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// Backoff defines the time to wait before the first retry (e,g: 100ms). If not provided the default backoff (100ms)
+	// will be used
+	Backoff string `yaml:"backoff,omitempty"`
+}
+
+// Validate checks whether the retry configuration is valid
+func (t *TelemetryRetryConfig) Validate() error {
+	if t.MaxRetries < 0 {
+		return fmt.Errorf("telemetry retry max_retries '%d' is not valid, the value must be a positive number", t.MaxRetries)
+	}
+	if t.Backoff != "" {
+		backoff, err := time.ParseDuration(t.Backoff)
+		if err != nil {
+			return fmt.Errorf("telemetry retry backoff '%s' is not valid: %s", t.Backoff, err)
+		}
+		if backoff < 0 {
+			return fmt.Errorf("telemetry retry backoff '%s' is not valid, the value must be a positive duration", t.Backoff)
+		}
+	}
+	return nil
+}
+
+func (t *TelemetryRetryConfig) getMaxRetries() int {
+	if t.MaxRetries == 0 {
+		return telemetryRetryDefaultMaxRetries
+	}
+	return t.MaxRetries
+}
+
+func (t *TelemetryRetryConfig) getBackoff() time.Duration {
+	backoff, err := time.ParseDuration(t.Backoff)
+	if err != nil || backoff == 0 {
+		return telemetryRetryDefaultBackoff
+	}
+	return backoff
+}
+
+// TelemetryCircuitBreakerConfig contains the configuration needed to stop submitting the metrics to a telemetry provider
+// for the rest of the run once the provider has failed a number of consecutive times
+type TelemetryCircuitBreakerConfig struct {
+	// FailureThreshold defines the number of consecutive failed submissions (after retries) that disable the telemetry
+	// provider. If not provided the default failure threshold (3) will be used
+	FailureThreshold int `yaml:"failure_threshold,omitempty"`
+}
+
+// Validate checks whether the circuit breaker configuration is valid
+func (t *TelemetryCircuitBreakerConfig) Validate() error {
+	if t.FailureThreshold < 0 {
+		return fmt.Errorf("telemetry circuit_breaker failure_threshold '%d' is not valid, the value must be a positive number", t.FailureThreshold)
+	}
+	return nil
+}
+
+func (t *TelemetryCircuitBreakerConfig) getFailureThreshold() int {
+	if t.FailureThreshold == 0 {
+		return telemetryCircuitBreakerDefaultFailureThreshold
+	}
+	return t.FailureThreshold
+}
+
+// telemetryCircuitBreaker keeps track of the consecutive failures of a telemetry provider. Once the failure threshold
+// is reached the circuit opens and stays open for the rest of the run
+type telemetryCircuitBreaker struct {
+	failureThreshold    int
+	consecutiveFailures int
+	open                bool
+	mutex               sync.Mutex
+}
+
+func newTelemetryCircuitBreaker(failureThreshold int) *telemetryCircuitBreaker {
+	return &telemetryCircuitBreaker{failureThreshold: failureThreshold}
+}
+
+// isOpen returns true if the telemetry provider has been disabled. A nil circuit breaker is never open
+func (c *telemetryCircuitBreaker) isOpen() bool {
+	if c == nil {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.open
+}
+
+// recordSuccess resets the consecutive failures
+func (c *telemetryCircuitBreaker) recordSuccess() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.consecutiveFailures = 0
+}
+
+// recordFailure increments the consecutive failures and returns true if the failure recorded opened the circuit
+func (c *telemetryCircuitBreaker) recordFailure() bool {
+	if c == nil {
+		return false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.consecutiveFailures++
+	if !c.open && c.consecutiveFailures >= c.failureThreshold {
+		c.open = true
+		return true
+	}
+	return false
+}
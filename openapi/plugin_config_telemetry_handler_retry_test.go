@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryRetryConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		retryConfig   TelemetryRetryConfig
+		expectedError error
+	}{
+		{
+			name:          "retry config with default values",
+			retryConfig:   TelemetryRetryConfig{},
+			expectedError: nil,
+		},
+		{
+			name:          "retry config with max retries and backoff",
+			retryConfig:   TelemetryRetryConfig{MaxRetries: 5, Backoff: "1s"},
+			expectedError: nil,
+		},
+		{
+			name:          "retry config with negative max retries",
+			retryConfig:   TelemetryRetryConfig{MaxRetries: -1},
+			expectedError: errors.New("telemetry retry max_retries '-1' is not valid, the value must be a positive number"),
+		},
+		{
+			name:          "retry config with wrong backoff",
+			retryConfig:   TelemetryRetryConfig{Backoff: "wrong"},
+			expectedError: errors.New("telemetry retry backoff 'wrong' is not valid: time: invalid duration \"wrong\""),
+		},
+		{
+			name:          "retry config with negative backoff",
+			retryConfig:   TelemetryRetryConfig{Backoff: "-1s"},
+			expectedError: errors.New("telemetry retry backoff '-1s' is not valid, the value must be a positive duration"),
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.retryConfig.Validate()
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
+
+func TestTelemetryRetryConfigDefaults(t *testing.T) {
+	retryConfig := TelemetryRetryConfig{}
+	assert.Equal(t, telemetryRetryDefaultMaxRetries, retryConfig.getMaxRetries())
+	assert.Equal(t, telemetryRetryDefaultBackoff, retryConfig.getBackoff())
+
+	retryConfig = TelemetryRetryConfig{MaxRetries: 5, Backoff: "1s"}
+	assert.Equal(t, 5, retryConfig.getMaxRetries())
+	assert.Equal(t, time.Second, retryConfig.getBackoff())
+}
+
+func TestTelemetryCircuitBreakerConfig(t *testing.T) {
+	assert.NoError(t, (&TelemetryCircuitBreakerConfig{}).Validate())
+	assert.Equal(t, errors.New("telemetry circuit_breaker failure_threshold '-1' is not valid, the value must be a positive number"), (&TelemetryCircuitBreakerConfig{FailureThreshold: -1}).Validate())
+	assert.Equal(t, telemetryCircuitBreakerDefaultFailureThreshold, (&TelemetryCircuitBreakerConfig{}).getFailureThreshold())
+	assert.Equal(t, 5, (&TelemetryCircuitBreakerConfig{FailureThreshold: 5}).getFailureThreshold())
+}
+
+func TestTelemetryCircuitBreaker(t *testing.T) {
+	t.Run("the circuit opens once the consecutive failures reach the threshold", func(t *testing.T) {
+		circuitBreaker := newTelemetryCircuitBreaker(2)
+		assert.False(t, circuitBreaker.recordFailure())
+		assert.False(t, circuitBreaker.isOpen())
+		assert.True(t, circuitBreaker.recordFailure())
+		assert.True(t, circuitBreaker.isOpen())
+		assert.False(t, circuitBreaker.recordFailure(), "the circuit should only be reported as opened once")
+	})
+
+	t.Run("successful submissions reset the consecutive failures", func(t *testing.T) {
+		circuitBreaker := newTelemetryCircuitBreaker(2)
+		circuitBreaker.recordFailure()
+		circuitBreaker.recordSuccess()
+		assert.False(t, circuitBreaker.recordFailure())
+		assert.False(t, circuitBreaker.isOpen())
+	})
+
+	t.Run("a nil circuit breaker is never open", func(t *testing.T) {
+		var circuitBreaker *telemetryCircuitBreaker
+		assert.False(t, circuitBreaker.recordFailure())
+		circuitBreaker.recordSuccess()
+		assert.False(t, circuitBreaker.isOpen())
+	})
+}
+
+func TestTelemetryHandlerSubmitWithRetries(t *testing.T) {
+	t.Run("the metric is retried until it succeeds", func(t *testing.T) {
+		logger := &loggerStub{}
+		attempts := 0
+		ths := telemetryHandlerTimeoutSupport{timeout: 1, logger: logger, maxRetries: 2, retryBackoff: time.Millisecond}
+		ths.submit(telemetryMetricSubmission{name: "someMetricName", submitter: func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("some error")
+			}
+			return nil
+		}})
+		assert.Equal(t, 3, attempts)
+		assert.True(t, logger.containsMessage("DEBUG", "metric 'someMetricName' submission failed: some error, retrying in 1ms"))
+		assert.True(t, logger.containsMessage("DEBUG", "metric 'someMetricName' submission failed: some error, retrying in 2ms"))
+		assert.False(t, logger.containsMessage("WARN", "metric 'someMetricName' submission failed: some error"))
+	})
+
+	t.Run("the failure is logged once the retries are exhausted", func(t *testing.T) {
+		logger := &loggerStub{}
+		attempts := 0
+		ths := telemetryHandlerTimeoutSupport{timeout: 1, logger: logger, maxRetries: 1, retryBackoff: time.Millisecond}
+		ths.submit(telemetryMetricSubmission{name: "someMetricName", submitter: func() error {
+			attempts++
+			return errors.New("some error")
+		}})
+		assert.Equal(t, 2, attempts)
+		assert.True(t, logger.containsMessage("DEBUG", "metric 'someMetricName' submission failed: some error"))
+		assert.False(t, logger.containsMessage("WARN", "metric 'someMetricName' submission failed: some error"))
+	})
+
+	t.Run("the metrics are not submitted once the circuit breaker opens", func(t *testing.T) {
+		logger := &loggerStub{}
+		attempts := 0
+		circuitBreaker := newTelemetryCircuitBreaker(2)
+		ths := telemetryHandlerTimeoutSupport{timeout: 1, logger: logger}
+		metric := telemetryMetricSubmission{name: "someMetricName", circuitBreaker: circuitBreaker, submitter: func() error {
+			attempts++
+			return errors.New("some error")
+		}}
+		for i := 0; i < 3; i++ {
+			ths.submit(metric)
+		}
+		assert.Equal(t, 2, attempts)
+		assert.True(t, logger.containsMessage("WARN", "telemetry provider disabled for the rest of the run after 2 consecutive failed submissions"))
+		assert.True(t, logger.containsMessage("DEBUG", "metric 'someMetricName' not submitted since the telemetry provider has been disabled due to consecutive failures"))
+	})
+
+	t.Run("the circuit breaker is assigned to the metrics of the corresponding telemetry provider", func(t *testing.T) {
+		circuitBreaker := newTelemetryCircuitBreaker(1)
+		ths := telemetryHandlerTimeoutSupport{
+			timeout:            1,
+			telemetryProviders: []TelemetryProvider{&telemetryProviderStub{}, &telemetryProviderStub{}},
+			circuitBreakers:    []*telemetryCircuitBreaker{nil, circuitBreaker},
+		}
+		metrics := ths.getMetrics()
+		assert.Nil(t, metrics[0].circuitBreaker)
+		assert.Nil(t, metrics[1].circuitBreaker)
+		assert.Equal(t, circuitBreaker, metrics[2].circuitBreaker)
+		assert.Equal(t, circuitBreaker, metrics[3].circuitBreaker)
+	})
+}
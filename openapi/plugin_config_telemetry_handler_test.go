@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"log"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -100,3 +101,74 @@ func TestSubmitMetric(t *testing.T) {
 		assert.Contains(t, buf.String(), tc.expectedLogging, tc.name)
 	}
 }
+
+func TestSubmitResourceOperationTimingMetric(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "providerName",
+		timeout:            1,
+		telemetryProviders: []TelemetryProvider{stub},
+	}
+	ths.SubmitResourceOperationTimingMetric("cdn_v1", TelemetryResourceOperationCreate, time.Second)
+	assert.Equal(t, "providerName", stub.providerNameReceived)
+	assert.Equal(t, "cdn_v1", stub.resourceNameReceived)
+	assert.Equal(t, TelemetryResourceOperationCreate, stub.resourceOperationReceived)
+	assert.Equal(t, time.Second, stub.durationReceived)
+}
+
+func TestIncResourceOperationCounters(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		failed                  bool
+		expectedErrorsTotalRuns int
+	}{
+		{name: "operation succeeded", failed: false, expectedErrorsTotalRuns: 0},
+		{name: "operation failed", failed: true, expectedErrorsTotalRuns: 1},
+	}
+	for _, tc := range testCases {
+		stub := &telemetryProviderStub{}
+		ths := telemetryHandlerTimeoutSupport{
+			providerName:       "providerName",
+			timeout:            1,
+			telemetryProviders: []TelemetryProvider{stub},
+		}
+		ths.IncResourceOperationCounters("cdn_v1", TelemetryResourceOperationUpdate, tc.failed)
+		assert.Equal(t, "providerName", stub.providerNameReceived, tc.name)
+		assert.Equal(t, "cdn_v1", stub.resourceNameReceived, tc.name)
+		assert.Equal(t, TelemetryResourceOperationUpdate, stub.resourceOperationReceived, tc.name)
+		assert.Equal(t, 1, stub.resourceOperationTotalRuns, tc.name)
+		assert.Equal(t, tc.expectedErrorsTotalRuns, stub.resourceOperationErrors, tc.name)
+	}
+}
+
+func TestTelemetryHandlerTags(t *testing.T) {
+	stub := &telemetryProviderStub{}
+	ths := telemetryHandlerTimeoutSupport{
+		providerName:       "cdn",
+		openAPIVersion:     "0.26.0",
+		timeout:            1,
+		telemetryProviders: []TelemetryProvider{stub},
+		terraformVersion:   &atomic.Value{},
+	}
+
+	ths.SubmitMetrics()
+	assert.Equal(t, TelemetryTags{"provider_name": "cdn", "openapi_plugin_version": "0.26.0"}, stub.tagsReceived, "the terraform version should not be tagged before the provider is configured")
+
+	ths.SetTerraformVersion("0.12.29")
+	ths.IncResourceOperationCounters("cdn_v1", TelemetryResourceOperationCreate, false)
+	assert.Equal(t, TelemetryTags{"provider_name": "cdn", "openapi_plugin_version": "0.26.0", "terraform_version": "0.12.29", "resource_name": "cdn_v1", "operation": "create"}, stub.tagsReceived)
+}
+
+func TestTelemetryHandlerSetTerraformVersionIsSharedAcrossCopies(t *testing.T) {
+	ths := telemetryHandlerTimeoutSupport{providerName: "cdn", terraformVersion: &atomic.Value{}}
+	var handler TelemetryHandler = newTelemetryHandlerAsync(ths, 10, 1, 0, time.Second)
+	handler.SetTerraformVersion("0.12.29")
+	handler.Flush()
+	assert.Equal(t, "0.12.29", ths.getTags()[telemetryTagTerraformVersion])
+}
+
+func TestTelemetryHandlerSetTerraformVersionWithoutStorage(t *testing.T) {
+	ths := telemetryHandlerTimeoutSupport{providerName: "cdn"}
+	ths.SetTerraformVersion("0.12.29")
+	assert.NotContains(t, ths.getTags(), telemetryTagTerraformVersion)
+}
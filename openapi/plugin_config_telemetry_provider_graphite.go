@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/DataDog/datadog-go/statsd"
-	"log"
 	"strings"
+	"time"
 )
 
 // TelemetryProviderGraphite defines the configuration for Graphite. This struct also implements the TelemetryProvider interface
@@ -17,6 +17,11 @@ type TelemetryProviderGraphite struct {
 	Port int `yaml:"port"`
 	// Prefix enables to append a prefix to the metrics pushed to graphite
 	Prefix string `yaml:"prefix,omitempty"`
+	// TaggedMetrics enables shipping the metrics as Graphite tagged series (e,g: <metric>;provider_name=cdn) containing the
+	// metric tags. Requires Graphite 1.1 or later
+	TaggedMetrics bool `yaml:"tagged_metrics,omitempty"`
+	// logger is used to log the metrics submissions. The value is populated from the plugin configuration
+	logger Logger
 }
 
 // Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
@@ -34,41 +39,81 @@ func (g TelemetryProviderGraphite) Validate() error {
 
 // IncOpenAPIPluginVersionTotalRunsCounter will increment the counter 'statsd.<prefix>.terraform.openapi_plugin_version.%s.total_runs' metric to 1. The
 // %s will be replaced by the OpenAPI plugin version used at runtime
-func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string) error {
+func (g TelemetryProviderGraphite) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
 	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
 	metric := fmt.Sprintf("terraform.openapi_plugin_version.%s.total_runs", version)
-	log.Printf("[INFO] graphite metric to be submitted: %s", metric)
-	if err := g.submitMetric(metric); err != nil {
-		return err
-	}
-	log.Printf("[INFO] graphite metric successfully submitted: %s", metric)
-	return nil
+	return g.incCounter(metric, tags)
 }
 
 // IncServiceProviderTotalRunsCounter will increment the counter for a given provider 'statsd.<prefix>.terraform.providers.%s.total_runs' metric to 1. The
 // %s will be replaced by the provider name used at runtime
-func (g TelemetryProviderGraphite) IncServiceProviderTotalRunsCounter(providerName string) error {
+func (g TelemetryProviderGraphite) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
 	metric := fmt.Sprintf("terraform.providers.%s.total_runs", providerName)
-	log.Printf("[INFO] graphite metric to be submitted: %s", metric)
-	if err := g.submitMetric(metric); err != nil {
+	return g.incCounter(metric, tags)
+}
+
+// SubmitResourceOperationTimingMetric will submit the timing metric 'statsd.<prefix>.terraform.providers.%s.resources.%s.%s.duration'
+// containing the time it took to perform the resource operation. The %s will be replaced by the provider name, the
+// resource name and the operation respectively
+func (g TelemetryProviderGraphite) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	metric := buildResourceOperationTimingMetricName(providerName, resourceName, operation)
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("graphite metric to be submitted: %s", metric), "metric", metric)
+	c, err := g.getGraphiteClient()
+	if err != nil {
+		return err
+	}
+	if err := c.Timing(g.buildMetricName(metric, tags), duration, nil, 1.0); err != nil {
+		return err
+	}
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("graphite metric successfully submitted: %s", metric), "metric", metric)
+	return nil
+}
+
+// IncResourceOperationTotalCounter will increment the counter 'statsd.<prefix>.terraform.providers.%s.%s.%s.total' metric
+// to 1. The %s will be replaced by the provider name, the resource name and the operation respectively
+func (g TelemetryProviderGraphite) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return g.incCounter(buildResourceOperationTotalCounterName(providerName, resourceName, operation), tags)
+}
+
+// IncResourceOperationErrorsTotalCounter will increment the counter 'statsd.<prefix>.terraform.providers.%s.%s.%s.errors.total'
+// metric to 1. The %s will be replaced by the provider name, the resource name and the operation respectively
+func (g TelemetryProviderGraphite) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return g.incCounter(buildResourceOperationErrorsTotalCounterName(providerName, resourceName, operation), tags)
+}
+
+func (g TelemetryProviderGraphite) incCounter(metric string, tags TelemetryTags) error {
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("graphite metric to be submitted: %s", metric), "metric", metric)
+	if err := g.submitMetric(metric, tags); err != nil {
 		return err
 	}
-	log.Printf("[INFO] graphite metric successfully submitted: %s", metric)
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("graphite metric successfully submitted: %s", metric), "metric", metric)
 	return nil
 }
 
-func (g TelemetryProviderGraphite) submitMetric(name string) error {
+func (g TelemetryProviderGraphite) submitMetric(name string, tags TelemetryTags) error {
 	c, err := g.getGraphiteClient()
 	if err != nil {
 		return err
 	}
-	nameWithPrefix := g.buildMetricName(name)
+	nameWithPrefix := g.buildMetricName(name, tags)
 	return c.Incr(nameWithPrefix, nil, 1.0)
 }
 
-func (g TelemetryProviderGraphite) buildMetricName(name string) string {
+// buildMetricName returns the metric name including the prefix (if configured). If tagged metrics are enabled, the tags
+// are appended following the Graphite tagged series format <metric>;<tag>=<value>;...
+func (g TelemetryProviderGraphite) buildMetricName(name string, tags TelemetryTags) string {
 	if g.Prefix != "" {
-		return fmt.Sprintf("%s.%s", g.Prefix, name)
+		name = fmt.Sprintf("%s.%s", g.Prefix, name)
+	}
+	if !g.TaggedMetrics {
+		return name
+	}
+	for _, tagName := range tags.sortedNames() {
+		tagValue := strings.Replace(tags[tagName], ";", "_", -1)
+		if tagValue == "" {
+			continue
+		}
+		name = fmt.Sprintf("%s;%s=%s", name, tagName, tagValue)
 	}
 	return name
 }
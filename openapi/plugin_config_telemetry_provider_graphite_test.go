@@ -8,6 +8,7 @@ import (
 	"net"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestTelemetryProviderGraphite_Validate(t *testing.T) {
@@ -66,7 +67,7 @@ func TestTelemetryProviderGraphite_IncOpenAPIPluginVersionTotalRunsCounter(t *te
 		Port:   telemetryPortInt,
 		Prefix: "myPrefixName",
 	}
-	err = tpg.IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion)
+	err = tpg.IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion, nil)
 	assert.Nil(t, err)
 	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
 }
@@ -76,7 +77,7 @@ func TestTelemetryProviderGraphite_IncOpenAPIPluginVersionTotalRunsCounter_BadHo
 	expectedError := &net.DNSError{Err: "no such host", Name: "bad graphite host", Server: "", IsTimeout: false, IsTemporary: false}
 
 	tpg := createTestGraphiteProviderBadHost()
-	err := tpg.IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion)
+	err := tpg.IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion, nil)
 
 	assert.Equal(t, expectedError, err)
 }
@@ -100,7 +101,7 @@ func TestTelemetryProviderGraphite_IncServiceProviderTotalRunsCounter(t *testing
 		Port:   telemetryPortInt,
 		Prefix: "myPrefixName",
 	}
-	err = tpg.IncServiceProviderTotalRunsCounter(providerName)
+	err = tpg.IncServiceProviderTotalRunsCounter(providerName, nil)
 	assert.Nil(t, err)
 	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
 }
@@ -110,7 +111,7 @@ func TestTelemetryProviderGraphite_IncServiceProviderTotalRunsCounter_BadHost(t
 	expectedError := &net.DNSError{Err: "no such host", Name: "bad graphite host", Server: "", IsTimeout: false, IsTemporary: false}
 
 	tpg := createTestGraphiteProviderBadHost()
-	err := tpg.IncServiceProviderTotalRunsCounter(providerName)
+	err := tpg.IncServiceProviderTotalRunsCounter(providerName, nil)
 
 	assert.Equal(t, expectedError, err)
 }
@@ -120,6 +121,8 @@ func TestTelemetryProviderGraphite_BuildMetricName(t *testing.T) {
 		testName               string
 		prefix                 string
 		metricName             string
+		taggedMetrics          bool
+		tags                   TelemetryTags
 		expectedFullMetricName string
 	}{
 		{
@@ -133,18 +136,40 @@ func TestTelemetryProviderGraphite_BuildMetricName(t *testing.T) {
 			metricName:             "myMetricName",
 			expectedFullMetricName: "myMetricName",
 		},
+		{
+			testName:               "happy path - tags are ignored when tagged metrics are not enabled",
+			metricName:             "myMetricName",
+			tags:                   TelemetryTags{"provider_name": "cdn"},
+			expectedFullMetricName: "myMetricName",
+		},
+		{
+			testName:               "happy path - tagged metrics enabled",
+			prefix:                 "myPrefixName",
+			metricName:             "myMetricName",
+			taggedMetrics:          true,
+			tags:                   TelemetryTags{"provider_name": "cdn", "openapi_plugin_version": "0.26.0", "terraform_version": ""},
+			expectedFullMetricName: "myPrefixName.myMetricName;openapi_plugin_version=0.26.0;provider_name=cdn",
+		},
+		{
+			testName:               "happy path - tagged metrics enabled and tag value containing semicolons",
+			metricName:             "myMetricName",
+			taggedMetrics:          true,
+			tags:                   TelemetryTags{"resource_name": "cdn;v1"},
+			expectedFullMetricName: "myMetricName;resource_name=cdn_v1",
+		},
 	}
 
 	for _, tc := range testCases {
 		tpg := TelemetryProviderGraphite{
-			Host:   "myTelemetryHost",
-			Port:   8125,
-			Prefix: tc.prefix,
+			Host:          "myTelemetryHost",
+			Port:          8125,
+			Prefix:        tc.prefix,
+			TaggedMetrics: tc.taggedMetrics,
 		}
 
-		fullMetricName := tpg.buildMetricName(tc.metricName)
+		fullMetricName := tpg.buildMetricName(tc.metricName, tc.tags)
 
-		assert.Equal(t, tc.expectedFullMetricName, fullMetricName)
+		assert.Equal(t, tc.expectedFullMetricName, fullMetricName, tc.testName)
 	}
 }
 
@@ -156,3 +181,67 @@ func createTestGraphiteProviderBadHost() TelemetryProviderGraphite {
 	}
 	return tpg
 }
+
+func TestTelemetryProviderGraphite_SubmitResourceOperationTimingMetric(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] graphite metric to be submitted: terraform.providers.myProviderName.resources.cdn_v1.create.duration"
+	expectedLogMetricSuccess := "[INFO] graphite metric successfully submitted: terraform.providers.myProviderName.resources.cdn_v1.create.duration"
+	expectedMetric := "myPrefixName.terraform.providers.myProviderName.resources.cdn_v1.create.duration:1500.000000|ms"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	telemetryPortInt, err := strconv.Atoi(telemetryPort)
+	tpg := TelemetryProviderGraphite{
+		Host:   telemetryHost,
+		Port:   telemetryPortInt,
+		Prefix: "myPrefixName",
+	}
+	err = tpg.SubmitResourceOperationTimingMetric("myProviderName", "cdn_v1", TelemetryResourceOperationCreate, 1500*time.Millisecond, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderGraphite_IncResourceOperationTotalCounters(t *testing.T) {
+	testCases := []struct {
+		name           string
+		submit         func(tpg TelemetryProviderGraphite) error
+		expectedMetric string
+	}{
+		{
+			name: "resource operation total counter",
+			submit: func(tpg TelemetryProviderGraphite) error {
+				return tpg.IncResourceOperationTotalCounter("myProviderName", "cdn_v1", TelemetryResourceOperationCreate, nil)
+			},
+			expectedMetric: "terraform.providers.myProviderName.cdn_v1.create.total",
+		},
+		{
+			name: "resource operation errors total counter",
+			submit: func(tpg TelemetryProviderGraphite) error {
+				return tpg.IncResourceOperationErrorsTotalCounter("myProviderName", "cdn_v1", TelemetryResourceOperationCreate, nil)
+			},
+			expectedMetric: "terraform.providers.myProviderName.cdn_v1.create.errors.total",
+		},
+	}
+	for _, tc := range testCases {
+		var logging bytes.Buffer
+		log.SetOutput(&logging)
+
+		metricChannel := make(chan string)
+		pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+
+		telemetryPortInt, _ := strconv.Atoi(telemetryPort)
+		tpg := TelemetryProviderGraphite{
+			Host:   telemetryHost,
+			Port:   telemetryPortInt,
+			Prefix: "myPrefixName",
+		}
+		err := tc.submit(tpg)
+		assert.Nil(t, err, tc.name)
+		assertExpectedMetricAndLogging(t, metricChannel, "myPrefixName."+tc.expectedMetric+":1|c", "[INFO] graphite metric to be submitted: "+tc.expectedMetric, "[INFO] graphite metric successfully submitted: "+tc.expectedMetric, &logging)
+		pc.Close()
+	}
+}
@@ -6,12 +6,18 @@ import (
 	"fmt"
 	"github.com/asaskevich/govalidator"
 	"github.com/dikhan/terraform-provider-openapi/openapi/version"
-	"log"
 	"net/http"
+	"os"
+	"regexp"
 	"runtime"
 	"strings"
+	"time"
 )
 
+// telemetryEnvVarRegex matches the environment variables references (e,g: ${METRICS_TOKEN}) supported in the HTTP
+// endpoint authentication configuration
+var telemetryEnvVarRegex = regexp.MustCompile(`\$\{([a-zA-Z_][a-zA-Z0-9_]*)\}`)
+
 // TelemetryProviderHTTPEndpoint defines the configuration for HTTPEndpoint. This struct also implements the TelemetryProvider interface
 // and ships metrics to the following namespace by default <prefix>.terraform.* where '<prefix>' can be configured.
 type TelemetryProviderHTTPEndpoint struct {
@@ -19,24 +25,56 @@ type TelemetryProviderHTTPEndpoint struct {
 	URL string `yaml:"url"`
 	// Prefix enables to append a prefix to the metrics pushed to graphite
 	Prefix string `yaml:"prefix,omitempty"`
+	// ValidateConnectivity enables probing the URL when the telemetry is configured to confirm the endpoint is reachable
+	ValidateConnectivity bool `yaml:"validate_connectivity,omitempty"`
+	// Headers contains additional headers sent along the metrics. The values may reference environment variables (e,g: ${API_KEY})
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// BearerToken is sent in the Authorization header as a bearer token. The value may reference environment variables (e,g: ${TOKEN})
+	BearerToken string `yaml:"bearer_token,omitempty"`
+	// BasicAuth contains the credentials sent in the Authorization header using the basic authentication scheme
+	BasicAuth *TelemetryHTTPEndpointBasicAuth `yaml:"basic_auth,omitempty"`
+	// userAgentSuffix is appended to the default user agent sent in the telemetry requests. The value is populated
+	// from the service configuration user_agent_suffix
+	userAgentSuffix string
+	// logger is used to log the metrics submissions. The value is populated from the plugin configuration
+	logger Logger
+}
+
+// TelemetryHTTPEndpointBasicAuth defines the basic authentication credentials used to submit the metrics to the HTTP
+// endpoint. The values may reference environment variables (e,g: ${METRICS_PASSWORD})
+type TelemetryHTTPEndpointBasicAuth struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password,omitempty"`
 }
 
 type metricType string
 
 const (
 	metricTypeCounter metricType = "IncCounter"
+	metricTypeTiming  metricType = "Timing"
 )
 
 type telemetryMetric struct {
 	MetricType metricType `json:"metric_type"`
 	MetricName string     `json:"metric_name"`
+	// DurationMilliseconds is only populated for the timing metrics
+	DurationMilliseconds float64 `json:"duration_ms,omitempty"`
+	// Tags contains the dimensions of the metric (e,g: provider_name, resource_name, etc)
+	Tags map[string]string `json:"tags,omitempty"`
 }
 
-func createNewCounterMetric(prefix, metricName string) telemetryMetric {
+func createNewCounterMetric(prefix, metricName string, tags TelemetryTags) telemetryMetric {
 	if prefix != "" {
 		metricName = fmt.Sprintf("%s.%s", prefix, metricName)
 	}
-	return telemetryMetric{MetricType: metricTypeCounter, MetricName: metricName}
+	return telemetryMetric{MetricType: metricTypeCounter, MetricName: metricName, Tags: tags}
+}
+
+func createNewTimingMetric(prefix, metricName string, duration time.Duration, tags TelemetryTags) telemetryMetric {
+	metric := createNewCounterMetric(prefix, metricName, tags)
+	metric.MetricType = metricTypeTiming
+	metric.DurationMilliseconds = durationInMilliseconds(duration)
+	return metric
 }
 
 // Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider registration. If this
@@ -49,15 +87,91 @@ func (g TelemetryProviderHTTPEndpoint) Validate() error {
 	if !govalidator.IsURL(g.URL) {
 		return fmt.Errorf("http endpoint telemetry configuration does not have a valid URL '%s'", g.URL)
 	}
+	return g.validateAuthentication()
+}
+
+// validateAuthentication checks the headers, bearer token and basic auth configuration, including that the environment
+// variables referenced are set
+func (g TelemetryProviderHTTPEndpoint) validateAuthentication() error {
+	if g.BearerToken != "" && g.BasicAuth != nil {
+		return errors.New("http endpoint telemetry configuration must not contain both 'bearer_token' and 'basic_auth' properties")
+	}
+	if g.BasicAuth != nil && g.BasicAuth.Username == "" {
+		return errors.New("http endpoint telemetry configuration 'basic_auth' property is missing a value for the 'username' property")
+	}
+	values := []string{g.BearerToken}
+	if g.BasicAuth != nil {
+		values = append(values, g.BasicAuth.Username, g.BasicAuth.Password)
+	}
+	for name, value := range g.Headers {
+		if name == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("http endpoint telemetry configuration contains an invalid header name '%s'", name)
+		}
+		if (g.BearerToken != "" || g.BasicAuth != nil) && http.CanonicalHeaderKey(name) == authorizationHeader {
+			return fmt.Errorf("http endpoint telemetry configuration header '%s' conflicts with the 'bearer_token'/'basic_auth' properties", name)
+		}
+		values = append(values, value)
+	}
+	for _, value := range values {
+		for _, match := range telemetryEnvVarRegex.FindAllStringSubmatch(value, -1) {
+			if _, ok := os.LookupEnv(match[1]); !ok {
+				return fmt.Errorf("http endpoint telemetry configuration references the environment variable '%s' which is not set", match[1])
+			}
+		}
+	}
+	return nil
+}
+
+// setRequestHeaders sets the user agent and the authentication headers configured (interpolating the environment
+// variables referenced) in the request
+func (g TelemetryProviderHTTPEndpoint) setRequestHeaders(req *http.Request) {
+	for name, value := range g.Headers {
+		req.Header.Set(name, expandTelemetryEnvVars(value))
+	}
+	if g.BearerToken != "" {
+		req.Header.Set(authorizationHeader, fmt.Sprintf("Bearer %s", expandTelemetryEnvVars(g.BearerToken)))
+	}
+	if g.BasicAuth != nil {
+		req.SetBasicAuth(expandTelemetryEnvVars(g.BasicAuth.Username), expandTelemetryEnvVars(g.BasicAuth.Password))
+	}
+	req.Header.Set(userAgentHeader, version.BuildUserAgentWithSuffix(runtime.GOOS, runtime.GOARCH, g.userAgentSuffix))
+}
+
+// expandTelemetryEnvVars replaces the environment variables references (e,g: ${TOKEN}) with their values
+func expandTelemetryEnvVars(value string) string {
+	return telemetryEnvVarRegex.ReplaceAllStringFunc(value, func(reference string) string {
+		return os.Getenv(telemetryEnvVarRegex.FindStringSubmatch(reference)[1])
+	})
+}
+
+// HealthCheck performs a lightweight HEAD request against the URL to confirm the endpoint is reachable. Any response
+// received is considered healthy except for 404 Not Found which most likely means the URL is misconfigured. The probe
+// is bounded by the telemetry timeout
+func (g TelemetryProviderHTTPEndpoint) HealthCheck() error {
+	req, err := http.NewRequest(http.MethodHead, g.URL, nil)
+	if err != nil {
+		return err
+	}
+	g.setRequestHeaders(req)
+	c := http.Client{Timeout: time.Duration(telemetryTimeout) * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request HEAD %s failed. Response Error: '%s'", g.URL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("response returned from HEAD '%s' returned status code %d, please make sure the URL is correct", g.URL, resp.StatusCode)
+	}
+	loggerOrDefault(g.logger).Debug(fmt.Sprintf("http endpoint telemetry '%s' is reachable (status code %d)", g.URL, resp.StatusCode))
 	return nil
 }
 
 // IncOpenAPIPluginVersionTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.openapi_plugin_version.%s.total_runs'. The
 // %s will be replaced by the OpenAPI plugin version used at runtime
-func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string) error {
+func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
 	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
 	metricName := fmt.Sprintf("terraform.openapi_plugin_version.%s.total_runs", version)
-	metric := createNewCounterMetric(g.Prefix, metricName)
+	metric := createNewCounterMetric(g.Prefix, metricName, tags)
 	if err := g.submitMetric(metric); err != nil {
 		return err
 	}
@@ -66,17 +180,37 @@ func (g TelemetryProviderHTTPEndpoint) IncOpenAPIPluginVersionTotalRunsCounter(o
 
 // IncServiceProviderTotalRunsCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.providers.%s.total_runs'. The
 // %s will be replaced by the provider name used at runtime
-func (g TelemetryProviderHTTPEndpoint) IncServiceProviderTotalRunsCounter(providerName string) error {
+func (g TelemetryProviderHTTPEndpoint) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
 	metricName := fmt.Sprintf("terraform.providers.%s.total_runs", providerName)
-	metric := createNewCounterMetric(g.Prefix, metricName)
+	metric := createNewCounterMetric(g.Prefix, metricName, tags)
 	if err := g.submitMetric(metric); err != nil {
 		return err
 	}
 	return nil
 }
 
+// SubmitResourceOperationTimingMetric will submit the metric type timing '<prefix>.terraform.providers.%s.resources.%s.%s.duration'
+// containing the time it took to perform the resource operation in milliseconds. The %s will be replaced by the provider
+// name, the resource name and the operation respectively
+func (g TelemetryProviderHTTPEndpoint) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	metric := createNewTimingMetric(g.Prefix, buildResourceOperationTimingMetricName(providerName, resourceName, operation), duration, tags)
+	return g.submitMetric(metric)
+}
+
+// IncResourceOperationTotalCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.providers.%s.%s.%s.total'.
+// The %s will be replaced by the provider name, the resource name and the operation respectively
+func (g TelemetryProviderHTTPEndpoint) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return g.submitMetric(createNewCounterMetric(g.Prefix, buildResourceOperationTotalCounterName(providerName, resourceName, operation), tags))
+}
+
+// IncResourceOperationErrorsTotalCounter will submit an increment to 1 the metric type counter '<prefix>.terraform.providers.%s.%s.%s.errors.total'.
+// The %s will be replaced by the provider name, the resource name and the operation respectively
+func (g TelemetryProviderHTTPEndpoint) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return g.submitMetric(createNewCounterMetric(g.Prefix, buildResourceOperationErrorsTotalCounterName(providerName, resourceName, operation), tags))
+}
+
 func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric) error {
-	log.Printf("[INFO] http endpoint metric to be submitted: %s", metric.MetricName)
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("http endpoint metric to be submitted: %s", metric.MetricName), "metric", metric.MetricName)
 	req, err := g.createNewRequest(metric)
 	if err != nil {
 		return err
@@ -89,7 +223,7 @@ func (g TelemetryProviderHTTPEndpoint) submitMetric(metric telemetryMetric) erro
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
 		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d", g.URL, resp.StatusCode)
 	}
-	log.Printf("[INFO] http endpoint metric successfully submitted: %s", metric)
+	loggerOrDefault(g.logger).Info(fmt.Sprintf("http endpoint metric successfully submitted: %s", metric.MetricName), "metric", metric.MetricName)
 	return nil
 }
 
@@ -104,7 +238,7 @@ func (g TelemetryProviderHTTPEndpoint) createNewRequest(metric telemetryMetric)
 	if err != nil {
 		return nil, err
 	}
+	g.setRequestHeaders(req)
 	req.Header.Set(contentType, "application/json")
-	req.Header.Set(userAgentHeader, version.BuildUserAgent(runtime.GOOS, runtime.GOARCH))
 	return req, nil
 }
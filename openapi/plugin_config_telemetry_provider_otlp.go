@@ -0,0 +1,241 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// otlpProtocolHTTPProtobuf exports the metrics via OTLP/HTTP using the binary protobuf encoding
+	otlpProtocolHTTPProtobuf = "http/protobuf"
+	// otlpProtocolHTTPJSON exports the metrics via OTLP/HTTP using the JSON protobuf encoding
+	otlpProtocolHTTPJSON = "http/json"
+	// otlpProtocolGRPC exports the metrics via OTLP/gRPC
+	otlpProtocolGRPC = "grpc"
+)
+
+const otlpHTTPMetricsPath = "/v1/metrics"
+const otlpGRPCExportMethod = "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"
+const otlpScopeName = "github.com/dikhan/terraform-provider-openapi"
+const otlpServiceName = "terraform-provider-openapi"
+
+// TelemetryProviderOTLP defines the configuration for an OpenTelemetry collector (or any other backend supporting the
+// OpenTelemetry Protocol). This struct also implements the TelemetryProvider interface and exports the counters as OTLP
+// monotonic sum metrics named <prefix>.terraform.* where '<prefix>' can be configured.
+type TelemetryProviderOTLP struct {
+	// Endpoint describes where the metrics are exported to. For the HTTP protocols the value is the collector URL (e,g:
+	// http://localhost:4318), the '/v1/metrics' path is appended if the URL does not contain it already. For the grpc
+	// protocol the value is the collector address in the form host:port (e,g: localhost:4317)
+	Endpoint string `yaml:"endpoint"`
+	// Protocol describes the OTLP transport and encoding used: http/protobuf (default), http/json or grpc
+	Protocol string `yaml:"protocol,omitempty"`
+	// Insecure disables TLS for the grpc protocol. The HTTP protocols rely on the scheme of the endpoint instead
+	Insecure bool `yaml:"insecure,omitempty"`
+	// Headers contains additional headers (gRPC metadata when using the grpc protocol) sent along the metrics, e,g: the
+	// collector API key
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Prefix enables to append a prefix to the metrics exported
+	Prefix string `yaml:"prefix,omitempty"`
+	// userAgentSuffix is appended to the default user agent sent in the telemetry requests. The value is populated
+	// from the service configuration user_agent_suffix
+	userAgentSuffix string
+	// logger is used to log the metrics submissions. The value is populated from the plugin configuration
+	logger Logger
+	// now returns the time of the data points exported, configurable for testing purposes
+	now func() time.Time
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider
+// registration. If this method returns an error the error will be logged but the telemetry will be disabled. Otherwise,
+// the telemetry will be enabled and the corresponding metrics will be exported to the OTLP endpoint
+func (o TelemetryProviderOTLP) Validate() error {
+	if o.Endpoint == "" {
+		return errors.New("otlp telemetry configuration is missing a value for the 'endpoint' property")
+	}
+	switch o.getProtocol() {
+	case otlpProtocolHTTPProtobuf, otlpProtocolHTTPJSON:
+		u, err := url.Parse(o.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("otlp telemetry configuration 'endpoint' property value '%s' is not valid, the %s protocol expects an http or https URL (e,g: http://localhost:4318)", o.Endpoint, o.getProtocol())
+		}
+	case otlpProtocolGRPC:
+		host, port, err := net.SplitHostPort(o.Endpoint)
+		if err != nil || host == "" || port == "" || strings.Contains(o.Endpoint, "/") {
+			return fmt.Errorf("otlp telemetry configuration 'endpoint' property value '%s' is not valid, the %s protocol expects an address in the form host:port (e,g: localhost:4317)", o.Endpoint, otlpProtocolGRPC)
+		}
+	default:
+		return fmt.Errorf("otlp telemetry configuration 'protocol' property value '%s' is not supported, supported values are: %s, %s and %s", o.Protocol, otlpProtocolHTTPProtobuf, otlpProtocolHTTPJSON, otlpProtocolGRPC)
+	}
+	return nil
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will export the counter '<prefix>.terraform.openapi_plugin_version.total_runs'
+// increment with the 'openapi_plugin_version' attribute containing the OpenAPI plugin version used at runtime
+func (o TelemetryProviderOTLP) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
+	return o.exportCounter("terraform.openapi_plugin_version.total_runs", map[string]string{"openapi_plugin_version": openAPIPluginVersion})
+}
+
+// IncServiceProviderTotalRunsCounter will export the counter '<prefix>.terraform.providers.total_runs' increment with
+// the 'provider_name' attribute containing the provider name used at runtime
+func (o TelemetryProviderOTLP) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
+	return o.exportCounter("terraform.providers.total_runs", map[string]string{"provider_name": providerName})
+}
+
+// SubmitResourceOperationTimingMetric will export the histogram '<prefix>.terraform.resource_operation.duration' (in
+// milliseconds) with the 'provider_name', 'resource_name' and 'operation' attributes
+func (o TelemetryProviderOTLP) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	metricName := o.buildMetricName("terraform.resource_operation.duration")
+	export := o.newExport()
+	export.histograms = []otlpHistogram{{
+		name:       metricName,
+		unit:       "ms",
+		attributes: map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)},
+		timestamp:  export.timestamp,
+		value:      durationInMilliseconds(duration),
+	}}
+	return o.export(metricName, export)
+}
+
+// IncResourceOperationTotalCounter will export the counter '<prefix>.terraform.resource_operation.total' increment with
+// the 'provider_name', 'resource_name' and 'operation' attributes
+func (o TelemetryProviderOTLP) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return o.exportCounter("terraform.resource_operation.total", map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)})
+}
+
+// IncResourceOperationErrorsTotalCounter will export the counter '<prefix>.terraform.resource_operation.errors.total'
+// increment with the 'provider_name', 'resource_name' and 'operation' attributes
+func (o TelemetryProviderOTLP) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return o.exportCounter("terraform.resource_operation.errors.total", map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)})
+}
+
+func (o TelemetryProviderOTLP) exportCounter(metricName string, attributes map[string]string) error {
+	metricName = o.buildMetricName(metricName)
+	return o.export(metricName, o.newExport(otlpCounter{name: metricName, attributes: attributes, value: 1}))
+}
+
+func (o TelemetryProviderOTLP) buildMetricName(metricName string) string {
+	if o.Prefix != "" {
+		return fmt.Sprintf("%s.%s", o.Prefix, metricName)
+	}
+	return metricName
+}
+
+func (o TelemetryProviderOTLP) export(metricName string, export otlpExport) error {
+	loggerOrDefault(o.logger).Info(fmt.Sprintf("otlp metric to be submitted: %s", metricName), "metric", metricName)
+	var err error
+	if o.getProtocol() == otlpProtocolGRPC {
+		err = o.exportGRPC(export)
+	} else {
+		err = o.exportHTTP(export)
+	}
+	if err != nil {
+		return err
+	}
+	loggerOrDefault(o.logger).Info(fmt.Sprintf("otlp metric successfully submitted: %s", metricName), "metric", metricName)
+	return nil
+}
+
+func (o TelemetryProviderOTLP) newExport(counters ...otlpCounter) otlpExport {
+	now := time.Now
+	if o.now != nil {
+		now = o.now
+	}
+	timestamp := uint64(now().UnixNano())
+	for i := range counters {
+		counters[i].timestamp = timestamp
+	}
+	return otlpExport{
+		timestamp:          timestamp,
+		resourceAttributes: map[string]string{"service.name": otlpServiceName, "service.version": version.Version},
+		scopeName:          otlpScopeName,
+		scopeVersion:       version.Version,
+		counters:           counters,
+	}
+}
+
+func (o TelemetryProviderOTLP) exportHTTP(export otlpExport) error {
+	var body []byte
+	var err error
+	contentTypeValue := "application/x-protobuf"
+	if o.getProtocol() == otlpProtocolHTTPJSON {
+		contentTypeValue = "application/json"
+		if body, err = export.marshalJSON(); err != nil {
+			return err
+		}
+	} else {
+		body = export.marshalProtobuf()
+	}
+	metricsURL := o.getHTTPMetricsURL()
+	req, err := http.NewRequest(http.MethodPost, metricsURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, value := range o.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set(contentType, contentTypeValue)
+	req.Header.Set(userAgentHeader, version.BuildUserAgentWithSuffix(runtime.GOOS, runtime.GOARCH, o.userAgentSuffix))
+	c := http.Client{Timeout: time.Duration(telemetryTimeout) * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request POST %s failed. Response Error: '%s'", metricsURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d: %s", metricsURL, resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	return nil
+}
+
+func (o TelemetryProviderOTLP) exportGRPC(export otlpExport) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(telemetryTimeout)*time.Second)
+	defer cancel()
+	transportCredentials := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))
+	if o.Insecure {
+		transportCredentials = grpc.WithInsecure()
+	}
+	conn, err := grpc.DialContext(ctx, o.Endpoint, transportCredentials, grpc.WithUserAgent(version.BuildUserAgentWithSuffix(runtime.GOOS, runtime.GOARCH, o.userAgentSuffix)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the otlp grpc endpoint '%s': %s", o.Endpoint, err)
+	}
+	defer conn.Close()
+	if len(o.Headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(o.Headers))
+	}
+	if err := conn.Invoke(ctx, otlpGRPCExportMethod, &otlpRawMessage{data: export.marshalProtobuf()}, &otlpRawMessage{}, grpc.ForceCodec(otlpRawCodec{})); err != nil {
+		return fmt.Errorf("grpc export to the otlp endpoint '%s' failed: %s", o.Endpoint, err)
+	}
+	return nil
+}
+
+// getHTTPMetricsURL returns the endpoint with the '/v1/metrics' path appended unless the endpoint already contains it
+func (o TelemetryProviderOTLP) getHTTPMetricsURL() string {
+	endpoint := strings.TrimRight(o.Endpoint, "/")
+	if strings.HasSuffix(endpoint, otlpHTTPMetricsPath) {
+		return endpoint
+	}
+	return endpoint + otlpHTTPMetricsPath
+}
+
+func (o TelemetryProviderOTLP) getProtocol() string {
+	if o.Protocol == "" {
+		return otlpProtocolHTTPProtobuf
+	}
+	return o.Protocol
+}
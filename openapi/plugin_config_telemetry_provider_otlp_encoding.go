@@ -0,0 +1,293 @@
+package openapi
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// otlpAggregationTemporalityDelta is the OTLP AGGREGATION_TEMPORALITY_DELTA enum value. Each counter increment is
+// exported as a delta since the plugin process does not keep track of the previous values
+const otlpAggregationTemporalityDelta = 1
+
+// otlpCounter describes a monotonic counter increment exported via OTLP
+type otlpCounter struct {
+	name       string
+	attributes map[string]string
+	timestamp  uint64
+	value      int64
+}
+
+// otlpHistogram describes a single observation (e,g: the duration of an operation) exported via OTLP as a histogram
+// with no explicit bounds, so the backend can aggregate the observations
+type otlpHistogram struct {
+	name       string
+	unit       string
+	attributes map[string]string
+	timestamp  uint64
+	value      float64
+}
+
+// otlpExport contains the information needed to build an OTLP ExportMetricsServiceRequest
+type otlpExport struct {
+	// timestamp is the time of the data points exported in nanoseconds since the epoch
+	timestamp          uint64
+	resourceAttributes map[string]string
+	scopeName          string
+	scopeVersion       string
+	counters           []otlpCounter
+	histograms         []otlpHistogram
+}
+
+// OTLP protobuf field numbers as defined in https://github.com/open-telemetry/opentelemetry-proto (v1)
+const (
+	otlpFieldExportRequestResourceMetrics = 1
+	otlpFieldResourceMetricsResource      = 1
+	otlpFieldResourceMetricsScopeMetrics  = 2
+	otlpFieldResourceAttributes           = 1
+	otlpFieldKeyValueKey                  = 1
+	otlpFieldKeyValueValue                = 2
+	otlpFieldAnyValueStringValue          = 1
+	otlpFieldScopeMetricsScope            = 1
+	otlpFieldScopeMetricsMetrics          = 2
+	otlpFieldScopeName                    = 1
+	otlpFieldScopeVersion                 = 2
+	otlpFieldMetricName                   = 1
+	otlpFieldMetricUnit                   = 3
+	otlpFieldMetricSum                    = 7
+	otlpFieldMetricHistogram              = 9
+	otlpFieldSumDataPoints                = 1
+	otlpFieldSumAggregationTemporality    = 2
+	otlpFieldSumIsMonotonic               = 3
+	otlpFieldDataPointStartTimeUnixNano   = 2
+	otlpFieldDataPointTimeUnixNano        = 3
+	otlpFieldDataPointAsInt               = 6
+	otlpFieldDataPointAttributes          = 7
+	otlpFieldHistogramDataPoints          = 1
+	otlpFieldHistogramTemporality         = 2
+	otlpFieldHistogramPointStartTime      = 2
+	otlpFieldHistogramPointTime           = 3
+	otlpFieldHistogramPointCount          = 4
+	otlpFieldHistogramPointSum            = 5
+	otlpFieldHistogramPointBucketCounts   = 6
+	otlpFieldHistogramPointAttributes     = 9
+)
+
+// protobuf wire types
+const (
+	protobufWireVarint          = 0
+	protobufWireFixed64         = 1
+	protobufWireLengthDelimited = 2
+)
+
+// marshalProtobuf returns the protobuf encoding of the ExportMetricsServiceRequest. The encoding is done by hand since
+// only a handful of messages are needed, which avoids depending on the generated OTLP protobuf packages
+func (e otlpExport) marshalProtobuf() []byte {
+	var resource []byte
+	for _, key := range sortedOTLPAttributeKeys(e.resourceAttributes) {
+		resource = appendProtobufMessage(resource, otlpFieldResourceAttributes, marshalOTLPKeyValue(key, e.resourceAttributes[key]))
+	}
+	var scope []byte
+	scope = appendProtobufString(scope, otlpFieldScopeName, e.scopeName)
+	scope = appendProtobufString(scope, otlpFieldScopeVersion, e.scopeVersion)
+
+	var scopeMetrics []byte
+	scopeMetrics = appendProtobufMessage(scopeMetrics, otlpFieldScopeMetricsScope, scope)
+	for _, counter := range e.counters {
+		scopeMetrics = appendProtobufMessage(scopeMetrics, otlpFieldScopeMetricsMetrics, counter.marshalProtobuf())
+	}
+	for _, histogram := range e.histograms {
+		scopeMetrics = appendProtobufMessage(scopeMetrics, otlpFieldScopeMetricsMetrics, histogram.marshalProtobuf())
+	}
+
+	var resourceMetrics []byte
+	resourceMetrics = appendProtobufMessage(resourceMetrics, otlpFieldResourceMetricsResource, resource)
+	resourceMetrics = appendProtobufMessage(resourceMetrics, otlpFieldResourceMetricsScopeMetrics, scopeMetrics)
+
+	return appendProtobufMessage(nil, otlpFieldExportRequestResourceMetrics, resourceMetrics)
+}
+
+func (c otlpCounter) marshalProtobuf() []byte {
+	var dataPoint []byte
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldDataPointStartTimeUnixNano, c.timestamp)
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldDataPointTimeUnixNano, c.timestamp)
+	// as_int is a sfixed64 field, hence the two's complement of the value is encoded
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldDataPointAsInt, uint64(c.value))
+	for _, key := range sortedOTLPAttributeKeys(c.attributes) {
+		dataPoint = appendProtobufMessage(dataPoint, otlpFieldDataPointAttributes, marshalOTLPKeyValue(key, c.attributes[key]))
+	}
+	var sum []byte
+	sum = appendProtobufMessage(sum, otlpFieldSumDataPoints, dataPoint)
+	sum = appendProtobufVarint(sum, otlpFieldSumAggregationTemporality, otlpAggregationTemporalityDelta)
+	sum = appendProtobufVarint(sum, otlpFieldSumIsMonotonic, 1)
+
+	var metric []byte
+	metric = appendProtobufString(metric, otlpFieldMetricName, c.name)
+	return appendProtobufMessage(metric, otlpFieldMetricSum, sum)
+}
+
+func (h otlpHistogram) marshalProtobuf() []byte {
+	var dataPoint []byte
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldHistogramPointStartTime, h.timestamp)
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldHistogramPointTime, h.timestamp)
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldHistogramPointCount, 1)
+	dataPoint = appendProtobufFixed64(dataPoint, otlpFieldHistogramPointSum, math.Float64bits(h.value))
+	// bucket_counts is a packed repeated fixed64 field; with no explicit bounds there is only one bucket
+	var bucketCounts [8]byte
+	binary.LittleEndian.PutUint64(bucketCounts[:], 1)
+	dataPoint = appendProtobufMessage(dataPoint, otlpFieldHistogramPointBucketCounts, bucketCounts[:])
+	for _, key := range sortedOTLPAttributeKeys(h.attributes) {
+		dataPoint = appendProtobufMessage(dataPoint, otlpFieldHistogramPointAttributes, marshalOTLPKeyValue(key, h.attributes[key]))
+	}
+	var histogram []byte
+	histogram = appendProtobufMessage(histogram, otlpFieldHistogramDataPoints, dataPoint)
+	histogram = appendProtobufVarint(histogram, otlpFieldHistogramTemporality, otlpAggregationTemporalityDelta)
+
+	var metric []byte
+	metric = appendProtobufString(metric, otlpFieldMetricName, h.name)
+	metric = appendProtobufString(metric, otlpFieldMetricUnit, h.unit)
+	return appendProtobufMessage(metric, otlpFieldMetricHistogram, histogram)
+}
+
+func marshalOTLPKeyValue(key, value string) []byte {
+	var keyValue []byte
+	keyValue = appendProtobufString(keyValue, otlpFieldKeyValueKey, key)
+	return appendProtobufMessage(keyValue, otlpFieldKeyValueValue, appendProtobufString(nil, otlpFieldAnyValueStringValue, value))
+}
+
+func appendProtobufTag(b []byte, field int, wireType uint64) []byte {
+	return appendUvarint(b, uint64(field)<<3|wireType)
+}
+
+func appendProtobufVarint(b []byte, field int, value uint64) []byte {
+	return appendUvarint(appendProtobufTag(b, field, protobufWireVarint), value)
+}
+
+func appendProtobufFixed64(b []byte, field int, value uint64) []byte {
+	b = appendProtobufTag(b, field, protobufWireFixed64)
+	var fixed [8]byte
+	binary.LittleEndian.PutUint64(fixed[:], value)
+	return append(b, fixed[:]...)
+}
+
+func appendProtobufMessage(b []byte, field int, message []byte) []byte {
+	b = appendUvarint(appendProtobufTag(b, field, protobufWireLengthDelimited), uint64(len(message)))
+	return append(b, message...)
+}
+
+func appendProtobufString(b []byte, field int, value string) []byte {
+	return appendProtobufMessage(b, field, []byte(value))
+}
+
+func appendUvarint(b []byte, value uint64) []byte {
+	var varint [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(varint[:], value)
+	return append(b, varint[:n]...)
+}
+
+// marshalJSON returns the OTLP JSON encoding of the ExportMetricsServiceRequest as described in
+// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/protocol/otlp.md#json-protobuf-encoding
+// (lowerCamelCase field names, 64 bit integers as strings and enums as integers)
+func (e otlpExport) marshalJSON() ([]byte, error) {
+	var metrics []interface{}
+	for _, counter := range e.counters {
+		metrics = append(metrics, map[string]interface{}{
+			"name": counter.name,
+			"sum": map[string]interface{}{
+				"dataPoints": []interface{}{
+					map[string]interface{}{
+						"attributes":        marshalOTLPJSONAttributes(counter.attributes),
+						"startTimeUnixNano": strconv.FormatUint(counter.timestamp, 10),
+						"timeUnixNano":      strconv.FormatUint(counter.timestamp, 10),
+						"asInt":             strconv.FormatInt(counter.value, 10),
+					},
+				},
+				"aggregationTemporality": otlpAggregationTemporalityDelta,
+				"isMonotonic":            true,
+			},
+		})
+	}
+	for _, histogram := range e.histograms {
+		metrics = append(metrics, map[string]interface{}{
+			"name": histogram.name,
+			"unit": histogram.unit,
+			"histogram": map[string]interface{}{
+				"dataPoints": []interface{}{
+					map[string]interface{}{
+						"attributes":        marshalOTLPJSONAttributes(histogram.attributes),
+						"startTimeUnixNano": strconv.FormatUint(histogram.timestamp, 10),
+						"timeUnixNano":      strconv.FormatUint(histogram.timestamp, 10),
+						"count":             "1",
+						"sum":               histogram.value,
+						"bucketCounts":      []string{"1"},
+					},
+				},
+				"aggregationTemporality": otlpAggregationTemporalityDelta,
+			},
+		})
+	}
+	return json.Marshal(map[string]interface{}{
+		"resourceMetrics": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": marshalOTLPJSONAttributes(e.resourceAttributes),
+				},
+				"scopeMetrics": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{
+							"name":    e.scopeName,
+							"version": e.scopeVersion,
+						},
+						"metrics": metrics,
+					},
+				},
+			},
+		},
+	})
+}
+
+func marshalOTLPJSONAttributes(attributes map[string]string) []interface{} {
+	jsonAttributes := []interface{}{}
+	for _, key := range sortedOTLPAttributeKeys(attributes) {
+		jsonAttributes = append(jsonAttributes, map[string]interface{}{
+			"key":   key,
+			"value": map[string]interface{}{"stringValue": attributes[key]},
+		})
+	}
+	return jsonAttributes
+}
+
+// otlpRawMessage holds an already encoded protobuf message so it can be sent over gRPC via the otlpRawCodec
+type otlpRawMessage struct {
+	data []byte
+}
+
+// otlpRawCodec is a gRPC codec that sends and receives the protobuf messages as they are. The response messages are not
+// needed so their content is discarded
+type otlpRawCodec struct{}
+
+func (otlpRawCodec) Marshal(v interface{}) ([]byte, error) {
+	return v.(*otlpRawMessage).data, nil
+}
+
+func (otlpRawCodec) Unmarshal(data []byte, v interface{}) error {
+	v.(*otlpRawMessage).data = data
+	return nil
+}
+
+// Name returns 'proto' so the requests are sent with the standard 'application/grpc+proto' content type
+func (otlpRawCodec) Name() string {
+	return "proto"
+}
+
+// sortedOTLPAttributeKeys returns the attribute keys sorted so the encoding of the attributes is deterministic
+func sortedOTLPAttributeKeys(attributes map[string]string) []string {
+	var keys []string
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
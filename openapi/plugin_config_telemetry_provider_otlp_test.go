@@ -0,0 +1,341 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTelemetryProviderOTLP_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		endpoint    string
+		protocol    string
+		expectedErr error
+	}{
+		{
+			testName:    "happy path - http endpoint with the default protocol",
+			endpoint:    "http://localhost:4318",
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - https endpoint with the http/json protocol",
+			endpoint:    "https://otel-collector.myhost.com/v1/metrics",
+			protocol:    otlpProtocolHTTPJSON,
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - grpc address",
+			endpoint:    "localhost:4317",
+			protocol:    otlpProtocolGRPC,
+			expectedErr: nil,
+		},
+		{
+			testName:    "crappy path - endpoint is empty",
+			endpoint:    "",
+			expectedErr: errors.New("otlp telemetry configuration is missing a value for the 'endpoint' property"),
+		},
+		{
+			testName:    "crappy path - http protocol with an endpoint that is not a URL",
+			endpoint:    "localhost:4318",
+			expectedErr: errors.New("otlp telemetry configuration 'endpoint' property value 'localhost:4318' is not valid, the http/protobuf protocol expects an http or https URL (e,g: http://localhost:4318)"),
+		},
+		{
+			testName:    "crappy path - grpc protocol with an endpoint that is not an address",
+			endpoint:    "http://localhost",
+			protocol:    otlpProtocolGRPC,
+			expectedErr: errors.New("otlp telemetry configuration 'endpoint' property value 'http://localhost' is not valid, the grpc protocol expects an address in the form host:port (e,g: localhost:4317)"),
+		},
+		{
+			testName:    "crappy path - protocol not supported",
+			endpoint:    "http://localhost:4318",
+			protocol:    "udp",
+			expectedErr: errors.New("otlp telemetry configuration 'protocol' property value 'udp' is not supported, supported values are: http/protobuf, http/json and grpc"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tpo := TelemetryProviderOTLP{
+			Endpoint: tc.endpoint,
+			Protocol: tc.protocol,
+		}
+		err := tpo.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderOTLP_getHTTPMetricsURL(t *testing.T) {
+	testCases := []struct {
+		endpoint    string
+		expectedURL string
+	}{
+		{endpoint: "http://localhost:4318", expectedURL: "http://localhost:4318/v1/metrics"},
+		{endpoint: "http://localhost:4318/", expectedURL: "http://localhost:4318/v1/metrics"},
+		{endpoint: "https://otel-collector.myhost.com/v1/metrics", expectedURL: "https://otel-collector.myhost.com/v1/metrics"},
+		{endpoint: "https://myhost.com/otlp", expectedURL: "https://myhost.com/otlp/v1/metrics"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedURL, TelemetryProviderOTLP{Endpoint: tc.endpoint}.getHTTPMetricsURL(), tc.endpoint)
+	}
+}
+
+type otlpTestHTTPRequest struct {
+	path        string
+	contentType string
+	userAgent   string
+	apiKey      string
+	body        []byte
+}
+
+func newOTLPTestHTTPServer(statusCode int) (*httptest.Server, chan otlpTestHTTPRequest) {
+	requests := make(chan otlpTestHTTPRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- otlpTestHTTPRequest{path: r.URL.Path, contentType: r.Header.Get(contentType), userAgent: r.Header.Get(userAgentHeader), apiKey: r.Header.Get("X-Api-Key"), body: body}
+		w.WriteHeader(statusCode)
+		w.Write([]byte("some error message"))
+	}))
+	return server, requests
+}
+
+func TestTelemetryProviderOTLP_IncOpenAPIPluginVersionTotalRunsCounter_HTTPJSON(t *testing.T) {
+	server, requests := newOTLPTestHTTPServer(http.StatusOK)
+	defer server.Close()
+
+	now := time.Unix(0, 1600000000000000000)
+	tpo := TelemetryProviderOTLP{
+		Endpoint:        server.URL,
+		Protocol:        otlpProtocolHTTPJSON,
+		Prefix:          "myPrefixName",
+		Headers:         map[string]string{"X-Api-Key": "some-key"},
+		userAgentSuffix: "my-suffix",
+		now:             func() time.Time { return now },
+	}
+	err := tpo.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil)
+	assert.NoError(t, err)
+
+	request := <-requests
+	assert.Equal(t, "/v1/metrics", request.path)
+	assert.Equal(t, "application/json", request.contentType)
+	assert.Equal(t, "some-key", request.apiKey)
+	assert.Contains(t, request.userAgent, "my-suffix")
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(request.body, &body))
+	expectedBody := map[string]interface{}{
+		"resourceMetrics": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": []interface{}{
+						map[string]interface{}{"key": "service.name", "value": map[string]interface{}{"stringValue": "terraform-provider-openapi"}},
+						map[string]interface{}{"key": "service.version", "value": map[string]interface{}{"stringValue": version.Version}},
+					},
+				},
+				"scopeMetrics": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "github.com/dikhan/terraform-provider-openapi", "version": version.Version},
+						"metrics": []interface{}{
+							map[string]interface{}{
+								"name": "myPrefixName.terraform.openapi_plugin_version.total_runs",
+								"sum": map[string]interface{}{
+									"dataPoints": []interface{}{
+										map[string]interface{}{
+											"attributes":        []interface{}{map[string]interface{}{"key": "openapi_plugin_version", "value": map[string]interface{}{"stringValue": "0.25.0"}}},
+											"startTimeUnixNano": "1600000000000000000",
+											"timeUnixNano":      "1600000000000000000",
+											"asInt":             "1",
+										},
+									},
+									"aggregationTemporality": float64(1),
+									"isMonotonic":            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedBody, body)
+}
+
+func TestTelemetryProviderOTLP_IncServiceProviderTotalRunsCounter_HTTPProtobuf(t *testing.T) {
+	server, requests := newOTLPTestHTTPServer(http.StatusOK)
+	defer server.Close()
+
+	now := time.Unix(0, 1600000000000000000)
+	tpo := TelemetryProviderOTLP{
+		Endpoint: server.URL + "/v1/metrics",
+		now:      func() time.Time { return now },
+	}
+	err := tpo.IncServiceProviderTotalRunsCounter("cdn", nil)
+	assert.NoError(t, err)
+
+	request := <-requests
+	assert.Equal(t, "/v1/metrics", request.path)
+	assert.Equal(t, "application/x-protobuf", request.contentType)
+	expectedExport := tpo.newExport(otlpCounter{name: "terraform.providers.total_runs", attributes: map[string]string{"provider_name": "cdn"}, value: 1})
+	assert.Equal(t, expectedExport.marshalProtobuf(), request.body)
+}
+
+func TestTelemetryProviderOTLP_ExportHTTPFailure(t *testing.T) {
+	server, requests := newOTLPTestHTTPServer(http.StatusBadRequest)
+	defer server.Close()
+
+	tpo := TelemetryProviderOTLP{Endpoint: server.URL}
+	err := tpo.IncServiceProviderTotalRunsCounter("cdn", nil)
+	<-requests
+	assert.EqualError(t, err, "response returned from POST '"+server.URL+"/v1/metrics' returned a non expected status code 400: some error message")
+}
+
+// otlpTestGRPCServerCodec adapts the otlpRawCodec to the codec interface expected by the gRPC server
+type otlpTestGRPCServerCodec struct {
+	otlpRawCodec
+}
+
+func (otlpTestGRPCServerCodec) String() string {
+	return "proto"
+}
+
+type otlpTestGRPCRequest struct {
+	method string
+	apiKey []string
+	body   []byte
+}
+
+func TestTelemetryProviderOTLP_IncServiceProviderTotalRunsCounter_GRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	requests := make(chan otlpTestGRPCRequest, 1)
+	server := grpc.NewServer(grpc.CustomCodec(otlpTestGRPCServerCodec{}), grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		request := &otlpRawMessage{}
+		if err := stream.RecvMsg(request); err != nil {
+			return err
+		}
+		requests <- otlpTestGRPCRequest{method: method, apiKey: md.Get("x-api-key"), body: request.data}
+		return stream.SendMsg(&otlpRawMessage{})
+	}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	now := time.Unix(0, 1600000000000000000)
+	tpo := TelemetryProviderOTLP{
+		Endpoint: listener.Addr().String(),
+		Protocol: otlpProtocolGRPC,
+		Insecure: true,
+		Headers:  map[string]string{"X-Api-Key": "some-key"},
+		now:      func() time.Time { return now },
+	}
+	err = tpo.IncServiceProviderTotalRunsCounter("cdn", nil)
+	assert.NoError(t, err)
+
+	request := <-requests
+	assert.Equal(t, "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export", request.method)
+	assert.Equal(t, []string{"some-key"}, request.apiKey)
+	expectedExport := tpo.newExport(otlpCounter{name: "terraform.providers.total_runs", attributes: map[string]string{"provider_name": "cdn"}, value: 1})
+	assert.Equal(t, expectedExport.marshalProtobuf(), request.body)
+}
+
+func TestOTLPExportMarshalProtobuf(t *testing.T) {
+	// KeyValue{key: "a", value: AnyValue{string_value: "b"}}
+	assert.Equal(t, []byte{0x0a, 0x01, 'a', 0x12, 0x03, 0x0a, 0x01, 'b'}, marshalOTLPKeyValue("a", "b"))
+
+	counter := otlpCounter{name: "m", timestamp: 1, value: 1}
+	expectedDataPoint := []byte{
+		0x11, 1, 0, 0, 0, 0, 0, 0, 0, // start_time_unix_nano
+		0x19, 1, 0, 0, 0, 0, 0, 0, 0, // time_unix_nano
+		0x31, 1, 0, 0, 0, 0, 0, 0, 0, // as_int
+	}
+	expectedSum := append([]byte{0x0a, byte(len(expectedDataPoint))}, expectedDataPoint...)
+	expectedSum = append(expectedSum, 0x10, 0x01, 0x18, 0x01) // aggregation_temporality DELTA and is_monotonic
+	expectedMetric := append([]byte{0x0a, 0x01, 'm', 0x3a, byte(len(expectedSum))}, expectedSum...)
+	assert.Equal(t, expectedMetric, counter.marshalProtobuf())
+}
+
+func TestTelemetryProviderOTLP_SubmitResourceOperationTimingMetric(t *testing.T) {
+	server, requests := newOTLPTestHTTPServer(http.StatusOK)
+	defer server.Close()
+
+	now := time.Unix(0, 1600000000000000000)
+	tpo := TelemetryProviderOTLP{
+		Endpoint: server.URL,
+		Protocol: otlpProtocolHTTPJSON,
+		now:      func() time.Time { return now },
+	}
+	err := tpo.SubmitResourceOperationTimingMetric("cdn", "cdn_v1", TelemetryResourceOperationCreate, 1500*time.Microsecond, nil)
+	assert.NoError(t, err)
+
+	request := <-requests
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(request.body, &body))
+	metrics := body["resourceMetrics"].([]interface{})[0].(map[string]interface{})["scopeMetrics"].([]interface{})[0].(map[string]interface{})["metrics"]
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name": "terraform.resource_operation.duration",
+			"unit": "ms",
+			"histogram": map[string]interface{}{
+				"dataPoints": []interface{}{
+					map[string]interface{}{
+						"attributes": []interface{}{
+							map[string]interface{}{"key": "operation", "value": map[string]interface{}{"stringValue": "create"}},
+							map[string]interface{}{"key": "provider_name", "value": map[string]interface{}{"stringValue": "cdn"}},
+							map[string]interface{}{"key": "resource_name", "value": map[string]interface{}{"stringValue": "cdn_v1"}},
+						},
+						"startTimeUnixNano": "1600000000000000000",
+						"timeUnixNano":      "1600000000000000000",
+						"count":             "1",
+						"sum":               1.5,
+						"bucketCounts":      []interface{}{"1"},
+					},
+				},
+				"aggregationTemporality": float64(1),
+			},
+		},
+	}, metrics)
+}
+
+func TestOTLPHistogramMarshalProtobuf(t *testing.T) {
+	histogram := otlpHistogram{name: "m", unit: "ms", timestamp: 1, value: 1}
+	expectedDataPoint := []byte{
+		0x11, 1, 0, 0, 0, 0, 0, 0, 0, // start_time_unix_nano
+		0x19, 1, 0, 0, 0, 0, 0, 0, 0, // time_unix_nano
+		0x21, 1, 0, 0, 0, 0, 0, 0, 0, // count
+		0x29, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, // sum (1.0)
+		0x32, 0x08, 1, 0, 0, 0, 0, 0, 0, 0, // bucket_counts
+	}
+	expectedHistogram := append([]byte{0x0a, byte(len(expectedDataPoint))}, expectedDataPoint...)
+	expectedHistogram = append(expectedHistogram, 0x10, 0x01) // aggregation_temporality DELTA
+	expectedMetric := append([]byte{0x0a, 0x01, 'm', 0x1a, 0x02, 'm', 's', 0x4a, byte(len(expectedHistogram))}, expectedHistogram...)
+	assert.Equal(t, expectedMetric, histogram.marshalProtobuf())
+}
+
+func TestTelemetryProviderOTLP_IncResourceOperationTotalCounters(t *testing.T) {
+	server, requests := newOTLPTestHTTPServer(http.StatusOK)
+	defer server.Close()
+
+	now := time.Unix(0, 1600000000000000000)
+	tpo := TelemetryProviderOTLP{
+		Endpoint: server.URL,
+		now:      func() time.Time { return now },
+	}
+	expectedAttributes := map[string]string{"provider_name": "cdn", "resource_name": "cdn_v1", "operation": "update"}
+
+	assert.NoError(t, tpo.IncResourceOperationTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationUpdate, nil))
+	expectedExport := tpo.newExport(otlpCounter{name: "terraform.resource_operation.total", attributes: expectedAttributes, value: 1})
+	assert.Equal(t, expectedExport.marshalProtobuf(), (<-requests).body)
+
+	assert.NoError(t, tpo.IncResourceOperationErrorsTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationUpdate, nil))
+	expectedExport = tpo.newExport(otlpCounter{name: "terraform.resource_operation.errors.total", attributes: expectedAttributes, value: 1})
+	assert.Equal(t, expectedExport.marshalProtobuf(), (<-requests).body)
+}
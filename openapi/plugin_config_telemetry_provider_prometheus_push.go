@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/version"
+)
+
+const prometheusPushDefaultJob = "terraform-provider-openapi"
+const prometheusTextFormatContentType = "text/plain; version=0.0.4"
+
+var prometheusLabelNameRegex = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
+var prometheusMetricNameInvalidChars = regexp.MustCompile("[^a-zA-Z0-9_:]")
+
+// TelemetryProviderPrometheusPush defines the configuration for a Prometheus Pushgateway. This struct also implements the
+// TelemetryProvider interface and pushes the counters using the Prometheus text exposition format to the grouping key
+// /metrics/job/<job>/instance/<instance> of the Pushgateway. The metrics are named <prefix>_terraform_* where '<prefix>'
+// can be configured.
+type TelemetryProviderPrometheusPush struct {
+	// URL describes the Pushgateway URL (e,g: http://pushgateway.myhost.com:9091)
+	URL string `yaml:"url"`
+	// Job describes the job label of the metrics pushed. Defaults to terraform-provider-openapi
+	Job string `yaml:"job,omitempty"`
+	// Instance describes the instance label of the metrics pushed. If not provided, the metrics are pushed without the
+	// instance label
+	Instance string `yaml:"instance,omitempty"`
+	// Labels contains additional grouping labels added to the metrics pushed
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Prefix enables to append a prefix to the metrics pushed
+	Prefix string `yaml:"prefix,omitempty"`
+	// userAgentSuffix is appended to the default user agent sent in the telemetry requests. The value is populated
+	// from the service configuration user_agent_suffix
+	userAgentSuffix string
+	// logger is used to log the metrics submissions. The value is populated from the plugin configuration
+	logger Logger
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider
+// registration. If this method returns an error the error will be logged but the telemetry will be disabled. Otherwise,
+// the telemetry will be enabled and the corresponding metrics will be pushed to the Pushgateway
+func (p TelemetryProviderPrometheusPush) Validate() error {
+	if p.URL == "" {
+		return errors.New("prometheus push telemetry configuration is missing a value for the 'url' property")
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("prometheus push telemetry configuration does not have a valid URL '%s'", p.URL)
+	}
+	for name := range p.Labels {
+		if !prometheusLabelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("prometheus push telemetry configuration contains an invalid label name '%s', label names must match %s and must not start with '__'", name, prometheusLabelNameRegex)
+		}
+		if name == "job" || name == "instance" {
+			return fmt.Errorf("prometheus push telemetry configuration label '%s' must be configured using the '%s' property instead", name, name)
+		}
+	}
+	return nil
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will push the counter '<prefix>_terraform_openapi_plugin_version_total_runs'
+// with the 'openapi_plugin_version' label containing the OpenAPI plugin version used at runtime
+func (p TelemetryProviderPrometheusPush) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
+	return p.push("terraform_openapi_plugin_version_total_runs", "counter", map[string]string{"openapi_plugin_version": openAPIPluginVersion}, "1")
+}
+
+// IncServiceProviderTotalRunsCounter will push the counter '<prefix>_terraform_providers_total_runs' with the
+// 'provider_name' label containing the provider name used at runtime
+func (p TelemetryProviderPrometheusPush) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
+	return p.push("terraform_providers_total_runs", "counter", map[string]string{"provider_name": providerName}, "1")
+}
+
+// SubmitResourceOperationTimingMetric will push the gauge '<prefix>_terraform_resource_operation_duration_seconds' with
+// the 'provider_name', 'resource_name' and 'operation' labels containing the time it took to perform the last resource
+// operation
+func (p TelemetryProviderPrometheusPush) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	labels := map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)}
+	return p.push("terraform_resource_operation_duration_seconds", "gauge", labels, strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+}
+
+// IncResourceOperationTotalCounter will push the counter '<prefix>_terraform_resource_operation_total' with the
+// 'provider_name', 'resource_name' and 'operation' labels
+func (p TelemetryProviderPrometheusPush) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return p.push("terraform_resource_operation_total", "counter", map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)}, "1")
+}
+
+// IncResourceOperationErrorsTotalCounter will push the counter '<prefix>_terraform_resource_operation_errors_total' with
+// the 'provider_name', 'resource_name' and 'operation' labels
+func (p TelemetryProviderPrometheusPush) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return p.push("terraform_resource_operation_errors_total", "counter", map[string]string{"provider_name": providerName, "resource_name": resourceName, "operation": string(operation)}, "1")
+}
+
+// push pushes the metric sample using POST so only the metrics with the same name are replaced in the grouping key,
+// leaving the rest of the metrics pushed by the plugin untouched
+func (p TelemetryProviderPrometheusPush) push(metricName, metricType string, labels map[string]string, value string) error {
+	metric := p.buildMetricName(metricName)
+	loggerOrDefault(p.logger).Info(fmt.Sprintf("prometheus push metric to be submitted: %s", metric), "metric", metric)
+	pushURL := p.getPushURL()
+	var labelPairs []string
+	for name, labelValue := range labels {
+		labelPairs = append(labelPairs, fmt.Sprintf("%s=\"%s\"", name, escapePrometheusLabelValue(labelValue)))
+	}
+	sort.Strings(labelPairs)
+	body := fmt.Sprintf("# TYPE %s %s\n%s{%s} %s\n", metric, metricType, metric, strings.Join(labelPairs, ","), value)
+	req, err := http.NewRequest(http.MethodPost, pushURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set(contentType, prometheusTextFormatContentType)
+	req.Header.Set(userAgentHeader, version.BuildUserAgentWithSuffix(runtime.GOOS, runtime.GOARCH, p.userAgentSuffix))
+	c := http.Client{Timeout: time.Duration(telemetryTimeout) * time.Second}
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("request POST %s failed. Response Error: '%s'", pushURL, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		responseBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("response returned from POST '%s' returned a non expected status code %d: %s", pushURL, resp.StatusCode, strings.TrimSpace(string(responseBody)))
+	}
+	loggerOrDefault(p.logger).Info(fmt.Sprintf("prometheus push metric successfully submitted: %s", metric), "metric", metric)
+	return nil
+}
+
+// getPushURL returns the Pushgateway URL for the grouping key job/<job>/instance/<instance>/<label>/<value>. Label values
+// containing slashes are base64 encoded as supported by the Pushgateway
+func (p TelemetryProviderPrometheusPush) getPushURL() string {
+	job := p.Job
+	if job == "" {
+		job = prometheusPushDefaultJob
+	}
+	groupingKey := []string{"job", job}
+	if p.Instance != "" {
+		groupingKey = append(groupingKey, "instance", p.Instance)
+	}
+	var labelNames []string
+	for name := range p.Labels {
+		labelNames = append(labelNames, name)
+	}
+	sort.Strings(labelNames)
+	for _, name := range labelNames {
+		groupingKey = append(groupingKey, name, p.Labels[name])
+	}
+	var path []string
+	for i := 0; i < len(groupingKey); i += 2 {
+		name, value := groupingKey[i], groupingKey[i+1]
+		if strings.Contains(value, "/") || value == "" {
+			path = append(path, name+"@base64", base64.RawURLEncoding.EncodeToString([]byte(value)))
+			continue
+		}
+		path = append(path, name, url.PathEscape(value))
+	}
+	return fmt.Sprintf("%s/metrics/%s", strings.TrimRight(p.URL, "/"), strings.Join(path, "/"))
+}
+
+// buildMetricName returns the metric name with the prefix (if any), replacing the characters not allowed in Prometheus
+// metric names with underscores
+func (p TelemetryProviderPrometheusPush) buildMetricName(metricName string) string {
+	if p.Prefix != "" {
+		metricName = fmt.Sprintf("%s_%s", p.Prefix, metricName)
+	}
+	return prometheusMetricNameInvalidChars.ReplaceAllString(metricName, "_")
+}
+
+func escapePrometheusLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`).Replace(value)
+}
@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryProviderPrometheusPush_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		url         string
+		labels      map[string]string
+		expectedErr error
+	}{
+		{
+			testName:    "happy path - url populated",
+			url:         "http://pushgateway.myhost.com:9091",
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - url and labels populated",
+			url:         "https://pushgateway.myhost.com",
+			labels:      map[string]string{"environment": "prod"},
+			expectedErr: nil,
+		},
+		{
+			testName:    "crappy path - url is empty",
+			url:         "",
+			expectedErr: errors.New("prometheus push telemetry configuration is missing a value for the 'url' property"),
+		},
+		{
+			testName:    "crappy path - url is not valid",
+			url:         "pushgateway.myhost.com:9091",
+			expectedErr: errors.New("prometheus push telemetry configuration does not have a valid URL 'pushgateway.myhost.com:9091'"),
+		},
+		{
+			testName:    "crappy path - label name is not valid",
+			url:         "http://pushgateway.myhost.com:9091",
+			labels:      map[string]string{"my-label": "value"},
+			expectedErr: errors.New("prometheus push telemetry configuration contains an invalid label name 'my-label', label names must match ^[a-zA-Z_][a-zA-Z0-9_]*$ and must not start with '__'"),
+		},
+		{
+			testName:    "crappy path - job configured as a label",
+			url:         "http://pushgateway.myhost.com:9091",
+			labels:      map[string]string{"job": "value"},
+			expectedErr: errors.New("prometheus push telemetry configuration label 'job' must be configured using the 'job' property instead"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tpp := TelemetryProviderPrometheusPush{
+			URL:    tc.url,
+			Labels: tc.labels,
+		}
+		err := tpp.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderPrometheusPush_getPushURL(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		provider    TelemetryProviderPrometheusPush
+		expectedURL string
+	}{
+		{
+			testName:    "default job",
+			provider:    TelemetryProviderPrometheusPush{URL: "http://pushgateway:9091/"},
+			expectedURL: "http://pushgateway:9091/metrics/job/terraform-provider-openapi",
+		},
+		{
+			testName:    "job, instance and labels",
+			provider:    TelemetryProviderPrometheusPush{URL: "http://pushgateway:9091", Job: "terraform", Instance: "ci-runner-1", Labels: map[string]string{"team": "infra", "environment": "prod"}},
+			expectedURL: "http://pushgateway:9091/metrics/job/terraform/instance/ci-runner-1/environment/prod/team/infra",
+		},
+		{
+			testName:    "values containing slashes are base64 encoded",
+			provider:    TelemetryProviderPrometheusPush{URL: "http://pushgateway:9091", Job: "terraform", Instance: "runners/1"},
+			expectedURL: "http://pushgateway:9091/metrics/job/terraform/instance@base64/cnVubmVycy8x",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedURL, tc.provider.getPushURL(), tc.testName)
+	}
+}
+
+func TestTelemetryProviderPrometheusPush_IncCounters(t *testing.T) {
+	type pushRequest struct {
+		method      string
+		path        string
+		contentType string
+		body        string
+	}
+	requests := make(chan pushRequest, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- pushRequest{method: r.Method, path: r.URL.Path, contentType: r.Header.Get(contentType), body: string(body)}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tpp := TelemetryProviderPrometheusPush{URL: server.URL, Job: "terraform", Instance: "ci", Prefix: "my.prefix"}
+
+	assert.NoError(t, tpp.IncOpenAPIPluginVersionTotalRunsCounter("0.25.0", nil))
+	request := <-requests
+	assert.Equal(t, http.MethodPost, request.method)
+	assert.Equal(t, "/metrics/job/terraform/instance/ci", request.path)
+	assert.Equal(t, "text/plain; version=0.0.4", request.contentType)
+	assert.Equal(t, "# TYPE my_prefix_terraform_openapi_plugin_version_total_runs counter\nmy_prefix_terraform_openapi_plugin_version_total_runs{openapi_plugin_version=\"0.25.0\"} 1\n", request.body)
+
+	assert.NoError(t, tpp.IncServiceProviderTotalRunsCounter("cdn", nil))
+	request = <-requests
+	assert.Equal(t, "# TYPE my_prefix_terraform_providers_total_runs counter\nmy_prefix_terraform_providers_total_runs{provider_name=\"cdn\"} 1\n", request.body)
+}
+
+func TestTelemetryProviderPrometheusPush_PushFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("pushed metrics are invalid"))
+	}))
+	defer server.Close()
+
+	tpp := TelemetryProviderPrometheusPush{URL: server.URL}
+	err := tpp.IncServiceProviderTotalRunsCounter("cdn", nil)
+	assert.EqualError(t, err, "response returned from POST '"+server.URL+"/metrics/job/terraform-provider-openapi' returned a non expected status code 400: pushed metrics are invalid")
+}
+
+func TestTelemetryProviderPrometheusPush_SubmitResourceOperationTimingMetric(t *testing.T) {
+	bodies := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tpp := TelemetryProviderPrometheusPush{URL: server.URL}
+	assert.NoError(t, tpp.SubmitResourceOperationTimingMetric("cdn", "cdn_v1", TelemetryResourceOperationDelete, 1500*time.Millisecond, nil))
+	assert.Equal(t, "# TYPE terraform_resource_operation_duration_seconds gauge\nterraform_resource_operation_duration_seconds{operation=\"delete\",provider_name=\"cdn\",resource_name=\"cdn_v1\"} 1.5\n", <-bodies)
+}
+
+func TestTelemetryProviderPrometheusPush_IncResourceOperationTotalCounters(t *testing.T) {
+	bodies := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies <- string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tpp := TelemetryProviderPrometheusPush{URL: server.URL}
+	assert.NoError(t, tpp.IncResourceOperationTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	assert.Equal(t, "# TYPE terraform_resource_operation_total counter\nterraform_resource_operation_total{operation=\"create\",provider_name=\"cdn\",resource_name=\"cdn_v1\"} 1\n", <-bodies)
+	assert.NoError(t, tpp.IncResourceOperationErrorsTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationCreate, nil))
+	assert.Equal(t, "# TYPE terraform_resource_operation_errors_total counter\nterraform_resource_operation_errors_total{operation=\"create\",provider_name=\"cdn\",resource_name=\"cdn_v1\"} 1\n", <-bodies)
+}
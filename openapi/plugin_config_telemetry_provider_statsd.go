@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TelemetryProviderStatsd defines the configuration for a statsd agent. This struct also implements the TelemetryProvider
+// interface and ships the counters over UDP using the statsd line format (<prefix>.terraform.*:1|c) where '<prefix>' can
+// be configured. If tags are configured, the DogStatsD tag extension is used (<prefix>.terraform.*:1|c|#tag1,tag2).
+type TelemetryProviderStatsd struct {
+	// Address describes the statsd agent address in the form host:port (e,g: localhost:8125). Alternatively, the host
+	// and port can be configured separately
+	Address string `yaml:"address,omitempty"`
+	// Host describes the statsd agent host, only used if the address is not provided
+	Host string `yaml:"host,omitempty"`
+	// Port describes the statsd agent port, only used if the address is not provided
+	Port int `yaml:"port,omitempty"`
+	// Prefix enables to append a prefix to the metrics pushed to statsd
+	Prefix string `yaml:"prefix,omitempty"`
+	// Tags contains the DogStatsD tags (e,g: environment:prod) added to all the metrics pushed to statsd. Note the tags
+	// are only supported by agents implementing the DataDog extension
+	Tags []string `yaml:"tags,omitempty"`
+	// logger is used to log the metrics submissions. The value is populated from the plugin configuration
+	logger Logger
+}
+
+// Validate checks whether the provider is configured correctly. This validation is performed upon telemetry provider
+// registration. If this method returns an error the error will be logged but the telemetry will be disabled. Otherwise,
+// the telemetry will be enabled and the corresponding metrics will be shipped to the statsd agent
+func (s TelemetryProviderStatsd) Validate() error {
+	if err := s.validateAddress(); err != nil {
+		return err
+	}
+	for _, tag := range s.Tags {
+		if tag == "" || strings.ContainsAny(tag, "|,\n") {
+			return fmt.Errorf("statsd telemetry configuration contains an invalid tag '%s', tags must not be empty nor contain the characters '|' or ','", tag)
+		}
+	}
+	return nil
+}
+
+func (s TelemetryProviderStatsd) validateAddress() error {
+	if s.Address == "" && s.Host != "" {
+		if s.Port < 1 || s.Port > 65535 {
+			return fmt.Errorf("statsd telemetry configuration 'port' property value '%d' is not valid, the port must be a number between 1 and 65535", s.Port)
+		}
+		return nil
+	}
+	if s.Address == "" {
+		return errors.New("statsd telemetry configuration is missing a value for the 'address' property")
+	}
+	host, port, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		return fmt.Errorf("statsd telemetry configuration 'address' property value '%s' is not valid: %s", s.Address, err)
+	}
+	if host == "" {
+		return fmt.Errorf("statsd telemetry configuration 'address' property value '%s' is missing the host", s.Address)
+	}
+	if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("statsd telemetry configuration 'address' property value '%s' contains an invalid port, the port must be a number between 1 and 65535", s.Address)
+	}
+	return nil
+}
+
+// getAddress returns the address of the statsd agent, built from the host and port if the address is not provided
+func (s TelemetryProviderStatsd) getAddress() string {
+	if s.Address == "" && s.Host != "" {
+		return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	}
+	return s.Address
+}
+
+// IncOpenAPIPluginVersionTotalRunsCounter will increment the counter '<prefix>.terraform.openapi_plugin_version.%s.total_runs'
+// metric to 1. The %s will be replaced by the OpenAPI plugin version used at runtime
+func (s TelemetryProviderStatsd) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
+	version := strings.Replace(openAPIPluginVersion, ".", "_", -1)
+	return s.submitCounter(fmt.Sprintf("terraform.openapi_plugin_version.%s.total_runs", version))
+}
+
+// IncServiceProviderTotalRunsCounter will increment the counter for a given provider '<prefix>.terraform.providers.%s.total_runs'
+// metric to 1. The %s will be replaced by the provider name used at runtime
+func (s TelemetryProviderStatsd) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
+	return s.submitCounter(fmt.Sprintf("terraform.providers.%s.total_runs", providerName))
+}
+
+// SubmitResourceOperationTimingMetric will submit the timing '<prefix>.terraform.providers.%s.resources.%s.%s.duration'
+// metric in milliseconds. The %s will be replaced by the provider name, the resource name and the operation respectively
+func (s TelemetryProviderStatsd) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	metric := buildResourceOperationTimingMetricName(providerName, resourceName, operation)
+	return s.submitLine(metric, s.buildLine(metric, strconv.FormatFloat(durationInMilliseconds(duration), 'f', -1, 64), "ms"))
+}
+
+// IncResourceOperationTotalCounter will increment the counter '<prefix>.terraform.providers.%s.%s.%s.total' metric to 1.
+// The %s will be replaced by the provider name, the resource name and the operation respectively
+func (s TelemetryProviderStatsd) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return s.submitCounter(buildResourceOperationTotalCounterName(providerName, resourceName, operation))
+}
+
+// IncResourceOperationErrorsTotalCounter will increment the counter '<prefix>.terraform.providers.%s.%s.%s.errors.total'
+// metric to 1. The %s will be replaced by the provider name, the resource name and the operation respectively
+func (s TelemetryProviderStatsd) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	return s.submitCounter(buildResourceOperationErrorsTotalCounterName(providerName, resourceName, operation))
+}
+
+// submitCounter writes the counter line to the statsd agent. Errors are returned to the telemetry handler which logs
+// them without affecting the provider execution
+func (s TelemetryProviderStatsd) submitCounter(metric string) error {
+	return s.submitLine(metric, s.buildCounterLine(metric))
+}
+
+func (s TelemetryProviderStatsd) submitLine(metric, line string) error {
+	loggerOrDefault(s.logger).Info(fmt.Sprintf("statsd metric to be submitted: %s", metric), "metric", metric)
+	address := s.getAddress()
+	conn, err := net.DialTimeout("udp", address, telemetryTimeout*time.Second)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the statsd agent '%s': %s", address, err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(line)); err != nil {
+		return fmt.Errorf("failed to submit metric '%s' to the statsd agent '%s': %s", metric, address, err)
+	}
+	loggerOrDefault(s.logger).Info(fmt.Sprintf("statsd metric successfully submitted: %s", metric), "metric", metric)
+	return nil
+}
+
+func (s TelemetryProviderStatsd) buildCounterLine(metric string) string {
+	return s.buildLine(metric, "1", "c")
+}
+
+// buildLine returns the statsd line <prefix>.<metric>:<value>|<type> including the DogStatsD tags if configured
+func (s TelemetryProviderStatsd) buildLine(metric, value, metricType string) string {
+	if s.Prefix != "" {
+		metric = fmt.Sprintf("%s.%s", s.Prefix, metric)
+	}
+	if len(s.Tags) > 0 {
+		return fmt.Sprintf("%s:%s|%s|#%s", metric, value, metricType, strings.Join(s.Tags, ","))
+	}
+	return fmt.Sprintf("%s:%s|%s", metric, value, metricType)
+}
@@ -0,0 +1,302 @@
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryProviderStatsd_Validate(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		address     string
+		expectedErr error
+	}{
+		{
+			testName:    "happy path - host and port populated",
+			address:     "localhost:8125",
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - ipv6 host and port populated",
+			address:     "[::1]:8125",
+			expectedErr: nil,
+		},
+		{
+			testName:    "crappy path - address is empty",
+			address:     "",
+			expectedErr: errors.New("statsd telemetry configuration is missing a value for the 'address' property"),
+		},
+		{
+			testName:    "crappy path - address is missing the port",
+			address:     "localhost",
+			expectedErr: errors.New("statsd telemetry configuration 'address' property value 'localhost' is not valid: address localhost: missing port in address"),
+		},
+		{
+			testName:    "crappy path - address is missing the host",
+			address:     ":8125",
+			expectedErr: errors.New("statsd telemetry configuration 'address' property value ':8125' is missing the host"),
+		},
+		{
+			testName:    "crappy path - port is not a number",
+			address:     "localhost:statsd",
+			expectedErr: errors.New("statsd telemetry configuration 'address' property value 'localhost:statsd' contains an invalid port, the port must be a number between 1 and 65535"),
+		},
+		{
+			testName:    "crappy path - port is 0",
+			address:     "localhost:0",
+			expectedErr: errors.New("statsd telemetry configuration 'address' property value 'localhost:0' contains an invalid port, the port must be a number between 1 and 65535"),
+		},
+		{
+			testName:    "crappy path - port is out of range",
+			address:     "localhost:65536",
+			expectedErr: errors.New("statsd telemetry configuration 'address' property value 'localhost:65536' contains an invalid port, the port must be a number between 1 and 65535"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tps := TelemetryProviderStatsd{
+			Address: tc.address,
+		}
+		err := tps.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderStatsd_ValidateHostPortAndTags(t *testing.T) {
+	testCases := []struct {
+		testName    string
+		host        string
+		port        int
+		tags        []string
+		expectedErr error
+	}{
+		{
+			testName:    "happy path - host and port populated",
+			host:        "localhost",
+			port:        8125,
+			expectedErr: nil,
+		},
+		{
+			testName:    "happy path - tags populated",
+			host:        "localhost",
+			port:        8125,
+			tags:        []string{"environment:prod", "canary"},
+			expectedErr: nil,
+		},
+		{
+			testName:    "crappy path - host populated but port is missing",
+			host:        "localhost",
+			expectedErr: errors.New("statsd telemetry configuration 'port' property value '0' is not valid, the port must be a number between 1 and 65535"),
+		},
+		{
+			testName:    "crappy path - tag is empty",
+			host:        "localhost",
+			port:        8125,
+			tags:        []string{""},
+			expectedErr: errors.New("statsd telemetry configuration contains an invalid tag '', tags must not be empty nor contain the characters '|' or ','"),
+		},
+		{
+			testName:    "crappy path - tag contains a comma",
+			host:        "localhost",
+			port:        8125,
+			tags:        []string{"environment:prod,team:infra"},
+			expectedErr: errors.New("statsd telemetry configuration contains an invalid tag 'environment:prod,team:infra', tags must not be empty nor contain the characters '|' or ','"),
+		},
+	}
+
+	for _, tc := range testCases {
+		tps := TelemetryProviderStatsd{
+			Host: tc.host,
+			Port: tc.port,
+			Tags: tc.tags,
+		}
+		err := tps.Validate()
+		assert.Equal(t, tc.expectedErr, err, tc.testName)
+	}
+}
+
+func TestTelemetryProviderStatsd_IncOpenAPIPluginVersionTotalRunsCounter(t *testing.T) {
+	openAPIPluginVersion := "0.25.0"
+	expectedLogMetricToSubmit := "[INFO] statsd metric to be submitted: terraform.openapi_plugin_version.0_25_0.total_runs"
+	expectedLogMetricSuccess := "[INFO] statsd metric successfully submitted: terraform.openapi_plugin_version.0_25_0.total_runs"
+	expectedMetric := "myPrefixName.terraform.openapi_plugin_version.0_25_0.total_runs:1|c"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+	defer log.SetOutput(os.Stderr)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	tps := TelemetryProviderStatsd{
+		Address: telemetryHost + ":" + telemetryPort,
+		Prefix:  "myPrefixName",
+	}
+	err := tps.IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderStatsd_IncServiceProviderTotalRunsCounter(t *testing.T) {
+	providerName := "myProviderName"
+	expectedLogMetricToSubmit := "[INFO] statsd metric to be submitted: terraform.providers.myProviderName.total_runs"
+	expectedLogMetricSuccess := "[INFO] statsd metric successfully submitted: terraform.providers.myProviderName.total_runs"
+	expectedMetric := "terraform.providers.myProviderName.total_runs:1|c"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+	defer log.SetOutput(os.Stderr)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	tps := TelemetryProviderStatsd{
+		Address: telemetryHost + ":" + telemetryPort,
+	}
+	err := tps.IncServiceProviderTotalRunsCounter(providerName, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderStatsd_IncServiceProviderTotalRunsCounterWithHostPortAndTags(t *testing.T) {
+	providerName := "myProviderName"
+	expectedLogMetricToSubmit := "[INFO] statsd metric to be submitted: terraform.providers.myProviderName.total_runs"
+	expectedLogMetricSuccess := "[INFO] statsd metric successfully submitted: terraform.providers.myProviderName.total_runs"
+	expectedMetric := "terraform.providers.myProviderName.total_runs:1|c|#environment:prod,canary"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+	defer log.SetOutput(os.Stderr)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	port, _ := strconv.Atoi(telemetryPort)
+	tps := TelemetryProviderStatsd{
+		Host: telemetryHost,
+		Port: port,
+		Tags: []string{"environment:prod", "canary"},
+	}
+	err := tps.IncServiceProviderTotalRunsCounter(providerName, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderStatsd_SubmitCounterConnectionFailure(t *testing.T) {
+	tps := TelemetryProviderStatsd{
+		Address: "bad statsd host:8125",
+	}
+	err := tps.IncServiceProviderTotalRunsCounter("myProviderName", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect to the statsd agent 'bad statsd host:8125'")
+}
+
+func TestTelemetryProviderStatsd_BuildCounterLine(t *testing.T) {
+	testCases := []struct {
+		testName            string
+		prefix              string
+		tags                []string
+		metricName          string
+		expectedCounterLine string
+	}{
+		{
+			testName:            "happy path - with prefix",
+			prefix:              "myPrefixName",
+			metricName:          "myMetricName",
+			expectedCounterLine: "myPrefixName.myMetricName:1|c",
+		},
+		{
+			testName:            "happy path - without prefix",
+			metricName:          "myMetricName",
+			expectedCounterLine: "myMetricName:1|c",
+		},
+		{
+			testName:            "happy path - with DogStatsD tags",
+			prefix:              "myPrefixName",
+			tags:                []string{"environment:prod", "canary"},
+			metricName:          "myMetricName",
+			expectedCounterLine: "myPrefixName.myMetricName:1|c|#environment:prod,canary",
+		},
+	}
+
+	for _, tc := range testCases {
+		tps := TelemetryProviderStatsd{
+			Address: "localhost:8125",
+			Prefix:  tc.prefix,
+			Tags:    tc.tags,
+		}
+		assert.Equal(t, tc.expectedCounterLine, tps.buildCounterLine(tc.metricName), tc.testName)
+	}
+}
+
+func TestTelemetryProviderStatsd_SubmitResourceOperationTimingMetric(t *testing.T) {
+	expectedLogMetricToSubmit := "[INFO] statsd metric to be submitted: terraform.providers.cdn.resources.cdn_v1.update.duration"
+	expectedLogMetricSuccess := "[INFO] statsd metric successfully submitted: terraform.providers.cdn.resources.cdn_v1.update.duration"
+	expectedMetric := "myPrefixName.terraform.providers.cdn.resources.cdn_v1.update.duration:250.5|ms"
+
+	var logging bytes.Buffer
+	log.SetOutput(&logging)
+	defer log.SetOutput(os.Stderr)
+
+	metricChannel := make(chan string)
+	pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+	defer pc.Close()
+
+	tps := TelemetryProviderStatsd{
+		Address: telemetryHost + ":" + telemetryPort,
+		Prefix:  "myPrefixName",
+	}
+	err := tps.SubmitResourceOperationTimingMetric("cdn", "cdn_v1", TelemetryResourceOperationUpdate, 250500*time.Microsecond, nil)
+	assert.Nil(t, err)
+	assertExpectedMetricAndLogging(t, metricChannel, expectedMetric, expectedLogMetricToSubmit, expectedLogMetricSuccess, &logging)
+}
+
+func TestTelemetryProviderStatsd_IncResourceOperationTotalCounters(t *testing.T) {
+	testCases := []struct {
+		name           string
+		submit         func(tps TelemetryProviderStatsd) error
+		expectedMetric string
+	}{
+		{
+			name: "resource operation total counter",
+			submit: func(tps TelemetryProviderStatsd) error {
+				return tps.IncResourceOperationTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil)
+			},
+			expectedMetric: "terraform.providers.cdn.cdn_v1.read.total",
+		},
+		{
+			name: "resource operation errors total counter",
+			submit: func(tps TelemetryProviderStatsd) error {
+				return tps.IncResourceOperationErrorsTotalCounter("cdn", "cdn_v1", TelemetryResourceOperationRead, nil)
+			},
+			expectedMetric: "terraform.providers.cdn.cdn_v1.read.errors.total",
+		},
+	}
+	for _, tc := range testCases {
+		var logging bytes.Buffer
+		log.SetOutput(&logging)
+
+		metricChannel := make(chan string)
+		pc, telemetryHost, telemetryPort := udpServer(metricChannel)
+
+		tps := TelemetryProviderStatsd{
+			Address: telemetryHost + ":" + telemetryPort,
+			Prefix:  "myPrefixName",
+		}
+		err := tc.submit(tps)
+		assert.Nil(t, err, tc.name)
+		assertExpectedMetricAndLogging(t, metricChannel, "myPrefixName."+tc.expectedMetric+":1|c", "[INFO] statsd metric to be submitted: "+tc.expectedMetric, "[INFO] statsd metric successfully submitted: "+tc.expectedMetric, &logging)
+		pc.Close()
+	}
+	log.SetOutput(os.Stderr)
+}
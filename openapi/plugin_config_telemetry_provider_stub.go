@@ -1,10 +1,18 @@
 package openapi
 
+import "time"
+
 type telemetryProviderStub struct {
 	validationError              error
 	terraformVersionReceived     string
 	openAPIPluginVersionReceived string
 	providerNameReceived         string
+	resourceNameReceived         string
+	resourceOperationReceived    TelemetryResourceOperation
+	durationReceived             time.Duration
+	resourceOperationTotalRuns   int
+	resourceOperationErrors      int
+	tagsReceived                 TelemetryTags
 }
 
 func (t *telemetryProviderStub) Validate() error {
@@ -16,12 +24,41 @@ func (t *telemetryProviderStub) IncTerraformVersionTotalRunsCounter(terraformVer
 	return nil
 }
 
-func (t *telemetryProviderStub) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string) error {
+func (t *telemetryProviderStub) IncOpenAPIPluginVersionTotalRunsCounter(openAPIPluginVersion string, tags TelemetryTags) error {
+	t.tagsReceived = tags
 	t.openAPIPluginVersionReceived = openAPIPluginVersion
 	return nil
 }
 
-func (t *telemetryProviderStub) IncServiceProviderTotalRunsCounter(providerName string) error {
+func (t *telemetryProviderStub) IncServiceProviderTotalRunsCounter(providerName string, tags TelemetryTags) error {
+	t.tagsReceived = tags
+	t.providerNameReceived = providerName
+	return nil
+}
+
+func (t *telemetryProviderStub) SubmitResourceOperationTimingMetric(providerName, resourceName string, operation TelemetryResourceOperation, duration time.Duration, tags TelemetryTags) error {
+	t.tagsReceived = tags
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.resourceOperationReceived = operation
+	t.durationReceived = duration
+	return nil
+}
+
+func (t *telemetryProviderStub) IncResourceOperationTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	t.tagsReceived = tags
+	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.resourceOperationReceived = operation
+	t.resourceOperationTotalRuns++
+	return nil
+}
+
+func (t *telemetryProviderStub) IncResourceOperationErrorsTotalCounter(providerName, resourceName string, operation TelemetryResourceOperation, tags TelemetryTags) error {
+	t.tagsReceived = tags
 	t.providerNameReceived = providerName
+	t.resourceNameReceived = resourceName
+	t.resourceOperationReceived = operation
+	t.resourceOperationErrors++
 	return nil
 }
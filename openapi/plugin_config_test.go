@@ -102,6 +102,34 @@ func TestGetServiceProviderConfiguration(t *testing.T) {
 		os.Unsetenv(otfVarNameUc)
 	})
 
+	Convey("Given a PluginConfiguration for 'test' provider with a plugin configuration file containing allowed resources and the OTF_VAR_test_ALLOWED_RESOURCES and OTF_VAR_TEST_EXCLUDED_RESOURCES env variables set", t, func() {
+		pluginConfig := fmt.Sprintf(`version: '1'
+services:
+    %s:
+        swagger-url: %s
+        allowed_resources: ["lb_v1"]`, providerName, otfVarSwaggerURLValue)
+		pluginConfiguration := PluginConfiguration{
+			ProviderName:  providerName,
+			Configuration: strings.NewReader(pluginConfig),
+		}
+		allowedResourcesEnvVar := fmt.Sprintf(otfVarAllowedResources, providerName)
+		excludedResourcesEnvVar := strings.ToUpper(fmt.Sprintf(otfVarExcludedResources, providerName))
+		os.Setenv(allowedResourcesEnvVar, "cdn_*, monitor_v1")
+		os.Setenv(excludedResourcesEnvVar, "cdn_v2")
+		Convey("When getServiceConfiguration is called", func() {
+			serviceConfiguration, err := pluginConfiguration.getServiceConfiguration()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the allowed and excluded resources should be the ones set in the env variables", func() {
+				So(serviceConfiguration.GetAllowedResources(), ShouldResemble, []string{"cdn_*", "monitor_v1"})
+				So(serviceConfiguration.GetExcludedResources(), ShouldResemble, []string{"cdn_v2"})
+			})
+		})
+		os.Unsetenv(allowedResourcesEnvVar)
+		os.Unsetenv(excludedResourcesEnvVar)
+	})
+
 	Convey(fmt.Sprintf("Given a PluginConfiguration for 'test' provider and a OTF_VAR_test_SWAGGER_URL env variable is not set"), t, func() {
 		pluginConfiguration, _ := NewPluginConfiguration(providerName)
 		Convey("When getServiceConfiguration is called", func() {
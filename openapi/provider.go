@@ -1,32 +1,56 @@
 package openapi
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"net/http"
 
-	"crypto/tls"
-
 	"fmt"
-	"log"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 // ProviderOpenAPI defines the struct for the OpenAPI Terraform Provider
 type ProviderOpenAPI struct {
 	ProviderName string
-	provider     *schema.Provider
-	err          error
+	// RequestInterceptor (optional) is invoked right before every API request performed by the provider is dispatched.
+	// Refer to RequestInterceptor for more details.
+	RequestInterceptor RequestInterceptor
+	// Logger (optional) is used to log the provider messages including the CRUD, authentication and telemetry ones. If
+	// not provided the messages will be logged using the standard logger. Refer to Logger for more details.
+	Logger         Logger
+	provider       *schema.Provider
+	providerServer func() tfprotov6.ProviderServer
+	// frameworkResources are the resources served by the terraform-plugin-framework once the protocol v6 provider server
+	// is created
+	frameworkResources []*frameworkResource
+	telemetryHandler   TelemetryHandler
+	err                error
 }
 
 // CreateSchemaProvider returns a terraform.ResourceProvider.
 func (p *ProviderOpenAPI) CreateSchemaProvider() (*schema.Provider, error) {
-	serviceConfiguration, err := getServiceConfiguration(p.ProviderName)
+	serviceConfiguration, telemetryHandler, err := getServiceConfiguration(p.ProviderName, p.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("plugin init error: %s", err)
 	}
+	p.telemetryHandler = telemetryHandler
 	return p.CreateSchemaProviderFromServiceConfiguration(serviceConfiguration)
 }
 
+// Shutdown should be called right before the provider process exits so any pending telemetry metrics that are being
+// submitted asynchronously are flushed.
+func (p *ProviderOpenAPI) Shutdown() {
+	if p.telemetryHandler != nil {
+		p.telemetryHandler.Flush()
+	}
+}
+
 // CreateSchemaProviderFromServiceConfiguration helper function to enable creation of schema.Provider with the given serviceConfiguration
 func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceConfiguration ServiceConfiguration) (*schema.Provider, error) {
 	if p.err != nil {
@@ -36,9 +60,74 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 		return p.provider, nil
 	}
 
-	log.Printf("[DEBUG] service configuration = %+v", serviceConfiguration)
+	providerFactory, err := p.createProviderFactory(serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	p.provider, err = providerFactory.createProvider()
+	if err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+	}
+	return p.provider, nil
+}
+
+// CreateServeOpts returns the plugin serve options for the provider. If protocol v6 is enabled in the service
+// configuration the provider is served with the protocol v6 provider server (refer to
+// CreateProviderServerFromServiceConfiguration for more details), otherwise the SDK provider is served with protocol v5
+func (p *ProviderOpenAPI) CreateServeOpts() (*plugin.ServeOpts, error) {
+	serviceConfiguration, telemetryHandler, err := getServiceConfiguration(p.ProviderName, p.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	p.telemetryHandler = telemetryHandler
+	if serviceConfiguration.IsProtocolV6Enabled() {
+		providerServer, err := p.CreateProviderServerFromServiceConfiguration(serviceConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		return &plugin.ServeOpts{GRPCProviderV6Func: providerServer}, nil
+	}
+	provider, err := p.CreateSchemaProviderFromServiceConfiguration(serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ServeOpts{
+		ProviderFunc: func() *schema.Provider {
+			return provider
+		},
+	}, nil
+}
+
+// CreateProviderServerFromServiceConfiguration helper function to enable creation of the protocol v6 provider server
+// with the given serviceConfiguration. The resources marked with the x-terraform-resource-protocol-v6 extension are
+// served by the terraform-plugin-framework and the rest by the SDK provider
+func (p *ProviderOpenAPI) CreateProviderServerFromServiceConfiguration(serviceConfiguration ServiceConfiguration) (func() tfprotov6.ProviderServer, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.providerServer != nil {
+		return p.providerServer, nil
+	}
+	providerFactory, err := p.createProviderFactory(serviceConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	p.providerServer, p.frameworkResources, err = providerFactory.createProviderServer(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating provider server: %s", p.ProviderName, err)
+	}
+	return p.providerServer, nil
+}
+
+func (p *ProviderOpenAPI) createProviderFactory(serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
+	logger := loggerOrDefault(p.Logger)
+	logger.Debug(fmt.Sprintf("service configuration = %+v", serviceConfiguration))
 
-	openAPISpecAnalyser, err := CreateSpecAnalyser(specAnalyserV2, serviceConfiguration.GetSwaggerURL())
+	serviceTransport, err := newServiceHTTPTransport(serviceConfiguration, nil)
+	if err != nil {
+		return nil, fmt.Errorf("plugin init error: %s", err)
+	}
+	openAPISpecAnalyser, err := newSpecAnalyserFromServiceConfiguration(serviceConfiguration, serviceTransport, logger)
 	if err != nil {
 		return nil, fmt.Errorf("plugin OpenAPI spec analyser error: %s", err)
 	}
@@ -47,36 +136,112 @@ func (p *ProviderOpenAPI) CreateSchemaProviderFromServiceConfiguration(serviceCo
 	if err != nil {
 		return nil, fmt.Errorf("plugin provider factory init error: %s", err)
 	}
+	providerFactory.requestInterceptor = p.RequestInterceptor
+	providerFactory.logger = p.Logger
+	providerFactory.telemetryHandler = p.telemetryHandler
+	return providerFactory, nil
+}
 
-	p.provider, err = providerFactory.createProvider()
+// newSpecAnalyserFromServiceConfiguration returns the SpecAnalyser of the OpenAPI document configured in the service
+// configuration. The documents served over HTTP are retrieved with the swagger request settings configured, using the
+// on-disk cache if the spec cache is configured, and the service transport provided (see newServiceHTTPTransport). If a
+// SHA-256 checksum is configured, the document must match it. If additional swagger documents are configured, the returned
+// SpecAnalyser merges all the documents. The messages produced while loading and analysing the documents are logged with
+// the logger provided
+func newSpecAnalyserFromServiceConfiguration(serviceConfiguration ServiceConfiguration, serviceTransport *http.Transport, logger Logger) (SpecAnalyser, error) {
+	swaggerURL := serviceConfiguration.GetSwaggerURL()
+	if swaggerURL == "" {
+		return nil, errors.New("open api document filename argument empty, please provide the url of the OpenAPI document")
+	}
+	specAnalyser, err := newSpecAnalyserFromURL(swaggerURL, serviceConfiguration.GetSwaggerSHA256(), serviceConfiguration, serviceTransport, logger)
 	if err != nil {
-		return nil, fmt.Errorf("plugin terraform-provider-%s init error while creating schema provider: %s", p.ProviderName, err)
+		return nil, err
 	}
-	return p.provider, nil
+	additionalDocuments := serviceConfiguration.GetAdditionalSwaggerDocuments()
+	if len(additionalDocuments) == 0 {
+		return specAnalyser, nil
+	}
+	documents := []mergedSpecDocument{{url: swaggerURL, specAnalyser: specAnalyser}}
+	for _, additionalDocument := range additionalDocuments {
+		additionalSpecAnalyser, err := newSpecAnalyserFromURL(additionalDocument.URL, additionalDocument.SwaggerSHA256, serviceConfiguration, serviceTransport, logger)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, mergedSpecDocument{
+			url:                additionalDocument.URL,
+			resourceNamePrefix: additionalDocument.ResourceNamePrefix,
+			specAnalyser:       additionalSpecAnalyser,
+		})
+	}
+	mergedAnalyser := newMergedSpecAnalyser(documents)
+	mergedAnalyser.logger = logger
+	return mergedAnalyser, nil
+}
+
+func newSpecAnalyserFromURL(swaggerURL, swaggerSHA256 string, serviceConfiguration ServiceConfiguration, serviceTransport *http.Transport, logger Logger) (SpecAnalyser, error) {
+	document, err := getServiceOpenAPIDocument(swaggerURL, serviceConfiguration, serviceTransport, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve the OpenAPI document from '%s' - error = %s", swaggerURL, err)
+	}
+	if err := verifyOpenAPIDocumentChecksum(swaggerURL, document, swaggerSHA256); err != nil {
+		return nil, err
+	}
+	return newSpecAnalyserFromDocument(swaggerURL, document, logger)
+}
+
+func getServiceOpenAPIDocument(swaggerURL string, serviceConfiguration ServiceConfiguration, serviceTransport *http.Transport, logger Logger) ([]byte, error) {
+	specCacheConfiguration := serviceConfiguration.GetSpecCacheConfiguration()
+	swaggerRequestConfiguration := serviceConfiguration.GetSwaggerRequestConfiguration()
+	if (specCacheConfiguration == nil && swaggerRequestConfiguration == nil && serviceTransport == nil) || !(strings.HasPrefix(swaggerURL, "http://") || strings.HasPrefix(swaggerURL, "https://")) {
+		return loadOpenAPIDocument(swaggerURL)
+	}
+	if specCacheConfiguration == nil {
+		return fetchSwaggerDocument(swaggerURL, swaggerRequestConfiguration, serviceTransport)
+	}
+	specCache, err := newSpecDocumentCache(specCacheConfiguration, swaggerRequestConfiguration, serviceTransport, logger)
+	if err != nil {
+		return nil, err
+	}
+	return specCache.getDocument(swaggerURL)
+}
+
+// verifyOpenAPIDocumentChecksum returns an error if the expected SHA-256 checksum is provided and the document does not
+// match it
+func verifyOpenAPIDocumentChecksum(swaggerURL string, document []byte, expectedSHA256 string) error {
+	if expectedSHA256 == "" {
+		return nil
+	}
+	hash := sha256.Sum256(document)
+	if actualSHA256 := hex.EncodeToString(hash[:]); !strings.EqualFold(actualSHA256, expectedSHA256) {
+		return fmt.Errorf("the OpenAPI document retrieved from '%s' does not match the swagger_sha256 configured (expected '%s' but got '%s'), refusing to use a document that might have been tampered with. If the document has been legitimately updated, please update the swagger_sha256 with the new checksum", swaggerURL, strings.ToLower(expectedSHA256), actualSHA256)
+	}
+	return nil
 }
 
 // This function is implemented with temporary code thus it can serve as an example
 // on how the same code base can be used by binaries of this same provider named differently
 // but internally each will end up calling a different service provider's api
-func getServiceConfiguration(providerName string) (ServiceConfiguration, error) {
+func getServiceConfiguration(providerName string, logger Logger) (ServiceConfiguration, TelemetryHandler, error) {
 	var serviceConfiguration ServiceConfiguration
-	pluginConfiguration, err := NewPluginConfiguration(providerName)
+	pluginConfiguration, err := NewPluginConfigurationWithLogger(providerName, logger)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	serviceConfiguration, err = pluginConfiguration.getServiceConfiguration()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	// The insecure skip verify as well as the client certificate and CA bundle are applied by the service transport (see
+	// newServiceHTTPTransport) used to retrieve the swagger file and to perform the API requests
 	if serviceConfiguration.IsInsecureSkipVerifyEnabled() {
-		tr := http.DefaultTransport.(*http.Transport)
-		tr.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
-		log.Printf("[WARN] Provider '%s' is using insecure skip verify. Please make sure you trust the aforementioned server hosting the swagger file. Otherwise, it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable when executing this provider", providerName)
+		loggerOrDefault(logger).Warn(fmt.Sprintf("Provider '%s' is using insecure skip verify. Please make sure you trust the aforementioned server hosting the swagger file. Otherwise, it's highly recommended avoiding the use of OTF_INSECURE_SKIP_VERIFY env variable when executing this provider", providerName), "provider", providerName)
+	}
+
+	if !serviceConfiguration.GetClientTLSConfiguration().isDefault() {
+		loggerOrDefault(logger).Debug(fmt.Sprintf("Provider '%s' is using the client certificate and/or CA bundle configured in the plugin configuration", providerName), "provider", providerName)
 	}
 
-	log.Printf("[INFO] Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL())
-	return serviceConfiguration, nil
+	loggerOrDefault(logger).Info(fmt.Sprintf("Provider %s is using the following swagger file: %s", providerName, serviceConfiguration.GetSwaggerURL()), "provider", providerName, "swagger_url", serviceConfiguration.GetSwaggerURL())
+	return serviceConfiguration, pluginConfiguration.telemetryHandler, nil
 }
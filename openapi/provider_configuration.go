@@ -1,25 +1,45 @@
 package openapi
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const providerPropertyRegion = "region"
 const providerPropertyEndPoints = "endpoints"
+const providerPropertyAPIBaseURL = "api_base_url"
+const providerPropertyDefaultQueryParams = "default_query_params"
+const providerPropertySecuritySchemes = "security_schemes"
 
 // providerConfiguration contains all the configuration related to the OpenAPI provider. The configuration at the moment
 // supports:
 // - Headers: The headers map contains the header names as well as the values provided by the user in the terraform configuration
 // file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
 // - Security Definitions: The security definitions map contains the security definition names as well as the values provided by the user in the terraform configuration
-// file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc)
+// file. These headers may be sent as part of the HTTP calls if the resource requires them (as specified in the swagger doc). OAuth2 client credentials
+// security definitions obtain the access token sent from the token URL using the client id and secret provided by the user
 // - Endpoints contains the endpoints configured by the user, which effectively will override the default host set in the swagger file
+// - TagEndpoints contains the endpoints configured by the user for the resource tags, which effectively will override the
+// default host of the resources tagged with them (unless the resource endpoint is also configured)
 // - Region contains the region if user provided value for it (only supported for multi-region providers)
+// - APIBaseURL contains the base URL if user provided value for it, which will override the host and base path set in the swagger file
+// - DefaultQueryParams contains the query parameters provided by the user that will be appended to all the API request URLs
+// - SecuritySchemes contains the security schemes the user chose to activate (in order of preference) when the operations
+// support multiple security requirements
 type providerConfiguration struct {
 	Headers                   map[string]string
 	SecuritySchemaDefinitions map[string]specAPIKeyAuthenticator
 	Endpoints                 map[string]string
+	TagEndpoints              map[string]string
 	Region                    string
+	APIBaseURL                string
+	DefaultQueryParams        map[string]string
+	SecuritySchemes           []string
 }
 
 // createProviderConfig returns a providerConfiguration populated with the values provided by the user in the provider's terraform
@@ -37,6 +57,12 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 	if securitySchemaDefinitions != nil {
 		for _, secDef := range *securitySchemaDefinitions {
 			secDefTerraformCompliantName := secDef.getTerraformConfigurationName()
+			if oauth2SecDef, ok := secDef.(specOAuth2ClientCredentialsSecurityDefinition); ok {
+				clientID, _ := data.Get(oauth2SecDef.getClientIDTerraformConfigurationName()).(string)
+				clientSecret, _ := data.Get(oauth2SecDef.getClientSecretTerraformConfigurationName()).(string)
+				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = newAPIOAuth2ClientCredentialsAuthenticator(secDef, clientID, clientSecret)
+				continue
+			}
 			if value, exists := data.GetOkExists(secDefTerraformCompliantName); exists {
 				providerConfiguration.SecuritySchemaDefinitions[secDefTerraformCompliantName] = createAPIKeyAuthenticator(secDef, value.(string))
 			} else {
@@ -66,16 +92,172 @@ func newProviderConfiguration(specAnalyser SpecAnalyser, data *schema.ResourceDa
 
 	if providerConfigurationEndPoints != nil {
 		providerConfiguration.Endpoints = providerConfigurationEndPoints.configureEndpoints(data)
+		providerConfiguration.TagEndpoints = providerConfigurationEndPoints.configureTagEndpoints(data)
+	}
+
+	if apiBaseURL, exists := data.GetOkExists(providerPropertyAPIBaseURL); exists && apiBaseURL.(string) != "" {
+		if err := validateAPIBaseURL(apiBaseURL.(string)); err != nil {
+			return nil, err
+		}
+		providerConfiguration.APIBaseURL = strings.TrimSuffix(apiBaseURL.(string), "/")
+	}
+
+	if defaultQueryParams, exists := data.GetOk(providerPropertyDefaultQueryParams); exists {
+		providerConfiguration.DefaultQueryParams = map[string]string{}
+		for name, value := range defaultQueryParams.(map[string]interface{}) {
+			providerConfiguration.DefaultQueryParams[name] = value.(string)
+		}
+	}
+
+	if securitySchemes, exists := data.GetOk(providerPropertySecuritySchemes); exists {
+		for _, securityScheme := range securitySchemes.([]interface{}) {
+			name, _ := securityScheme.(string)
+			if _, defined := providerConfiguration.SecuritySchemaDefinitions[name]; !defined {
+				return nil, fmt.Errorf("security scheme '%s' configured in the property '%s' is not defined, the security schemes available are: %s", name, providerPropertySecuritySchemes, providerConfiguration.getSecuritySchemaDefinitionNames())
+			}
+			providerConfiguration.SecuritySchemes = append(providerConfiguration.SecuritySchemes, name)
+		}
 	}
 
 	return providerConfiguration, nil
 }
 
+// getSecuritySchemaDefinitionNames returns the sorted names of the security definitions
+func (p *providerConfiguration) getSecuritySchemaDefinitionNames() []string {
+	var names []string
+	for name := range p.SecuritySchemaDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selectSecuritySchemes returns the security schemes of the security requirement that should be used to authenticate
+// the API request given the security requirements provided (in order of preference):
+// - If the user configured the security schemes to activate, only the security requirements which schemes are all
+// activated are considered and they are sorted based on the order of the activated schemes
+// - The first security requirement which security schemes are all configured with valid values is selected, falling
+// back to the first security requirement otherwise so the API request fails with the corresponding validation error
+func (p *providerConfiguration) selectSecuritySchemes(securityRequirements []SpecSecuritySchemes) SpecSecuritySchemes {
+	if len(securityRequirements) == 0 {
+		return nil
+	}
+	candidates := p.getActivatedSecurityRequirements(securityRequirements)
+	if len(candidates) == 0 {
+		candidates = securityRequirements
+	}
+	for _, candidate := range candidates {
+		if p.isSecurityRequirementConfigured(candidate) {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// getActivatedSecurityRequirements returns the security requirements which security schemes have all been activated by
+// the user, sorted by the position of the most preferred security scheme they contain. If the user did not configure
+// the security schemes to activate, all the security requirements are returned
+func (p *providerConfiguration) getActivatedSecurityRequirements(securityRequirements []SpecSecuritySchemes) []SpecSecuritySchemes {
+	if len(p.SecuritySchemes) == 0 {
+		return securityRequirements
+	}
+	preference := map[string]int{}
+	for i, name := range p.SecuritySchemes {
+		preference[name] = i
+	}
+	type rankedSecurityRequirement struct {
+		securityRequirement SpecSecuritySchemes
+		rank                int
+	}
+	var ranked []rankedSecurityRequirement
+	for _, securityRequirement := range securityRequirements {
+		rank := len(p.SecuritySchemes)
+		isActivated := true
+		for i := range securityRequirement {
+			position, exists := preference[securityRequirement[i].getTerraformConfigurationName()]
+			if !exists {
+				isActivated = false
+				break
+			}
+			if position < rank {
+				rank = position
+			}
+		}
+		if isActivated {
+			ranked = append(ranked, rankedSecurityRequirement{securityRequirement, rank})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].rank < ranked[j].rank })
+	var activated []SpecSecuritySchemes
+	for _, r := range ranked {
+		activated = append(activated, r.securityRequirement)
+	}
+	return activated
+}
+
+// isSecurityRequirementConfigured returns true if all the security schemes of the security requirement provided have an
+// authenticator configured with valid values
+func (p *providerConfiguration) isSecurityRequirementConfigured(securityRequirement SpecSecuritySchemes) bool {
+	for _, securityScheme := range securityRequirement {
+		authenticator := p.getAuthenticatorFor(securityScheme)
+		if authenticator == nil || authenticator.validate() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// getSecurityDefinitionHeadersAndQueryParams returns the names of the headers and query parameters used by the security
+// definitions to send the credentials to the API
+func (p *providerConfiguration) getSecurityDefinitionHeadersAndQueryParams() ([]string, []string) {
+	var headers, queryParams []string
+	for _, name := range p.getSecuritySchemaDefinitionNames() {
+		authenticator := p.SecuritySchemaDefinitions[name]
+		apiKey, ok := authenticator.getContext().(apiKey)
+		if !ok || apiKey.name == "" {
+			continue
+		}
+		switch authenticator.getType() {
+		case authTypeAPIKeyHeader:
+			headers = append(headers, apiKey.name)
+		case authTypeAPIQuery:
+			queryParams = append(queryParams, apiKey.name)
+		}
+	}
+	return headers, queryParams
+}
+
 func (p *providerConfiguration) getAuthenticatorFor(s SpecSecurityScheme) specAPIKeyAuthenticator {
 	securitySchemeConfigName := s.getTerraformConfigurationName()
 	return p.SecuritySchemaDefinitions[securitySchemeConfigName]
 }
 
+// setOAuth2Transport configures the OAuth2 client credentials authenticators to request the access tokens using the
+// transport provided, nil means that the default transport will be used
+func (p *providerConfiguration) setOAuth2Transport(transport http.RoundTripper) {
+	for _, authenticator := range p.SecuritySchemaDefinitions {
+		if oauth2Authenticator, ok := authenticator.(*apiOAuth2ClientCredentialsAuthenticator); ok {
+			oauth2Authenticator.httpClient.Transport = transport
+		}
+	}
+}
+
+// requestOAuth2AccessTokens requests the access tokens of the OAuth2 client credentials security definitions that have
+// been configured with the client credentials, so invalid credentials are reported when the provider is configured. The
+// access tokens are cached by the authenticators and refreshed once they expire
+func (p *providerConfiguration) requestOAuth2AccessTokens() error {
+	for _, authenticator := range p.SecuritySchemaDefinitions {
+		oauth2Authenticator, ok := authenticator.(*apiOAuth2ClientCredentialsAuthenticator)
+		if !ok || oauth2Authenticator.validate() != nil {
+			continue
+		}
+		if _, err := oauth2Authenticator.getAccessToken(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *providerConfiguration) getHeaderValueFor(s SpecHeaderParam) string {
 	headerConfigName := s.GetHeaderTerraformConfigurationName()
 	return p.Headers[headerConfigName]
@@ -86,6 +268,29 @@ func (p *providerConfiguration) getRegion() string {
 	return p.Region
 }
 
+// getAPIBaseURL returns the base URL value provided by the user in the configuration for the provider
+func (p *providerConfiguration) getAPIBaseURL() string {
+	return p.APIBaseURL
+}
+
+// getDefaultQueryParams returns the query parameters provided by the user in the configuration for the provider that
+// should be appended to all the API requests
+func (p *providerConfiguration) getDefaultQueryParams() map[string]string {
+	return p.DefaultQueryParams
+}
+
+// validateAPIBaseURL checks that the api base URL provided is a well formed http(s) URL
+func validateAPIBaseURL(apiBaseURL string) error {
+	u, err := url.Parse(apiBaseURL)
+	if err != nil {
+		return fmt.Errorf("property '%s' value '%s' is not a valid URL: %s", providerPropertyAPIBaseURL, apiBaseURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("property '%s' value '%s' is not valid, the value must be a well formed http(s) URL (e,g: https://api.staging.example.com/v1)", providerPropertyAPIBaseURL, apiBaseURL)
+	}
+	return nil
+}
+
 // getEndPoint resolves the endpoint value for a given resource name
 func (p *providerConfiguration) getEndPoint(resourceName string) string {
 	if p.Endpoints != nil {
@@ -95,3 +300,14 @@ func (p *providerConfiguration) getEndPoint(resourceName string) string {
 	}
 	return ""
 }
+
+// getTagEndPoint resolves the endpoint value for the given resource tags, the first tag with an endpoint configured
+// takes preference
+func (p *providerConfiguration) getTagEndPoint(tags []string) string {
+	for _, tag := range tags {
+		if endpoint := p.TagEndpoints[tag]; endpoint != "" {
+			return endpoint
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package openapi
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const providerPropertyAWSSigV4 = "aws_sigv4"
+const awsSigV4PropertyAccessKey = "access_key"
+const awsSigV4PropertySecretKey = "secret_key"
+const awsSigV4PropertySessionToken = "session_token"
+const awsSigV4PropertyRegion = "region"
+const awsSigV4PropertyService = "service"
+
+// awsSigV4Configuration contains the credentials and the scope (region and service) used to sign the API requests with AWS
+// Signature Version 4
+type awsSigV4Configuration struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Region          string
+	Service         string
+}
+
+// awsSigV4Scope contains the region and service configured in the x-terraform-aws-sigv4 extension
+type awsSigV4Scope struct {
+	Region  string
+	Service string
+	// Required is true when the x-terraform-authenticator extension is set to 'awsv4', meaning all the API requests must
+	// be signed even if the user does not configure the aws_sigv4 property
+	Required bool
+}
+
+// awsSigV4Schema returns the schema for the provider's aws_sigv4 property. The credentials default to the standard AWS
+// environment variables and are marked as sensitive so they are never displayed
+func awsSigV4Schema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "If present, all the API requests will be signed with AWS Signature Version 4 (e,g: APIs fronted by AWS API Gateway using IAM authorization)",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				awsSigV4PropertyAccessKey: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("AWS_ACCESS_KEY_ID", ""),
+					Description: "AWS access key id used to sign the requests. Defaults to the AWS_ACCESS_KEY_ID environment variable",
+				},
+				awsSigV4PropertySecretKey: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("AWS_SECRET_ACCESS_KEY", ""),
+					Description: "AWS secret access key used to sign the requests. Defaults to the AWS_SECRET_ACCESS_KEY environment variable",
+				},
+				awsSigV4PropertySessionToken: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("AWS_SESSION_TOKEN", ""),
+					Description: "AWS session token used to sign the requests when using temporary credentials. Defaults to the AWS_SESSION_TOKEN environment variable",
+				},
+				awsSigV4PropertyRegion: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "AWS region used to sign the requests. Defaults to the region configured in the x-terraform-aws-sigv4 extension",
+				},
+				awsSigV4PropertyService: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "AWS service name (e,g: execute-api) used to sign the requests. Defaults to the service configured in the x-terraform-aws-sigv4 extension",
+				},
+			},
+		},
+	}
+}
+
+// newAWSSigV4Configuration returns the AWS SigV4 configuration provided by the user, nil if the aws_sigv4 property is not
+// configured. The region and service not provided by the user are populated from the x-terraform-aws-sigv4 extension
+// scope (if any). If the scope is required and the aws_sigv4 property is not configured, the credentials are obtained
+// from the standard AWS environment variables
+func newAWSSigV4Configuration(data *schema.ResourceData, scope *awsSigV4Scope) (*awsSigV4Configuration, error) {
+	var properties map[string]interface{}
+	if v, exists := data.GetOk(providerPropertyAWSSigV4); exists && len(v.([]interface{})) > 0 {
+		properties, _ = v.([]interface{})[0].(map[string]interface{})
+	} else if scope != nil && scope.Required {
+		properties = map[string]interface{}{
+			awsSigV4PropertyAccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			awsSigV4PropertySecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			awsSigV4PropertySessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	} else {
+		return nil, nil
+	}
+	getString := func(name string) string {
+		value, _ := properties[name].(string)
+		return value
+	}
+	configuration := &awsSigV4Configuration{
+		AccessKeyID:     getString(awsSigV4PropertyAccessKey),
+		SecretAccessKey: getString(awsSigV4PropertySecretKey),
+		SessionToken:    getString(awsSigV4PropertySessionToken),
+		Region:          getString(awsSigV4PropertyRegion),
+		Service:         getString(awsSigV4PropertyService),
+	}
+	if scope != nil {
+		if configuration.Region == "" {
+			configuration.Region = scope.Region
+		}
+		if configuration.Service == "" {
+			configuration.Service = scope.Service
+		}
+	}
+	if err := configuration.validate(); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+// validate checks that the configuration contains all the values needed to sign the requests. Note the values of the
+// credentials are never included in the errors
+func (c awsSigV4Configuration) validate() error {
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return fmt.Errorf("property '%s' is missing the credentials, please provide the '%s' and '%s' values (or set the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables)", providerPropertyAWSSigV4, awsSigV4PropertyAccessKey, awsSigV4PropertySecretKey)
+	}
+	if c.Region == "" {
+		return fmt.Errorf("property '%s' is missing the '%s' value and the OpenAPI document does not define it in the '%s' extension", providerPropertyAWSSigV4, awsSigV4PropertyRegion, extTfAWSSigV4)
+	}
+	if c.Service == "" {
+		return fmt.Errorf("property '%s' is missing the '%s' value and the OpenAPI document does not define it in the '%s' extension", providerPropertyAWSSigV4, awsSigV4PropertyService, extTfAWSSigV4)
+	}
+	return nil
+}
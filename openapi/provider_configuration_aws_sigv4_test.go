@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAWSSigV4Configuration(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		rawConfig             map[string]interface{}
+		scope                 *awsSigV4Scope
+		expectedConfiguration *awsSigV4Configuration
+		expectedError         string
+	}{
+		{
+			name:                  "aws sigv4 property not configured",
+			rawConfig:             map[string]interface{}{},
+			scope:                 &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"},
+			expectedConfiguration: nil,
+		},
+		{
+			name:          "aws sigv4 property not configured, the scope is required and the environment does not contain the credentials",
+			rawConfig:     map[string]interface{}{},
+			scope:         &awsSigV4Scope{Region: "us-west-2", Service: "execute-api", Required: true},
+			expectedError: "property 'aws_sigv4' is missing the credentials, please provide the 'access_key' and 'secret_key' values (or set the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables)",
+		},
+		{
+			name: "aws sigv4 property configured with all the values",
+			rawConfig: map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{
+					awsSigV4PropertyAccessKey:    "AKID",
+					awsSigV4PropertySecretKey:    "SECRET",
+					awsSigV4PropertySessionToken: "TOKEN",
+					awsSigV4PropertyRegion:       "eu-west-1",
+					awsSigV4PropertyService:      "lambda",
+				}},
+			},
+			scope:                 &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"},
+			expectedConfiguration: &awsSigV4Configuration{AccessKeyID: "AKID", SecretAccessKey: "SECRET", SessionToken: "TOKEN", Region: "eu-west-1", Service: "lambda"},
+		},
+		{
+			name: "aws sigv4 property configured with the credentials only and the scope defined in the extension",
+			rawConfig: map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{
+					awsSigV4PropertyAccessKey: "AKID",
+					awsSigV4PropertySecretKey: "SECRET",
+				}},
+			},
+			scope:                 &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"},
+			expectedConfiguration: &awsSigV4Configuration{AccessKeyID: "AKID", SecretAccessKey: "SECRET", Region: "us-west-2", Service: "execute-api"},
+		},
+		{
+			name: "aws sigv4 property missing the secret key",
+			rawConfig: map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{
+					awsSigV4PropertyAccessKey: "AKID",
+				}},
+			},
+			scope:         &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"},
+			expectedError: "property 'aws_sigv4' is missing the credentials, please provide the 'access_key' and 'secret_key' values (or set the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables)",
+		},
+		{
+			name: "aws sigv4 property missing the region and no extension defined",
+			rawConfig: map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{
+					awsSigV4PropertyAccessKey: "AKID",
+					awsSigV4PropertySecretKey: "SECRET",
+					awsSigV4PropertyService:   "execute-api",
+				}},
+			},
+			scope:         nil,
+			expectedError: "property 'aws_sigv4' is missing the 'region' value and the OpenAPI document does not define it in the 'x-terraform-aws-sigv4' extension",
+		},
+		{
+			name: "aws sigv4 property missing the service and the extension only defines the region",
+			rawConfig: map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{
+					awsSigV4PropertyAccessKey: "AKID",
+					awsSigV4PropertySecretKey: "SECRET",
+				}},
+			},
+			scope:         &awsSigV4Scope{Region: "us-west-2"},
+			expectedError: "property 'aws_sigv4' is missing the 'service' value and the OpenAPI document does not define it in the 'x-terraform-aws-sigv4' extension",
+		},
+	}
+	for _, tc := range testCases {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyAWSSigV4: awsSigV4Schema()}, tc.rawConfig)
+		configuration, err := newAWSSigV4Configuration(data, tc.scope)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedConfiguration, configuration, tc.name)
+	}
+}
+
+func TestNewAWSSigV4ConfigurationEnvironmentCredentials(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "ENV_AKID")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "ENV_SECRET")
+	os.Setenv("AWS_SESSION_TOKEN", "ENV_TOKEN")
+	defer func() {
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+		os.Unsetenv("AWS_SESSION_TOKEN")
+	}()
+	data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyAWSSigV4: awsSigV4Schema()}, map[string]interface{}{
+		providerPropertyAWSSigV4: []interface{}{map[string]interface{}{awsSigV4PropertyRegion: "us-west-2", awsSigV4PropertyService: "execute-api"}},
+	})
+	configuration, err := newAWSSigV4Configuration(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &awsSigV4Configuration{AccessKeyID: "ENV_AKID", SecretAccessKey: "ENV_SECRET", SessionToken: "ENV_TOKEN", Region: "us-west-2", Service: "execute-api"}, configuration)
+}
+
+func TestNewAWSSigV4ConfigurationRequiredScope(t *testing.T) {
+	os.Setenv("AWS_ACCESS_KEY_ID", "ENV_AKID")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "ENV_SECRET")
+	defer func() {
+		os.Unsetenv("AWS_ACCESS_KEY_ID")
+		os.Unsetenv("AWS_SECRET_ACCESS_KEY")
+	}()
+	data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyAWSSigV4: awsSigV4Schema()}, map[string]interface{}{})
+	configuration, err := newAWSSigV4Configuration(data, &awsSigV4Scope{Region: "us-west-2", Service: "execute-api", Required: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &awsSigV4Configuration{AccessKeyID: "ENV_AKID", SecretAccessKey: "ENV_SECRET", Region: "us-west-2", Service: "execute-api"}, configuration)
+}
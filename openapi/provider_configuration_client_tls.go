@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const providerPropertyTLS = "tls"
+const tlsPropertyClientCertificate = "client_certificate"
+const tlsPropertyClientKey = "client_key"
+const tlsPropertyCABundle = "ca_bundle"
+
+// clientTLSConfiguration contains the PEM encoded client certificate and key used to authenticate against the APIs
+// requiring mutual TLS, as well as the PEM encoded CA bundle used to verify the API server certificates
+type clientTLSConfiguration struct {
+	ClientCertificate []byte
+	ClientKey         []byte
+	CABundle          []byte
+	// source describes where the configuration comes from (e,g: the provider property) and it is used to give context
+	// to the validation errors
+	source string
+}
+
+// tlsSchema returns the schema for the provider's tls property. The client key is marked as sensitive so it is never
+// displayed
+func tlsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "If present, the API requests will be performed using the client certificate (mutual TLS) and/or the CA bundle provided",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				tlsPropertyClientCertificate: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded client certificate presented to the APIs requiring mutual TLS (e,g: file(\"client.crt\")). Requires the client_key",
+				},
+				tlsPropertyClientKey: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "PEM encoded private key of the client certificate (e,g: file(\"client.key\")). Requires the client_certificate",
+				},
+				tlsPropertyCABundle: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded CA certificates trusted (in addition to the system CAs) to verify the API server certificates",
+				},
+			},
+		},
+	}
+}
+
+// newClientTLSConfiguration returns the TLS configuration provided by the user in the tls property, nil if the property is
+// not configured
+func newClientTLSConfiguration(data *schema.ResourceData) (*clientTLSConfiguration, error) {
+	v, exists := data.GetOk(providerPropertyTLS)
+	if !exists {
+		return nil, nil
+	}
+	values := v.([]interface{})
+	if len(values) == 0 {
+		return nil, nil
+	}
+	properties, _ := values[0].(map[string]interface{})
+	getBytes := func(name string) []byte {
+		value, _ := properties[name].(string)
+		if value == "" {
+			return nil
+		}
+		return []byte(value)
+	}
+	configuration := &clientTLSConfiguration{
+		ClientCertificate: getBytes(tlsPropertyClientCertificate),
+		ClientKey:         getBytes(tlsPropertyClientKey),
+		CABundle:          getBytes(tlsPropertyCABundle),
+		source:            fmt.Sprintf("property '%s'", providerPropertyTLS),
+	}
+	if err := configuration.validate(); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+// validate checks that the client certificate and key are provided together and that the certificates are well formed.
+// Note the value of the client key is never included in the errors
+func (c clientTLSConfiguration) validate() error {
+	_, err := c.apply(&tls.Config{})
+	return err
+}
+
+// apply returns a copy of the tls config provided with the client certificate and the CA bundle configured. If a CA
+// bundle is provided, the certificates are trusted in addition to the system CAs (replacing any root CAs already present
+// in the tls config provided)
+func (c clientTLSConfiguration) apply(tlsConfig *tls.Config) (*tls.Config, error) {
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	tlsConfig = tlsConfig.Clone()
+	if len(c.ClientCertificate) > 0 || len(c.ClientKey) > 0 {
+		if len(c.ClientCertificate) == 0 || len(c.ClientKey) == 0 {
+			return nil, fmt.Errorf("%s must contain both the client certificate and the client key to enable mutual TLS", c.source)
+		}
+		certificate, err := tls.X509KeyPair(c.ClientCertificate, c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s client certificate and key are not valid: %s", c.source, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if len(c.CABundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(c.CABundle) {
+			return nil, fmt.Errorf("%s CA bundle does not contain any valid PEM encoded certificate", c.source)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return tlsConfig, nil
+}
+
+// merge returns the configuration resulting of overriding the receiver client certificate and key with the ones in the
+// configuration provided (if any). The CA bundles of both configurations are trusted
+func (c *clientTLSConfiguration) merge(other *clientTLSConfiguration) *clientTLSConfiguration {
+	if c == nil {
+		return other
+	}
+	if other == nil {
+		return c
+	}
+	merged := *other
+	if len(merged.ClientCertificate) == 0 && len(merged.ClientKey) == 0 {
+		merged.ClientCertificate = c.ClientCertificate
+		merged.ClientKey = c.ClientKey
+	}
+	merged.CABundle = append(append([]byte{}, c.CABundle...), append([]byte("\n"), other.CABundle...)...)
+	return &merged
+}
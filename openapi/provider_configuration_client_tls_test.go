@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificatePEM returns a PEM encoded self-signed client certificate and its PEM encoded private key
+func newTestCertificatePEM(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-openapi"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestNewClientTLSConfiguration(t *testing.T) {
+	certificate, key := newTestCertificatePEM(t)
+	testCases := []struct {
+		name                  string
+		rawConfig             map[string]interface{}
+		expectedConfiguration *clientTLSConfiguration
+		expectedError         string
+	}{
+		{
+			name:                  "tls property not configured",
+			rawConfig:             map[string]interface{}{},
+			expectedConfiguration: nil,
+		},
+		{
+			name: "tls property configured with the client certificate, key and CA bundle",
+			rawConfig: map[string]interface{}{
+				providerPropertyTLS: []interface{}{map[string]interface{}{
+					tlsPropertyClientCertificate: string(certificate),
+					tlsPropertyClientKey:         string(key),
+					tlsPropertyCABundle:          string(certificate),
+				}},
+			},
+			expectedConfiguration: &clientTLSConfiguration{ClientCertificate: certificate, ClientKey: key, CABundle: certificate, source: "property 'tls'"},
+		},
+		{
+			name: "tls property configured with the CA bundle only",
+			rawConfig: map[string]interface{}{
+				providerPropertyTLS: []interface{}{map[string]interface{}{
+					tlsPropertyCABundle: string(certificate),
+				}},
+			},
+			expectedConfiguration: &clientTLSConfiguration{CABundle: certificate, source: "property 'tls'"},
+		},
+		{
+			name: "tls property configured with the client certificate but missing the key",
+			rawConfig: map[string]interface{}{
+				providerPropertyTLS: []interface{}{map[string]interface{}{
+					tlsPropertyClientCertificate: string(certificate),
+				}},
+			},
+			expectedError: "property 'tls' must contain both the client certificate and the client key to enable mutual TLS",
+		},
+		{
+			name: "tls property configured with a client key not matching the certificate",
+			rawConfig: map[string]interface{}{
+				providerPropertyTLS: []interface{}{map[string]interface{}{
+					tlsPropertyClientCertificate: string(certificate),
+					tlsPropertyClientKey:         string(certificate),
+				}},
+			},
+			expectedError: "property 'tls' client certificate and key are not valid: tls: found a certificate rather than a key in the PEM for the private key",
+		},
+		{
+			name: "tls property configured with a CA bundle not containing certificates",
+			rawConfig: map[string]interface{}{
+				providerPropertyTLS: []interface{}{map[string]interface{}{
+					tlsPropertyCABundle: "not a certificate",
+				}},
+			},
+			expectedError: "property 'tls' CA bundle does not contain any valid PEM encoded certificate",
+		},
+	}
+	for _, tc := range testCases {
+		data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyTLS: tlsSchema()}, tc.rawConfig)
+		configuration, err := newClientTLSConfiguration(data)
+		if tc.expectedError != "" {
+			assert.EqualError(t, err, tc.expectedError, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.expectedConfiguration, configuration, tc.name)
+	}
+}
+
+func TestTLSSchema(t *testing.T) {
+	s := tlsSchema()
+	assert.Equal(t, schema.TypeList, s.Type)
+	assert.True(t, s.Optional)
+	assert.Equal(t, 1, s.MaxItems)
+	properties := s.Elem.(*schema.Resource).Schema
+	assert.False(t, properties[tlsPropertyClientCertificate].Sensitive)
+	assert.True(t, properties[tlsPropertyClientKey].Sensitive)
+	assert.False(t, properties[tlsPropertyCABundle].Sensitive)
+}
+
+func TestClientTLSConfigurationApply(t *testing.T) {
+	certificate, key := newTestCertificatePEM(t)
+	configuration := clientTLSConfiguration{ClientCertificate: certificate, ClientKey: key, CABundle: certificate}
+	original := &tls.Config{InsecureSkipVerify: true}
+	tlsConfig, err := configuration.apply(original)
+	assert.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.True(t, tlsConfig.InsecureSkipVerify, "the settings of the tls config provided should be kept")
+	assert.Empty(t, original.Certificates, "the tls config provided should not be modified")
+	assert.Nil(t, original.RootCAs, "the tls config provided should not be modified")
+}
+
+func TestClientTLSConfigurationMerge(t *testing.T) {
+	var nilConfiguration *clientTLSConfiguration
+	providerConfiguration := &clientTLSConfiguration{ClientCertificate: []byte("providerCert"), ClientKey: []byte("providerKey"), CABundle: []byte("providerCA")}
+	pluginConfiguration := &clientTLSConfiguration{ClientCertificate: []byte("pluginCert"), ClientKey: []byte("pluginKey"), CABundle: []byte("pluginCA")}
+
+	assert.Equal(t, providerConfiguration, nilConfiguration.merge(providerConfiguration))
+	assert.Equal(t, pluginConfiguration, pluginConfiguration.merge(nil))
+
+	merged := pluginConfiguration.merge(providerConfiguration)
+	assert.Equal(t, []byte("providerCert"), merged.ClientCertificate)
+	assert.Equal(t, []byte("providerKey"), merged.ClientKey)
+	assert.Equal(t, []byte("pluginCA\nproviderCA"), merged.CABundle)
+
+	merged = pluginConfiguration.merge(&clientTLSConfiguration{CABundle: []byte("providerCA")})
+	assert.Equal(t, []byte("pluginCert"), merged.ClientCertificate, "the plugin client certificate should be used if the provider does not configure one")
+	assert.Equal(t, []byte("pluginKey"), merged.ClientKey)
+}
+
+func TestProviderFactoryGetHTTPClientWithClientTLS(t *testing.T) {
+	clientCertificate, clientKey := newTestCertificatePEM(t)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM(clientCertificate))
+
+	api := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	api.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	api.StartTLS()
+	defer api.Close()
+	serverCertificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: api.Certificate().Raw})
+
+	p := providerFactory{serviceConfiguration: &ServiceConfigStub{}}
+
+	t.Run("the API request succeeds when the client certificate and the CA bundle are configured", func(t *testing.T) {
+		transport, err := p.getHTTPTransport(&clientTLSConfiguration{ClientCertificate: clientCertificate, ClientKey: clientKey, CABundle: serverCertificate})
+		require.NoError(t, err)
+		httpClient := p.getHTTPClient(nil, transport)
+		resp, err := httpClient.Get(api.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("the API request fails when the client certificate is not configured", func(t *testing.T) {
+		transport, err := p.getHTTPTransport(&clientTLSConfiguration{CABundle: serverCertificate})
+		require.NoError(t, err)
+		httpClient := p.getHTTPClient(nil, transport)
+		_, err = httpClient.Get(api.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("the API request fails when the server certificate is not trusted", func(t *testing.T) {
+		transport, err := p.getHTTPTransport(&clientTLSConfiguration{ClientCertificate: clientCertificate, ClientKey: clientKey})
+		require.NoError(t, err)
+		httpClient := p.getHTTPClient(nil, transport)
+		_, err = httpClient.Get(api.URL)
+		assert.Error(t, err)
+	})
+}
@@ -3,13 +3,22 @@ package openapi
 import (
 	"bytes"
 	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapiutils"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// providerPropertyEndPointsTags is the name of the endpoints property where the user can override the host of all the
+// resources tagged with a given tag
+const providerPropertyEndPointsTags = "tags"
+
 type providerConfigurationEndPoints struct {
 	resourceNames []string
+	// tagNames contains the tags of the resources exposed by the provider, which enable overriding the endpoint of
+	// groups of resources
+	tagNames []string
 }
 
 // endpointsSchema returns a schema for the provider's endpoint property
@@ -25,6 +34,15 @@ func (p *providerConfigurationEndPoints) endpointsSchema() *schema.Schema {
 				Description:  "Use this to override the resource endpoint URL (the default one or the one constructed from the `region`).\n",
 			}
 		}
+		if len(p.tagNames) > 0 && !p.isResourceName(providerPropertyEndPointsTags) {
+			endpoints[providerPropertyEndPointsTags] = &schema.Schema{
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: p.endpointsTagsValidateFunc(),
+				Description:  fmt.Sprintf("Use this to override the endpoint URL of all the resources tagged with the given tags (e,g: billing = \"billing.staging.api.com\"), the resource endpoints take preference. Supported tags: %s\n", strings.Join(p.tagNames, ", ")),
+			}
+		}
 		return &schema.Schema{
 			Type:     schema.TypeSet,
 			Optional: true,
@@ -47,6 +65,39 @@ func (p *providerConfigurationEndPoints) endpointsValidateFunc() schema.SchemaVa
 	}
 }
 
+// endpointsTagsValidateFunc validates the tags are supported and the endpoints are valid hosts
+func (p *providerConfigurationEndPoints) endpointsTagsValidateFunc() schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (warns []string, errs []error) {
+		for tag, endpoint := range value.(map[string]interface{}) {
+			if !p.isTagName(tag) {
+				errs = append(errs, fmt.Errorf("property '%s' tag '%s' is not valid, please make sure the tag is one of [%s]", key, tag, strings.Join(p.tagNames, ", ")))
+				continue
+			}
+			_, endpointErrs := p.endpointsValidateFunc()(endpoint, fmt.Sprintf("%s.%s", key, tag))
+			errs = append(errs, endpointErrs...)
+		}
+		return nil, errs
+	}
+}
+
+func (p *providerConfigurationEndPoints) isResourceName(name string) bool {
+	for _, resourceName := range p.resourceNames {
+		if resourceName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *providerConfigurationEndPoints) isTagName(name string) bool {
+	for _, tagName := range p.tagNames {
+		if tagName == name {
+			return true
+		}
+	}
+	return false
+}
+
 // endpointsToHash calculates the unique ID used to store the endpoints element in a hash.
 func (p *providerConfigurationEndPoints) endpointsToHash(resources []string) schema.SchemaSetFunc {
 	return func(v interface{}) int {
@@ -55,7 +106,17 @@ func (p *providerConfigurationEndPoints) endpointsToHash(resources []string) sch
 		for _, name := range resources {
 			buf.WriteString(fmt.Sprintf("%s-", m[name].(string)))
 		}
-		return hashcode.String(buf.String())
+		if tagEndpoints, ok := m[providerPropertyEndPointsTags].(map[string]interface{}); ok && !p.isResourceName(providerPropertyEndPointsTags) {
+			var tags []string
+			for tag := range tagEndpoints {
+				tags = append(tags, tag)
+			}
+			sort.Strings(tags)
+			for _, tag := range tags {
+				buf.WriteString(fmt.Sprintf("%s=%s-", tag, tagEndpoints[tag]))
+			}
+		}
+		return schema.HashString(buf.String())
 	}
 }
 
@@ -77,3 +138,24 @@ func (p *providerConfigurationEndPoints) configureEndpoints(data *schema.Resourc
 	}
 	return nil
 }
+
+// configureTagEndpoints creates a map containing the endpoints provided by the user for the resource tags (if present)
+func (p *providerConfigurationEndPoints) configureTagEndpoints(data *schema.ResourceData) map[string]string {
+	if len(p.tagNames) == 0 || p.isResourceName(providerPropertyEndPointsTags) || data.Get(providerPropertyEndPoints) == nil {
+		return nil
+	}
+	endpointsSet, ok := data.Get(providerPropertyEndPoints).(*schema.Set)
+	if !ok {
+		return nil
+	}
+	tagEndpoints := map[string]string{}
+	for _, endpointsSetI := range endpointsSet.List() {
+		endpoints := endpointsSetI.(map[string]interface{})
+		if tags, ok := endpoints[providerPropertyEndPointsTags].(map[string]interface{}); ok {
+			for tag, endpoint := range tags {
+				tagEndpoints[tag] = endpoint.(string)
+			}
+		}
+	}
+	return tagEndpoints
+}
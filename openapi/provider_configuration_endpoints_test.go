@@ -5,8 +5,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/hashcode"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -69,7 +68,7 @@ func TestEndpointsToHash(t *testing.T) {
 				m[resourceName] = "something to get the string representation from"
 				var buf bytes.Buffer
 				buf.WriteString(fmt.Sprintf("%s-", m[resourceName].(string)))
-				So(schemaSetFunction(m), ShouldEqual, hashcode.String(buf.String()))
+				So(schemaSetFunction(m), ShouldEqual, schema.HashString(buf.String()))
 			})
 		})
 	})
@@ -120,6 +119,70 @@ func TestEndpointsValidateFunc(t *testing.T) {
 	})
 }
 
+func TestEndpointsSchemaWithTags(t *testing.T) {
+	Convey("Given a provider configuration endpoints configured with resources and tags", t, func() {
+		p := providerConfigurationEndPoints{
+			resourceNames: []string{"cdn_v1"},
+			tagNames:      []string{"billing", "network"},
+		}
+		Convey("When endpointsSchema is called", func() {
+			s := p.endpointsSchema()
+			Convey("Then the schema element resource schema should contain the tags map", func() {
+				So(s.Elem.(*schema.Resource).Schema, ShouldContainKey, "cdn_v1")
+				So(s.Elem.(*schema.Resource).Schema, ShouldContainKey, providerPropertyEndPointsTags)
+				So(s.Elem.(*schema.Resource).Schema[providerPropertyEndPointsTags].Type, ShouldEqual, schema.TypeMap)
+			})
+		})
+		Convey("When the tags validate function is invoked with supported tags and valid hosts", func() {
+			_, errs := p.endpointsTagsValidateFunc()(map[string]interface{}{"billing": "billing.staging.com"}, "tags")
+			Convey("Then the errs should be empty", func() {
+				So(errs, ShouldBeEmpty)
+			})
+		})
+		Convey("When the tags validate function is invoked with a tag that is not supported", func() {
+			_, errs := p.endpointsTagsValidateFunc()(map[string]interface{}{"compute": "compute.staging.com"}, "tags")
+			Convey("Then the error message should be the expected one", func() {
+				So(errs, ShouldHaveLength, 1)
+				So(errs[0].Error(), ShouldEqual, "property 'tags' tag 'compute' is not valid, please make sure the tag is one of [billing, network]")
+			})
+		})
+		Convey("When the tags validate function is invoked with an endpoint that is not a valid host", func() {
+			_, errs := p.endpointsTagsValidateFunc()(map[string]interface{}{"billing": "http://billing.staging.com"}, "tags")
+			Convey("Then the error message should refer to the tag", func() {
+				So(errs, ShouldHaveLength, 1)
+				So(errs[0].Error(), ShouldStartWith, "property 'tags.billing' value 'http://billing.staging.com' is not valid")
+			})
+		})
+		Convey("When configureTagEndpoints is called with resource data containing tag endpoints", func() {
+			resourceSchema := map[string]*schema.Schema{providerPropertyEndPoints: p.endpointsSchema()}
+			data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+				providerPropertyEndPoints: []interface{}{
+					map[string]interface{}{
+						"cdn_v1":                      "cdn.staging.com",
+						providerPropertyEndPointsTags: map[string]interface{}{"billing": "billing.staging.com"},
+					},
+				},
+			})
+			Convey("Then the resource and tag endpoints should be configured", func() {
+				So(p.configureEndpoints(data), ShouldResemble, map[string]string{"cdn_v1": "cdn.staging.com"})
+				So(p.configureTagEndpoints(data), ShouldResemble, map[string]string{"billing": "billing.staging.com"})
+			})
+		})
+	})
+	Convey("Given a provider configuration endpoints configured with a resource named tags", t, func() {
+		p := providerConfigurationEndPoints{
+			resourceNames: []string{providerPropertyEndPointsTags},
+			tagNames:      []string{"billing"},
+		}
+		Convey("When endpointsSchema is called", func() {
+			s := p.endpointsSchema()
+			Convey("Then the resource endpoint should take preference over the tags map", func() {
+				So(s.Elem.(*schema.Resource).Schema[providerPropertyEndPointsTags].Type, ShouldEqual, schema.TypeString)
+			})
+		})
+	})
+}
+
 //func TestGetProviderConfigEndPointsFromData(t *testing.T) {
 //	Convey("Given a provider factory", t, func() {
 //		expectedResource := "resource_name"
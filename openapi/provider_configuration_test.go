@@ -1,7 +1,10 @@
 package openapi
 
 import (
+	"fmt"
 	. "github.com/smartystreets/goconvey/convey"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -48,6 +51,285 @@ func TestNewProviderConfiguration(t *testing.T) {
 	})
 }
 
+func TestNewProviderConfigurationWithOAuth2ClientCredentials(t *testing.T) {
+	Convey("Given a specAnalyser containing an oauth2 client credentials security definition and a schema ResourceData containing the client credentials", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{
+				securityDefinitions: &SpecSecurityDefinitions{
+					newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token", []string{"read"}),
+				},
+				globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+			},
+		}
+		clientIDProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_id", "", false, false, "someClientID")
+		clientSecretProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_secret", "", false, false, "someClientSecret")
+		data := newTestSchema(clientIDProperty, clientSecretProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, &providerConfigurationEndPoints{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the providerConfiguration securitySchemaDefinitions should contain the oauth2 authenticator configured with the client credentials", func() {
+				So(providerConfiguration.SecuritySchemaDefinitions, ShouldContainKey, "oauth2_auth")
+				authenticator := providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"].(*apiOAuth2ClientCredentialsAuthenticator)
+				So(authenticator.clientID, ShouldEqual, "someClientID")
+				So(authenticator.clientSecret, ShouldEqual, "someClientSecret")
+				So(authenticator.tokenURL, ShouldEqual, "https://api.iam.com/oauth2/token")
+				So(authenticator.scopes, ShouldResemble, []string{"read"})
+			})
+		})
+	})
+}
+
+func TestRequestOAuth2AccessTokens(t *testing.T) {
+	Convey("Given a providerConfiguration containing oauth2 authenticators with and without client credentials", t, func() {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken"}`)
+		defer server.Close()
+		secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", server.URL, []string{"read", "write"})
+		providerConfiguration := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"oauth2_auth":           newAPIOAuth2ClientCredentialsAuthenticator(secDef, "someClientID", "someClientSecret"),
+				"oauth2_not_configured": newAPIOAuth2ClientCredentialsAuthenticator(secDef, "", ""),
+				"apikey_header_auth":    newAPIKeyHeaderAuthenticator("Authorization", "someToken", "apikey_header_auth"),
+			},
+		}
+		Convey("When requestOAuth2AccessTokens method is called", func() {
+			err := providerConfiguration.requestOAuth2AccessTokens()
+			Convey("Then the error returned should be nil and only the access token of the configured authenticator should be requested", func() {
+				So(err, ShouldBeNil)
+				So(requests, ShouldEqual, 1)
+				So(providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"].(*apiOAuth2ClientCredentialsAuthenticator).accessToken, ShouldEqual, "someAccessToken")
+			})
+		})
+	})
+	Convey("Given a providerConfiguration containing an oauth2 authenticator configured with the wrong client credentials", t, func() {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken"}`)
+		defer server.Close()
+		secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", server.URL, []string{"read", "write"})
+		providerConfiguration := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"oauth2_auth": newAPIOAuth2ClientCredentialsAuthenticator(secDef, "someClientID", "wrongClientSecret"),
+			},
+		}
+		Convey("When requestOAuth2AccessTokens method is called", func() {
+			err := providerConfiguration.requestOAuth2AccessTokens()
+			Convey("Then the error returned should describe the token request failure", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, fmt.Sprintf("oauth2 token POST response '%s' status code '401'", server.URL))
+			})
+		})
+	})
+}
+
+func TestSetOAuth2Transport(t *testing.T) {
+	Convey("Given a providerConfiguration containing an oauth2 authenticator which token URL is served over TLS", t, func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(contentType, "application/json")
+			fmt.Fprint(w, `{"access_token":"someAccessToken"}`)
+		}))
+		defer server.Close()
+		secDef := newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", server.URL, nil)
+		providerConfiguration := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"oauth2_auth":        newAPIOAuth2ClientCredentialsAuthenticator(secDef, "someClientID", "someClientSecret"),
+				"apikey_header_auth": newAPIKeyHeaderAuthenticator("Authorization", "someToken", "apikey_header_auth"),
+			},
+		}
+		Convey("When setOAuth2Transport method is called with a transport trusting the token URL server certificate", func() {
+			providerConfiguration.setOAuth2Transport(server.Client().Transport)
+			err := providerConfiguration.requestOAuth2AccessTokens()
+			Convey("Then the access token should have been requested using the transport provided", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.SecuritySchemaDefinitions["oauth2_auth"].(*apiOAuth2ClientCredentialsAuthenticator).accessToken, ShouldEqual, "someAccessToken")
+			})
+		})
+		Convey("When requestOAuth2AccessTokens method is called without configuring the transport", func() {
+			err := providerConfiguration.requestOAuth2AccessTokens()
+			Convey("Then the error returned should be the TLS verification failure", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationWithAPIBaseURL(t *testing.T) {
+	Convey("Given a spec analyser and a schema ResourceData containing a value for the api_base_url property", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{},
+		}
+		apiBaseURLProperty := newStringSchemaDefinitionPropertyWithDefaults(providerPropertyAPIBaseURL, "", false, false, "http://localhost:8080/api/")
+		data := newTestSchema(apiBaseURLProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the providerConfiguration should contain the api base url without the trailing slash", func() {
+				So(providerConfiguration.getAPIBaseURL(), ShouldEqual, "http://localhost:8080/api")
+			})
+		})
+	})
+	Convey("Given a spec analyser and a schema ResourceData containing a non valid value for the api_base_url property", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{},
+		}
+		apiBaseURLProperty := newStringSchemaDefinitionPropertyWithDefaults(providerPropertyAPIBaseURL, "", false, false, "localhost:8080")
+		data := newTestSchema(apiBaseURLProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			_, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "property 'api_base_url' value 'localhost:8080' is not valid, the value must be a well formed http(s) URL (e,g: https://api.staging.example.com/v1)")
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationWithDefaultQueryParams(t *testing.T) {
+	Convey("Given a spec analyser and a schema ResourceData containing a value for the default_query_params property", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{},
+		}
+		defaultQueryParamsProperty := newMapSchemaDefinitionPropertyWithDefaults(providerPropertyDefaultQueryParams, "", false, false, map[string]interface{}{"apiVersion": "2023-01-01"}, typeString)
+		data := newTestSchema(defaultQueryParamsProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the providerConfiguration should contain the default query params", func() {
+				So(providerConfiguration.getDefaultQueryParams(), ShouldResemble, map[string]string{"apiVersion": "2023-01-01"})
+			})
+		})
+	})
+	Convey("Given a spec analyser and a schema ResourceData that does not contain the default_query_params property", t, func() {
+		specAnalyser := &specAnalyserStub{
+			security: &specSecurityStub{},
+		}
+		data := newTestSchema(stringProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the providerConfiguration should not contain default query params", func() {
+				So(err, ShouldBeNil)
+				So(providerConfiguration.getDefaultQueryParams(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestNewProviderConfigurationWithSecuritySchemes(t *testing.T) {
+	specAnalyser := &specAnalyserStub{
+		security: &specSecurityStub{
+			securityDefinitions: &SpecSecurityDefinitions{
+				newAPIKeyHeaderSecurityDefinition("header_auth", "X-API-Key"),
+				newAPIKeyQuerySecurityDefinition("query_auth", "api_key"),
+			},
+		},
+	}
+	Convey("Given a spec analyser and a schema ResourceData containing security schemes to activate that are defined", t, func() {
+		securitySchemesProperty := newListSchemaDefinitionPropertyWithDefaults(providerPropertySecuritySchemes, "", false, false, false, []interface{}{"query_auth", "header_auth"}, typeString, nil)
+		data := newTestSchema(securitySchemesProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			providerConfiguration, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the providerConfiguration should contain the security schemes in the order configured", func() {
+				So(providerConfiguration.SecuritySchemes, ShouldResemble, []string{"query_auth", "header_auth"})
+			})
+		})
+	})
+	Convey("Given a spec analyser and a schema ResourceData containing a security scheme to activate that is not defined", t, func() {
+		securitySchemesProperty := newListSchemaDefinitionPropertyWithDefaults(providerPropertySecuritySchemes, "", false, false, false, []interface{}{"non_existing_auth"}, typeString, nil)
+		data := newTestSchema(securitySchemesProperty).getResourceData(t)
+		Convey("When newProviderConfiguration method is called", func() {
+			_, err := newProviderConfiguration(specAnalyser, data, nil)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "security scheme 'non_existing_auth' configured in the property 'security_schemes' is not defined, the security schemes available are: [header_auth query_auth]")
+			})
+		})
+	})
+}
+
+func TestSelectSecuritySchemes(t *testing.T) {
+	headerAuth := SpecSecuritySchemes{SpecSecurityScheme{Name: "header_auth"}}
+	queryAuth := SpecSecuritySchemes{SpecSecurityScheme{Name: "query_auth"}}
+	headerAndQueryAuth := SpecSecuritySchemes{SpecSecurityScheme{Name: "header_auth"}, SpecSecurityScheme{Name: "query_auth"}}
+	noAuth := SpecSecuritySchemes{}
+	newProviderConfig := func(headerValue, queryValue string, securitySchemes ...string) providerConfiguration {
+		return providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"header_auth": createAPIKeyAuthenticator(newAPIKeyHeaderSecurityDefinition("header_auth", "X-API-Key"), headerValue),
+				"query_auth":  createAPIKeyAuthenticator(newAPIKeyQuerySecurityDefinition("query_auth", "api_key"), queryValue),
+			},
+			SecuritySchemes: securitySchemes,
+		}
+	}
+	testCases := []struct {
+		name                    string
+		providerConfig          providerConfiguration
+		securityRequirements    []SpecSecuritySchemes
+		expectedSecuritySchemes SpecSecuritySchemes
+	}{
+		{name: "no security requirements", providerConfig: newProviderConfig("", ""), securityRequirements: nil, expectedSecuritySchemes: nil},
+		{name: "first security requirement configured", providerConfig: newProviderConfig("header", "query"), securityRequirements: []SpecSecuritySchemes{headerAuth, queryAuth}, expectedSecuritySchemes: headerAuth},
+		{name: "first security requirement not configured falls back to the next one configured", providerConfig: newProviderConfig("", "query"), securityRequirements: []SpecSecuritySchemes{headerAuth, queryAuth}, expectedSecuritySchemes: queryAuth},
+		{name: "AND-combined security requirement partially configured falls back to the next one configured", providerConfig: newProviderConfig("header", ""), securityRequirements: []SpecSecuritySchemes{headerAndQueryAuth, headerAuth}, expectedSecuritySchemes: headerAuth},
+		{name: "AND-combined security requirement configured", providerConfig: newProviderConfig("header", "query"), securityRequirements: []SpecSecuritySchemes{headerAndQueryAuth, headerAuth}, expectedSecuritySchemes: headerAndQueryAuth},
+		{name: "none of the security requirements configured returns the first one", providerConfig: newProviderConfig("", ""), securityRequirements: []SpecSecuritySchemes{headerAuth, queryAuth}, expectedSecuritySchemes: headerAuth},
+		{name: "optional security falls back to no auth", providerConfig: newProviderConfig("", ""), securityRequirements: []SpecSecuritySchemes{headerAuth, noAuth}, expectedSecuritySchemes: noAuth},
+		{name: "security schemes activated by the user in order of preference", providerConfig: newProviderConfig("header", "query", "query_auth", "header_auth"), securityRequirements: []SpecSecuritySchemes{headerAuth, queryAuth}, expectedSecuritySchemes: queryAuth},
+		{name: "security requirements containing schemes not activated by the user are ignored", providerConfig: newProviderConfig("header", "query", "header_auth"), securityRequirements: []SpecSecuritySchemes{headerAndQueryAuth, queryAuth, headerAuth}, expectedSecuritySchemes: headerAuth},
+		{name: "security schemes activated by the user not matching any requirement", providerConfig: newProviderConfig("header", "query", "header_auth"), securityRequirements: []SpecSecuritySchemes{queryAuth}, expectedSecuritySchemes: queryAuth},
+	}
+	for _, tc := range testCases {
+		Convey(fmt.Sprintf("Given a provider configuration and %s", tc.name), t, func() {
+			securitySchemes := tc.providerConfig.selectSecuritySchemes(tc.securityRequirements)
+			So(securitySchemes, ShouldResemble, tc.expectedSecuritySchemes)
+		})
+	}
+}
+
+func TestValidateAPIBaseURL(t *testing.T) {
+	testCases := []struct {
+		apiBaseURL  string
+		expectedErr bool
+	}{
+		{apiBaseURL: "https://api.staging.example.com", expectedErr: false},
+		{apiBaseURL: "http://localhost:8080/api/v1", expectedErr: false},
+		{apiBaseURL: "ftp://api.example.com", expectedErr: true},
+		{apiBaseURL: "api.example.com", expectedErr: true},
+		{apiBaseURL: "https://", expectedErr: true},
+		{apiBaseURL: "http://api example.com", expectedErr: true},
+	}
+	for _, tc := range testCases {
+		Convey(fmt.Sprintf("Given the api base url '%s'", tc.apiBaseURL), t, func() {
+			err := validateAPIBaseURL(tc.apiBaseURL)
+			So(err != nil, ShouldEqual, tc.expectedErr)
+		})
+	}
+}
+
+func TestGetSecurityDefinitionHeadersAndQueryParams(t *testing.T) {
+	Convey("Given a providerConfiguration with header and query security schema definitions", t, func() {
+		providerConfiguration := providerConfiguration{
+			SecuritySchemaDefinitions: map[string]specAPIKeyAuthenticator{
+				"header_auth": createAPIKeyAuthenticator(newAPIKeyHeaderSecurityDefinition("header_auth", "X-API-Key"), "value"),
+				"bearer_auth": createAPIKeyAuthenticator(newAPIKeyHeaderBearerSecurityDefinition("bearer_auth"), "value"),
+				"query_auth":  createAPIKeyAuthenticator(newAPIKeyQuerySecurityDefinition("query_auth", "api_key"), "value"),
+			},
+		}
+		Convey("When getSecurityDefinitionHeadersAndQueryParams method is called", func() {
+			headers, queryParams := providerConfiguration.getSecurityDefinitionHeadersAndQueryParams()
+			Convey("Then the headers and query params used by the security definitions should be returned", func() {
+				So(headers, ShouldResemble, []string{authorizationHeader, "X-API-Key"})
+				So(queryParams, ShouldResemble, []string{"api_key"})
+			})
+		})
+	})
+}
+
 func TestGetAuthenticatorFor(t *testing.T) {
 	Convey("Given a providerConfiguration with some security schema definitions", t, func() {
 		providerConfiguration := providerConfiguration{
@@ -154,3 +436,49 @@ func TestGetEndPoint(t *testing.T) {
 		})
 	})
 }
+
+func TestGetTagEndPoint(t *testing.T) {
+	Convey("Given a providerConfiguration configured with some tag endpoints", t, func() {
+		providerConfiguration := providerConfiguration{
+			TagEndpoints: map[string]string{
+				"billing": "billing.staging.com",
+				"network": "network.staging.com",
+			},
+		}
+		Convey("When getTagEndPoint method is called with tags that have endpoints configured", func() {
+			value := providerConfiguration.getTagEndPoint([]string{"compute", "network", "billing"})
+			Convey("Then the value returned should be the endpoint of the first tag configured", func() {
+				So(value, ShouldEqual, "network.staging.com")
+			})
+		})
+		Convey("When getTagEndPoint method is called with tags that do not have endpoints configured", func() {
+			value := providerConfiguration.getTagEndPoint([]string{"compute"})
+			Convey("Then the value returned should be empty", func() {
+				So(value, ShouldBeEmpty)
+			})
+		})
+	})
+	Convey("Given a providerConfiguration configured with nil tag endpoints", t, func() {
+		providerConfiguration := providerConfiguration{}
+		Convey("When getTagEndPoint method is called", func() {
+			value := providerConfiguration.getTagEndPoint([]string{"billing"})
+			Convey("Then the value returned should be empty", func() {
+				So(value, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestGetAPIBaseURL(t *testing.T) {
+	Convey("Given a providerConfiguration with an api base url", t, func() {
+		providerConfiguration := providerConfiguration{
+			APIBaseURL: "http://localhost:8080",
+		}
+		Convey("When getAPIBaseURL() method is called", func() {
+			value := providerConfiguration.getAPIBaseURL()
+			Convey("Then the value returned should be the expected one", func() {
+				So(value, ShouldEqual, "http://localhost:8080")
+			})
+		})
+	})
+}
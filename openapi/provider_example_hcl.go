@@ -0,0 +1,263 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GenerateExampleHCL returns a skeleton terraform configuration block for the given resource exposed by the provider
+// which can be used as a starting point when writing configurations. The required arguments are populated with
+// placeholder values, the optional ones are commented out and the computed attributes are listed as comments. The
+// resource name can be provided either with or without the provider name prefix (e,g: openapi_cdn_v1 or cdn_v1). The
+// resources served by the terraform-plugin-framework when protocol v6 is enabled are generated from the framework
+// schema, hence the objects and arrays of objects are written as nested attributes instead of blocks
+func (p *ProviderOpenAPI) GenerateExampleHCL(resourceName string) (string, error) {
+	if p.provider == nil && p.providerServer == nil {
+		serviceConfiguration, telemetryHandler, err := getServiceConfiguration(p.ProviderName, p.Logger)
+		if err != nil {
+			return "", fmt.Errorf("plugin init error: %s", err)
+		}
+		p.telemetryHandler = telemetryHandler
+		if serviceConfiguration.IsProtocolV6Enabled() {
+			if _, err := p.CreateProviderServerFromServiceConfiguration(serviceConfiguration); err != nil {
+				return "", err
+			}
+		}
+		if _, err := p.CreateSchemaProviderFromServiceConfiguration(serviceConfiguration); err != nil {
+			return "", err
+		}
+	}
+	terraformResourceNames := []string{resourceName, fmt.Sprintf("%s_%s", p.ProviderName, resourceName)}
+	for _, terraformResourceName := range terraformResourceNames {
+		for _, resource := range p.frameworkResources {
+			if resource.name == terraformResourceName {
+				return generateFrameworkExampleHCL(terraformResourceName, resource.schema), nil
+			}
+		}
+	}
+	provider := p.provider
+	if provider == nil {
+		var err error
+		if provider, err = p.CreateSchemaProvider(); err != nil {
+			return "", err
+		}
+	}
+	for _, terraformResourceName := range terraformResourceNames {
+		if resource, exists := provider.ResourcesMap[terraformResourceName]; exists {
+			return generateExampleHCL(terraformResourceName, resource), nil
+		}
+	}
+	return "", fmt.Errorf("resource '%s' is not exposed by the provider '%s'", resourceName, p.ProviderName)
+}
+
+func generateExampleHCL(resourceName string, resource *schema.Resource) string {
+	var hcl strings.Builder
+	fmt.Fprintf(&hcl, "resource \"%s\" \"example\" {\n", resourceName)
+	writeExampleHCLAttributes(&hcl, resource.Schema, "  ")
+	hcl.WriteString("}\n")
+	return hcl.String()
+}
+
+func writeExampleHCLAttributes(hcl *strings.Builder, attributes map[string]*schema.Schema, prefix string) {
+	var required, optional, computed []string
+	for name, attribute := range attributes {
+		switch {
+		case attribute.Required:
+			required = append(required, name)
+		case attribute.Optional:
+			optional = append(optional, name)
+		default:
+			computed = append(computed, name)
+		}
+	}
+	writeExampleHCLAttributeGroups(hcl, required, optional, computed, prefix, func(name, prefix string) {
+		writeExampleHCLAttribute(hcl, name, attributes[name], prefix)
+	})
+}
+
+// writeExampleHCLAttributeGroups writes the required attributes first followed by the optional ones (commented out) and
+// the computed ones, each group sorted by name. The prefix contains the indentation and, for nested blocks that are
+// commented out, the comment character
+func writeExampleHCLAttributeGroups(hcl *strings.Builder, required, optional, computed []string, prefix string, writeAttribute func(name, prefix string)) {
+	sort.Strings(required)
+	sort.Strings(optional)
+	sort.Strings(computed)
+	for _, name := range required {
+		writeAttribute(name, prefix)
+	}
+	for _, name := range optional {
+		writeAttribute(name, commentedExampleHCLPrefix(prefix))
+	}
+	if len(computed) > 0 {
+		fmt.Fprintf(hcl, "%sComputed attributes: %s\n", commentedExampleHCLPrefix(prefix), strings.Join(computed, ", "))
+	}
+}
+
+func writeExampleHCLAttribute(hcl *strings.Builder, name string, attribute *schema.Schema, prefix string) {
+	note := ""
+	if attribute.Sensitive {
+		note = " # sensitive"
+	}
+	if nestedResource, isBlock := attribute.Elem.(*schema.Resource); isBlock {
+		fmt.Fprintf(hcl, "%s%s {%s\n", prefix, name, note)
+		writeExampleHCLAttributes(hcl, nestedResource.Schema, prefix+"  ")
+		fmt.Fprintf(hcl, "%s}\n", prefix)
+		return
+	}
+	fmt.Fprintf(hcl, "%s%s = %s%s\n", prefix, name, getExampleHCLValue(name, attribute), note)
+}
+
+// getExampleHCLValue returns the placeholder value for the attribute. If the attribute has a default value, the default
+// value is used instead
+func getExampleHCLValue(name string, attribute *schema.Schema) string {
+	if attribute.Default != nil {
+		if defaultValue, isString := attribute.Default.(string); isString {
+			return fmt.Sprintf("%q", defaultValue)
+		}
+		return fmt.Sprintf("%v", attribute.Default)
+	}
+	switch attribute.Type {
+	case schema.TypeString:
+		return fmt.Sprintf("\"<%s>\"", name)
+	case schema.TypeInt:
+		return "0"
+	case schema.TypeFloat:
+		return "0.0"
+	case schema.TypeBool:
+		return "false"
+	case schema.TypeList, schema.TypeSet:
+		if elem, ok := attribute.Elem.(*schema.Schema); ok {
+			return fmt.Sprintf("[%s]", getExampleHCLValue(name, elem))
+		}
+	case schema.TypeMap:
+		if elem, ok := attribute.Elem.(*schema.Schema); ok {
+			return fmt.Sprintf("{ key = %s }", getExampleHCLValue("value", elem))
+		}
+		return "{ key = \"<value>\" }"
+	}
+	return "null"
+}
+
+// generateFrameworkExampleHCL returns the example HCL of a resource served by the terraform-plugin-framework, the
+// attributes are written in the same order as the SDK resources ones (see writeExampleHCLAttributeGroups)
+func generateFrameworkExampleHCL(resourceName string, resourceSchema resourceschema.Schema) string {
+	var hcl strings.Builder
+	fmt.Fprintf(&hcl, "resource \"%s\" \"example\" {\n", resourceName)
+	writeFrameworkExampleHCLAttributes(&hcl, resourceSchema.Attributes, "  ")
+	hcl.WriteString("}\n")
+	return hcl.String()
+}
+
+func writeFrameworkExampleHCLAttributes(hcl *strings.Builder, attributes map[string]resourceschema.Attribute, prefix string) {
+	var required, optional, computed []string
+	for name, attribute := range attributes {
+		switch {
+		case attribute.IsRequired():
+			required = append(required, name)
+		case attribute.IsOptional():
+			optional = append(optional, name)
+		default:
+			computed = append(computed, name)
+		}
+	}
+	writeExampleHCLAttributeGroups(hcl, required, optional, computed, prefix, func(name, prefix string) {
+		writeFrameworkExampleHCLAttribute(hcl, name, attributes[name], prefix)
+	})
+}
+
+// writeFrameworkExampleHCLAttribute writes the attribute, the nested attributes are written as object (single nested
+// attributes), list of objects (list nested attributes) or map of objects (map nested attributes) values
+func writeFrameworkExampleHCLAttribute(hcl *strings.Builder, name string, attribute resourceschema.Attribute, prefix string) {
+	note := ""
+	if attribute.IsSensitive() {
+		note = " # sensitive"
+	}
+	switch nestedAttribute := attribute.(type) {
+	case resourceschema.SingleNestedAttribute:
+		fmt.Fprintf(hcl, "%s%s = {%s\n", prefix, name, note)
+		writeFrameworkExampleHCLAttributes(hcl, nestedAttribute.Attributes, prefix+"  ")
+		fmt.Fprintf(hcl, "%s}\n", prefix)
+		return
+	case resourceschema.ListNestedAttribute:
+		fmt.Fprintf(hcl, "%s%s = [{%s\n", prefix, name, note)
+		writeFrameworkExampleHCLAttributes(hcl, nestedAttribute.NestedObject.Attributes, prefix+"  ")
+		fmt.Fprintf(hcl, "%s}]\n", prefix)
+		return
+	case resourceschema.MapNestedAttribute:
+		fmt.Fprintf(hcl, "%s%s = {%s\n", prefix, name, note)
+		fmt.Fprintf(hcl, "%s  key = {\n", prefix)
+		writeFrameworkExampleHCLAttributes(hcl, nestedAttribute.NestedObject.Attributes, prefix+"    ")
+		fmt.Fprintf(hcl, "%s  }\n", prefix)
+		fmt.Fprintf(hcl, "%s}\n", prefix)
+		return
+	}
+	fmt.Fprintf(hcl, "%s%s = %s%s\n", prefix, name, getFrameworkExampleHCLValue(name, attribute), note)
+}
+
+// getFrameworkExampleHCLValue returns the placeholder value for the attribute. If the attribute has a default value, the
+// default value is used instead
+func getFrameworkExampleHCLValue(name string, attribute resourceschema.Attribute) string {
+	ctx := context.Background()
+	switch primitiveAttribute := attribute.(type) {
+	case resourceschema.StringAttribute:
+		if primitiveAttribute.Default != nil {
+			resp := &defaults.StringResponse{}
+			primitiveAttribute.Default.DefaultString(ctx, defaults.StringRequest{}, resp)
+			return fmt.Sprintf("%q", resp.PlanValue.ValueString())
+		}
+	case resourceschema.Int64Attribute:
+		if primitiveAttribute.Default != nil {
+			resp := &defaults.Int64Response{}
+			primitiveAttribute.Default.DefaultInt64(ctx, defaults.Int64Request{}, resp)
+			return fmt.Sprintf("%v", resp.PlanValue.ValueInt64())
+		}
+	case resourceschema.Float64Attribute:
+		if primitiveAttribute.Default != nil {
+			resp := &defaults.Float64Response{}
+			primitiveAttribute.Default.DefaultFloat64(ctx, defaults.Float64Request{}, resp)
+			return fmt.Sprintf("%v", resp.PlanValue.ValueFloat64())
+		}
+	case resourceschema.BoolAttribute:
+		if primitiveAttribute.Default != nil {
+			resp := &defaults.BoolResponse{}
+			primitiveAttribute.Default.DefaultBool(ctx, defaults.BoolRequest{}, resp)
+			return fmt.Sprintf("%v", resp.PlanValue.ValueBool())
+		}
+	}
+	return getFrameworkExampleHCLTypeValue(name, attribute.GetType())
+}
+
+func getFrameworkExampleHCLTypeValue(name string, attributeType attr.Type) string {
+	switch {
+	case attributeType.Equal(types.StringType):
+		return fmt.Sprintf("\"<%s>\"", name)
+	case attributeType.Equal(types.Int64Type):
+		return "0"
+	case attributeType.Equal(types.Float64Type):
+		return "0.0"
+	case attributeType.Equal(types.BoolType):
+		return "false"
+	}
+	switch collectionType := attributeType.(type) {
+	case types.ListType:
+		return fmt.Sprintf("[%s]", getFrameworkExampleHCLTypeValue(name, collectionType.ElemType))
+	case types.MapType:
+		return fmt.Sprintf("{ key = %s }", getFrameworkExampleHCLTypeValue("value", collectionType.ElemType))
+	}
+	return "null"
+}
+
+func commentedExampleHCLPrefix(prefix string) string {
+	if strings.Contains(prefix, "#") {
+		return prefix
+	}
+	return prefix + "# "
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"testing"
+
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGenerateExampleHCL(t *testing.T) {
+	Convey("Given a ProviderOpenAPI exposing a resource with required, optional, sensitive and computed attributes", t, func() {
+		p := ProviderOpenAPI{
+			ProviderName: "openapi",
+			provider: &schema.Provider{
+				ResourcesMap: map[string]*schema.Resource{
+					"openapi_cdn_v1": {
+						Schema: map[string]*schema.Schema{
+							"label":    {Type: schema.TypeString, Required: true},
+							"password": {Type: schema.TypeString, Required: true, Sensitive: true},
+							"port":     {Type: schema.TypeInt, Required: true},
+							"ratio":    {Type: schema.TypeFloat, Optional: true},
+							"enabled":  {Type: schema.TypeBool, Optional: true, Default: true},
+							"region":   {Type: schema.TypeString, Optional: true, Default: "us-west1"},
+							"ips":      {Type: schema.TypeList, Required: true, Elem: &schema.Schema{Type: schema.TypeString}},
+							"tags":     {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+							"object_property": {Type: schema.TypeList, Required: true, MaxItems: 1, Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name":   {Type: schema.TypeString, Required: true},
+									"weight": {Type: schema.TypeInt, Optional: true},
+									"uuid":   {Type: schema.TypeString, Computed: true},
+								},
+							}},
+							"optional_object_property": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name":  {Type: schema.TypeString, Required: true},
+									"token": {Type: schema.TypeString, Optional: true, Sensitive: true},
+								},
+							}},
+							"status":     {Type: schema.TypeString, Computed: true},
+							"created_at": {Type: schema.TypeString, Computed: true},
+						},
+					},
+				},
+			},
+		}
+		expectedHCL := `resource "openapi_cdn_v1" "example" {
+  ips = ["<ips>"]
+  label = "<label>"
+  object_property {
+    name = "<name>"
+    # weight = 0
+    # Computed attributes: uuid
+  }
+  password = "<password>" # sensitive
+  port = 0
+  # enabled = true
+  # optional_object_property {
+  #   name = "<name>"
+  #   token = "<token>" # sensitive
+  # }
+  # ratio = 0.0
+  # region = "us-west1"
+  # tags = { key = "<value>" }
+  # Computed attributes: created_at, status
+}
+`
+		Convey("When GenerateExampleHCL is called with the full resource name", func() {
+			hcl, err := p.GenerateExampleHCL("openapi_cdn_v1")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the example HCL returned should contain the placeholders for the required attributes, the optional ones commented out and the computed ones noted", func() {
+				So(hcl, ShouldEqual, expectedHCL)
+			})
+		})
+		Convey("When GenerateExampleHCL is called with the resource name without the provider name prefix", func() {
+			hcl, err := p.GenerateExampleHCL("cdn_v1")
+			Convey("Then the example HCL returned should be the same as the one generated with the full resource name", func() {
+				So(err, ShouldBeNil)
+				So(hcl, ShouldEqual, expectedHCL)
+			})
+		})
+		Convey("When GenerateExampleHCL is called with a resource that is not exposed by the provider", func() {
+			_, err := p.GenerateExampleHCL("non_existing_v1")
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "resource 'non_existing_v1' is not exposed by the provider 'openapi'")
+			})
+		})
+	})
+	Convey("Given a ProviderOpenAPI exposing a resource served by the terraform-plugin-framework", t, func() {
+		p := ProviderOpenAPI{
+			ProviderName: "openapi",
+			provider:     &schema.Provider{ResourcesMap: map[string]*schema.Resource{}},
+			frameworkResources: []*frameworkResource{
+				{
+					name: "openapi_firewall_v1",
+					schema: resourceschema.Schema{
+						Attributes: map[string]resourceschema.Attribute{
+							"id":      resourceschema.StringAttribute{Computed: true},
+							"label":   resourceschema.StringAttribute{Required: true},
+							"port":    resourceschema.Int64Attribute{Optional: true, Computed: true, Default: int64default.StaticInt64(8080)},
+							"enabled": resourceschema.BoolAttribute{Optional: true},
+							"ips":     resourceschema.ListAttribute{Required: true, ElementType: types.StringType},
+							"settings": resourceschema.SingleNestedAttribute{Required: true, Attributes: map[string]resourceschema.Attribute{
+								"name":  resourceschema.StringAttribute{Required: true},
+								"token": resourceschema.StringAttribute{Optional: true, Sensitive: true},
+							}},
+							"rules": resourceschema.ListNestedAttribute{Optional: true, NestedObject: resourceschema.NestedAttributeObject{Attributes: map[string]resourceschema.Attribute{
+								"ratio": resourceschema.Float64Attribute{Required: true},
+								"uuid":  resourceschema.StringAttribute{Computed: true},
+							}}},
+						},
+					},
+				},
+			},
+		}
+		Convey("When GenerateExampleHCL is called with the resource name without the provider name prefix", func() {
+			hcl, err := p.GenerateExampleHCL("firewall_v1")
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the example HCL returned should contain the objects and arrays of objects as nested attributes", func() {
+				So(hcl, ShouldEqual, `resource "openapi_firewall_v1" "example" {
+  ips = ["<ips>"]
+  label = "<label>"
+  settings = {
+    name = "<name>"
+    # token = "<token>" # sensitive
+  }
+  # enabled = false
+  # port = 8080
+  # rules = [{
+  #   ratio = 0.0
+  #   Computed attributes: uuid
+  # }]
+  # Computed attributes: id
+}
+`)
+			})
+		})
+	})
+}
@@ -1,23 +1,33 @@
 package openapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"path"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/terraformutils"
 
-	"log"
-
 	"github.com/dikhan/http_goclient"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type providerFactory struct {
 	name                 string
 	specAnalyser         SpecAnalyser
 	serviceConfiguration ServiceConfiguration
+	requestInterceptor   RequestInterceptor
+	logger               Logger
+	// telemetryHandler (optional) is used to submit the resources operations timing metrics
+	telemetryHandler TelemetryHandler
 }
 
 func newProviderFactory(name string, specAnalyser SpecAnalyser, serviceConfiguration ServiceConfiguration) (*providerFactory, error) {
@@ -57,7 +67,11 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 	}
 
 	resourceNames := p.getResourceNames(resourceMap)
-	providerConfigurationEndPoints := &providerConfigurationEndPoints{resourceNames}
+	tagNames, err := p.getResourceTagNames(resourceMap)
+	if err != nil {
+		return nil, err
+	}
+	providerConfigurationEndPoints := &providerConfigurationEndPoints{resourceNames: resourceNames, tagNames: tagNames}
 
 	if providerSchema, err = p.createTerraformProviderSchema(openAPIBackendConfiguration, providerConfigurationEndPoints); err != nil {
 		return nil, err
@@ -70,19 +84,126 @@ func (p providerFactory) createProvider() (*schema.Provider, error) {
 		dataSources[k] = v
 	}
 
+	if p.serviceConfiguration.IsGetOnlyDataSourcesEnabled() {
+		getOnlyDataSourcesInstance, err := p.createTerraformProviderGetOnlyDataSourceInstanceMap()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range getOnlyDataSourcesInstance {
+			if _, alreadyThere := dataSources[k]; alreadyThere {
+				p.getLogger().Warn(fmt.Sprintf("'%s' is a duplicate data source name and the GET-only data source instance is not being registered in the provider", k), "data_source", k)
+				continue
+			}
+			dataSources[k] = v
+		}
+	}
+
 	provider := &schema.Provider{
 		Schema:         providerSchema,
 		ResourcesMap:   resourceMap,
 		DataSourcesMap: dataSources,
-		ConfigureFunc:  p.configureProvider(openAPIBackendConfiguration, providerConfigurationEndPoints),
+	}
+	configureFunc := p.configureProvider(openAPIBackendConfiguration, providerConfigurationEndPoints)
+	provider.ConfigureContextFunc = func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		// the terraform version is only known once terraform configures the provider
+		if p.telemetryHandler != nil {
+			p.telemetryHandler.SetTerraformVersion(provider.TerraformVersion)
+		}
+		openAPIClient, err := configureFunc(data)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return openAPIClient, nil
 	}
 	return provider, nil
 }
 
+// createProviderServer returns the protocol v6 provider server factory. The resources marked with the
+// x-terraform-resource-protocol-v6 extension are served by the terraform-plugin-framework so objects and arrays of
+// objects are represented as nested attributes, whereas the rest of the resources, the data sources and the provider
+// configuration keep being served by the SDK provider upgraded to protocol v6. The subresources and the resources using
+// features that are only implemented by the SDK resources (see getFrameworkUnsupportedFeatures) are also served by the
+// SDK provider. Both servers are muxed together and share the same provider schema and API client. The resources served
+// by the terraform-plugin-framework are returned along with the provider server factory
+func (p providerFactory) createProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, []*frameworkResource, error) {
+	provider, err := p.createProvider()
+	if err != nil {
+		return nil, nil, err
+	}
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, nil, err
+	}
+	regions, err := p.getMultiRegionRegions()
+	if err != nil {
+		return nil, nil, err
+	}
+	var frameworkResources []*frameworkResource
+	for _, openAPIResource := range openAPIResources {
+		if !openAPIResource.isProtocolV6Resource() {
+			continue
+		}
+		resourceName, err := p.getProviderResourceName(openAPIResource.getResourceName())
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, registered := provider.ResourcesMap[resourceName]; !registered {
+			continue
+		}
+		if openAPIResource.getParentResourceInfo() != nil {
+			p.getLogger().Warn(fmt.Sprintf("'%s' is a subresource and subresources are not supported with protocol v6 yet, the resource is served by the SDK provider", openAPIResource.getResourceName()), "resource", openAPIResource.getResourceName())
+			continue
+		}
+		r := newResourceFactory(openAPIResource)
+		r.logger = p.logger
+		r.telemetryHandler = p.telemetryHandler
+		if host, err := openAPIResource.getHost(); err == nil && host == "" {
+			r.regions = regions
+		}
+		unsupportedFeatures, err := r.getFrameworkUnsupportedFeatures()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(unsupportedFeatures) > 0 {
+			p.getLogger().Warn(fmt.Sprintf("'%s' uses features that are not supported with protocol v6 yet (%s), the resource is served by the SDK provider", openAPIResource.getResourceName(), strings.Join(unsupportedFeatures, ", ")), "resource", openAPIResource.getResourceName())
+			continue
+		}
+		resource, err := newFrameworkResource(resourceName, r)
+		if err != nil {
+			return nil, nil, err
+		}
+		delete(provider.ResourcesMap, resourceName)
+		frameworkResources = append(frameworkResources, resource)
+		p.getLogger().Info(fmt.Sprintf("resource '%s' successfully registered in the provider using protocol v6", resourceName), "resource", resourceName)
+	}
+
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, provider.GRPCProvider)
+	if err != nil {
+		return nil, nil, err
+	}
+	sdkProviderSchema, err := sdkServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sdkProviderSchema.Diagnostics) > 0 {
+		return nil, nil, fmt.Errorf("failed to load the SDK provider schema: %s %s", sdkProviderSchema.Diagnostics[0].Summary, sdkProviderSchema.Diagnostics[0].Detail)
+	}
+	fwProvider, err := newFrameworkProvider(p.name, provider, sdkProviderSchema.Provider, frameworkResources)
+	if err != nil {
+		return nil, nil, err
+	}
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, func() tfprotov6.ProviderServer { return sdkServer }, providerserver.NewProtocol6(fwProvider))
+	if err != nil {
+		return nil, nil, err
+	}
+	return muxServer.ProviderServer, frameworkResources, nil
+}
+
 // createTerraformProviderSchema adds support for specific provider configuration such as:
 // - api key auth which will be used as the authentication mechanism when making http requests to the service provider
 // - specific headers used in operations
 // - endpoints override in case the user wants to point the resource to a different API (e,g: staging environment endpoint)
+// - api base url override in case the user wants to point all the resources to a different API (e,g: a local mock)
 func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) (map[string]*schema.Schema, error) {
 	s := map[string]*schema.Schema{}
 
@@ -91,17 +212,26 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		return nil, err
 	}
 	if isMultiRegion {
-		log.Printf("[DEBUG] service provider is configured with multi-region. API calls will be made against %s and the region provided by the user (or the default value otherwise, being the first element of supported region list: %+v), unless overridden by specific resources", host, regions)
+		p.getLogger().Debug(fmt.Sprintf("service provider is configured with multi-region. API calls will be made against %s and the region provided by the user (or the default value otherwise, being the first element of supported region list: %+v), unless overridden by specific resources", host, regions), "host", host)
 		if err := p.configureProviderProperty(s, providerPropertyRegion, regions[0], true, regions); err != nil {
 			return nil, err
 		}
 	}
 
-	// Override security definitions to required if they are global security schemes
+	// Override security definitions to required if they are global security schemes. If the global security contains
+	// alternative security requirements none of the security definitions is required since the user can choose which
+	// security requirement to configure
 	globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
 	if err != nil {
 		return nil, err
 	}
+	globalSecurityRequirements, err := p.specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
+	if err != nil {
+		return nil, err
+	}
+	if len(globalSecurityRequirements) > 1 {
+		globalSecuritySchemes = SpecSecuritySchemes{}
+	}
 
 	// Add all security definitions as optional properties
 	securityDefinitions, err := p.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
@@ -114,11 +244,18 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		if globalSecuritySchemes.securitySchemeExists(securityDefinition) {
 			required = true
 		}
+		// OAuth2 client credentials security definitions are configured with the client id and secret used to obtain the access token
+		if oauth2SecDef, ok := securityDefinition.(specOAuth2ClientCredentialsSecurityDefinition); ok {
+			p.configureProviderPropertyFromPluginConfig(s, oauth2SecDef.getClientIDTerraformConfigurationName(), required)
+			p.configureProviderPropertyFromPluginConfig(s, oauth2SecDef.getClientSecretTerraformConfigurationName(), required)
+			s[oauth2SecDef.getClientSecretTerraformConfigurationName()].Sensitive = true
+			continue
+		}
 		p.configureProviderPropertyFromPluginConfig(s, secDefName, required)
 	}
 
 	headers, err := p.specAnalyser.GetAllHeaderParameters()
-	log.Printf("[DEBUG] all header parameters: %+v", headers)
+	p.getLogger().Debug(fmt.Sprintf("all header parameters: %+v", headers))
 	if err != nil {
 		return nil, err
 	}
@@ -134,6 +271,29 @@ func (p providerFactory) createTerraformProviderSchema(openAPIBackendConfigurati
 		}
 	}
 
+	s[providerPropertyAPIBaseURL] = terraformutils.CreateStringSchemaProperty(providerPropertyAPIBaseURL, false, "")
+	s[providerPropertyAPIBaseURL].Description = "Base URL (e,g: https://api.staging.example.com/v1) that overrides the host and base path from the swagger file for all the API requests"
+
+	s[providerPropertyAWSSigV4] = awsSigV4Schema()
+
+	s[providerPropertyTLS] = tlsSchema()
+
+	if len(*securityDefinitions) > 0 {
+		s[providerPropertySecuritySchemes] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Security schemes (e,g: [\"apikey_auth\"]) to activate, in order of preference, when the API operations support multiple security requirements. If not set, the first security requirement configured with values is used",
+		}
+	}
+
+	s[providerPropertyDefaultQueryParams] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "Query parameters (e,g: apiVersion = \"2023-01-01\") that will be appended to all the API request URLs, unless the URL already contains them",
+	}
+
 	return s, nil
 }
 
@@ -148,28 +308,60 @@ func (p providerFactory) getResourceNames(resourceMap map[string]*schema.Resourc
 	return resourceNames
 }
 
+// getResourceTagNames returns the sorted tags of the resources exposed by the provider, which are used to create the
+// tags property of the provider's endpoint schema property so users can override the endpoints per tag group
+func (p providerFactory) getResourceTagNames(resourceMap map[string]*schema.Resource) ([]string, error) {
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string]bool{}
+	for _, openAPIResource := range openAPIResources {
+		resourceName, err := p.getProviderResourceName(openAPIResource.getResourceName())
+		if err != nil {
+			continue
+		}
+		if _, registered := resourceMap[resourceName]; !registered {
+			continue
+		}
+		for _, tag := range openAPIResource.getResourceTags() {
+			tags[tag] = true
+		}
+	}
+	var tagNames []string
+	for tag := range tags {
+		tagNames = append(tagNames, tag)
+	}
+	sort.Strings(tagNames)
+	return tagNames, nil
+}
+
 func (p providerFactory) configureProviderPropertyFromPluginConfig(providerSchema map[string]*schema.Schema, schemaPropertyName string, required bool) {
 	var defaultValue = ""
 	var err error
 	schemaPropertyConfiguration := p.serviceConfiguration.GetSchemaPropertyConfiguration(schemaPropertyName)
 	if schemaPropertyConfiguration != nil {
+		// the value will be obtained from the token command (or token file) if not provided so the property is no longer required
+		if schemaPropertyConfiguration.GetTokenCommand() != nil || schemaPropertyConfiguration.GetTokenFile() != nil {
+			required = false
+		}
 		err = schemaPropertyConfiguration.ExecuteCommand()
 		if err != nil {
-			log.Printf("[ERROR] %s", err)
+			p.getLogger().Error(err.Error(), "property", schemaPropertyName)
 		}
 		defaultValue, err = schemaPropertyConfiguration.GetDefaultValue()
 		if err != nil {
-			log.Printf("[ERROR] %s", err)
+			p.getLogger().Error(err.Error(), "property", schemaPropertyName)
 		}
 	}
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
-	log.Printf("[DEBUG] registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required)
+	p.getLogger().Debug(fmt.Sprintf("registered new property '%s' (required=%t) into provider schema", schemaPropertyName, required), "property", schemaPropertyName)
 }
 
 func (p providerFactory) configureProviderProperty(providerSchema map[string]*schema.Schema, schemaPropertyName string, defaultValue string, required bool, allowedValues []string) error {
 	providerSchema[schemaPropertyName] = terraformutils.CreateStringSchemaProperty(schemaPropertyName, required, defaultValue)
 	providerSchema[schemaPropertyName].ValidateFunc = p.createValidateFunc(allowedValues)
-	log.Printf("[DEBUG] registered new property '%s' into provider schema", schemaPropertyName)
+	p.getLogger().Debug(fmt.Sprintf("registered new property '%s' into provider schema", schemaPropertyName), "property", schemaPropertyName)
 	return nil
 }
 
@@ -196,18 +388,56 @@ func (p providerFactory) createTerraformProviderDataSourceMap() (map[string]*sch
 		if err != nil {
 			return nil, err
 		}
+		if openAPIDataSource.shouldIgnoreResource() {
+			p.getLogger().Warn(fmt.Sprintf("'%s' is marked to be ignored and therefore skipping data source registration into the provider", openAPIDataSource.getResourceName()), "data_source", openAPIDataSource.getResourceName())
+			continue
+		}
+		if !p.isResourceAllowed(openAPIDataSource.getResourceName()) {
+			p.getLogger().Info(fmt.Sprintf("'%s' is not allowed as per the allowed/excluded resources configuration and therefore skipping data source registration into the provider", openAPIDataSource.getResourceName()), "data_source", openAPIDataSource.getResourceName())
+			continue
+		}
 		start := time.Now()
 		d := newDataSourceFactory(openAPIDataSource)
 		dataSourceTFSchema, err := d.createTerraformDataSource()
 		if err != nil {
 			return nil, err
 		}
-		log.Printf("[INFO] data source '%s' successfully registered in the provider (time:%s)", dataSourceName, time.Since(start))
+		p.getLogger().Info(fmt.Sprintf("data source '%s' successfully registered in the provider (time:%s)", dataSourceName, time.Since(start)), "data_source", dataSourceName)
 		dataSourceMap[dataSourceName] = dataSourceTFSchema
 	}
 	return dataSourceMap, nil
 }
 
+// createTerraformProviderGetOnlyDataSourceInstanceMap returns a map containing the data source instances of the GET-only
+// endpoints (resource instance paths which root path does not expose a POST operation). These data sources enable
+// looking up existing API objects by id even though they can not be managed as resources
+func (p providerFactory) createTerraformProviderGetOnlyDataSourceInstanceMap() (map[string]*schema.Resource, error) {
+	dataSourceInstanceMap := map[string]*schema.Resource{}
+	for _, openAPIResource := range p.specAnalyser.GetTerraformCompliantDataSourceInstances() {
+		if openAPIResource.shouldIgnoreResource() {
+			p.getLogger().Warn(fmt.Sprintf("'%s' is marked to be ignored and therefore skipping data source instance registration into the provider", openAPIResource.getResourceName()), "data_source", openAPIResource.getResourceName())
+			continue
+		}
+		if !p.isResourceAllowed(openAPIResource.getResourceName()) {
+			p.getLogger().Info(fmt.Sprintf("'%s' is not allowed as per the allowed/excluded resources configuration and therefore skipping data source instance registration into the provider", openAPIResource.getResourceName()), "data_source", openAPIResource.getResourceName())
+			continue
+		}
+		start := time.Now()
+		d := newDataSourceInstanceFactory(openAPIResource)
+		dataSourceInstanceName, err := p.getProviderResourceName(d.getDataSourceInstanceName())
+		if err != nil {
+			return nil, err
+		}
+		dataSourceInstance, err := d.createTerraformInstanceDataSource()
+		if err != nil {
+			return nil, err
+		}
+		p.getLogger().Info(fmt.Sprintf("data source instance '%s' successfully registered in the provider (time:%s)", dataSourceInstanceName, time.Since(start)), "data_source", dataSourceInstanceName)
+		dataSourceInstanceMap[dataSourceInstanceName] = dataSourceInstance
+	}
+	return dataSourceInstanceMap, nil
+}
+
 // createTerraformProviderResourceMapAndDataSourceInstanceMap is responsible for building the following:
 // - a map containing the resources that are terraform compatible
 // - a map containing the data sources from the resources that are terraform compatible. This data sources enable data
@@ -219,6 +449,10 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 	if err != nil {
 		return nil, nil, err
 	}
+	regions, err := p.getMultiRegionRegions()
+	if err != nil {
+		return nil, nil, err
+	}
 	for _, openAPIResource := range openAPIResources {
 		start := time.Now()
 
@@ -228,16 +462,32 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		}
 
 		if openAPIResource.shouldIgnoreResource() {
-			log.Printf("[WARN] '%s' is marked to be ignored and therefore skipping resource registration into the provider", openAPIResource.getResourceName())
+			p.getLogger().Warn(fmt.Sprintf("'%s' is marked to be ignored and therefore skipping resource registration into the provider", openAPIResource.getResourceName()), "resource", openAPIResource.getResourceName())
+			continue
+		}
+
+		if !p.isResourceAllowed(openAPIResource.getResourceName()) {
+			p.getLogger().Info(fmt.Sprintf("'%s' is not allowed as per the allowed/excluded resources configuration and therefore skipping resource registration into the provider", openAPIResource.getResourceName()), "resource", openAPIResource.getResourceName())
 			continue
 		}
 
 		r := newResourceFactory(openAPIResource)
+		r.logger = p.logger
+		r.telemetryHandler = p.telemetryHandler
+		// the resources overriding the host (e,g: via x-terraform-resource-host) are not managed in the provider regions
+		if host, err := openAPIResource.getHost(); err == nil && host == "" {
+			r.regions = regions
+		}
+		if pollingConfiguration := p.getPollingConfiguration(); pollingConfiguration != nil {
+			r.defaultPollInterval = pollingConfiguration.getInterval(r.defaultPollInterval)
+			r.defaultPollMinTimeout = pollingConfiguration.getMinTimeout(r.defaultPollMinTimeout)
+			r.defaultPollDelay = pollingConfiguration.getDelay(r.defaultPollDelay)
+		}
 		d := newDataSourceInstanceFactory(openAPIResource)
 		fullDataSourceInstanceName, _ := p.getProviderResourceName(d.getDataSourceInstanceName())
 
 		if _, alreadyThere := resourceMap[resourceName]; alreadyThere {
-			log.Printf("[WARN] '%s' is a duplicate resource name and is being removed from the provider", openAPIResource.getResourceName())
+			p.getLogger().Warn(fmt.Sprintf("'%s' is a duplicate resource name and is being removed from the provider", openAPIResource.getResourceName()), "resource", openAPIResource.getResourceName())
 			delete(resourceMap, resourceName)
 			delete(dataSourceInstanceMap, fullDataSourceInstanceName)
 			continue
@@ -248,50 +498,269 @@ func (p providerFactory) createTerraformProviderResourceMapAndDataSourceInstance
 		if err != nil {
 			return nil, nil, err
 		}
-		log.Printf("[INFO] resource '%s' successfully registered in the provider (time:%s)", resourceName, time.Since(start))
+		p.getLogger().Info(fmt.Sprintf("resource '%s' successfully registered in the provider (time:%s)", resourceName, time.Since(start)), "resource", resourceName)
 		resourceMap[resourceName] = resource
 
 		// Register data source instance
 		dataSourceInstance, _ := d.createTerraformInstanceDataSource() // if createTerraformResource did not throw an error, it's assumed that the data source instance would work too considering it's subset of the resource
-		log.Printf("[INFO] data source instance '%s' successfully registered in the provider (time:%s)", fullDataSourceInstanceName, time.Since(start))
+		p.getLogger().Info(fmt.Sprintf("data source instance '%s' successfully registered in the provider (time:%s)", fullDataSourceInstanceName, time.Since(start)), "data_source", fullDataSourceInstanceName)
 		dataSourceInstanceMap[fullDataSourceInstanceName] = dataSourceInstance
 	}
 	return resourceMap, dataSourceInstanceMap, nil
 }
 
+// getMultiRegionRegions returns the regions supported by the provider if the OpenAPI document is multi-region; nil
+// otherwise
+func (p providerFactory) getMultiRegionRegions() ([]string, error) {
+	openAPIBackendConfiguration, err := p.specAnalyser.GetAPIBackendConfiguration()
+	if err != nil || openAPIBackendConfiguration == nil {
+		return nil, err
+	}
+	isMultiRegion, _, regions, err := openAPIBackendConfiguration.isMultiRegion()
+	if err != nil || !isMultiRegion {
+		return nil, err
+	}
+	return regions, nil
+}
+
 func (p providerFactory) configureProvider(openAPIBackendConfiguration SpecBackendConfiguration, providerConfigurationEndPoints *providerConfigurationEndPoints) schema.ConfigureFunc {
 	return func(data *schema.ResourceData) (interface{}, error) {
-		globalSecuritySchemes, err := p.specAnalyser.GetSecurity().GetGlobalSecuritySchemes()
+		globalSecurityRequirements, err := p.specAnalyser.GetSecurity().GetGlobalSecurityRequirements()
 		if err != nil {
 			return nil, err
 		}
-		authenticator := newAPIAuthenticator(&globalSecuritySchemes)
 		config, err := p.createProviderConfig(data, providerConfigurationEndPoints)
 		if err != nil {
 			return nil, err
 		}
+		globalSecuritySchemes := config.selectSecuritySchemes(globalSecurityRequirements)
+		authenticator := newAPIAuthenticator(&globalSecuritySchemes, p.logger)
+		clientTLS, err := newClientTLSConfiguration(data)
+		if err != nil {
+			return nil, err
+		}
+		transport, err := p.getHTTPTransport(clientTLS)
+		if err != nil {
+			return nil, err
+		}
+		// The access tokens are requested with the same transport as the API requests (so the TLS and proxy settings
+		// apply) but without the debug logging, so the client credentials are never logged
+		config.setOAuth2Transport(transport)
+		if err := config.requestOAuth2AccessTokens(); err != nil {
+			return nil, err
+		}
+		awsSigV4Configuration, err := p.createAWSSigV4Configuration(data, openAPIBackendConfiguration)
+		if err != nil {
+			return nil, err
+		}
+		httpClient := p.getHTTPClient(awsSigV4Configuration, transport)
+		if isHTTPDebugLoggingEnabled() {
+			sensitiveHeaders, sensitiveQueryParams := config.getSecurityDefinitionHeadersAndQueryParams()
+			httpClient.Transport = newHTTPDebugLoggingTransport(httpClient.Transport, sensitiveHeaders, sensitiveQueryParams, p.getSensitivePropertyNames(), p.logger)
+			p.getLogger().Debug("API requests and responses will be logged with the credentials redacted")
+		}
 		openAPIClient := &ProviderClient{
 			openAPIBackendConfiguration: openAPIBackendConfiguration,
 			apiAuthenticator:            authenticator,
-			httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+			httpClient:                  &http_goclient.HttpClient{HttpClient: httpClient},
 			providerConfiguration:       *config,
+			userAgentSuffix:             p.getUserAgentSuffix(),
+			retryConfiguration:          p.getRetryConfiguration(),
+			logger:                      p.logger,
+		}
+		// The health check validates the provider configuration (e,g: credentials) before any resource operation is
+		// performed, so misconfigurations are reported as soon as the provider is configured
+		if healthCheckPath := openAPIBackendConfiguration.getHealthCheckPath(); healthCheckPath != "" {
+			if err := openAPIClient.healthCheck(healthCheckPath); err != nil {
+				return nil, err
+			}
 		}
 		return openAPIClient, nil
 	}
 }
 
+// getLogger returns the logger configured in the provider factory or the default logger if none was provided
+func (p providerFactory) getLogger() Logger {
+	return loggerOrDefault(p.logger)
+}
+
+// getSensitivePropertyNames returns the names of the payload fields holding the values of the sensitive properties of
+// all the resources, so they can be redacted when logging the API requests and responses
+func (p providerFactory) getSensitivePropertyNames() []string {
+	var names []string
+	openAPIResources, err := p.specAnalyser.GetTerraformCompliantResources()
+	if err != nil {
+		return nil
+	}
+	for _, openAPIResource := range openAPIResources {
+		if resourceSchema, err := openAPIResource.getResourceSchema(); err == nil && resourceSchema != nil {
+			names = append(names, resourceSchema.getSensitivePropertyNames()...)
+		}
+	}
+	return names
+}
+
+// getHTTPClient returns the http client used to perform the API requests. If gzip compression is enabled in the service
+// configuration, the requests are compressed before the request interceptor (if any) is called so the interceptor sees
+// the final body sent to the API (e,g: to compute signatures). If the AWS SigV4 configuration is provided, the requests
+// are signed right before being sent so the signature covers the final body and headers. If a rate limit is configured,
+// the requests wait for the rate limiter before anything else so the rate limit is shared across all the API requests
+// performed by the provider instance. The given transport (see getHTTPTransport) performs the requests, nil means that
+// the default transport will be used
+func (p providerFactory) getHTTPClient(awsSigV4Configuration *awsSigV4Configuration, transport http.RoundTripper) *http.Client {
+	if awsSigV4Configuration != nil {
+		transport = newAWSSigV4Transport(*awsSigV4Configuration, transport)
+	}
+	httpClient := newHTTPClient(p.requestInterceptor, transport)
+	if p.serviceConfiguration != nil && p.serviceConfiguration.IsGzipCompressionEnabled() {
+		httpClient.Transport = newGzipTransport(httpClient.Transport)
+	}
+	if p.serviceConfiguration != nil && p.serviceConfiguration.GetRateLimitConfiguration() != nil {
+		httpClient.Transport = newRateLimitedTransport(httpClient.Transport, newTokenBucketRateLimiter(*p.serviceConfiguration.GetRateLimitConfiguration()))
+	}
+	return httpClient
+}
+
+// createAWSSigV4Configuration returns the AWS SigV4 configuration provided by the user in the provider's terraform
+// configuration, falling back to the region and service defined in the OpenAPI document for the values not provided
+func (p providerFactory) createAWSSigV4Configuration(data *schema.ResourceData, openAPIBackendConfiguration SpecBackendConfiguration) (*awsSigV4Configuration, error) {
+	scope, err := openAPIBackendConfiguration.getAWSSigV4Scope()
+	if err != nil {
+		return nil, err
+	}
+	awsSigV4Configuration, err := newAWSSigV4Configuration(data, scope)
+	if err != nil {
+		return nil, err
+	}
+	if awsSigV4Configuration != nil {
+		p.getLogger().Debug(fmt.Sprintf("API requests will be signed with AWS SigV4 (region: %s, service: %s)", awsSigV4Configuration.Region, awsSigV4Configuration.Service), "region", awsSigV4Configuration.Region, "service", awsSigV4Configuration.Service)
+	}
+	return awsSigV4Configuration, nil
+}
+
+// getHTTPTransport returns the service transport (see newServiceHTTPTransport) configured with the connection pooling,
+// insecure skip verify and TLS settings from the service configuration. If the client TLS configuration is provided by
+// the user, the transport will use it on top of the client certificate and CA bundle configured in the service
+// configuration (if any). If no settings are configured nil is returned, meaning that the default transport will be used
+func (p providerFactory) getHTTPTransport(clientTLS *clientTLSConfiguration) (http.RoundTripper, error) {
+	transport, err := newServiceHTTPTransport(p.serviceConfiguration, clientTLS)
+	if err != nil || transport == nil {
+		return nil, err
+	}
+	if clientTLS != nil {
+		p.getLogger().Debug("API requests will be performed using the client certificate and/or CA bundle configured in the provider")
+	}
+	return transport, nil
+}
+
+// isResourceAllowed checks whether the given resource name is allowed to be registered in the provider as per the
+// allowed and excluded resources configured in the service configuration. Excluded resources take preference over the
+// allowed ones, and if no allowed resources are configured, all resources not excluded are allowed
+func (p providerFactory) isResourceAllowed(resourceName string) bool {
+	if p.serviceConfiguration == nil {
+		return true
+	}
+	if matchesResourceNamePattern(resourceName, p.serviceConfiguration.GetExcludedResources()) {
+		return false
+	}
+	allowedResources := p.serviceConfiguration.GetAllowedResources()
+	if len(allowedResources) == 0 {
+		return true
+	}
+	return matchesResourceNamePattern(resourceName, allowedResources)
+}
+
+// matchesResourceNamePattern returns true if the resource name matches any of the given names or glob patterns (e,g: cdn_*)
+func matchesResourceNamePattern(resourceName string, resourceNamePatterns []string) bool {
+	for _, resourceNamePattern := range resourceNamePatterns {
+		if matched, _ := path.Match(resourceNamePattern, resourceName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getUserAgentSuffix returns the user agent suffix configured in the service configuration if any
+func (p providerFactory) getUserAgentSuffix() string {
+	if p.serviceConfiguration == nil {
+		return ""
+	}
+	return p.serviceConfiguration.GetUserAgentSuffix()
+}
+
+// getRetryConfiguration returns the retry policy configured in the service configuration if any
+func (p providerFactory) getRetryConfiguration() *RetryConfiguration {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	return p.serviceConfiguration.GetRetryConfiguration()
+}
+
+// getPollingConfiguration returns the polling settings configured in the service configuration if any
+func (p providerFactory) getPollingConfiguration() *PollingConfiguration {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	return p.serviceConfiguration.GetPollingConfiguration()
+}
+
 // createProviderConfig returns a providerConfiguration populated with:
 // - Header values that might be required by API operations
 // - Security definition values that might be required by API operations (or globally)
+// - Security definitions configured with a token command or a token file in the plugin configuration (and with no value
+// provided by the user) will get their value from the token command or the token file
 // configuration mapped to the corresponding
 func (p providerFactory) createProviderConfig(data *schema.ResourceData, providerConfigurationEndPoints *providerConfigurationEndPoints) (*providerConfiguration, error) {
 	providerConfiguration, err := newProviderConfiguration(p.specAnalyser, data, providerConfigurationEndPoints)
 	if err != nil {
 		return nil, err
 	}
+	if err := p.configureTokenCommandAuthenticators(data, providerConfiguration); err != nil {
+		return nil, err
+	}
 	return providerConfiguration, nil
 }
 
+// configureTokenCommandAuthenticators replaces the authenticators of the security definitions that have a token command
+// or a token file configured in the plugin configuration with token command authenticators. If the user provided a value
+// for the security definition in the terraform configuration, that value takes preference and the token command (or
+// token file) is not used.
+func (p providerFactory) configureTokenCommandAuthenticators(data *schema.ResourceData, providerConfiguration *providerConfiguration) error {
+	if p.serviceConfiguration == nil {
+		return nil
+	}
+	securityDefinitions, err := p.specAnalyser.GetSecurity().GetAPIKeySecurityDefinitions()
+	if err != nil {
+		return err
+	}
+	if securityDefinitions == nil {
+		return nil
+	}
+	for _, secDef := range *securityDefinitions {
+		secDefName := secDef.getTerraformConfigurationName()
+		schemaPropertyConfiguration := p.serviceConfiguration.GetSchemaPropertyConfiguration(secDefName)
+		if schemaPropertyConfiguration == nil {
+			continue
+		}
+		var source tokenSource
+		var sourceName string
+		if tokenCommandConfig := schemaPropertyConfiguration.GetTokenCommand(); tokenCommandConfig != nil {
+			source, sourceName = newTokenCommand(secDefName, *tokenCommandConfig, p.logger), "token command"
+		} else if tokenFileConfig := schemaPropertyConfiguration.GetTokenFile(); tokenFileConfig != nil {
+			source, sourceName = newTokenFile(secDefName, *tokenFileConfig, p.logger), "token file"
+		} else {
+			continue
+		}
+		if value, exists := data.GetOkExists(secDefName); exists && value.(string) != "" {
+			p.getLogger().Debug(fmt.Sprintf("security definition '%s' has a value configured, the %s will not be used", secDefName, sourceName), "property", secDefName)
+			continue
+		}
+		providerConfiguration.SecuritySchemaDefinitions[secDefName] = newAPITokenCommandAuthenticator(secDef, source)
+		p.getLogger().Debug(fmt.Sprintf("security definition '%s' configured to obtain its value from the %s", secDefName, sourceName), "property", secDefName)
+	}
+	return nil
+}
+
 func (p providerFactory) getProviderResourceName(resourceName string) (string, error) {
 	if resourceName == "" {
 		return "", fmt.Errorf("resource name can not be empty")
@@ -1,10 +1,21 @@
 package openapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/dikhan/http_goclient"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/stretchr/testify/assert"
 
@@ -156,6 +167,59 @@ func TestCreateProvider(t *testing.T) {
 		})
 	})
 
+	Convey("Given a provider factory with the GET-only data sources enabled", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				dataSourceInstances: []SpecResource{newSpecStubResource("regions_v1", "/v1/regions", false, &specSchemaDefinition{})},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{},
+				},
+				backendConfiguration: &specStubBackendConfiguration{},
+			},
+			serviceConfiguration: &ServiceConfigStub{GetOnlyDataSources: true},
+		}
+		Convey("When createProvider is called ", func() {
+			p, err := p.createProvider()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider returned should contain the GET-only data source instance registered", func() {
+				So(p.DataSourcesMap, ShouldContainKey, "provider_regions_v1_instance")
+			})
+			Convey("And the provider returned should not contain any resource", func() {
+				So(p.ResourcesMap, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a provider factory configured with a telemetry handler", t, func() {
+		telemetryHandler := telemetryHandlerTimeoutSupport{providerName: "provider", terraformVersion: &atomic.Value{}}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources:            []SpecResource{newSpecStubResource("resource_v1", "/v1/resource", false, &specSchemaDefinition{})},
+				security:             &specSecurityStub{securityDefinitions: &SpecSecurityDefinitions{}},
+				backendConfiguration: &specStubBackendConfiguration{},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+			telemetryHandler:     telemetryHandler,
+		}
+		Convey("When createProvider is called and the provider returned is configured by terraform", func() {
+			provider, err := p.createProvider()
+			So(err, ShouldBeNil)
+			provider.TerraformVersion = "0.12.29"
+			_, diags := provider.ConfigureContextFunc(context.Background(), schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{}))
+			Convey("Then the diagnostics returned should be nil", func() {
+				So(diags, ShouldBeNil)
+			})
+			Convey("And the telemetry handler should include the terraform version in the metric tags", func() {
+				So(telemetryHandler.getTags(), ShouldContainKey, telemetryTagTerraformVersion)
+				So(telemetryHandler.getTags()[telemetryTagTerraformVersion], ShouldEqual, "0.12.29")
+			})
+		})
+	})
+
 	Convey("Given a provider factory with multi-region backend configuration", t, func() {
 		apiKeyAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("apikey_auth", "", true, false, "someAuthValue")
 		headerProperty := newStringSchemaDefinitionPropertyWithDefaults("header_name", "", true, false, "someHeaderValue")
@@ -339,6 +403,108 @@ func TestCreateProvider(t *testing.T) {
 	})
 }
 
+func TestCreateProviderServer(t *testing.T) {
+	Convey("Given a provider factory with a resource marked to be served with protocol v6", t, func() {
+		objectSchemaDefinition := newTestSchema(newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil)).getSchemaDefinition()
+		protocolV6Resource := newSpecStubResource("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, objectSchemaDefinition),
+		).getSchemaDefinition())
+		protocolV6Resource.protocolV6 = true
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{
+					newSpecStubResource("resource_v1", "/v1/resource", false, newTestSchema(newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil)).getSchemaDefinition()),
+					protocolV6Resource,
+				},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{},
+				},
+				backendConfiguration: &specStubBackendConfiguration{},
+			},
+			serviceConfiguration: &ServiceConfigStub{ProtocolV6: true},
+		}
+		Convey("When createProviderServer is called", func() {
+			providerServer, frameworkResources, err := p.createProviderServer(context.Background())
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the protocol v6 resource should be returned as the only resource served by the framework", func() {
+				So(frameworkResources, ShouldHaveLength, 1)
+				So(frameworkResources[0].name, ShouldEqual, "provider_firewall_v1")
+			})
+			Convey("And the provider server schema should contain both resources", func() {
+				providerSchema, err := providerServer().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+				So(err, ShouldBeNil)
+				So(providerSchema.Diagnostics, ShouldBeEmpty)
+				So(providerSchema.ResourceSchemas, ShouldContainKey, "provider_resource_v1")
+				So(providerSchema.ResourceSchemas, ShouldContainKey, "provider_firewall_v1")
+				So(providerSchema.DataSourceSchemas, ShouldContainKey, "provider_firewall_v1_instance")
+			})
+			Convey("And the object property of the protocol v6 resource should be a nested attribute", func() {
+				providerSchema, err := providerServer().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+				So(err, ShouldBeNil)
+				var settings *tfprotov6.SchemaAttribute
+				for _, attribute := range providerSchema.ResourceSchemas["provider_firewall_v1"].Block.Attributes {
+					if attribute.Name == "settings" {
+						settings = attribute
+					}
+				}
+				So(settings, ShouldNotBeNil)
+				So(settings.NestedType, ShouldNotBeNil)
+				So(settings.NestedType.Nesting, ShouldEqual, tfprotov6.SchemaObjectNestingModeSingle)
+			})
+		})
+	})
+	Convey("Given a provider factory with a resource marked to be served with protocol v6 that uses asynchronous operations", t, func() {
+		objectSchemaDefinition := newTestSchema(newBoolSchemaDefinitionPropertyWithDefaults("enabled", "", false, false, nil)).getSchemaDefinition()
+		protocolV6Resource := newSpecStubResourceWithOperations("firewall_v1", "/v1/firewalls", false, newTestSchema(
+			newStringSchemaDefinitionPropertyWithDefaults("id", "", false, true, nil),
+			newObjectSchemaDefinitionPropertyWithDefaults("settings", "", false, false, false, nil, objectSchemaDefinition),
+		).getSchemaDefinition(), &specResourceOperation{
+			responses: specResponses{http.StatusAccepted: &specResponse{asyncOperation: &specAsyncOperation{locationHeader: "Location"}}},
+		}, nil, &specResourceOperation{}, nil)
+		protocolV6Resource.protocolV6 = true
+		logger := &loggerStub{}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{protocolV6Resource},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{},
+				},
+				backendConfiguration: &specStubBackendConfiguration{},
+			},
+			serviceConfiguration: &ServiceConfigStub{ProtocolV6: true},
+			logger:               logger,
+		}
+		Convey("When createProviderServer is called", func() {
+			providerServer, _, err := p.createProviderServer(context.Background())
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource should be served by the SDK provider so the object property is not a nested attribute", func() {
+				providerSchema, err := providerServer().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+				So(err, ShouldBeNil)
+				So(providerSchema.Diagnostics, ShouldBeEmpty)
+				So(providerSchema.ResourceSchemas, ShouldContainKey, "provider_firewall_v1")
+				var settings *tfprotov6.SchemaAttribute
+				for _, attribute := range providerSchema.ResourceSchemas["provider_firewall_v1"].Block.Attributes {
+					if attribute.Name == "settings" {
+						settings = attribute
+					}
+				}
+				So(settings, ShouldNotBeNil)
+				So(settings.NestedType, ShouldBeNil)
+			})
+			Convey("And a warning listing the features not supported with protocol v6 should be logged", func() {
+				So(logger.containsMessage("WARN", "'firewall_v1' uses features that are not supported with protocol v6 yet (x-terraform-async-operation), the resource is served by the SDK provider"), ShouldBeTrue)
+			})
+		})
+	})
+}
+
 func TestCreateValidateFunc(t *testing.T) {
 	Convey("Given a provider factory", t, func() {
 		p := providerFactory{}
@@ -441,7 +607,7 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 				{
 					SchemaPropertyName:   "apikey_auth",
 					ExecuteCommandCalled: false,
-					Err:                  fmt.Errorf(expectedError),
+					Err:                  errors.New(expectedError),
 				},
 			},
 		}
@@ -477,6 +643,39 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 		})
 	})
 
+	Convey("Given a provider factory that is configured with alternative global security requirements", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				headers: SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newAPIKeyHeaderSecurityDefinition("header_auth", authorizationHeader),
+						newAPIKeyQuerySecurityDefinition("query_auth", "api_key"),
+					},
+					globalSecuritySchemes:      createSecuritySchemes([]map[string][]string{{"header_auth": []string{""}}}),
+					globalSecurityRequirements: createSecurityRequirements([]map[string][]string{{"header_auth": []string{""}}, {"query_auth": []string{""}}}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+		Convey("When createTerraformProviderSchema is called", func() {
+			providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And none of the security definitions should be required since the user can choose which one to configure", func() {
+				So(providerSchema["header_auth"].Optional, ShouldBeTrue)
+				So(providerSchema["query_auth"].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional security_schemes property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertySecuritySchemes)
+				So(providerSchema[providerPropertySecuritySchemes].Type, ShouldEqual, schema.TypeList)
+				So(providerSchema[providerPropertySecuritySchemes].Optional, ShouldBeTrue)
+			})
+		})
+	})
+
 	Convey("Given a provider factory that is configured with security definitions that are not all part of the global schemes", t, func() {
 		var globalSecurityDefinitionName = "api_key_auth"
 		var otherSecurityDefinitionName = "other_security_definition_name"
@@ -581,6 +780,26 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 				So(providerSchema, ShouldContainKey, providerPropertyEndPoints)
 				So(providerSchema[providerPropertyEndPoints].Elem.(*schema.Resource).Schema, ShouldContainKey, "resourceName")
 			})
+			Convey("And the provider schema should contain the optional api base url property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyAPIBaseURL)
+				So(providerSchema[providerPropertyAPIBaseURL].Type, ShouldEqual, schema.TypeString)
+				So(providerSchema[providerPropertyAPIBaseURL].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional default query params map property", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyDefaultQueryParams)
+				So(providerSchema[providerPropertyDefaultQueryParams].Type, ShouldEqual, schema.TypeMap)
+				So(providerSchema[providerPropertyDefaultQueryParams].Optional, ShouldBeTrue)
+			})
+			Convey("And the provider schema should contain the optional aws sigv4 block property with sensitive credentials", func() {
+				So(providerSchema, ShouldContainKey, providerPropertyAWSSigV4)
+				So(providerSchema[providerPropertyAWSSigV4].Type, ShouldEqual, schema.TypeList)
+				So(providerSchema[providerPropertyAWSSigV4].Optional, ShouldBeTrue)
+				So(providerSchema[providerPropertyAWSSigV4].MaxItems, ShouldEqual, 1)
+				awsSigV4Properties := providerSchema[providerPropertyAWSSigV4].Elem.(*schema.Resource).Schema
+				So(awsSigV4Properties[awsSigV4PropertyAccessKey].Sensitive, ShouldBeTrue)
+				So(awsSigV4Properties[awsSigV4PropertySecretKey].Sensitive, ShouldBeTrue)
+				So(awsSigV4Properties[awsSigV4PropertySessionToken].Sensitive, ShouldBeTrue)
+			})
 		})
 	})
 	Convey("Given a provider factory (testing endpoints)", t, func() {
@@ -607,6 +826,37 @@ func TestCreateTerraformProviderSchema(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a provider factory containing a global oauth2 client credentials security definition", t, func() {
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				resources: []SpecResource{},
+				headers:   SpecHeaderParameters{},
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", "https://api.iam.com/oauth2/token", nil),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{{"oauth2_auth": []string{}}}),
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{},
+		}
+		Convey("When createTerraformProviderSchema is called", func() {
+			providerSchema, err := p.createTerraformProviderSchema(&specStubBackendConfiguration{}, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider schema should contain the required client credentials properties instead of the security definition property", func() {
+				So(providerSchema, ShouldNotContainKey, "oauth2_auth")
+				So(providerSchema, ShouldContainKey, "oauth2_auth_client_id")
+				So(providerSchema, ShouldContainKey, "oauth2_auth_client_secret")
+				So(providerSchema["oauth2_auth_client_id"].Required, ShouldBeTrue)
+				So(providerSchema["oauth2_auth_client_secret"].Required, ShouldBeTrue)
+				So(providerSchema["oauth2_auth_client_id"].Sensitive, ShouldBeFalse)
+				So(providerSchema["oauth2_auth_client_secret"].Sensitive, ShouldBeTrue)
+			})
+		})
+	})
 }
 
 func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
@@ -685,6 +935,56 @@ func TestConfigureProviderPropertyFromPluginConfig(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("Given a provider factory containing a schema property configured with a token command", t, func() {
+		serviceConfig := &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName: "bearer_auth",
+					TokenCommand:       &TokenCommandConfiguration{Command: []string{"echo", "someToken"}},
+				},
+			},
+		}
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{},
+			serviceConfiguration: serviceConfig,
+		}
+		Convey("When configureProviderPropertyFromPluginConfig is called with a required property", func() {
+			providerSchema := map[string]*schema.Schema{}
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "bearer_auth", true)
+			Convey("Then the provider schema property should be optional since the value will be obtained from the token command", func() {
+				So(providerSchema, ShouldContainKey, "bearer_auth")
+				So(providerSchema["bearer_auth"].Required, ShouldBeFalse)
+				So(providerSchema["bearer_auth"].Optional, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a provider factory containing a schema property configured with a token file", t, func() {
+		serviceConfig := &ServiceConfigStub{
+			SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+				{
+					SchemaPropertyName: "bearer_auth",
+					TokenFile:          &TokenFileConfiguration{File: "/var/run/secrets/token"},
+				},
+			},
+		}
+		p := providerFactory{
+			name:                 "provider",
+			specAnalyser:         &specAnalyserStub{},
+			serviceConfiguration: serviceConfig,
+		}
+		Convey("When configureProviderPropertyFromPluginConfig is called with a required property", func() {
+			providerSchema := map[string]*schema.Schema{}
+			p.configureProviderPropertyFromPluginConfig(providerSchema, "bearer_auth", true)
+			Convey("Then the provider schema property should be optional since the value will be obtained from the token file", func() {
+				So(providerSchema, ShouldContainKey, "bearer_auth")
+				So(providerSchema["bearer_auth"].Required, ShouldBeFalse)
+				So(providerSchema["bearer_auth"].Optional, ShouldBeTrue)
+			})
+		})
+	})
 }
 
 func TestConfigureProvider(t *testing.T) {
@@ -724,6 +1024,152 @@ func TestConfigureProvider(t *testing.T) {
 				var _ ClientOpenAPI = providerClient
 			})
 		})
+		Convey("When configureProvider is called with a service configuration containing a user agent suffix and the returned configureFunc is invoked upon ", func() {
+			p.serviceConfiguration = &ServiceConfigStub{UserAgentSuffix: "acme-cli/1.2"}
+			backendConfig := &specStubBackendConfiguration{}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should be configured with the user agent suffix", func() {
+				So(client.(*ProviderClient).userAgentSuffix, ShouldEqual, "acme-cli/1.2")
+			})
+		})
+		Convey("When configureProvider is called with a service configuration containing connection pooling settings and the returned configureFunc is invoked upon ", func() {
+			p.serviceConfiguration = &ServiceConfigStub{HTTPTransport: HTTPTransportConfiguration{MaxIdleConnsPerHost: 100}}
+			backendConfig := &specStubBackendConfiguration{}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should be configured with a transport containing the connection pooling settings", func() {
+				httpClient := client.(*ProviderClient).httpClient.(*http_goclient.HttpClient)
+				So(httpClient.HttpClient.Transport.(*http.Transport).MaxIdleConnsPerHost, ShouldEqual, 100)
+			})
+		})
+		Convey("When configureProvider is called with a service configuration with gzip compression enabled and the returned configureFunc is invoked upon ", func() {
+			p.serviceConfiguration = &ServiceConfigStub{GzipCompression: true, HTTPTransport: HTTPTransportConfiguration{MaxIdleConnsPerHost: 100}}
+			backendConfig := &specStubBackendConfiguration{}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should be configured with the gzip transport delegating to the transport containing the connection pooling settings", func() {
+				httpClient := client.(*ProviderClient).httpClient.(*http_goclient.HttpClient)
+				So(httpClient.HttpClient.Transport, ShouldHaveSameTypeAs, &gzipTransport{})
+				So(httpClient.HttpClient.Transport.(*gzipTransport).next.(*http.Transport).MaxIdleConnsPerHost, ShouldEqual, 100)
+			})
+		})
+		Convey("When configureProvider is called with the aws sigv4 configuration and the returned configureFunc is invoked upon ", func() {
+			p.serviceConfiguration = &ServiceConfigStub{GzipCompression: true}
+			backendConfig := &specStubBackendConfiguration{awsSigV4Scope: &awsSigV4Scope{Region: "us-west-2", Service: "execute-api"}}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyAWSSigV4: awsSigV4Schema()}, map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{awsSigV4PropertyAccessKey: "AKID", awsSigV4PropertySecretKey: "SECRET"}},
+			})
+			client, err := configureFunc(data)
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should be configured with the gzip transport delegating to the aws sigv4 transport so the signature covers the compressed body", func() {
+				httpClient := client.(*ProviderClient).httpClient.(*http_goclient.HttpClient)
+				So(httpClient.HttpClient.Transport, ShouldHaveSameTypeAs, &gzipTransport{})
+				awsSigV4 := httpClient.HttpClient.Transport.(*gzipTransport).next.(*awsSigV4Transport)
+				So(awsSigV4.region, ShouldEqual, "us-west-2")
+				So(awsSigV4.service, ShouldEqual, "execute-api")
+			})
+		})
+		Convey("When configureProvider is called with an aws sigv4 configuration missing the region and the returned configureFunc is invoked upon ", func() {
+			backendConfig := &specStubBackendConfiguration{}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			data := schema.TestResourceDataRaw(t, map[string]*schema.Schema{providerPropertyAWSSigV4: awsSigV4Schema()}, map[string]interface{}{
+				providerPropertyAWSSigV4: []interface{}{map[string]interface{}{awsSigV4PropertyAccessKey: "AKID", awsSigV4PropertySecretKey: "SECRET", awsSigV4PropertyService: "execute-api"}},
+			})
+			_, err := configureFunc(data)
+			Convey("Then error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "property 'aws_sigv4' is missing the 'region' value and the OpenAPI document does not define it in the 'x-terraform-aws-sigv4' extension")
+			})
+		})
+		Convey("When configureProvider is called with a backend configured with a health check and the returned configureFunc is invoked upon ", func() {
+			var receivedAuthHeaders []string
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				receivedAuthHeaders = append(receivedAuthHeaders, r.Header.Get(authorizationHeader))
+				if r.URL.Path != "/me" || r.Header.Get(authorizationHeader) != "someAuthValue" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer api.Close()
+			backendConfig := &specStubBackendConfiguration{host: strings.TrimPrefix(api.URL, "http://"), httpScheme: "http", healthCheckPath: "/me"}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+				So(client, ShouldNotBeNil)
+			})
+			Convey("And the health check request should have been sent with the configured credentials", func() {
+				So(receivedAuthHeaders, ShouldResemble, []string{"someAuthValue"})
+			})
+		})
+		Convey("When configureProvider is called with a backend configured with a health check that rejects the credentials and the returned configureFunc is invoked upon ", func() {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer api.Close()
+			backendConfig := &specStubBackendConfiguration{host: strings.TrimPrefix(api.URL, "http://"), httpScheme: "http", healthCheckPath: "/me"}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then the configuration should fail with the expected auth error", func() {
+				So(client, ShouldBeNil)
+				So(err.Error(), ShouldEqual, "provider health check GET "+api.URL+"/me failed with HTTP Response Status Code 403: the API rejected the credentials, please make sure the authentication properties configured in the provider are valid")
+			})
+		})
+		Convey("When configureProvider is called with a provider factory configured with a logger and the returned configureFunc is invoked upon ", func() {
+			logger := &loggerStub{}
+			p.logger = logger
+			backendConfig := &specStubBackendConfiguration{}
+			configureFunc := p.configureProvider(backendConfig, &providerConfigurationEndPoints{})
+			client, err := configureFunc(testProviderSchema.getResourceData(t))
+			Convey("Then error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client and its authenticator should be configured with the logger", func() {
+				So(client.(*ProviderClient).logger, ShouldEqual, logger)
+				So(client.(*ProviderClient).apiAuthenticator.(apiAuth).logger, ShouldEqual, logger)
+			})
+		})
+	})
+
+	Convey("Given a provider factory containing an oauth2 client credentials security definition configured with the wrong client credentials", t, func() {
+		requests := 0
+		server := newOAuth2TokenTestServer(t, &requests, `{"access_token":"someAccessToken"}`)
+		defer server.Close()
+		clientIDProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_id", "", false, false, "someClientID")
+		clientSecretProperty := newStringSchemaDefinitionPropertyWithDefaults("oauth2_auth_client_secret", "", false, false, "wrongClientSecret")
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				security: &specSecurityStub{
+					securityDefinitions: &SpecSecurityDefinitions{
+						newOAuth2ClientCredentialsSecurityDefinition("oauth2_auth", server.URL, []string{"read", "write"}),
+					},
+					globalSecuritySchemes: createSecuritySchemes([]map[string][]string{}),
+				},
+			},
+		}
+		Convey("When configureProvider is called and the returned configureFunc is invoked", func() {
+			configureFunc := p.configureProvider(&specStubBackendConfiguration{}, &providerConfigurationEndPoints{})
+			_, err := configureFunc(newTestSchema(clientIDProperty, clientSecretProperty).getResourceData(t))
+			Convey("Then the error returned should be the access token request failure", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, fmt.Sprintf("oauth2 token POST response '%s' status code '401'", server.URL))
+				So(requests, ShouldEqual, 1)
+			})
+		})
 	})
 }
 
@@ -857,6 +1303,98 @@ func TestCreateProviderConfig(t *testing.T) {
 		})
 	})
 
+	Convey("Given a provider factory configured with a bearer security definition that has a token command in the plugin configuration", t, func() {
+		bearerAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("bearer_auth", "", true, false, "")
+		expectedSecurityDefinitions := SpecSecurityDefinitions{
+			newAPIKeyHeaderBearerSecurityDefinition(bearerAuthProperty.Name),
+		}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				security: &specSecurityStub{
+					securityDefinitions: &expectedSecurityDefinitions,
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{
+				SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+					{
+						SchemaPropertyName: bearerAuthProperty.Name,
+						TokenCommand:       &TokenCommandConfiguration{Command: []string{"echo", "someToken"}, Timeout: 5 * time.Second},
+					},
+				},
+			},
+		}
+		Convey("When createProviderConfig is called with a resource data that does not contain a value for the security definition", func() {
+			testProviderSchema := newTestSchema(bearerAuthProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider configuration returned should contain a token command authenticator for the security definition", func() {
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name], ShouldHaveSameTypeAs, &apiTokenCommandAuthenticator{})
+			})
+			Convey("And the authenticator should use the token returned by the token command", func() {
+				ctx := &authContext{headers: map[string]string{}}
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name].prepareAuth(ctx), ShouldBeNil)
+				So(ctx.headers[authorizationHeader], ShouldEqual, "Bearer someToken")
+			})
+		})
+		Convey("When createProviderConfig is called with a resource data that contains a value for the security definition", func() {
+			bearerAuthProperty.Default = "someUserProvidedToken"
+			testProviderSchema := newTestSchema(bearerAuthProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the provider configuration returned should use the value provided by the user instead of the token command", func() {
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name], ShouldNotHaveSameTypeAs, &apiTokenCommandAuthenticator{})
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name].getContext().(apiKey).value, ShouldEqual, "Bearer someUserProvidedToken")
+			})
+		})
+	})
+}
+
+func TestCreateProviderConfigWithTokenFile(t *testing.T) {
+	Convey("Given a provider factory configured with a bearer security definition that has a token file in the plugin configuration", t, func() {
+		tokenFile, err := ioutil.TempFile("", "token_file")
+		So(err, ShouldBeNil)
+		defer os.Remove(tokenFile.Name())
+		So(ioutil.WriteFile(tokenFile.Name(), []byte("someToken\n"), 0600), ShouldBeNil)
+
+		bearerAuthProperty := newStringSchemaDefinitionPropertyWithDefaults("bearer_auth", "", true, false, "")
+		expectedSecurityDefinitions := SpecSecurityDefinitions{
+			newAPIKeyHeaderBearerSecurityDefinition(bearerAuthProperty.Name),
+		}
+		p := providerFactory{
+			name: "provider",
+			specAnalyser: &specAnalyserStub{
+				security: &specSecurityStub{
+					securityDefinitions: &expectedSecurityDefinitions,
+				},
+			},
+			serviceConfiguration: &ServiceConfigStub{
+				SchemaConfiguration: []*ServiceSchemaPropertyConfigurationStub{
+					{
+						SchemaPropertyName: bearerAuthProperty.Name,
+						TokenFile:          &TokenFileConfiguration{File: tokenFile.Name()},
+					},
+				},
+			},
+		}
+		Convey("When createProviderConfig is called with a resource data that does not contain a value for the security definition", func() {
+			testProviderSchema := newTestSchema(bearerAuthProperty)
+			providerConfiguration, err := p.createProviderConfig(testProviderSchema.getResourceData(t), &providerConfigurationEndPoints{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the authenticator should use the token read from the token file", func() {
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name], ShouldHaveSameTypeAs, &apiTokenCommandAuthenticator{})
+				ctx := &authContext{headers: map[string]string{}}
+				So(providerConfiguration.SecuritySchemaDefinitions[bearerAuthProperty.Name].prepareAuth(ctx), ShouldBeNil)
+				So(ctx.headers[authorizationHeader], ShouldEqual, "Bearer someToken")
+			})
+		})
+	})
 }
 
 func TestGetProviderResourceName(t *testing.T) {
@@ -953,6 +1491,25 @@ func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap(t *testing.T
 	}
 }
 
+func TestCreateTerraformProviderResourceMapAndDataSourceInstanceMap_multi_region(t *testing.T) {
+	hostOverrideResource := newSpecStubResource("host_override", "/v1/host_override", false, &specSchemaDefinition{})
+	hostOverrideResource.host = "some.other.host.com"
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			resources: []SpecResource{
+				newSpecStubResource("resource", "/v1/resource", false, &specSchemaDefinition{}),
+				hostOverrideResource,
+			},
+			backendConfiguration: &specStubBackendConfiguration{host: "www.%s.host.com", regions: []string{"rst1", "dub1"}},
+		},
+	}
+	resourceMap, _, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	assert.Nil(t, err)
+	assert.Contains(t, resourceMap["provider_resource"].Schema, "region", "regional resources should expose the region property")
+	assert.NotContains(t, resourceMap["provider_host_override"].Schema, "region", "resources overriding the host should not expose the region property")
+}
+
 func TestCreateTerraformProviderDataSourceInstanceMap_ignore_resource(t *testing.T) {
 	p := providerFactory{
 		name: "provider",
@@ -1036,3 +1593,152 @@ func TestCreateTerraformProviderDataSourceMap(t *testing.T) {
 	}
 
 }
+
+func TestCreateTerraformProviderGetOnlyDataSourceInstanceMap(t *testing.T) {
+
+	testcases := []struct {
+		name                 string
+		specV2stub           SpecAnalyser
+		expectedResourceName string
+		expectedError        string
+	}{
+		{
+			name: "happy path",
+			specV2stub: &specAnalyserStub{
+				dataSourceInstances: []SpecResource{newSpecStubResource("regions_v1", "/v1/regions", false, &specSchemaDefinition{})},
+			},
+			expectedResourceName: "provider_regions_v1_instance",
+		},
+		{
+			name: "createTerraformInstanceDataSource fails",
+			specV2stub: &specAnalyserStub{
+				dataSourceInstances: []SpecResource{&specStubResource{
+					name: "hello",
+					funcGetResourceSchema: func() (*specSchemaDefinition, error) {
+						return nil, errors.New("createTerraformInstanceDataSource failed")
+					},
+				}},
+			},
+			expectedError: "createTerraformInstanceDataSource failed",
+		},
+	}
+
+	for _, tc := range testcases {
+		p := providerFactory{
+			name:         "provider",
+			specAnalyser: tc.specV2stub,
+		}
+		schemaResource, err := p.createTerraformProviderGetOnlyDataSourceInstanceMap()
+
+		if tc.expectedError == "" {
+			assert.Nil(t, err)
+			assert.Contains(t, schemaResource, tc.expectedResourceName, tc.name)
+		} else {
+			assert.EqualError(t, err, tc.expectedError)
+		}
+	}
+}
+
+func TestCreateTerraformProviderDataSourceMap_ignore_resource(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			dataSources: []SpecResource{
+				newSpecStubResource("resource", "/v1/resource", true, &specSchemaDefinition{}),
+			},
+		},
+	}
+	dataSourceMap, err := p.createTerraformProviderDataSourceMap()
+	assert.Nil(t, err)
+	assert.Empty(t, dataSourceMap)
+}
+
+func TestCreateTerraformProviderMaps_allowed_resources(t *testing.T) {
+	p := providerFactory{
+		name: "provider",
+		specAnalyser: &specAnalyserStub{
+			resources: []SpecResource{
+				newSpecStubResource("allowed", "/v1/allowed", false, &specSchemaDefinition{}),
+				newSpecStubResource("not_allowed", "/v1/not_allowed", false, &specSchemaDefinition{}),
+			},
+			dataSources: []SpecResource{
+				newSpecStubResource("allowed", "/v1/allowed", false, &specSchemaDefinition{}),
+				newSpecStubResource("not_allowed", "/v1/not_allowed", false, &specSchemaDefinition{}),
+			},
+		},
+		serviceConfiguration: &ServiceConfigStub{AllowedResources: []string{"allowed"}},
+	}
+	resourceMap, dataSourceInstanceMap, err := p.createTerraformProviderResourceMapAndDataSourceInstanceMap()
+	assert.Nil(t, err)
+	assert.Len(t, resourceMap, 1)
+	assert.Contains(t, resourceMap, "provider_allowed")
+	assert.Len(t, dataSourceInstanceMap, 1)
+	assert.Contains(t, dataSourceInstanceMap, "provider_allowed_instance")
+
+	dataSourceMap, err := p.createTerraformProviderDataSourceMap()
+	assert.Nil(t, err)
+	assert.Len(t, dataSourceMap, 1)
+	assert.Contains(t, dataSourceMap, "provider_allowed")
+}
+
+func TestIsResourceAllowed(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		serviceConfiguration ServiceConfiguration
+		resourceName         string
+		expectedResult       bool
+	}{
+		{
+			name:                 "nil service configuration allows all resources",
+			serviceConfiguration: nil,
+			resourceName:         "cdn_v1",
+			expectedResult:       true,
+		},
+		{
+			name:                 "service configuration without allowed resources allows all resources",
+			serviceConfiguration: &ServiceConfigStub{},
+			resourceName:         "cdn_v1",
+			expectedResult:       true,
+		},
+		{
+			name:                 "resource listed in the allowed resources",
+			serviceConfiguration: &ServiceConfigStub{AllowedResources: []string{"lb_v1", "cdn_v1"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       true,
+		},
+		{
+			name:                 "resource not listed in the allowed resources",
+			serviceConfiguration: &ServiceConfigStub{AllowedResources: []string{"lb_v1"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       false,
+		},
+		{
+			name:                 "resource matching a pattern of the allowed resources",
+			serviceConfiguration: &ServiceConfigStub{AllowedResources: []string{"lb_v1", "cdn_*"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       true,
+		},
+		{
+			name:                 "resource matching a pattern of the excluded resources",
+			serviceConfiguration: &ServiceConfigStub{ExcludedResources: []string{"*_v1"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       false,
+		},
+		{
+			name:                 "resource not matching the excluded resources",
+			serviceConfiguration: &ServiceConfigStub{ExcludedResources: []string{"*_v2"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       true,
+		},
+		{
+			name:                 "excluded resources take preference over the allowed resources",
+			serviceConfiguration: &ServiceConfigStub{AllowedResources: []string{"cdn_*"}, ExcludedResources: []string{"cdn_v1"}},
+			resourceName:         "cdn_v1",
+			expectedResult:       false,
+		},
+	}
+	for _, tc := range testCases {
+		p := providerFactory{serviceConfiguration: tc.serviceConfiguration}
+		assert.Equal(t, tc.expectedResult, p.isResourceAllowed(tc.resourceName), tc.name)
+	}
+}
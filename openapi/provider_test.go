@@ -6,9 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -227,10 +228,10 @@ definitions:
 						So(tfProvider.ResourcesMap[resourceName].Schema["label"].Computed, ShouldBeFalse)
 					})
 					Convey("the provider cdn resource should have the expected operations configured", func() {
-						So(tfProvider.ResourcesMap[resourceName].Create, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Read, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Update, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Delete, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].CreateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].UpdateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].DeleteContext, ShouldNotBeNil)
 						So(tfProvider.ResourcesMap[resourceName].Importer, ShouldNotBeNil)
 					})
 				})
@@ -249,10 +250,10 @@ definitions:
 						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["label"].Computed, ShouldBeTrue)
 					})
 					Convey("the provider cdn data source instance should have the expected operations configured", func() {
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Create, ShouldBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Read, ShouldNotBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Update, ShouldBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Delete, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].CreateContext, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].UpdateContext, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].DeleteContext, ShouldBeNil)
 						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Importer, ShouldBeNil)
 					})
 				})
@@ -269,10 +270,10 @@ definitions:
 						So(tfProvider.ResourcesMap[resourceName].Schema["name"].Computed, ShouldBeFalse)
 					})
 					Convey("the provider cdn resource should have the expected operations configured", func() {
-						So(tfProvider.ResourcesMap[resourceName].Create, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Read, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Update, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Delete, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].CreateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].UpdateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].DeleteContext, ShouldNotBeNil)
 						So(tfProvider.ResourcesMap[resourceName].Importer, ShouldNotBeNil)
 					})
 				})
@@ -291,15 +292,15 @@ definitions:
 						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Schema["name"].Computed, ShouldBeTrue)
 					})
 					Convey("the provider cdn data source instance should have the expected operations configured", func() {
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Create, ShouldBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Read, ShouldNotBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Update, ShouldBeNil)
-						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Delete, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].CreateContext, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].UpdateContext, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceInstanceName].DeleteContext, ShouldBeNil)
 						So(tfProvider.DataSourcesMap[dataSourceInstanceName].Importer, ShouldBeNil)
 					})
 				})
 				Convey("the provider configuration function should not be nil", func() {
-					So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+					So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 				})
 			})
 		})
@@ -409,9 +410,9 @@ definitions:
 						So(elements["values"].Type, ShouldEqual, schema.TypeList)
 					})
 					Convey("the provider cdn-datasource data source should have only the READ operation configured", func() {
-						So(tfProvider.DataSourcesMap[resourceName].Read, ShouldNotBeNil)
-						So(tfProvider.DataSourcesMap[resourceName].Create, ShouldBeNil)
-						So(tfProvider.DataSourcesMap[resourceName].Delete, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[resourceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.DataSourcesMap[resourceName].CreateContext, ShouldBeNil)
+						So(tfProvider.DataSourcesMap[resourceName].DeleteContext, ShouldBeNil)
 					})
 
 					Convey("and the provider resource map must be nil as no resources are configured in the swagger", func() {
@@ -419,7 +420,7 @@ definitions:
 					})
 				})
 				Convey("the provider configuration function should not be nil", func() {
-					So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+					So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 				})
 			})
 		})
@@ -498,7 +499,7 @@ definitions:
 						So(elements["values"].Type, ShouldEqual, schema.TypeList)
 					})
 					Convey("the provider cdn-datasource data source should have only the READ operation configured", func() {
-						So(tfProvider.DataSourcesMap[dataSourceName].Read, ShouldNotBeNil)
+						So(tfProvider.DataSourcesMap[dataSourceName].ReadContext, ShouldNotBeNil)
 					})
 
 					Convey("and the provider resource map must be nil as no resources are configured in the swagger", func() {
@@ -506,7 +507,7 @@ definitions:
 					})
 				})
 				Convey("the provider configuration function should not be nil", func() {
-					So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+					So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 				})
 			})
 		})
@@ -605,19 +606,18 @@ definitions:
 						assertTerraformSchemaProperty(t, nestedObject.Elem.(*schema.Resource).Schema["name"], schema.TypeString, false, true)
 						assertTerraformSchemaProperty(t, nestedObject.Elem.(*schema.Resource).Schema["object_property"], schema.TypeMap, false, false)
 						object := nestedObject.Elem.(*schema.Resource).Schema["object_property"]
-						assertTerraformSchemaProperty(t, object.Elem.(*schema.Resource).Schema["account"], schema.TypeString, false, false)
-						assertTerraformSchemaProperty(t, object.Elem.(*schema.Resource).Schema["read_only"], schema.TypeString, false, true)
+						So(object.Elem.(*schema.Schema).Type, ShouldEqual, schema.TypeString)
 					})
 					Convey("the provider cdn resource should have the expected operations configured", func() {
-						So(tfProvider.ResourcesMap[resourceName].Create, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Read, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Update, ShouldNotBeNil)
-						So(tfProvider.ResourcesMap[resourceName].Delete, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].CreateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].ReadContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].UpdateContext, ShouldNotBeNil)
+						So(tfProvider.ResourcesMap[resourceName].DeleteContext, ShouldNotBeNil)
 						So(tfProvider.ResourcesMap[resourceName].Importer, ShouldNotBeNil)
 					})
 				})
 				Convey("the provider configuration function should not be nil", func() {
-					So(tfProvider.ConfigureFunc, ShouldNotBeNil)
+					So(tfProvider.ConfigureContextFunc, ShouldNotBeNil)
 				})
 			})
 		})
@@ -727,16 +727,6 @@ definitions:
 				preferredName1:  "collision",
 				preferredName2:  "collision",
 				expectedWarning: "'collision_v1' is a duplicate resource name and is being removed from the provider"},
-			{label: "resources with colliding x-terraform-resource-name calculated name and calculated versioned name",
-				path1:           "/v1/collision",
-				path2:           "/xyz",
-				preferredName2:  "collision_v1",
-				expectedWarning: "'collision_v1' is a duplicate resource name and is being removed from the provider"},
-			{label: "resources with colliding x-terraform-resource-name calculated name and calculated versioned name",
-				path1:           "/v1/collision",
-				path2:           "/v1/xyz",
-				preferredName2:  "collision",
-				expectedWarning: "'collision_v1' is a duplicate resource name and is being removed from the provider"},
 			{label: "resources with colliding calculated names",
 				path1:           "/v1/collision",
 				path2:           "/collision_v1",
@@ -758,6 +748,38 @@ definitions:
 		}
 	})
 
+	Convey("Given a swagger doc that declares resources with colliding names where only one of them has the name set with the x-terraform-resource-name extension, "+
+		"When CreateSchemaProviderWithConfiguration is called, "+
+		"Then there should be no error and the provider should only have the resource with the x-terraform-resource-name extension and a warning should be logged", t, func() {
+
+		testcases := []struct {
+			label          string
+			path2          string
+			preferredName2 string
+		}{
+			{label: "resources with colliding x-terraform-resource-name calculated name and calculated versioned name",
+				path2:          "/xyz",
+				preferredName2: "collision_v1"},
+			{label: "resources with colliding x-terraform-resource-name calculated versioned name and calculated versioned name",
+				path2:          "/v1/xyz",
+				preferredName2: "collision"},
+		}
+
+		for _, tc := range testcases {
+			out := newTestWriter()
+			log.SetOutput(out)
+
+			p := ProviderOpenAPI{ProviderName: "something"}
+			swaggerDoc := makeSwaggerDoc("/v1/collision", "", tc.path2, tc.preferredName2, false)
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerDocServerURL(swaggerDoc)})
+
+			So(err, ShouldBeNil)
+			So(len(tfProvider.ResourcesMap), ShouldEqual, 1)
+			So(tfProvider.ResourcesMap, ShouldContainKey, "something_collision_v1")
+			So(out.written, ShouldContainSubstring, "keeping the resource with rootPath='"+tc.path2+"'")
+		}
+	})
+
 	Convey("Given a swagger doc that declares resources identical paths and colliding names preferred names, "+
 		"When CreateSchemaProviderWithConfiguration is called, "+
 		"Then there will be no error and the provider will have one of those resources (indeterminately selected) and no warning will be logged", t, func() {
@@ -834,7 +856,7 @@ func TestGetServiceConfiguration(t *testing.T) {
 		os.Setenv(fmt.Sprintf(otfVarSwaggerURL, providerName), expectedSwaggerURL)
 		os.Setenv(otfVarInsecureSkipVerify, "false")
 		Convey("When getServiceConfiguration method is called", func() {
-			serviceConfiguration, err := getServiceConfiguration(providerName)
+			serviceConfiguration, _, err := getServiceConfiguration(providerName, nil)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -847,3 +869,235 @@ func TestGetServiceConfiguration(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateSchemaProviderWithExcludedResources(t *testing.T) {
+	swaggerDoc := `swagger: "2.0"
+paths:
+  /v1/cdns:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/whatever"
+    post:
+      x-terraform-exclude-resource: true
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/whatever"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/whatever"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/whatever"
+  /v1/lbs:
+    get:
+      responses:
+        200:
+          schema:
+            type: "array"
+            items:
+              $ref: "#/definitions/whatever"
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/whatever"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/whatever"
+  /v1/lbs/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/whatever"
+  /v1/monitors:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/whatever"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/whatever"
+  /v1/monitors/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/whatever"
+definitions:
+  whatever:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true`
+	swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(swaggerDoc))
+	}))
+	defer swaggerServer.Close()
+
+	Convey("Given a swagger doc containing a resource marked with x-terraform-exclude-resource and other compliant resources", t, func() {
+		p := ProviderOpenAPI{ProviderName: "provider"}
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called", func() {
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the excluded resource and its data sources should not be registered in the provider", func() {
+				So(tfProvider.ResourcesMap, ShouldNotContainKey, "provider_cdns_v1")
+				So(tfProvider.DataSourcesMap, ShouldNotContainKey, "provider_cdns_v1")
+				So(tfProvider.DataSourcesMap, ShouldNotContainKey, "provider_cdns_v1_instance")
+			})
+			Convey("And the rest of the resources and their data sources should be registered in the provider", func() {
+				So(tfProvider.ResourcesMap, ShouldContainKey, "provider_lbs_v1")
+				So(tfProvider.DataSourcesMap, ShouldContainKey, "provider_lbs_v1")
+				So(tfProvider.DataSourcesMap, ShouldContainKey, "provider_lbs_v1_instance")
+				So(tfProvider.ResourcesMap, ShouldContainKey, "provider_monitors_v1")
+			})
+		})
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called with a service configuration containing allowed resources", func() {
+			tfProvider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, AllowedResources: []string{"lbs_v1"}})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And only the allowed resource and its data sources should be registered in the provider", func() {
+				So(len(tfProvider.ResourcesMap), ShouldEqual, 1)
+				So(tfProvider.ResourcesMap, ShouldContainKey, "provider_lbs_v1")
+				So(len(tfProvider.DataSourcesMap), ShouldEqual, 2)
+				So(tfProvider.DataSourcesMap, ShouldContainKey, "provider_lbs_v1")
+				So(tfProvider.DataSourcesMap, ShouldContainKey, "provider_lbs_v1_instance")
+			})
+		})
+	})
+}
+
+func TestCreateSchemaProviderFromServiceConfigurationWithSwaggerSHA256(t *testing.T) {
+	Convey("Given a swagger document served by an API and its SHA-256 checksum", t, func() {
+		swaggerDoc := `swagger: "2.0"
+paths: {}`
+		swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(swaggerDoc))
+		}))
+		defer swaggerServer.Close()
+		documentSHA256 := "ea6d3bc6d190406e2c4cbc9dc7aa252f2208dc0085106af84be4a30050061c06"
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called with the checksum of the document in upper case", func() {
+			p := ProviderOpenAPI{ProviderName: "something"}
+			_, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, SwaggerSHA256: strings.ToUpper(documentSHA256)})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called with a checksum that does not match the document", func() {
+			p := ProviderOpenAPI{ProviderName: "something"}
+			expectedSHA256 := strings.Repeat("0", 64)
+			_, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{SwaggerURL: swaggerServer.URL, SwaggerSHA256: expectedSHA256})
+			Convey("Then the error returned should explain the mismatch", func() {
+				So(err.Error(), ShouldEqual, "plugin OpenAPI spec analyser error: the OpenAPI document retrieved from '"+swaggerServer.URL+"' does not match the swagger_sha256 configured (expected '"+expectedSHA256+"' but got '"+documentSHA256+"'), refusing to use a document that might have been tampered with. If the document has been legitimately updated, please update the swagger_sha256 with the new checksum")
+			})
+		})
+	})
+}
+
+func TestCreateSchemaProviderFromServiceConfigurationWithAdditionalSwaggerDocuments(t *testing.T) {
+	Convey("Given two swagger documents served by different microservices exposing a resource with the same name", t, func() {
+		swaggerDoc := `swagger: "2.0"
+host: %s
+paths:
+  /v1/cdns:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        schema:
+          $ref: "#/definitions/ContentDeliveryNetwork"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+  /v1/cdns/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/ContentDeliveryNetwork"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  ContentDeliveryNetwork:
+    type: "object"
+    properties:
+      id:
+        type: "string"
+        readOnly: true
+      label:
+        type: "string"`
+		mainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(swaggerDoc, "api.example.com")))
+		}))
+		defer mainServer.Close()
+		additionalServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(swaggerDoc, "edge.example.com")))
+		}))
+		defer additionalServer.Close()
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called with a resource name prefix for the additional document", func() {
+			p := ProviderOpenAPI{ProviderName: "something"}
+			provider, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{
+				SwaggerURL:          mainServer.URL,
+				AdditionalDocuments: []SwaggerDocumentConfiguration{{URL: additionalServer.URL, ResourceNamePrefix: "edge"}},
+			})
+			Convey("Then the provider should contain the resources of both documents", func() {
+				So(err, ShouldBeNil)
+				So(provider.ResourcesMap, ShouldContainKey, "something_cdns_v1")
+				So(provider.ResourcesMap, ShouldContainKey, "something_edge_cdns_v1")
+			})
+		})
+		Convey("When CreateSchemaProviderFromServiceConfiguration is called without a resource name prefix for the additional document", func() {
+			p := ProviderOpenAPI{ProviderName: "something"}
+			_, err := p.CreateSchemaProviderFromServiceConfiguration(&ServiceConfigStub{
+				SwaggerURL:          mainServer.URL,
+				AdditionalDocuments: []SwaggerDocumentConfiguration{{URL: additionalServer.URL}},
+			})
+			Convey("Then the error returned should describe the name collision", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "resource name 'cdns_v1' is defined in multiple OpenAPI documents")
+			})
+		})
+	})
+}
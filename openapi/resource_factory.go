@@ -1,18 +1,21 @@
 package openapi
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type resourceFactory struct {
@@ -21,24 +24,73 @@ type resourceFactory struct {
 	defaultPollInterval   time.Duration
 	defaultPollMinTimeout time.Duration
 	defaultPollDelay      time.Duration
+	// readAfterCreateRetryInterval is the time waited before reading again the resources that are not visible yet right
+	// after being created ('x-terraform-read-after-create-retries' extension)
+	readAfterCreateRetryInterval time.Duration
+	// readAfterCreateRetries is the number of times the resource is read again if it is not visible yet right after being
+	// created (see withReadAfterCreateRetries)
+	readAfterCreateRetries int
+	logger                 Logger
+	// telemetryHandler (optional) is used to submit the time it takes to perform the resource operations
+	telemetryHandler TelemetryHandler
+	// regions (optional) contains the regions the resource can be managed in when the provider is multi-region, in
+	// which case the resource schema exposes the region property to override the provider region
+	regions []string
+	// resourceHeaders contains the headers required by the resource operations that are resource attributes
+	// ('x-terraform-header-mode' extension set to 'resource'), which values are configured in the resource
+	resourceHeaders SpecHeaderParameters
+	// storesETag is true if the resource schema contains the property storing the ETag of the resources configured
+	// with the etag optimistic locking ('x-terraform-optimistic-locking' extension) or conditional reads
+	// ('x-terraform-conditional-read' extension)
+	storesETag bool
+	// storesBinaryContent is true if the resource schema contains the property storing the base64 encoded content of
+	// the resources which read operation returns binary blobs (application/octet-stream)
+	storesBinaryContent bool
+	// responseHeaderProperties contains the terraform names of the computed properties populated with the values of the
+	// response headers ('x-terraform-response-header-property' extension), keyed by the property names configured
+	responseHeaderProperties map[string]string
+	// actionTriggerNames contains the names of the properties triggering the resource actions
+	// ('x-terraform-resource-action' extension)
+	actionTriggerNames []string
 }
 
 // only applicable when remote resource no longer exists and GET operations return 404 NotFound
 const defaultDestroyStatus = "destroyed"
 
+// internal states used when polling the asynchronous operations, the statuses returned by the API are mapped to these
+const asyncOperationInProgress = "in_progress"
+const asyncOperationSucceeded = "succeeded"
+
 var defaultPollInterval = time.Duration(5 * time.Second)
 var defaultPollMinTimeout = time.Duration(10 * time.Second)
 var defaultPollDelay = time.Duration(1 * time.Second)
 var defaultTimeout = time.Duration(10 * time.Minute)
+var defaultReadAfterCreateRetryInterval = time.Duration(1 * time.Second)
 
 func newResourceFactory(openAPIResource SpecResource) resourceFactory {
 	return resourceFactory{
-		openAPIResource:       openAPIResource,
-		defaultPollDelay:      defaultPollDelay,
-		defaultPollInterval:   defaultPollInterval,
-		defaultPollMinTimeout: defaultPollMinTimeout,
-		defaultTimeout:        defaultTimeout,
+		openAPIResource:              openAPIResource,
+		defaultPollDelay:             defaultPollDelay,
+		defaultPollInterval:          defaultPollInterval,
+		defaultPollMinTimeout:        defaultPollMinTimeout,
+		defaultTimeout:               defaultTimeout,
+		readAfterCreateRetryInterval: defaultReadAfterCreateRetryInterval,
+	}
+}
+
+// getLogger returns the logger configured in the resource factory or the default logger if none was provided
+func (r resourceFactory) getLogger() Logger {
+	return loggerOrDefault(r.logger)
+}
+
+// redactSensitiveValues returns a copy of the payload with the sensitive values redacted so it can be logged safely.
+// If the resource schema can not be loaded, the payload is fully redacted to avoid leaking any secret
+func (r resourceFactory) redactSensitiveValues(payload map[string]interface{}) interface{} {
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return redactedValue
 	}
+	return resourceSchema.redactSensitiveValues(payload)
 }
 
 func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
@@ -46,36 +98,97 @@ func (r resourceFactory) createTerraformResource() (*schema.Resource, error) {
 	if err != nil {
 		return nil, err
 	}
+	if !r.addRegionSchema(s) {
+		r.regions = nil
+	}
+	r.resourceHeaders = r.addHeadersSchema(s)
+	r.storesETag = r.addETagSchema(s)
+	r.storesBinaryContent = r.addBinaryContentSchema(s)
+	r.actionTriggerNames = r.addActionsSchema(s)
+	r.responseHeaderProperties = r.addResponseHeadersSchema(s)
 	//log.Printf("[DEBUG] '%s' terraform schema: %+v", r.openAPIResource.getResourceName(), s)
 	//spew.Dump(s)
 	timeouts, err := r.createSchemaResourceTimeout()
 	if err != nil {
 		return nil, err
 	}
-	return &schema.Resource{
-		Schema:   s,
-		Create:   r.create,
-		Read:     r.read,
-		Delete:   r.delete,
-		Update:   r.update,
-		Importer: r.importer(),
-		Timeouts: timeouts,
-	}, nil
+	resource := &schema.Resource{
+		Schema:        s,
+		CreateContext: withDiagnostics(r.withTelemetryMetrics(TelemetryResourceOperationCreate, r.create)),
+		ReadContext:   withDiagnostics(r.withTelemetryMetrics(TelemetryResourceOperationRead, r.read)),
+		DeleteContext: withDiagnostics(r.withTelemetryMetrics(TelemetryResourceOperationDelete, r.delete)),
+		UpdateContext: withDiagnostics(r.withTelemetryMetrics(TelemetryResourceOperationUpdate, r.update)),
+		Importer:      r.importer(),
+		Timeouts:      timeouts,
+	}
+	// Read only resources have all their properties computed so there is nothing that can be updated (besides the
+	// properties triggering the resource actions)
+	if r.openAPIResource.isReadOnlyResource() && len(r.actionTriggerNames) == 0 {
+		resource.UpdateContext = nil
+	}
+	stateMigrations, err := r.openAPIResource.getStateMigrations()
+	if err != nil {
+		return nil, err
+	}
+	resource.SchemaVersion = stateMigrations.getSchemaVersion()
+	resource.StateUpgraders = stateMigrations.createStateUpgraders(resource)
+	schemaDefinition, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	requiredIfRules, err := createRequiredIfRules(schemaDefinition)
+	if err != nil {
+		return nil, err
+	}
+	resource.CustomizeDiff = createRequiredIfCustomizeDiff(requiredIfRules)
+	return resource, nil
+}
+
+// withTelemetryMetrics returns a function that submits the time it took to perform the resource operation (regardless
+// of the operation result) and the resource operation counters to the telemetry handler. If there is no telemetry
+// handler the operation is returned as is
+func (r resourceFactory) withTelemetryMetrics(operation TelemetryResourceOperation, f resourceOperation) resourceOperation {
+	if r.telemetryHandler == nil {
+		return f
+	}
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) (err error) {
+		start := time.Now()
+		defer func() {
+			resourceName := r.openAPIResource.getResourceName()
+			r.telemetryHandler.SubmitResourceOperationTimingMetric(resourceName, operation, time.Since(start))
+			r.telemetryHandler.IncResourceOperationCounters(resourceName, operation, err != nil)
+		}()
+		return f(ctx, data, i)
+	}
 }
 
+// createSchemaResourceTimeout returns the resource timeouts: the timeouts defined in the spec for each operation and the
+// default timeout for the create, update and delete operations that do not define one
 func (r resourceFactory) createSchemaResourceTimeout() (*schema.ResourceTimeout, error) {
 	var timeouts *specTimeouts
 	var err error
 	if timeouts, err = r.openAPIResource.getTimeouts(); err != nil {
 		return nil, err
 	}
-	return &schema.ResourceTimeout{
+	resourceTimeout := &schema.ResourceTimeout{
 		Create:  timeouts.Post,
 		Read:    timeouts.Get,
 		Update:  timeouts.Put,
 		Delete:  timeouts.Delete,
 		Default: &r.defaultTimeout,
-	}, nil
+	}
+	// The create, update and delete timeouts are always exposed in the resource timeouts block so users can configure
+	// them for long running operations even if the spec does not define their timeouts (the default timeout is used)
+	if resourceTimeout.Create == nil {
+		resourceTimeout.Create = &r.defaultTimeout
+	}
+	if resourceTimeout.Update == nil && !r.openAPIResource.isReadOnlyResource() {
+		resourceTimeout.Update = &r.defaultTimeout
+	}
+	if resourceTimeout.Delete == nil {
+		resourceTimeout.Delete = &r.defaultTimeout
+	}
+	return resourceTimeout, nil
 }
 
 func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Schema, error) {
@@ -83,11 +196,12 @@ func (r resourceFactory) createTerraformResourceSchema() (map[string]*schema.Sch
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[DEBUG] resource '%s' schemaDefinition: %s", r.openAPIResource.getResourceName(), sPrettyPrint(schemaDefinition))
+	r.getLogger().Debug(fmt.Sprintf("resource '%s' schemaDefinition: %s", r.openAPIResource.getResourceName(), sPrettyPrint(schemaDefinition)), "resource", r.openAPIResource.getResourceName())
 	return schemaDefinition.createResourceSchema()
 }
 
-func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) create(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+	r = r.withResourceHeaders(data).withResourceRegion(data)
 	providerClient := i.(ClientOpenAPI)
 
 	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
@@ -95,33 +209,148 @@ func (r resourceFactory) create(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
+	if r.openAPIResource.isReadOnlyResource() {
+		return fmt.Errorf("[resource='%s'] resource is read only and can not be created, existing instances can be imported with 'terraform import' or read using the resource data source instance", r.openAPIResource.getResourceName())
+	}
+
 	operation := r.openAPIResource.getResourceOperations().Post
-	requestPayload := r.createPayloadFromLocalStateData(data)
+	r = r.withReadAfterCreateRetries(operation)
+	requestPayload, err := r.withFileContents(operation, r.createPayloadFromLocalStateData(data))
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, err)
+	}
 	responsePayload := map[string]interface{}{}
 
-	res, err := providerClient.Post(r.openAPIResource, requestPayload, &responsePayload, parentIDs...)
+	res, err := providerClient.Post(r.openAPIResource, operation.wrapRequestPayload(requestPayload), &responsePayload, parentIDs...)
 	if err != nil {
 		return err
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+	if err := r.checkHTTPStatusCodeWithFieldErrors(operation, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+		return fmt.Errorf("[resource='%s'] POST %s failed: %w", r.openAPIResource.getResourceName(), resourcePath, err)
+	}
+	if responsePayload, err = operation.unwrapResponsePayload(responsePayload); err != nil {
 		return fmt.Errorf("[resource='%s'] POST %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, err)
 	}
 
-	err = setStateID(r.openAPIResource, data, responsePayload)
+	idFromLocationHeader, err := r.setStateIDFromResponse(operation, res, responsePayload, data)
 	if err != nil {
 		return err
 	}
-	log.Printf("[INFO] Resource '%s' ID: %s", resourcePath, data.Id())
+	if err := r.setETagState(res.Header.Get(etagHeader), data); err != nil {
+		return err
+	}
+	r.getLogger().Info(fmt.Sprintf("Resource '%s' ID: %s", resourcePath, data.Id()), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate)
+	err = r.handleAsyncOperationIfConfigured(ctx, &responsePayload, res, data, providerClient, operation, schema.TimeoutCreate, parentIDs...)
+	if err != nil {
+		return fmt.Errorf("asynchronous operation failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+	}
+
+	err = r.handlePollingIfConfigured(ctx, &responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutCreate)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after POST %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+	// The POST response payload does not contain the resource (only its location), so the rest of the state is populated
+	// from the resource returned by the API
+	if idFromLocationHeader {
+		var etag string
+		responsePayload, etag, err = r.readRemoteAfterCreate(ctx, data.Id(), providerClient, parentIDs...)
+		if err != nil {
+			return fmt.Errorf("[resource='%s'] GET %s/%s after POST failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+		}
+		if err := r.setETagState(etag, data); err != nil {
+			return err
+		}
+		if err := r.setBinaryContentState(responsePayload, data); err != nil {
+			return err
+		}
+	} else if err := r.readBinaryContent(ctx, data, providerClient, parentIDs...); err != nil {
+		return fmt.Errorf("[resource='%s'] GET %s/%s after POST failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+	}
+
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return err
+	}
+	return r.setResponseHeadersState(operation, res, data)
 }
 
-func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
+// setStateIDFromResponse sets the resource id from the POST response payload. If the payload does not contain the
+// identifier property (e,g: APIs returning 201 with an empty body) but the response contains the location header, the
+// id is extracted from the location URL path instead (e,g: Location: /v1/resource/{id}). The bool returned is true when
+// the id was extracted from the location header. Asynchronous responses are the exception since their location header
+// points to the operation rather than the resource, in which case the id is not set and it is taken from the operation
+// payload once the operation succeeds (see handleAsyncOperationIfConfigured)
+func (r resourceFactory) setStateIDFromResponse(operation *specResourceOperation, res *http.Response, responsePayload map[string]interface{}, data *schema.ResourceData) (bool, error) {
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return false, err
+	}
+	identifierProperty, err := resourceSchema.getResourceIdentifier()
+	if err != nil {
+		return false, err
+	}
+	identifier, _ := getPayloadValue(responsePayload, identifierProperty)
+	if response := operation.responses.getResponse(res.StatusCode); identifier == nil && response != nil && response.asyncOperation != nil {
+		return false, nil
+	}
+	locationHeader := operation.getLocationHeader()
+	location := res.Header.Get(locationHeader)
+	if identifier != nil || location == "" {
+		return false, setStateID(r.openAPIResource, data, responsePayload)
+	}
+	id, err := getIDFromLocation(location)
+	if err != nil {
+		return false, fmt.Errorf("response object returned from the API is missing mandatory identifier property '%s' and the id could not be extracted from the '%s' header: %s", identifierProperty, locationHeader, err)
+	}
+	data.SetId(id)
+	return true, nil
+}
+
+// withReadAfterCreateRetries returns a copy of the resource factory that reads the resource again, up to the number of
+// retries configured in the POST operation ('x-terraform-read-after-create-retries' extension), when the reads
+// performed right after the resource is created respond with 404 Not Found
+func (r resourceFactory) withReadAfterCreateRetries(operation *specResourceOperation) resourceFactory {
+	r.readAfterCreateRetries = operation.readAfterCreateRetries
+	return r
+}
+
+// readRemoteAfterCreate returns the resource read from the API right after being created along with its ETag. APIs that
+// are eventually consistent might respond with 404 Not Found until the resource is visible, in which case the resource is
+// read again up to the number of read after create retries (see withReadAfterCreateRetries). All the reads performed
+// while creating the resource (e,g: polling or the read after an asynchronous operation) go through this method, for the
+// rest of the operations no retries are configured so the resource is read only once
+func (r resourceFactory) readRemoteAfterCreate(ctx context.Context, id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, string, error) {
+	for attempt := 0; ; attempt++ {
+		remoteData, etag, err := r.readRemoteWithETag(id, providerClient, parentIDs...)
+		if openapiErr, ok := err.(openapierr.Error); !ok || openapierr.NotFound != openapiErr.Code() || attempt >= r.readAfterCreateRetries {
+			return remoteData, etag, err
+		}
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] %s not found after being created, reading it again in %s (retry %d/%d)", r.openAPIResource.getResourceName(), id, r.readAfterCreateRetryInterval, attempt+1, r.readAfterCreateRetries), "resource", r.openAPIResource.getResourceName(), "id", id)
+		select {
+		case <-ctx.Done():
+			return nil, "", ctx.Err()
+		case <-time.After(r.readAfterCreateRetryInterval):
+		}
+	}
+}
+
+// getIDFromLocation returns the last segment of the location URL path, which is expected to be the resource id
+func getIDFromLocation(location string) (string, error) {
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	path := strings.TrimRight(locationURL.Path, "/")
+	id := path[strings.LastIndex(path, "/")+1:]
+	if id == "" {
+		return "", fmt.Errorf("location '%s' does not contain the resource id", location)
+	}
+	return id, nil
+}
+
+func (r resourceFactory) read(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+	r = r.withResourceHeaders(data).withResourceRegion(data)
 	openAPIClient := i.(ClientOpenAPI)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
@@ -129,34 +358,66 @@ func (r resourceFactory) read(data *schema.ResourceData, i interface{}) error {
 		return err
 	}
 
-	remoteData, err := r.readRemote(data.Id(), openAPIClient, parentsIDs...)
+	remoteData, etag, modified, err := r.readRemoteIfNoneMatch(data.Id(), r.getConditionalReadETag(data), openAPIClient, parentsIDs...)
 
 	if err != nil {
-		if openapiErr, ok := err.(openapierr.Error); ok {
-			if openapierr.NotFound == openapiErr.Code() {
-				return nil
-			}
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() && r.openAPIResource.shouldRemoveOnNotFound() {
+			r.getLogger().Warn(fmt.Sprintf("[resource='%s'] %s/%s no longer exists, removing it from the state", r.openAPIResource.getResourceName(), resourcePath, data.Id()), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+			data.SetId("")
+			return nil
 		}
 		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
+	// The resource has not changed since it was last read, hence the state is kept as is
+	if !modified {
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] %s/%s has not been modified since it was last read", r.openAPIResource.getResourceName(), resourcePath, data.Id()), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+		return nil
+	}
+	if err := r.setETagState(etag, data); err != nil {
+		return err
+	}
+	if err := r.setBinaryContentState(remoteData, data); err != nil {
+		return err
+	}
 
 	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
 }
 
 func (r resourceFactory) readRemote(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, error) {
+	responsePayload, _, err := r.readRemoteWithETag(id, providerClient, parentIDs...)
+	return responsePayload, err
+}
+
+// readRemoteWithETag returns the resource read from the API along with the ETag response header (empty if the API does
+// not return it)
+func (r resourceFactory) readRemoteWithETag(id string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, string, error) {
+	responsePayload, etag, _, err := r.readRemoteIfNoneMatch(id, "", providerClient, parentIDs...)
+	return responsePayload, etag, err
+}
+
+// readRemoteIfNoneMatch returns the resource read from the API along with the ETag response header. If the given ETag
+// is not empty, the request is conditional (If-None-Match header) and false is returned along with a nil resource if
+// the API responds with 304 Not Modified
+func (r resourceFactory) readRemoteIfNoneMatch(id, ifNoneMatch string, providerClient ClientOpenAPI, parentIDs ...string) (map[string]interface{}, string, bool, error) {
 	var err error
 	responsePayload := map[string]interface{}{}
-	resp, err := providerClient.Get(r.openAPIResource, id, &responsePayload, parentIDs...)
+	resp, err := providerClient.Get(r.withIfNoneMatch(ifNoneMatch), id, &responsePayload, parentIDs...)
 	if err != nil {
-		return nil, err
+		return nil, "", false, err
+	}
+	if ifNoneMatch != "" && resp.StatusCode == http.StatusNotModified {
+		return nil, ifNoneMatch, false, nil
 	}
 
 	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
-		return nil, err
+		return nil, "", false, err
+	}
+	if responsePayload, err = r.openAPIResource.getResourceOperations().Get.unwrapResponsePayload(responsePayload); err != nil {
+		return nil, "", false, err
 	}
 
-	log.Printf("[DEBUG] GET '%s' response payload: %#v", r.openAPIResource.getResourceName(), responsePayload)
-	return responsePayload, nil
+	r.getLogger().Debug(fmt.Sprintf("GET '%s' response payload: %#v", r.openAPIResource.getResourceName(), r.redactSensitiveValues(responsePayload)), "resource", r.openAPIResource.getResourceName())
+	return responsePayload, resp.Header.Get(etagHeader), true, nil
 }
 
 func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, error) {
@@ -182,7 +443,8 @@ func (r resourceFactory) getParentIDs(data *schema.ResourceData) ([]string, erro
 	return []string{}, nil
 }
 
-func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) update(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+	r = r.withResourceHeaders(data).withResourceRegion(data)
 	providerClient := i.(ClientOpenAPI)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
@@ -190,32 +452,104 @@ func (r resourceFactory) update(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
-	operation := r.openAPIResource.getResourceOperations().Put
+	// The resource is not updated if only the properties triggering the resource actions changed
+	actions := r.getTriggeredActions(data)
+	if len(r.actionTriggerNames) > 0 && !data.HasChangesExcept(r.actionTriggerNames...) {
+		return r.performActions(data, providerClient, actions, resourcePath, parentsIDs...)
+	}
+
+	operation, method := r.openAPIResource.getResourceOperations().getUpdateOperation()
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support PUT operation, check the swagger file exposed on '%s'", r.openAPIResource.getResourceName(), resourcePath)
 	}
 	requestPayload := r.createPayloadFromLocalStateData(data)
-	responsePayload := map[string]interface{}{}
+	var responsePayload map[string]interface{}
 	if err := r.checkImmutableFields(data, providerClient, parentsIDs...); err != nil {
 		return err
 	}
-	res, err := providerClient.Put(r.openAPIResource, data.Id(), requestPayload, &responsePayload, parentsIDs...)
-	if err != nil {
-		return err
+	var res *http.Response
+	expectedStatusCodes := []int{http.StatusOK, http.StatusAccepted}
+	if method == httpPatch {
+		expectedStatusCodes = append(expectedStatusCodes, http.StatusNoContent)
 	}
-	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusAccepted}); err != nil {
+	version := r.getLockingVersion(operation.optimisticLocking, data)
+	for retries := 0; ; retries++ {
+		responsePayload = map[string]interface{}{}
+		res, err = r.sendUpdateRequest(data, providerClient, operation, method, requestPayload, version, &responsePayload, parentsIDs...)
+		if err != nil {
+			return err
+		}
+		if !operation.optimisticLocking.shouldRetry(res, retries) {
+			break
+		}
+		r.getLogger().Warn(fmt.Sprintf("[resource='%s'] UPDATE %s/%s conflicted with a concurrent change (%d), retrying with the current version of the resource", r.openAPIResource.getResourceName(), resourcePath, data.Id(), res.StatusCode), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+		if version, err = r.readLockingVersion(operation.optimisticLocking, data.Id(), providerClient, parentsIDs...); err != nil {
+			return fmt.Errorf("[resource='%s'] GET %s/%s failed after UPDATE conflict: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+		}
+	}
+	if err := r.checkHTTPStatusCodeWithFieldErrors(operation, res, expectedStatusCodes); err != nil {
+		return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %w", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+	}
+	if responsePayload, err = operation.unwrapResponsePayload(responsePayload); err != nil {
 		return fmt.Errorf("[resource='%s'] UPDATE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(&responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate)
+	err = r.handleAsyncOperationIfConfigured(ctx, &responsePayload, res, data, providerClient, operation, schema.TimeoutUpdate, parentsIDs...)
+	if err != nil {
+		return fmt.Errorf("asynchronous operation failed after %s %s call with response status code (%d): %s", method, resourcePath, res.StatusCode, err)
+	}
+
+	err = r.handlePollingIfConfigured(ctx, &responsePayload, data, providerClient, operation, res.StatusCode, schema.TimeoutUpdate)
 	if err != nil {
-		return fmt.Errorf("polling mechanism failed after PUT %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+		return fmt.Errorf("polling mechanism failed after %s %s call with response status code (%d): %s", method, resourcePath, res.StatusCode, err)
+	}
+
+	// PATCH operations might not return the resource (e,g: 204 No Content), in which case the resource is read so the
+	// state reflects the remote values
+	etag := res.Header.Get(etagHeader)
+	if method == httpPatch && len(responsePayload) == 0 {
+		if responsePayload, etag, err = r.readRemoteWithETag(data.Id(), providerClient, parentsIDs...); err != nil {
+			return fmt.Errorf("[resource='%s'] GET %s/%s failed after PATCH: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+		}
+		if err := r.setBinaryContentState(responsePayload, data); err != nil {
+			return err
+		}
+	} else if err := r.readBinaryContent(ctx, data, providerClient, parentsIDs...); err != nil {
+		return fmt.Errorf("[resource='%s'] GET %s/%s after %s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), method, err)
+	}
+	if err := r.setETagState(etag, data); err != nil {
+		return err
+	}
+
+	if err := updateStateWithPayloadData(r.openAPIResource, responsePayload, data); err != nil {
+		return err
+	}
+	if err := r.setResponseHeadersState(operation, res, data); err != nil {
+		return err
 	}
+	return r.performActions(data, providerClient, actions, resourcePath, parentsIDs...)
+}
 
-	return updateStateWithPayloadData(r.openAPIResource, responsePayload, data)
+// sendUpdateRequest sends the PUT or PATCH request (depending on the given method) updating the resource. If the
+// operation is configured with optimistic locking, the request contains the given version of the resource
+func (r resourceFactory) sendUpdateRequest(data *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, method httpMethodSupported, requestPayload map[string]interface{}, version interface{}, responsePayload *map[string]interface{}, parentsIDs ...string) (*http.Response, error) {
+	resource := r.openAPIResource
+	if operation.optimisticLocking.usesETag() {
+		resource = r.withIfMatch(version)
+	}
+	if method == httpPatch {
+		patchPayload := r.createPatchPayload(operation.getUpdateStrategy(), r.createPayloadFromPriorStateData(data, requestPayload), requestPayload)
+		return providerClient.Patch(resource, data.Id(), operation.wrapRequestPayload(operation.optimisticLocking.addVersion(patchPayload, version)), responsePayload, parentsIDs...)
+	}
+	requestPayload, err := r.withFileContents(operation, requestPayload)
+	if err != nil {
+		return nil, fmt.Errorf("[resource='%s'] %s", r.openAPIResource.getResourceName(), err)
+	}
+	return providerClient.Put(resource, data.Id(), operation.wrapRequestPayload(operation.optimisticLocking.addVersion(requestPayload, version)), responsePayload, parentsIDs...)
 }
 
-func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error {
+func (r resourceFactory) delete(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+	r = r.withResourceHeaders(data).withResourceRegion(data)
 	providerClient := i.(ClientOpenAPI)
 
 	parentsIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
@@ -223,14 +557,39 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 		return err
 	}
 
+	// Read only resources can not be deleted, the resource is just removed from the state
+	if r.openAPIResource.isReadOnlyResource() {
+		r.getLogger().Warn(fmt.Sprintf("resource '%s' is read only and can not be deleted, removing %s/%s from the state only", r.openAPIResource.getResourceName(), resourcePath, data.Id()), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+		return nil
+	}
+
 	operation := r.openAPIResource.getResourceOperations().Delete
 	if operation == nil {
 		return fmt.Errorf("[resource='%s'] resource does not support DELETE operation, check the swagger file exposed on '%s'", r.openAPIResource.getResourceName(), resourcePath)
 	}
-	res, err := providerClient.Delete(r.openAPIResource, data.Id(), parentsIDs...)
-	if err != nil {
+	if err := r.callPreDeleteOperationIfConfigured(data, providerClient, operation, resourcePath, parentsIDs...); err != nil {
 		return err
 	}
+	var res *http.Response
+	version := r.getLockingVersion(operation.optimisticLocking, data)
+	requestPayload := r.createDeletePayload(operation, data)
+	for retries := 0; ; retries++ {
+		if requestPayload == nil {
+			res, err = providerClient.Delete(r.withIfMatch(version), data.Id(), parentsIDs...)
+		} else {
+			res, err = providerClient.DeleteWithPayload(r.withIfMatch(version), data.Id(), requestPayload, parentsIDs...)
+		}
+		if err != nil {
+			return err
+		}
+		if !operation.optimisticLocking.shouldRetry(res, retries) {
+			break
+		}
+		r.getLogger().Warn(fmt.Sprintf("[resource='%s'] DELETE %s/%s conflicted with a concurrent change (%d), retrying with the current version of the resource", r.openAPIResource.getResourceName(), resourcePath, data.Id(), res.StatusCode), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+		if version, err = r.readLockingVersion(operation.optimisticLocking, data.Id(), providerClient, parentsIDs...); err != nil {
+			return fmt.Errorf("[resource='%s'] GET %s/%s failed after DELETE conflict: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+		}
+	}
 	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusNoContent, http.StatusOK, http.StatusAccepted}); err != nil {
 		if openapiErr, ok := err.(openapierr.Error); ok {
 			if openapierr.NotFound == openapiErr.Code() {
@@ -240,49 +599,223 @@ func (r resourceFactory) delete(data *schema.ResourceData, i interface{}) error
 		return fmt.Errorf("[resource='%s'] DELETE %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
 	}
 
-	err = r.handlePollingIfConfigured(nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete)
+	err = r.handleAsyncOperationIfConfigured(ctx, nil, res, data, providerClient, operation, schema.TimeoutDelete, parentsIDs...)
+	if err != nil {
+		return fmt.Errorf("asynchronous operation failed after DELETE %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
+	}
+
+	err = r.handlePollingIfConfigured(ctx, nil, data, providerClient, operation, res.StatusCode, schema.TimeoutDelete)
 	if err != nil {
 		return fmt.Errorf("polling mechanism failed after DELETE %s call with response status code (%d): %s", resourcePath, res.StatusCode, err)
 	}
 
+	err = r.waitForDeletionIfConfigured(ctx, data, providerClient, operation, parentsIDs...)
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] DELETE %s/%s verification failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+	}
+
 	return nil
 }
 
 func (r resourceFactory) importer() *schema.ResourceImporter {
 	return &schema.ResourceImporter{
-		State: func(data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+		StateContext: func(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
 			results := make([]*schema.ResourceData, 1, 1)
 			results[0] = data
-			parentResourceInfo := r.openAPIResource.getParentResourceInfo()
-			if parentResourceInfo != nil {
-				parentPropertyNames := parentResourceInfo.getParentPropertiesNames()
-
-				// The expected format for the ID provided when importing a sub-resource is 1234/567 where 1234 would be the parentID and 567 the instance ID
-				ids := strings.Split(data.Id(), "/")
-				if len(ids) < 2 {
-					return results, fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID", len(parentPropertyNames))
-				}
-				parentIDsLen := len(ids) - 1
-				if len(parentPropertyNames) < parentIDsLen {
-					return results, fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d", parentIDsLen, len(parentPropertyNames))
-				}
-				if len(parentPropertyNames) > parentIDsLen {
-					return results, fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs", len(parentPropertyNames), parentIDsLen)
-				}
-				for idx, parentPropertyName := range parentPropertyNames {
-					data.Set(parentPropertyName, ids[idx])
-				}
-				data.SetId(ids[len(ids)-1])
-			}
-			// If the resources is NOT a sub-resource and just a top level resource then the array passed in will just contain
-			// 	the data object we get from terraform core without any updates.
-			err := r.read(data, i)
-			return results, err
+			return results, r.importState(data, i.(ClientOpenAPI))
 		},
 	}
 }
 
-func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
+// importState populates the state of the resource being imported. The import ID is the instance ID for top level
+// resources; sub-resources are expected to be imported providing all their parent IDs too (e,g: parent_id/instance_id).
+// Resources with composite identifiers can define their own import ID format instead. If the resource supports import
+// lookups, the instance ID can also be the value of the lookup property. The resource is then read from the API and the
+// import fails if it does not exist
+func (r resourceFactory) importState(data *schema.ResourceData, providerClient ClientOpenAPI) error {
+	parentResourceInfo := r.openAPIResource.getParentResourceInfo()
+	if template := r.openAPIResource.getImportIDFormat(); template != "" {
+		if err := r.parseImportIDWithFormat(template, data); err != nil {
+			return err
+		}
+	} else if parentResourceInfo != nil {
+		parentPropertyNames := parentResourceInfo.getParentPropertiesNames()
+
+		// The expected format for the ID provided when importing a sub-resource is 1234/567 where 1234 would be the parentID and 567 the instance ID
+		ids := strings.Split(data.Id(), "/")
+		if len(ids) < 2 {
+			return fmt.Errorf("can not import a subresource without providing all the parent IDs (%d) and the instance ID", len(parentPropertyNames))
+		}
+		parentIDsLen := len(ids) - 1
+		if len(parentPropertyNames) < parentIDsLen {
+			return fmt.Errorf("the number of parent IDs provided %d is greater than the expected number of parent IDs %d", parentIDsLen, len(parentPropertyNames))
+		}
+		if len(parentPropertyNames) > parentIDsLen {
+			return fmt.Errorf("can not import a subresource without all the parent ids, expected %d and got %d parent IDs", len(parentPropertyNames), parentIDsLen)
+		}
+		for _, id := range ids {
+			if id == "" {
+				return fmt.Errorf("can not import a subresource with empty IDs, the expected format is '%s/<id>' and got '%s'", strings.Join(parentPropertyNames, "/"), data.Id())
+			}
+		}
+		for idx, parentPropertyName := range parentPropertyNames {
+			data.Set(parentPropertyName, ids[idx])
+		}
+		data.SetId(ids[len(ids)-1])
+	}
+	if data.Id() == "" {
+		return fmt.Errorf("[resource='%s'] can not import a resource without providing its ID", r.openAPIResource.getResourceName())
+	}
+	parentIDs, resourcePath, err := getParentIDsAndResourcePath(r.openAPIResource, data)
+	if err != nil {
+		return err
+	}
+	if r.openAPIResource.getImportLookupProperty() != "" {
+		if err := r.importLookup(data, providerClient, parentIDs...); err != nil {
+			return err
+		}
+	}
+	remoteData, err := r.readRemote(data.Id(), providerClient, parentIDs...)
+	if err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			return fmt.Errorf("[resource='%s'] can not import non-existent remote object %s/%s", r.openAPIResource.getResourceName(), resourcePath, data.Id())
+		}
+		return fmt.Errorf("[resource='%s'] GET %s/%s failed: %s", r.openAPIResource.getResourceName(), resourcePath, data.Id(), err)
+	}
+	if err := r.setBinaryContentState(remoteData, data); err != nil {
+		return err
+	}
+	return updateStateWithPayloadData(r.openAPIResource, remoteData, data)
+}
+
+// parseImportIDWithFormat populates the parent properties and the ID of the resource being imported with the values
+// contained in the import ID as per the format configured in the x-terraform-import-id-format extension
+func (r resourceFactory) parseImportIDWithFormat(template string, data *schema.ResourceData) error {
+	parentResourceInfo := r.openAPIResource.getParentResourceInfo()
+	format, err := newImportIDFormat(template, parentResourceInfo)
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] %s", r.openAPIResource.getResourceName(), err)
+	}
+	parentIDs, id, err := format.parse(data.Id())
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] %s", r.openAPIResource.getResourceName(), err)
+	}
+	if parentResourceInfo != nil {
+		for idx, parentPropertyName := range parentResourceInfo.getParentPropertiesNames() {
+			data.Set(parentPropertyName, parentIDs[idx])
+		}
+	}
+	data.SetId(id)
+	return nil
+}
+
+// importLookup resolves the resource id when the value provided in the import matches the value of the property configured
+// in the 'x-terraform-import-lookup' extension (refer to lookupImportID). The resolved id is stored as the state ID.
+func (r resourceFactory) importLookup(data *schema.ResourceData, providerClient ClientOpenAPI, parentIDs ...string) error {
+	id, err := r.lookupImportID(data.Id(), providerClient, parentIDs...)
+	if err != nil {
+		return err
+	}
+	data.SetId(id)
+	return nil
+}
+
+// lookupImportID returns the id of the resource which value of the property configured in the 'x-terraform-import-lookup'
+// extension matches the lookup value provided. The resources are listed and filtered by the look up property; if exactly
+// one resource matches, its identifier is returned. If no resources match, the lookup value is considered the actual id
+// of the resource. If more than one resource matches the lookup fails.
+func (r resourceFactory) lookupImportID(lookupValue string, providerClient ClientOpenAPI, parentIDs ...string) (string, error) {
+	lookupPropertyName := r.openAPIResource.getImportLookupProperty()
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return "", err
+	}
+	lookupProperty, err := resourceSchema.getProperty(lookupPropertyName)
+	if err != nil {
+		return "", fmt.Errorf("[resource='%s'] import lookup property '%s' not found in the resource schema: %s", r.openAPIResource.getResourceName(), lookupPropertyName, err)
+	}
+	if r.openAPIResource.getResourceOperations().List == nil {
+		return "", fmt.Errorf("[resource='%s'] import lookup by '%s' requires the resource root path to have a GET operation to list the resources", r.openAPIResource.getResourceName(), lookupPropertyName)
+	}
+
+	var matches []map[string]interface{}
+	responsePayload := newListItemsStream(func(item map[string]interface{}) error {
+		if value, exists := item[lookupProperty.getResponseFieldName()]; exists && value != nil && fmt.Sprintf("%v", value) == lookupValue {
+			matches = append(matches, item)
+		}
+		return nil
+	})
+	resp, err := providerClient.List(r.openAPIResource, responsePayload, parentIDs...)
+	if err != nil {
+		return "", err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK}); err != nil {
+		return "", fmt.Errorf("[resource='%s'] import lookup failed: %s", r.openAPIResource.getResourceName(), err)
+	}
+
+	switch len(matches) {
+	case 0:
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] import lookup by '%s' did not match any resource with value '%s', the value will be used as the resource id", r.openAPIResource.getResourceName(), lookupPropertyName, lookupValue), "resource", r.openAPIResource.getResourceName())
+		return lookupValue, nil
+	case 1:
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] import lookup by '%s' matched resource with value '%s'", r.openAPIResource.getResourceName(), lookupPropertyName, lookupValue), "resource", r.openAPIResource.getResourceName())
+		return getResourceIDFromPayload(r.openAPIResource, matches[0])
+	}
+	return "", fmt.Errorf("[resource='%s'] import lookup by '%s' with value '%s' is ambiguous, %d resources matched. Please import the resource using its id instead", r.openAPIResource.getResourceName(), lookupPropertyName, lookupValue, len(matches))
+}
+
+// checkHTTPStatusCodeWithFieldErrors behaves as checkHTTPStatusCode. In addition, if the operation response for the status
+// code received documents where the field errors are located in the response payload (x-terraform-error-fields), the field
+// errors that can be correlated to the resource attributes are returned as attribute level errors. If none of the field
+// errors can be correlated, the resource level error is returned instead
+func (r resourceFactory) checkHTTPStatusCodeWithFieldErrors(operation *specResourceOperation, res *http.Response, expectedHTTPStatusCodes []int) error {
+	if operation == nil || responseContainsExpectedStatus(expectedHTTPStatusCodes, res.StatusCode) {
+		return checkHTTPStatusCode(r.openAPIResource, res, expectedHTTPStatusCodes)
+	}
+	response := operation.responses.getResponse(res.StatusCode)
+	if response == nil || response.fieldErrors == nil || res.Body == nil {
+		return checkHTTPStatusCode(r.openAPIResource, res, expectedHTTPStatusCodes)
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("[resource='%s'] HTTP Response Status Code %d - Error '%s' occurred while reading the response body", r.openAPIResource.getResourceName(), res.StatusCode, err)
+	}
+	if err := r.createAttributeErrors(response.fieldErrors, b, res.StatusCode); err != nil {
+		return err
+	}
+	// restoring the body so the resource level error contains the response body
+	res.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return checkHTTPStatusCode(r.openAPIResource, res, expectedHTTPStatusCodes)
+}
+
+// createAttributeErrors returns an error listing the field errors contained in the response body, using the terraform
+// attribute paths for the ones that can be correlated to the resource attributes. Nil is returned if the field errors can
+// not be read from the response body or none of them can be correlated
+func (r resourceFactory) createAttributeErrors(responseFieldErrors *specResponseFieldErrors, responseBody []byte, statusCode int) error {
+	fieldErrors, err := responseFieldErrors.getFieldErrors(responseBody)
+	if err != nil {
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] field errors could not be read from the response: %s", r.openAPIResource.getResourceName(), err), "resource", r.openAPIResource.getResourceName())
+		return nil
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil || resourceSchema == nil {
+		return nil
+	}
+	attributeErrs := &attributeErrors{resourceName: r.openAPIResource.getResourceName(), statusCode: statusCode}
+	for _, fieldError := range fieldErrors {
+		if attributePath, ok := resourceSchema.getTerraformAttributeCtyPath(fieldError.field); ok {
+			attributeErrs.attributeErrors = append(attributeErrs.attributeErrors, attributeError{path: attributePath, message: fieldError.message})
+			continue
+		}
+		attributeErrs.unmappedErrors = append(attributeErrs.unmappedErrors, fmt.Sprintf("- field '%s': %s", fieldError.field, fieldError.message))
+	}
+	if len(attributeErrs.attributeErrors) == 0 {
+		return nil
+	}
+	return attributeErrs
+}
+
+func (r resourceFactory) handlePollingIfConfigured(ctx context.Context, responsePayload *map[string]interface{}, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, responseStatusCode int, timeoutFor string) error {
 	response := operation.responses.getResponse(responseStatusCode)
 
 	if response == nil || !response.isPollingEnabled {
@@ -299,27 +832,28 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	// will be overridden
 	if responsePayload == nil {
 		if len(targetStatuses) > 0 {
-			log.Printf("[WARN] resource speficied poll target statuses for a DELETE operation. This is not expected as the normal behaviour is the resource to no longer exists once the DELETE operation is completed; hence subsequent GET calls should return 404 NotFound instead")
+			r.getLogger().Warn("resource speficied poll target statuses for a DELETE operation. This is not expected as the normal behaviour is the resource to no longer exists once the DELETE operation is completed; hence subsequent GET calls should return 404 NotFound instead", "resource", r.openAPIResource.getResourceName())
 		}
-		log.Printf("[WARN] overriding target status with default destroy status")
+		r.getLogger().Warn("overriding target status with default destroy status", "resource", r.openAPIResource.getResourceName())
 		targetStatuses = []string{defaultDestroyStatus}
 	}
 
-	log.Printf("[DEBUG] target statuses (%s); pending statuses (%s)", targetStatuses, pendingStatuses)
-	log.Printf("[INFO] Waiting for resource '%s' to reach a completion status (%s)", r.openAPIResource.getResourceName(), targetStatuses)
+	pollInterval, pollMinTimeout, pollDelay := r.getPollSettings(response)
+	r.getLogger().Debug(fmt.Sprintf("target statuses (%s); pending statuses (%s); poll interval (%s); poll min timeout (%s); poll delay (%s)", targetStatuses, pendingStatuses, pollInterval, pollMinTimeout, pollDelay), "resource", r.openAPIResource.getResourceName())
+	r.getLogger().Info(fmt.Sprintf("Waiting for resource '%s' to reach a completion status (%s)", r.openAPIResource.getResourceName(), targetStatuses), "resource", r.openAPIResource.getResourceName())
 
-	stateConf := &resource.StateChangeConf{
+	stateConf := &retry.StateChangeConf{
 		Pending:      pendingStatuses,
 		Target:       targetStatuses,
-		Refresh:      r.resourceStateRefreshFunc(resourceLocalData, providerClient),
+		Refresh:      r.resourceStateRefreshFunc(ctx, resourceLocalData, providerClient),
 		Timeout:      resourceLocalData.Timeout(timeoutFor),
-		PollInterval: r.defaultPollInterval,
-		MinTimeout:   r.defaultPollMinTimeout,
-		Delay:        r.defaultPollDelay,
+		PollInterval: pollInterval,
+		MinTimeout:   pollMinTimeout,
+		Delay:        pollDelay,
 	}
 
 	// Wait, catching any errors
-	remoteData, err := stateConf.WaitForState()
+	remoteData, err := stateConf.WaitForStateContext(ctx)
 	if err != nil {
 		return fmt.Errorf("error waiting for resource to reach a completion status (%s) [valid pending statuses (%s)]: %s", targetStatuses, pendingStatuses, err)
 	}
@@ -334,10 +868,107 @@ func (r resourceFactory) handlePollingIfConfigured(responsePayload *map[string]i
 	return nil
 }
 
-func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) resource.StateRefreshFunc {
+// getPollSettings returns the poll interval, min timeout and delay used when polling after receiving the given response.
+// The settings configured in the response extensions take preference over the resource factory defaults (which may
+// have been configured in the plugin configuration)
+func (r resourceFactory) getPollSettings(response *specResponse) (time.Duration, time.Duration, time.Duration) {
+	pollInterval, pollMinTimeout, pollDelay := r.defaultPollInterval, r.defaultPollMinTimeout, r.defaultPollDelay
+	if response.pollInterval != nil {
+		pollInterval = *response.pollInterval
+	}
+	if response.pollMinTimeout != nil {
+		pollMinTimeout = *response.pollMinTimeout
+	}
+	if response.pollDelay != nil {
+		pollDelay = *response.pollDelay
+	}
+	return pollInterval, pollMinTimeout, pollDelay
+}
+
+// handleAsyncOperationIfConfigured polls the asynchronous operation returned by the API (if the response is configured
+// with the 'x-terraform-async-operation' extension) until the operation succeeds, fails or the timeout is reached. Once the
+// operation succeeds the resource is read again so the response payload (if provided) contains the resource up to date. If
+// the resource id is not known yet (the response that started the operation did not contain it), the id is taken from the
+// payload of the operation that succeeded
+func (r resourceFactory) handleAsyncOperationIfConfigured(ctx context.Context, responsePayload *map[string]interface{}, res *http.Response, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, timeoutFor string, parentIDs ...string) error {
+	response := operation.responses.getResponse(res.StatusCode)
+	if response == nil || response.asyncOperation == nil {
+		return nil
+	}
+	var payload map[string]interface{}
+	if responsePayload != nil {
+		payload = *responsePayload
+	}
+	operationURL, err := response.asyncOperation.getOperationURL(res, payload)
+	if err != nil {
+		return err
+	}
+	r.getLogger().Info(fmt.Sprintf("Waiting for the asynchronous operation '%s' of resource '%s' to finish", operationURL, r.openAPIResource.getResourceName()), "resource", r.openAPIResource.getResourceName(), "url", operationURL)
+	pollInterval, pollMinTimeout, pollDelay := r.getPollSettings(response)
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{asyncOperationInProgress},
+		Target:       []string{asyncOperationSucceeded},
+		Refresh:      r.asyncOperationRefreshFunc(*response.asyncOperation, operationURL, providerClient, parentIDs...),
+		Timeout:      resourceLocalData.Timeout(timeoutFor),
+		PollInterval: pollInterval,
+		MinTimeout:   pollMinTimeout,
+		Delay:        pollDelay,
+	}
+	operationPayload, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for the asynchronous operation '%s' to finish: %s", operationURL, err)
+	}
+	if responsePayload == nil {
+		return nil
+	}
+	if resourceLocalData.Id() == "" {
+		if err := setStateID(r.openAPIResource, resourceLocalData, operationPayload.(map[string]interface{})); err != nil {
+			return fmt.Errorf("the id of the resource could not be determined from the asynchronous operation '%s' payload: %s", operationURL, err)
+		}
+	}
+	remoteData, _, err := r.readRemoteAfterCreate(ctx, resourceLocalData.Id(), providerClient, parentIDs...)
+	if err != nil {
+		return err
+	}
+	*responsePayload = remoteData
+	return nil
+}
+
+// asyncOperationRefreshFunc returns the function that reads the asynchronous operation status. An error is returned if
+// the operation failed, including the operation error message if available
+func (r resourceFactory) asyncOperationRefreshFunc(asyncOperation specAsyncOperation, operationURL string, providerClient ClientOpenAPI, parentIDs ...string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		operationPayload := map[string]interface{}{}
+		resp, err := providerClient.GetAsyncOperation(r.openAPIResource, operationURL, &operationPayload, parentIDs...)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := checkHTTPStatusCode(r.openAPIResource, resp, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted}); err != nil {
+			return nil, "", fmt.Errorf("error on retrieving the asynchronous operation: %s", err)
+		}
+		status, err := asyncOperation.getStatus(operationPayload)
+		if err != nil {
+			return nil, "", err
+		}
+		r.getLogger().Debug(fmt.Sprintf("asynchronous operation '%s' status: %s", operationURL, status), "resource", r.openAPIResource.getResourceName(), "url", operationURL, "status", status)
+		if asyncOperation.isSucceeded(status) {
+			return operationPayload, asyncOperationSucceeded, nil
+		}
+		if asyncOperation.isFailed(status) {
+			if message := asyncOperation.getErrorMessage(operationPayload); message != "" {
+				return nil, "", fmt.Errorf("asynchronous operation finished with status '%s': %s", status, message)
+			}
+			return nil, "", fmt.Errorf("asynchronous operation finished with status '%s'", status)
+		}
+		return operationPayload, asyncOperationInProgress, nil
+	}
+}
+
+func (r resourceFactory) resourceStateRefreshFunc(ctx context.Context, resourceLocalData *schema.ResourceData, providerClient ClientOpenAPI) retry.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 
-		remoteData, err := r.readRemote(resourceLocalData.Id(), providerClient)
+		remoteData, _, err := r.readRemoteAfterCreate(ctx, resourceLocalData.Id(), providerClient)
 		if err != nil {
 			if openapiErr, ok := err.(openapierr.Error); ok {
 				if openapierr.NotFound == openapiErr.Code() {
@@ -352,7 +983,7 @@ func (r resourceFactory) resourceStateRefreshFunc(resourceLocalData *schema.Reso
 			return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), err)
 		}
 
-		log.Printf("[DEBUG] resource status '%s' (%s): %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), newStatus)
+		r.getLogger().Debug(fmt.Sprintf("resource status '%s' (%s): %s", r.openAPIResource.getResourceName(), resourceLocalData.Id(), newStatus), "resource", r.openAPIResource.getResourceName(), "id", resourceLocalData.Id(), "status", newStatus)
 		return remoteData, newStatus, nil
 	}
 }
@@ -412,6 +1043,19 @@ func (r resourceFactory) validateImmutableProperty(property *specSchemaDefinitio
 				}
 			}
 		}
+	case typeMap:
+		if property.Immutable || checkObjectPropertiesUpdates {
+			localMap, _ := localData.(map[string]interface{})
+			remoteMap, _ := remoteData.(map[string]interface{})
+			if len(localMap) != len(remoteMap) {
+				return fmt.Errorf("user attempted to update an immutable map property ('%s') size: [user input map size: %d; actual map size: %d]", property.Name, len(localMap), len(remoteMap))
+			}
+			for key, localValue := range localMap {
+				if fmt.Sprintf("%v", localValue) != fmt.Sprintf("%v", remoteMap[key]) {
+					return fmt.Errorf("user attempted to update an immutable map property ('%s') element: [user input: %+v; actual: %+v]", property.Name, localMap, remoteMap)
+				}
+			}
+		}
 	case typeObject:
 		localObject := localData.(map[string]interface{})
 		remoteObject := remoteData.(map[string]interface{})
@@ -466,36 +1110,66 @@ func (r resourceFactory) createPayloadFromLocalStateData(resourceLocalData *sche
 			if dataValue, ok := r.getResourceDataOKExists(propertyName, resourceLocalData); ok {
 				err := r.populatePayload(input, property, dataValue)
 				if err != nil {
-					log.Printf("[ERROR] [resource='%s'] error when creating the property payload for property '%s': %s", r.openAPIResource.getResourceName(), propertyName, err)
+					r.getLogger().Error(fmt.Sprintf("[resource='%s'] error when creating the property payload for property '%s': %s", r.openAPIResource.getResourceName(), propertyName, err), "resource", r.openAPIResource.getResourceName())
 				}
+				r.omitPropertyWhenEmpty(input, property)
 			}
-			log.Printf("[DEBUG] [resource='%s'] property payload [propertyName: %s; propertyValue: %+v]", r.openAPIResource.getResourceName(), propertyName, input[propertyName])
+			var propertyValue interface{} = input[propertyName]
+			if property.Sensitive {
+				propertyValue = redactedValue
+			}
+			r.getLogger().Debug(fmt.Sprintf("[resource='%s'] property payload [propertyName: %s; propertyValue: %+v]", r.openAPIResource.getResourceName(), propertyName, propertyValue), "resource", r.openAPIResource.getResourceName())
 		}
 	}
-	log.Printf("[DEBUG] [resource='%s'] buildPayloadFromLocalStateDataForPostOperation: %s", r.openAPIResource.getResourceName(), sPrettyPrint(input))
+	r.getLogger().Debug(fmt.Sprintf("[resource='%s'] buildPayloadFromLocalStateDataForPostOperation: %s", r.openAPIResource.getResourceName(), sPrettyPrint(resourceSchema.redactSensitiveValues(input))), "resource", r.openAPIResource.getResourceName())
 	return input
 }
 
+// omitPropertyWhenEmpty removes the property from the payload if the property is configured to be omitted when its value
+// is empty. Required properties are always kept in the payload, even when their value is the zero value
+func (r resourceFactory) omitPropertyWhenEmpty(input map[string]interface{}, property *specSchemaDefinitionProperty) {
+	if value, exists := input[property.Name]; exists && property.shouldOmitWhenEmpty(value) {
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] omitting property '%s' from the payload since its value is empty", r.openAPIResource.getResourceName(), property.Name), "resource", r.openAPIResource.getResourceName())
+		delete(input, property.Name)
+	}
+}
+
 func (r resourceFactory) populatePayload(input map[string]interface{}, property *specSchemaDefinitionProperty, dataValue interface{}) error {
 	if property.isReadOnly() {
 		return nil
 	}
+	// Nullable properties explicitly set to the null sentinel by the user are sent to the API as JSON null
+	if property.isNullValue(dataValue) {
+		input[property.Name] = nil
+		return nil
+	}
 	if dataValue == nil {
 		return fmt.Errorf("property '%s' has a nil state dataValue", property.Name)
 	}
+	if property.isMapOfObjectsProperty() {
+		return r.populateMapOfObjectsPayload(input, property, dataValue)
+	}
 	dataValueKind := reflect.TypeOf(dataValue).Kind()
 	switch dataValueKind {
 	case reflect.Map:
 		objectInput := map[string]interface{}{}
 		mapValue := dataValue.(map[string]interface{})
 		for propertyName, propertyValue := range mapValue {
-			schemaDefinitionProperty, err := property.SpecSchemaDefinition.getPropertyBasedOnTerraformName(propertyName)
+			schemaDefinitionProperty, err := r.getObjectPropertyBasedOnTerraformName(property, propertyName)
 			if err != nil {
 				return err
 			}
 			if err := r.populatePayload(objectInput, schemaDefinitionProperty, propertyValue); err != nil {
 				return err
 			}
+			r.omitPropertyWhenEmpty(objectInput, schemaDefinitionProperty)
+		}
+		if property.SpecSchemaDefinition.isDiscriminatedUnion() {
+			unionInput, err := property.SpecSchemaDefinition.toUnionPayload(objectInput)
+			if err != nil {
+				return fmt.Errorf("property '%s': %s", property.getTerraformCompliantPropertyName(), err)
+			}
+			objectInput = unionInput
 		}
 		input[property.Name] = objectInput
 	case reflect.Slice, reflect.Array:
@@ -507,6 +1181,10 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 			// array but rather just a json object
 			if property.shouldUseLegacyTerraformSDKBlockApproachForComplexObjects() {
 				arrayValue := dataValue.([]interface{})
+				// Optional nested blocks (at any nesting level) that are not configured are not part of the payload
+				if len(arrayValue) == 0 {
+					return nil
+				}
 				if len(arrayValue) != 1 {
 					return fmt.Errorf("something is really wrong here...an object property with nested objects should have exactly one elem in the terraform state list")
 				}
@@ -568,6 +1246,58 @@ func (r resourceFactory) populatePayload(input map[string]interface{}, property
 	return nil
 }
 
+// getObjectPropertyBasedOnTerraformName returns the object's property matching the given terraform name. If the object is
+// a map or an object that allows additionalProperties and the name does not match any of the fixed properties, an
+// additional property is returned instead.
+// populateMapOfObjectsPayload populates the payload with the map of objects built from the blocks stored in the state,
+// where each block contains the key of the map along with the properties of the object value
+func (r resourceFactory) populateMapOfObjectsPayload(input map[string]interface{}, property *specSchemaDefinitionProperty, dataValue interface{}) error {
+	var items []interface{}
+	switch value := dataValue.(type) {
+	case *schema.Set:
+		items = value.List()
+	case []interface{}:
+		items = value
+	default:
+		return fmt.Errorf("property '%s' is supposed to be a set of objects", property.Name)
+	}
+	mapInput := map[string]interface{}{}
+	for _, item := range items {
+		itemValue, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("property '%s' is supposed to be a set of objects", property.Name)
+		}
+		key, _ := itemValue[mapOfObjectsKeyPropertyName].(string)
+		objectValue := map[string]interface{}{}
+		for name, value := range itemValue {
+			if name != mapOfObjectsKeyPropertyName {
+				objectValue[name] = value
+			}
+		}
+		objectInput := map[string]interface{}{}
+		if err := r.populatePayload(objectInput, property.newAdditionalProperty(key), objectValue); err != nil {
+			return err
+		}
+		mapInput[key] = objectInput[key]
+	}
+	input[property.Name] = mapInput
+	return nil
+}
+
+func (r resourceFactory) getObjectPropertyBasedOnTerraformName(objectProperty *specSchemaDefinitionProperty, terraformName string) (*specSchemaDefinitionProperty, error) {
+	if objectProperty.isMapProperty() {
+		return objectProperty.newAdditionalProperty(terraformName), nil
+	}
+	schemaDefinitionProperty, err := objectProperty.SpecSchemaDefinition.getPropertyBasedOnTerraformName(terraformName)
+	if err != nil {
+		if objectProperty.allowsAdditionalProperties() {
+			return objectProperty.newAdditionalProperty(terraformName), nil
+		}
+		return nil, err
+	}
+	return schemaDefinitionProperty, nil
+}
+
 func (r resourceFactory) getStatusValueFromPayload(payload map[string]interface{}) (string, error) {
 	resourceSchema, err := r.openAPIResource.getResourceSchema()
 	if err != nil {
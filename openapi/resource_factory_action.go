@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// addActionsSchema adds an optional property to the resource schema for each resource action ('x-terraform-resource-action'
+// extension) which value triggers the action when it changes, returning the names of the properties added. The actions
+// whose trigger names collide with an existing property are not added
+func (r resourceFactory) addActionsSchema(resourceSchema map[string]*schema.Schema) []string {
+	var triggerNames []string
+	for _, action := range r.openAPIResource.getResourceOperations().Actions {
+		name := action.getTriggerPropertyName()
+		if _, exists := resourceSchema[name]; exists {
+			r.getLogger().Warn(fmt.Sprintf("resource '%s' already has a property named '%s', the action '%s' will not be available", r.openAPIResource.getResourceName(), name, action.name), "resource", r.openAPIResource.getResourceName())
+			continue
+		}
+		resourceSchema[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Changing the value of this property to a non empty value calls the '%s' action of the resource", action.name),
+		}
+		triggerNames = append(triggerNames, name)
+	}
+	return triggerNames
+}
+
+// getTriggeredActions returns the actions which trigger property values changed to a non empty value
+func (r resourceFactory) getTriggeredActions(data *schema.ResourceData) []*specResourceAction {
+	var actions []*specResourceAction
+	for _, action := range r.openAPIResource.getResourceOperations().Actions {
+		name := action.getTriggerPropertyName()
+		if !r.isActionTrigger(name) || !data.HasChange(name) || data.Get(name).(string) == "" {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+func (r resourceFactory) isActionTrigger(name string) bool {
+	for _, triggerName := range r.actionTriggerNames {
+		if triggerName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// performActions calls the given actions in order, the actions body templates are rendered with the resource attributes
+// (refer to renderBodyTemplateValue)
+func (r resourceFactory) performActions(data *schema.ResourceData, providerClient ClientOpenAPI, actions []*specResourceAction, resourcePath string, parentIDs ...string) error {
+	for _, action := range actions {
+		var requestPayload interface{}
+		if action.body != nil {
+			requestPayload, _ = renderBodyTemplateValue(action.body, r.createPayloadFromLocalStateData(data))
+		}
+		r.getLogger().Debug(fmt.Sprintf("[resource='%s'] calling the action '%s' (%s %s/%s%s)", r.openAPIResource.getResourceName(), action.name, action.method, resourcePath, data.Id(), action.pathSuffix), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+		res, err := providerClient.PerformAction(r.openAPIResource, action, data.Id(), requestPayload, parentIDs...)
+		if err != nil {
+			return err
+		}
+		if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent}); err != nil {
+			return fmt.Errorf("[resource='%s'] %s %s/%s%s action '%s' failed: %s", r.openAPIResource.getResourceName(), action.method, resourcePath, data.Id(), action.pathSuffix, action.name, err)
+		}
+	}
+	return nil
+}
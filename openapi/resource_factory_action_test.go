@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddActionsSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource with a reboot action", t, func() {
+		r := newResourceFactory(&specStubResource{name: "server", resourceActions: []*specResourceAction{{name: "reboot", method: httpPost, pathSuffix: "/reboot"}}})
+		Convey("When addActionsSchema is called with a resource schema without a property named reboot_trigger", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			triggerNames := r.addActionsSchema(resourceSchema)
+			Convey("Then the optional trigger property should be added", func() {
+				So(triggerNames, ShouldResemble, []string{"reboot_trigger"})
+				So(resourceSchema["reboot_trigger"].Optional, ShouldBeTrue)
+				So(resourceSchema["reboot_trigger"].Type, ShouldEqual, schema.TypeString)
+			})
+		})
+		Convey("When addActionsSchema is called with a resource schema that already has a property named reboot_trigger", func() {
+			property := &schema.Schema{Type: schema.TypeInt, Optional: true}
+			resourceSchema := map[string]*schema.Schema{"reboot_trigger": property}
+			triggerNames := r.addActionsSchema(resourceSchema)
+			Convey("Then the resource property should be kept", func() {
+				So(triggerNames, ShouldBeEmpty)
+				So(resourceSchema["reboot_trigger"], ShouldEqual, property)
+			})
+		})
+	})
+}
+
+func TestUpdateWithActions(t *testing.T) {
+	nameProperty := newStringSchemaDefinitionPropertyWithDefaults("name", "", false, false, nil)
+	testCreateActionsResourceFactory := func(config map[string]interface{}) (resourceFactory, *schema.ResourceData) {
+		specResource := newSpecStubResourceWithOperations("server", "/v1/servers", false, newTestSchema(nameProperty).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{})
+		specResource.resourceActions = []*specResourceAction{{name: "reboot", method: httpPost, pathSuffix: "/reboot", body: map[string]interface{}{"name": "{" + nameProperty.Name + "}"}}}
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		r.actionTriggerNames = r.addActionsSchema(resourceSchema)
+		data := schema.TestResourceDataRaw(t, resourceSchema, config)
+		data.SetId("id")
+		return r, data
+	}
+	Convey("Given a resource factory of a resource with a reboot action which trigger is the only property changed", t, func() {
+		r, data := testCreateActionsResourceFactory(map[string]interface{}{"reboot_trigger": "1"})
+		Convey("When update is called", func() {
+			putCalled := false
+			client := &clientOpenAPIStub{
+				funcPut: func() (*http.Response, error) {
+					putCalled = true
+					return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the action should be called without updating the resource", func() {
+				So(err, ShouldBeNil)
+				So(client.actionsReceived, ShouldResemble, []string{"reboot"})
+				So(putCalled, ShouldBeFalse)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource with a reboot action which trigger and properties changed", t, func() {
+		r, data := testCreateActionsResourceFactory(map[string]interface{}{"reboot_trigger": "1", nameProperty.Name: "someValue"})
+		Convey("When update is called", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{nameProperty.Name: "someValue"}}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the resource should be updated and the action called with the body rendered", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(nameProperty.Name), ShouldEqual, "someValue")
+				So(client.actionsReceived, ShouldResemble, []string{"reboot"})
+				So(client.actionPayloadsReceived, ShouldResemble, []interface{}{map[string]interface{}{"name": "someValue"}})
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource with a reboot action which trigger did not change", t, func() {
+		r, data := testCreateActionsResourceFactory(map[string]interface{}{nameProperty.Name: "someValue"})
+		Convey("When update is called", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{nameProperty.Name: "someValue"}}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the action should not be called", func() {
+				So(err, ShouldBeNil)
+				So(client.actionsReceived, ShouldBeEmpty)
+			})
+		})
+	})
+}
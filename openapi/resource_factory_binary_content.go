@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// binaryContentPropertyName is the name of the computed property storing the base64 encoded content of the resources
+// which GET operation returns binary blobs (application/octet-stream), e,g: generated configurations or certificates
+const binaryContentPropertyName = "content_base64"
+
+// addBinaryContentSchema adds the computed property storing the base64 encoded content of the resource to the resource
+// schema if the read operation returns binary blobs, returning whether the property was added. The property is not
+// added if the resource already has a property with the same name
+func (r resourceFactory) addBinaryContentSchema(resourceSchema map[string]*schema.Schema) bool {
+	if !r.openAPIResource.getResourceOperations().Get.returnsBinary() {
+		return false
+	}
+	if _, exists := resourceSchema[binaryContentPropertyName]; exists {
+		r.getLogger().Warn(fmt.Sprintf("resource '%s' already has a property named '%s', the binary content of the resource will not be stored", r.openAPIResource.getResourceName(), binaryContentPropertyName), "resource", r.openAPIResource.getResourceName())
+		return false
+	}
+	resourceSchema[binaryContentPropertyName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The base64 encoded binary content of the resource returned by the API",
+	}
+	return true
+}
+
+// setBinaryContentState stores the binary content contained in the given remote data in the state if the resource read
+// operation returns binary blobs. The binary content is removed from the remote data since it is not part of the
+// resource schema
+func (r resourceFactory) setBinaryContentState(remoteData map[string]interface{}, data *schema.ResourceData) error {
+	content, exists := remoteData[binaryContentPropertyName]
+	if !r.storesBinaryContent || !exists {
+		return nil
+	}
+	delete(remoteData, binaryContentPropertyName)
+	return data.Set(binaryContentPropertyName, content)
+}
+
+// readBinaryContent reads the resource binary content from the API and stores it in the state if the resource read
+// operation returns binary blobs, so the content is available right after the resource is created or updated
+func (r resourceFactory) readBinaryContent(ctx context.Context, data *schema.ResourceData, providerClient ClientOpenAPI, parentIDs ...string) error {
+	if !r.storesBinaryContent {
+		return nil
+	}
+	remoteData, _, err := r.readRemoteAfterCreate(ctx, data.Id(), providerClient, parentIDs...)
+	if err != nil {
+		return err
+	}
+	return r.setBinaryContentState(remoteData, data)
+}
@@ -0,0 +1,89 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddBinaryContentSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource which read operation returns binary blobs", t, func() {
+		r := newResourceFactory(&specStubResource{name: "certificate", resourceGetOperation: &specResourceOperation{responseMediaType: mediaTypeOctetStream}})
+		Convey("When addBinaryContentSchema is called with a resource schema without a property named content_base64", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			added := r.addBinaryContentSchema(resourceSchema)
+			Convey("Then the computed content_base64 property should be added", func() {
+				So(added, ShouldBeTrue)
+				So(resourceSchema[binaryContentPropertyName].Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When addBinaryContentSchema is called with a resource schema that already has a property named content_base64", func() {
+			property := &schema.Schema{Type: schema.TypeString, Optional: true}
+			resourceSchema := map[string]*schema.Schema{binaryContentPropertyName: property}
+			added := r.addBinaryContentSchema(resourceSchema)
+			Convey("Then the resource property should be kept", func() {
+				So(added, ShouldBeFalse)
+				So(resourceSchema[binaryContentPropertyName], ShouldEqual, property)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which read operation returns JSON", t, func() {
+		r := newResourceFactory(&specStubResource{name: "certificate", resourceGetOperation: &specResourceOperation{}})
+		Convey("When addBinaryContentSchema is called", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			Convey("Then the content_base64 property should not be added", func() {
+				So(r.addBinaryContentSchema(resourceSchema), ShouldBeFalse)
+				So(resourceSchema, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestReadWithBinaryContent(t *testing.T) {
+	Convey("Given a resource factory of a resource which read operation returns binary blobs", t, func() {
+		specResource := newSpecStubResourceWithOperations("certificate", "/v1/certificates", false, newTestSchema(idProperty, stringProperty).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{responseMediaType: mediaTypeOctetStream}, &specResourceOperation{})
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		r.storesBinaryContent = r.addBinaryContentSchema(resourceSchema)
+		data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		data.SetId("id")
+		Convey("When read is called with a client that returns the binary content of the resource", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{binaryContentPropertyName: "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"}}
+			err := r.read(context.Background(), data, client)
+			Convey("Then the binary content should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(binaryContentPropertyName), ShouldEqual, "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t")
+			})
+			Convey("And the rest of the state should be kept", func() {
+				So(data.Get(stringProperty.Name), ShouldEqual, stringProperty.Default)
+			})
+		})
+		Convey("When create is called with a client that returns the binary content of the resource", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{idProperty.Name: "id", binaryContentPropertyName: "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"}}
+			err := r.create(context.Background(), data, client)
+			Convey("Then the binary content read after the resource is created should be stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(binaryContentPropertyName), ShouldEqual, "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t")
+				So(client.idReceived, ShouldEqual, "id")
+			})
+		})
+		Convey("When create is called with a POST operation configured to read the resource again and the resource is not visible yet when its binary content is read", func() {
+			specResource.getResourceOperations().Post.readAfterCreateRetries = 1
+			r.readAfterCreateRetryInterval = time.Millisecond
+			client := &clientOpenAPINotFoundStub{
+				clientOpenAPIStub: clientOpenAPIStub{responsePayload: map[string]interface{}{idProperty.Name: "id", binaryContentPropertyName: "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"}},
+				notFoundResponses: 1,
+			}
+			err := r.create(context.Background(), data, client)
+			Convey("Then the binary content should be read again until the resource is visible", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 2)
+				So(data.Get(binaryContentPropertyName), ShouldEqual, "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t")
+			})
+		})
+	})
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+const ifNoneMatchHeader = "If-None-Match"
+
+// specResourceWithIfNoneMatch decorates the resources configured with conditional reads so the read operation sends the
+// If-None-Match header with the ETag of the resource stored in the state
+type specResourceWithIfNoneMatch struct {
+	SpecResource
+	ifNoneMatch string
+}
+
+// getResourceOperations returns a copy of the resource operations where the read operation contains the If-None-Match
+// header
+func (r *specResourceWithIfNoneMatch) getResourceOperations() specResourceOperations {
+	operations := r.SpecResource.getResourceOperations()
+	if operations.Get != nil {
+		operationWithIfNoneMatch := *operations.Get
+		operationWithIfNoneMatch.HeaderParameters = append(append(SpecHeaderParameters{}, operations.Get.HeaderParameters...), SpecHeaderParam{Name: ifNoneMatchHeader, IsResourceAttribute: true, value: r.ifNoneMatch})
+		operations.Get = &operationWithIfNoneMatch
+	}
+	return operations
+}
+
+// getConditionalReadETag returns the ETag stored in the state if the resource read operation is conditional, empty
+// otherwise (or if the ETag is not known yet, e,g: when the resource is imported)
+func (r resourceFactory) getConditionalReadETag(data *schema.ResourceData) string {
+	if !r.storesETag || !r.openAPIResource.getResourceOperations().Get.isConditionalRead() {
+		return ""
+	}
+	return data.Get(etagPropertyName).(string)
+}
+
+// withIfNoneMatch returns the resource to use in the read requests so the API only returns the resource if its ETag
+// does not match the given one, empty ETags are not sent
+func (r resourceFactory) withIfNoneMatch(etag string) SpecResource {
+	if etag == "" {
+		return r.openAPIResource
+	}
+	return &specResourceWithIfNoneMatch{SpecResource: r.openAPIResource, ifNoneMatch: etag}
+}
@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// clientOpenAPINotModifiedStub responds to the GET requests with 304 Not Modified if the If-None-Match header received
+// matches the given ETag, recording the If-None-Match header received
+type clientOpenAPINotModifiedStub struct {
+	clientOpenAPIStub
+	etag                string
+	ifNoneMatchReceived string
+}
+
+func (c *clientOpenAPINotModifiedStub) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	for _, headerParam := range resource.getResourceOperations().Get.HeaderParameters {
+		if headerParam.Name == ifNoneMatchHeader {
+			c.ifNoneMatchReceived = headerParam.value
+		}
+	}
+	header := http.Header{http.CanonicalHeaderKey(etagHeader): []string{c.etag}}
+	if c.ifNoneMatchReceived == c.etag {
+		return &http.Response{StatusCode: http.StatusNotModified, Body: ioutil.NopCloser(strings.NewReader("")), Header: header}, nil
+	}
+	*responsePayload.(*map[string]interface{}) = c.responsePayload
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("")), Header: header}, nil
+}
+
+func TestReadWithConditionalRead(t *testing.T) {
+	testCreateConditionalReadResourceFactory := func(conditionalRead bool) (resourceFactory, *schema.ResourceData) {
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, newTestSchema(stringProperty).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{conditionalRead: conditionalRead}, &specResourceOperation{})
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		r.storesETag = r.addETagSchema(resourceSchema)
+		data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		data.SetId("id")
+		return r, data
+	}
+	Convey("Given a resource factory of a resource which read operation is conditional", t, func() {
+		r, data := testCreateConditionalReadResourceFactory(true)
+		Convey("When read is called and the state does not contain the ETag of the resource yet", func() {
+			client := &clientOpenAPINotModifiedStub{clientOpenAPIStub: clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"}}, etag: `"v1"`}
+			err := r.read(context.Background(), data, client)
+			Convey("Then the If-None-Match header should not be sent", func() {
+				So(err, ShouldBeNil)
+				So(client.ifNoneMatchReceived, ShouldBeEmpty)
+			})
+			Convey("And the state should be updated with the resource and its ETag", func() {
+				So(data.Get(stringProperty.Name), ShouldEqual, "someUpdatedValue")
+				So(data.Get(etagPropertyName), ShouldEqual, `"v1"`)
+			})
+		})
+		Convey("When read is called and the resource has not been modified since it was last read", func() {
+			So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+			client := &clientOpenAPINotModifiedStub{clientOpenAPIStub: clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"}}, etag: `"v1"`}
+			err := r.read(context.Background(), data, client)
+			Convey("Then the If-None-Match header should be sent with the ETag stored in the state", func() {
+				So(err, ShouldBeNil)
+				So(client.ifNoneMatchReceived, ShouldEqual, `"v1"`)
+			})
+			Convey("And the state should be kept as is", func() {
+				So(data.Id(), ShouldEqual, "id")
+				So(data.Get(stringProperty.Name), ShouldEqual, stringProperty.Default)
+				So(data.Get(etagPropertyName), ShouldEqual, `"v1"`)
+			})
+		})
+		Convey("When read is called and the resource has been modified since it was last read", func() {
+			So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+			client := &clientOpenAPINotModifiedStub{clientOpenAPIStub: clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"}}, etag: `"v2"`}
+			err := r.read(context.Background(), data, client)
+			Convey("Then the state should be updated with the resource and its new ETag", func() {
+				So(err, ShouldBeNil)
+				So(data.Get(stringProperty.Name), ShouldEqual, "someUpdatedValue")
+				So(data.Get(etagPropertyName), ShouldEqual, `"v2"`)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which read operation is not conditional", t, func() {
+		r, data := testCreateConditionalReadResourceFactory(false)
+		Convey("When read is called", func() {
+			client := &clientOpenAPINotModifiedStub{clientOpenAPIStub: clientOpenAPIStub{responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"}}, etag: `"v1"`}
+			err := r.read(context.Background(), data, client)
+			Convey("Then the If-None-Match header should not be sent and the etag property should not be part of the schema", func() {
+				So(err, ShouldBeNil)
+				So(client.ifNoneMatchReceived, ShouldBeEmpty)
+				So(r.storesETag, ShouldBeFalse)
+			})
+		})
+	})
+}
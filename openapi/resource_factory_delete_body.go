@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// createDeletePayload returns the request body of the delete requests, nil if the DELETE operation does not define a
+// body parameter nor the 'x-terraform-delete-body' extension. The properties of the body parameter are populated with
+// the resource attributes with the same names, and the template values take preference (refer to renderBodyTemplateValue)
+func (r resourceFactory) createDeletePayload(operation *specResourceOperation, data *schema.ResourceData) interface{} {
+	if len(operation.deleteBodyProperties) == 0 && operation.deleteBodyTemplate == nil {
+		return nil
+	}
+	attributes := r.createPayloadFromLocalStateData(data)
+	payload := map[string]interface{}{}
+	for _, propertyName := range operation.deleteBodyProperties {
+		if value, exists := attributes[propertyName]; exists {
+			payload[propertyName] = value
+		}
+	}
+	for name, value := range operation.deleteBodyTemplate {
+		if renderedValue, ok := renderBodyTemplateValue(value, attributes); ok {
+			payload[name] = renderedValue
+		}
+	}
+	return payload
+}
+
+// renderBodyTemplateValue returns the given template value replacing the strings with the form {property_name} with the
+// value of the resource attribute (e,g: "{name}"), objects and lists are rendered recursively. False is returned if the
+// value references an attribute which value is not known so it is not sent
+func renderBodyTemplateValue(value interface{}, attributes map[string]interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(v, "{") && strings.HasSuffix(v, "}") {
+			attributeValue, exists := attributes[strings.TrimSuffix(strings.TrimPrefix(v, "{"), "}")]
+			return attributeValue, exists
+		}
+	case map[string]interface{}:
+		object := map[string]interface{}{}
+		for name, item := range v {
+			if renderedItem, ok := renderBodyTemplateValue(item, attributes); ok {
+				object[name] = renderedItem
+			}
+		}
+		return object, true
+	case []interface{}:
+		list := []interface{}{}
+		for _, item := range v {
+			if renderedItem, ok := renderBodyTemplateValue(item, attributes); ok {
+				list = append(list, renderedItem)
+			}
+		}
+		return list, true
+	}
+	return value, true
+}
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateDeletePayload(t *testing.T) {
+	Convey("Given a resource factory", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty, boolProperty)
+		Convey("When createDeletePayload is called with an operation that has a body parameter", func() {
+			payload := r.createDeletePayload(&specResourceOperation{deleteBodyProperties: []string{boolProperty.Name, "reason"}}, resourceData)
+			Convey("Then the payload should contain the values of the resource attributes with the same names", func() {
+				So(payload, ShouldResemble, map[string]interface{}{boolProperty.Name: boolProperty.Default})
+			})
+		})
+		Convey("When createDeletePayload is called with an operation that has a delete body template", func() {
+			operation := &specResourceOperation{
+				deleteBodyProperties: []string{boolProperty.Name},
+				deleteBodyTemplate: map[string]interface{}{
+					boolProperty.Name: true,
+					"reason":          "deleted by terraform",
+					"options":         map[string]interface{}{"name": "{" + stringProperty.Name + "}", "unknown": "{unknown_property}"},
+				},
+			}
+			payload := r.createDeletePayload(operation, resourceData)
+			Convey("Then the payload should contain the template values taking preference and the placeholders replaced", func() {
+				So(payload, ShouldResemble, map[string]interface{}{
+					boolProperty.Name: true,
+					"reason":          "deleted by terraform",
+					"options":         map[string]interface{}{"name": stringProperty.Default},
+				})
+			})
+		})
+		Convey("When createDeletePayload is called with an operation without body", func() {
+			payload := r.createDeletePayload(&specResourceOperation{}, resourceData)
+			Convey("Then the payload should be nil", func() {
+				So(payload, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestDeleteWithPayload(t *testing.T) {
+	Convey("Given a resource factory which delete operation has a delete body template", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).resourceDeleteOperation = &specResourceOperation{deleteBodyTemplate: map[string]interface{}{"force": true}}
+		Convey("When delete is called", func() {
+			client := &clientOpenAPIStub{}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the delete request should contain the payload", func() {
+				So(err, ShouldBeNil)
+				So(client.deletePayloadReceived, ShouldResemble, map[string]interface{}{"force": true})
+			})
+		})
+	})
+}
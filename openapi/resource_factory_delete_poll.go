@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deletePending is the internal state used when verifying the resource deletion while the resource still exists
+const deletePending = "delete_pending"
+
+// waitForDeletionIfConfigured reads the resource after the DELETE request succeeds until the resource is gone if the
+// operation is configured with the 'x-terraform-resource-delete-poll' extension, so the delete does not complete while
+// the resource still exists in the API
+func (r resourceFactory) waitForDeletionIfConfigured(ctx context.Context, data *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, parentIDs ...string) error {
+	deletePoll := operation.deletePoll
+	if deletePoll == nil {
+		return nil
+	}
+	pollInterval := r.defaultPollInterval
+	if deletePoll.interval != nil {
+		pollInterval = *deletePoll.interval
+	}
+	r.getLogger().Info(fmt.Sprintf("Waiting for resource '%s' (%s) to be deleted", r.openAPIResource.getResourceName(), data.Id()), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{deletePending},
+		Target:       []string{defaultDestroyStatus},
+		Refresh:      r.deletionStateRefreshFunc(deletePoll, data.Id(), providerClient, parentIDs...),
+		Timeout:      data.Timeout(schema.TimeoutDelete),
+		PollInterval: pollInterval,
+		Delay:        r.defaultPollDelay,
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for resource to be deleted: %s", err)
+	}
+	return nil
+}
+
+// deletionStateRefreshFunc returns the destroyed state once the resource is not found (404 Not Found or 410 Gone) or its
+// status is one of the deleted statuses, the pending state otherwise
+func (r resourceFactory) deletionStateRefreshFunc(deletePoll *specDeletePoll, id string, providerClient ClientOpenAPI, parentIDs ...string) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		remoteData, err := r.readRemote(id, providerClient, parentIDs...)
+		if err != nil {
+			if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+				return 0, defaultDestroyStatus, nil
+			}
+			return nil, "", fmt.Errorf("error on retrieving resource '%s' (%s) when waiting for the deletion: %s", r.openAPIResource.getResourceName(), id, err)
+		}
+		if len(deletePoll.deletedStatuses) > 0 {
+			status, err := r.getStatusValueFromPayload(remoteData)
+			if err != nil {
+				return nil, "", fmt.Errorf("error occurred while retrieving status identifier value from payload for resource '%s' (%s): %s", r.openAPIResource.getResourceName(), id, err)
+			}
+			if deletePoll.isDeletedStatus(status) {
+				return remoteData, defaultDestroyStatus, nil
+			}
+			r.getLogger().Debug(fmt.Sprintf("resource '%s' (%s) still exists with status: %s", r.openAPIResource.getResourceName(), id, status), "resource", r.openAPIResource.getResourceName(), "id", id, "status", status)
+		}
+		return remoteData, deletePending, nil
+	}
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// clientOpenAPIDeletePollStub responds to the GET requests with the given responses (in order), the last one being
+// returned once the rest have been consumed
+type clientOpenAPIDeletePollStub struct {
+	clientOpenAPIStub
+	getResponses []deletePollStubResponse
+	getCalls     int
+}
+
+type deletePollStubResponse struct {
+	statusCode int
+	payload    map[string]interface{}
+}
+
+func (c *clientOpenAPIDeletePollStub) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	response := c.getResponses[len(c.getResponses)-1]
+	if c.getCalls < len(c.getResponses) {
+		response = c.getResponses[c.getCalls]
+	}
+	c.getCalls++
+	*responsePayload.(*map[string]interface{}) = response.payload
+	return &http.Response{StatusCode: response.statusCode, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestDeleteWithDeletePoll(t *testing.T) {
+	testCreateDeletePollResourceFactory := func(deletePoll *specDeletePoll) (resourceFactory, *schema.ResourceData) {
+		testSchema := newTestSchema(idProperty, stringProperty, statusProperty)
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{deletePoll: deletePoll})
+		r := newResourceFactory(specResource)
+		r.defaultPollDelay = 0
+		resourceData := testSchema.getResourceData(t)
+		resourceData.SetId(idProperty.Default.(string))
+		return r, resourceData
+	}
+	interval := time.Millisecond
+	existing := deletePollStubResponse{statusCode: http.StatusOK, payload: map[string]interface{}{idProperty.Name: idProperty.Default, statusProperty.Name: "deleting"}}
+	Convey("Given a resource factory which delete operation is configured to verify the resource is gone", t, func() {
+		r, resourceData := testCreateDeletePollResourceFactory(&specDeletePoll{interval: &interval})
+		Convey("When delete is called and the API responds with 410 Gone once the resource is deleted", func() {
+			client := &clientOpenAPIDeletePollStub{
+				clientOpenAPIStub: clientOpenAPIStub{returnHTTPCode: http.StatusAccepted},
+				getResponses:      []deletePollStubResponse{existing, existing, {statusCode: http.StatusGone}},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the resource should be read until it is gone", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 3)
+			})
+		})
+		Convey("When delete is called and the resource is never deleted", func() {
+			client := &clientOpenAPIDeletePollStub{
+				clientOpenAPIStub: clientOpenAPIStub{returnHTTPCode: http.StatusAccepted},
+				getResponses:      []deletePollStubResponse{existing},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			err := r.delete(ctx, resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldStartWith, "[resource='resourceName'] DELETE /v1/resource/id verification failed: error waiting for resource to be deleted")
+			})
+		})
+		Convey("When delete is called and the read fails", func() {
+			client := &clientOpenAPIDeletePollStub{
+				clientOpenAPIStub: clientOpenAPIStub{returnHTTPCode: http.StatusAccepted},
+				getResponses:      []deletePollStubResponse{{statusCode: http.StatusInternalServerError}},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the read one", func() {
+				So(err.Error(), ShouldContainSubstring, "error on retrieving resource 'resourceName' (id) when waiting for the deletion: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()")
+			})
+		})
+	})
+	Convey("Given a resource factory which delete operation is configured with deleted statuses", t, func() {
+		r, resourceData := testCreateDeletePollResourceFactory(&specDeletePoll{deletedStatuses: []string{"deleted"}, interval: &interval})
+		Convey("When delete is called and the resource status becomes one of the deleted statuses", func() {
+			client := &clientOpenAPIDeletePollStub{
+				clientOpenAPIStub: clientOpenAPIStub{returnHTTPCode: http.StatusAccepted},
+				getResponses:      []deletePollStubResponse{existing, {statusCode: http.StatusOK, payload: map[string]interface{}{idProperty.Name: idProperty.Default, statusProperty.Name: "deleted"}}},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the resource should be read until its status is deleted", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 2)
+			})
+		})
+	})
+	for _, goneStatusCode := range []int{http.StatusNotFound, http.StatusGone} {
+		goneStatusCode := goneStatusCode
+		Convey(fmt.Sprintf("Given a resource factory which delete operation is configured to verify the resource is gone and an API that responds with %d and an empty body once the resource is deleted", goneStatusCode), t, func() {
+			var requests []string
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method)
+				switch {
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusAccepted)
+				case len(requests) == 2:
+					w.Write([]byte(`{"id":"id","status":"deleting"}`))
+				default:
+					w.WriteHeader(goneStatusCode)
+				}
+			}))
+			defer api.Close()
+			r, resourceData := testCreateDeletePollResourceFactory(&specDeletePoll{interval: &interval})
+			Convey("When delete is called with a provider client", func() {
+				err := r.delete(context.Background(), resourceData, newTestAPIProviderClient(api.URL))
+				Convey("Then the resource should be read until it is gone", func() {
+					So(err, ShouldBeNil)
+					So(requests, ShouldResemble, []string{http.MethodDelete, http.MethodGet, http.MethodGet})
+				})
+			})
+		})
+	}
+	Convey("Given a resource factory which delete operation is not configured to verify the resource is gone", t, func() {
+		r, resourceData := testCreateDeletePollResourceFactory(nil)
+		Convey("When delete is called", func() {
+			client := &clientOpenAPIDeletePollStub{
+				clientOpenAPIStub: clientOpenAPIStub{returnHTTPCode: http.StatusAccepted},
+				getResponses:      []deletePollStubResponse{existing},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the resource should not be read", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 0)
+			})
+		})
+	})
+}
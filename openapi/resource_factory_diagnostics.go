@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceOperation defines the signature of the resource and data source operations. The errors returned by the
+// operations are translated into diagnostics with withDiagnostics
+type resourceOperation func(ctx context.Context, data *schema.ResourceData, i interface{}) error
+
+// attributeError describes an error that can be correlated to a resource attribute
+type attributeError struct {
+	path    cty.Path
+	message string
+}
+
+// attributeErrors is returned when the API rejects some of the resource attributes (e,g: the field errors documented
+// with the 'x-terraform-error-fields' extension). Each attribute error is returned to Terraform as a diagnostic with the
+// attribute path so Terraform can point at the attribute in the configuration
+type attributeErrors struct {
+	resourceName    string
+	statusCode      int
+	attributeErrors []attributeError
+	// unmappedErrors contains the field errors that could not be correlated to any of the resource attributes
+	unmappedErrors []string
+}
+
+func (a *attributeErrors) Error() string {
+	var errs []string
+	for _, attributeErr := range a.attributeErrors {
+		errs = append(errs, fmt.Sprintf("- attribute '%s': %s", formatAttributePath(attributeErr.path), attributeErr.message))
+	}
+	errs = append(errs, a.unmappedErrors...)
+	return fmt.Sprintf("[resource='%s'] HTTP Response Status Code %d - the following attributes are not valid:\n%s", a.resourceName, a.statusCode, strings.Join(errs, "\n"))
+}
+
+// diagnostics returns a diagnostic per attribute error. The detail of the diagnostics contains the error message
+// provided which is expected to describe the operation that failed
+func (a *attributeErrors) diagnostics(detail string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, attributeErr := range a.attributeErrors {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("[resource='%s'] attribute '%s' is not valid: %s", a.resourceName, formatAttributePath(attributeErr.path), attributeErr.message),
+			Detail:        detail,
+			AttributePath: attributeErr.path,
+		})
+	}
+	return diags
+}
+
+// withDiagnostics returns the Terraform context aware function for the given operation, translating the error returned
+// by the operation (if any) into diagnostics
+func withDiagnostics(operation resourceOperation) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) diag.Diagnostics {
+		return errorToDiagnostics(operation(ctx, data, i))
+	}
+}
+
+// errorToDiagnostics translates the given error into diagnostics. Errors caused by attributes that are not valid result
+// into one diagnostic per attribute containing the attribute path, any other error results into a single diagnostic
+func errorToDiagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var attributeErrs *attributeErrors
+	if errors.As(err, &attributeErrs) {
+		return attributeErrs.diagnostics(err.Error())
+	}
+	return diag.FromErr(err)
+}
+
+// formatAttributePath returns the given attribute path using the dot separated format used by Terraform in the state
+// (e,g: nested_object.0.some_property)
+func formatAttributePath(attributePath cty.Path) string {
+	var steps []string
+	for _, step := range attributePath {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			steps = append(steps, step.Name)
+		case cty.IndexStep:
+			if step.Key.Type() == cty.Number {
+				steps = append(steps, step.Key.AsBigFloat().Text('f', -1))
+			} else {
+				steps = append(steps, step.Key.AsString())
+			}
+		}
+	}
+	return strings.Join(steps, ".")
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorToDiagnostics(t *testing.T) {
+	attributeErrs := &attributeErrors{
+		resourceName: "cdn_v1",
+		statusCode:   422,
+		attributeErrors: []attributeError{
+			{path: cty.GetAttrPath("label"), message: "must not be empty"},
+			{path: cty.GetAttrPath("listeners").IndexInt(1).GetAttr("port"), message: "must be greater than 0"},
+		},
+		unmappedErrors: []string{"- field 'unknown': not allowed"},
+	}
+	wrappedAttributeErrs := fmt.Errorf("[resource='cdn_v1'] POST /v1/cdns failed: %w", attributeErrs)
+	testCases := []struct {
+		name          string
+		err           error
+		expectedDiags diag.Diagnostics
+	}{
+		{
+			name:          "no error",
+			err:           nil,
+			expectedDiags: nil,
+		},
+		{
+			name:          "resource level error",
+			err:           errors.New("some error"),
+			expectedDiags: diag.Diagnostics{{Severity: diag.Error, Summary: "some error"}},
+		},
+		{
+			name: "attribute errors wrapped by the operation error",
+			err:  wrappedAttributeErrs,
+			expectedDiags: diag.Diagnostics{
+				{
+					Severity:      diag.Error,
+					Summary:       "[resource='cdn_v1'] attribute 'label' is not valid: must not be empty",
+					Detail:        "[resource='cdn_v1'] POST /v1/cdns failed: [resource='cdn_v1'] HTTP Response Status Code 422 - the following attributes are not valid:\n- attribute 'label': must not be empty\n- attribute 'listeners.1.port': must be greater than 0\n- field 'unknown': not allowed",
+					AttributePath: cty.GetAttrPath("label"),
+				},
+				{
+					Severity:      diag.Error,
+					Summary:       "[resource='cdn_v1'] attribute 'listeners.1.port' is not valid: must be greater than 0",
+					Detail:        "[resource='cdn_v1'] POST /v1/cdns failed: [resource='cdn_v1'] HTTP Response Status Code 422 - the following attributes are not valid:\n- attribute 'label': must not be empty\n- attribute 'listeners.1.port': must be greater than 0\n- field 'unknown': not allowed",
+					AttributePath: cty.GetAttrPath("listeners").IndexInt(1).GetAttr("port"),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDiags, errorToDiagnostics(tc.err), tc.name)
+	}
+}
+
+func TestWithDiagnostics(t *testing.T) {
+	expectedErr := errors.New("some error")
+	diags := withDiagnostics(func(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+		return expectedErr
+	})(context.Background(), nil, nil)
+	assert.Equal(t, diag.FromErr(expectedErr), diags)
+
+	diags = withDiagnostics(func(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+		return nil
+	})(context.Background(), nil, nil)
+	assert.Nil(t, diags)
+}
+
+func TestFormatAttributePath(t *testing.T) {
+	assert.Equal(t, "label", formatAttributePath(cty.GetAttrPath("label")))
+	assert.Equal(t, "complex_object.0.host_name", formatAttributePath(cty.GetAttrPath("complex_object").IndexInt(0).GetAttr("host_name")))
+	assert.Equal(t, "simple_object.origin", formatAttributePath(cty.GetAttrPath("simple_object").IndexString("origin")))
+	assert.Equal(t, "", formatAttributePath(cty.Path{}))
+}
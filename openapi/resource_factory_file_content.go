@@ -0,0 +1,46 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// withFileContents returns the request payload where the values of the file content properties ('x-terraform-file-content'
+// extension) are replaced with the files sent as file parts in the multipart/form-data requests: the content of the
+// file the path points at or the decoded base64 content. The payload is returned as is if the operation does not send
+// the request payload as multipart/form-data
+func (r resourceFactory) withFileContents(operation *specResourceOperation, payload map[string]interface{}) (map[string]interface{}, error) {
+	if !operation.sendsMultipartFormData() {
+		return payload, nil
+	}
+	resourceSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return nil, err
+	}
+	payloadWithFiles := make(map[string]interface{}, len(payload))
+	for name, value := range payload {
+		payloadWithFiles[name] = value
+	}
+	for _, property := range resourceSchema.Properties {
+		value, ok := payload[property.Name].(string)
+		if property.FileContent == "" || !ok {
+			continue
+		}
+		file := multipartFile{fileName: property.Name}
+		switch property.FileContent {
+		case fileContentPath:
+			file.fileName = filepath.Base(value)
+			if file.content, err = ioutil.ReadFile(value); err != nil {
+				return nil, fmt.Errorf("failed to read the file of the property '%s': %s", property.Name, err)
+			}
+		case fileContentBase64:
+			if file.content, err = base64.StdEncoding.DecodeString(value); err != nil {
+				return nil, fmt.Errorf("failed to decode the base64 content of the property '%s': %s", property.Name, err)
+			}
+		}
+		payloadWithFiles[property.Name] = file
+	}
+	return payloadWithFiles, nil
+}
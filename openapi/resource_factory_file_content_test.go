@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWithFileContents(t *testing.T) {
+	Convey("Given a resource factory of a resource with file content properties", t, func() {
+		pathProperty := newStringSchemaDefinitionPropertyWithDefaults("certificate", "", true, false, nil)
+		pathProperty.FileContent = fileContentPath
+		base64Property := newStringSchemaDefinitionPropertyWithDefaults("private_key", "", true, false, nil)
+		base64Property.FileContent = fileContentBase64
+		r := newResourceFactory(newSpecStubResourceWithOperations("certificate", "/v1/certificates", false, newTestSchema(pathProperty, base64Property, stringProperty).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		certificatePath := filepath.Join(t.TempDir(), "cert.pem")
+		So(ioutil.WriteFile(certificatePath, []byte("certificate content"), 0600), ShouldBeNil)
+		payload := map[string]interface{}{pathProperty.Name: certificatePath, base64Property.Name: "a2V5IGNvbnRlbnQ=", stringProperty.Name: "someValue"}
+		Convey("When withFileContents is called with an operation that consumes multipart/form-data", func() {
+			payloadWithFiles, err := r.withFileContents(&specResourceOperation{requestMediaType: mediaTypeMultipartFormData}, payload)
+			Convey("Then the file content properties should be replaced with the files", func() {
+				So(err, ShouldBeNil)
+				So(payloadWithFiles, ShouldResemble, map[string]interface{}{
+					pathProperty.Name:   multipartFile{fileName: "cert.pem", content: []byte("certificate content")},
+					base64Property.Name: multipartFile{fileName: base64Property.Name, content: []byte("key content")},
+					stringProperty.Name: "someValue",
+				})
+			})
+			Convey("And the given payload should not be modified", func() {
+				So(payload[pathProperty.Name], ShouldEqual, certificatePath)
+			})
+		})
+		Convey("When withFileContents is called with an operation that does not consume multipart/form-data", func() {
+			payloadWithFiles, err := r.withFileContents(&specResourceOperation{}, payload)
+			Convey("Then the payload should be returned as is", func() {
+				So(err, ShouldBeNil)
+				So(payloadWithFiles, ShouldResemble, payload)
+			})
+		})
+		Convey("When withFileContents is called with a path that does not exist", func() {
+			_, err := r.withFileContents(&specResourceOperation{requestMediaType: mediaTypeMultipartFormData}, map[string]interface{}{pathProperty.Name: filepath.Join(os.TempDir(), "does-not-exist.pem")})
+			Convey("Then the error returned should reference the property", func() {
+				So(err.Error(), ShouldStartWith, "failed to read the file of the property 'certificate':")
+			})
+		})
+		Convey("When withFileContents is called with a content that is not base64 encoded", func() {
+			_, err := r.withFileContents(&specResourceOperation{requestMediaType: mediaTypeMultipartFormData}, map[string]interface{}{base64Property.Name: "not base64!"})
+			Convey("Then the error returned should reference the property", func() {
+				So(err.Error(), ShouldStartWith, "failed to decode the base64 content of the property 'private_key':")
+			})
+		})
+	})
+}
+
+func TestCreateWithFileContents(t *testing.T) {
+	Convey("Given a resource factory of a resource which POST operation consumes multipart/form-data", t, func() {
+		base64Property := newStringSchemaDefinitionPropertyWithDefaults("private_key", "", true, false, "a2V5IGNvbnRlbnQ=")
+		base64Property.FileContent = fileContentBase64
+		base64Property.WriteOnly = true
+		r, resourceData := testCreateResourceFactory(t, idProperty, base64Property)
+		r.openAPIResource.(*specStubResource).resourcePostOperation = &specResourceOperation{requestMediaType: mediaTypeMultipartFormData}
+		Convey("When create is called", func() {
+			client := &clientOpenAPIStub{responsePayload: map[string]interface{}{idProperty.Name: idProperty.Default}}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the file content property should be sent as a file", func() {
+				So(err, ShouldBeNil)
+				So(client.postPayloadReceived.(map[string]interface{})[base64Property.Name], ShouldResemble, multipartFile{fileName: base64Property.Name, content: []byte("key content")})
+			})
+			Convey("And the value configured should be kept in the state", func() {
+				So(resourceData.Get(base64Property.Name), ShouldEqual, "a2V5IGNvbnRlbnQ=")
+			})
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// specResourceWithHeaders decorates the resources whose operations require headers that are resource attributes
+// ('x-terraform-header-mode' extension set to 'resource') so the operations carry the values configured in the resource
+type specResourceWithHeaders struct {
+	SpecResource
+	headerValues map[string]string
+}
+
+// getResourceOperations returns a copy of the resource operations with the values of the headers that are resource
+// attributes populated
+func (r *specResourceWithHeaders) getResourceOperations() specResourceOperations {
+	operations := r.SpecResource.getResourceOperations()
+	operations.List = r.withHeaderValues(operations.List)
+	operations.Post = r.withHeaderValues(operations.Post)
+	operations.Get = r.withHeaderValues(operations.Get)
+	operations.Put = r.withHeaderValues(operations.Put)
+	operations.Patch = r.withHeaderValues(operations.Patch)
+	operations.Delete = r.withHeaderValues(operations.Delete)
+	actions := make([]*specResourceAction, len(operations.Actions))
+	for i, action := range operations.Actions {
+		actionWithHeaderValues := *action
+		actionWithHeaderValues.operation = r.withHeaderValues(action.operation)
+		actions[i] = &actionWithHeaderValues
+	}
+	operations.Actions = actions
+	return operations
+}
+
+func (r *specResourceWithHeaders) withHeaderValues(operation *specResourceOperation) *specResourceOperation {
+	if operation == nil {
+		return nil
+	}
+	operationWithHeaderValues := *operation
+	operationWithHeaderValues.HeaderParameters = make(SpecHeaderParameters, len(operation.HeaderParameters))
+	for i, headerParam := range operation.HeaderParameters {
+		if headerParam.IsResourceAttribute {
+			headerParam.value = r.headerValues[headerParam.GetHeaderTerraformConfigurationName()]
+		}
+		operationWithHeaderValues.HeaderParameters[i] = headerParam
+	}
+	return &operationWithHeaderValues
+}
+
+// getResourceHeaderParameters returns the headers that are resource attributes required by the operations of the given
+// resource, the headers used by multiple operations are only returned once
+func getResourceHeaderParameters(resource SpecResource) SpecHeaderParameters {
+	operations := resource.getResourceOperations()
+	headerParameters := SpecHeaderParameters{}
+	operationsWithHeaders := []*specResourceOperation{operations.List, operations.Post, operations.Get, operations.Put, operations.Patch, operations.Delete}
+	for _, action := range operations.Actions {
+		operationsWithHeaders = append(operationsWithHeaders, action.operation)
+	}
+	for _, operation := range operationsWithHeaders {
+		if operation == nil {
+			continue
+		}
+		for _, headerParam := range operation.HeaderParameters {
+			if headerParam.IsResourceAttribute && !headerParameters.specHeaderExists(headerParam) {
+				headerParameters = append(headerParameters, headerParam)
+			}
+		}
+	}
+	return headerParameters
+}
+
+// addHeadersSchema adds an optional property to the resource schema for each header that is a resource attribute,
+// returning the headers whose properties were added. The headers whose names collide with an existing property are
+// not added
+func (r resourceFactory) addHeadersSchema(resourceSchema map[string]*schema.Schema) SpecHeaderParameters {
+	var headerParameters SpecHeaderParameters
+	for _, headerParam := range getResourceHeaderParameters(r.openAPIResource) {
+		name := headerParam.GetHeaderTerraformConfigurationName()
+		if _, exists := resourceSchema[name]; exists {
+			r.getLogger().Warn(fmt.Sprintf("resource '%s' already has a property named '%s', the header '%s' will be sent without value", r.openAPIResource.getResourceName(), name, headerParam.Name), "resource", r.openAPIResource.getResourceName())
+			continue
+		}
+		resourceSchema[name] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("The value of the '%s' header sent in the API requests of the resource", headerParam.Name),
+		}
+		headerParameters = append(headerParameters, headerParam)
+	}
+	return headerParameters
+}
+
+// withResourceHeaders returns the resource factory to use for the given resource data: if the resource operations
+// require headers that are resource attributes, the resource is decorated so the API calls send the values configured
+func (r resourceFactory) withResourceHeaders(data *schema.ResourceData) resourceFactory {
+	if len(r.resourceHeaders) == 0 || data == nil {
+		return r
+	}
+	if _, ok := r.openAPIResource.(*specResourceWithHeaders); ok {
+		return r
+	}
+	headerValues := map[string]string{}
+	for _, headerParam := range r.resourceHeaders {
+		name := headerParam.GetHeaderTerraformConfigurationName()
+		if value, ok := data.GetOk(name); ok {
+			headerValues[name] = value.(string)
+		}
+	}
+	r.openAPIResource = &specResourceWithHeaders{SpecResource: r.openAPIResource, headerValues: headerValues}
+	return r
+}
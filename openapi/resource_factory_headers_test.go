@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddHeadersSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource which operations require headers that are resource attributes", t, func() {
+		requestTierHeader := SpecHeaderParam{Name: "X-Request-Tier", IsResourceAttribute: true}
+		resource := &specStubResource{
+			name:                  "cdn",
+			resourcePostOperation: &specResourceOperation{HeaderParameters: SpecHeaderParameters{requestTierHeader, {Name: "X-Request-ID"}}},
+			resourceGetOperation:  &specResourceOperation{HeaderParameters: SpecHeaderParameters{requestTierHeader}},
+		}
+		r := newResourceFactory(resource)
+		Convey("When addHeadersSchema is called with a resource schema without properties named after the headers", func() {
+			resourceSchema := map[string]*schema.Schema{"label": {Type: schema.TypeString, Required: true}}
+			headers := r.addHeadersSchema(resourceSchema)
+			Convey("Then an optional property should be added to the schema for the resource attribute header only once", func() {
+				So(headers, ShouldResemble, SpecHeaderParameters{requestTierHeader})
+				So(resourceSchema, ShouldHaveLength, 2)
+				So(resourceSchema, ShouldContainKey, "x_request_tier")
+				So(resourceSchema["x_request_tier"].Optional, ShouldBeTrue)
+			})
+		})
+		Convey("When addHeadersSchema is called with a resource schema that already has a property named after the header", func() {
+			property := &schema.Schema{Type: schema.TypeString, Computed: true}
+			resourceSchema := map[string]*schema.Schema{"x_request_tier": property}
+			headers := r.addHeadersSchema(resourceSchema)
+			Convey("Then the resource property should be kept", func() {
+				So(headers, ShouldBeEmpty)
+				So(resourceSchema["x_request_tier"], ShouldEqual, property)
+			})
+		})
+	})
+}
+
+func TestWithResourceHeaders(t *testing.T) {
+	Convey("Given a resource factory of a resource which operations require headers that are resource attributes", t, func() {
+		resource := &specStubResource{
+			name:                  "cdn",
+			resourcePostOperation: &specResourceOperation{HeaderParameters: SpecHeaderParameters{{Name: "X-Request-Tier", IsResourceAttribute: true}, {Name: "X-Request-ID"}}},
+		}
+		r := newResourceFactory(resource)
+		resourceSchema := map[string]*schema.Schema{}
+		r.resourceHeaders = r.addHeadersSchema(resourceSchema)
+		Convey("When withResourceHeaders is called with resource data containing the header value", func() {
+			data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"x_request_tier": "premium"})
+			headersFactory := r.withResourceHeaders(data)
+			Convey("Then the resource operations should carry the header value configured in the resource", func() {
+				headers := headersFactory.openAPIResource.getResourceOperations().Post.HeaderParameters
+				So(headers, ShouldHaveLength, 2)
+				So(headers[0].value, ShouldEqual, "premium")
+				So(headers[1].value, ShouldBeEmpty)
+				So(headersFactory.openAPIResource.getResourceOperations().Get, ShouldBeNil)
+			})
+			Convey("And the original resource operations should not be modified", func() {
+				So(resource.resourcePostOperation.HeaderParameters[0].value, ShouldBeEmpty)
+			})
+			Convey("And calling withResourceHeaders again should not decorate the resource twice", func() {
+				So(headersFactory.withResourceHeaders(data).openAPIResource, ShouldEqual, headersFactory.openAPIResource)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which operations do not require headers that are resource attributes", t, func() {
+		resource := &specStubResource{name: "cdn"}
+		r := newResourceFactory(resource)
+		Convey("When withResourceHeaders is called", func() {
+			headersFactory := r.withResourceHeaders(schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{}))
+			Convey("Then the resource should not be decorated", func() {
+				So(headersFactory.openAPIResource, ShouldEqual, resource)
+			})
+		})
+	})
+}
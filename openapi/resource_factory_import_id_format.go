@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// importIDFormatPlaceholderRegex matches the placeholders of the import ID format (e,g: {zone_id})
+var importIDFormatPlaceholderRegex = regexp.MustCompile(`{(\w+)}`)
+
+// importIDFormat describes how the import ID of a resource with a composite identifier maps to its parent IDs and its
+// instance ID as per the x-terraform-import-id-format extension (e,g: {zone_id}:{record_id})
+type importIDFormat struct {
+	template string
+	regex    *regexp.Regexp
+	// parentIndexes contains, in the order the placeholders appear in the template, the index of the parent ID each
+	// placeholder refers to; or -1 for the placeholder of the instance ID
+	parentIndexes []int
+	parentsLen    int
+}
+
+// newImportIDFormat returns the import ID format for the template provided. The placeholders of the template can refer
+// to the parents of the resource either by the name of the path parameter (e,g: {zone_id} for /v1/zones/{zone_id}/records)
+// or by the name of the parent property (e,g: {zones_v1_id}); the remaining placeholder represents the instance ID. An
+// error is returned if the template does not contain exactly one placeholder for each parent and the instance ID
+func newImportIDFormat(template string, parentInfo *parentResourceInfo) (*importIDFormat, error) {
+	var parentPathParameters, parentPropertyNames []string
+	if parentInfo != nil {
+		parentPathParameters = parentInfo.parentPathParameters
+		parentPropertyNames = parentInfo.getParentPropertiesNames()
+	}
+	format := &importIDFormat{template: template, parentsLen: len(parentPropertyNames)}
+	parentsFound := make([]bool, len(parentPropertyNames))
+	var instancePlaceholders []string
+	pattern := "^"
+	previousEnd := 0
+	for _, match := range importIDFormatPlaceholderRegex.FindAllStringSubmatchIndex(template, -1) {
+		if match[0] == previousEnd && previousEnd > 0 {
+			return nil, fmt.Errorf("import ID format '%s' placeholders must be separated by at least one character", template)
+		}
+		pattern += regexp.QuoteMeta(template[previousEnd:match[0]]) + "(.+?)"
+		previousEnd = match[1]
+		placeholder := template[match[2]:match[3]]
+		parentIndex := -1
+		for i, parentPropertyName := range parentPropertyNames {
+			if placeholder == parentPropertyName || (i < len(parentPathParameters) && placeholder == parentPathParameters[i]) {
+				parentIndex = i
+				break
+			}
+		}
+		if parentIndex == -1 {
+			instancePlaceholders = append(instancePlaceholders, placeholder)
+		} else {
+			if parentsFound[parentIndex] {
+				return nil, fmt.Errorf("import ID format '%s' contains more than one placeholder for the parent '%s'", template, parentPropertyNames[parentIndex])
+			}
+			parentsFound[parentIndex] = true
+		}
+		format.parentIndexes = append(format.parentIndexes, parentIndex)
+	}
+	for i, found := range parentsFound {
+		if !found {
+			return nil, fmt.Errorf("import ID format '%s' is missing the placeholder for the parent '%s'", template, parentPropertyNames[i])
+		}
+	}
+	if len(instancePlaceholders) != 1 {
+		return nil, fmt.Errorf("import ID format '%s' must contain exactly one placeholder for the resource instance ID, found %d [%s]", template, len(instancePlaceholders), strings.Join(instancePlaceholders, ", "))
+	}
+	pattern += regexp.QuoteMeta(template[previousEnd:]) + "$"
+	format.regex = regexp.MustCompile(pattern)
+	return format, nil
+}
+
+// parse returns the parent IDs (in the order of the resource parents) and the instance ID contained in the import ID
+func (f importIDFormat) parse(importID string) ([]string, string, error) {
+	matches := f.regex.FindStringSubmatch(importID)
+	if matches == nil {
+		return nil, "", fmt.Errorf("import ID '%s' does not match the expected format '%s'", importID, f.template)
+	}
+	parentIDs := make([]string, f.parentsLen)
+	var id string
+	for i, parentIndex := range f.parentIndexes {
+		if parentIndex == -1 {
+			id = matches[i+1]
+		} else {
+			parentIDs[parentIndex] = matches[i+1]
+		}
+	}
+	return parentIDs, id, nil
+}
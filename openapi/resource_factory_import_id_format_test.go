@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportIDFormat(t *testing.T) {
+	zoneRecords := &parentResourceInfo{
+		parentResourceNames:  []string{"zones_v1", "zones_v1_records_v1"},
+		parentPathParameters: []string{"zone_id", "record_id"},
+	}
+	testCases := []struct {
+		name              string
+		template          string
+		parentInfo        *parentResourceInfo
+		importID          string
+		expectedParentIDs []string
+		expectedID        string
+		expectedError     error
+	}{
+		{
+			name:              "top level resource with a prefixed import ID",
+			template:          "record:{id}",
+			importID:          "record:1234",
+			expectedParentIDs: []string{},
+			expectedID:        "1234",
+		},
+		{
+			name:              "sub-resource with the parents referred to by their path parameter names",
+			template:          "{zone_id}:{record_id}:{tag_id}",
+			parentInfo:        zoneRecords,
+			importID:          "zone1:record2:tag3",
+			expectedParentIDs: []string{"zone1", "record2"},
+			expectedID:        "tag3",
+		},
+		{
+			name:              "sub-resource with the parents referred to by their property names in a different order",
+			template:          "{id}@{zones_v1_records_v1_id}.{zones_v1_id}",
+			parentInfo:        zoneRecords,
+			importID:          "tag3@record2.zone1",
+			expectedParentIDs: []string{"zone1", "record2"},
+			expectedID:        "tag3",
+		},
+		{
+			name:          "import ID not matching the format",
+			template:      "{zone_id}:{record_id}:{tag_id}",
+			parentInfo:    zoneRecords,
+			importID:      "zone1/record2/tag3",
+			expectedError: errors.New("import ID 'zone1/record2/tag3' does not match the expected format '{zone_id}:{record_id}:{tag_id}'"),
+		},
+		{
+			name:          "import ID with an empty value",
+			template:      "{zone_id}:{record_id}:{tag_id}",
+			parentInfo:    zoneRecords,
+			importID:      "zone1::tag3",
+			expectedError: errors.New("import ID 'zone1::tag3' does not match the expected format '{zone_id}:{record_id}:{tag_id}'"),
+		},
+		{
+			name:          "format missing a parent placeholder",
+			template:      "{zone_id}:{tag_id}",
+			parentInfo:    zoneRecords,
+			expectedError: errors.New("import ID format '{zone_id}:{tag_id}' is missing the placeholder for the parent 'zones_v1_records_v1_id'"),
+		},
+		{
+			name:          "format with a parent placeholder repeated",
+			template:      "{zone_id}:{zones_v1_id}:{record_id}:{tag_id}",
+			parentInfo:    zoneRecords,
+			expectedError: errors.New("import ID format '{zone_id}:{zones_v1_id}:{record_id}:{tag_id}' contains more than one placeholder for the parent 'zones_v1_id'"),
+		},
+		{
+			name:          "format with more than one instance placeholder",
+			template:      "{zone}:{id}",
+			expectedError: errors.New("import ID format '{zone}:{id}' must contain exactly one placeholder for the resource instance ID, found 2 [zone, id]"),
+		},
+		{
+			name:          "format without placeholders",
+			template:      "id",
+			expectedError: errors.New("import ID format 'id' must contain exactly one placeholder for the resource instance ID, found 0 []"),
+		},
+		{
+			name:          "format with placeholders not separated",
+			template:      "{zone_id}{record_id}:{id}",
+			parentInfo:    zoneRecords,
+			expectedError: errors.New("import ID format '{zone_id}{record_id}:{id}' placeholders must be separated by at least one character"),
+		},
+	}
+	for _, tc := range testCases {
+		format, err := newImportIDFormat(tc.template, tc.parentInfo)
+		if err == nil {
+			var parentIDs []string
+			var id string
+			parentIDs, id, err = format.parse(tc.importID)
+			if err == nil {
+				assert.Equal(t, tc.expectedParentIDs, parentIDs, tc.name)
+				assert.Equal(t, tc.expectedID, id, tc.name)
+			}
+		}
+		assert.Equal(t, tc.expectedError, err, tc.name)
+	}
+}
@@ -0,0 +1,112 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// etagPropertyName is the name of the computed property storing the ETag of the resources configured with the etag
+// optimistic locking or conditional reads
+const etagPropertyName = "etag"
+
+const etagHeader = "ETag"
+const ifMatchHeader = "If-Match"
+
+// specResourceWithIfMatch decorates the resources configured with optimistic locking so the update and delete
+// operations send the If-Match header with the version of the resource the changes are based on
+type specResourceWithIfMatch struct {
+	SpecResource
+	ifMatch string
+}
+
+// getResourceOperations returns a copy of the resource operations where the operations configured with optimistic
+// locking contain the If-Match header
+func (r *specResourceWithIfMatch) getResourceOperations() specResourceOperations {
+	operations := r.SpecResource.getResourceOperations()
+	operations.Put = r.withIfMatchHeader(operations.Put)
+	operations.Patch = r.withIfMatchHeader(operations.Patch)
+	operations.Delete = r.withIfMatchHeader(operations.Delete)
+	return operations
+}
+
+func (r *specResourceWithIfMatch) withIfMatchHeader(operation *specResourceOperation) *specResourceOperation {
+	if operation == nil || operation.optimisticLocking == nil {
+		return operation
+	}
+	operationWithIfMatch := *operation
+	operationWithIfMatch.HeaderParameters = append(append(SpecHeaderParameters{}, operation.HeaderParameters...), SpecHeaderParam{Name: ifMatchHeader, IsResourceAttribute: true, value: r.ifMatch})
+	return &operationWithIfMatch
+}
+
+// addETagSchema adds the computed property storing the ETag of the resource to the resource schema if any of the
+// resource operations is configured with the etag optimistic locking or the read operation is conditional, returning
+// whether the property was added. The property is not added if the resource already has a property with the same name
+func (r resourceFactory) addETagSchema(resourceSchema map[string]*schema.Schema) bool {
+	operations := r.openAPIResource.getResourceOperations()
+	if !operations.Put.usesETagLocking() && !operations.Patch.usesETagLocking() && !operations.Delete.usesETagLocking() && !operations.Get.isConditionalRead() {
+		return false
+	}
+	if _, exists := resourceSchema[etagPropertyName]; exists {
+		r.getLogger().Warn(fmt.Sprintf("resource '%s' already has a property named '%s', the ETag of the resource will not be stored", r.openAPIResource.getResourceName(), etagPropertyName), "resource", r.openAPIResource.getResourceName())
+		return false
+	}
+	resourceSchema[etagPropertyName] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ETag of the resource returned by the API",
+	}
+	return true
+}
+
+// setETagState stores the given ETag in the state if the resource is configured with the etag optimistic locking
+func (r resourceFactory) setETagState(etag string, data *schema.ResourceData) error {
+	if !r.storesETag || etag == "" {
+		return nil
+	}
+	return data.Set(etagPropertyName, etag)
+}
+
+// getLockingVersion returns the version of the resource stored in the state for the given optimistic locking: the ETag
+// or the value of the version property. Nil is returned if the version is not known
+func (r resourceFactory) getLockingVersion(optimisticLocking *specOptimisticLocking, data *schema.ResourceData) interface{} {
+	switch {
+	case optimisticLocking.usesETag():
+		if etag, ok := data.GetOk(etagPropertyName); ok && r.storesETag {
+			return etag
+		}
+	case optimisticLocking.usesVersionProperty():
+		resourceSchema, err := r.openAPIResource.getResourceSchema()
+		if err != nil {
+			return nil
+		}
+		property, err := resourceSchema.getProperty(optimisticLocking.versionProperty)
+		if err != nil {
+			r.getLogger().Warn(fmt.Sprintf("resource '%s' does not have the version property '%s' configured in the '%s' extension", r.openAPIResource.getResourceName(), optimisticLocking.versionProperty, extTfOptimisticLocking), "resource", r.openAPIResource.getResourceName())
+			return nil
+		}
+		return data.Get(property.getTerraformCompliantPropertyName())
+	}
+	return nil
+}
+
+// readLockingVersion reads the resource from the API returning its current version for the given optimistic locking
+func (r resourceFactory) readLockingVersion(optimisticLocking *specOptimisticLocking, id string, providerClient ClientOpenAPI, parentIDs ...string) (interface{}, error) {
+	remoteData, etag, err := r.readRemoteWithETag(id, providerClient, parentIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if optimisticLocking.usesVersionProperty() {
+		return remoteData[optimisticLocking.versionProperty], nil
+	}
+	return etag, nil
+}
+
+// withIfMatch returns the resource to use in the requests that should only be applied if the resource matches the
+// given version, nil or empty versions are not sent
+func (r resourceFactory) withIfMatch(version interface{}) SpecResource {
+	if version == nil || version == "" {
+		return r.openAPIResource
+	}
+	return &specResourceWithIfMatch{SpecResource: r.openAPIResource, ifMatch: fmt.Sprint(version)}
+}
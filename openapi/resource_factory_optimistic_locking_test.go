@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// clientOpenAPIConflictStub responds to the PUT and DELETE requests with the given status codes (in order), recording
+// the If-Match header and the payload received in each request
+type clientOpenAPIConflictStub struct {
+	clientOpenAPIStub
+	statusCodes             []int
+	ifMatchReceived         []string
+	requestPayloadsReceived []interface{}
+}
+
+func (c *clientOpenAPIConflictStub) Put(resource SpecResource, id string, requestPayload interface{}, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.requestPayloadsReceived = append(c.requestPayloadsReceived, requestPayload)
+	return c.respond(resource.getResourceOperations().Put, responsePayload)
+}
+
+func (c *clientOpenAPIConflictStub) Delete(resource SpecResource, id string, parentIDs ...string) (*http.Response, error) {
+	return c.respond(resource.getResourceOperations().Delete, nil)
+}
+
+func (c *clientOpenAPIConflictStub) respond(operation *specResourceOperation, responsePayload interface{}) (*http.Response, error) {
+	ifMatch := ""
+	for _, headerParam := range operation.HeaderParameters {
+		if headerParam.Name == ifMatchHeader {
+			ifMatch = headerParam.value
+		}
+	}
+	c.ifMatchReceived = append(c.ifMatchReceived, ifMatch)
+	statusCode := c.statusCodes[0]
+	c.statusCodes = c.statusCodes[1:]
+	if p, ok := responsePayload.(*map[string]interface{}); ok && statusCode == http.StatusOK {
+		*p = c.responsePayload
+	}
+	return &http.Response{StatusCode: statusCode, Body: ioutil.NopCloser(strings.NewReader("")), Header: c.returnHeaders}, nil
+}
+
+func TestAddETagSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource which update operation is configured with the etag optimistic locking", t, func() {
+		r := newResourceFactory(&specStubResource{name: "cdn", resourcePutOperation: &specResourceOperation{optimisticLocking: &specOptimisticLocking{strategy: optimisticLockingETag}}})
+		Convey("When addETagSchema is called with a resource schema without a property named etag", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			added := r.addETagSchema(resourceSchema)
+			Convey("Then the computed etag property should be added", func() {
+				So(added, ShouldBeTrue)
+				So(resourceSchema[etagPropertyName].Computed, ShouldBeTrue)
+			})
+		})
+		Convey("When addETagSchema is called with a resource schema that already has a property named etag", func() {
+			property := &schema.Schema{Type: schema.TypeString, Optional: true}
+			resourceSchema := map[string]*schema.Schema{etagPropertyName: property}
+			added := r.addETagSchema(resourceSchema)
+			Convey("Then the resource property should be kept", func() {
+				So(added, ShouldBeFalse)
+				So(resourceSchema[etagPropertyName], ShouldEqual, property)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which operations are configured with the version optimistic locking", t, func() {
+		r := newResourceFactory(&specStubResource{name: "cdn", resourcePutOperation: &specResourceOperation{optimisticLocking: &specOptimisticLocking{strategy: optimisticLockingVersion, versionProperty: "version"}}})
+		Convey("When addETagSchema is called", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			Convey("Then the etag property should not be added", func() {
+				So(r.addETagSchema(resourceSchema), ShouldBeFalse)
+				So(resourceSchema, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestUpdateWithOptimisticLocking(t *testing.T) {
+	testCreateLockingResourceFactory := func(optimisticLocking *specOptimisticLocking, properties ...*specSchemaDefinitionProperty) (resourceFactory, *schema.ResourceData) {
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, newTestSchema(properties...).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{optimisticLocking: optimisticLocking}, &specResourceOperation{}, &specResourceOperation{optimisticLocking: optimisticLocking})
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		r.storesETag = r.addETagSchema(resourceSchema)
+		data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+		data.SetId("id")
+		return r, data
+	}
+	Convey("Given a resource factory of a resource configured with the etag optimistic locking and one retry", t, func() {
+		r, data := testCreateLockingResourceFactory(&specOptimisticLocking{strategy: optimisticLockingETag, maxRetries: 1}, stringProperty)
+		So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+		Convey("When update is called with a client that responds with a conflict first", func() {
+			client := &clientOpenAPIConflictStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"},
+					returnHeaders:   http.Header{http.CanonicalHeaderKey(etagHeader): []string{`"v2"`}},
+				},
+				statusCodes: []int{http.StatusPreconditionFailed, http.StatusOK},
+			}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the update should be retried with the current ETag of the resource", func() {
+				So(err, ShouldBeNil)
+				So(client.ifMatchReceived, ShouldResemble, []string{`"v1"`, `"v2"`})
+			})
+			Convey("And the ETag returned by the API should be stored in the state", func() {
+				So(data.Get(etagPropertyName), ShouldEqual, `"v2"`)
+			})
+		})
+		Convey("When delete is called with a client that responds with a conflict first", func() {
+			client := &clientOpenAPIConflictStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					responsePayload: map[string]interface{}{stringProperty.Name: stringProperty.Default},
+					returnHeaders:   http.Header{http.CanonicalHeaderKey(etagHeader): []string{`"v2"`}},
+				},
+				statusCodes: []int{http.StatusConflict, http.StatusNoContent},
+			}
+			err := r.delete(context.Background(), data, client)
+			Convey("Then the delete should be retried with the current ETag of the resource", func() {
+				So(err, ShouldBeNil)
+				So(client.ifMatchReceived, ShouldResemble, []string{`"v1"`, `"v2"`})
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource configured with the etag optimistic locking without retries", t, func() {
+		r, data := testCreateLockingResourceFactory(&specOptimisticLocking{strategy: optimisticLockingETag}, stringProperty)
+		So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+		Convey("When update is called with a client that responds with a conflict", func() {
+			client := &clientOpenAPIConflictStub{statusCodes: []int{http.StatusPreconditionFailed}}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the error returned should be the conflict", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 412 not matching expected one [200 202] ()")
+				So(client.ifMatchReceived, ShouldHaveLength, 1)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource configured with the etag optimistic locking and an API that responds to the PUT request with 412 Precondition Failed and an empty body", t, func() {
+		var ifMatchReceived []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(etagHeader, `"v2"`)
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`{"string_property":"someValue"}`))
+				return
+			}
+			ifMatchReceived = append(ifMatchReceived, r.Header.Get(ifMatchHeader))
+			if r.Header.Get(ifMatchHeader) != `"v2"` {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			w.Write([]byte(`{"string_property":"someUpdatedValue"}`))
+		}))
+		defer api.Close()
+		Convey("When update is called with a provider client and the optimistic locking is configured with one retry", func() {
+			r, data := testCreateLockingResourceFactory(&specOptimisticLocking{strategy: optimisticLockingETag, maxRetries: 1}, stringProperty)
+			So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+			err := r.update(context.Background(), data, newTestAPIProviderClient(api.URL))
+			Convey("Then the update should be retried with the current ETag of the resource", func() {
+				So(err, ShouldBeNil)
+				So(ifMatchReceived, ShouldResemble, []string{`"v1"`, `"v2"`})
+				So(data.Get(stringProperty.Name), ShouldEqual, "someUpdatedValue")
+			})
+		})
+		Convey("When update is called with a provider client and the optimistic locking is configured without retries", func() {
+			r, data := testCreateLockingResourceFactory(&specOptimisticLocking{strategy: optimisticLockingETag}, stringProperty)
+			So(data.Set(etagPropertyName, `"v1"`), ShouldBeNil)
+			err := r.update(context.Background(), data, newTestAPIProviderClient(api.URL))
+			Convey("Then the error returned should be the conflict", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 412 not matching expected one [200 202] ()")
+				So(ifMatchReceived, ShouldResemble, []string{`"v1"`})
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource configured with the version optimistic locking and one retry", t, func() {
+		versionProperty := newIntSchemaDefinitionPropertyWithDefaults("version", "", false, true, nil)
+		r, data := testCreateLockingResourceFactory(&specOptimisticLocking{strategy: optimisticLockingVersion, versionProperty: versionProperty.Name, maxRetries: 1}, stringProperty, versionProperty)
+		So(data.Set(versionProperty.Name, 1), ShouldBeNil)
+		Convey("When update is called with a client that responds with a conflict first", func() {
+			client := &clientOpenAPIConflictStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					responsePayload: map[string]interface{}{stringProperty.Name: stringProperty.Default, versionProperty.Name: float64(2)},
+				},
+				statusCodes: []int{http.StatusConflict, http.StatusOK},
+			}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the update should be retried with the current version of the resource in the payload", func() {
+				So(err, ShouldBeNil)
+				So(client.requestPayloadsReceived, ShouldHaveLength, 2)
+				So(client.requestPayloadsReceived[0].(map[string]interface{})[versionProperty.Name], ShouldEqual, 1)
+				So(client.requestPayloadsReceived[1].(map[string]interface{})[versionProperty.Name], ShouldEqual, float64(2))
+			})
+			Convey("And the If-Match header should not be sent", func() {
+				So(client.ifMatchReceived, ShouldResemble, []string{"", ""})
+			})
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// jsonPatchOperation defines an operation of a JSON patch document (RFC 6902)
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON omits the value of the remove operations, the rest of operations always include the value even if null
+func (o jsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(map[string]string{"op": o.Op, "path": o.Path})
+	}
+	type operation jsonPatchOperation
+	return json.Marshal(operation(o))
+}
+
+// createPatchPayload returns the patch document containing the changes between the payload built from the prior state
+// and the payload built from the planned values, formatted based on the update strategy given. The changes are logged
+// with the sensitive values redacted
+func (r resourceFactory) createPatchPayload(updateStrategy string, priorPayload, requestPayload map[string]interface{}) interface{} {
+	changes := createMergePatch(priorPayload, requestPayload)
+	r.getLogger().Debug(fmt.Sprintf("[resource='%s'] PATCH changes (%s): %s", r.openAPIResource.getResourceName(), updateStrategy, sPrettyPrint(r.redactSensitiveValues(changes))), "resource", r.openAPIResource.getResourceName())
+	if updateStrategy == updateStrategyJSONPatch {
+		return createJSONPatch(priorPayload, requestPayload)
+	}
+	return changes
+}
+
+// createPayloadFromPriorStateData returns the payload corresponding to the values stored in the state before the update.
+// The properties that have not changed keep the value in the payload given. Properties that did not have a value are not
+// present in the payload returned
+func (r resourceFactory) createPayloadFromPriorStateData(data *schema.ResourceData, payload map[string]interface{}) map[string]interface{} {
+	priorPayload := map[string]interface{}{}
+	for name, value := range payload {
+		priorPayload[name] = value
+	}
+	resourceSchema, _ := r.openAPIResource.getResourceSchema()
+	for _, property := range resourceSchema.Properties {
+		if property.isReadOnly() || property.IsParentProperty {
+			continue
+		}
+		terraformName := property.getTerraformCompliantPropertyName()
+		if !data.HasChange(terraformName) {
+			continue
+		}
+		delete(priorPayload, property.Name)
+		priorValue, _ := data.GetChange(terraformName)
+		if isEmptyStateValue(priorValue) {
+			continue
+		}
+		if err := r.populatePayload(priorPayload, property, priorValue); err != nil {
+			r.getLogger().Error(fmt.Sprintf("[resource='%s'] error when creating the prior state payload for property '%s': %s", r.openAPIResource.getResourceName(), property.Name, err), "resource", r.openAPIResource.getResourceName())
+		}
+	}
+	return priorPayload
+}
+
+// isEmptyStateValue returns true if the given state value is the zero value of its type or an empty collection, which is
+// what the state returns for properties that do not have a value
+func isEmptyStateValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if set, ok := value.(*schema.Set); ok {
+		return set.Len() == 0
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// createMergePatch returns the JSON merge patch (RFC 7386) that transforms the original object into the modified one.
+// Objects are patched recursively, members removed are set to null and any other value (including arrays) is replaced
+// as a whole
+func createMergePatch(original, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for name, modifiedValue := range modified {
+		originalValue, exists := original[name]
+		if !exists {
+			patch[name] = modifiedValue
+			continue
+		}
+		if reflect.DeepEqual(originalValue, modifiedValue) {
+			continue
+		}
+		originalObject, originalIsObject := originalValue.(map[string]interface{})
+		modifiedObject, modifiedIsObject := modifiedValue.(map[string]interface{})
+		if originalIsObject && modifiedIsObject {
+			patch[name] = createMergePatch(originalObject, modifiedObject)
+			continue
+		}
+		patch[name] = modifiedValue
+	}
+	for name := range original {
+		if _, exists := modified[name]; !exists {
+			patch[name] = nil
+		}
+	}
+	return patch
+}
+
+// createJSONPatch returns the JSON patch (RFC 6902) operations that transform the original object into the modified one.
+// Objects are patched recursively and any other value (including arrays) is replaced as a whole. The operations are
+// sorted by path so the document is deterministic
+func createJSONPatch(original, modified map[string]interface{}) []jsonPatchOperation {
+	return appendJSONPatchOperations([]jsonPatchOperation{}, "", original, modified)
+}
+
+func appendJSONPatchOperations(operations []jsonPatchOperation, path string, original, modified map[string]interface{}) []jsonPatchOperation {
+	var names []string
+	for name := range original {
+		names = append(names, name)
+	}
+	for name := range modified {
+		if _, exists := original[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		memberPath := path + "/" + escapeJSONPointerToken(name)
+		originalValue, originalExists := original[name]
+		modifiedValue, modifiedExists := modified[name]
+		switch {
+		case !modifiedExists:
+			operations = append(operations, jsonPatchOperation{Op: "remove", Path: memberPath})
+		case !originalExists:
+			operations = append(operations, jsonPatchOperation{Op: "add", Path: memberPath, Value: modifiedValue})
+		case reflect.DeepEqual(originalValue, modifiedValue):
+		default:
+			originalObject, originalIsObject := originalValue.(map[string]interface{})
+			modifiedObject, modifiedIsObject := modifiedValue.(map[string]interface{})
+			if originalIsObject && modifiedIsObject {
+				operations = appendJSONPatchOperations(operations, memberPath, originalObject, modifiedObject)
+				continue
+			}
+			operations = append(operations, jsonPatchOperation{Op: "replace", Path: memberPath, Value: modifiedValue})
+		}
+	}
+	return operations
+}
+
+// escapeJSONPointerToken escapes the characters with special meaning in JSON pointers (RFC 6901)
+func escapeJSONPointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMergePatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		original      map[string]interface{}
+		modified      map[string]interface{}
+		expectedPatch map[string]interface{}
+	}{
+		{
+			name:          "no changes",
+			original:      map[string]interface{}{"name": "some name", "size": 1},
+			modified:      map[string]interface{}{"name": "some name", "size": 1},
+			expectedPatch: map[string]interface{}{},
+		},
+		{
+			name:          "member replaced",
+			original:      map[string]interface{}{"name": "some name", "size": 1},
+			modified:      map[string]interface{}{"name": "some other name", "size": 1},
+			expectedPatch: map[string]interface{}{"name": "some other name"},
+		},
+		{
+			name:          "member added",
+			original:      map[string]interface{}{"name": "some name"},
+			modified:      map[string]interface{}{"name": "some name", "enabled": false},
+			expectedPatch: map[string]interface{}{"enabled": false},
+		},
+		{
+			name:          "member removed",
+			original:      map[string]interface{}{"name": "some name", "description": "some description"},
+			modified:      map[string]interface{}{"name": "some name"},
+			expectedPatch: map[string]interface{}{"description": nil},
+		},
+		{
+			name:          "nested object members changed",
+			original:      map[string]interface{}{"settings": map[string]interface{}{"ttl": 60, "region": "us-west1", "label": "some label"}},
+			modified:      map[string]interface{}{"settings": map[string]interface{}{"ttl": 120, "region": "us-west1"}},
+			expectedPatch: map[string]interface{}{"settings": map[string]interface{}{"ttl": 120, "label": nil}},
+		},
+		{
+			name:          "arrays are replaced as a whole",
+			original:      map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			modified:      map[string]interface{}{"tags": []interface{}{"a", "c"}},
+			expectedPatch: map[string]interface{}{"tags": []interface{}{"a", "c"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPatch, createMergePatch(tc.original, tc.modified))
+		})
+	}
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	testCases := []struct {
+		name          string
+		original      map[string]interface{}
+		modified      map[string]interface{}
+		expectedPatch []jsonPatchOperation
+	}{
+		{
+			name:          "no changes",
+			original:      map[string]interface{}{"name": "some name"},
+			modified:      map[string]interface{}{"name": "some name"},
+			expectedPatch: []jsonPatchOperation{},
+		},
+		{
+			name:     "members added, replaced and removed",
+			original: map[string]interface{}{"name": "some name", "description": "some description"},
+			modified: map[string]interface{}{"name": "some other name", "enabled": true},
+			expectedPatch: []jsonPatchOperation{
+				{Op: "remove", Path: "/description"},
+				{Op: "add", Path: "/enabled", Value: true},
+				{Op: "replace", Path: "/name", Value: "some other name"},
+			},
+		},
+		{
+			name:     "nested object members changed",
+			original: map[string]interface{}{"settings": map[string]interface{}{"ttl": 60, "region": "us-west1"}},
+			modified: map[string]interface{}{"settings": map[string]interface{}{"ttl": 120, "region": "us-west1"}},
+			expectedPatch: []jsonPatchOperation{
+				{Op: "replace", Path: "/settings/ttl", Value: 120},
+			},
+		},
+		{
+			name:     "member names are escaped",
+			original: map[string]interface{}{"a/b": "some value", "c~d": "some value"},
+			modified: map[string]interface{}{"a/b": "some other value", "c~d": "some other value"},
+			expectedPatch: []jsonPatchOperation{
+				{Op: "replace", Path: "/a~1b", Value: "some other value"},
+				{Op: "replace", Path: "/c~0d", Value: "some other value"},
+			},
+		},
+		{
+			name:     "arrays are replaced as a whole",
+			original: map[string]interface{}{"tags": []interface{}{"a", "b"}},
+			modified: map[string]interface{}{"tags": []interface{}{"a"}},
+			expectedPatch: []jsonPatchOperation{
+				{Op: "replace", Path: "/tags", Value: []interface{}{"a"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedPatch, createJSONPatch(tc.original, tc.modified))
+		})
+	}
+}
+
+func TestJSONPatchOperationMarshalJSON(t *testing.T) {
+	testCases := []struct {
+		name         string
+		operation    jsonPatchOperation
+		expectedJSON string
+	}{
+		{
+			name:         "remove operations do not include the value",
+			operation:    jsonPatchOperation{Op: "remove", Path: "/description"},
+			expectedJSON: `{"op":"remove","path":"/description"}`,
+		},
+		{
+			name:         "replace operations include null values",
+			operation:    jsonPatchOperation{Op: "replace", Path: "/description"},
+			expectedJSON: `{"op":"replace","path":"/description","value":null}`,
+		},
+		{
+			name:         "add operations include the value",
+			operation:    jsonPatchOperation{Op: "add", Path: "/enabled", Value: false},
+			expectedJSON: `{"op":"add","path":"/enabled","value":false}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.operation)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expectedJSON, string(b))
+		})
+	}
+}
+
+func TestIsEmptyStateValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected bool
+	}{
+		{name: "nil value", value: nil, expected: true},
+		{name: "empty string", value: "", expected: true},
+		{name: "zero int", value: 0, expected: true},
+		{name: "false bool", value: false, expected: true},
+		{name: "empty list", value: []interface{}{}, expected: true},
+		{name: "empty map", value: map[string]interface{}{}, expected: true},
+		{name: "string", value: "some value", expected: false},
+		{name: "int", value: 1, expected: false},
+		{name: "list", value: []interface{}{"a"}, expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isEmptyStateValue(tc.value))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dikhan/terraform-provider-openapi/openapi/openapierr"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// callPreDeleteOperationIfConfigured calls the operation that must be called before deleting the resource if the DELETE
+// operation is configured with the 'x-terraform-pre-delete-operation' extension (e,g: detaching the resource from its
+// dependencies). The resource is considered deleted already if the operation responds with 404 Not Found, in which
+// case the DELETE request is still sent
+func (r resourceFactory) callPreDeleteOperationIfConfigured(data *schema.ResourceData, providerClient ClientOpenAPI, operation *specResourceOperation, resourcePath string, parentIDs ...string) error {
+	preDeleteOperation := operation.preDeleteOperation
+	if preDeleteOperation == nil {
+		return nil
+	}
+	var requestPayload interface{}
+	if preDeleteOperation.body != nil {
+		requestPayload, _ = renderBodyTemplateValue(preDeleteOperation.body, r.createPayloadFromLocalStateData(data))
+	}
+	res, err := providerClient.PreDelete(r.openAPIResource, data.Id(), requestPayload, parentIDs...)
+	if err != nil {
+		return err
+	}
+	if err := checkHTTPStatusCode(r.openAPIResource, res, []int{http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent}); err != nil {
+		if openapiErr, ok := err.(openapierr.Error); ok && openapierr.NotFound == openapiErr.Code() {
+			r.getLogger().Warn(fmt.Sprintf("[resource='%s'] %s %s/%s%s responded with not found, proceeding with the DELETE request", r.openAPIResource.getResourceName(), preDeleteOperation.method, resourcePath, data.Id(), preDeleteOperation.pathSuffix), "resource", r.openAPIResource.getResourceName(), "id", data.Id())
+			return nil
+		}
+		return fmt.Errorf("[resource='%s'] %s %s/%s%s before DELETE failed: %s", r.openAPIResource.getResourceName(), preDeleteOperation.method, resourcePath, data.Id(), preDeleteOperation.pathSuffix, err)
+	}
+	return nil
+}
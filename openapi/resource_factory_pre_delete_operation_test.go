@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeleteWithPreDeleteOperation(t *testing.T) {
+	Convey("Given a resource factory which delete operation is configured with a pre delete operation", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).resourceDeleteOperation = &specResourceOperation{
+			preDeleteOperation: &specPreDeleteOperation{method: httpPost, pathSuffix: "/detach", body: map[string]interface{}{"name": "{" + stringProperty.Name + "}"}},
+		}
+		Convey("When delete is called", func() {
+			client := &clientOpenAPIStub{}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the pre delete operation should be called with the body rendered before deleting the resource", func() {
+				So(err, ShouldBeNil)
+				So(client.preDeletePayloadsReceived, ShouldResemble, []interface{}{map[string]interface{}{"name": stringProperty.Default}})
+				So(client.idReceived, ShouldEqual, idProperty.Default)
+			})
+		})
+		Convey("When delete is called and the pre delete operation fails", func() {
+			client := &clientOpenAPIStub{
+				funcPreDelete: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusConflict, Body: ioutil.NopCloser(strings.NewReader("resource is attached"))}, nil
+				},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the expected one and the resource should not be deleted", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource/id/detach before DELETE failed: [resource='resourceName'] HTTP Response Status Code 409 not matching expected one [200 201 202 204] (resource is attached)")
+				So(client.idReceived, ShouldBeEmpty)
+			})
+		})
+		Convey("When delete is called and the pre delete operation responds with not found", func() {
+			client := &clientOpenAPIStub{
+				funcPreDelete: func() (*http.Response, error) {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+				},
+			}
+			err := r.delete(context.Background(), resourceData, client)
+			Convey("Then the DELETE request should still be sent", func() {
+				So(err, ShouldBeNil)
+				So(client.idReceived, ShouldEqual, idProperty.Default)
+			})
+		})
+	})
+}
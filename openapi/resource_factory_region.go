@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// specRegionalResource decorates the resources configured with a region (via the resource region property) so the
+// API calls are made against the host of that region instead of the region configured in the provider
+type specRegionalResource struct {
+	SpecResource
+	region string
+}
+
+// getRegion returns the region of the resource if the given resource is a regional resource; empty otherwise
+func getRegion(resource SpecResource) string {
+	if regionalResource, ok := resource.(*specRegionalResource); ok {
+		return regionalResource.region
+	}
+	return ""
+}
+
+// addRegionSchema adds the optional region property to the resource schema if the resource can be deployed in
+// multiple regions, returning whether the property was added. The property is not added if the resource already has a
+// property with the same name
+func (r resourceFactory) addRegionSchema(resourceSchema map[string]*schema.Schema) bool {
+	if len(r.regions) == 0 {
+		return false
+	}
+	if _, exists := resourceSchema[providerPropertyRegion]; exists {
+		r.getLogger().Warn(fmt.Sprintf("resource '%s' already has a property named '%s', the region can only be configured at the provider level", r.openAPIResource.getResourceName(), providerPropertyRegion), "resource", r.openAPIResource.getResourceName())
+		return false
+	}
+	regions := r.regions
+	resourceSchema[providerPropertyRegion] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("The region where the resource is managed, overriding the region configured in the provider. Allowed values: %v", regions),
+		ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+			for _, region := range regions {
+				if val.(string) == region {
+					return nil, nil
+				}
+			}
+			return nil, []error{fmt.Errorf("property %s value %s is not valid, please make sure the value is one of %+v", key, val, regions)}
+		},
+	}
+	return true
+}
+
+// withResourceRegion returns the resource factory to use for the given resource data: if the user configured the
+// resource region, the resource is decorated so the API calls are made against the host of that region
+func (r resourceFactory) withResourceRegion(data *schema.ResourceData) resourceFactory {
+	if len(r.regions) == 0 || getRegion(r.openAPIResource) != "" {
+		return r
+	}
+	region, ok := data.GetOk(providerPropertyRegion)
+	if !ok {
+		return r
+	}
+	r.openAPIResource = &specRegionalResource{SpecResource: r.openAPIResource, region: region.(string)}
+	return r
+}
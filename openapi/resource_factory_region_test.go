@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddRegionSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource that can be managed in multiple regions", t, func() {
+		r := newResourceFactory(&specStubResource{name: "cdn"})
+		r.regions = []string{"us-west1", "us-east1"}
+		Convey("When addRegionSchema is called with a resource schema without a region property", func() {
+			resourceSchema := map[string]*schema.Schema{"label": {Type: schema.TypeString, Required: true}}
+			added := r.addRegionSchema(resourceSchema)
+			Convey("Then the optional region property should be added to the schema forcing new resources", func() {
+				So(added, ShouldBeTrue)
+				So(resourceSchema, ShouldContainKey, "region")
+				So(resourceSchema["region"].Optional, ShouldBeTrue)
+				So(resourceSchema["region"].ForceNew, ShouldBeTrue)
+			})
+			Convey("And the region property should only allow the provider regions", func() {
+				_, errs := resourceSchema["region"].ValidateFunc("us-east1", "region")
+				So(errs, ShouldBeEmpty)
+				_, errs = resourceSchema["region"].ValidateFunc("eu-west1", "region")
+				So(errs, ShouldHaveLength, 1)
+				So(errs[0].Error(), ShouldEqual, "property region value eu-west1 is not valid, please make sure the value is one of [us-west1 us-east1]")
+			})
+		})
+		Convey("When addRegionSchema is called with a resource schema that already has a region property", func() {
+			regionProperty := &schema.Schema{Type: schema.TypeString, Computed: true}
+			resourceSchema := map[string]*schema.Schema{"region": regionProperty}
+			added := r.addRegionSchema(resourceSchema)
+			Convey("Then the resource region property should be kept", func() {
+				So(added, ShouldBeFalse)
+				So(resourceSchema["region"], ShouldEqual, regionProperty)
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource that is not multi-region", t, func() {
+		r := newResourceFactory(&specStubResource{name: "cdn"})
+		Convey("When addRegionSchema is called", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			added := r.addRegionSchema(resourceSchema)
+			Convey("Then the region property should not be added", func() {
+				So(added, ShouldBeFalse)
+				So(resourceSchema, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestWithResourceRegion(t *testing.T) {
+	Convey("Given a resource factory of a resource that can be managed in multiple regions", t, func() {
+		resource := &specStubResource{name: "cdn"}
+		r := newResourceFactory(resource)
+		r.regions = []string{"us-west1", "us-east1"}
+		resourceSchema := map[string]*schema.Schema{}
+		r.addRegionSchema(resourceSchema)
+		Convey("When withResourceRegion is called with resource data containing the region", func() {
+			data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{"region": "us-east1"})
+			regionalFactory := r.withResourceRegion(data)
+			Convey("Then the resource should be decorated with the region", func() {
+				So(getRegion(regionalFactory.openAPIResource), ShouldEqual, "us-east1")
+				So(regionalFactory.openAPIResource.getResourceName(), ShouldEqual, "cdn")
+			})
+			Convey("And calling withResourceRegion again should not decorate the resource twice", func() {
+				So(regionalFactory.withResourceRegion(data).openAPIResource, ShouldEqual, regionalFactory.openAPIResource)
+			})
+		})
+		Convey("When withResourceRegion is called with resource data without the region", func() {
+			data := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+			regionalFactory := r.withResourceRegion(data)
+			Convey("Then the resource should not be decorated", func() {
+				So(regionalFactory.openAPIResource, ShouldEqual, resource)
+				So(getRegion(regionalFactory.openAPIResource), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// requiredIfRule describes a property that is required only when the conditions are met
+type requiredIfRule struct {
+	terraformName string
+	// conditions maps the terraform names of the properties the rule depends on to the values that make the property
+	// required
+	conditions map[string]interface{}
+}
+
+// createRequiredIfRules returns the rules of the properties configured with the x-terraform-required-if extension. The
+// property names used in the conditions are resolved to their terraform names so they can be looked up at plan time
+func createRequiredIfRules(schemaDefinition *specSchemaDefinition) ([]requiredIfRule, error) {
+	var rules []requiredIfRule
+	for _, property := range schemaDefinition.Properties {
+		if len(property.RequiredIf) == 0 {
+			continue
+		}
+		rule := requiredIfRule{terraformName: property.getTerraformCompliantPropertyName(), conditions: map[string]interface{}{}}
+		for conditionPropertyName, conditionValue := range property.RequiredIf {
+			conditionProperty, err := schemaDefinition.getProperty(conditionPropertyName)
+			if err != nil {
+				return nil, fmt.Errorf("property '%s' %s extension refers to property '%s' which does not exist", property.Name, extTfRequiredIf, conditionPropertyName)
+			}
+			if !conditionProperty.isPrimitiveProperty() {
+				return nil, fmt.Errorf("property '%s' %s extension refers to property '%s' which is not a primitive property", property.Name, extTfRequiredIf, conditionPropertyName)
+			}
+			rule.conditions[conditionProperty.getTerraformCompliantPropertyName()] = conditionValue
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].terraformName < rules[j].terraformName })
+	return rules, nil
+}
+
+// createRequiredIfCustomizeDiff returns the CustomizeDiff function that enforces the required if rules at plan time; nil
+// if there are no rules
+func createRequiredIfCustomizeDiff(rules []requiredIfRule) schema.CustomizeDiffFunc {
+	if len(rules) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		for _, rule := range rules {
+			if err := rule.validate(diff); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// validate returns an error if all the conditions of the rule are met and the property is not set. Conditions that
+// depend on values not known until apply are not considered met, and values not known until apply are considered set
+func (r requiredIfRule) validate(diff *schema.ResourceDiff) error {
+	var conditionsDescription []string
+	for conditionTerraformName, conditionValue := range r.conditions {
+		if !diff.NewValueKnown(conditionTerraformName) {
+			return nil
+		}
+		value, exists := diff.GetOkExists(conditionTerraformName)
+		if !exists || fmt.Sprintf("%v", value) != fmt.Sprintf("%v", conditionValue) {
+			return nil
+		}
+		conditionsDescription = append(conditionsDescription, fmt.Sprintf("'%s' is '%v'", conditionTerraformName, conditionValue))
+	}
+	if !diff.NewValueKnown(r.terraformName) {
+		return nil
+	}
+	if _, exists := diff.GetOkExists(r.terraformName); exists {
+		return nil
+	}
+	sort.Strings(conditionsDescription)
+	return fmt.Errorf("property '%s' is required when %s", r.terraformName, strings.Join(conditionsDescription, " and "))
+}
@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// unknownVariableValue is the value used by terraform to represent values that are not known until apply
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func newRequiredIfSchemaDefinition(requiredIf map[string]interface{}) *specSchemaDefinition {
+	return &specSchemaDefinition{
+		Properties: specSchemaDefinitionProperties{
+			idProperty,
+			&specSchemaDefinitionProperty{Name: "storageType", Type: typeString},
+			&specSchemaDefinitionProperty{Name: "replicas", Type: typeInt},
+			&specSchemaDefinitionProperty{Name: "bucket", Type: typeString, RequiredIf: requiredIf},
+			&specSchemaDefinitionProperty{Name: "tags", Type: typeList, ArrayItemsType: typeString},
+		},
+	}
+}
+
+func TestCreateRequiredIfRules(t *testing.T) {
+	Convey("Given a schema definition with a property configured with conditions on other properties", t, func() {
+		schemaDefinition := newRequiredIfSchemaDefinition(map[string]interface{}{"storageType": "s3", "replicas": float64(2)})
+		Convey("When createRequiredIfRules is called", func() {
+			rules, err := createRequiredIfRules(schemaDefinition)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the rules returned should use the terraform names of the properties", func() {
+				So(rules, ShouldResemble, []requiredIfRule{{terraformName: "bucket", conditions: map[string]interface{}{"storage_type": "s3", "replicas": float64(2)}}})
+			})
+		})
+	})
+	Convey("Given a schema definition without properties configured with conditions", t, func() {
+		schemaDefinition := newRequiredIfSchemaDefinition(nil)
+		Convey("When createRequiredIfRules is called", func() {
+			rules, err := createRequiredIfRules(schemaDefinition)
+			Convey("Then the error returned should be nil and no rules should be returned", func() {
+				So(err, ShouldBeNil)
+				So(rules, ShouldBeEmpty)
+			})
+			Convey("And the CustomizeDiff function created from the rules should be nil", func() {
+				So(createRequiredIfCustomizeDiff(rules), ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a schema definition with a property which conditions refer to a property that does not exist", t, func() {
+		schemaDefinition := newRequiredIfSchemaDefinition(map[string]interface{}{"nonExisting": "s3"})
+		Convey("When createRequiredIfRules is called", func() {
+			_, err := createRequiredIfRules(schemaDefinition)
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "property 'bucket' x-terraform-required-if extension refers to property 'nonExisting' which does not exist")
+			})
+		})
+	})
+	Convey("Given a schema definition with a property which conditions refer to a property that is not primitive", t, func() {
+		schemaDefinition := newRequiredIfSchemaDefinition(map[string]interface{}{"tags": "s3"})
+		Convey("When createRequiredIfRules is called", func() {
+			_, err := createRequiredIfRules(schemaDefinition)
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "property 'bucket' x-terraform-required-if extension refers to property 'tags' which is not a primitive property")
+			})
+		})
+	})
+}
+
+func TestRequiredIfCustomizeDiff(t *testing.T) {
+	Convey("Given a terraform resource created from a resource which 'bucket' property is required when 'storage_type' is 's3'", t, func() {
+		r := newResourceFactory(newSpecStubResource("storage", "/v1/storages", false, newRequiredIfSchemaDefinition(map[string]interface{}{"storageType": "s3"})))
+		resource, err := r.createTerraformResource()
+		So(err, ShouldBeNil)
+		So(resource.CustomizeDiff, ShouldNotBeNil)
+		diff := func(config map[string]interface{}) error {
+			_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+			return err
+		}
+		Convey("When the plan is computed for a configuration that meets the condition and does not set the property", func() {
+			err := diff(map[string]interface{}{"storage_type": "s3"})
+			Convey("Then the error returned should name both properties", func() {
+				So(err.Error(), ShouldEqual, "property 'bucket' is required when 'storage_type' is 's3'")
+			})
+		})
+		Convey("When the plan is computed for a configuration that meets the condition and sets the property", func() {
+			err := diff(map[string]interface{}{"storage_type": "s3", "bucket": "my-bucket"})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the plan is computed for a configuration that does not meet the condition", func() {
+			err := diff(map[string]interface{}{"storage_type": "disk"})
+			Convey("Then the error returned should be nil since the property remains optional", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the plan is computed for a configuration that does not set the property the condition depends on", func() {
+			err := diff(map[string]interface{}{})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+		Convey("When the plan is computed for a configuration where the condition value is not known until apply", func() {
+			err := diff(map[string]interface{}{"storage_type": unknownVariableValue})
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a terraform resource which 'bucket' property is required when 'storage_type' is 's3' and 'replicas' is 2", t, func() {
+		r := newResourceFactory(newSpecStubResource("storage", "/v1/storages", false, newRequiredIfSchemaDefinition(map[string]interface{}{"storageType": "s3", "replicas": float64(2)})))
+		resource, err := r.createTerraformResource()
+		So(err, ShouldBeNil)
+		Convey("When the plan is computed for a configuration that meets all the conditions and does not set the property", func() {
+			_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{"storage_type": "s3", "replicas": 2}), nil)
+			Convey("Then the error returned should describe all the conditions", func() {
+				So(err.Error(), ShouldEqual, "property 'bucket' is required when 'replicas' is '2' and 'storage_type' is 's3'")
+			})
+		})
+		Convey("When the plan is computed for a configuration that only meets one of the conditions", func() {
+			_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{"storage_type": "s3", "replicas": 1}), nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a terraform resource which property conditions refer to a property that does not exist", t, func() {
+		r := newResourceFactory(newSpecStubResource("storage", "/v1/storages", false, newRequiredIfSchemaDefinition(map[string]interface{}{"nonExisting": "s3"})))
+		Convey("When createTerraformResource is called", func() {
+			_, err := r.createTerraformResource()
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "property 'bucket' x-terraform-required-if extension refers to property 'nonExisting' which does not exist")
+			})
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// snakeCaseNameRegex matches the snake case names (e,g: request_id), which are valid terraform attribute names
+var snakeCaseNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// addResponseHeadersSchema makes sure the resource schema contains the computed properties populated with the values of
+// the response headers of the create and update operations ('x-terraform-response-header-property' extension), returning
+// the terraform names of the properties keyed by the property names configured in the extension. The properties
+// defined in the resource schema are used if they are computed strings, otherwise a computed string property named as
+// configured in the extension is added (unless the resource already has a property with the same name or the name is
+// not terraform compliant)
+func (r resourceFactory) addResponseHeadersSchema(resourceSchema map[string]*schema.Schema) map[string]string {
+	operations := r.openAPIResource.getResourceOperations()
+	var propertyNames []string
+	for _, operation := range []*specResourceOperation{operations.Post, operations.Put, operations.Patch} {
+		if operation == nil {
+			continue
+		}
+		for _, propertyName := range operation.responseHeaderProperties {
+			propertyNames = append(propertyNames, propertyName)
+		}
+	}
+	if len(propertyNames) == 0 {
+		return nil
+	}
+	sort.Strings(propertyNames)
+	specSchema, err := r.openAPIResource.getResourceSchema()
+	if err != nil {
+		return nil
+	}
+	responseHeaderProperties := map[string]string{}
+	for _, propertyName := range propertyNames {
+		if _, exists := responseHeaderProperties[propertyName]; exists {
+			continue
+		}
+		if property, err := specSchema.getProperty(propertyName); err == nil {
+			if !property.isComputed() || property.Type != typeString {
+				r.getLogger().Warn(fmt.Sprintf("resource '%s' property '%s' configured in the '%s' extension is not a computed string property, the response header will not be stored", r.openAPIResource.getResourceName(), propertyName, extTfResponseHeaderProperty), "resource", r.openAPIResource.getResourceName())
+				continue
+			}
+			responseHeaderProperties[propertyName] = property.getTerraformCompliantPropertyName()
+			continue
+		}
+		if _, exists := resourceSchema[propertyName]; exists || !snakeCaseNameRegex.MatchString(propertyName) {
+			r.getLogger().Warn(fmt.Sprintf("resource '%s' property '%s' configured in the '%s' extension is not a snake case name or collides with an existing property, the response header will not be stored", r.openAPIResource.getResourceName(), propertyName, extTfResponseHeaderProperty), "resource", r.openAPIResource.getResourceName())
+			continue
+		}
+		resourceSchema[propertyName] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The value of the response header returned by the API when the resource is created or updated",
+		}
+		responseHeaderProperties[propertyName] = propertyName
+	}
+	return responseHeaderProperties
+}
+
+// setResponseHeadersState stores the values of the response headers configured in the operation in the properties
+// they populate. The headers not returned by the API are ignored so the values previously stored are kept
+func (r resourceFactory) setResponseHeadersState(operation *specResourceOperation, res *http.Response, data *schema.ResourceData) error {
+	if operation == nil || res == nil {
+		return nil
+	}
+	for header, propertyName := range operation.responseHeaderProperties {
+		terraformName, ok := r.responseHeaderProperties[propertyName]
+		value := res.Header.Get(header)
+		if !ok || value == "" {
+			continue
+		}
+		if err := data.Set(terraformName, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAddResponseHeadersSchema(t *testing.T) {
+	Convey("Given a resource factory of a resource which create operation captures response headers into properties", t, func() {
+		resourceIDProperty := newStringSchemaDefinitionPropertyWithDefaults("resourceId", "", false, true, nil)
+		countProperty := newIntSchemaDefinitionPropertyWithDefaults("count", "", false, true, nil)
+		postOperation := &specResourceOperation{responseHeaderProperties: map[string]string{"X-Resource-Id": "resourceId", "X-Count": "count", "X-Request-Id": "request_id", "X-Invalid": "Invalid-Name", "X-Label": stringProperty.Name}}
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, newTestSchema(idProperty, stringProperty, resourceIDProperty, countProperty).getSchemaDefinition(), postOperation, &specResourceOperation{responseHeaderProperties: map[string]string{"X-Resource-Id": "resourceId"}}, &specResourceOperation{}, &specResourceOperation{})
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		Convey("When addResponseHeadersSchema is called", func() {
+			responseHeaderProperties := r.addResponseHeadersSchema(resourceSchema)
+			Convey("Then the computed string properties of the resource schema and the properties added should be returned", func() {
+				So(responseHeaderProperties, ShouldResemble, map[string]string{"resourceId": "resource_id", "request_id": "request_id"})
+			})
+			Convey("And the computed property should be added for the property names not defined in the resource schema", func() {
+				So(resourceSchema["request_id"].Computed, ShouldBeTrue)
+				So(resourceSchema["request_id"].Type, ShouldEqual, schema.TypeString)
+				So(resourceSchema, ShouldNotContainKey, "Invalid-Name")
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which operations do not capture response headers", t, func() {
+		r := newResourceFactory(newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, newTestSchema(idProperty).getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}))
+		Convey("When addResponseHeadersSchema is called", func() {
+			resourceSchema := map[string]*schema.Schema{}
+			Convey("Then no properties should be returned nor added", func() {
+				So(r.addResponseHeadersSchema(resourceSchema), ShouldBeNil)
+				So(resourceSchema, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestCreateAndUpdateWithResponseHeaders(t *testing.T) {
+	testCreateResponseHeadersResourceFactory := func(properties ...*specSchemaDefinitionProperty) (resourceFactory, *schema.ResourceData) {
+		postOperation := &specResourceOperation{responseHeaderProperties: map[string]string{"X-Resource-Id": "resourceId", "X-Request-Id": "request_id"}}
+		putOperation := &specResourceOperation{responseHeaderProperties: map[string]string{"X-Request-Id": "request_id"}}
+		specResource := newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, newTestSchema(properties...).getSchemaDefinition(), postOperation, putOperation, &specResourceOperation{}, &specResourceOperation{})
+		r := newResourceFactory(specResource)
+		resourceSchema, err := r.createTerraformResourceSchema()
+		So(err, ShouldBeNil)
+		r.responseHeaderProperties = r.addResponseHeadersSchema(resourceSchema)
+		return r, schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{stringProperty.Name: stringProperty.Default})
+	}
+	resourceIDProperty := newStringSchemaDefinitionPropertyWithDefaults("resourceId", "", false, true, nil)
+	Convey("Given a resource factory of a resource which create operation captures response headers into properties", t, func() {
+		r, data := testCreateResponseHeadersResourceFactory(idProperty, stringProperty, resourceIDProperty)
+		Convey("When create is called with a client that returns the headers", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{idProperty.Name: "id", stringProperty.Name: stringProperty.Default},
+				returnHeaders:   http.Header{"X-Resource-Id": []string{"res-1234"}, "X-Request-Id": []string{"req-1"}},
+			}
+			err := r.create(context.Background(), data, client)
+			Convey("Then the values of the headers should be stored in the properties", func() {
+				So(err, ShouldBeNil)
+				So(data.Get("resource_id"), ShouldEqual, "res-1234")
+				So(data.Get("request_id"), ShouldEqual, "req-1")
+			})
+		})
+	})
+	Convey("Given a resource factory of a resource which update operation captures response headers into properties", t, func() {
+		r, data := testCreateResponseHeadersResourceFactory(stringProperty, resourceIDProperty)
+		data.SetId("id")
+		So(data.Set("resource_id", "res-1234"), ShouldBeNil)
+		So(data.Set("request_id", "req-1"), ShouldBeNil)
+		Convey("When update is called with a client that only returns some of the headers", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{stringProperty.Name: "someUpdatedValue"},
+				returnHeaders:   http.Header{"X-Request-Id": []string{"req-2"}},
+			}
+			err := r.update(context.Background(), data, client)
+			Convey("Then the properties of the headers returned should be updated and the rest kept", func() {
+				So(err, ShouldBeNil)
+				So(data.Get("request_id"), ShouldEqual, "req-2")
+				So(data.Get("resource_id"), ShouldEqual, "res-1234")
+			})
+		})
+	})
+}
@@ -1,20 +1,24 @@
 package openapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/dikhan/http_goclient"
 	"github.com/go-openapi/spec"
 
 	"encoding/json"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -43,6 +47,41 @@ func TestCreateSchemaResourceTimeout(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given a resource factory initialised with a spec resource that does not have any timeouts", t, func() {
+		r := newResourceFactory(&specStubResource{
+			timeouts: &specTimeouts{},
+		})
+		Convey("When createSchemaResourceTimeout is called", func() {
+			timeouts, err := r.createSchemaResourceTimeout()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the create, update and delete timeouts should be exposed with the default timeout", func() {
+				So(*timeouts.Create, ShouldEqual, defaultTimeout)
+				So(*timeouts.Update, ShouldEqual, defaultTimeout)
+				So(*timeouts.Delete, ShouldEqual, defaultTimeout)
+				So(*timeouts.Default, ShouldEqual, defaultTimeout)
+			})
+			Convey("And the read timeout should not be exposed", func() {
+				So(timeouts.Read, ShouldBeNil)
+			})
+		})
+	})
+	Convey("Given a resource factory initialised with a read only spec resource that does not have any timeouts", t, func() {
+		r := newResourceFactory(&specStubResource{
+			timeouts: &specTimeouts{},
+			readOnly: true,
+		})
+		Convey("When createSchemaResourceTimeout is called", func() {
+			timeouts, err := r.createSchemaResourceTimeout()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the update timeout should not be exposed since the resource can not be updated", func() {
+				So(timeouts.Update, ShouldBeNil)
+			})
+		})
+	})
 }
 
 func TestCreateTerraformResource(t *testing.T) {
@@ -63,21 +102,95 @@ func TestCreateTerraformResource(t *testing.T) {
 				So(schemaResource.Schema, ShouldNotBeEmpty)
 			})
 			Convey("And the create function is invokable and returns nil error", func() {
-				err := schemaResource.Create(resourceData, client)
-				So(err, ShouldBeNil)
+				diags := schemaResource.CreateContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
 			})
 			Convey("And the read function is invokable and returns nil error", func() {
-				err := schemaResource.Read(resourceData, client)
-				So(err, ShouldBeNil)
+				diags := schemaResource.ReadContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
 			})
 			Convey("And the update function is invokable and returns nil error", func() {
-				err := schemaResource.Update(resourceData, client)
-				So(err, ShouldBeNil)
+				diags := schemaResource.UpdateContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
 			})
 			Convey("And the delete function is invokable and returns nil error", func() {
-				err := schemaResource.Delete(resourceData, client)
+				diags := schemaResource.DeleteContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestCreateTerraformResourceReadOnly(t *testing.T) {
+	Convey("Given a resource factory initialised with a read only spec resource", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, computedProperty)
+		r.openAPIResource.(*specStubResource).readOnly = true
+		Convey("When createTerraformResource is called", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:       idProperty.Default,
+					computedProperty.Name: "someValue",
+				},
+			}
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
+			Convey("And the schema resource should be valid and not support updates", func() {
+				So(schemaResource.InternalValidate(nil, true), ShouldBeNil)
+				So(schemaResource.UpdateContext, ShouldBeNil)
+			})
+			Convey("And the create function should return an error explaining the resource can not be created", func() {
+				diags := schemaResource.CreateContext(context.Background(), resourceData, client)
+				So(diags, ShouldHaveLength, 1)
+				So(diags[0].Summary, ShouldEqual, "[resource='resourceName'] resource is read only and can not be created, existing instances can be imported with 'terraform import' or read using the resource data source instance")
+			})
+			Convey("And the read function is invokable and populates the state with the values returned by the API", func() {
+				diags := schemaResource.ReadContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
+				So(resourceData.Get(computedProperty.Name), ShouldEqual, "someValue")
+			})
+			Convey("And the delete function should not call the API and just remove the resource from the state", func() {
+				diags := schemaResource.DeleteContext(context.Background(), resourceData, client)
+				So(diags, ShouldBeNil)
+				So(client.responsePayload, ShouldContainKey, idProperty.Name)
+			})
+		})
+	})
+}
+
+func TestCreateTerraformResourceStateMigrations(t *testing.T) {
+	Convey("Given a resource factory initialised with a spec resource that declares state migrations", t, func() {
+		r, _ := testCreateResourceFactoryWithID(t, idProperty, optionalProperty)
+		r.openAPIResource.(*specStubResource).stateMigrations = specStateMigrations{
+			{renames: map[string]string{"previous_name": optionalProperty.Name}},
+		}
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the schema resource should be valid and contain the schema version and state upgraders", func() {
+				So(schemaResource.InternalValidate(nil, true), ShouldBeNil)
+				So(schemaResource.SchemaVersion, ShouldEqual, 1)
+				So(len(schemaResource.StateUpgraders), ShouldEqual, 1)
+			})
+			Convey("And the state upgrader should migrate the previous state to the current schema", func() {
+				state, err := schemaResource.StateUpgraders[0].Upgrade(context.Background(), map[string]interface{}{"id": "someID", "previous_name": "someValue"}, nil)
+				So(err, ShouldBeNil)
+				So(state, ShouldResemble, map[string]interface{}{"id": "someID", optionalProperty.Name: "someValue"})
+			})
+		})
+	})
+	Convey("Given a resource factory initialised with a spec resource that does not declare state migrations", t, func() {
+		r, _ := testCreateResourceFactoryWithID(t, idProperty, optionalProperty)
+		Convey("When createTerraformResource is called", func() {
+			schemaResource, err := r.createTerraformResource()
+			Convey("Then the schema resource should keep the default schema version and no state upgraders", func() {
+				So(err, ShouldBeNil)
+				So(schemaResource.SchemaVersion, ShouldEqual, 0)
+				So(schemaResource.StateUpgraders, ShouldBeNil)
+			})
 		})
 	})
 }
@@ -100,6 +213,33 @@ func TestCreateTerraformResourceSchema(t *testing.T) {
 	})
 }
 
+// clientOpenAPINotFoundStub responds to the first GET requests with 404 Not Found, emulating the APIs that are
+// eventually consistent
+type clientOpenAPINotFoundStub struct {
+	clientOpenAPIStub
+	notFoundResponses int
+	getCalls          int
+}
+
+func (c *clientOpenAPINotFoundStub) Get(resource SpecResource, id string, responsePayload interface{}, parentIDs ...string) (*http.Response, error) {
+	c.getCalls++
+	if c.getCalls <= c.notFoundResponses {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}
+	return c.clientOpenAPIStub.Get(resource, id, responsePayload, parentIDs...)
+}
+
+// newTestAPIProviderClient returns a provider client that sends the requests to the API URL provided (e,g: the URL of a
+// httptest server)
+func newTestAPIProviderClient(apiURL string) *ProviderClient {
+	return &ProviderClient{
+		openAPIBackendConfiguration: newStubBackendConfiguration(strings.TrimPrefix(apiURL, "http://"), "", "http"),
+		httpClient:                  &http_goclient.HttpClient{HttpClient: &http.Client{}},
+		providerConfiguration:       providerConfiguration{},
+		apiAuthenticator:            newAPIAuthenticator(&SpecSecuritySchemes{}, nil),
+	}
+}
+
 func TestCreate(t *testing.T) {
 	Convey("Given a resource factory", t, func() {
 		r, resourceData := testCreateResourceFactory(t, idProperty, stringProperty)
@@ -110,7 +250,7 @@ func TestCreate(t *testing.T) {
 					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
 				},
 			}
-			err := r.create(resourceData, client)
+			err := r.create(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -119,6 +259,24 @@ func TestCreate(t *testing.T) {
 				So(resourceData.Get(stringProperty.Name), ShouldEqual, client.responsePayload[stringProperty.Name])
 			})
 		})
+		Convey("When create is called with a client returning the resource wrapped in the response wrapper property configured", func() {
+			r.openAPIResource.(*specStubResource).resourcePostOperation.responseWrapperProperty = "data"
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					"data": map[string]interface{}{
+						idProperty.Name:     "someID",
+						stringProperty.Name: "someValueInTheEnvelope",
+					},
+					"meta": map[string]interface{}{"request_id": "someRequestID"},
+				},
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then resourceData should be populated with the values of the resource contained in the envelope", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValueInTheEnvelope")
+			})
+		})
 		Convey("When create is called with resource data and a client configured to return an error when POST is called", func() {
 			createError := fmt.Errorf("some error when deleting")
 			client := &clientOpenAPIStub{
@@ -127,7 +285,7 @@ func TestCreate(t *testing.T) {
 				},
 				error: createError,
 			}
-			err := r.create(resourceData, client)
+			err := r.create(context.Background(), resourceData, client)
 			Convey("Then the error returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -141,7 +299,7 @@ func TestCreate(t *testing.T) {
 				responsePayload: map[string]interface{}{},
 				returnHTTPCode:  http.StatusInternalServerError,
 			}
-			err := r.create(resourceData, client)
+			err := r.create(context.Background(), resourceData, client)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -154,7 +312,7 @@ func TestCreate(t *testing.T) {
 			client := &clientOpenAPIStub{
 				responsePayload: map[string]interface{}{},
 			}
-			err := r.create(resourceData, client)
+			err := r.create(context.Background(), resourceData, client)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -164,17 +322,271 @@ func TestCreate(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory which create operation (POST) returns the location of the resource created instead of the resource", t, func() {
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		postOperation := &specResourceOperation{}
+		r := resourceFactory{
+			openAPIResource: newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}),
+		}
+		newPostResponse := func(header, location string) func() (*http.Response, error) {
+			return func() (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusCreated, Header: http.Header{header: []string{location}}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			}
+		}
+		Convey("When create is called and the POST response contains the Location header", func() {
+			client := &clientOpenAPIStub{
+				funcPost: newPostResponse("Location", "https://api.domain.com/v1/resource/someID"),
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "someID",
+					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+				},
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the id should be extracted from the location and used to read the resource", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(client.idReceived, ShouldEqual, "someID")
+			})
+			Convey("And resourceData should be populated with the values returned by the API when reading the resource", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someExtraValueThatProvesResponseDataIsPersisted")
+			})
+		})
+		Convey("When create is called and the POST response contains the location in the header configured in the operation", func() {
+			postOperation.locationHeader = "X-Resource-Location"
+			client := &clientOpenAPIStub{
+				funcPost:        newPostResponse("X-Resource-Location", "/v1/resource/someOtherID/"),
+				responsePayload: map[string]interface{}{idProperty.Name: "someOtherID"},
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil and the id should be extracted from the configured header", func() {
+				So(err, ShouldBeNil)
+				So(resourceData.Id(), ShouldEqual, "someOtherID")
+			})
+		})
+		Convey("When create is called and the POST response location does not contain an id", func() {
+			client := &clientOpenAPIStub{
+				funcPost: newPostResponse("Location", "https://api.domain.com/"),
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "response object returned from the API is missing mandatory identifier property 'id' and the id could not be extracted from the 'Location' header: location 'https://api.domain.com/' does not contain the resource id")
+			})
+		})
+		Convey("When create is called and the resource created is not visible yet but the operation is configured to read it again", func() {
+			postOperation.readAfterCreateRetries = 2
+			r.readAfterCreateRetryInterval = time.Millisecond
+			client := &clientOpenAPINotFoundStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					funcPost:        newPostResponse("Location", "/v1/resource/someID"),
+					responsePayload: map[string]interface{}{idProperty.Name: "someID", stringProperty.Name: "someValue"},
+				},
+				notFoundResponses: 2,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the resource should be read until it is visible", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 3)
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValue")
+			})
+		})
+		Convey("When create is called and the resource created is still not visible once the read retries are exhausted", func() {
+			postOperation.readAfterCreateRetries = 1
+			r.readAfterCreateRetryInterval = time.Millisecond
+			client := &clientOpenAPINotFoundStub{
+				clientOpenAPIStub: clientOpenAPIStub{funcPost: newPostResponse("Location", "/v1/resource/someID")},
+				notFoundResponses: 2,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the not found one", func() {
+				So(err.Error(), ShouldStartWith, "[resource='resourceName'] GET /v1/resource/someID after POST failed: HTTP Response Status Code 404 - Not Found")
+				So(client.getCalls, ShouldEqual, 2)
+			})
+		})
+		Convey("When create is called and the read of the resource created fails", func() {
+			client := &clientOpenAPIStub{
+				funcPost:       newPostResponse("Location", "/v1/resource/someID"),
+				returnHTTPCode: http.StatusInternalServerError,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the expected", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] GET /v1/resource/someID after POST failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200] ()")
+			})
+		})
+	})
+
+	Convey("Given a resource factory and an API that responds to the POST request with 201 Created, the Location header and an empty body", t, func() {
+		var requests []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+			switch r.Method {
+			case http.MethodPost:
+				w.Header().Set("Location", "/v1/resource/someID")
+				w.WriteHeader(http.StatusCreated)
+			case http.MethodGet:
+				w.Write([]byte(`{"id":"someID","string_property":"someExtraValueThatProvesResponseDataIsPersisted"}`))
+			}
+		}))
+		defer api.Close()
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		r := resourceFactory{
+			openAPIResource: newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}),
+		}
+		Convey("When create is called with a provider client", func() {
+			err := r.create(context.Background(), resourceData, newTestAPIProviderClient(api.URL))
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the id should be extracted from the location and used to read the resource", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(requests, ShouldResemble, []string{"POST /v1/resource", "GET /v1/resource/someID"})
+			})
+			Convey("And resourceData should be populated with the values returned by the API when reading the resource", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someExtraValueThatProvesResponseDataIsPersisted")
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured to read the resource again after being created and an API that responds with 404 Not Found and an empty body until the resource is visible", t, func() {
+		var requests []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+			switch {
+			case r.Method == http.MethodPost:
+				w.Header().Set("Location", "/v1/resource/someID")
+				w.WriteHeader(http.StatusCreated)
+			case len(requests) < 4:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.Write([]byte(`{"id":"someID","string_property":"someValue"}`))
+			}
+		}))
+		defer api.Close()
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		r := resourceFactory{
+			openAPIResource:              newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), &specResourceOperation{readAfterCreateRetries: 2}, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}),
+			readAfterCreateRetryInterval: time.Millisecond,
+		}
+		Convey("When create is called with a provider client", func() {
+			err := r.create(context.Background(), resourceData, newTestAPIProviderClient(api.URL))
+			Convey("Then the resource should be read until it is visible", func() {
+				So(err, ShouldBeNil)
+				So(requests, ShouldResemble, []string{"POST /v1/resource", "GET /v1/resource/someID", "GET /v1/resource/someID", "GET /v1/resource/someID"})
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValue")
+			})
+		})
+	})
+
+	Convey("Given a resource factory which create operation (POST) is asynchronous and an API that responds with 202 Accepted, the operation location and an empty body", t, func() {
+		var requests []string
+		api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+			switch r.URL.Path {
+			case "/v1/resource":
+				w.Header().Set("Location", "/v1/operations/1")
+				w.WriteHeader(http.StatusAccepted)
+			case "/v1/operations/1":
+				if len(requests) == 2 {
+					w.Write([]byte(`{"status":"running"}`))
+					return
+				}
+				w.Write([]byte(`{"status":"succeeded","id":"someID"}`))
+			case "/v1/resource/someID":
+				w.Write([]byte(`{"id":"someID","string_property":"someValue"}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer api.Close()
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		postOperation := &specResourceOperation{
+			responses: map[int]*specResponse{
+				http.StatusAccepted: {asyncOperation: &specAsyncOperation{locationHeader: "Location", statusField: "status", successStatuses: []string{"succeeded"}, failureStatuses: []string{"failed"}}},
+			},
+		}
+		r := resourceFactory{
+			openAPIResource:       newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}),
+			defaultPollDelay:      time.Millisecond,
+			defaultPollInterval:   time.Millisecond,
+			defaultPollMinTimeout: time.Millisecond,
+		}
+		Convey("When create is called with a provider client", func() {
+			err := r.create(context.Background(), resourceData, newTestAPIProviderClient(api.URL))
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the id should be taken from the operation payload rather than the operation location", func() {
+				So(resourceData.Id(), ShouldEqual, "someID")
+				So(requests, ShouldResemble, []string{"POST /v1/resource", "GET /v1/operations/1", "GET /v1/operations/1", "GET /v1/resource/someID"})
+			})
+			Convey("And resourceData should be populated with the values returned by the API when reading the resource", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValue")
+			})
+		})
+	})
+
 	Convey("Given a resource factory with an empty OpenAPI resource", t, func() {
 		r := resourceFactory{}
 		Convey("When create is called with empty data and a empty client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.create(nil, client)
+			err := r.create(context.Background(), nil, client)
 			Convey("Then the error should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
 		})
 	})
 
+	Convey("Given a resource factory which create operation documents the field errors for 422 responses", t, func() {
+		testSchema := newTestSchema(idProperty, stringProperty)
+		resourceData := testSchema.getResourceData(t)
+		postOperation := &specResourceOperation{responses: specResponses{http.StatusUnprocessableEntity: &specResponse{fieldErrors: &specResponseFieldErrors{path: "errors", fieldKey: "field", messageKey: "message"}}}}
+		r := resourceFactory{
+			openAPIResource: newSpecStubResourceWithOperations("resourceName", "/v1/resource", false, testSchema.getSchemaDefinition(), postOperation, &specResourceOperation{}, &specResourceOperation{}, &specResourceOperation{}),
+		}
+		Convey("When create is called and the API returns field errors that can be correlated to the resource attributes", func() {
+			client := &clientOpenAPIStub{
+				returnHTTPCode: http.StatusUnprocessableEntity,
+				returnBody:     `{"errors":[{"field":"string_property","message":"must not be empty"},{"field":"unknown","message":"not allowed"}]}`,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the error returned should contain the attribute level errors", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 422 - the following attributes are not valid:\n- attribute 'string_property': must not be empty\n- field 'unknown': not allowed")
+			})
+			Convey("And the diagnostics created from the error should point at the attributes not valid", func() {
+				diags := errorToDiagnostics(err)
+				So(diags, ShouldHaveLength, 1)
+				So(diags[0].AttributePath, ShouldResemble, cty.GetAttrPath("string_property"))
+				So(diags[0].Summary, ShouldEqual, "[resource='resourceName'] attribute 'string_property' is not valid: must not be empty")
+				So(diags[0].Detail, ShouldEqual, err.Error())
+			})
+		})
+		Convey("When create is called and the API returns field errors that can not be correlated to the resource attributes", func() {
+			client := &clientOpenAPIStub{
+				returnHTTPCode: http.StatusUnprocessableEntity,
+				returnBody:     `{"errors":[{"field":"unknown","message":"not allowed"}]}`,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the resource level error should be returned", func() {
+				So(err.Error(), ShouldEqual, `[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 422 not matching expected one [200 201 202] ({"errors":[{"field":"unknown","message":"not allowed"}]})`)
+			})
+		})
+		Convey("When create is called and the API returns an error response that does not contain field errors", func() {
+			client := &clientOpenAPIStub{
+				returnHTTPCode: http.StatusUnprocessableEntity,
+				returnBody:     `some error`,
+			}
+			err := r.create(context.Background(), resourceData, client)
+			Convey("Then the resource level error should be returned", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] POST /v1/resource failed: [resource='resourceName'] HTTP Response Status Code 422 not matching expected one [200 201 202] (some error)")
+			})
+		})
+	})
+
 	Convey("Given a resource factory that has an asynchronous create operation (post) but the polling operation fails for some reason", t, func() {
 		expectedReturnCode := 202
 		testSchema := newTestSchema(idProperty, stringProperty)
@@ -192,7 +604,7 @@ func TestCreate(t *testing.T) {
 					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
 				},
 			}
-			err := r.create(resourceData, client)
+			err := r.create(context.Background(), resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "polling mechanism failed after POST /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' (someID) when waiting: [resource='resourceName'] HTTP Response Status Code 202 not matching expected one [200] ()")
 			})
@@ -209,7 +621,7 @@ func TestRead(t *testing.T) {
 					stringProperty.Name: "someOtherStringValue",
 				},
 			}
-			err := r.read(resourceData, client)
+			err := r.read(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -219,6 +631,54 @@ func TestRead(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory configured with a resource that no longer exists in the API", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		client := &clientOpenAPIStub{
+			returnHTTPCode: http.StatusNotFound,
+		}
+		Convey("When read is called with resource data and a client that returns 404", func() {
+			err := r.read(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource should be removed from the state", func() {
+				So(resourceData.Id(), ShouldBeEmpty)
+			})
+		})
+		Convey("When read is called and the resource is configured to not be removed from the state on 404", func() {
+			r.openAPIResource.(*specStubResource).keepOnNotFound = true
+			err := r.read(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, fmt.Sprintf("[resource='resourceName'] GET /v1/resource/%s failed: HTTP Response Status Code 404 - Not Found", idProperty.Default))
+			})
+			Convey("And the resource should be kept in the state", func() {
+				So(resourceData.Id(), ShouldEqual, idProperty.Default)
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a property which value is returned by the API in a different response field", t, func() {
+		passwordProperty := newStringSchemaDefinitionPropertyWithDefaults("password", "", true, false, "somePassword")
+		passwordProperty.ResponseFieldName = "password_hash"
+		r, resourceData := testCreateResourceFactory(t, idProperty, passwordProperty)
+		Convey("When read is called with resource data and a client that returns the value in the aliased response field", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					"password":      "someOtherPassword",
+					"password_hash": "someHashedPassword",
+				},
+			}
+			err := r.read(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And resourceData should contain the value from the aliased response field", func() {
+				So(resourceData.Get(passwordProperty.Name), ShouldEqual, "someHashedPassword")
+			})
+		})
+	})
+
 	Convey("Given a resource factory configured with a resource that is a subresource", t, func() {
 
 		someOtherProperty := newStringSchemaDefinitionPropertyWithDefaults("some_string_prop", "", true, false, "some value")
@@ -248,7 +708,7 @@ func TestRead(t *testing.T) {
 					someOtherProperty.Name: "someOtherStringValue",
 				},
 			}
-			err := r.read(resourceData, client)
+			err := r.read(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -262,7 +722,7 @@ func TestRead(t *testing.T) {
 		r := resourceFactory{}
 		Convey("When create is called with empty data and a empty client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.read(nil, client)
+			err := r.read(context.Background(), nil, client)
 			Convey("Then the error should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -271,6 +731,22 @@ func TestRead(t *testing.T) {
 }
 
 func TestReadRemote(t *testing.T) {
+	Convey("Given a resource factory of a resource which GET operation wraps the resource in the response payload", t, func() {
+		r := newResourceFactory(&specStubResource{name: "resourceName", resourceGetOperation: &specResourceOperation{responseWrapperProperty: "data"}})
+		Convey("When readRemote is called with a client that returns the resource wrapped", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					"data": map[string]interface{}{idProperty.Name: "someID"},
+					"meta": map[string]interface{}{"request_id": "someRequestID"},
+				},
+			}
+			response, err := r.readRemote("someID", client)
+			Convey("Then the map returned should be the resource contained in the envelope", func() {
+				So(err, ShouldBeNil)
+				So(response, ShouldResemble, map[string]interface{}{idProperty.Name: "someID"})
+			})
+		})
+	})
 
 	Convey("Given a resource factory", t, func() {
 		r := newResourceFactory(&specStubResource{name: "resourceName"})
@@ -346,7 +822,7 @@ func TestUpdate(t *testing.T) {
 					immutableProperty.Name: immutableProperty.Default,
 				},
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -366,7 +842,7 @@ func TestUpdate(t *testing.T) {
 					immutableProperty.Name: "immutableOriginalValue",
 				},
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -387,7 +863,7 @@ func TestUpdate(t *testing.T) {
 				},
 				error: updateError,
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("Then the error returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -412,7 +888,7 @@ func TestUpdate(t *testing.T) {
 					}, nil
 				},
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("And the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 202] ()")
 			})
@@ -425,22 +901,132 @@ func TestUpdate(t *testing.T) {
 					stringProperty.Name: "someValue",
 				},
 				funcPut: func() (*http.Response, error) {
-					return nil, fmt.Errorf(expectedError)
+					return nil, errors.New(expectedError)
 				},
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("And the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, expectedError)
 			})
 		})
 	})
 
+	Convey("Given a resource factory for a resource that only supports PATCH updates", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		specResource := r.openAPIResource.(*specStubResource)
+		specResource.resourcePutOperation = nil
+		specResource.resourcePatchOperation = &specResourceOperation{}
+		Convey("When update is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "id",
+					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+				},
+			}
+			err := r.update(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should have received a merge patch containing only the changed properties", func() {
+				So(client.patchPayloadReceived, ShouldResemble, map[string]interface{}{idProperty.Name: idProperty.Default, stringProperty.Name: stringProperty.Default})
+			})
+			Convey("And resourceData should be populated with the values returned by the API", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, client.responsePayload[stringProperty.Name])
+			})
+		})
+		Convey("When update is called with resource data and a client that responds with no content", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "id",
+					stringProperty.Name: "someValueReadAfterThePatch",
+				},
+				funcPatch: func() (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusNoContent,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			}
+			err := r.update(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And resourceData should be populated with the values read from the API after the patch", func() {
+				So(resourceData.Get(stringProperty.Name), ShouldEqual, "someValueReadAfterThePatch")
+			})
+		})
+		Convey("When update is called with resource data and a client that responds with a non expected http code", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "id",
+				},
+				funcPatch: func() (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			}
+			err := r.update(context.Background(), resourceData, client)
+			Convey("Then the error returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] UPDATE /v1/resource/id failed: [resource='resourceName'] HTTP Response Status Code 500 not matching expected one [200 202 204] ()")
+			})
+		})
+	})
+
+	Convey("Given a resource factory for a resource which PATCH operation expects the resource wrapped in the request payload", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		specResource := r.openAPIResource.(*specStubResource)
+		specResource.resourcePutOperation = nil
+		specResource.resourcePatchOperation = &specResourceOperation{requestWrapperProperty: "server"}
+		Convey("When update is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name: "id",
+				},
+			}
+			err := r.update(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should have received the merge patch nested under the request wrapper property", func() {
+				So(client.patchPayloadReceived, ShouldResemble, map[string]interface{}{"server": map[string]interface{}{idProperty.Name: idProperty.Default, stringProperty.Name: stringProperty.Default}})
+			})
+		})
+	})
+
+	Convey("Given a resource factory for a resource that supports PUT and PATCH configured with the json-patch update strategy", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		r.openAPIResource.(*specStubResource).resourcePatchOperation = &specResourceOperation{updateStrategy: updateStrategyJSONPatch}
+		Convey("When update is called with resource data and a client", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					idProperty.Name:     "id",
+					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
+				},
+				funcPut: func() (*http.Response, error) {
+					return nil, errors.New("PUT should not be called")
+				},
+			}
+			err := r.update(context.Background(), resourceData, client)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the client should have received a JSON patch containing only the changed properties", func() {
+				So(client.patchPayloadReceived, ShouldResemble, []jsonPatchOperation{
+					{Op: "add", Path: "/" + idProperty.Name, Value: idProperty.Default},
+					{Op: "add", Path: "/" + stringProperty.Name, Value: stringProperty.Default},
+				})
+			})
+		})
+	})
+
 	Convey("Given a resource factory with no update operation configured", t, func() {
 		specResource := newSpecStubResource("resourceName", "/v1/resource", false, nil)
 		r := newResourceFactory(specResource)
 		Convey("When update is called with resource data and a client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.update(nil, client)
+			err := r.update(context.Background(), nil, client)
 			Convey("Then the expectedValue returned should be true", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -454,7 +1040,7 @@ func TestUpdate(t *testing.T) {
 		r := resourceFactory{}
 		Convey("When create is called with empty data and a empty client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.update(nil, client)
+			err := r.update(context.Background(), nil, client)
 			Convey("Then the error should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -483,7 +1069,7 @@ func TestUpdate(t *testing.T) {
 					stringProperty.Name: "someValue",
 				},
 			}
-			err := r.update(resourceData, client)
+			err := r.update(context.Background(), resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "polling mechanism failed after PUT /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([]) [valid pending statuses ([])]: error occurred while retrieving status identifier value from payload for resource 'resourceName' (): could not find any status property. Please make sure the resource schema definition has either one property named 'status' or one property is marked with IsStatusIdentifier set to true")
 			})
@@ -500,7 +1086,7 @@ func TestDelete(t *testing.T) {
 					idProperty.Name: idProperty.Default,
 				},
 			}
-			err := r.delete(resourceData, client)
+			err := r.delete(context.Background(), resourceData, client)
 			Convey("Then the error returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -516,7 +1102,7 @@ func TestDelete(t *testing.T) {
 				},
 				error: deleteError,
 			}
-			err := r.delete(resourceData, client)
+			err := r.delete(context.Background(), resourceData, client)
 			Convey("Then the error returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -530,7 +1116,7 @@ func TestDelete(t *testing.T) {
 				responsePayload: map[string]interface{}{},
 				returnHTTPCode:  http.StatusInternalServerError,
 			}
-			err := r.delete(resourceData, client)
+			err := r.delete(context.Background(), resourceData, client)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -544,7 +1130,7 @@ func TestDelete(t *testing.T) {
 				responsePayload: map[string]interface{}{},
 				returnHTTPCode:  http.StatusNotFound,
 			}
-			err := r.delete(resourceData, client)
+			err := r.delete(context.Background(), resourceData, client)
 			Convey("Then the error returned should NOT be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -556,7 +1142,7 @@ func TestDelete(t *testing.T) {
 		r := newResourceFactory(specResource)
 		Convey("When delete is called with resource data and a client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.delete(nil, client)
+			err := r.delete(context.Background(), nil, client)
 			Convey("Then the expectedValue returned should be true", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -570,7 +1156,7 @@ func TestDelete(t *testing.T) {
 		r := resourceFactory{}
 		Convey("When delete is called with empty data and a empty client", func() {
 			client := &clientOpenAPIStub{}
-			err := r.delete(nil, client)
+			err := r.delete(context.Background(), nil, client)
 			Convey("Then the error should not be nil", func() {
 				So(err, ShouldNotBeNil)
 			})
@@ -594,7 +1180,7 @@ func TestDelete(t *testing.T) {
 					stringProperty.Name: "someExtraValueThatProvesResponseDataIsPersisted",
 				},
 			}
-			err := r.delete(resourceData, client)
+			err := r.delete(context.Background(), resourceData, client)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "polling mechanism failed after DELETE /v1/resource call with response status code (202): error waiting for resource to reach a completion status ([destroyed]) [valid pending statuses ([])]: error on retrieving resource 'resourceName' () when waiting: [resource='resourceName'] HTTP Response Status Code 202 not matching expected one [200] ()")
 			})
@@ -617,7 +1203,7 @@ func TestImporter(t *testing.T) {
 				So(resourceImporter, ShouldNotBeNil)
 			})
 			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
-				data, err := resourceImporter.State(resourceData, client)
+				data, err := resourceImporter.StateContext(context.Background(), resourceData, client)
 				Convey("Then the err returned should be nil", func() {
 					So(err, ShouldBeNil)
 				})
@@ -657,7 +1243,7 @@ func TestImporter(t *testing.T) {
 				So(resourceImporter, ShouldNotBeNil)
 			})
 			Convey("And when the resourceImporter State method is invoked with the provider client and resource data for one item", func() {
-				data, err := resourceImporter.State(resourceData, client)
+				data, err := resourceImporter.StateContext(context.Background(), resourceData, client)
 				Convey("Then the err returned should be nil", func() {
 					So(err, ShouldBeNil)
 				})
@@ -677,6 +1263,35 @@ func TestImporter(t *testing.T) {
 		})
 	})
 
+	Convey("Given a resource factory configured with a sub-resource of three levels (and the already populated id property value provided by the user with all the parent IDs)", t, func() {
+		parentPropertyNames := []string{"vms_id", "disks_id", "snapshots_id"}
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "vm1/disk2/snap3/backup4")
+		properties := []*specSchemaDefinitionProperty{importedIDProperty, stringProperty}
+		for _, parentPropertyName := range parentPropertyNames {
+			properties = append(properties, newStringSchemaDefinitionProperty(parentPropertyName, "", true, true, false, false, false, true, false, false, ""))
+		}
+		r, resourceData := testCreateSubResourceFactory(t, "/vms/{vm_id}/disks/{disk_id}/snapshots/{snapshot_id}/backups", []string{"vms", "disks", "snapshots"}, parentPropertyNames, "vms_disks_snapshots", importedIDProperty, properties[1:]...)
+		Convey("When the resourceImporter State method is invoked with the provider client", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someOtherStringValue",
+				},
+			}
+			data, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the data returned should contain all the parent ids and the resource ID", func() {
+				So(err, ShouldBeNil)
+				So(data, ShouldHaveLength, 1)
+				So(data[0].Get("vms_id"), ShouldEqual, "vm1")
+				So(data[0].Get("disks_id"), ShouldEqual, "disk2")
+				So(data[0].Get("snapshots_id"), ShouldEqual, "snap3")
+				So(data[0].Id(), ShouldEqual, "backup4")
+			})
+			Convey("And the resource should be read with all the parent ids", func() {
+				So(client.parentIDsReceived, ShouldResemble, []string{"vm1", "disk2", "snap3"})
+			})
+		})
+	})
+
 	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value provided by the user with incorrect format)", t, func() {
 		expectedParentPropertyName := "cdns_v1_id"
 
@@ -696,7 +1311,7 @@ func TestImporter(t *testing.T) {
 				So(resourceImporter, ShouldNotBeNil)
 			})
 			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
-				_, err := resourceImporter.State(resourceData, client)
+				_, err := resourceImporter.StateContext(context.Background(), resourceData, client)
 				Convey("Then the err returned should be the expected one", func() {
 					So(err.Error(), ShouldEqual, "can not import a subresource without providing all the parent IDs (1) and the instance ID")
 				})
@@ -704,78 +1319,350 @@ func TestImporter(t *testing.T) {
 		})
 	})
 
-	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains more IDs than the resource number of parent properties)", t, func() {
-		expectedParentPropertyName := "cdns_v1_id"
-		importedIDValue := "/extraID/1234/23564"
-		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
-		expectedParentProperty := newStringSchemaDefinitionProperty(expectedParentPropertyName, "", true, true, false, false, false, true, false, false, "")
-		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, []string{expectedParentPropertyName}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains more IDs than the resource number of parent properties)", t, func() {
+		expectedParentPropertyName := "cdns_v1_id"
+		importedIDValue := "/extraID/1234/23564"
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
+		expectedParentProperty := newStringSchemaDefinitionProperty(expectedParentPropertyName, "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, []string{expectedParentPropertyName}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+
+		Convey("When importer is called", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someOtherStringValue",
+				},
+			}
+			resourceImporter := r.importer()
+			Convey("Then the resource importer returned should Not be nil", func() {
+				So(resourceImporter, ShouldNotBeNil)
+			})
+			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
+				_, err := resourceImporter.StateContext(context.Background(), resourceData, client)
+				Convey("Then the err returned should be the expected one", func() {
+					So(err.Error(), ShouldEqual, "the number of parent IDs provided 3 is greater than the expected number of parent IDs 1")
+				})
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains less IDs than the resource number of parent properties)", t, func() {
+		importedIDValue := "1234/5647" // missing one of the parent ids
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
+		expectedParentProperty := newStringSchemaDefinitionProperty("cdns_v1_firewalls_v1", "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewalls/{id}/rules", []string{"cdns_v1", "cdns_v1_firewalls_v1"}, []string{"cdns_v1_id", "cdns_v1_firewalls_v1_id"}, "cdns_v1_firewalls_v1", importedIDProperty, stringProperty, expectedParentProperty)
+
+		Convey("When importer is called", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someOtherStringValue",
+				},
+			}
+			resourceImporter := r.importer()
+			Convey("Then the resource importer returned should Not be nil", func() {
+				So(resourceImporter, ShouldNotBeNil)
+			})
+			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
+				_, err := resourceImporter.StateContext(context.Background(), resourceData, client)
+				Convey("Then the err returned should be the expected one", func() {
+					So(err.Error(), ShouldEqual, "can not import a subresource without all the parent ids, expected 2 and got 1 parent IDs")
+				})
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains the right IDs but the resource for some reason is not configured correctly and does not have configured the parent id property)", t, func() {
+		expectedParentPropertyName := "cdns_v1_id"
+		importedIDValue := "1234/5678"
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, []string{expectedParentPropertyName}, "cdns_v1", importedIDProperty, stringProperty)
+
+		Convey("When importer is called", func() {
+			client := &clientOpenAPIStub{
+				responsePayload: map[string]interface{}{
+					stringProperty.Name: "someOtherStringValue",
+				},
+			}
+			resourceImporter := r.importer()
+			Convey("Then the resource importer returned should Not be nil", func() {
+				So(resourceImporter, ShouldNotBeNil)
+			})
+			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
+				_, err := resourceImporter.StateContext(context.Background(), resourceData, client)
+				Convey("Then the err returned should be the expected one", func() {
+					So(err.Error(), ShouldEqual, "could not find ID value in the state file for subresource parent property 'cdns_v1_id'")
+				})
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a root resource (and the id of a resource that does not exist provided by the user)", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		Convey("When the resourceImporter State method is invoked and the API returns 404", func() {
+			client := &clientOpenAPIStub{
+				returnHTTPCode: http.StatusNotFound,
+			}
+			_, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, fmt.Sprintf("[resource='resourceName'] can not import non-existent remote object /v1/resource/%s", idProperty.Default))
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource that defines its import ID format (and the import ID provided by the user)", t, func() {
+		expectedParentPropertyName := "zones_v1_id"
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "zone1:record2")
+		expectedParentProperty := newStringSchemaDefinitionProperty(expectedParentPropertyName, "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/zones/{zone_id}/records", []string{"zones_v1"}, []string{expectedParentPropertyName}, "zones_v1", importedIDProperty, stringProperty, expectedParentProperty)
+		specResource := r.openAPIResource.(*specStubResource)
+		specResource.parentPathParameters = []string{"zone_id"}
+		specResource.importIDFormat = "{zone_id}:{record_id}"
+		client := &clientOpenAPIStub{
+			responsePayload: map[string]interface{}{
+				stringProperty.Name: "someOtherStringValue",
+			},
+		}
+		Convey("When the resourceImporter State method is invoked with an import ID matching the format", func() {
+			data, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the data returned should contain the parent id and the resource ID extracted from the import ID", func() {
+				So(data[0].Get(expectedParentPropertyName), ShouldEqual, "zone1")
+				So(data[0].Id(), ShouldEqual, "record2")
+				So(client.parentIDsReceived, ShouldResemble, []string{"zone1"})
+			})
+		})
+		Convey("When the resourceImporter State method is invoked with an import ID that does not match the format", func() {
+			resourceData.SetId("zone1/record2")
+			_, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='subResourceName'] import ID 'zone1/record2' does not match the expected format '{zone_id}:{record_id}'")
+			})
+		})
+	})
+
+	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains an empty parent ID)", t, func() {
+		expectedParentPropertyName := "cdns_v1_id"
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, "/5678")
+		expectedParentProperty := newStringSchemaDefinitionProperty(expectedParentPropertyName, "", true, true, false, false, false, true, false, false, "")
+		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, []string{expectedParentPropertyName}, "cdns_v1", importedIDProperty, stringProperty, expectedParentProperty)
+		Convey("When the resourceImporter State method is invoked", func() {
+			_, err := r.importer().StateContext(context.Background(), resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "can not import a subresource with empty IDs, the expected format is 'cdns_v1_id/<id>' and got '/5678'")
+			})
+		})
+	})
+}
+
+func TestImporterWithImportLookup(t *testing.T) {
+	Convey("Given a resource factory configured with a root resource that supports import lookup by name (and the name value provided by the user as the import id)", t, func() {
+		nameProperty := newStringSchemaDefinitionPropertyWithDefaults("name", "", true, false, nil)
+		importedIDProperty := newStringSchemaDefinitionProperty("id", "", false, true, false, false, false, false, false, false, "my-resource")
+		r, resourceData := testCreateResourceFactoryWithID(t, importedIDProperty, nameProperty)
+		specResource := r.openAPIResource.(*specStubResource)
+		specResource.importLookupProperty = nameProperty.Name
+		specResource.resourceListOperation = &specResourceOperation{}
+		Convey("When the resourceImporter State method is invoked and the list of resources contains exactly one resource matching the name", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{"id": "some-id", nameProperty.Name: "my-resource"},
+					{"id": "some-other-id", nameProperty.Name: "my-other-resource"},
+				},
+				responsePayload: map[string]interface{}{
+					"id":              "some-id",
+					nameProperty.Name: "my-resource",
+				},
+			}
+			data, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the data returned should contain the resolved resource ID", func() {
+				So(data[0].Id(), ShouldEqual, "some-id")
+			})
+			Convey("And the resource should have been read using the resolved resource ID", func() {
+				So(client.idReceived, ShouldEqual, "some-id")
+			})
+			Convey("And the data returned should contain the name field with the right value returned from the API", func() {
+				So(data[0].Get(nameProperty.Name), ShouldEqual, "my-resource")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the list of resources does not contain any resource matching the name", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{"id": "some-other-id", nameProperty.Name: "my-other-resource"},
+				},
+				responsePayload: map[string]interface{}{
+					"id":              "my-resource",
+					nameProperty.Name: "some-name",
+				},
+			}
+			data, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the value provided by the user should be considered the resource ID", func() {
+				So(data[0].Id(), ShouldEqual, "my-resource")
+				So(client.idReceived, ShouldEqual, "my-resource")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the list of resources contains more than one resource matching the name", func() {
+			client := &clientOpenAPIStub{
+				responseListPayload: []map[string]interface{}{
+					{"id": "some-id", nameProperty.Name: "my-resource"},
+					{"id": "some-other-id", nameProperty.Name: "my-resource"},
+				},
+			}
+			_, err := r.importer().StateContext(context.Background(), resourceData, client)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import lookup by 'name' with value 'my-resource' is ambiguous, 2 resources matched. Please import the resource using its id instead")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the list operation is not available", func() {
+			specResource.resourceListOperation = nil
+			_, err := r.importer().StateContext(context.Background(), resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should be the expected one", func() {
+				So(err.Error(), ShouldEqual, "[resource='resourceName'] import lookup by 'name' requires the resource root path to have a GET operation to list the resources")
+			})
+		})
+		Convey("When the resourceImporter State method is invoked and the import lookup property does not exist in the resource schema", func() {
+			specResource.importLookupProperty = "non_existing_property"
+			_, err := r.importer().StateContext(context.Background(), resourceData, &clientOpenAPIStub{})
+			Convey("Then the err returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "[resource='resourceName'] import lookup property 'non_existing_property' not found in the resource schema")
+			})
+		})
+	})
+}
+
+func TestGetPollSettings(t *testing.T) {
+	Convey("Given a resource factory with the default poll settings", t, func() {
+		r := newResourceFactory(&specStubResource{})
+		Convey("When getPollSettings is called with a response that does not override the poll settings", func() {
+			pollInterval, pollMinTimeout, pollDelay := r.getPollSettings(&specResponse{})
+			Convey("Then the poll settings returned should be the resource factory defaults", func() {
+				So(pollInterval, ShouldEqual, defaultPollInterval)
+				So(pollMinTimeout, ShouldEqual, defaultPollMinTimeout)
+				So(pollDelay, ShouldEqual, defaultPollDelay)
+			})
+		})
+		Convey("When getPollSettings is called with a response that overrides the poll settings", func() {
+			interval, minTimeout, delay := 500*time.Millisecond, time.Second, time.Duration(0)
+			pollInterval, pollMinTimeout, pollDelay := r.getPollSettings(&specResponse{pollInterval: &interval, pollMinTimeout: &minTimeout, pollDelay: &delay})
+			Convey("Then the poll settings returned should be the ones configured in the response", func() {
+				So(pollInterval, ShouldEqual, interval)
+				So(pollMinTimeout, ShouldEqual, minTimeout)
+				So(pollDelay, ShouldEqual, delay)
+			})
+		})
+	})
+}
 
-		Convey("When importer is called", func() {
+func TestHandleAsyncOperationIfConfigured(t *testing.T) {
+	Convey("Given a resource factory configured with a resource", t, func() {
+		r, resourceData := testCreateResourceFactoryWithID(t, idProperty, stringProperty)
+		r.defaultPollDelay = time.Millisecond
+		r.defaultPollInterval = time.Millisecond
+		r.defaultPollMinTimeout = time.Millisecond
+		res := &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{"Location": []string{"/v1/operations/1"}}}
+		operation := &specResourceOperation{
+			responses: map[int]*specResponse{
+				http.StatusAccepted: {asyncOperation: &specAsyncOperation{locationHeader: "Location", statusField: "status", successStatuses: []string{"succeeded"}, failureStatuses: []string{"failed"}, errorField: "error"}},
+			},
+		}
+		Convey("When handleAsyncOperationIfConfigured is called and the API reports that the operation is in progress and then that it succeeded", func() {
 			client := &clientOpenAPIStub{
 				responsePayload: map[string]interface{}{
-					stringProperty.Name: "someOtherStringValue",
+					idProperty.Name:     idProperty.Default,
+					stringProperty.Name: "updated value",
 				},
+				asyncOperationPayloads: []map[string]interface{}{{"status": "Running"}, {"status": "Succeeded"}},
 			}
-			resourceImporter := r.importer()
-			Convey("Then the resource importer returned should Not be nil", func() {
-				So(resourceImporter, ShouldNotBeNil)
+			responsePayload := map[string]interface{}{idProperty.Name: idProperty.Default}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), &responsePayload, res, resourceData, client, operation, schema.TimeoutCreate)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
 			})
-			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
-				_, err := resourceImporter.State(resourceData, client)
-				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "the number of parent IDs provided 3 is greater than the expected number of parent IDs 1")
-				})
+			Convey("And the operation URL returned in the location header should have been polled until the operation succeeded", func() {
+				So(client.asyncOperationURLsReceived, ShouldResemble, []string{"/v1/operations/1", "/v1/operations/1"})
+			})
+			Convey("And the response payload should contain the resource read after the operation succeeded", func() {
+				So(responsePayload[stringProperty.Name], ShouldEqual, "updated value")
 			})
 		})
-	})
-
-	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains less IDs than the resource number of parent properties)", t, func() {
-		importedIDValue := "1234/5647" // missing one of the parent ids
-		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
-		expectedParentProperty := newStringSchemaDefinitionProperty("cdns_v1_firewalls_v1", "", true, true, false, false, false, true, false, false, "")
-		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewalls/{id}/rules", []string{"cdns_v1", "cdns_v1_firewalls_v1"}, []string{"cdns_v1_id", "cdns_v1_firewalls_v1_id"}, "cdns_v1_firewalls_v1", importedIDProperty, stringProperty, expectedParentProperty)
-
-		Convey("When importer is called", func() {
-			client := &clientOpenAPIStub{
-				responsePayload: map[string]interface{}{
-					stringProperty.Name: "someOtherStringValue",
+		Convey("When handleAsyncOperationIfConfigured is called by a resource factory configured with read after create retries and the resource is not visible yet once the operation succeeded", func() {
+			r := r.withReadAfterCreateRetries(&specResourceOperation{readAfterCreateRetries: 1})
+			r.readAfterCreateRetryInterval = time.Millisecond
+			client := &clientOpenAPINotFoundStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					responsePayload:        map[string]interface{}{idProperty.Name: idProperty.Default, stringProperty.Name: "updated value"},
+					asyncOperationPayloads: []map[string]interface{}{{"status": "Succeeded"}},
 				},
+				notFoundResponses: 1,
 			}
-			resourceImporter := r.importer()
-			Convey("Then the resource importer returned should Not be nil", func() {
-				So(resourceImporter, ShouldNotBeNil)
+			responsePayload := map[string]interface{}{idProperty.Name: idProperty.Default}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), &responsePayload, res, resourceData, client, operation, schema.TimeoutCreate)
+			Convey("Then the resource should be read again until it is visible", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 2)
+				So(responsePayload[stringProperty.Name], ShouldEqual, "updated value")
 			})
-			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
-				_, err := resourceImporter.State(resourceData, client)
-				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "can not import a subresource without all the parent ids, expected 2 and got 1 parent IDs")
-				})
+		})
+		Convey("When handleAsyncOperationIfConfigured is called for a resource without id and the payload of the operation that succeeded does not contain the id", func() {
+			resourceData.SetId("")
+			client := &clientOpenAPIStub{
+				asyncOperationPayloads: []map[string]interface{}{{"status": "succeeded"}},
+			}
+			responsePayload := map[string]interface{}{}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), &responsePayload, res, resourceData, client, operation, schema.TimeoutCreate)
+			Convey("Then the err returned should be the expected one", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "the id of the resource could not be determined from the asynchronous operation '/v1/operations/1' payload: response object returned from the API is missing mandatory identifier property 'id'")
 			})
 		})
-	})
-
-	Convey("Given a resource factory configured with a sub-resource (and the already populated id property value contains the right IDs but the resource for some reason is not configured correctly and does not have configured the parent id property)", t, func() {
-		expectedParentPropertyName := "cdns_v1_id"
-		importedIDValue := "1234/5678"
-		importedIDProperty := newStringSchemaDefinitionProperty("id", "", true, true, false, false, false, true, false, false, importedIDValue)
-		r, resourceData := testCreateSubResourceFactory(t, "/v1/cdns/{id}/firewall", []string{"cdns_v1"}, []string{expectedParentPropertyName}, "cdns_v1", importedIDProperty, stringProperty)
-
-		Convey("When importer is called", func() {
+		Convey("When handleAsyncOperationIfConfigured is called and the API reports that the operation failed", func() {
 			client := &clientOpenAPIStub{
-				responsePayload: map[string]interface{}{
-					stringProperty.Name: "someOtherStringValue",
-				},
+				asyncOperationPayloads: []map[string]interface{}{{"status": "failed", "error": "quota exceeded"}},
 			}
-			resourceImporter := r.importer()
-			Convey("Then the resource importer returned should Not be nil", func() {
-				So(resourceImporter, ShouldNotBeNil)
+			responsePayload := map[string]interface{}{}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), &responsePayload, res, resourceData, client, operation, schema.TimeoutCreate)
+			Convey("Then the err returned should contain the operation error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "error waiting for the asynchronous operation '/v1/operations/1' to finish: asynchronous operation finished with status 'failed': quota exceeded")
 			})
-			Convey("And when the resourceImporter State method is invoked with data resource and the provider client", func() {
-				_, err := resourceImporter.State(resourceData, client)
-				Convey("Then the err returned should be the expected one", func() {
-					So(err.Error(), ShouldEqual, "could not find ID value in the state file for subresource parent property 'cdns_v1_id'")
-				})
+		})
+		Convey("When handleAsyncOperationIfConfigured is called with a nil response payload (meaning we are handling a DELETE operation)", func() {
+			client := &clientOpenAPIStub{
+				asyncOperationPayloads: []map[string]interface{}{{"status": "succeeded"}},
+				error:                  nil,
+			}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), nil, res, resourceData, client, operation, schema.TimeoutDelete)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the resource should not be read again", func() {
+				So(client.idReceived, ShouldBeEmpty)
+			})
+		})
+		Convey("When handleAsyncOperationIfConfigured is called with a response that does not contain the operation URL", func() {
+			client := &clientOpenAPIStub{}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), nil, &http.Response{StatusCode: http.StatusAccepted, Header: http.Header{}}, resourceData, client, operation, schema.TimeoutDelete)
+			Convey("Then the err returned should not be nil", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "response does not contain the asynchronous operation URL header 'Location'")
+			})
+		})
+		Convey("When handleAsyncOperationIfConfigured is called with a response status code that is not configured with an asynchronous operation", func() {
+			client := &clientOpenAPIStub{}
+			err := r.handleAsyncOperationIfConfigured(context.Background(), nil, &http.Response{StatusCode: http.StatusOK}, resourceData, client, operation, schema.TimeoutCreate)
+			Convey("Then the err returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the operation should not be polled", func() {
+				So(client.asyncOperationURLsReceived, ShouldBeEmpty)
 			})
 		})
 	})
@@ -807,7 +1694,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(&responsePayload, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			err := r.handlePollingIfConfigured(context.Background(), &responsePayload, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -835,7 +1722,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			err := r.handlePollingIfConfigured(context.Background(), nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -852,7 +1739,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 			operation := &specResourceOperation{
 				responses: map[int]*specResponse{},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			err := r.handlePollingIfConfigured(context.Background(), nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
 			Convey("Then the err  should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -870,7 +1757,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 					},
 				},
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
+			err := r.handlePollingIfConfigured(context.Background(), nil, resourceData, client, operation, responseStatusCode, schema.TimeoutCreate)
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
 			})
@@ -898,7 +1785,7 @@ func TestHandlePollingIfConfigured(t *testing.T) {
 				},
 				error: fmt.Errorf("some error"),
 			}
-			err := r.handlePollingIfConfigured(nil, resourceData, client, operation, expectedReturnCode, schema.TimeoutCreate)
+			err := r.handlePollingIfConfigured(context.Background(), nil, resourceData, client, operation, expectedReturnCode, schema.TimeoutCreate)
 			Convey("Then the error returned should be the expected one", func() {
 				So(err.Error(), ShouldEqual, "error waiting for resource to reach a completion status ([destroyed]) [valid pending statuses ([pending])]: error on retrieving resource 'resourceName' (id) when waiting: some error")
 			})
@@ -918,7 +1805,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					statusProperty.Name: statusProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -937,7 +1824,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 			client := &clientOpenAPIStub{
 				returnHTTPCode: http.StatusNotFound,
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
 			_, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should be nil", func() {
 				So(err, ShouldBeNil)
@@ -946,13 +1833,34 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 				So(newStatus, ShouldEqual, defaultDestroyStatus)
 			})
 		})
+		Convey("When resourceStateRefreshFunc is called by a resource factory configured with read after create retries and the resource is not visible yet", func() {
+			r := r.withReadAfterCreateRetries(&specResourceOperation{readAfterCreateRetries: 1})
+			r.readAfterCreateRetryInterval = time.Millisecond
+			client := &clientOpenAPINotFoundStub{
+				clientOpenAPIStub: clientOpenAPIStub{
+					responsePayload: map[string]interface{}{
+						idProperty.Name:     idProperty.Default,
+						stringProperty.Name: stringProperty.Default,
+						statusProperty.Name: statusProperty.Default,
+					},
+				},
+				notFoundResponses: 1,
+			}
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
+			_, newStatus, err := stateRefreshFunc()
+			Convey("Then the resource should be read again instead of being considered destroyed", func() {
+				So(err, ShouldBeNil)
+				So(client.getCalls, ShouldEqual, 2)
+				So(newStatus, ShouldEqual, statusProperty.Default)
+			})
+		})
 
 		Convey("When resourceStateRefreshFunc is called with an update resource data and an open api client that returns an error", func() {
 			expectedError := "some error"
 			client := &clientOpenAPIStub{
 				error: errors.New(expectedError),
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -978,7 +1886,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -1004,7 +1912,7 @@ func TestResourceStateRefreshFunc(t *testing.T) {
 					stringProperty.Name: stringProperty.Default,
 				},
 			}
-			stateRefreshFunc := r.resourceStateRefreshFunc(resourceData, client)
+			stateRefreshFunc := r.resourceStateRefreshFunc(context.Background(), resourceData, client)
 			remoteData, newStatus, err := stateRefreshFunc()
 			Convey("Then the err returned should not be nil", func() {
 				So(err, ShouldNotBeNil)
@@ -1644,6 +2552,82 @@ func TestCreatePayloadFromLocalStateData(t *testing.T) {
 				},
 			},
 		},
+		{
+			// - Representation of resourceData configuration containing a map of strings
+			// {
+			//	 metadata = {
+			//		key1 = "value1"
+			//		key2 = "value2"
+			//	 }
+			// }
+			name: "map properties should be included in the payload as json objects",
+			inputProps: []*specSchemaDefinitionProperty{
+				newMapSchemaDefinitionPropertyWithDefaults("metadata", "", false, false, map[string]interface{}{
+					"key1": "value1",
+					"key2": "value2",
+				}, typeString),
+			},
+			expectedPayload: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"key1": "value1",
+					"key2": "value2",
+				},
+			},
+		},
+		{
+			// - Representation of resourceData configuration containing an object with fixed properties and additionalProperties
+			// {
+			//	 object_property = {
+			//		protocol = "http"
+			//		extra_key = "extra_value"
+			//	 }
+			// }
+			name: "additional properties within objects that allow them should be included in the payload",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{
+					Name:                     "object_property",
+					Type:                     typeObject,
+					Required:                 true,
+					AdditionalPropertiesType: typeString,
+					Default: map[string]interface{}{
+						"protocol":  "http",
+						"extra_key": "extra_value",
+					},
+					SpecSchemaDefinition: &specSchemaDefinition{
+						Properties: specSchemaDefinitionProperties{
+							newStringSchemaDefinitionPropertyWithDefaults("protocol", "", true, false, "http"),
+						},
+					},
+				},
+			},
+			expectedPayload: map[string]interface{}{
+				"object_property": map[string]interface{}{
+					"protocol":  "http",
+					"extra_key": "extra_value",
+				},
+			},
+		},
+		{
+			name: "nullable properties explicitly set to the null sentinel should be included in the payload with null values",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "nullable_string_property", Type: typeString, Nullable: true, Default: nullValueSentinel},
+				&specSchemaDefinitionProperty{Name: "non_nullable_string_property", Type: typeString, Default: nullValueSentinel},
+			},
+			expectedPayload: map[string]interface{}{
+				"nullable_string_property":     nil,
+				"non_nullable_string_property": nullValueSentinel,
+			},
+		},
+		{
+			name: "nullable optional properties that are not set should not be part of the payload",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "nullable_string_property", Type: typeString, Nullable: true},
+				stringProperty,
+			},
+			expectedPayload: map[string]interface{}{
+				stringProperty.getTerraformCompliantPropertyName(): stringProperty.Default,
+			},
+		},
 		{
 			name: "properties with zero values should be included in the payload",
 			inputProps: []*specSchemaDefinitionProperty{
@@ -1659,6 +2643,80 @@ func TestCreatePayloadFromLocalStateData(t *testing.T) {
 				"slice_property":  []interface{}{interface{}(nil)},
 			},
 		},
+		{
+			name: "optional properties configured with omit when empty and empty values should not be part of the payload",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "string_property", Type: typeString, OmitWhenEmpty: true, Default: ""},
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, OmitWhenEmpty: true, Default: 0},
+				&specSchemaDefinitionProperty{Name: "number_property", Type: typeFloat, OmitWhenEmpty: true, Default: float64(0)},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, OmitWhenEmpty: true, Default: false},
+				stringProperty,
+			},
+			expectedPayload: map[string]interface{}{
+				stringProperty.getTerraformCompliantPropertyName(): stringProperty.Default,
+			},
+		},
+		{
+			name: "required properties configured with omit when empty should be part of the payload even when their values are empty",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, Required: true, OmitWhenEmpty: true, Default: 0},
+				&specSchemaDefinitionProperty{Name: "number_property", Type: typeFloat, Required: true, OmitWhenEmpty: true, Default: float64(0)},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, Required: true, OmitWhenEmpty: true, Default: false},
+			},
+			expectedPayload: map[string]interface{}{
+				"int_property":    0,
+				"number_property": float64(0),
+				"bool_property":   false,
+			},
+		},
+		{
+			name: "optional properties configured with omit when empty and non empty values should be part of the payload",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "string_property", Type: typeString, OmitWhenEmpty: true, Default: "someValue"},
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, OmitWhenEmpty: true, Default: 1},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, OmitWhenEmpty: true, Default: true},
+			},
+			expectedPayload: map[string]interface{}{
+				"string_property": "someValue",
+				"int_property":    1,
+				"bool_property":   true,
+			},
+		},
+		{
+			name: "nullable optional properties configured with omit when empty and explicitly set to the null sentinel should be part of the payload with null values",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "nullable_string_property", Type: typeString, Nullable: true, OmitWhenEmpty: true, Default: nullValueSentinel},
+			},
+			expectedPayload: map[string]interface{}{
+				"nullable_string_property": nil,
+			},
+		},
+		{
+			// - Representation of resourceData configuration containing an object
+			// {
+			//	 object_property = {
+			//		origin_port = 0
+			//		protocol = "http"
+			//	 }
+			// }
+			name: "properties within objects configured with omit when empty and empty values should not be part of the payload",
+			inputProps: []*specSchemaDefinitionProperty{
+				newObjectSchemaDefinitionPropertyWithDefaults("object_property", "", true, false, false, map[string]interface{}{
+					"origin_port": 0,
+					"protocol":    "http",
+				}, &specSchemaDefinition{
+					Properties: specSchemaDefinitionProperties{
+						&specSchemaDefinitionProperty{Name: "origin_port", Type: typeInt, OmitWhenEmpty: true, Default: 0},
+						newStringSchemaDefinitionPropertyWithDefaults("protocol", "", true, false, "http"),
+					},
+				}),
+			},
+			expectedPayload: map[string]interface{}{
+				"object_property": map[string]interface{}{
+					"protocol": "http",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1668,6 +2726,79 @@ func TestCreatePayloadFromLocalStateData(t *testing.T) {
 	}
 }
 
+func TestCreatePayloadFromLocalStateDataForceComputed(t *testing.T) {
+	Convey("Given a resource factory with a writable property marked with the x-terraform-force-computed extension that has a value in the state", t, func() {
+		propertySchema := spec.Schema{
+			SchemaProps:      spec.SchemaProps{Type: spec.StringOrArray{"string"}},
+			VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{extTfForceComputed: true}},
+		}
+		forceComputedProperty, err := (&SpecV2Resource{}).createSchemaDefinitionProperty("server_set_property", propertySchema, []string{})
+		So(err, ShouldBeNil)
+		forceComputedProperty.Default = "value set by the API"
+		r, resourceData := testCreateResourceFactory(t, forceComputedProperty, optionalProperty)
+		Convey("When createPayloadFromLocalStateData is called", func() {
+			payload := r.createPayloadFromLocalStateData(resourceData)
+			Convey("Then the payload should not contain the force computed property", func() {
+				So(payload, ShouldNotContainKey, "server_set_property")
+				So(payload, ShouldContainKey, optionalProperty.Name)
+			})
+		})
+	})
+}
+
+func TestCreatePayloadFromLocalStateDataOmitWhenEmptySerializedBody(t *testing.T) {
+	testCases := []struct {
+		name         string
+		inputProps   []*specSchemaDefinitionProperty
+		expectedBody string
+	}{
+		{
+			name: "optional properties with empty values are omitted from the body",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "string_property", Type: typeString, OmitWhenEmpty: true, Default: ""},
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, OmitWhenEmpty: true, Default: 0},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, OmitWhenEmpty: true, Default: false},
+			},
+			expectedBody: `{}`,
+		},
+		{
+			name: "required properties with empty values are always included in the body",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, Required: true, OmitWhenEmpty: true, Default: 0},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, Required: true, OmitWhenEmpty: true, Default: false},
+			},
+			expectedBody: `{"bool_property":false,"int_property":0}`,
+		},
+		{
+			name: "optional properties with empty values not configured with omit when empty are included in the body",
+			inputProps: []*specSchemaDefinitionProperty{
+				&specSchemaDefinitionProperty{Name: "int_property", Type: typeInt, OmitWhenEmpty: true, Default: 0},
+				&specSchemaDefinitionProperty{Name: "bool_property", Type: typeBool, Default: false},
+			},
+			expectedBody: `{"bool_property":false}`,
+		},
+	}
+	for _, tc := range testCases {
+		r, resourceData := testCreateResourceFactory(t, tc.inputProps...)
+		body, err := json.Marshal(r.createPayloadFromLocalStateData(resourceData))
+		assert.Nil(t, err, tc.name)
+		assert.Equal(t, tc.expectedBody, string(body), tc.name)
+	}
+}
+
+func TestCreatePayloadFromLocalStateDataRedactsSensitiveValuesInLogs(t *testing.T) {
+	passwordProperty := newStringSchemaDefinitionProperty("password", "", true, false, false, false, true, false, false, false, "someSecretValue")
+	r, resourceData := testCreateResourceFactory(t, passwordProperty, stringProperty)
+	logger := &loggerStub{}
+	r.logger = logger
+	payload := r.createPayloadFromLocalStateData(resourceData)
+	assert.Equal(t, "someSecretValue", payload["password"])
+	for _, m := range logger.messages {
+		assert.NotContains(t, m.msg, "someSecretValue")
+	}
+	assert.True(t, logger.containsMessage("DEBUG", fmt.Sprintf("[resource='%s'] property payload [propertyName: password; propertyValue: %s]", r.openAPIResource.getResourceName(), redactedValue)))
+}
+
 func TestGetPropertyPayload(t *testing.T) {
 	Convey("Given a resource factory"+
 		"When populatePayload is called with a nil property"+
@@ -1949,10 +3080,11 @@ func TestGetPropertyPayload(t *testing.T) {
 			So(err.Error(), ShouldEqual, "something is really wrong here...an object property with nested objects should have exactly one elem in the terraform state list")
 
 		})
-		Convey("When populatePayload is called a slice with <1 dataValue, it complains", func() {
-			err := r.populatePayload(map[string]interface{}{}, propertyWithNestedObject, []interface{}{})
-			So(err.Error(), ShouldEqual, "something is really wrong here...an object property with nested objects should have exactly one elem in the terraform state list")
-
+		Convey("When populatePayload is called a slice with <1 dataValue (block not configured), the property is not part of the payload", func() {
+			payload := map[string]interface{}{}
+			err := r.populatePayload(payload, propertyWithNestedObject, []interface{}{})
+			So(err, ShouldBeNil)
+			So(payload, ShouldBeEmpty)
 		})
 
 		Convey("When populatePayload is called with an empty map, the property with nested object in the resource schema and it's corresponding terraform resourceData state data value", func() {
@@ -2231,3 +3363,91 @@ func testCreateSubResourceFactory(t *testing.T, path string, parentResourceNames
 	specResource.fullParentResourceName = fullParentResourceName
 	return newResourceFactory(specResource), resourceData
 }
+
+func TestWithTelemetryMetrics(t *testing.T) {
+	Convey("Given a resource factory configured with a telemetry handler", t, func() {
+		stub := &telemetryProviderStub{}
+		r := resourceFactory{
+			openAPIResource: &specStubResource{name: "cdn_v1"},
+			telemetryHandler: telemetryHandlerTimeoutSupport{
+				providerName:       "providerName",
+				timeout:            1,
+				telemetryProviders: []TelemetryProvider{stub},
+			},
+		}
+		Convey("When withTelemetryMetrics is called and the wrapped function returned is invoked", func() {
+			expectedErr := errors.New("some error")
+			err := r.withTelemetryMetrics(TelemetryResourceOperationRead, func(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+				return expectedErr
+			})(context.Background(), nil, nil)
+			Convey("Then the error returned should be the one returned by the wrapped function", func() {
+				So(err, ShouldEqual, expectedErr)
+			})
+			Convey("And the timing metric should have been submitted for the resource and operation", func() {
+				So(stub.providerNameReceived, ShouldEqual, "providerName")
+				So(stub.resourceNameReceived, ShouldEqual, "cdn_v1")
+				So(stub.resourceOperationReceived, ShouldEqual, TelemetryResourceOperationRead)
+				So(stub.durationReceived, ShouldBeGreaterThan, 0)
+			})
+			Convey("And the resource operation total and errors counters should have been incremented", func() {
+				So(stub.resourceOperationTotalRuns, ShouldEqual, 1)
+				So(stub.resourceOperationErrors, ShouldEqual, 1)
+			})
+		})
+		Convey("When withTelemetryMetrics is called and the wrapped function returned succeeds", func() {
+			err := r.withTelemetryMetrics(TelemetryResourceOperationCreate, func(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+				return nil
+			})(context.Background(), nil, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And only the resource operation total counter should have been incremented", func() {
+				So(stub.resourceOperationTotalRuns, ShouldEqual, 1)
+				So(stub.resourceOperationErrors, ShouldEqual, 0)
+			})
+		})
+	})
+	Convey("Given a resource factory configured without a telemetry handler", t, func() {
+		r := resourceFactory{openAPIResource: &specStubResource{name: "cdn_v1"}}
+		Convey("When withTelemetryMetrics is called and the wrapped function returned is invoked", func() {
+			err := r.withTelemetryMetrics(TelemetryResourceOperationRead, func(ctx context.Context, data *schema.ResourceData, i interface{}) error {
+				return nil
+			})(context.Background(), nil, nil)
+			Convey("Then the error returned should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestPopulatePayloadDiscriminatedUnion(t *testing.T) {
+	Convey("Given a resource factory and a discriminated union property", t, func() {
+		r := resourceFactory{}
+		property := newObjectSchemaDefinitionPropertyWithDefaults("pet", "", false, false, false, nil, newUnionSchemaDefinitionStub())
+		Convey("When populatePayload is called with the state data value of one of the variants", func() {
+			payload := map[string]interface{}{}
+			dataValue := []interface{}{map[string]interface{}{
+				"cat": []interface{}{},
+				"dog": []interface{}{map[string]interface{}{"bark": true}},
+			}}
+			err := r.populatePayload(payload, property, dataValue)
+			Convey("Then the error should be nil", func() {
+				So(err, ShouldBeNil)
+			})
+			Convey("And the payload should contain the variant values along with the discriminator", func() {
+				So(payload, ShouldResemble, map[string]interface{}{"pet": map[string]interface{}{"kind": "dog", "bark": true}})
+			})
+		})
+		Convey("When populatePayload is called with the state data value of more than one variant", func() {
+			payload := map[string]interface{}{}
+			dataValue := []interface{}{map[string]interface{}{
+				"cat": []interface{}{map[string]interface{}{"lives": 7}},
+				"dog": []interface{}{map[string]interface{}{"bark": true}},
+			}}
+			err := r.populatePayload(payload, property, dataValue)
+			Convey("Then the error returned should state that only one variant can be configured", func() {
+				So(err.Error(), ShouldEqual, "property 'pet': only one of the cat, dog variants can be configured, found: cat, dog")
+			})
+		})
+	})
+}
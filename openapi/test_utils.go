@@ -2,7 +2,7 @@ package openapi
 
 import (
 	"encoding/json"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
@@ -98,6 +98,12 @@ func newListSchemaDefinitionProperty(name, preferredName string, required, readO
 	return schemaDefProperty
 }
 
+func newMapSchemaDefinitionPropertyWithDefaults(name, preferredName string, required, readOnly bool, defaultValue interface{}, valuesType schemaDefinitionPropertyType) *specSchemaDefinitionProperty {
+	schemaDefProperty := newSchemaDefinitionProperty(name, preferredName, typeMap, required, readOnly, false, false, false, false, false, false, defaultValue)
+	schemaDefProperty.AdditionalPropertiesType = valuesType
+	return schemaDefProperty
+}
+
 func newSchemaDefinitionProperty(name, preferredName string, propertyType schemaDefinitionPropertyType, required, readOnly, computed, forceNew, sensitive, immutable, isIdentifier, isStatusIdentifier bool, defaultValue interface{}) *specSchemaDefinitionProperty {
 	return &specSchemaDefinitionProperty{
 		Name:               name,
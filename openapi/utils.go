@@ -10,7 +10,7 @@ import (
 
 func prettyPrint(v interface{}) {
 	b, _ := json.MarshalIndent(v, "", "  ")
-	log.Printf(string(b))
+	log.Print(string(b))
 	log.Println()
 }
 
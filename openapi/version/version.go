@@ -18,3 +18,13 @@ var (
 func BuildUserAgent(runtime, arch string) string {
 	return fmt.Sprintf("OpenAPI Terraform Provider/%s-%s (%s/%s)", Version, Commit, runtime, arch)
 }
+
+// BuildUserAgentWithSuffix creates the same user agent string as BuildUserAgent appending the suffix provided at the
+// end (separated by a white space). If the suffix is empty the user agent returned is the same as BuildUserAgent
+func BuildUserAgentWithSuffix(runtime, arch, suffix string) string {
+	userAgent := BuildUserAgent(runtime, arch)
+	if suffix == "" {
+		return userAgent
+	}
+	return fmt.Sprintf("%s %s", userAgent, suffix)
+}
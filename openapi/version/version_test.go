@@ -19,3 +19,22 @@ func TestBuildUserAgent(t *testing.T) {
 		})
 	})
 }
+
+func TestBuildUserAgentWithSuffix(t *testing.T) {
+	Convey("Given a version and a commit hash", t, func() {
+		Version = "someVersion"
+		Commit = "someCommit"
+		Convey("When BuildUserAgentWithSuffix method is called with some runtime and a suffix", func() {
+			value := BuildUserAgentWithSuffix("linux", "amd64", "acme-cli/1.2")
+			Convey("Then the value of the header should contain the suffix at the end", func() {
+				So(value, ShouldEqual, "OpenAPI Terraform Provider/someVersion-someCommit (linux/amd64) acme-cli/1.2")
+			})
+		})
+		Convey("When BuildUserAgentWithSuffix method is called with some runtime and an empty suffix", func() {
+			value := BuildUserAgentWithSuffix("linux", "amd64", "")
+			Convey("Then the value of the header should be the default user agent", func() {
+				So(value, ShouldEqual, "OpenAPI Terraform Provider/someVersion-someCommit (linux/amd64)")
+			})
+		})
+	})
+}
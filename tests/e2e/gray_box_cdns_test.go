@@ -10,11 +10,10 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/require"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi"
 	"github.com/stretchr/testify/assert"
@@ -256,6 +255,15 @@ definitions:
             type: string
             readOnly: true`
 
+// testAccProviderFactories returns the provider factories used by the acceptance tests to serve the given provider
+func testAccProviderFactories(provider *schema.Provider) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		providerName: func() (*schema.Provider, error) {
+			return provider, nil
+		},
+	}
+}
+
 const expectedCDNID = "42"
 const expectedCDNFirewallID = "1337"
 
@@ -452,12 +460,12 @@ func TestAccCDN_Create_and_UpdateSubResource(t *testing.T) {
 		openAPIResourceNameCDN:         fmt.Sprintf("%s/v1/cdns", api.apiHost),
 	}
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest:   true,
-		Providers:    testAccProviders,
-		PreCheck:     func() { testAccPreCheck(t, api.swaggerURL) },
-		CheckDestroy: testAccCheckDestroy(resourceInstancesToCheck),
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, api.swaggerURL) },
+		CheckDestroy:      testAccCheckDestroy(resourceInstancesToCheck),
 		Steps: []resource.TestStep{
 			{
 				Config: tfFileContents,
@@ -536,7 +544,7 @@ func TestAcc_Create_MissingRequiredParentPropertyInTFConfigurationFile(t *testin
 	assert.NoError(t, err)
 	assertProviderSchema(t, provider)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	testCDNCreateMissingParentPropertyInFW := fmt.Sprintf(`
 		# URI /v1/cdns/
 		resource "%s" "%s" {
@@ -548,10 +556,10 @@ func TestAcc_Create_MissingRequiredParentPropertyInTFConfigurationFile(t *testin
            label = "%s"
         }`, openAPIResourceNameCDN, openAPIResourceInstanceNameCDN, expectedCDNLabel, openAPIResourceNameCDNFirewall, openAPIResourceInstanceNameCDNFirewall, openAPIResourceStateCDN, expectedCDNFirewallLabel)
 
-	expectedValidationError, _ := regexp.Compile(".*config is invalid: Missing required argument: The argument \"cdn_v1_id\" is required, but no definition was found.*")
+	expectedValidationError, _ := regexp.Compile(`(?s)Missing required argument.*The argument "cdn_v1_id" is required, but no definition was found`)
 	resource.Test(t, resource.TestCase{
 		IsUnitTest:                true,
-		Providers:                 testAccProviders,
+		ProviderFactories:         testAccProviders,
 		PreventPostDestroyRefresh: true,
 		Steps: []resource.TestStep{
 			{
@@ -584,12 +592,12 @@ func TestAccCDN_ImportSubResource(t *testing.T) {
 		openAPIResourceNameCDN:         fmt.Sprintf("%s/v1/cdns", api.apiHost),
 	}
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest:   true,
-		Providers:    testAccProviders,
-		PreCheck:     func() { testAccPreCheck(t, api.swaggerURL) },
-		CheckDestroy: testAccCheckDestroy(resourceInstancesToCheck),
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, api.swaggerURL) },
+		CheckDestroy:      testAccCheckDestroy(resourceInstancesToCheck),
 		Steps: []resource.TestStep{
 			{
 				Config:        tfFileContents,
@@ -625,11 +633,11 @@ func TestAccCDN_DataSource(t *testing.T) {
 	assert.NoError(t, err)
 	assertProviderSchema(t, provider)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, api.swaggerURL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, api.swaggerURL) },
 		Steps: []resource.TestStep{
 			{
 				Config: tfFileContents,
@@ -670,11 +678,11 @@ func TestAccCDN_DataSourceInstance(t *testing.T) {
 	assert.NoError(t, err)
 	assertProviderSchema(t, provider)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, api.swaggerURL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, api.swaggerURL) },
 		Steps: []resource.TestStep{
 			{
 				Config: tfFileContents,
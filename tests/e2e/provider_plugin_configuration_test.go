@@ -3,8 +3,8 @@ package e2e
 import (
 	"fmt"
 	"github.com/dikhan/terraform-provider-openapi/openapi"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"io/ioutil"
 	"log"
@@ -125,11 +125,11 @@ services:
 	provider, err := p.CreateSchemaProvider()
 	assert.NoError(t, err)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`resource "openapi_cdns_v1" "my_cdn" { label = "some_label"}`),
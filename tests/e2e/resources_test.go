@@ -1,14 +1,17 @@
 package e2e
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 
 	"github.com/dikhan/terraform-provider-openapi/openapi"
 	"github.com/stretchr/testify/assert"
@@ -88,11 +91,11 @@ resource "openapi_cdns" "my_cdn" {
 }`)
 
 	expectedValidationError, _ := regexp.Compile(".*failed to configure the API request for POST http://127.0.0.1:[\\d]+/cdns: required header 'required_header_example' is missing the value. Please make sure the property 'required_header_example' is configured with a value in the provider's terraform configuration.*")
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config:      tfFileContents,
@@ -177,11 +180,11 @@ resource "openapi_cdns" "my_cdn" {
 }`)
 
 	expectedValidationError, _ := regexp.Compile(".*failed to configure the API request for POST http://127.0.0.1:[\\d]+/cdns: required security definition 'some_not_global_sec_def' is missing the value. Please make sure the property 'some_not_global_sec_def' is configured with a value in the provider's terraform configuration.*")
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config:      tfFileContents,
@@ -266,11 +269,11 @@ resource "openapi_cdns" "my_cdn" {
 }`)
 
 	expectedValidationError, _ := regexp.Compile(".*failed to configure the API request for POST http://127.0.0.1:[\\d]+/cdns: required security definition 'some_not_global_sec_def' is missing the value. Please make sure the property 'some_not_global_sec_def' is configured with a value in the provider's terraform configuration.*")
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config:      tfFileContents,
@@ -356,11 +359,11 @@ resource "openapi_cdns" "my_cdn" {
 }`)
 
 	expectedValidationError, _ := regexp.Compile(".*failed to configure the API request for POST http://127.0.0.1:[\\d]+/cdns: required security definition 'some_not_global_sec_def' is missing the value. Please make sure the property 'some_not_global_sec_def' is configured with a value in the provider's terraform configuration.*")
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config:      tfFileContents,
@@ -439,11 +442,11 @@ definitions:
 
 	tfFileContents := fmt.Sprintf(`resource "openapi_deploykey_v1" "my_deploykeyv1" {}`)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config: tfFileContents,
@@ -528,11 +531,11 @@ definitions:
 
 	tfFileContents := fmt.Sprintf(`resource "openapi_deploykey_v1" "my_deploykeyv1" {}`)
 
-	var testAccProviders = map[string]terraform.ResourceProvider{providerName: provider}
+	var testAccProviders = testAccProviderFactories(provider)
 	resource.Test(t, resource.TestCase{
-		IsUnitTest: true,
-		Providers:  testAccProviders,
-		PreCheck:   func() { testAccPreCheck(t, swaggerServer.URL) },
+		IsUnitTest:        true,
+		ProviderFactories: testAccProviders,
+		PreCheck:          func() { testAccPreCheck(t, swaggerServer.URL) },
 		Steps: []resource.TestStep{
 			{
 				Config: tfFileContents,
@@ -548,3 +551,235 @@ definitions:
 		},
 	})
 }
+
+func TestAcc_ResourceProtocolV6NestedAttributes(t *testing.T) {
+	var mutex sync.Mutex
+	resources := map[string][]byte{}
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		switch {
+		case r.Method == http.MethodPost:
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			body["id"] = r.URL.Path[len("/v1/"):]
+			payload, _ := json.Marshal(body)
+			resources[r.URL.Path+"/"+body["id"].(string)] = payload
+			w.WriteHeader(http.StatusCreated)
+			w.Write(payload)
+		case r.Method == http.MethodPut:
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			body["id"] = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			payload, _ := json.Marshal(body)
+			resources[r.URL.Path] = payload
+			w.Write(payload)
+		case r.Method == http.MethodDelete:
+			delete(resources, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			payload, exists := resources[r.URL.Path]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(payload)
+		}
+	}))
+	apiHost := apiServer.URL[7:]
+
+	swaggerServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		swaggerYAMLTemplate := fmt.Sprintf(`swagger: "2.0"
+host: "%s"
+
+schemes:
+- "http"
+
+paths:
+  /v1/firewalls:
+    x-terraform-resource-protocol-v6: true
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/Firewall"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Firewall"
+  /v1/firewalls/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Firewall"
+    put:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/Firewall"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Firewall"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+  /v1/labels:
+    post:
+      parameters:
+      - in: "body"
+        name: "body"
+        required: true
+        schema:
+          $ref: "#/definitions/Label"
+      responses:
+        201:
+          schema:
+            $ref: "#/definitions/Label"
+  /v1/labels/{id}:
+    get:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        200:
+          schema:
+            $ref: "#/definitions/Label"
+    delete:
+      parameters:
+      - name: "id"
+        in: "path"
+        required: true
+        type: "string"
+      responses:
+        204:
+          description: "successful operation, no content is returned"
+definitions:
+  Firewall:
+    type: "object"
+    required:
+    - name
+    properties:
+      id:
+        readOnly: true
+        type: string
+      name:
+        type: string
+      settings:
+        type: object
+        properties:
+          enabled:
+            type: boolean
+          priority:
+            type: integer
+      rules:
+        type: array
+        items:
+          type: object
+          properties:
+            port:
+              type: integer
+            protocol:
+              type: string
+  Label:
+    type: "object"
+    required:
+    - value
+    properties:
+      id:
+        readOnly: true
+        type: string
+      value:
+        type: string`, apiHost)
+		w.Write([]byte(swaggerYAMLTemplate))
+	}))
+
+	p := openapi.ProviderOpenAPI{ProviderName: providerName}
+	providerServer, err := p.CreateProviderServerFromServiceConfiguration(&openapi.ServiceConfigStub{
+		SwaggerURL: swaggerServer.URL,
+		ProtocolV6: true,
+	})
+	assert.NoError(t, err)
+
+	tfFileContentsTemplate := `resource "openapi_firewalls_v1" "my_firewall" {
+  name = "my_firewall"
+  settings = {
+    enabled  = true
+    priority = %d
+  }
+  rules = [
+    {
+      port     = 443
+      protocol = "tcp"
+    },
+  ]
+}
+
+resource "openapi_labels_v1" "my_label" {
+  value = "my_label"
+}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			providerName: func() (tfprotov6.ProviderServer, error) {
+				return providerServer(), nil
+			},
+		},
+		PreCheck: func() { testAccPreCheck(t, swaggerServer.URL) },
+		CheckDestroy: testAccCheckDestroy(map[string]string{
+			"openapi_firewalls_v1": fmt.Sprintf("%s/v1/firewalls", apiHost),
+			"openapi_labels_v1":    fmt.Sprintf("%s/v1/labels", apiHost),
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(tfFileContentsTemplate, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "id", "firewalls"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "settings.enabled", "true"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "settings.priority", "1"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "rules.#", "1"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "rules.0.port", "443"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "rules.0.protocol", "tcp"),
+					resource.TestCheckResourceAttr("openapi_labels_v1.my_label", "id", "labels"),
+					resource.TestCheckResourceAttr("openapi_labels_v1.my_label", "value", "my_label"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(tfFileContentsTemplate, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "id", "firewalls"),
+					resource.TestCheckResourceAttr("openapi_firewalls_v1.my_firewall", "settings.priority", "2"),
+				),
+			},
+			{
+				ResourceName:      "openapi_firewalls_v1.my_firewall",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}